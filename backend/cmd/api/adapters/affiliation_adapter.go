@@ -0,0 +1,94 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	appAffiliation "github.com/kuro48/idol-api/internal/application/affiliation"
+	appGroup "github.com/kuro48/idol-api/internal/application/group"
+	appIdol "github.com/kuro48/idol-api/internal/application/idol"
+	domainAffiliation "github.com/kuro48/idol-api/internal/domain/affiliation"
+	ucAffiliation "github.com/kuro48/idol-api/internal/usecase/affiliation"
+)
+
+// AffiliationAppAdapter は appAffiliation.ApplicationService を ucAffiliation.AffiliationAppPort に適合させる
+type AffiliationAppAdapter struct {
+	svc *appAffiliation.ApplicationService
+}
+
+func NewAffiliationAppAdapter(svc *appAffiliation.ApplicationService) ucAffiliation.AffiliationAppPort {
+	return &AffiliationAppAdapter{svc: svc}
+}
+
+func (a *AffiliationAppAdapter) CreateAffiliation(ctx context.Context, input ucAffiliation.AffiliationCreateInput) (*domainAffiliation.Affiliation, error) {
+	return a.svc.CreateAffiliation(ctx, appAffiliation.CreateInput{
+		TalentType:   input.TalentType,
+		TalentID:     input.TalentID,
+		AgencyID:     input.AgencyID,
+		ContractType: input.ContractType,
+		JoinedAt:     input.JoinedAt,
+		LeftAt:       input.LeftAt,
+	})
+}
+
+func (a *AffiliationAppAdapter) GetAffiliation(ctx context.Context, id string) (*domainAffiliation.Affiliation, error) {
+	return a.svc.GetAffiliation(ctx, id)
+}
+
+func (a *AffiliationAppAdapter) ListByTalent(ctx context.Context, talentType, talentID string) ([]*domainAffiliation.Affiliation, error) {
+	return a.svc.ListByTalent(ctx, talentType, talentID)
+}
+
+func (a *AffiliationAppAdapter) ListByAgency(ctx context.Context, agencyID string, at *time.Time) ([]*domainAffiliation.Affiliation, error) {
+	return a.svc.ListByAgency(ctx, agencyID, at)
+}
+
+func (a *AffiliationAppAdapter) UpdateAffiliation(ctx context.Context, input ucAffiliation.AffiliationUpdateInput) error {
+	return a.svc.UpdateAffiliation(ctx, appAffiliation.UpdateInput{
+		ID:           input.ID,
+		ContractType: input.ContractType,
+		JoinedAt:     input.JoinedAt,
+		LeftAt:       input.LeftAt,
+	})
+}
+
+func (a *AffiliationAppAdapter) DeleteAffiliation(ctx context.Context, id string) error {
+	return a.svc.DeleteAffiliation(ctx, id)
+}
+
+// IdolAffiliationRecorderAdapter は appAffiliation.ApplicationService を appIdol.AffiliationRecorder に適合させる
+type IdolAffiliationRecorderAdapter struct {
+	svc *appAffiliation.ApplicationService
+}
+
+// NewIdolAffiliationRecorderAdapter は IdolAffiliationRecorderAdapter を生成する
+func NewIdolAffiliationRecorderAdapter(svc *appAffiliation.ApplicationService) appIdol.AffiliationRecorder {
+	return &IdolAffiliationRecorderAdapter{svc: svc}
+}
+
+func (a *IdolAffiliationRecorderAdapter) RecordIdolAffiliation(ctx context.Context, idolID, agencyID string) error {
+	_, err := a.svc.CreateAffiliation(ctx, appAffiliation.CreateInput{
+		TalentType: string(domainAffiliation.TalentIdol),
+		TalentID:   idolID,
+		AgencyID:   agencyID,
+	})
+	return err
+}
+
+// TalentAgencySyncAdapter は appIdol / appGroup の ApplicationService を appAffiliation.TalentAgencySyncer に適合させる
+type TalentAgencySyncAdapter struct {
+	idols  *appIdol.ApplicationService
+	groups *appGroup.ApplicationService
+}
+
+// NewTalentAgencySyncAdapter は TalentAgencySyncAdapter を生成する
+func NewTalentAgencySyncAdapter(idols *appIdol.ApplicationService, groups *appGroup.ApplicationService) appAffiliation.TalentAgencySyncer {
+	return &TalentAgencySyncAdapter{idols: idols, groups: groups}
+}
+
+func (a *TalentAgencySyncAdapter) SyncAgency(ctx context.Context, talentType domainAffiliation.TalentType, talentID string, agencyID *string) error {
+	if talentType == domainAffiliation.TalentGroup {
+		return a.groups.SyncAgency(ctx, talentID, agencyID)
+	}
+	return a.idols.SyncAgency(ctx, talentID, agencyID)
+}
@@ -23,6 +23,7 @@ func (a *AgencyAppAdapterForUsecase) CreateAgency(ctx context.Context, input ucA
 	return a.svc.CreateAgency(ctx, appAgency.CreateInput{
 		Name:            input.Name,
 		NameEn:          input.NameEn,
+		NameKana:        input.NameKana,
 		FoundedDate:     input.FoundedDate,
 		Country:         input.Country,
 		OfficialWebsite: input.OfficialWebsite,
@@ -48,6 +49,7 @@ func (a *AgencyAppAdapterForUsecase) UpdateAgency(ctx context.Context, input ucA
 		ID:              input.ID,
 		Name:            input.Name,
 		NameEn:          input.NameEn,
+		NameKana:        input.NameKana,
 		FoundedDate:     input.FoundedDate,
 		OfficialWebsite: input.OfficialWebsite,
 		Description:     input.Description,
@@ -0,0 +1,45 @@
+package adapters
+
+import (
+	"context"
+
+	appGroup "github.com/kuro48/idol-api/internal/application/group"
+	appIdol "github.com/kuro48/idol-api/internal/application/idol"
+	groupDomain "github.com/kuro48/idol-api/internal/domain/group"
+	idolDomain "github.com/kuro48/idol-api/internal/domain/idol"
+	ucAnniversary "github.com/kuro48/idol-api/internal/usecase/anniversary"
+)
+
+// AnniversaryIdolAdapter は appIdol.ApplicationService を ucAnniversary.IdolAppPort に適合させる
+type AnniversaryIdolAdapter struct {
+	svc *appIdol.ApplicationService
+}
+
+// NewAnniversaryIdolAdapter は AnniversaryIdolAdapter を生成する
+func NewAnniversaryIdolAdapter(svc *appIdol.ApplicationService) ucAnniversary.IdolAppPort {
+	return &AnniversaryIdolAdapter{svc: svc}
+}
+
+func (a *AnniversaryIdolAdapter) Birthdays(ctx context.Context, input ucAnniversary.PeriodInput) ([]idolDomain.Birthday, int, error) {
+	return a.svc.Birthdays(ctx, appIdol.BirthdaysInput{From: input.From, To: input.To, Offset: input.Offset, Limit: input.Limit})
+}
+
+// AnniversaryGroupAdapter は appGroup.ApplicationService を ucAnniversary.GroupAppPort に適合させる
+type AnniversaryGroupAdapter struct {
+	svc *appGroup.ApplicationService
+}
+
+// NewAnniversaryGroupAdapter は AnniversaryGroupAdapter を生成する
+func NewAnniversaryGroupAdapter(svc *appGroup.ApplicationService) ucAnniversary.GroupAppPort {
+	return &AnniversaryGroupAdapter{svc: svc}
+}
+
+func (a *AnniversaryGroupAdapter) Anniversaries(ctx context.Context, input ucAnniversary.PeriodInput) ([]groupDomain.Anniversary, int, error) {
+	return a.svc.Anniversaries(ctx, appGroup.AnniversariesInput{
+		From:   input.From,
+		To:     input.To,
+		Years:  input.Years,
+		Offset: input.Offset,
+		Limit:  input.Limit,
+	})
+}
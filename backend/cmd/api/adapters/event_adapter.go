@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"time"
 
 	appEvent "github.com/kuro48/idol-api/internal/application/event"
 	eventDomain "github.com/kuro48/idol-api/internal/domain/event"
@@ -27,16 +28,20 @@ func (a *EventAppAdapter) CreateEvent(ctx context.Context, input ucEvent.EventCr
 		})
 	}
 	return a.svc.CreateEvent(ctx, appEvent.CreateInput{
-		Title:         input.Title,
-		EventType:     input.EventType,
-		StartDateTime: input.StartDateTime,
-		EndDateTime:   input.EndDateTime,
-		VenueID:       input.VenueID,
-		Performers:    performers,
-		TicketURL:     input.TicketURL,
-		OfficialURL:   input.OfficialURL,
-		Description:   input.Description,
-		Tags:          input.Tags,
+		Streaming:               toAppStreamingInput(input.Streaming),
+		Title:                   input.Title,
+		EventType:               input.EventType,
+		StartDateTime:           input.StartDateTime,
+		EndDateTime:             input.EndDateTime,
+		VenueID:                 input.VenueID,
+		Performers:              performers,
+		TicketURL:               input.TicketURL,
+		OfficialURL:             input.OfficialURL,
+		Description:             input.Description,
+		Tags:                    input.Tags,
+		SeriesID:                input.SeriesID,
+		Strict:                  input.Strict,
+		CapacityConfigurationID: input.CapacityConfigurationID,
 	})
 }
 
@@ -50,14 +55,17 @@ func (a *EventAppAdapter) SearchEvents(ctx context.Context, criteria eventDomain
 
 func (a *EventAppAdapter) UpdateEvent(ctx context.Context, input ucEvent.EventUpdateInput) error {
 	return a.svc.UpdateEvent(ctx, appEvent.UpdateInput{
-		ID:            input.ID,
-		Title:         input.Title,
-		StartDateTime: input.StartDateTime,
-		EndDateTime:   input.EndDateTime,
-		VenueID:       input.VenueID,
-		TicketURL:     input.TicketURL,
-		OfficialURL:   input.OfficialURL,
-		Description:   input.Description,
+		ID:                      input.ID,
+		Title:                   input.Title,
+		StartDateTime:           input.StartDateTime,
+		EndDateTime:             input.EndDateTime,
+		VenueID:                 input.VenueID,
+		TicketURL:               input.TicketURL,
+		OfficialURL:             input.OfficialURL,
+		Description:             input.Description,
+		SeriesID:                input.SeriesID,
+		Strict:                  input.Strict,
+		CapacityConfigurationID: input.CapacityConfigurationID,
 	})
 }
 
@@ -80,6 +88,73 @@ func (a *EventAppAdapter) RemovePerformer(ctx context.Context, input ucEvent.Eve
 	})
 }
 
+func (a *EventAppAdapter) UpdateSetlist(ctx context.Context, input ucEvent.EventUpdateSetlistInput) error {
+	entries := make([]appEvent.SetlistEntryInput, 0, len(input.Entries))
+	for _, e := range input.Entries {
+		entries = append(entries, appEvent.SetlistEntryInput{
+			ReleaseID:    e.ReleaseID,
+			TrackNumber:  e.TrackNumber,
+			Title:        e.Title,
+			IsEncore:     e.IsEncore,
+			PerformerIDs: e.PerformerIDs,
+			Note:         e.Note,
+		})
+	}
+	return a.svc.UpdateSetlist(ctx, appEvent.UpdateSetlistInput{
+		EventID: input.EventID,
+		Entries: entries,
+	})
+}
+
 func (a *EventAppAdapter) FindUpcoming(ctx context.Context, limit int) ([]*eventDomain.Event, error) {
 	return a.svc.FindUpcoming(ctx, limit)
 }
+
+func (a *EventAppAdapter) FindConflicts(ctx context.Context, id string) ([]eventDomain.Conflict, error) {
+	return a.svc.FindConflicts(ctx, id)
+}
+
+func (a *EventAppAdapter) ConflictReport(ctx context.Context, from, to time.Time) ([]eventDomain.Conflict, error) {
+	return a.svc.ConflictReport(ctx, from, to)
+}
+
+func (a *EventAppAdapter) RecordAttendance(ctx context.Context, input ucEvent.EventRecordAttendanceInput) error {
+	return a.svc.RecordAttendance(ctx, appEvent.RecordAttendanceInput{
+		EventID:    input.EventID,
+		SoldOut:    input.SoldOut,
+		Attendance: input.Attendance,
+	})
+}
+
+func (a *EventAppAdapter) CapacityReport(ctx context.Context, input ucEvent.EventCapacityReportInput) ([]eventDomain.CapacityStats, error) {
+	return a.svc.CapacityReport(ctx, appEvent.CapacityReportInput{
+		SeriesID:    input.SeriesID,
+		PerformerID: input.PerformerID,
+		From:        input.From,
+		To:          input.To,
+	})
+}
+
+func (a *EventAppAdapter) UpdateStreaming(ctx context.Context, input ucEvent.EventUpdateStreamingInput) error {
+	return a.svc.UpdateStreaming(ctx, appEvent.UpdateStreamingInput{
+		EventID:   input.EventID,
+		Streaming: toAppStreamingInput(input.Streaming),
+	})
+}
+
+func toAppStreamingInput(input *ucEvent.EventStreamingInput) *appEvent.StreamingInput {
+	if input == nil {
+		return nil
+	}
+	tiers := make([]appEvent.PriceTierInput, 0, len(input.PriceTiers))
+	for _, t := range input.PriceTiers {
+		tiers = append(tiers, appEvent.PriceTierInput{Name: t.Name, Price: t.Price})
+	}
+	return &appEvent.StreamingInput{
+		Platform:     input.Platform,
+		URL:          input.URL,
+		TicketURL:    input.TicketURL,
+		ArchiveUntil: input.ArchiveUntil,
+		PriceTiers:   tiers,
+	}
+}
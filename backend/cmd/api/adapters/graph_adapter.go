@@ -0,0 +1,39 @@
+package adapters
+
+import (
+	"context"
+
+	appGraph "github.com/kuro48/idol-api/internal/application/graph"
+	domainGraph "github.com/kuro48/idol-api/internal/domain/graph"
+	ucGraph "github.com/kuro48/idol-api/internal/usecase/graph"
+)
+
+// GraphAppAdapter は appGraph.ApplicationService を ucGraph.GraphAppPort に適合させる
+type GraphAppAdapter struct {
+	svc *appGraph.ApplicationService
+}
+
+// NewGraphAppAdapter は GraphAppAdapter を生成する
+func NewGraphAppAdapter(svc *appGraph.ApplicationService) ucGraph.GraphAppPort {
+	return &GraphAppAdapter{svc: svc}
+}
+
+func (a *GraphAppAdapter) IdolGraph(ctx context.Context, input ucGraph.IdolGraphInput) (*domainGraph.Graph, error) {
+	return a.svc.IdolGraph(ctx, appGraph.IdolGraphInput{IdolID: input.IdolID, Depth: input.Depth})
+}
+
+func (a *GraphAppAdapter) CoMembers(ctx context.Context, idolID string) ([]domainGraph.CoMember, error) {
+	return a.svc.CoMembers(ctx, idolID)
+}
+
+func (a *GraphAppAdapter) CoStars(ctx context.Context, input ucGraph.CoStarsInput) ([]domainGraph.CoStar, error) {
+	return a.svc.CoStars(ctx, appGraph.CoStarsInput{IdolID: input.IdolID, Limit: input.Limit})
+}
+
+func (a *GraphAppAdapter) ShortestPath(ctx context.Context, input ucGraph.PathInput) (*domainGraph.Path, error) {
+	return a.svc.ShortestPath(ctx, appGraph.PathInput{
+		FromIdolID: input.FromIdolID,
+		ToIdolID:   input.ToIdolID,
+		MaxDepth:   input.MaxDepth,
+	})
+}
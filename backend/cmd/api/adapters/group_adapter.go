@@ -21,8 +21,11 @@ func NewGroupAppAdapter(svc *appGroup.ApplicationService) ucGroup.GroupAppPort {
 func (a *GroupAppAdapter) CreateGroup(ctx context.Context, input ucGroup.GroupCreateInput) (*groupDomain.Group, error) {
 	return a.svc.CreateGroup(ctx, appGroup.CreateInput{
 		Name:          input.Name,
+		NameKana:      input.NameKana,
 		FormationDate: input.FormationDate,
 		DisbandDate:   input.DisbandDate,
+		ParentGroupID: input.ParentGroupID,
+		RelationType:  input.RelationType,
 	})
 }
 
@@ -38,15 +41,39 @@ func (a *GroupAppAdapter) ListGroupWithPagination(ctx context.Context, opts grou
 	return a.svc.ListGroupWithPagination(ctx, opts)
 }
 
+func (a *GroupAppAdapter) ListUnits(ctx context.Context, id string, relationType *string) ([]*groupDomain.Group, error) {
+	return a.svc.ListUnits(ctx, id, relationType)
+}
+
+func (a *GroupAppAdapter) GetParent(ctx context.Context, id string) (*groupDomain.Group, groupDomain.RelationType, error) {
+	return a.svc.GetParent(ctx, id)
+}
+
 func (a *GroupAppAdapter) UpdateGroup(ctx context.Context, input ucGroup.GroupUpdateInput) error {
 	return a.svc.UpdateGroup(ctx, appGroup.UpdateInput{
 		ID:            input.ID,
 		Name:          input.Name,
+		NameKana:      input.NameKana,
 		FormationDate: input.FormationDate,
 		DisbandDate:   input.DisbandDate,
+		ParentGroupID: input.ParentGroupID,
+		RelationType:  input.RelationType,
+		RenamedAt:     input.RenamedAt,
+		NameHistory:   toGroupNameHistoryInputs(input.NameHistory),
 	})
 }
 
+func toGroupNameHistoryInputs(entries []ucGroup.NameHistoryEntry) []appGroup.NameHistoryInput {
+	if entries == nil {
+		return nil
+	}
+	inputs := make([]appGroup.NameHistoryInput, 0, len(entries))
+	for _, e := range entries {
+		inputs = append(inputs, appGroup.NameHistoryInput{Name: e.Name, From: e.From, Until: e.Until})
+	}
+	return inputs
+}
+
 func (a *GroupAppAdapter) DeleteGroup(ctx context.Context, id string) error {
 	return a.svc.DeleteGroup(ctx, id)
 }
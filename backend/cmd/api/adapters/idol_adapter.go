@@ -26,6 +26,7 @@ func NewIdolAppAdapter(svc *appIdol.ApplicationService) ucIdol.IdolAppPort {
 func (a *IdolAppAdapter) CreateIdol(ctx context.Context, input ucIdol.IdolCreateInput) (*idolDomain.Idol, error) {
 	return a.svc.CreateIdol(ctx, appIdol.CreateInput{
 		Name:      input.Name,
+		NameKana:  input.NameKana,
 		Birthdate: input.Birthdate,
 		AgencyID:  input.AgencyID,
 		Aliases:   input.Aliases,
@@ -42,14 +43,28 @@ func (a *IdolAppAdapter) ListIdols(ctx context.Context) ([]*idolDomain.Idol, err
 
 func (a *IdolAppAdapter) UpdateIdol(ctx context.Context, input ucIdol.IdolUpdateInput) error {
 	return a.svc.UpdateIdol(ctx, appIdol.UpdateInput{
-		ID:        input.ID,
-		Name:      input.Name,
-		Birthdate: input.Birthdate,
-		AgencyID:  input.AgencyID,
-		Aliases:   input.Aliases,
+		ID:          input.ID,
+		Name:        input.Name,
+		NameKana:    input.NameKana,
+		Birthdate:   input.Birthdate,
+		AgencyID:    input.AgencyID,
+		Aliases:     input.Aliases,
+		RenamedAt:   input.RenamedAt,
+		NameHistory: toIdolNameHistoryInputs(input.NameHistory),
 	})
 }
 
+func toIdolNameHistoryInputs(entries []ucIdol.NameHistoryEntry) []appIdol.NameHistoryInput {
+	if entries == nil {
+		return nil
+	}
+	inputs := make([]appIdol.NameHistoryInput, 0, len(entries))
+	for _, e := range entries {
+		inputs = append(inputs, appIdol.NameHistoryInput{Name: e.Name, From: e.From, Until: e.Until})
+	}
+	return inputs
+}
+
 func (a *IdolAppAdapter) DeleteIdol(ctx context.Context, id string) error {
 	return a.svc.DeleteIdol(ctx, id)
 }
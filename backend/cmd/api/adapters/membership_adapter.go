@@ -3,6 +3,7 @@ package adapters
 import (
 	"context"
 
+	appGroup "github.com/kuro48/idol-api/internal/application/group"
 	appMembership "github.com/kuro48/idol-api/internal/application/membership"
 	domainMembership "github.com/kuro48/idol-api/internal/domain/membership"
 	ucMembership "github.com/kuro48/idol-api/internal/usecase/membership"
@@ -19,10 +20,11 @@ func NewMembershipAppAdapter(svc *appMembership.ApplicationService) ucMembership
 
 func (a *MembershipAppAdapter) CreateMembership(ctx context.Context, input ucMembership.MembershipCreateInput) (*domainMembership.Membership, error) {
 	return a.svc.CreateMembership(ctx, appMembership.CreateInput{
-		IdolID:   input.IdolID,
-		GroupID:  input.GroupID,
-		Role:     input.Role,
-		JoinedAt: input.JoinedAt,
+		IdolID:     input.IdolID,
+		GroupID:    input.GroupID,
+		Role:       input.Role,
+		Generation: input.Generation,
+		JoinedAt:   input.JoinedAt,
 	})
 }
 
@@ -34,8 +36,8 @@ func (a *MembershipAppAdapter) ListByIdolID(ctx context.Context, idolID string)
 	return a.svc.ListByIdolID(ctx, idolID)
 }
 
-func (a *MembershipAppAdapter) ListByGroupID(ctx context.Context, groupID string) ([]*domainMembership.Membership, error) {
-	return a.svc.ListByGroupID(ctx, groupID)
+func (a *MembershipAppAdapter) ListByGroupIDs(ctx context.Context, groupIDs []string) ([]*domainMembership.Membership, error) {
+	return a.svc.ListByGroupIDs(ctx, groupIDs)
 }
 
 func (a *MembershipAppAdapter) SearchMemberships(ctx context.Context, criteria domainMembership.SearchCriteria) ([]*domainMembership.Membership, error) {
@@ -48,13 +50,28 @@ func (a *MembershipAppAdapter) CountMemberships(ctx context.Context, criteria do
 
 func (a *MembershipAppAdapter) UpdateMembership(ctx context.Context, input ucMembership.MembershipUpdateInput) error {
 	return a.svc.UpdateMembership(ctx, appMembership.UpdateInput{
-		ID:       input.ID,
-		Role:     input.Role,
-		JoinedAt: input.JoinedAt,
-		LeftAt:   input.LeftAt,
+		ID:         input.ID,
+		Role:       input.Role,
+		Generation: input.Generation,
+		JoinedAt:   input.JoinedAt,
+		LeftAt:     input.LeftAt,
 	})
 }
 
 func (a *MembershipAppAdapter) DeleteMembership(ctx context.Context, id string) error {
 	return a.svc.DeleteMembership(ctx, id)
 }
+
+// GroupUnitAdapter は appGroup.ApplicationService を ucMembership.GroupUnitPort に適合させる
+type GroupUnitAdapter struct {
+	svc *appGroup.ApplicationService
+}
+
+// NewGroupUnitAdapter は GroupUnitAdapter を生成する
+func NewGroupUnitAdapter(svc *appGroup.ApplicationService) ucMembership.GroupUnitPort {
+	return &GroupUnitAdapter{svc: svc}
+}
+
+func (a *GroupUnitAdapter) ListSubUnitIDs(ctx context.Context, groupID string) ([]string, error) {
+	return a.svc.ListSubUnitIDs(ctx, groupID)
+}
@@ -0,0 +1,88 @@
+package adapters
+
+import (
+	"context"
+
+	appRelated "github.com/kuro48/idol-api/internal/application/related"
+	domainRelated "github.com/kuro48/idol-api/internal/domain/related"
+	"github.com/kuro48/idol-api/internal/shared/geo"
+	ucAffiliation "github.com/kuro48/idol-api/internal/usecase/affiliation"
+	ucEvent "github.com/kuro48/idol-api/internal/usecase/event"
+	ucGroup "github.com/kuro48/idol-api/internal/usecase/group"
+	ucIdol "github.com/kuro48/idol-api/internal/usecase/idol"
+	ucRelease "github.com/kuro48/idol-api/internal/usecase/release"
+)
+
+// RelatedAppAdapter は appRelated.ApplicationService を各ユースケースの RelatedAppPort に適合させる
+type RelatedAppAdapter struct {
+	svc *appRelated.ApplicationService
+}
+
+// NewIdolRelatedAdapter は ucIdol.RelatedAppPort を生成する
+func NewIdolRelatedAdapter(svc *appRelated.ApplicationService) ucIdol.RelatedAppPort {
+	return &RelatedAppAdapter{svc: svc}
+}
+
+// NewGroupRelatedAdapter は ucGroup.RelatedAppPort を生成する
+func NewGroupRelatedAdapter(svc *appRelated.ApplicationService) ucGroup.RelatedAppPort {
+	return &RelatedAppAdapter{svc: svc}
+}
+
+// NewReleaseRelatedAdapter は ucRelease.RelatedAppPort を生成する
+func NewReleaseRelatedAdapter(svc *appRelated.ApplicationService) ucRelease.RelatedAppPort {
+	return &RelatedAppAdapter{svc: svc}
+}
+
+// NewEventRelatedAdapter は ucEvent.RelatedAppPort を生成する
+func NewEventRelatedAdapter(svc *appRelated.ApplicationService) ucEvent.RelatedAppPort {
+	return &RelatedAppAdapter{svc: svc}
+}
+
+// NewAffiliationRelatedAdapter は ucAffiliation.RelatedAppPort を生成する
+func NewAffiliationRelatedAdapter(svc *appRelated.ApplicationService) ucAffiliation.RelatedAppPort {
+	return &RelatedAppAdapter{svc: svc}
+}
+
+func (a *RelatedAppAdapter) FindAgencies(ctx context.Context, ids []string) (map[string]domainRelated.AgencySummary, error) {
+	return a.svc.FindAgencies(ctx, ids)
+}
+
+func (a *RelatedAppAdapter) FindIdols(ctx context.Context, ids []string) (map[string]domainRelated.IdolSummary, error) {
+	return a.svc.FindIdols(ctx, ids)
+}
+
+func (a *RelatedAppAdapter) FindGroups(ctx context.Context, ids []string) (map[string]domainRelated.GroupSummary, error) {
+	return a.svc.FindGroups(ctx, ids)
+}
+
+func (a *RelatedAppAdapter) FindTags(ctx context.Context, ids []string) (map[string]domainRelated.TagSummary, error) {
+	return a.svc.FindTags(ctx, ids)
+}
+
+func (a *RelatedAppAdapter) FindVenues(ctx context.Context, ids []string) (map[string]domainRelated.VenueSummary, error) {
+	return a.svc.FindVenues(ctx, ids)
+}
+
+func (a *RelatedAppAdapter) FindVenuesNear(ctx context.Context, center geo.Point, radiusKm float64, limit int) (map[string]float64, error) {
+	return a.svc.FindVenuesNear(ctx, center, radiusKm, limit)
+}
+
+func (a *RelatedAppAdapter) FindSubUnits(ctx context.Context, parentIDs []string) (map[string][]string, error) {
+	return a.svc.FindSubUnits(ctx, parentIDs)
+}
+
+func (a *RelatedAppAdapter) FindMembershipsByIdols(ctx context.Context, idolIDs []string, limit int) (map[string][]domainRelated.MembershipSummary, error) {
+	return a.svc.FindMembershipsByIdols(ctx, idolIDs, limit)
+}
+
+func (a *RelatedAppAdapter) FindMembershipsByGroups(ctx context.Context, groupIDs []string, limit int) (map[string][]domainRelated.MembershipSummary, error) {
+	return a.svc.FindMembershipsByGroups(ctx, groupIDs, limit)
+}
+
+func (a *RelatedAppAdapter) FindReleasesByArtists(ctx context.Context, artistIDs []string, limit int) (map[string][]domainRelated.ReleaseSummary, error) {
+	return a.svc.FindReleasesByArtists(ctx, artistIDs, limit)
+}
+
+func (a *RelatedAppAdapter) FindEventsByPerformers(ctx context.Context, performerIDs []string, limit int) (map[string][]domainRelated.EventSummary, error) {
+	return a.svc.FindEventsByPerformers(ctx, performerIDs, limit)
+}
@@ -52,6 +52,14 @@ func (a *ReleaseAppAdapter) UpdateExternalIDs(ctx context.Context, input appRele
 	return a.svc.UpdateExternalIDs(ctx, input)
 }
 
+func (a *ReleaseAppAdapter) UpdateEditions(ctx context.Context, input appRelease.UpdateEditionsInput) (*domainRelease.Release, error) {
+	return a.svc.UpdateEditions(ctx, input)
+}
+
+func (a *ReleaseAppAdapter) FindEditionByJANCode(ctx context.Context, janCode string) (*domainRelease.Release, domainRelease.Edition, error) {
+	return a.svc.FindEditionByJANCode(ctx, janCode)
+}
+
 // IdolExistenceAdapter は appIdol.ApplicationService を ucRelease.IdolExistencePort に適合させる
 type IdolExistenceAdapter struct {
 	svc *appIdol.ApplicationService
@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"context"
+
+	appSearch "github.com/kuro48/idol-api/internal/application/search"
+	ucSearch "github.com/kuro48/idol-api/internal/usecase/search"
+)
+
+// SearchAppAdapter は appSearch.ApplicationService を ucSearch.SearchAppPort に適合させる
+type SearchAppAdapter struct {
+	svc *appSearch.ApplicationService
+}
+
+// NewSearchAppAdapter は SearchAppAdapter を生成する
+func NewSearchAppAdapter(svc *appSearch.ApplicationService) ucSearch.SearchAppPort {
+	return &SearchAppAdapter{svc: svc}
+}
+
+func (a *SearchAppAdapter) Search(ctx context.Context, input ucSearch.SearchInput) (*ucSearch.SearchOutput, error) {
+	result, err := a.svc.Search(ctx, appSearch.SearchInput{
+		Query: input.Query,
+		Types: input.Types,
+		Limit: input.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ucSearch.SearchOutput{
+		Types:  result.Types,
+		Hits:   result.Hits,
+		Facets: result.Facets,
+		Total:  result.Total,
+		Limit:  result.Limit,
+	}, nil
+}
+
+func (a *SearchAppAdapter) Suggest(ctx context.Context, input ucSearch.SuggestInput) (*ucSearch.SuggestOutput, error) {
+	result, err := a.svc.Suggest(ctx, appSearch.SuggestInput{
+		Query: input.Query,
+		Types: input.Types,
+		Limit: input.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ucSearch.SuggestOutput{
+		Suggestions: result.Suggestions,
+		Limit:       result.Limit,
+	}, nil
+}
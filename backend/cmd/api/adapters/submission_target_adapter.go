@@ -36,6 +36,7 @@ func NewSubmissionTargetAppAdapter(
 func (a *SubmissionTargetAppAdapter) CreateIdol(ctx context.Context, input ucSubmission.IdolCreateInput) error {
 	_, err := a.idolSvc.CreateIdol(ctx, appIdol.CreateInput{
 		Name:      input.Name,
+		NameKana:  input.NameKana,
 		Birthdate: input.Birthdate,
 		AgencyID:  input.AgencyID,
 		Aliases:   input.Aliases,
@@ -46,6 +47,7 @@ func (a *SubmissionTargetAppAdapter) CreateIdol(ctx context.Context, input ucSub
 func (a *SubmissionTargetAppAdapter) CreateGroup(ctx context.Context, input ucSubmission.GroupCreateInput) error {
 	_, err := a.groupSvc.CreateGroup(ctx, appGroup.CreateInput{
 		Name:          input.Name,
+		NameKana:      input.NameKana,
 		FormationDate: input.FormationDate,
 		DisbandDate:   input.DisbandDate,
 	})
@@ -56,6 +58,7 @@ func (a *SubmissionTargetAppAdapter) CreateAgency(ctx context.Context, input ucS
 	_, err := a.agencySvc.CreateAgency(ctx, appAgency.CreateInput{
 		Name:            input.Name,
 		NameEn:          input.NameEn,
+		NameKana:        input.NameKana,
 		FoundedDate:     input.FoundedDate,
 		Country:         input.Country,
 		OfficialWebsite: input.OfficialWebsite,
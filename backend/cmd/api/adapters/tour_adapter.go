@@ -0,0 +1,66 @@
+package adapters
+
+import (
+	"context"
+
+	appTour "github.com/kuro48/idol-api/internal/application/tour"
+	domainTour "github.com/kuro48/idol-api/internal/domain/tour"
+	ucTour "github.com/kuro48/idol-api/internal/usecase/tour"
+)
+
+// TourAppAdapter は appTour.ApplicationService を ucTour.TourAppPort に適合させる
+type TourAppAdapter struct {
+	svc *appTour.ApplicationService
+}
+
+// NewTourAppAdapter は TourAppAdapter を生成する
+func NewTourAppAdapter(svc *appTour.ApplicationService) ucTour.TourAppPort {
+	return &TourAppAdapter{svc: svc}
+}
+
+func (a *TourAppAdapter) CreateTour(ctx context.Context, input ucTour.TourCreateInput) (*domainTour.Tour, error) {
+	return a.svc.CreateTour(ctx, appTour.CreateInput{
+		Title:       input.Title,
+		EventType:   input.EventType,
+		Performers:  toTourPerformerInputs(input.Performers),
+		Tags:        input.Tags,
+		OfficialURL: input.OfficialURL,
+		Description: input.Description,
+	})
+}
+
+func (a *TourAppAdapter) GetTour(ctx context.Context, id string) (*domainTour.Tour, error) {
+	return a.svc.GetTour(ctx, id)
+}
+
+func (a *TourAppAdapter) SearchTours(ctx context.Context, criteria domainTour.SearchCriteria) ([]*domainTour.Tour, int64, error) {
+	return a.svc.SearchTours(ctx, criteria)
+}
+
+func (a *TourAppAdapter) UpdateTour(ctx context.Context, input ucTour.TourUpdateInput) error {
+	return a.svc.UpdateTour(ctx, appTour.UpdateInput{
+		ID:          input.ID,
+		Title:       input.Title,
+		EventType:   input.EventType,
+		Performers:  toTourPerformerInputs(input.Performers),
+		Tags:        input.Tags,
+		OfficialURL: input.OfficialURL,
+		Description: input.Description,
+	})
+}
+
+func (a *TourAppAdapter) DeleteTour(ctx context.Context, id string) error {
+	return a.svc.DeleteTour(ctx, id)
+}
+
+// toTourPerformerInputs は出演者の入力を変換する。nil（未指定）は nil のまま渡す
+func toTourPerformerInputs(performers []ucTour.TourPerformerInput) []appTour.PerformerInput {
+	if performers == nil {
+		return nil
+	}
+	inputs := make([]appTour.PerformerInput, 0, len(performers))
+	for _, p := range performers {
+		inputs = append(inputs, appTour.PerformerInput{PerformerID: p.PerformerID, BillingStatus: p.BillingStatus})
+	}
+	return inputs
+}
@@ -25,6 +25,8 @@ func (a *VenueAppAdapter) CreateVenue(ctx context.Context, input ucVenue.VenueCr
 		Address:     input.Address,
 		Capacity:    input.Capacity,
 		OfficialURL: input.OfficialURL,
+		Latitude:    input.Latitude,
+		Longitude:   input.Longitude,
 	})
 }
 
@@ -42,17 +44,45 @@ func (a *VenueAppAdapter) CountVenues(ctx context.Context, criteria domainVenue.
 
 func (a *VenueAppAdapter) UpdateVenue(ctx context.Context, input ucVenue.VenueUpdateInput) error {
 	return a.svc.UpdateVenue(ctx, appVenue.UpdateInput{
-		ID:          input.ID,
-		Name:        input.Name,
-		NameEn:      input.NameEn,
-		Prefecture:  input.Prefecture,
-		City:        input.City,
-		Address:     input.Address,
-		Capacity:    input.Capacity,
-		OfficialURL: input.OfficialURL,
+		ID:            input.ID,
+		Name:          input.Name,
+		NameEn:        input.NameEn,
+		Prefecture:    input.Prefecture,
+		City:          input.City,
+		Address:       input.Address,
+		Capacity:      input.Capacity,
+		OfficialURL:   input.OfficialURL,
+		Latitude:      input.Latitude,
+		Longitude:     input.Longitude,
+		ClearLocation: input.ClearLocation,
 	})
 }
 
 func (a *VenueAppAdapter) DeleteVenue(ctx context.Context, id string) error {
 	return a.svc.DeleteVenue(ctx, id)
 }
+
+func (a *VenueAppAdapter) UpdateLocations(ctx context.Context, items []ucVenue.VenueLocationInput) *ucVenue.VenueLocationsResult {
+	inputs := make([]appVenue.LocationInput, 0, len(items))
+	for _, item := range items {
+		inputs = append(inputs, appVenue.LocationInput{
+			VenueID:   item.VenueID,
+			Latitude:  item.Latitude,
+			Longitude: item.Longitude,
+		})
+	}
+	result := a.svc.UpdateLocations(ctx, inputs)
+	errs := make([]ucVenue.VenueLocationError, 0, len(result.Errors))
+	for _, e := range result.Errors {
+		errs = append(errs, ucVenue.VenueLocationError{Index: e.Index, VenueID: e.VenueID, Error: e.Error})
+	}
+	return &ucVenue.VenueLocationsResult{Processed: result.Processed, Success: result.Success, Errors: errs}
+}
+
+func (a *VenueAppAdapter) UpdateConfigurations(ctx context.Context, input ucVenue.VenueConfigurationsInput) (*domainVenue.Venue, error) {
+	configs := make([]appVenue.ConfigurationInput, 0, len(input.Configurations))
+	for _, c := range input.Configurations {
+		configs = append(configs, appVenue.ConfigurationInput{ID: c.ID, Name: c.Name, Layout: c.Layout, Capacity: c.Capacity})
+	}
+	return a.svc.UpdateConfigurations(ctx, appVenue.UpdateConfigurationsInput{VenueID: input.VenueID, Configurations: configs})
+}
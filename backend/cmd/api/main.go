@@ -34,6 +34,7 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/cmd/api/adapters"
+	appAffiliation "github.com/kuro48/idol-api/internal/application/affiliation"
 	appAgency "github.com/kuro48/idol-api/internal/application/agency"
 	appAnalytics "github.com/kuro48/idol-api/internal/application/analytics"
 	appAPIKey "github.com/kuro48/idol-api/internal/application/apikey"
@@ -41,14 +42,20 @@ import (
 	appEditHistory "github.com/kuro48/idol-api/internal/application/edithistory"
 	appEvent "github.com/kuro48/idol-api/internal/application/event"
 	appExport "github.com/kuro48/idol-api/internal/application/export"
+	appGraph "github.com/kuro48/idol-api/internal/application/graph"
 	appGroup "github.com/kuro48/idol-api/internal/application/group"
 	appIdol "github.com/kuro48/idol-api/internal/application/idol"
 	appJob "github.com/kuro48/idol-api/internal/application/job"
 	appMembership "github.com/kuro48/idol-api/internal/application/membership"
+	appRelated "github.com/kuro48/idol-api/internal/application/related"
 	appRelease "github.com/kuro48/idol-api/internal/application/release"
 	appRemoval "github.com/kuro48/idol-api/internal/application/removal"
+	appScheduler "github.com/kuro48/idol-api/internal/application/scheduler"
+	appSearch "github.com/kuro48/idol-api/internal/application/search"
 	appSubmission "github.com/kuro48/idol-api/internal/application/submission"
 	appTag "github.com/kuro48/idol-api/internal/application/tag"
+	appTour "github.com/kuro48/idol-api/internal/application/tour"
+	appUsage "github.com/kuro48/idol-api/internal/application/usage"
 	appVenue "github.com/kuro48/idol-api/internal/application/venue"
 	appWebhook "github.com/kuro48/idol-api/internal/application/webhook"
 	"github.com/kuro48/idol-api/internal/config"
@@ -61,17 +68,24 @@ import (
 	infraStripe "github.com/kuro48/idol-api/internal/infrastructure/stripe"
 	"github.com/kuro48/idol-api/internal/interface/handlers"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/audit"
+	sharedid "github.com/kuro48/idol-api/internal/shared/id"
 	"github.com/kuro48/idol-api/internal/shared/logger"
+	usecaseAffiliation "github.com/kuro48/idol-api/internal/usecase/affiliation"
 	usecaseAgency "github.com/kuro48/idol-api/internal/usecase/agency"
+	usecaseAnniversary "github.com/kuro48/idol-api/internal/usecase/anniversary"
 	usecaseEditHistory "github.com/kuro48/idol-api/internal/usecase/edithistory"
 	usecaseEvent "github.com/kuro48/idol-api/internal/usecase/event"
+	usecaseGraph "github.com/kuro48/idol-api/internal/usecase/graph"
 	usecaseGroup "github.com/kuro48/idol-api/internal/usecase/group"
 	usecaseIdol "github.com/kuro48/idol-api/internal/usecase/idol"
 	usecaseMembership "github.com/kuro48/idol-api/internal/usecase/membership"
 	usecaseRelease "github.com/kuro48/idol-api/internal/usecase/release"
 	usecaseRemoval "github.com/kuro48/idol-api/internal/usecase/removal"
+	usecaseSearch "github.com/kuro48/idol-api/internal/usecase/search"
 	usecaseSubmission "github.com/kuro48/idol-api/internal/usecase/submission"
 	usecaseTag "github.com/kuro48/idol-api/internal/usecase/tag"
+	usecaseTour "github.com/kuro48/idol-api/internal/usecase/tour"
 	usecaseVenue "github.com/kuro48/idol-api/internal/usecase/venue"
 
 	_ "github.com/kuro48/idol-api/docs" // Swagger docs
@@ -118,11 +132,18 @@ func main() {
 	submissionRepo := mongodb.NewSubmissionRepository(db.Database)
 	apikeyRepo := mongodb.NewAPIKeyRepository(db.Database)
 	usageRepo := mongodb.NewUsageRepository(db.Database)
+	thresholdEmailRepo := mongodb.NewThresholdEmailRepository(db.Database)
 	billingRepo := mongodb.NewBillingFulfillmentRepository(db.Database)
+	stripeEventRepo := mongodb.NewStripeEventRepository(db.Database)
 	releaseRepo := mongodb.NewReleaseRepository(db.Database)
 	editHistoryRepo := mongodb.NewEditHistoryRepository(db.Database)
 	membershipRepo := mongodb.NewMembershipRepository(db.Database)
+	affiliationRepo := mongodb.NewAffiliationRepository(db.Database)
 	venueRepo := mongodb.NewVenueRepository(db.Database)
+	tourRepo := mongodb.NewTourRepository(db.Database)
+	searchRepo := mongodb.NewSearchRepository(db.Database)
+	graphRepo := mongodb.NewGraphRepository(db.Database)
+	relatedRepo := mongodb.NewRelatedRepository(db.Database)
 
 	// MongoDBインデックスの作成
 	ctx := context.Background()
@@ -181,6 +202,11 @@ func main() {
 	} else {
 		slog.Info("Usageインデックス作成完了", "collection", "api_key_usage")
 	}
+	if err := thresholdEmailRepo.EnsureIndexes(ctx); err != nil {
+		slog.Warn("ThresholdEmailインデックス作成失敗（続行）", "error", err, "collection", "usage_threshold_emails")
+	} else {
+		slog.Info("ThresholdEmailインデックス作成完了", "collection", "usage_threshold_emails")
+	}
 	if err := webhookSubRepo.EnsureIndexes(ctx); err != nil {
 		slog.Warn("WebhookSubインデックス作成失敗（続行）", "error", err, "collection", "webhook_subscriptions")
 	} else {
@@ -201,6 +227,11 @@ func main() {
 	} else {
 		slog.Info("BillingFulfillmentインデックス作成完了", "collection", "billing_fulfillments")
 	}
+	if err := stripeEventRepo.EnsureIndexes(ctx); err != nil {
+		slog.Warn("StripeEventインデックス作成失敗（続行）", "error", err, "collection", "billing_stripe_events")
+	} else {
+		slog.Info("StripeEventインデックス作成完了", "collection", "billing_stripe_events")
+	}
 	if err := releaseRepo.EnsureIndexes(ctx); err != nil {
 		slog.Warn("Releaseインデックス作成失敗（続行）", "error", err, "collection", "releases")
 	} else {
@@ -216,11 +247,85 @@ func main() {
 	} else {
 		slog.Info("Membershipインデックス作成完了", "collection", "memberships")
 	}
+	if err := affiliationRepo.EnsureIndexes(ctx); err != nil {
+		slog.Warn("Affiliationインデックス作成失敗（続行）", "error", err, "collection", "agency_affiliations")
+	} else {
+		slog.Info("Affiliationインデックス作成完了", "collection", "agency_affiliations")
+	}
 	if err := venueRepo.EnsureIndexes(ctx); err != nil {
 		slog.Warn("Venueインデックス作成失敗（続行）", "error", err, "collection", "venues")
 	} else {
 		slog.Info("Venueインデックス作成完了", "collection", "venues")
 	}
+	if err := tourRepo.EnsureIndexes(ctx); err != nil {
+		slog.Warn("Tourインデックス作成失敗（続行）", "error", err, "collection", "tours")
+	} else {
+		slog.Info("Tourインデックス作成完了", "collection", "tours")
+	}
+
+	// 名前検索用の正規化キーを未生成・古い版の既存ドキュメントに付与（作り直し）
+	searchKeyBackfills := []struct {
+		collection string
+		backfill   func(context.Context) (int, error)
+	}{
+		{"idols", idolRepo.BackfillSearchKeys},
+		{"groups", groupRepo.BackfillSearchKeys},
+		{"agencies", agencyRepo.BackfillSearchKeys},
+		{"venues", venueRepo.BackfillSearchKeys},
+		{"releases", releaseRepo.BackfillSearchKeys},
+		{"events", eventRepo.BackfillSearchKeys},
+	}
+	for _, b := range searchKeyBackfills {
+		if updated, err := b.backfill(ctx); err != nil {
+			slog.Warn("検索キー生成失敗（続行）", "error", err, "collection", b.collection)
+		} else if updated > 0 {
+			slog.Info("検索キー生成完了", "collection", b.collection, "updated", updated)
+		}
+	}
+
+	// 誕生日・結成記念日の検索用の月日を未生成の既存ドキュメントに付与
+	monthDayBackfills := []struct {
+		collection string
+		backfill   func(context.Context) (int, error)
+	}{
+		{"idols", idolRepo.BackfillMonthDays},
+		{"groups", groupRepo.BackfillMonthDays},
+	}
+	for _, b := range monthDayBackfills {
+		if updated, err := b.backfill(ctx); err != nil {
+			slog.Warn("月日フィールド生成失敗（続行）", "error", err, "collection", b.collection)
+		} else if updated > 0 {
+			slog.Info("月日フィールド生成完了", "collection", b.collection, "updated", updated)
+		}
+	}
+
+	// リリース単位で登録されていたJANコードをエディションへ移行
+	if migrated, err := releaseRepo.MigrateJANCodesToEditions(ctx); err != nil {
+		slog.Warn("JANコードのエディション移行失敗（続行）", "error", err, "collection", "releases")
+	} else if migrated > 0 {
+		slog.Info("JANコードのエディション移行完了", "collection", "releases", "migrated", migrated)
+	}
+
+	// リリース単位で登録されていたUPCをエディションへ移行
+	if migrated, err := releaseRepo.MigrateUPCsToEditions(ctx); err != nil {
+		slog.Warn("UPCのエディション移行失敗（続行）", "error", err, "collection", "releases")
+	} else if migrated > 0 {
+		slog.Info("UPCのエディション移行完了", "collection", "releases", "migrated", migrated)
+	}
+
+	// エディションのJANコード・UPCを一意インデックス用フィールドへ集約
+	if updated, err := releaseRepo.BackfillEditionCodes(ctx); err != nil {
+		slog.Warn("エディションコード生成失敗（続行）", "error", err, "collection", "releases")
+	} else if updated > 0 {
+		slog.Info("エディションコード生成完了", "collection", "releases", "updated", updated)
+	}
+
+	// agency_id の事務所への所属履歴を持たないアイドル・グループについて所属履歴を生成
+	if seeded, err := affiliationRepo.SeedFromAgencyIDs(ctx); err != nil {
+		slog.Warn("所属履歴の生成失敗（続行）", "error", err, "collection", "agency_affiliations")
+	} else if seeded > 0 {
+		slog.Info("所属履歴の生成完了", "collection", "agency_affiliations", "seeded", seeded)
+	}
 
 	// アプリケーション層: アプリケーションサービス
 	analyticsAppService := appAnalytics.NewApplicationService(analyticsRepo)
@@ -229,16 +334,23 @@ func main() {
 	removalAppService := appRemoval.NewApplicationService(removalRepo)
 	groupAppService := appGroup.NewApplicationService(groupRepo, webhookAppService)
 	agencyAppService := appAgency.NewApplicationService(agencyRepo, webhookAppService)
-	eventAppService := appEvent.NewApplicationService(eventRepo, webhookAppService)
+	eventAppService := appEvent.NewApplicationService(eventRepo, tourRepo, venueRepo, releaseRepo, webhookAppService)
 	jobAppService := appJob.NewApplicationService(jobRepo, idolAppService)
 	tagAppService := appTag.NewApplicationService(tagRepo)
 	exportAppService := appExport.NewApplicationService(exportLogRepo, idolAppService)
 	submissionAppService := appSubmission.NewApplicationService(submissionRepo)
 	apikeyAppService := appAPIKey.NewApplicationService(apikeyRepo)
-	releaseAppService := appRelease.NewApplicationService(releaseRepo, webhookAppService)
+	releaseAppService := appRelease.NewApplicationService(releaseRepo, webhookAppService).WithSetlistReferences(eventRepo)
 	editHistoryAppService := appEditHistory.NewApplicationService(editHistoryRepo)
 	membershipAppService := appMembership.NewApplicationService(membershipRepo)
-	venueAppService := appVenue.NewApplicationService(venueRepo)
+	affiliationAppService := appAffiliation.NewApplicationService(affiliationRepo, adapters.NewTalentAgencySyncAdapter(idolAppService, groupAppService))
+	idolAppService.WithAffiliationRecorder(adapters.NewIdolAffiliationRecorderAdapter(affiliationAppService))
+	venueAppService := appVenue.NewApplicationService(venueRepo).WithConfigurationReferences(eventRepo)
+	tourAppService := appTour.NewApplicationService(tourRepo, eventRepo, webhookAppService)
+	searchAppService := appSearch.NewApplicationService(searchRepo, searchRepo, cfg.SuggestCacheTTL)
+	graphAppService := appGraph.NewApplicationService(graphRepo)
+	relatedAppService := appRelated.NewApplicationService(relatedRepo)
+	usageAppService := appUsage.NewApplicationService(apikeyRepo, usageRepo, analyticsRepo)
 
 	// 起動時に RUNNING 状態で止まっているジョブを PENDING に戻す
 	if err := jobAppService.RecoverStuckJobs(ctx); err != nil {
@@ -262,7 +374,11 @@ func main() {
 	releaseGroupPort := adapters.NewGroupExistenceAdapter(groupAppService)
 	editHistoryAppPort := adapters.NewEditHistoryAppAdapter(editHistoryAppService)
 	membershipAppPort := adapters.NewMembershipAppAdapter(membershipAppService)
+	affiliationAppPort := adapters.NewAffiliationAppAdapter(affiliationAppService)
 	venueAppPort := adapters.NewVenueAppAdapter(venueAppService)
+	tourAppPort := adapters.NewTourAppAdapter(tourAppService)
+	searchAppPort := adapters.NewSearchAppAdapter(searchAppService)
+	graphAppPort := adapters.NewGraphAppAdapter(graphAppService)
 
 	// メール通知の初期化（SMTP_HOST が設定されている場合のみ有効化）
 	var smtpNotifier *email.SMTPNotifier
@@ -283,17 +399,22 @@ func main() {
 	}
 
 	// ユースケース層
-	idolUsecase := usecaseIdol.NewUsecase(idolAppPort, agencyAppPortForIdol)
+	idolUsecase := usecaseIdol.NewUsecase(idolAppPort, agencyAppPortForIdol, adapters.NewIdolRelatedAdapter(relatedAppService))
 	removalUsecase := usecaseRemoval.NewUsecase(removalAppPort, removalIdolPort, removalGroupPort, smtpNotifier, webhookAppService)
-	groupUsecase := usecaseGroup.NewUsecase(groupAppPort)
+	groupUsecase := usecaseGroup.NewUsecase(groupAppPort, adapters.NewGroupRelatedAdapter(relatedAppService))
 	agencyUsecase := usecaseAgency.NewUsecase(agencyAppPort)
-	eventUsecase := usecaseEvent.NewUsecase(eventAppPort)
+	eventUsecase := usecaseEvent.NewUsecase(eventAppPort, adapters.NewEventRelatedAdapter(relatedAppService))
 	tagUsecase := usecaseTag.NewUsecase(tagAppPort)
 	submissionUsecase := usecaseSubmission.NewUsecase(submissionAppPort, submissionTargetPort, emailNotifier)
-	releaseUsecase := usecaseRelease.NewUsecase(releaseAppPort, releaseIdolPort, releaseGroupPort)
+	releaseUsecase := usecaseRelease.NewUsecase(releaseAppPort, releaseIdolPort, releaseGroupPort, adapters.NewReleaseRelatedAdapter(relatedAppService))
 	editHistoryUsecase := usecaseEditHistory.NewUsecase(editHistoryAppPort)
-	membershipUsecase := usecaseMembership.NewUsecase(membershipAppPort)
+	membershipUsecase := usecaseMembership.NewUsecase(membershipAppPort, adapters.NewGroupUnitAdapter(groupAppService))
+	affiliationUsecase := usecaseAffiliation.NewUsecase(affiliationAppPort, adapters.NewAffiliationRelatedAdapter(relatedAppService))
 	venueUsecase := usecaseVenue.NewUsecase(venueAppPort)
+	tourUsecase := usecaseTour.NewUsecase(tourAppPort)
+	searchUsecase := usecaseSearch.NewUsecase(searchAppPort)
+	graphUsecase := usecaseGraph.NewUsecase(graphAppPort)
+	anniversaryUsecase := usecaseAnniversary.NewUsecase(adapters.NewAnniversaryIdolAdapter(idolAppService), adapters.NewAnniversaryGroupAdapter(groupAppService))
 
 	// プレゼンテーション層: ハンドラー
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsAppService)
@@ -311,13 +432,20 @@ func main() {
 	releaseHandler := handlers.NewReleaseHandler(releaseUsecase)
 	editHistoryHandler := handlers.NewEditHistoryHandler(editHistoryUsecase)
 	membershipHandler := handlers.NewMembershipHandler(membershipUsecase)
+	affiliationHandler := handlers.NewAffiliationHandler(affiliationUsecase)
 	venueHandler := handlers.NewVenueHandler(venueUsecase)
+	tourHandler := handlers.NewTourHandler(tourUsecase, eventUsecase)
+	searchHandler := handlers.NewSearchHandler(searchUsecase)
+	graphHandler := handlers.NewGraphHandler(graphUsecase)
+	anniversaryHandler := handlers.NewAnniversaryHandler(anniversaryUsecase)
 	apikeyHandler := handlers.NewAPIKeyHandler(apikeyAppService)
 	meHandler := handlers.NewMeHandler()
+	usageHandler := handlers.NewUsageHandler(usageAppService)
 	healthHandler := handlers.NewHealthHandler(db)
 	billingService := appBilling.NewService(
 		nil,
 		billingRepo,
+		stripeEventRepo,
 		apikeyAppService,
 		smtpNotifier,
 		appBilling.Config{
@@ -330,10 +458,13 @@ func main() {
 		},
 	)
 	var billingHandler *handlers.BillingHandler
+	var overageReporter *appBilling.OverageReporter
 	if cfg.StripeSecretKey != "" && smtpNotifier != nil {
+		stripeClient := infraStripe.NewClient(cfg.StripeSecretKey, cfg.StripeWebhookSecret)
 		billingService = appBilling.NewService(
-			infraStripe.NewClient(cfg.StripeSecretKey, cfg.StripeWebhookSecret),
+			stripeClient,
 			billingRepo,
+			stripeEventRepo,
 			apikeyAppService,
 			smtpNotifier,
 			appBilling.Config{
@@ -343,17 +474,33 @@ func main() {
 					plan.TypeDeveloper: cfg.StripePriceDeveloper,
 					plan.TypeBusiness:  cfg.StripePriceBusiness,
 				},
+				UpgradeSuccessURL:  cfg.StripeUpgradeSuccessURL,
+				UpgradeCancelURL:   cfg.StripeUpgradeCancelURL,
+				OveragePriceID:     cfg.StripePriceOverage,
+				PaymentGracePeriod: cfg.StripePaymentGracePeriod,
 			},
 		)
+		apikeyAppService.WithOverageBilling(billingService)
+		overageReporter = appBilling.NewOverageReporter(apikeyRepo, usageRepo, billingRepo, stripeClient, cfg.StripeOverageMeterEvent)
 		slog.Info("Stripe課金導線が有効です")
 	} else {
 		slog.Info("Stripe課金導線は無効です", "stripe_enabled", cfg.StripeSecretKey != "", "smtp_enabled", smtpNotifier != nil)
 	}
 	billingHandler = handlers.NewBillingHandlerWithAllowedRedirectOrigins(billingService, parseCORSOrigins(cfg.CORSAllowedOrigins, cfg.GinMode))
+	stripeEventHandler := handlers.NewStripeEventHandler(billingService)
+
+	// 月次使用量のしきい値（80/95/100%）到達通知
+	var thresholdNotifier appUsage.ThresholdNotifier
+	if smtpNotifier != nil {
+		thresholdNotifier = smtpNotifier
+	}
+	usageAlertService := appUsage.NewAlertService(usageRepo, thresholdEmailRepo, thresholdNotifier, webhookAppService, billingService)
 
 	// プランベース認証ミドルウェア（外部開発者向けAPIキー）
 	// Auth: APIキー必須。検証に成功したリクエストのみ使用量をカウントして通過させる。
-	planAuth := middleware.NewPlanAuth(apikeyRepo, usageRepo)
+	// Identify: 公開読み取り向け。キーがあればプラン種別だけを判定し（include 展開の上限に使用）、なければ匿名で通過させる。
+	planAuth := middleware.NewPlanAuthWithAlerter(apikeyRepo, usageRepo, usageAlertService).
+		WithOverageHardCap(cfg.OverageHardCap)
 
 	// Ginルーターのセットアップ（デフォルトミドルウェアなし）
 	router := gin.New()
@@ -462,14 +609,28 @@ func main() {
 		v1.GET("/me", userAuth, meHandler.GetMe)
 		v1.GET("/me/submissions", userAuth, submissionHandler.ListMySubmissions)
 		v1.GET("/me/removal-requests", userAuth, removalHandler.ListMyRemovalRequests)
+		v1.GET("/me/usage", userAuth, usageHandler.GetMyUsage)                        // 自分のAPIキー利用状況（?format=csv 対応）
+		v1.PUT("/me/apikeys/:id/overage", userAuth, apikeyHandler.SetMyAPIKeyOverage) // 超過利用（従量課金）のオプトイン
+
+		// 横断検索（公開）: アイドル・グループ・事務所・リリース・イベント・会場をまとめて検索
+		v1.GET("/search", searchHandler.Search)
+		v1.GET("/suggest", searchHandler.Suggest) // 入力補完（エンティティ選択欄向けの前方一致・短時間キャッシュ）
 
 		// アイドル: 読み取りは公開、書き込みは write スコープ必須
-		idols := v1.Group("/idols")
+		idols := v1.Group("/idols", planAuth.Identify())
 		{
 			idols.GET("", idolHandler.ListIdols)                                 // 一覧取得
+			idols.GET("/birthdays", anniversaryHandler.Birthdays)                // 期間内の誕生日（?from=MM-DD&to=MM-DD）
 			idols.GET("/:id", idolHandler.GetIdol)                               // 詳細取得
+			idols.GET("/:id/name", idolHandler.GetNameAt)                        // 指定日時点の芸名（?at=YYYY-MM-DD）
 			idols.GET("/:id/external-ids", idolHandler.GetExternalIDs)           // 外部IDマッピング取得
 			idols.GET("/:id/memberships", membershipHandler.ListIdolMemberships) // メンバーシップ一覧
+			idols.GET("/:id/agencies", affiliationHandler.ListIdolAgencies)      // 所属事務所の履歴
+			idols.GET("/:id/graph", graphHandler.IdolGraph)                      // 関係グラフ（?depth=1〜3）
+			idols.GET("/:id/co-members", graphHandler.CoMembers)                 // 同じグループに在籍したアイドル
+			idols.GET("/:id/co-stars", graphHandler.CoStars)                     // 共演者（同じイベントへの出演）
+			idols.GET("/:id/path", graphHandler.ShortestPath)                    // 2アイドル間の最短経路（?to=）
+			idols.GET("/:id/events.ics", eventHandler.IdolEventsICS)             // 出演イベントのカレンダー配信（iCalendar）
 		}
 		idolsWrite := v1.Group("/idols", writeAuth)
 		{
@@ -503,6 +664,7 @@ func main() {
 		adminAnalytics := v1.Group("/admin/analytics", adminAuth)
 		{
 			adminAnalytics.GET("/usage", analyticsHandler.GetUsageSummary) // API利用サマリー取得
+			adminAnalytics.GET("/capacity", eventHandler.CapacityReport)   // ツアー・出演者ごとの収容人数レポート
 		}
 
 		// 非同期ジョブ管理（admin スコープ必須）
@@ -521,6 +683,13 @@ func main() {
 			adminWebhooks.DELETE("/:id", webhookHandler.DeleteSubscription) // 購読削除
 		}
 
+		// Stripe Webhook イベント台帳（admin スコープ必須）
+		adminStripeEvents := v1.Group("/admin/billing/stripe-events", adminAuth)
+		{
+			adminStripeEvents.GET("", stripeEventHandler.ListStripeEvents)              // 台帳一覧（?status=failed）
+			adminStripeEvents.POST("/:id/replay", stripeEventHandler.ReplayStripeEvent) // 失敗イベントの再実行
+		}
+
 		// Webhook受信エンドポイント（公開: 外部からの受信）
 		v1.POST("/webhooks/receive/:subscription_id", publicMutationLimiter.Limit(), webhookHandler.ReceiveWebhook)
 
@@ -531,6 +700,18 @@ func main() {
 			adminEditHistory.GET("/:id", editHistoryHandler.GetEditHistory) // 編集履歴詳細
 		}
 
+		// イベントの日程重複レポート（admin スコープ必須）
+		adminEvents := v1.Group("/admin/events", adminAuth)
+		{
+			adminEvents.GET("/conflicts", eventHandler.ListConflicts) // 出演者・会場の日程重複一覧
+		}
+
+		// 会場座標の一括登録（admin スコープ必須）
+		adminVenues := v1.Group("/admin/venues", adminAuth)
+		{
+			adminVenues.PUT("/locations", venueHandler.UpdateVenueLocations)
+		}
+
 		// エクスポート（admin スコープ必須）
 		adminExport := v1.Group("/admin/export", adminAuth)
 		{
@@ -552,11 +733,17 @@ func main() {
 		}
 
 		// グループ: 読み取りは公開、書き込みは write スコープ必須
-		groups := v1.Group("/groups")
+		groups := v1.Group("/groups", planAuth.Identify())
 		{
 			groups.GET("", groupHandler.ListGroup)
+			groups.GET("/anniversaries", anniversaryHandler.GroupAnniversaries) // 対象月の結成記念日（?month=YYYY-MM&years=）
 			groups.GET("/:id", groupHandler.GetGroup)
-			groups.GET("/:id/memberships", membershipHandler.ListGroupMemberships) // メンバーシップ一覧
+			groups.GET("/:id/memberships", membershipHandler.ListGroupMemberships) // メンバーシップ一覧（サブユニット分を含む）
+			groups.GET("/:id/agencies", affiliationHandler.ListGroupAgencies)      // 所属事務所の履歴
+			groups.GET("/:id/units", groupHandler.ListUnits)                       // 子グループ一覧
+			groups.GET("/:id/parent", groupHandler.GetParent)                      // 親グループ
+			groups.GET("/:id/name", groupHandler.GetNameAt)                        // 指定日時点のグループ名（?at=YYYY-MM-DD）
+			groups.GET("/:id/events.ics", eventHandler.GroupEventsICS)             // 出演イベントのカレンダー配信（iCalendar）
 		}
 		groupsWrite := v1.Group("/groups", writeAuth)
 		{
@@ -578,16 +765,30 @@ func main() {
 			membershipsWrite.DELETE("/:id", membershipHandler.DeleteMembership)
 		}
 
+		// 事務所の所属履歴: 読み取りは公開、書き込みは write スコープ必須
+		affiliations := v1.Group("/affiliations")
+		{
+			affiliations.GET("/:id", affiliationHandler.GetAffiliation)
+		}
+		affiliationsWrite := v1.Group("/affiliations", writeAuth)
+		{
+			affiliationsWrite.POST("", affiliationHandler.CreateAffiliation)
+			affiliationsWrite.PUT("/:id", affiliationHandler.UpdateAffiliation)
+			affiliationsWrite.DELETE("/:id", affiliationHandler.DeleteAffiliation)
+		}
+
 		// 会場: 読み取りは公開、書き込みは write スコープ必須
 		venues := v1.Group("/venues")
 		{
 			venues.GET("", venueHandler.ListVenues)
 			venues.GET("/:id", venueHandler.GetVenue)
+			venues.GET("/:id/events.ics", eventHandler.VenueEventsICS) // 開催イベントのカレンダー配信（iCalendar）
 		}
 		venuesWrite := v1.Group("/venues", writeAuth)
 		{
 			venuesWrite.POST("", venueHandler.CreateVenue)
 			venuesWrite.PUT("/:id", venueHandler.UpdateVenue)
+			venuesWrite.PUT("/:id/configurations", venueHandler.UpdateVenueConfigurations) // 収容構成の置き換え
 			venuesWrite.DELETE("/:id", venueHandler.DeleteVenue)
 		}
 
@@ -596,6 +797,7 @@ func main() {
 		{
 			agencies.GET("", agencyHandler.ListAgencies)
 			agencies.GET("/:id", agencyHandler.GetAgency)
+			agencies.GET("/:id/talents", affiliationHandler.ListAgencyTalents) // 所属タレント（?at=YYYY-MM-DD で当時の所属）
 		}
 		agenciesWrite := v1.Group("/agencies", writeAuth)
 		{
@@ -611,12 +813,14 @@ func main() {
 		}
 
 		// イベント: 読み取りは公開、書き込みは write スコープ必須
-		events := v1.Group("/events")
+		events := v1.Group("/events", planAuth.Identify())
 		{
 			events.GET("", eventHandler.ListEvents)                 // イベント一覧取得（検索機能付き）
 			events.GET("/upcoming", eventHandler.GetUpcomingEvents) // 今後のイベント取得
 			events.GET("/:id", eventHandler.GetEvent)               // イベント詳細取得
 		}
+		v1.GET("/events.ics", planAuth.Identify(), eventHandler.EventsICS)                     // 検索条件に合うイベントのカレンダー配信（iCalendar）
+		v1.GET("/anniversaries.ics", planAuth.Identify(), anniversaryHandler.AnniversariesICS) // 誕生日・結成記念日のカレンダー配信（iCalendar）
 		eventsWrite := v1.Group("/events", writeAuth)
 		{
 			eventsWrite.POST("", eventHandler.CreateEvent)                                    // イベント作成
@@ -624,13 +828,34 @@ func main() {
 			eventsWrite.DELETE("/:id", eventHandler.DeleteEvent)                              // イベント削除
 			eventsWrite.POST("/:id/performers", eventHandler.AddPerformer)                    // パフォーマー追加
 			eventsWrite.DELETE("/:id/performers/:performer_id", eventHandler.RemovePerformer) // パフォーマー削除
+			eventsWrite.PUT("/:id/setlist", eventHandler.UpdateSetlist)                       // セットリスト更新
+			eventsWrite.PUT("/:id/attendance", eventHandler.RecordAttendance)                 // 完売・動員数の記録
+			eventsWrite.PUT("/:id/streaming", eventHandler.UpdateStreaming)                   // 配信情報の更新
+			eventsWrite.DELETE("/:id/streaming", eventHandler.DeleteStreaming)                // 配信情報の削除
+		}
+
+		// ツアー（複数日程のイベントシリーズ）: 読み取りは公開、書き込みは write スコープ必須
+		tours := v1.Group("/tours", planAuth.Identify())
+		{
+			tours.GET("", tourHandler.ListTours)
+			tours.GET("/:id", tourHandler.GetTour)
+			tours.GET("/:id/events", tourHandler.ListTourEvents) // 公演日程の一覧
+		}
+		toursWrite := v1.Group("/tours", writeAuth)
+		{
+			toursWrite.POST("", tourHandler.CreateTour)
+			toursWrite.PUT("/:id", tourHandler.UpdateTour)              // 出演者・タグの変更は配下の公演にも反映
+			toursWrite.DELETE("/:id", tourHandler.DeleteTour)           // 配下の公演はツアーから外す
+			toursWrite.POST("/:id/events", tourHandler.CreateTourEvent) // 公演日程の追加（出演者・タグを引き継ぐ）
 		}
 
 		// リリース: 読み取りは公開、書き込みは write スコープ必須
-		releases := v1.Group("/releases")
+		releases := v1.Group("/releases", planAuth.Identify())
 		{
 			releases.GET("", releaseHandler.ListReleases)
 			releases.GET("/:id", releaseHandler.GetRelease)
+			releases.GET("/jan/:jan_code", releaseHandler.GetEditionByJANCode)                   // JANコードによるエディション検索
+			releases.GET("/:id/tracks/:track_number/events", eventHandler.ListTrackPerformances) // 収録曲が演奏されたイベント一覧
 		}
 		releasesWrite := v1.Group("/releases", writeAuth)
 		{
@@ -639,6 +864,7 @@ func main() {
 			releasesWrite.DELETE("/:id", releaseHandler.DeleteRelease)
 			releasesWrite.PUT("/:id/streaming-links", releaseHandler.UpdateStreamingLinks)
 			releasesWrite.PUT("/:id/external-ids", releaseHandler.UpdateExternalIDs)
+			releasesWrite.PUT("/:id/editions", releaseHandler.UpdateEditions)
 		}
 		releasesAdmin := v1.Group("/releases", adminAuth)
 		{
@@ -691,6 +917,48 @@ func main() {
 	// 失敗した Webhook 配信を 5 分ごとにリトライ
 	webhookAppService.StartRetryWorker(workerCtx, 5*time.Minute)
 
+	// 送信に失敗したしきい値到達メールを 1 分ごとに再送（再送時刻はメールごとのバックオフで決まる）
+	usageAlertService.StartRetryWorker(workerCtx, time.Minute)
+
+	// Business プランの超過利用を 1 時間ごとに Stripe へ報告
+	if overageReporter != nil {
+		overageReporter.StartReportWorker(workerCtx, time.Hour)
+	}
+
+	// 時刻起点の状態遷移。タスクごとのリースにより、複数レプリカでも同じ間隔内に実行するのは1台だけ
+	taskScheduler := appScheduler.NewScheduler(mongodb.NewSchedulerLeaseRepository(db.Database), schedulerHolder())
+	if err := taskScheduler.Register(appScheduler.Task{
+		Name:     "event.complete",
+		Interval: 5 * time.Minute,
+		Run: func(ctx context.Context) error {
+			ctx = audit.WithSource(audit.WithActor(ctx, "scheduler"), "scheduler")
+			completed, err := eventAppService.CompleteFinishedEvents(ctx, time.Now())
+			if completed > 0 {
+				slog.Info("終了時刻を過ぎたイベントを終了にしました", "count", completed)
+			}
+			return err
+		},
+	}); err != nil {
+		slog.Error("スケジューラのタスク登録エラー", "error", err)
+		os.Exit(1)
+	}
+	if err := taskScheduler.Register(appScheduler.Task{
+		Name:     "billing.expire_grace",
+		Interval: 15 * time.Minute,
+		Run: func(ctx context.Context) error {
+			ctx = audit.WithSource(audit.WithActor(ctx, "scheduler"), "scheduler")
+			expired, err := billingService.ExpireLapsedGracePeriods(ctx)
+			if expired > 0 {
+				slog.Info("支払い猶予期限を過ぎたAPIキーを降格しました", "count", expired)
+			}
+			return err
+		},
+	}); err != nil {
+		slog.Error("スケジューラのタスク登録エラー", "error", err)
+		os.Exit(1)
+	}
+	taskScheduler.Start(workerCtx)
+
 	slog.Info("サーバーを起動します", "address", addr, "architecture", "DDD")
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -711,6 +979,11 @@ func main() {
 	// インフライトの非同期処理が完了するまで待機
 	webhookAppService.Shutdown()
 	jobAppService.Shutdown()
+	usageAlertService.Shutdown()
+	if overageReporter != nil {
+		overageReporter.Shutdown()
+	}
+	taskScheduler.Shutdown()
 
 	// HTTP サーバーを 30 秒以内にシャットダウン
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -721,6 +994,15 @@ func main() {
 	slog.Info("サーバーを正常に停止しました")
 }
 
+// schedulerHolder はスケジューラのリース保持者として使うレプリカごとに一意な識別子を返す
+func schedulerHolder() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return host + "-" + sharedid.Generate()
+}
+
 func parseCORSOrigins(raw string, ginMode string) []string {
 	if strings.TrimSpace(raw) == "" && ginMode != gin.ReleaseMode {
 		return []string{"http://localhost:3000", "http://localhost:5173", "http://localhost:8080"}
@@ -0,0 +1,17 @@
+package affiliation
+
+type CreateInput struct {
+	TalentType   string // "idol" or "group"
+	TalentID     string
+	AgencyID     string
+	ContractType *string // nil の場合は専属契約
+	JoinedAt     *string // "2006-01-02" or nil
+	LeftAt       *string // "2006-01-02" or nil
+}
+
+type UpdateInput struct {
+	ID           string
+	ContractType *string
+	JoinedAt     *string // "2006-01-02"; omitted means no change; empty string means clear
+	LeftAt       *string // "2006-01-02"; omitted means no change; empty string means clear
+}
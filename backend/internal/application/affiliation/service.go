@@ -0,0 +1,229 @@
+package affiliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/affiliation"
+)
+
+// TalentAgencySyncer はアイドル・グループの所属事務所（agency_id）を所属履歴に合わせる契約
+type TalentAgencySyncer interface {
+	SyncAgency(ctx context.Context, talentType affiliation.TalentType, talentID string, agencyID *string) error
+}
+
+type ApplicationService struct {
+	repository affiliation.Repository
+	syncer     TalentAgencySyncer
+}
+
+func NewApplicationService(repo affiliation.Repository, syncer TalentAgencySyncer) *ApplicationService {
+	return &ApplicationService{repository: repo, syncer: syncer}
+}
+
+func (s *ApplicationService) CreateAffiliation(ctx context.Context, input CreateInput) (*affiliation.Affiliation, error) {
+	talentType, err := affiliation.NewTalentType(input.TalentType)
+	if err != nil {
+		return nil, err
+	}
+	contractType := affiliation.ContractExclusive
+	if input.ContractType != nil {
+		if contractType, err = affiliation.NewContractType(*input.ContractType); err != nil {
+			return nil, err
+		}
+	}
+	joinedAt, err := parseDate(input.JoinedAt, "所属日")
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := affiliation.NewAffiliation(talentType, input.TalentID, input.AgencyID, contractType, joinedAt)
+	if err != nil {
+		return nil, err
+	}
+	leftAt, err := parseDate(input.LeftAt, "退所日")
+	if err != nil {
+		return nil, err
+	}
+	if leftAt != nil {
+		if err := a.Leave(*leftAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.ensureNoOverlap(ctx, a); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Save(ctx, a); err != nil {
+		return nil, fmt.Errorf("所属履歴の保存エラー: %w", err)
+	}
+	if err := s.syncTalentAgency(ctx, a.TalentType(), a.TalentID()); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *ApplicationService) GetAffiliation(ctx context.Context, id string) (*affiliation.Affiliation, error) {
+	aid, err := affiliation.NewAffiliationID(id)
+	if err != nil {
+		return nil, fmt.Errorf("IDの生成エラー: %w", err)
+	}
+	a, err := s.repository.FindByID(ctx, aid)
+	if err != nil {
+		return nil, fmt.Errorf("所属履歴の取得エラー: %w", err)
+	}
+	return a, nil
+}
+
+// ListByTalent はアイドル・グループの所属履歴を所属日の古い順に取得する
+func (s *ApplicationService) ListByTalent(ctx context.Context, talentType, talentID string) ([]*affiliation.Affiliation, error) {
+	t, err := affiliation.NewTalentType(talentType)
+	if err != nil {
+		return nil, err
+	}
+	as, err := s.repository.FindByTalent(ctx, t, talentID)
+	if err != nil {
+		return nil, fmt.Errorf("所属履歴の取得エラー: %w", err)
+	}
+	return as, nil
+}
+
+// ListByAgency は事務所の所属タレントを取得する。at を指定した場合はその日に所属していたものに絞る
+func (s *ApplicationService) ListByAgency(ctx context.Context, agencyID string, at *time.Time) ([]*affiliation.Affiliation, error) {
+	as, err := s.repository.FindByAgencyID(ctx, agencyID, at)
+	if err != nil {
+		return nil, fmt.Errorf("所属タレントの取得エラー: %w", err)
+	}
+	return as, nil
+}
+
+func (s *ApplicationService) UpdateAffiliation(ctx context.Context, input UpdateInput) error {
+	aid, err := affiliation.NewAffiliationID(input.ID)
+	if err != nil {
+		return fmt.Errorf("IDの生成エラー: %w", err)
+	}
+	a, err := s.repository.FindByID(ctx, aid)
+	if err != nil {
+		return fmt.Errorf("所属履歴の取得エラー: %w", err)
+	}
+
+	if input.ContractType != nil {
+		contractType, err := affiliation.NewContractType(*input.ContractType)
+		if err != nil {
+			return err
+		}
+		if err := a.UpdateContractType(contractType); err != nil {
+			return err
+		}
+	}
+
+	// 退所日を先に解除しておくと、所属日を退所日より後ろへ動かす更新も受け付けられる
+	if input.LeftAt != nil {
+		a.ClearLeftAt()
+	}
+	if input.JoinedAt != nil {
+		joinedAt, err := parseDate(input.JoinedAt, "所属日")
+		if err != nil {
+			return err
+		}
+		if err := a.UpdateJoinedAt(joinedAt); err != nil {
+			return err
+		}
+	}
+	if input.LeftAt != nil {
+		leftAt, err := parseDate(input.LeftAt, "退所日")
+		if err != nil {
+			return err
+		}
+		if leftAt != nil {
+			if err := a.Leave(*leftAt); err != nil {
+				return err
+			}
+		}
+	}
+	if err := s.ensureNoOverlap(ctx, a); err != nil {
+		return err
+	}
+
+	if err := s.repository.Update(ctx, a); err != nil {
+		return fmt.Errorf("所属履歴の更新エラー: %w", err)
+	}
+	return s.syncTalentAgency(ctx, a.TalentType(), a.TalentID())
+}
+
+func (s *ApplicationService) DeleteAffiliation(ctx context.Context, id string) error {
+	aid, err := affiliation.NewAffiliationID(id)
+	if err != nil {
+		return fmt.Errorf("IDの生成エラー: %w", err)
+	}
+	a, err := s.repository.FindByID(ctx, aid)
+	if err != nil {
+		return fmt.Errorf("所属履歴の取得エラー: %w", err)
+	}
+	if err := s.repository.Delete(ctx, aid); err != nil {
+		return fmt.Errorf("所属履歴の削除エラー: %w", err)
+	}
+	return s.syncTalentAgency(ctx, a.TalentType(), a.TalentID())
+}
+
+// syncTalentAgency はタレントの agency_id を現在所属している事務所に合わせる。
+// 現在の所属が複数ある場合は所属日の最も新しいものを採り、所属がなければ agency_id を外す
+func (s *ApplicationService) syncTalentAgency(ctx context.Context, talentType affiliation.TalentType, talentID string) error {
+	if s.syncer == nil {
+		return nil
+	}
+	as, err := s.repository.FindByTalent(ctx, talentType, talentID)
+	if err != nil {
+		return fmt.Errorf("所属履歴の取得エラー: %w", err)
+	}
+	now := time.Now()
+	var current *affiliation.Affiliation
+	for _, a := range as {
+		if !a.ActiveAt(now) {
+			continue
+		}
+		if current == nil || (a.JoinedAt() != nil && (current.JoinedAt() == nil || a.JoinedAt().After(*current.JoinedAt()))) {
+			current = a
+		}
+	}
+	var agencyID *string
+	if current != nil {
+		id := current.AgencyID()
+		agencyID = &id
+	}
+	if err := s.syncer.SyncAgency(ctx, talentType, talentID, agencyID); err != nil {
+		return fmt.Errorf("所属事務所の同期エラー: %w", err)
+	}
+	return nil
+}
+
+// ensureNoOverlap は同じ事務所への所属期間が既存の所属履歴と重ならないことを確認する
+func (s *ApplicationService) ensureNoOverlap(ctx context.Context, a *affiliation.Affiliation) error {
+	existing, err := s.repository.FindByTalent(ctx, a.TalentType(), a.TalentID())
+	if err != nil {
+		return fmt.Errorf("所属履歴の取得エラー: %w", err)
+	}
+	for _, e := range existing {
+		if a.ID().Value() != "" && e.ID().Equals(a.ID()) {
+			continue
+		}
+		if a.Overlaps(e) {
+			return errors.New("同じ事務所への所属期間が既に登録されています（期間が重複）")
+		}
+	}
+	return nil
+}
+
+// parseDate は "2006-01-02" 形式の日付を解釈する。nil・空文字は nil を返す
+func parseDate(s *string, label string) (*time.Time, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", *s)
+	if err != nil {
+		return nil, fmt.Errorf("%sの形式が不正です: %w", label, err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,170 @@
+package affiliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/affiliation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type affiliationRepoStub struct {
+	items  map[string]*affiliation.Affiliation
+	nextID int
+}
+
+func newAffiliationRepoStub() *affiliationRepoStub {
+	return &affiliationRepoStub{items: map[string]*affiliation.Affiliation{}}
+}
+
+func (r *affiliationRepoStub) Save(_ context.Context, a *affiliation.Affiliation) error {
+	r.nextID++
+	id, _ := affiliation.NewAffiliationID(fmt.Sprintf("aff-%d", r.nextID))
+	a.SetID(id)
+	r.items[id.Value()] = a
+	return nil
+}
+
+func (r *affiliationRepoStub) FindByID(_ context.Context, id affiliation.AffiliationID) (*affiliation.Affiliation, error) {
+	a, ok := r.items[id.Value()]
+	if !ok {
+		return nil, errors.New("所属履歴が見つかりません")
+	}
+	return clone(a), nil
+}
+
+// clone は永続化層と同様に保存済みの状態を別インスタンスとして返す
+func clone(a *affiliation.Affiliation) *affiliation.Affiliation {
+	return affiliation.Reconstruct(a.ID(), a.TalentType(), a.TalentID(), a.AgencyID(), a.ContractType(),
+		a.JoinedAt(), a.LeftAt(), a.Sources(), a.CreatedAt(), a.UpdatedAt())
+}
+
+func (r *affiliationRepoStub) FindByTalent(_ context.Context, talentType affiliation.TalentType, talentID string) ([]*affiliation.Affiliation, error) {
+	var result []*affiliation.Affiliation
+	for _, a := range r.items {
+		if a.TalentType() == talentType && a.TalentID() == talentID {
+			result = append(result, clone(a))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID().Value() < result[j].ID().Value() })
+	return result, nil
+}
+
+func (r *affiliationRepoStub) FindByAgencyID(_ context.Context, agencyID string, at *time.Time) ([]*affiliation.Affiliation, error) {
+	var result []*affiliation.Affiliation
+	for _, a := range r.items {
+		if a.AgencyID() == agencyID && (at == nil || a.ActiveAt(*at)) {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+func (r *affiliationRepoStub) Update(_ context.Context, a *affiliation.Affiliation) error {
+	r.items[a.ID().Value()] = clone(a)
+	return nil
+}
+
+func (r *affiliationRepoStub) Delete(_ context.Context, id affiliation.AffiliationID) error {
+	delete(r.items, id.Value())
+	return nil
+}
+
+// agencySyncerStub は同期された agency_id をタレントごとに記録する
+type agencySyncerStub struct {
+	agencies map[string]*string
+}
+
+func (s *agencySyncerStub) SyncAgency(_ context.Context, talentType affiliation.TalentType, talentID string, agencyID *string) error {
+	if s.agencies == nil {
+		s.agencies = map[string]*string{}
+	}
+	s.agencies[talentType.String()+"/"+talentID] = agencyID
+	return nil
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestApplicationService_TransferRecordsDatedAffiliations(t *testing.T) {
+	t.Parallel()
+
+	svc := NewApplicationService(newAffiliationRepoStub(), &agencySyncerStub{})
+	ctx := context.Background()
+
+	first, err := svc.CreateAffiliation(ctx, CreateInput{TalentType: "idol", TalentID: "idol-1", AgencyID: "agency-a", JoinedAt: strPtr("2016-04-01")})
+	require.NoError(t, err)
+	assert.Equal(t, affiliation.ContractExclusive, first.ContractType())
+
+	require.NoError(t, svc.UpdateAffiliation(ctx, UpdateInput{ID: first.ID().Value(), LeftAt: strPtr("2021-03-31")}))
+	_, err = svc.CreateAffiliation(ctx, CreateInput{TalentType: "idol", TalentID: "idol-1", AgencyID: "agency-b", ContractType: strPtr("partnership"), JoinedAt: strPtr("2021-04-01")})
+	require.NoError(t, err)
+
+	history, err := svc.ListByTalent(ctx, "idol", "idol-1")
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	at := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	talents, err := svc.ListByAgency(ctx, "agency-a", &at)
+	require.NoError(t, err)
+	assert.Len(t, talents, 1)
+
+	at = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	talents, err = svc.ListByAgency(ctx, "agency-a", &at)
+	require.NoError(t, err)
+	assert.Empty(t, talents)
+}
+
+func TestApplicationService_SyncsTalentAgencyWithCurrentAffiliation(t *testing.T) {
+	t.Parallel()
+
+	syncer := &agencySyncerStub{}
+	svc := NewApplicationService(newAffiliationRepoStub(), syncer)
+	ctx := context.Background()
+
+	first, err := svc.CreateAffiliation(ctx, CreateInput{TalentType: "idol", TalentID: "idol-1", AgencyID: "agency-a", JoinedAt: strPtr("2016-04-01")})
+	require.NoError(t, err)
+	assert.Equal(t, strPtr("agency-a"), syncer.agencies["idol/idol-1"])
+
+	require.NoError(t, svc.UpdateAffiliation(ctx, UpdateInput{ID: first.ID().Value(), LeftAt: strPtr("2021-03-31")}))
+	assert.Nil(t, syncer.agencies["idol/idol-1"])
+
+	second, err := svc.CreateAffiliation(ctx, CreateInput{TalentType: "idol", TalentID: "idol-1", AgencyID: "agency-b", JoinedAt: strPtr("2021-04-01")})
+	require.NoError(t, err)
+	assert.Equal(t, strPtr("agency-b"), syncer.agencies["idol/idol-1"])
+
+	require.NoError(t, svc.DeleteAffiliation(ctx, second.ID().Value()))
+	assert.Nil(t, syncer.agencies["idol/idol-1"])
+}
+
+func TestApplicationService_RejectsOverlappingAffiliation(t *testing.T) {
+	t.Parallel()
+
+	svc := NewApplicationService(newAffiliationRepoStub(), &agencySyncerStub{})
+	ctx := context.Background()
+
+	first, err := svc.CreateAffiliation(ctx, CreateInput{TalentType: "group", TalentID: "group-1", AgencyID: "agency-a", JoinedAt: strPtr("2016-04-01"), LeftAt: strPtr("2020-04-01")})
+	require.NoError(t, err)
+
+	_, err = svc.CreateAffiliation(ctx, CreateInput{TalentType: "group", TalentID: "group-1", AgencyID: "agency-a", JoinedAt: strPtr("2019-04-01")})
+	assert.ErrorContains(t, err, "既に登録されています")
+
+	second, err := svc.CreateAffiliation(ctx, CreateInput{TalentType: "group", TalentID: "group-1", AgencyID: "agency-a", JoinedAt: strPtr("2020-04-01")})
+	require.NoError(t, err)
+
+	// 退所日を解除すると後の所属期間と重なる
+	err = svc.UpdateAffiliation(ctx, UpdateInput{ID: first.ID().Value(), LeftAt: strPtr("")})
+	assert.ErrorContains(t, err, "既に登録されています")
+
+	// 自分自身とは重複扱いしない
+	require.NoError(t, svc.UpdateAffiliation(ctx, UpdateInput{ID: second.ID().Value(), ContractType: strPtr("trainee")}))
+
+	_, err = svc.CreateAffiliation(ctx, CreateInput{TalentType: "agency", TalentID: "x", AgencyID: "agency-a"})
+	assert.ErrorContains(t, err, "無効なタレント種別")
+	_, err = svc.CreateAffiliation(ctx, CreateInput{TalentType: "idol", TalentID: "idol-9", AgencyID: "agency-a", JoinedAt: strPtr("2020/04/01")})
+	assert.ErrorContains(t, err, "形式が不正")
+}
@@ -4,6 +4,7 @@ package agency
 type CreateInput struct {
 	Name            string
 	NameEn          *string
+	NameKana        *string
 	FoundedDate     *string
 	Country         string
 	OfficialWebsite *string
@@ -16,6 +17,7 @@ type UpdateInput struct {
 	ID              string
 	Name            *string
 	NameEn          *string
+	NameKana        *string
 	FoundedDate     *string
 	OfficialWebsite *string
 	Description     *string
@@ -69,6 +69,10 @@ func (s *ApplicationService) CreateAgency(ctx context.Context, input CreateInput
 		newAgency.UpdateDetails(nil, input.NameEn, nil, input.OfficialWebsite, input.Description, input.LogoURL)
 	}
 
+	if err := newAgency.ChangeNameKana(input.NameKana); err != nil {
+		return nil, fmt.Errorf("読み仮名の生成エラー: %w", err)
+	}
+
 	// 保存
 	if err := s.repository.Save(ctx, newAgency); err != nil {
 		return nil, fmt.Errorf("事務所の保存エラー: %w", err)
@@ -156,6 +160,11 @@ func (s *ApplicationService) UpdateAgency(ctx context.Context, input UpdateInput
 
 	// 更新
 	existingAgency.UpdateDetails(newName, input.NameEn, foundedDate, input.OfficialWebsite, input.Description, input.LogoURL)
+	if input.NameKana != nil {
+		if err := existingAgency.ChangeNameKana(input.NameKana); err != nil {
+			return fmt.Errorf("読み仮名の生成エラー: %w", err)
+		}
+	}
 
 	// 保存
 	if err := s.repository.Update(ctx, existingAgency); err != nil {
@@ -215,6 +224,9 @@ func agencyWebhookPayload(entity *agency.Agency) map[string]interface{} {
 	if entity.NameEn() != nil {
 		payload["name_en"] = *entity.NameEn()
 	}
+	if entity.NameKana() != nil {
+		payload["name_kana"] = *entity.NameKana()
+	}
 	if entity.FoundedDate() != nil {
 		payload["founded_date"] = entity.FoundedDate().Format("2006-01-02")
 	}
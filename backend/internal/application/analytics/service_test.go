@@ -39,6 +39,14 @@ func (m *MockUsageRepository) AggregateByKey(ctx context.Context, from, to time.
 	return args.Get(0).([]*domainAnalytics.KeyUsageSummary), args.Error(1)
 }
 
+func (m *MockUsageRepository) AggregateByKeyPrefix(ctx context.Context, keyPrefix string, from, to time.Time, topEndpoints int) (*domainAnalytics.KeyUsageDetail, error) {
+	args := m.Called(ctx, keyPrefix, from, to, topEndpoints)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainAnalytics.KeyUsageDetail), args.Error(1)
+}
+
 func TestApplicationService_RecordUsage(t *testing.T) {
 	t.Run("保存成功時は非ブロッキングで記録される", func(t *testing.T) {
 		repo := new(MockUsageRepository)
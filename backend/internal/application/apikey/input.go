@@ -11,3 +11,11 @@ type CreateKeyInput struct {
 type RevokeKeyInput struct {
 	ID string // MongoDB ObjectID hex
 }
+
+// SetOverageInput は従量課金での超過利用設定の入力
+type SetOverageInput struct {
+	Email   string // キー所有者のメールアドレス（所有確認に使う）
+	KeyID   string
+	Enabled bool
+	HardCap int // 超過分を含む月間リクエスト総数の上限（0はシステム既定値）
+}
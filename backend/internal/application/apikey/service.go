@@ -16,9 +16,16 @@ type CreateKeyOutput struct {
 	Key    *domainapikey.APIKey
 }
 
+// OverageBilling は従量課金の超過分を請求できる状態にする契約
+type OverageBilling interface {
+	// EnsureOverageBilling はキーのサブスクリプションに従量課金の price を用意する（用意できなければエラー）
+	EnsureOverageBilling(ctx context.Context, apiKeyID string) error
+}
+
 // ApplicationService はAPIキー管理のアプリケーションサービス
 type ApplicationService struct {
-	repo domainapikey.Repository
+	repo           domainapikey.Repository
+	overageBilling OverageBilling
 }
 
 // NewApplicationService はAPIキーアプリケーションサービスを作成する
@@ -26,6 +33,13 @@ func NewApplicationService(repo domainapikey.Repository) *ApplicationService {
 	return &ApplicationService{repo: repo}
 }
 
+// WithOverageBilling は従量課金へのオプトイン時に請求の準備を行う契約を設定する
+// 未設定の場合、超過分を請求できないためオプトインを受け付けない
+func (s *ApplicationService) WithOverageBilling(billing OverageBilling) *ApplicationService {
+	s.overageBilling = billing
+	return s
+}
+
 // CreateKey は新しいAPIキーを作成する
 // 生のキー文字列は出力に一度だけ含まれ、DBには保存されない
 func (s *ApplicationService) CreateKey(ctx context.Context, input CreateKeyInput) (*CreateKeyOutput, error) {
@@ -126,6 +140,57 @@ func (s *ApplicationService) UpdateKeyPlanAndStatus(ctx context.Context, id stri
 	return key, nil
 }
 
+// UpdateKeyPlan は既存の API キーのプラン種別を同期する。有効状態は変更しない。
+func (s *ApplicationService) UpdateKeyPlan(ctx context.Context, id string, planType string) (*domainapikey.APIKey, error) {
+	key, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("APIキーの取得に失敗しました: %w", err)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("APIキーが見つかりません: %s", id)
+	}
+
+	if err := key.ChangePlan(plan.Type(planType)); err != nil {
+		return nil, fmt.Errorf("APIキーのプラン更新に失敗しました: %w", err)
+	}
+	if err := s.repo.Update(ctx, key); err != nil {
+		return nil, fmt.Errorf("APIキーの更新に失敗しました: %w", err)
+	}
+	return key, nil
+}
+
+// SetOverage は所有者による従量課金での超過利用のオプトイン・オプトアウトを行う
+func (s *ApplicationService) SetOverage(ctx context.Context, input SetOverageInput) (*domainapikey.APIKey, error) {
+	key, err := s.repo.FindByID(ctx, input.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("APIキーの取得に失敗しました: %w", err)
+	}
+	// 他人のキーは存在を明かさない
+	if key == nil || key.Email() != input.Email {
+		return nil, fmt.Errorf("APIキーが見つかりません: %s", input.KeyID)
+	}
+
+	if input.Enabled {
+		if err := key.EnableOverage(input.HardCap); err != nil {
+			return nil, err
+		}
+		// 超過分の報告が請求されるよう、有効化を保存する前にサブスクリプションへ metered price を用意する
+		if s.overageBilling == nil {
+			return nil, fmt.Errorf("無効な操作です: 従量課金は現在利用できません")
+		}
+		if err := s.overageBilling.EnsureOverageBilling(ctx, key.ID()); err != nil {
+			return nil, err
+		}
+	} else {
+		key.DisableOverage()
+	}
+
+	if err := s.repo.Update(ctx, key); err != nil {
+		return nil, fmt.Errorf("APIキーの更新に失敗しました: %w", err)
+	}
+	return key, nil
+}
+
 func (s *ApplicationService) findExistingByRawKey(ctx context.Context, rawKey string) (*domainapikey.APIKey, error) {
 	candidates, err := s.repo.FindByPrefix(ctx, domainapikey.PrefixOf(rawKey))
 	if err != nil {
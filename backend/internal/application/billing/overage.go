@@ -0,0 +1,183 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	domainapikey "github.com/kuro48/idol-api/internal/domain/apikey"
+	domainbilling "github.com/kuro48/idol-api/internal/domain/billing"
+	domainusage "github.com/kuro48/idol-api/internal/domain/usage"
+)
+
+// DefaultOverageMeterEventName は従量課金の Billing Meter の既定イベント名。
+const DefaultOverageMeterEventName = "api_overage_requests"
+
+// OverageKeyFinder は使用量のキープレフィックスから API キーを引く契約。
+type OverageKeyFinder interface {
+	FindByPrefix(ctx context.Context, prefix string) ([]*domainapikey.APIKey, error)
+}
+
+// OverageReporter は usage コレクションに集計された超過リクエスト数を Stripe へ従量課金として報告する。
+// 報告範囲と識別子を原子的に確保・永続化してから送信し、送信失敗時は同じ範囲・識別子で再送するため、
+// 複数レプリカで同時に動かしたり送信結果が不明なまま失敗したりしても Stripe 側で二重計上されない。
+type OverageReporter struct {
+	keys         OverageKeyFinder
+	usageRepo    domainusage.Repository
+	fulfillments domainbilling.FulfillmentRepository
+	stripeClient StripeClient
+	eventName    string
+	now          func() time.Time
+	wg           sync.WaitGroup
+}
+
+// NewOverageReporter は OverageReporter を作成する。
+// eventName が空の場合は DefaultOverageMeterEventName を使う。
+func NewOverageReporter(
+	keys OverageKeyFinder,
+	usageRepo domainusage.Repository,
+	fulfillments domainbilling.FulfillmentRepository,
+	stripeClient StripeClient,
+	eventName string,
+) *OverageReporter {
+	if eventName == "" {
+		eventName = DefaultOverageMeterEventName
+	}
+	return &OverageReporter{
+		keys:         keys,
+		usageRepo:    usageRepo,
+		fulfillments: fulfillments,
+		stripeClient: stripeClient,
+		eventName:    eventName,
+		now:          time.Now,
+	}
+}
+
+// StartReportWorker は超過使用量を定期的に報告するバックグラウンドワーカーを起動する。
+// ctx がキャンセルされるとワーカーは停止し、Shutdown() の待機対象に含まれる。
+func (r *OverageReporter) StartReportWorker(ctx context.Context, interval time.Duration) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.ReportOverage(ctx); err != nil {
+					slog.Error("超過使用量の報告ワーカーエラー", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Shutdown は実行中の報告が完了するまで待機する。
+func (r *OverageReporter) Shutdown() {
+	r.wg.Wait()
+}
+
+// ReportOverage は前月・当月の未報告の超過リクエスト数を Stripe へ報告する。
+// 前月分は月替わり直前に発生した超過の取りこぼしを報告するために含める。
+func (r *OverageReporter) ReportOverage(ctx context.Context) error {
+	now := r.now().UTC()
+	currentMonth := domainusage.MonthStartOf(now)
+	previousMonth := currentMonth.AddDate(0, -1, 0)
+
+	var errs []error
+	for _, month := range []time.Time{previousMonth, currentMonth} {
+		yearMonth := domainusage.YearMonthOf(month)
+		overages, err := r.usageRepo.ListUnreportedOverages(ctx, yearMonth)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		// 前月分は当月の請求に紛れ込まないよう、前月末の時刻で報告する
+		timestamp := now
+		if monthEnd := month.AddDate(0, 1, 0).Add(-time.Second); monthEnd.Before(now) {
+			timestamp = monthEnd
+		}
+		for _, overage := range overages {
+			if err := r.reportOne(ctx, overage, timestamp); err != nil {
+				slog.Warn("超過使用量の報告に失敗しました",
+					"key_prefix", overage.KeyPrefix(),
+					"year_month", overage.YearMonth(),
+					"error", err,
+				)
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *OverageReporter) reportOne(ctx context.Context, overage *domainusage.OverageUsage, timestamp time.Time) error {
+	pending := overage.Pending()
+	if pending == nil && overage.Unreported() == 0 {
+		return nil
+	}
+
+	customerID, err := r.customerIDFor(ctx, overage.KeyPrefix())
+	if err != nil {
+		return err
+	}
+
+	// 前回送信が完了しなかった範囲は、確保時の識別子のまま再送する
+	if pending != nil {
+		if err := r.send(ctx, overage, customerID, *pending, timestamp); err != nil {
+			return err
+		}
+	}
+	if overage.Unreported() == 0 {
+		return nil
+	}
+
+	report := domainusage.NewPendingOverageReport(overage.KeyPrefix(), overage.YearMonth(), overage.Reported(), overage.Count())
+	claimed, err := r.usageRepo.ClaimOverageReport(ctx, overage.KeyPrefix(), overage.YearMonth(), report)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		// 他のレプリカが報告中・報告済み
+		return nil
+	}
+	return r.send(ctx, overage, customerID, report, timestamp)
+}
+
+// send は確保済みの報告範囲を Stripe へ送信し、成功したら送信完了を記録する。
+// 失敗時は範囲を送信未完了のまま残し、次回の報告で同じ識別子により再送する。
+func (r *OverageReporter) send(ctx context.Context, overage *domainusage.OverageUsage, customerID string, report domainusage.PendingOverageReport, timestamp time.Time) error {
+	if err := r.stripeClient.ReportMeteredUsage(ctx, MeteredUsageInput{
+		CustomerID: customerID,
+		EventName:  r.eventName,
+		Quantity:   report.Quantity(),
+		Timestamp:  timestamp,
+		Identifier: report.Identifier,
+	}); err != nil {
+		return err
+	}
+	return r.usageRepo.CompleteOverageReport(ctx, overage.KeyPrefix(), overage.YearMonth(), report.Identifier)
+}
+
+func (r *OverageReporter) customerIDFor(ctx context.Context, keyPrefix string) (string, error) {
+	keys, err := r.keys.FindByPrefix(ctx, keyPrefix)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range keys {
+		fulfillment, err := r.fulfillments.FindLatestByAPIKeyID(ctx, key.ID())
+		if err != nil {
+			return "", err
+		}
+		if fulfillment != nil && fulfillment.CustomerID() != "" {
+			return fulfillment.CustomerID(), nil
+		}
+	}
+	return "", fmt.Errorf("APIキーに紐づく Stripe 顧客が見つかりません: %s", keyPrefix)
+}
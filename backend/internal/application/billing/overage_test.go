@@ -0,0 +1,221 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainapikey "github.com/kuro48/idol-api/internal/domain/apikey"
+	domainbilling "github.com/kuro48/idol-api/internal/domain/billing"
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	domainusage "github.com/kuro48/idol-api/internal/domain/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOverageUsageRepo struct {
+	domainusage.Repository
+	counts   map[string]int
+	reported map[string]int
+	pending  map[string]*domainusage.PendingOverageReport
+}
+
+func newFakeOverageUsageRepo() *fakeOverageUsageRepo {
+	return &fakeOverageUsageRepo{
+		counts:   make(map[string]int),
+		reported: make(map[string]int),
+		pending:  make(map[string]*domainusage.PendingOverageReport),
+	}
+}
+
+func (f *fakeOverageUsageRepo) ListUnreportedOverages(_ context.Context, yearMonth string) ([]*domainusage.OverageUsage, error) {
+	var result []*domainusage.OverageUsage
+	for id, count := range f.counts {
+		prefix, month := id[:len(id)-8], id[len(id)-7:]
+		if month == yearMonth && (count > f.reported[id] || f.pending[id] != nil) {
+			result = append(result, domainusage.ReconstructOverage(prefix, month, count, f.reported[id], f.pending[id]))
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeOverageUsageRepo) ClaimOverageReport(_ context.Context, keyPrefix, yearMonth string, report domainusage.PendingOverageReport) (bool, error) {
+	id := keyPrefix + ":" + yearMonth
+	if f.reported[id] != report.From || f.pending[id] != nil {
+		return false, nil
+	}
+	f.reported[id] = report.To
+	f.pending[id] = &report
+	return true, nil
+}
+
+func (f *fakeOverageUsageRepo) CompleteOverageReport(_ context.Context, keyPrefix, yearMonth, identifier string) error {
+	id := keyPrefix + ":" + yearMonth
+	if pending := f.pending[id]; pending != nil && pending.Identifier == identifier {
+		delete(f.pending, id)
+	}
+	return nil
+}
+
+type fakeOverageKeyFinder struct {
+	keys []*domainapikey.APIKey
+}
+
+func (f *fakeOverageKeyFinder) FindByPrefix(_ context.Context, prefix string) ([]*domainapikey.APIKey, error) {
+	var result []*domainapikey.APIKey
+	for _, key := range f.keys {
+		if key.Prefix() == prefix {
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+func newOverageFixture(t *testing.T) (*fakeOverageKeyFinder, *fakeFulfillmentRepo) {
+	t.Helper()
+	key, err := domainapikey.Reconstruct(
+		"507f1f77bcf86cd799439099",
+		"idol_abc",
+		"hash",
+		"idol_abc****",
+		"biz@example.com",
+		"Business",
+		plan.TypeBusiness,
+		true,
+		domainapikey.Overage{Enabled: true},
+		mustTime(),
+	)
+	require.NoError(t, err)
+
+	fulfillments := newFakeFulfillmentRepo()
+	require.NoError(t, fulfillments.Save(context.Background(), domainbilling.NewCheckoutFulfillment(
+		"cs_biz", "cus_biz", "biz@example.com", "Business", plan.TypeBusiness, key.ID(),
+	)))
+	return &fakeOverageKeyFinder{keys: []*domainapikey.APIKey{key}}, fulfillments
+}
+
+func TestReportOverage_ReportsUnreportedDeltaOnce(t *testing.T) {
+	t.Parallel()
+
+	keys, fulfillments := newOverageFixture(t)
+	usageRepo := newFakeOverageUsageRepo()
+	usageRepo.counts["idol_abc:2026-10"] = 120
+	usageRepo.reported["idol_abc:2026-10"] = 20
+	stripeClient := &fakeStripeClient{}
+
+	reporter := NewOverageReporter(keys, usageRepo, fulfillments, stripeClient, "")
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	reporter.now = func() time.Time { return now }
+
+	require.NoError(t, reporter.ReportOverage(context.Background()))
+	require.NoError(t, reporter.ReportOverage(context.Background()))
+
+	require.Len(t, stripeClient.usageInputs, 1)
+	input := stripeClient.usageInputs[0]
+	assert.Equal(t, "cus_biz", input.CustomerID)
+	assert.Equal(t, DefaultOverageMeterEventName, input.EventName)
+	assert.Equal(t, 100, input.Quantity)
+	assert.Equal(t, now, input.Timestamp)
+	assert.Equal(t, "overage_idol_abc_2026-10_20_120", input.Identifier)
+	assert.Equal(t, 120, usageRepo.reported["idol_abc:2026-10"])
+	assert.Nil(t, usageRepo.pending["idol_abc:2026-10"])
+}
+
+func TestReportOverage_ReportsPreviousMonthAtMonthEnd(t *testing.T) {
+	t.Parallel()
+
+	keys, fulfillments := newOverageFixture(t)
+	usageRepo := newFakeOverageUsageRepo()
+	usageRepo.counts["idol_abc:2026-09"] = 5
+	stripeClient := &fakeStripeClient{}
+
+	reporter := NewOverageReporter(keys, usageRepo, fulfillments, stripeClient, "overage")
+	reporter.now = func() time.Time { return time.Date(2026, 10, 1, 0, 30, 0, 0, time.UTC) }
+
+	require.NoError(t, reporter.ReportOverage(context.Background()))
+
+	require.Len(t, stripeClient.usageInputs, 1)
+	assert.Equal(t, time.Date(2026, 9, 30, 23, 59, 59, 0, time.UTC), stripeClient.usageInputs[0].Timestamp)
+	assert.Equal(t, 5, stripeClient.usageInputs[0].Quantity)
+}
+
+func TestReportOverage_RetriesPendingRangeWithSameIdentifier(t *testing.T) {
+	t.Parallel()
+
+	keys, fulfillments := newOverageFixture(t)
+	usageRepo := newFakeOverageUsageRepo()
+	usageRepo.counts["idol_abc:2026-10"] = 10
+	stripeClient := &fakeStripeClient{usageErr: errors.New("stripe down")}
+
+	reporter := NewOverageReporter(keys, usageRepo, fulfillments, stripeClient, "")
+	reporter.now = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) }
+
+	require.Error(t, reporter.ReportOverage(context.Background()))
+	pending := usageRepo.pending["idol_abc:2026-10"]
+	require.NotNil(t, pending)
+	assert.Equal(t, "overage_idol_abc_2026-10_0_10", pending.Identifier)
+
+	// 送信失敗後に増えた超過分は、送信未完了の範囲とは別の識別子で報告する
+	usageRepo.counts["idol_abc:2026-10"] = 15
+	stripeClient.usageErr = nil
+	require.NoError(t, reporter.ReportOverage(context.Background()))
+
+	require.Len(t, stripeClient.usageInputs, 2)
+	assert.Equal(t, "overage_idol_abc_2026-10_0_10", stripeClient.usageInputs[0].Identifier)
+	assert.Equal(t, 10, stripeClient.usageInputs[0].Quantity)
+	assert.Equal(t, "overage_idol_abc_2026-10_10_15", stripeClient.usageInputs[1].Identifier)
+	assert.Equal(t, 5, stripeClient.usageInputs[1].Quantity)
+	assert.Equal(t, 15, usageRepo.reported["idol_abc:2026-10"])
+	assert.Nil(t, usageRepo.pending["idol_abc:2026-10"])
+}
+
+func TestEnsureOverageBilling(t *testing.T) {
+	t.Parallel()
+
+	const keyID = "507f1f77bcf86cd799439099"
+	newService := func(stripeClient *fakeStripeClient, repo *fakeFulfillmentRepo, overagePriceID string) *Service {
+		return NewService(stripeClient, repo, nil, &fakeAPIKeyIssuer{}, &fakeNotifier{}, Config{
+			PriceIDs:       map[plan.Type]string{plan.TypeBusiness: "price_biz"},
+			OveragePriceID: overagePriceID,
+		})
+	}
+
+	t.Run("有効なサブスクリプションに metered price を用意する", func(t *testing.T) {
+		t.Parallel()
+		stripeClient := &fakeStripeClient{}
+		repo := newFakeFulfillmentRepo()
+		fulfillment := domainbilling.NewCheckoutFulfillment("cs_biz", "cus_biz", "biz@example.com", "Biz", plan.TypeBusiness, keyID)
+		fulfillment.LinkSubscription("sub_biz")
+		require.NoError(t, repo.Save(context.Background(), fulfillment))
+
+		require.NoError(t, newService(stripeClient, repo, "price_overage").EnsureOverageBilling(context.Background(), keyID))
+		assert.Equal(t, []EnsureSubscriptionItemInput{{SubscriptionID: "sub_biz", PriceID: "price_overage"}}, stripeClient.itemInputs)
+	})
+
+	t.Run("サブスクリプションがない・特定できない・解約済み・price 未設定なら拒否する", func(t *testing.T) {
+		t.Parallel()
+		stripeClient := &fakeStripeClient{}
+		repo := newFakeFulfillmentRepo()
+
+		require.Error(t, newService(stripeClient, repo, "price_overage").EnsureOverageBilling(context.Background(), keyID))
+
+		// Subscription ID を記録する前の fulfillment では同じ顧客の別のサブスクリプションに追加しない
+		require.NoError(t, repo.Save(context.Background(), domainbilling.NewCheckoutFulfillment(
+			"cs_legacy", "cus_biz", "biz@example.com", "Biz", plan.TypeBusiness, keyID,
+		)))
+		require.Error(t, newService(stripeClient, repo, "price_overage").EnsureOverageBilling(context.Background(), keyID))
+
+		canceled := domainbilling.NewCheckoutFulfillment("cs_biz", "cus_biz", "biz@example.com", "Biz", plan.TypeBusiness, keyID)
+		canceled.LinkSubscription("sub_biz")
+		canceled.Cancel(time.Now())
+		require.NoError(t, repo.Save(context.Background(), canceled))
+		require.Error(t, newService(stripeClient, repo, "price_overage").EnsureOverageBilling(context.Background(), keyID))
+
+		active := domainbilling.NewCheckoutFulfillment("cs_biz2", "cus_biz", "biz@example.com", "Biz", plan.TypeBusiness, keyID)
+		active.LinkSubscription("sub_biz2")
+		require.NoError(t, repo.Save(context.Background(), active))
+		require.Error(t, newService(stripeClient, repo, "").EnsureOverageBilling(context.Background(), keyID))
+		assert.Empty(t, stripeClient.itemInputs)
+	})
+}
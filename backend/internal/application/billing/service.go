@@ -2,7 +2,10 @@ package billing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	appAPIKey "github.com/kuro48/idol-api/internal/application/apikey"
 	domainapikey "github.com/kuro48/idol-api/internal/domain/apikey"
@@ -19,11 +22,33 @@ const (
 	WebhookEventTypeInvoicePaid              = "invoice.paid"
 )
 
+// stripeEventProcessingLease は処理中のまま残ったイベントを別の配信で引き継ぐまでの時間。
+const stripeEventProcessingLease = 5 * time.Minute
+
+// maxFulfillmentUpdateAttempts は並行する更新と競合したときにイベントを読み直して適用し直す回数の上限。
+const maxFulfillmentUpdateAttempts = 3
+
+// errStaleEvent は同じ fulfillment により新しいイベントが適用済みのため、イベントを適用しなかったことを表す。
+var errStaleEvent = errors.New("同じ fulfillment のより新しいイベントが適用済みです")
+
+// DefaultPaymentGracePeriod は支払い失敗から降格までの既定の猶予期間。
+const DefaultPaymentGracePeriod = 7 * 24 * time.Hour
+
 // Config は billing サービスの設定。
 type Config struct {
 	StripeSigningSecret string
 	KeySeedSecret       string
 	PriceIDs            map[plan.Type]string
+	// UpgradeSuccessURL / UpgradeCancelURL は使用量通知に含めるアップグレード用リンクの戻り先。
+	// Customer Portal の戻り先には UpgradeSuccessURL を使う。どちらかが空の場合はアップグレードリンクを生成しない。
+	UpgradeSuccessURL string
+	UpgradeCancelURL  string
+	// OveragePriceID は超過リクエストの Billing Meter に紐づく metered price の ID。
+	// 空の場合は従量課金へのオプトインを受け付けない。
+	OveragePriceID string
+	// PaymentGracePeriod は最初の支払い失敗から free プランへ降格するまでの猶予期間。
+	// 0 以下の場合は DefaultPaymentGracePeriod を使う。
+	PaymentGracePeriod time.Duration
 }
 
 // CheckoutSession は Stripe Checkout Session の最小表現。
@@ -34,32 +59,68 @@ type CheckoutSession struct {
 
 // CheckoutSessionCompleted は fulfillment に必要な Checkout 完了情報。
 type CheckoutSessionCompleted struct {
-	SessionID  string
-	CustomerID string
-	Email      string
-	Name       string
-	PlanType   plan.Type
+	SessionID      string
+	CustomerID     string
+	SubscriptionID string // Checkout で作成された Stripe Subscription ID
+	Email          string
+	Name           string
+	PlanType       plan.Type
+	APIKeyID       string // 既存キーのアップグレードとして作成した Session の場合のみ
 }
 
 // WebhookEvent は Stripe Webhook の最小表現。
 type WebhookEvent struct {
+	ID              string
 	Type            string
+	Created         time.Time // Stripe 側のイベント発生時刻
 	CheckoutSession *CheckoutSessionCompleted
 	Subscription    *SubscriptionUpdated
 	Invoice         *InvoiceUpdated
 }
 
+// CustomerID はイベントが対象とする Stripe customer ID を返す。
+func (e *WebhookEvent) CustomerID() string {
+	switch {
+	case e.CheckoutSession != nil:
+		return e.CheckoutSession.CustomerID
+	case e.Subscription != nil:
+		return e.Subscription.CustomerID
+	case e.Invoice != nil:
+		return e.Invoice.CustomerID
+	default:
+		return ""
+	}
+}
+
+// ObjectID はイベントが対象とする Stripe オブジェクト（session / subscription / invoice）の ID を返す。
+func (e *WebhookEvent) ObjectID() string {
+	switch {
+	case e.CheckoutSession != nil:
+		return e.CheckoutSession.SessionID
+	case e.Subscription != nil:
+		return e.Subscription.ID
+	case e.Invoice != nil:
+		return e.Invoice.ID
+	default:
+		return ""
+	}
+}
+
 // SubscriptionUpdated は subscription 更新時の最小情報。
 type SubscriptionUpdated struct {
+	ID         string // Stripe Subscription ID
 	CustomerID string
 	PriceID    string
+	PriceIDs   []string // Subscription の全 item の price ID（従量課金の metered price を含む）
 	Status     string
 }
 
 // InvoiceUpdated は invoice 更新時の最小情報。
 type InvoiceUpdated struct {
-	CustomerID string
-	Paid       bool
+	ID             string // Stripe Invoice ID
+	CustomerID     string
+	SubscriptionID string // 請求対象の Stripe Subscription ID（サブスクリプション以外の請求では空）
+	Paid           bool
 }
 
 // PortalSession は Stripe Customer Portal Session の最小表現。
@@ -75,6 +136,25 @@ type CreateCheckoutSessionInput struct {
 	SuccessURL string
 	CancelURL  string
 	PriceID    string
+	// APIKeyID は既存キーのアップグレードの場合に指定する。完了時は新規キーを発行せず、このキーのプランを変更する。
+	APIKeyID string
+	// CustomerID は既存の Stripe 顧客で購入させる場合に指定する（空なら Email から新規作成）。
+	CustomerID string
+}
+
+// MeteredUsageInput は従量課金の使用量報告入力。
+type MeteredUsageInput struct {
+	CustomerID string
+	EventName  string
+	Quantity   int
+	Timestamp  time.Time
+	Identifier string // 冪等性キー（同じ値の再送は Stripe 側で重複排除される）
+}
+
+// EnsureSubscriptionItemInput はサブスクリプションへの price 追加入力。
+type EnsureSubscriptionItemInput struct {
+	SubscriptionID string
+	PriceID        string
 }
 
 // CreatePortalSessionInput は Stripe Portal Session 作成入力。
@@ -108,47 +188,75 @@ type APIKeyIssuedNotification struct {
 	RawKey   string
 }
 
+// SubscriptionChangedNotification はサブスクリプション状態遷移の顧客向け通知。
+type SubscriptionChangedNotification struct {
+	To            string
+	Name          string
+	Transition    domainbilling.TransitionType
+	FromPlan      string
+	ToPlan        string
+	GraceDeadline *time.Time // 支払い失敗（猶予期間中）の場合のみ
+	OccurredAt    time.Time
+}
+
 // StripeClient は Stripe とのやり取りを抽象化する。
 type StripeClient interface {
 	CreateCheckoutSession(ctx context.Context, input CreateCheckoutSessionInput) (*CheckoutSession, error)
 	VerifyWebhookEvent(payload []byte, signature string) (*WebhookEvent, error)
+	// ParseWebhookEvent は署名検証なしでペイロードを解析する（台帳からの再実行用）。
+	ParseWebhookEvent(payload []byte) (*WebhookEvent, error)
+	// ReportMeteredUsage は従量課金の使用量を報告する。
+	ReportMeteredUsage(ctx context.Context, input MeteredUsageInput) error
+	// EnsureSubscriptionItem は指定したサブスクリプションが有効で price の item がなければ追加する。
+	EnsureSubscriptionItem(ctx context.Context, input EnsureSubscriptionItemInput) error
 	CreatePortalSession(ctx context.Context, input CreatePortalSessionInput) (*PortalSession, error)
 }
 
 // APIKeyIssuer は決済後に API キーを発行する契約。
 type APIKeyIssuer interface {
 	CreateOrGetKeyWithRawKey(ctx context.Context, input appAPIKey.CreateKeyInput, rawKey string) (*appAPIKey.CreateKeyOutput, error)
-	UpdateKeyPlanAndStatus(ctx context.Context, id string, planType string, active bool) (*domainapikey.APIKey, error)
+	// UpdateKeyPlan はプラン種別のみを同期する。所有者・管理者による無効化は維持する。
+	UpdateKeyPlan(ctx context.Context, id string, planType string) (*domainapikey.APIKey, error)
 }
 
-// Notifier は API キー発行通知を送る契約。
+// Notifier は API キー発行・サブスクリプション状態遷移の通知を送る契約。
 type Notifier interface {
 	NotifyAPIKeyIssued(ctx context.Context, notification APIKeyIssuedNotification) error
+	NotifySubscriptionChanged(ctx context.Context, notification SubscriptionChangedNotification) error
 }
 
 // Service は Stripe 課金導線を提供する。
 type Service struct {
 	stripeClient StripeClient
 	repo         domainbilling.FulfillmentRepository
+	eventLedger  domainbilling.StripeEventRepository
 	apiKeyIssuer APIKeyIssuer
 	notifier     Notifier
 	cfg          Config
+	now          func() time.Time
 }
 
 // NewService は billing サービスを作成する。
+// eventLedger が nil の場合は Webhook の重複排除を行わない（発生順の制御は fulfillment で行う）。
 func NewService(
 	stripeClient StripeClient,
 	repo domainbilling.FulfillmentRepository,
+	eventLedger domainbilling.StripeEventRepository,
 	apiKeyIssuer APIKeyIssuer,
 	notifier Notifier,
 	cfg Config,
 ) *Service {
+	if cfg.PaymentGracePeriod <= 0 {
+		cfg.PaymentGracePeriod = DefaultPaymentGracePeriod
+	}
 	return &Service{
 		stripeClient: stripeClient,
 		repo:         repo,
+		eventLedger:  eventLedger,
 		apiKeyIssuer: apiKeyIssuer,
 		notifier:     notifier,
 		cfg:          cfg,
+		now:          time.Now,
 	}
 }
 
@@ -174,7 +282,80 @@ func (s *Service) CreateCheckoutSession(ctx context.Context, input CreateCheckou
 	return &CreateCheckoutSessionResult{ID: session.ID, URL: session.URL}, nil
 }
 
+// CreateUpgradeURL は API キーを現在のプランの1段上へアップグレードするための URL を返す。
+// 解約されていない有料サブスクリプションがあるキーは Customer Portal でプランを変更させ、
+// それ以外はキー ID を指定した Checkout Session を作成し、完了時に同じキーのプランを変更する。
+// Stripe 未設定・戻り先URL未設定・最上位プランの場合は空文字を返す。
+func (s *Service) CreateUpgradeURL(ctx context.Context, key *domainapikey.APIKey) (string, error) {
+	if s.stripeClient == nil || s.cfg.UpgradeSuccessURL == "" || s.cfg.UpgradeCancelURL == "" {
+		return "", nil
+	}
+	target, ok := plan.UpgradeTarget(key.PlanType())
+	if !ok {
+		return "", nil
+	}
+
+	fulfillment, err := s.repo.FindLatestByAPIKeyID(ctx, key.ID())
+	if err != nil {
+		return "", err
+	}
+	if fulfillment != nil && fulfillment.DowngradeReason() != domainbilling.DowngradeReasonCanceled {
+		// 新しい Checkout では2つ目のサブスクリプションが作られるため、既存のサブスクリプションを変更させる
+		session, err := s.stripeClient.CreatePortalSession(ctx, CreatePortalSessionInput{
+			CustomerID: fulfillment.CustomerID(),
+			ReturnURL:  s.cfg.UpgradeSuccessURL,
+		})
+		if err != nil {
+			return "", err
+		}
+		return session.URL, nil
+	}
+
+	input := CreateCheckoutSessionInput{
+		Email:      key.Email(),
+		Name:       key.Name(),
+		PlanType:   string(target),
+		SuccessURL: s.cfg.UpgradeSuccessURL,
+		CancelURL:  s.cfg.UpgradeCancelURL,
+		APIKeyID:   key.ID(),
+	}
+	if fulfillment != nil {
+		input.CustomerID = fulfillment.CustomerID()
+	}
+	result, err := s.CreateCheckoutSession(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return result.URL, nil
+}
+
+// EnsureOverageBilling は API キーを購入した Stripe サブスクリプションに従量課金の metered price を追加する。
+// 追加済みなら何もしない。metered price が未設定の場合や、解約・降格されていない
+// サブスクリプションがない・特定できない場合は、報告した超過分が請求されないためエラーを返す。
+func (s *Service) EnsureOverageBilling(ctx context.Context, apiKeyID string) error {
+	if s.stripeClient == nil || s.cfg.OveragePriceID == "" {
+		return fmt.Errorf("無効な操作です: 従量課金は現在利用できません")
+	}
+	fulfillment, err := s.repo.FindLatestByAPIKeyID(ctx, apiKeyID)
+	if err != nil {
+		return err
+	}
+	if fulfillment == nil || fulfillment.Downgraded() {
+		return fmt.Errorf("無効な操作です: 従量課金には有効な Business サブスクリプションが必要です")
+	}
+	if fulfillment.SubscriptionID() == "" {
+		// 同じ顧客の別のサブスクリプションに請求しないよう、キーのサブスクリプションが分かるまで受け付けない
+		return fmt.Errorf("無効な操作です: API キーの Stripe サブスクリプションを特定できません")
+	}
+	return s.stripeClient.EnsureSubscriptionItem(ctx, EnsureSubscriptionItemInput{
+		SubscriptionID: fulfillment.SubscriptionID(),
+		PriceID:        s.cfg.OveragePriceID,
+	})
+}
+
 // HandleStripeWebhook は Stripe Webhook を処理する。
+// 台帳が設定されている場合は処理済みイベントの再送を無視する。
+// 同じ fulfillment により新しいイベントが適用済みであれば古いイベントはスキップする。
 func (s *Service) HandleStripeWebhook(ctx context.Context, payload []byte, signature string) error {
 	event, err := s.stripeClient.VerifyWebhookEvent(payload, signature)
 	if err != nil {
@@ -183,7 +364,95 @@ func (s *Service) HandleStripeWebhook(ctx context.Context, payload []byte, signa
 	if event == nil {
 		return nil
 	}
+	if s.eventLedger == nil || event.ID == "" {
+		if err := s.dispatchWebhookEvent(ctx, event); !errors.Is(err, errStaleEvent) {
+			return err
+		}
+		return nil
+	}
+
+	entry := domainbilling.NewStripeEvent(event.ID, event.Type, event.CustomerID(), event.ObjectID(), event.Created, payload)
+	_, err = s.processLedgerEvent(ctx, entry, event)
+	return err
+}
+
+// ListStripeEvents は Stripe Webhook イベント台帳を一覧取得する。
+func (s *Service) ListStripeEvents(ctx context.Context, status string, limit, offset int) ([]*domainbilling.StripeEvent, int64, error) {
+	if s.eventLedger == nil {
+		return nil, 0, fmt.Errorf("Stripeイベント台帳が未設定です")
+	}
+	st := domainbilling.StripeEventStatus(status)
+	if st != "" && !domainbilling.IsValidStripeEventStatus(st) {
+		return nil, 0, fmt.Errorf("無効なステータスです: %s", status)
+	}
+	return s.eventLedger.List(ctx, st, limit, offset)
+}
+
+// ReplayStripeEvent は失敗した Stripe Webhook イベントを保存済みペイロードから再実行する。
+func (s *Service) ReplayStripeEvent(ctx context.Context, eventID string) (*domainbilling.StripeEvent, error) {
+	if s.eventLedger == nil {
+		return nil, fmt.Errorf("Stripeイベント台帳が未設定です")
+	}
+	entry, err := s.eventLedger.FindByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("Stripeイベントが見つかりません")
+	}
+	if !entry.Failed() {
+		return nil, fmt.Errorf("不正な操作です: 再実行できるのは失敗したイベントのみです（現在のステータス: %s）", entry.Status())
+	}
+
+	event, err := s.stripeClient.ParseWebhookEvent(entry.Payload())
+	if err != nil {
+		return nil, err
+	}
+	// 処理自体の失敗は台帳に記録済みのため、結果のエントリとして返す
+	result, err := s.processLedgerEvent(ctx, entry, event)
+	if result != nil {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("Stripeイベントは既に他の処理で再実行中です")
+}
+
+// processLedgerEvent は台帳でイベントを確保してから処理し、結果を記録する。
+// 確保できなかった（処理済み・他で処理中）場合は nil を返す。
+func (s *Service) processLedgerEvent(ctx context.Context, entry *domainbilling.StripeEvent, event *WebhookEvent) (*domainbilling.StripeEvent, error) {
+	now := s.now()
+	if err := entry.StartAttempt(now); err != nil {
+		return nil, err
+	}
+	claimed, err := s.eventLedger.Claim(ctx, entry, now.Add(-stripeEventProcessingLease))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	procErr := s.dispatchWebhookEvent(ctx, event)
+	if errors.Is(procErr, errStaleEvent) {
+		entry.MarkSkipped(procErr.Error(), s.now())
+		return entry, s.eventLedger.Update(ctx, entry)
+	}
+	if procErr != nil {
+		entry.MarkFailed(procErr.Error(), s.now())
+		if err := s.eventLedger.Update(ctx, entry); err != nil {
+			return entry, errors.Join(procErr, err)
+		}
+		return entry, procErr
+	}
+
+	entry.MarkSucceeded(s.now())
+	return entry, s.eventLedger.Update(ctx, entry)
+}
 
+// dispatchWebhookEvent はイベント種別ごとの処理へ振り分ける。
+func (s *Service) dispatchWebhookEvent(ctx context.Context, event *WebhookEvent) error {
 	switch event.Type {
 	case WebhookEventTypeCheckoutSessionCompleted:
 		if event.CheckoutSession == nil {
@@ -194,26 +463,22 @@ func (s *Service) HandleStripeWebhook(ctx context.Context, payload []byte, signa
 		if event.Subscription == nil {
 			return nil
 		}
-		return s.syncSubscriptionState(ctx, event.Subscription)
+		return s.handleSubscriptionUpdated(ctx, event)
 	case WebhookEventTypeSubscriptionDeleted:
 		if event.Subscription == nil {
 			return nil
 		}
-		return s.syncSubscriptionState(ctx, &SubscriptionUpdated{
-			CustomerID: event.Subscription.CustomerID,
-			PriceID:    event.Subscription.PriceID,
-			Status:     "canceled",
-		})
+		return s.handleSubscriptionDeleted(ctx, event)
 	case WebhookEventTypeInvoicePaymentFailed:
 		if event.Invoice == nil {
 			return nil
 		}
-		return s.syncKeyStatusByCustomer(ctx, event.Invoice.CustomerID, false)
+		return s.handleInvoicePaymentFailed(ctx, event)
 	case WebhookEventTypeInvoicePaid:
 		if event.Invoice == nil {
 			return nil
 		}
-		return s.syncKeyStatusByCustomer(ctx, event.Invoice.CustomerID, true)
+		return s.handleInvoicePaid(ctx, event)
 	}
 
 	return nil
@@ -241,6 +506,10 @@ func (s *Service) CreatePortalSession(ctx context.Context, input CreatePortalSes
 }
 
 func (s *Service) handleCheckoutSessionCompleted(ctx context.Context, completed *CheckoutSessionCompleted) error {
+	if completed.APIKeyID != "" {
+		return s.handleUpgradeCheckoutCompleted(ctx, completed)
+	}
+
 	fulfillment, err := s.repo.FindBySessionID(ctx, completed.SessionID)
 	if err != nil {
 		return err
@@ -270,6 +539,7 @@ func (s *Service) handleCheckoutSessionCompleted(ctx context.Context, completed
 			completed.PlanType,
 			output.Key.ID(),
 		)
+		fulfillment.LinkSubscription(completed.SubscriptionID)
 		if err := s.repo.Save(ctx, fulfillment); err != nil {
 			return err
 		}
@@ -293,7 +563,10 @@ func (s *Service) handleCheckoutSessionCompleted(ctx context.Context, completed
 			return err
 		}
 		fulfillment.MarkNotified()
-		if err := s.repo.Update(ctx, fulfillment); err != nil {
+		if err := s.repo.Update(ctx, fulfillment); errors.Is(err, domainbilling.ErrFulfillmentConflict) {
+			// 通知中に Subscription イベントが適用された場合は、通知を再送せず通知済みだけを記録し直す
+			return s.markNotified(ctx, completed.SessionID)
+		} else if err != nil {
 			return err
 		}
 	}
@@ -301,42 +574,234 @@ func (s *Service) handleCheckoutSessionCompleted(ctx context.Context, completed
 	return nil
 }
 
-func (s *Service) syncSubscriptionState(ctx context.Context, subscription *SubscriptionUpdated) error {
-	fulfillment, err := s.repo.FindLatestByCustomerID(ctx, subscription.CustomerID)
+// markNotified は fulfillment を読み直して API キー通知済みとして保存する。
+func (s *Service) markNotified(ctx context.Context, sessionID string) error {
+	for attempt := 1; ; attempt++ {
+		fulfillment, err := s.repo.FindBySessionID(ctx, sessionID)
+		if err != nil {
+			return err
+		}
+		if fulfillment == nil || fulfillment.Notified() {
+			return nil
+		}
+		fulfillment.MarkNotified()
+		err = s.repo.Update(ctx, fulfillment)
+		if !errors.Is(err, domainbilling.ErrFulfillmentConflict) || attempt == maxFulfillmentUpdateAttempts {
+			return err
+		}
+	}
+}
+
+// handleUpgradeCheckoutCompleted は既存キーのアップグレードとして作成した Checkout の完了を反映する。
+// 新しいキーは発行せず、指定されたキーのプランを変更してサブスクリプションを紐づける。
+// 所有者は既にキーを持っているため、キー通知は送らず通知済みとして記録する。
+func (s *Service) handleUpgradeCheckoutCompleted(ctx context.Context, completed *CheckoutSessionCompleted) error {
+	fulfillment, err := s.repo.FindBySessionID(ctx, completed.SessionID)
 	if err != nil {
 		return err
 	}
-	if fulfillment == nil {
+	if fulfillment != nil {
 		return nil
 	}
 
-	planType, err := s.planTypeFromPriceID(subscription.PriceID)
+	key, err := s.apiKeyIssuer.UpdateKeyPlan(ctx, completed.APIKeyID, string(completed.PlanType))
 	if err != nil {
 		return err
 	}
-	active := isActiveSubscriptionStatus(subscription.Status)
-	if _, err := s.apiKeyIssuer.UpdateKeyPlanAndStatus(ctx, fulfillment.APIKeyID(), string(planType), active); err != nil {
-		return err
+
+	fulfillment = domainbilling.NewCheckoutFulfillment(
+		completed.SessionID,
+		completed.CustomerID,
+		completed.Email,
+		completed.Name,
+		completed.PlanType,
+		key.ID(),
+	)
+	fulfillment.LinkSubscription(completed.SubscriptionID)
+	fulfillment.MarkNotified()
+	return s.repo.Save(ctx, fulfillment)
+}
+
+// handleSubscriptionUpdated は Customer Portal でのプラン変更やステータス変化を反映する。
+func (s *Service) handleSubscriptionUpdated(ctx context.Context, event *WebhookEvent) error {
+	subscription := event.Subscription
+	switch subscription.Status {
+	case "canceled", "incomplete_expired":
+		return s.handleSubscriptionDeleted(ctx, event)
 	}
-	if fulfillment.PlanType() != planType {
-		fulfillment.UpdatePlanType(planType)
-		if err := s.repo.Update(ctx, fulfillment); err != nil {
+
+	return s.applySubscriptionEvent(ctx, event, subscription.ID, subscription.CustomerID, func(fulfillment *domainbilling.CheckoutFulfillment) error {
+		planType, err := s.planTypeFromSubscription(subscription)
+		if err != nil {
+			return err
+		}
+		now := s.now()
+		fulfillment.SyncSubscriptionStatus(subscription.Status)
+		fulfillment.ChangePlan(planType, now)
+		if isActiveSubscriptionStatus(subscription.Status) {
+			// 支払い回復後は Stripe が active へ戻すため、invoice.paid の取りこぼしに備えて復元する
+			fulfillment.RecoverPayment(now)
+		}
+		return nil
+	})
+}
+
+// handleSubscriptionDeleted は解約されたサブスクリプションの API キーを free プランへ降格する。
+func (s *Service) handleSubscriptionDeleted(ctx context.Context, event *WebhookEvent) error {
+	subscription := event.Subscription
+	return s.applySubscriptionEvent(ctx, event, subscription.ID, subscription.CustomerID, func(fulfillment *domainbilling.CheckoutFulfillment) error {
+		fulfillment.Cancel(s.now())
+		return nil
+	})
+}
+
+// handleInvoicePaymentFailed は支払い失敗を記録し、猶予期間経過後の失敗であれば降格する。
+func (s *Service) handleInvoicePaymentFailed(ctx context.Context, event *WebhookEvent) error {
+	invoice := event.Invoice
+	return s.applySubscriptionEvent(ctx, event, invoice.SubscriptionID, invoice.CustomerID, func(fulfillment *domainbilling.CheckoutFulfillment) error {
+		fulfillment.RecordPaymentFailure(s.now(), s.cfg.PaymentGracePeriod)
+		return nil
+	})
+}
+
+// handleInvoicePaid は支払い回復時に契約プランを復元する。
+func (s *Service) handleInvoicePaid(ctx context.Context, event *WebhookEvent) error {
+	invoice := event.Invoice
+	return s.applySubscriptionEvent(ctx, event, invoice.SubscriptionID, invoice.CustomerID, func(fulfillment *domainbilling.CheckoutFulfillment) error {
+		fulfillment.RecoverPayment(s.now())
+		return nil
+	})
+}
+
+// applySubscriptionEvent は Subscription / Invoice イベントを対象の fulfillment に発生順で適用する。
+// より新しいイベントが適用済みなら errStaleEvent を返す。発生順の判定と適用は、読み込んだ版が
+// 変わっていない場合だけ成功する1回の条件付き更新で保存し、並行する更新と競合したら読み直して判定し直す。
+func (s *Service) applySubscriptionEvent(
+	ctx context.Context,
+	event *WebhookEvent,
+	subscriptionID, customerID string,
+	apply func(fulfillment *domainbilling.CheckoutFulfillment) error,
+) error {
+	for attempt := 1; ; attempt++ {
+		fulfillment, err := s.findSubscriptionFulfillment(ctx, subscriptionID, customerID)
+		if err != nil || fulfillment == nil {
+			return err
+		}
+		if event.ID != "" && fulfillment.LastEventID() == event.ID {
+			// 保存後に API キーへの反映が失敗したイベントの再実行では、キーのプランだけ同期し直す
+			_, err := s.apiKeyIssuer.UpdateKeyPlan(ctx, fulfillment.APIKeyID(), string(fulfillment.EffectivePlanType()))
+			return err
+		}
+		if !fulfillment.ObserveEvent(event.ID, event.Created) {
+			return errStaleEvent
+		}
+
+		before := len(fulfillment.History())
+		if err := apply(fulfillment); err != nil {
+			return err
+		}
+		err = s.applyTransitions(ctx, fulfillment, before)
+		if !errors.Is(err, domainbilling.ErrFulfillmentConflict) || attempt == maxFulfillmentUpdateAttempts {
 			return err
 		}
 	}
-	return nil
 }
 
-func (s *Service) syncKeyStatusByCustomer(ctx context.Context, customerID string, active bool) error {
-	fulfillment, err := s.repo.FindLatestByCustomerID(ctx, customerID)
-	if err != nil {
+// findSubscriptionFulfillment は Subscription / Invoice イベントを適用する fulfillment を返す。
+// 1人の顧客が複数のキーを購入できるため Subscription ID で引き、見つからない場合に限り、
+// Subscription ID を記録する前に作成した fulfillment を顧客 ID から探して紐づける。
+func (s *Service) findSubscriptionFulfillment(ctx context.Context, subscriptionID, customerID string) (*domainbilling.CheckoutFulfillment, error) {
+	if subscriptionID != "" {
+		fulfillment, err := s.repo.FindBySubscriptionID(ctx, subscriptionID)
+		if err != nil || fulfillment != nil {
+			return fulfillment, err
+		}
+	}
+	fulfillment, err := s.repo.FindLatestUnlinkedByCustomerID(ctx, customerID)
+	if err != nil || fulfillment == nil {
+		return nil, err
+	}
+	fulfillment.LinkSubscription(subscriptionID)
+	return fulfillment, nil
+}
+
+// graceExpiryBatchSize は ExpireLapsedGracePeriods が1回の取得で扱う件数。
+const graceExpiryBatchSize = 100
+
+// ExpireLapsedGracePeriods は猶予期限を過ぎても支払いが回復していない API キーを free プランへ降格し、
+// 降格した件数を返す。失敗 Webhook が再送されなくても期限切れを反映するためスケジューラから呼ばれる。
+func (s *Service) ExpireLapsedGracePeriods(ctx context.Context) (int, error) {
+	now := s.now()
+	expired := 0
+	for {
+		fulfillments, err := s.repo.FindGraceExpired(ctx, now.Add(-s.cfg.PaymentGracePeriod), graceExpiryBatchSize)
+		if err != nil {
+			return expired, err
+		}
+		batchExpired := 0
+		for _, fulfillment := range fulfillments {
+			before := len(fulfillment.History())
+			if !fulfillment.ExpireGracePeriod(now, s.cfg.PaymentGracePeriod) {
+				continue
+			}
+			if err := s.applyTransitions(ctx, fulfillment, before); errors.Is(err, domainbilling.ErrFulfillmentConflict) {
+				// 並行して Webhook が適用された fulfillment は次回の実行で判定し直す
+				continue
+			} else if err != nil {
+				return expired, err
+			}
+			batchExpired++
+		}
+		expired += batchExpired
+		// 降格済みは次の取得対象から外れるため、先頭から取り直せば残りを辿れる
+		if len(fulfillments) < graceExpiryBatchSize || batchExpired == 0 {
+			return expired, nil
+		}
+	}
+}
+
+// applyTransitions は fulfillment を保存し、API キーへ実効プランを反映したうえで
+// before 以降に追加された状態遷移を顧客へ通知する。
+// 並行する更新と競合した場合は API キーを変更せずに domainbilling.ErrFulfillmentConflict を返す。
+func (s *Service) applyTransitions(ctx context.Context, fulfillment *domainbilling.CheckoutFulfillment, before int) error {
+	if err := s.repo.Update(ctx, fulfillment); err != nil {
 		return err
 	}
-	if fulfillment == nil {
-		return nil
+	if _, err := s.apiKeyIssuer.UpdateKeyPlan(ctx, fulfillment.APIKeyID(), string(fulfillment.EffectivePlanType())); err != nil {
+		return err
+	}
+
+	history := fulfillment.History()
+	for _, transition := range history[before:] {
+		s.notifySubscriptionChanged(ctx, fulfillment, transition)
+	}
+	return nil
+}
+
+// notifySubscriptionChanged は状態遷移を顧客へ通知する。
+// 状態は保存済みのため、通知失敗で Webhook を再送させないようログのみ残す。
+func (s *Service) notifySubscriptionChanged(ctx context.Context, fulfillment *domainbilling.CheckoutFulfillment, transition domainbilling.SubscriptionTransition) {
+	if s.notifier == nil {
+		return
+	}
+	notification := SubscriptionChangedNotification{
+		To:         fulfillment.Email(),
+		Name:       fulfillment.Name(),
+		Transition: transition.Type,
+		FromPlan:   string(transition.FromPlan),
+		ToPlan:     string(transition.ToPlan),
+		OccurredAt: transition.OccurredAt,
+	}
+	if transition.Type == domainbilling.TransitionPaymentFailed {
+		notification.GraceDeadline = fulfillment.GraceDeadline(s.cfg.PaymentGracePeriod)
+	}
+	if err := s.notifier.NotifySubscriptionChanged(ctx, notification); err != nil {
+		slog.Warn("サブスクリプション状態変更通知の送信に失敗しました",
+			"customer_id", fulfillment.CustomerID(),
+			"transition", transition.Type,
+			"error", err,
+		)
 	}
-	_, err = s.apiKeyIssuer.UpdateKeyPlanAndStatus(ctx, fulfillment.APIKeyID(), string(fulfillment.PlanType()), active)
-	return err
 }
 
 func (s *Service) planTypeFromPriceID(priceID string) (plan.Type, error) {
@@ -348,6 +813,16 @@ func (s *Service) planTypeFromPriceID(priceID string) (plan.Type, error) {
 	return "", fmt.Errorf("プランに対応する Stripe Price ID が見つかりません")
 }
 
+// planTypeFromSubscription は Subscription の item からプランに対応する price を探す。
+func (s *Service) planTypeFromSubscription(subscription *SubscriptionUpdated) (plan.Type, error) {
+	for _, priceID := range subscription.PriceIDs {
+		if planType, err := s.planTypeFromPriceID(priceID); err == nil {
+			return planType, nil
+		}
+	}
+	return s.planTypeFromPriceID(subscription.PriceID)
+}
+
 func isActiveSubscriptionStatus(status string) bool {
 	switch status {
 	case "active", "trialing":
@@ -21,6 +21,9 @@ type fakeStripeClient struct {
 	checkoutOut   *CheckoutSession
 	portalOut     *PortalSession
 	verifyErr     error
+	usageInputs   []MeteredUsageInput
+	usageErr      error
+	itemInputs    []EnsureSubscriptionItemInput
 }
 
 func (f *fakeStripeClient) CreateCheckoutSession(_ context.Context, input CreateCheckoutSessionInput) (*CheckoutSession, error) {
@@ -38,6 +41,10 @@ func (f *fakeStripeClient) VerifyWebhookEvent(_ []byte, _ string) (*WebhookEvent
 	return f.webhookEvent, nil
 }
 
+func (f *fakeStripeClient) ParseWebhookEvent(_ []byte) (*WebhookEvent, error) {
+	return f.webhookEvent, nil
+}
+
 func (f *fakeStripeClient) CreatePortalSession(_ context.Context, input CreatePortalSessionInput) (*PortalSession, error) {
 	f.portalInput = &input
 	if f.portalOut != nil {
@@ -46,10 +53,24 @@ func (f *fakeStripeClient) CreatePortalSession(_ context.Context, input CreatePo
 	return &PortalSession{URL: "https://billing.stripe.test/session"}, nil
 }
 
+func (f *fakeStripeClient) ReportMeteredUsage(_ context.Context, input MeteredUsageInput) error {
+	if f.usageErr != nil {
+		return f.usageErr
+	}
+	f.usageInputs = append(f.usageInputs, input)
+	return nil
+}
+
+func (f *fakeStripeClient) EnsureSubscriptionItem(_ context.Context, input EnsureSubscriptionItemInput) error {
+	f.itemInputs = append(f.itemInputs, input)
+	return nil
+}
+
 type fakeFulfillmentRepo struct {
 	bySession  map[string]*domainbilling.CheckoutFulfillment
 	latest     map[string]*domainbilling.CheckoutFulfillment
 	byCustomer map[string]*domainbilling.CheckoutFulfillment
+	conflicts  int // 残りの回数だけ Update を ErrFulfillmentConflict で失敗させる
 }
 
 func newFakeFulfillmentRepo() *fakeFulfillmentRepo {
@@ -79,18 +100,117 @@ func (f *fakeFulfillmentRepo) FindLatestByCustomerID(_ context.Context, customer
 	return f.byCustomer[customerID], nil
 }
 
+func (f *fakeFulfillmentRepo) FindBySubscriptionID(_ context.Context, subscriptionID string) (*domainbilling.CheckoutFulfillment, error) {
+	for _, fulfillment := range f.bySession {
+		if fulfillment.SubscriptionID() == subscriptionID {
+			return fulfillment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeFulfillmentRepo) FindLatestUnlinkedByCustomerID(_ context.Context, customerID string) (*domainbilling.CheckoutFulfillment, error) {
+	var latest *domainbilling.CheckoutFulfillment
+	for _, fulfillment := range f.bySession {
+		if fulfillment.CustomerID() != customerID || fulfillment.SubscriptionID() != "" {
+			continue
+		}
+		if latest == nil || fulfillment.CreatedAt().After(latest.CreatedAt()) {
+			latest = fulfillment
+		}
+	}
+	return latest, nil
+}
+
+func (f *fakeFulfillmentRepo) FindLatestByAPIKeyID(_ context.Context, apiKeyID string) (*domainbilling.CheckoutFulfillment, error) {
+	var latest *domainbilling.CheckoutFulfillment
+	for _, fulfillment := range f.bySession {
+		if fulfillment.APIKeyID() != apiKeyID {
+			continue
+		}
+		if latest == nil || fulfillment.CreatedAt().After(latest.CreatedAt()) {
+			latest = fulfillment
+		}
+	}
+	return latest, nil
+}
+
+func (f *fakeFulfillmentRepo) FindGraceExpired(_ context.Context, failedBefore time.Time, limit int) ([]*domainbilling.CheckoutFulfillment, error) {
+	var fulfillments []*domainbilling.CheckoutFulfillment
+	for _, fulfillment := range f.bySession {
+		failedAt := fulfillment.PaymentFailedAt()
+		if failedAt == nil || failedAt.After(failedBefore) || fulfillment.Downgraded() {
+			continue
+		}
+		fulfillments = append(fulfillments, fulfillment)
+		if len(fulfillments) == limit {
+			break
+		}
+	}
+	return fulfillments, nil
+}
+
 func (f *fakeFulfillmentRepo) Update(_ context.Context, fulfillment *domainbilling.CheckoutFulfillment) error {
+	if f.conflicts > 0 {
+		f.conflicts--
+		return domainbilling.ErrFulfillmentConflict
+	}
 	f.bySession[fulfillment.SessionID()] = fulfillment
 	f.latest[fulfillment.Email()] = fulfillment
 	f.byCustomer[fulfillment.CustomerID()] = fulfillment
 	return nil
 }
 
+type fakeStripeEventRepo struct {
+	events map[string]*domainbilling.StripeEvent
+}
+
+func newFakeStripeEventRepo() *fakeStripeEventRepo {
+	return &fakeStripeEventRepo{events: map[string]*domainbilling.StripeEvent{}}
+}
+
+func (f *fakeStripeEventRepo) Claim(_ context.Context, event *domainbilling.StripeEvent, staleBefore time.Time) (bool, error) {
+	existing, ok := f.events[event.EventID()]
+	if ok && !existing.Failed() && !(existing.Status() == domainbilling.StripeEventStatusProcessing && existing.LastAttemptAt().Before(staleBefore)) {
+		return false, nil
+	}
+	stored := *event
+	f.events[event.EventID()] = &stored
+	return true, nil
+}
+
+func (f *fakeStripeEventRepo) FindByEventID(_ context.Context, eventID string) (*domainbilling.StripeEvent, error) {
+	existing, ok := f.events[eventID]
+	if !ok {
+		return nil, nil
+	}
+	found := *existing
+	return &found, nil
+}
+
+func (f *fakeStripeEventRepo) Update(_ context.Context, event *domainbilling.StripeEvent) error {
+	stored := *event
+	f.events[event.EventID()] = &stored
+	return nil
+}
+
+func (f *fakeStripeEventRepo) List(_ context.Context, status domainbilling.StripeEventStatus, _, _ int) ([]*domainbilling.StripeEvent, int64, error) {
+	var result []*domainbilling.StripeEvent
+	for _, e := range f.events {
+		if status == "" || e.Status() == status {
+			result = append(result, e)
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
 type fakeAPIKeyIssuer struct {
 	calls      int
 	rawKey     string
 	key        *domainapikey.APIKey
 	updatedKey *domainapikey.APIKey
+	updates    int
+	updateErr  error
 }
 
 func (f *fakeAPIKeyIssuer) CreateOrGetKeyWithRawKey(_ context.Context, input appAPIKey.CreateKeyInput, rawKey string) (*appAPIKey.CreateKeyOutput, error) {
@@ -106,6 +226,7 @@ func (f *fakeAPIKeyIssuer) CreateOrGetKeyWithRawKey(_ context.Context, input app
 			input.Name,
 			plan.Type(input.PlanType),
 			true,
+			domainapikey.Overage{},
 			mustTime(),
 		)
 		if err != nil {
@@ -116,7 +237,11 @@ func (f *fakeAPIKeyIssuer) CreateOrGetKeyWithRawKey(_ context.Context, input app
 	return &appAPIKey.CreateKeyOutput{RawKey: rawKey, Key: f.key}, nil
 }
 
-func (f *fakeAPIKeyIssuer) UpdateKeyPlanAndStatus(_ context.Context, id string, planType string, active bool) (*domainapikey.APIKey, error) {
+func (f *fakeAPIKeyIssuer) UpdateKeyPlan(_ context.Context, id string, planType string) (*domainapikey.APIKey, error) {
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	f.updates++
 	key, err := domainapikey.Reconstruct(
 		id,
 		f.key.Prefix(),
@@ -125,7 +250,8 @@ func (f *fakeAPIKeyIssuer) UpdateKeyPlanAndStatus(_ context.Context, id string,
 		f.key.Email(),
 		f.key.Name(),
 		plan.Type(planType),
-		active,
+		f.key.IsActive(),
+		f.key.Overage(),
 		f.key.CreatedAt(),
 	)
 	if err != nil {
@@ -139,6 +265,7 @@ func (f *fakeAPIKeyIssuer) UpdateKeyPlanAndStatus(_ context.Context, id string,
 type fakeNotifier struct {
 	calls        int
 	notification APIKeyIssuedNotification
+	changes      []SubscriptionChangedNotification
 	err          error
 }
 
@@ -148,6 +275,11 @@ func (f *fakeNotifier) NotifyAPIKeyIssued(_ context.Context, notification APIKey
 	return f.err
 }
 
+func (f *fakeNotifier) NotifySubscriptionChanged(_ context.Context, notification SubscriptionChangedNotification) error {
+	f.changes = append(f.changes, notification)
+	return f.err
+}
+
 func TestCreateCheckoutSession_UsesStripePriceID(t *testing.T) {
 	t.Parallel()
 
@@ -155,6 +287,7 @@ func TestCreateCheckoutSession_UsesStripePriceID(t *testing.T) {
 	service := NewService(
 		stripeClient,
 		newFakeFulfillmentRepo(),
+		nil,
 		&fakeAPIKeyIssuer{},
 		&fakeNotifier{},
 		Config{
@@ -204,6 +337,7 @@ func TestHandleStripeWebhook_FulfillsCheckoutSessionIdempotently(t *testing.T) {
 	service := NewService(
 		stripeClient,
 		repo,
+		nil,
 		issuer,
 		notifier,
 		Config{
@@ -250,6 +384,7 @@ func TestCreatePortalSession_UsesLatestFulfillmentCustomer(t *testing.T) {
 	service := NewService(
 		stripeClient,
 		repo,
+		nil,
 		&fakeAPIKeyIssuer{},
 		&fakeNotifier{},
 		Config{
@@ -275,6 +410,7 @@ func TestHandleStripeWebhook_ReturnsErrorWhenSignatureInvalid(t *testing.T) {
 	service := NewService(
 		&fakeStripeClient{verifyErr: errors.New("invalid signature")},
 		newFakeFulfillmentRepo(),
+		nil,
 		&fakeAPIKeyIssuer{},
 		&fakeNotifier{},
 		Config{
@@ -287,7 +423,7 @@ func TestHandleStripeWebhook_ReturnsErrorWhenSignatureInvalid(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestHandleStripeWebhook_DeactivatesKeyOnSubscriptionDeleted(t *testing.T) {
+func TestHandleStripeWebhook_DowngradesKeyOnSubscriptionDeleted(t *testing.T) {
 	t.Parallel()
 
 	stripeClient := &fakeStripeClient{
@@ -304,21 +440,107 @@ func TestHandleStripeWebhook_DeactivatesKeyOnSubscriptionDeleted(t *testing.T) {
 	fulfillment := domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeBusiness, "507f1f77bcf86cd799439013")
 	require.NoError(t, repo.Save(context.Background(), fulfillment))
 	issuer := &fakeAPIKeyIssuer{}
-	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeBusiness, true, mustTime())
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeBusiness, true, domainapikey.Overage{}, mustTime())
+	notifier := &fakeNotifier{}
 
 	service := NewService(
 		stripeClient,
 		repo,
+		nil,
 		issuer,
-		&fakeNotifier{},
+		notifier,
 		Config{KeySeedSecret: "seed", PriceIDs: map[plan.Type]string{plan.TypeBusiness: "price_biz_123"}},
 	)
 
 	err := service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig")
 	require.NoError(t, err)
 	require.NotNil(t, issuer.updatedKey)
-	assert.False(t, issuer.updatedKey.IsActive())
-	assert.Equal(t, plan.TypeBusiness, issuer.updatedKey.PlanType())
+	assert.True(t, issuer.updatedKey.IsActive())
+	assert.Equal(t, plan.TypeFree, issuer.updatedKey.PlanType())
+
+	updated, err := repo.FindLatestByCustomerID(context.Background(), "cus_123")
+	require.NoError(t, err)
+	assert.Equal(t, domainbilling.DowngradeReasonCanceled, updated.DowngradeReason())
+	require.Len(t, updated.History(), 1)
+	assert.Equal(t, domainbilling.TransitionCanceled, updated.History()[0].Type)
+	assert.Equal(t, plan.TypeBusiness, updated.History()[0].FromPlan)
+	require.Len(t, notifier.changes, 1)
+	assert.Equal(t, domainbilling.TransitionCanceled, notifier.changes[0].Transition)
+	assert.Equal(t, "user@example.com", notifier.changes[0].To)
+
+	// 再送されても履歴・通知は増えない
+	err = service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig")
+	require.NoError(t, err)
+	assert.Len(t, updated.History(), 1)
+	assert.Len(t, notifier.changes, 1)
+}
+
+func TestHandleStripeWebhook_AppliesSubscriptionEventsToTheirOwnKey(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	keyA := domainbilling.NewCheckoutFulfillment("cs_a", "cus_123", "user@example.com", "Key A", plan.TypeBusiness, "507f1f77bcf86cd799439013")
+	keyA.LinkSubscription("sub_a")
+	require.NoError(t, repo.Save(context.Background(), keyA))
+	keyB := domainbilling.NewCheckoutFulfillment("cs_b", "cus_123", "user@example.com", "Key B", plan.TypeBusiness, "507f1f77bcf86cd799439014")
+	keyB.LinkSubscription("sub_b")
+	require.NoError(t, repo.Save(context.Background(), keyB))
+	issuer := &fakeAPIKeyIssuer{}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Key A", plan.TypeBusiness, true, domainapikey.Overage{}, mustTime())
+
+	stripeClient := &fakeStripeClient{
+		webhookEvent: &WebhookEvent{
+			Type:         WebhookEventTypeSubscriptionDeleted,
+			Subscription: &SubscriptionUpdated{ID: "sub_a", CustomerID: "cus_123", PriceID: "price_biz_123", Status: "canceled"},
+		},
+	}
+	service := NewService(stripeClient, repo, nil, issuer, nil, Config{KeySeedSecret: "seed", PriceIDs: map[plan.Type]string{plan.TypeBusiness: "price_biz_123"}})
+
+	// 最後に作成されたキー B ではなく、解約された Subscription のキー A を降格する
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	require.NotNil(t, issuer.updatedKey)
+	assert.Equal(t, "507f1f77bcf86cd799439013", issuer.updatedKey.ID())
+	assert.Equal(t, domainbilling.DowngradeReasonCanceled, keyA.DowngradeReason())
+	assert.False(t, keyB.Downgraded())
+
+	// Invoice も請求対象の Subscription のキーに適用する
+	stripeClient.webhookEvent = &WebhookEvent{
+		Type:    WebhookEventTypeInvoicePaymentFailed,
+		Invoice: &InvoiceUpdated{ID: "in_b", CustomerID: "cus_123", SubscriptionID: "sub_b"},
+	}
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	assert.Equal(t, 1, keyB.PaymentFailureCount())
+	assert.Equal(t, 0, keyA.PaymentFailureCount())
+}
+
+func TestHandleStripeWebhook_LinksLegacyFulfillmentToSubscription(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	legacy := domainbilling.NewCheckoutFulfillment("cs_legacy", "cus_123", "user@example.com", "Example App", plan.TypeBusiness, "507f1f77bcf86cd799439013")
+	require.NoError(t, repo.Save(context.Background(), legacy))
+	issuer := &fakeAPIKeyIssuer{}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeBusiness, true, domainapikey.Overage{}, mustTime())
+
+	stripeClient := &fakeStripeClient{
+		webhookEvent: &WebhookEvent{
+			Type:    WebhookEventTypeInvoicePaymentFailed,
+			Invoice: &InvoiceUpdated{ID: "in_1", CustomerID: "cus_123", SubscriptionID: "sub_legacy"},
+		},
+	}
+	service := NewService(stripeClient, repo, nil, issuer, nil, Config{KeySeedSecret: "seed", PriceIDs: map[plan.Type]string{plan.TypeBusiness: "price_biz_123"}})
+
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	assert.Equal(t, "sub_legacy", legacy.SubscriptionID())
+	assert.Equal(t, 1, legacy.PaymentFailureCount())
+
+	// 紐づけ後は別の Subscription のイベントを適用しない
+	stripeClient.webhookEvent = &WebhookEvent{
+		Type:    WebhookEventTypeInvoicePaymentFailed,
+		Invoice: &InvoiceUpdated{ID: "in_2", CustomerID: "cus_123", SubscriptionID: "sub_other"},
+	}
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	assert.Equal(t, 1, legacy.PaymentFailureCount())
 }
 
 func TestHandleStripeWebhook_SyncsPlanOnSubscriptionUpdated(t *testing.T) {
@@ -338,11 +560,12 @@ func TestHandleStripeWebhook_SyncsPlanOnSubscriptionUpdated(t *testing.T) {
 	fulfillment := domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeDeveloper, "507f1f77bcf86cd799439013")
 	require.NoError(t, repo.Save(context.Background(), fulfillment))
 	issuer := &fakeAPIKeyIssuer{}
-	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeDeveloper, true, mustTime())
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeDeveloper, true, domainapikey.Overage{}, mustTime())
 
 	service := NewService(
 		stripeClient,
 		repo,
+		nil,
 		issuer,
 		&fakeNotifier{},
 		Config{KeySeedSecret: "seed", PriceIDs: map[plan.Type]string{plan.TypeBusiness: "price_biz_123"}},
@@ -358,51 +581,495 @@ func TestHandleStripeWebhook_SyncsPlanOnSubscriptionUpdated(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, updatedFulfillment)
 	assert.Equal(t, plan.TypeBusiness, updatedFulfillment.PlanType())
+	assert.Equal(t, "active", updatedFulfillment.SubscriptionStatus())
+	require.Len(t, updatedFulfillment.History(), 1)
+	assert.Equal(t, domainbilling.TransitionPlanChanged, updatedFulfillment.History()[0].Type)
+	assert.Equal(t, plan.TypeDeveloper, updatedFulfillment.History()[0].FromPlan)
+	assert.Equal(t, plan.TypeBusiness, updatedFulfillment.History()[0].ToPlan)
 }
 
-func TestHandleStripeWebhook_DeactivatesAndReactivatesKeyOnInvoiceEvents(t *testing.T) {
+func TestHandleStripeWebhook_PaymentFailureGracePeriodAndRestore(t *testing.T) {
 	t.Parallel()
 
 	repo := newFakeFulfillmentRepo()
 	fulfillment := domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeDeveloper, "507f1f77bcf86cd799439013")
 	require.NoError(t, repo.Save(context.Background(), fulfillment))
 	issuer := &fakeAPIKeyIssuer{}
-	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeDeveloper, true, mustTime())
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeDeveloper, true, domainapikey.Overage{}, mustTime())
+	notifier := &fakeNotifier{}
 
-	service := NewService(
-		&fakeStripeClient{
-			webhookEvent: &WebhookEvent{
-				Type: WebhookEventTypeInvoicePaymentFailed,
-				Invoice: &InvoiceUpdated{
-					CustomerID: "cus_123",
-					Paid:       false,
-				},
-			},
+	failed := &fakeStripeClient{
+		webhookEvent: &WebhookEvent{
+			Type:    WebhookEventTypeInvoicePaymentFailed,
+			Invoice: &InvoiceUpdated{CustomerID: "cus_123", Paid: false},
 		},
+	}
+	paid := &fakeStripeClient{
+		webhookEvent: &WebhookEvent{
+			Type:    WebhookEventTypeInvoicePaid,
+			Invoice: &InvoiceUpdated{CustomerID: "cus_123", Paid: true},
+		},
+	}
+	service := NewService(failed, repo, nil, issuer, notifier, Config{KeySeedSecret: "seed", PaymentGracePeriod: 72 * time.Hour})
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	// 最初の失敗は猶予期間中としてプランを維持する
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	assert.True(t, issuer.updatedKey.IsActive())
+	assert.Equal(t, plan.TypeDeveloper, issuer.updatedKey.PlanType())
+	require.Len(t, notifier.changes, 1)
+	assert.Equal(t, domainbilling.TransitionPaymentFailed, notifier.changes[0].Transition)
+	require.NotNil(t, notifier.changes[0].GraceDeadline)
+	assert.Equal(t, now.Add(72*time.Hour), *notifier.changes[0].GraceDeadline)
+
+	// 猶予期間内の再失敗ではまだ降格しない
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	assert.Equal(t, plan.TypeDeveloper, issuer.updatedKey.PlanType())
+
+	// 猶予期間経過後の再失敗で free へ降格する
+	now = now.Add(48 * time.Hour)
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	assert.True(t, issuer.updatedKey.IsActive())
+	assert.Equal(t, plan.TypeFree, issuer.updatedKey.PlanType())
+	assert.Equal(t, domainbilling.TransitionGraceExpired, notifier.changes[len(notifier.changes)-1].Transition)
+
+	// invoice.paid で契約プランを復元する
+	service.stripeClient = paid
+	now = now.Add(time.Hour)
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	assert.True(t, issuer.updatedKey.IsActive())
+	assert.Equal(t, plan.TypeDeveloper, issuer.updatedKey.PlanType())
+
+	updated, err := repo.FindLatestByCustomerID(context.Background(), "cus_123")
+	require.NoError(t, err)
+	assert.Zero(t, updated.PaymentFailureCount())
+	assert.False(t, updated.Downgraded())
+
+	types := make([]domainbilling.TransitionType, 0)
+	for _, h := range updated.History() {
+		types = append(types, h.Type)
+	}
+	assert.Equal(t, []domainbilling.TransitionType{
+		domainbilling.TransitionPaymentFailed,
+		domainbilling.TransitionPaymentFailed,
+		domainbilling.TransitionGraceExpired,
+		domainbilling.TransitionRestored,
+	}, types)
+	assert.Equal(t, domainbilling.TransitionRestored, notifier.changes[len(notifier.changes)-1].Transition)
+}
+
+func TestExpireLapsedGracePeriods(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	require.NoError(t, repo.Save(context.Background(), domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeDeveloper, "507f1f77bcf86cd799439013")))
+	issuer := &fakeAPIKeyIssuer{}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeDeveloper, false, domainapikey.Overage{}, mustTime())
+	notifier := &fakeNotifier{}
+
+	service := NewService(
+		&fakeStripeClient{webhookEvent: &WebhookEvent{
+			Type:    WebhookEventTypeInvoicePaymentFailed,
+			Invoice: &InvoiceUpdated{CustomerID: "cus_123"},
+		}},
+		repo,
+		nil,
+		issuer,
+		notifier,
+		Config{KeySeedSecret: "seed", PaymentGracePeriod: 72 * time.Hour},
+	)
+	now := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+
+	// 猶予期間中は降格しない
+	now = now.Add(71 * time.Hour)
+	expired, err := service.ExpireLapsedGracePeriods(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+	assert.Equal(t, plan.TypeDeveloper, issuer.key.PlanType())
+
+	// 次の失敗 Webhook が届かなくても期限切れで降格する
+	now = now.Add(time.Hour)
+	expired, err = service.ExpireLapsedGracePeriods(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, plan.TypeFree, issuer.key.PlanType())
+	// 無効化済みのキーを再有効化しない
+	assert.False(t, issuer.key.IsActive())
+	assert.Equal(t, domainbilling.TransitionGraceExpired, notifier.changes[len(notifier.changes)-1].Transition)
+
+	expired, err = service.ExpireLapsedGracePeriods(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+}
+
+func TestHandleStripeWebhook_InvoicePaidDoesNotRestoreCanceledSubscription(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	fulfillment := domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeDeveloper, "507f1f77bcf86cd799439013")
+	fulfillment.Cancel(mustTime())
+	require.NoError(t, repo.Save(context.Background(), fulfillment))
+	issuer := &fakeAPIKeyIssuer{}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeFree, true, domainapikey.Overage{}, mustTime())
+	notifier := &fakeNotifier{}
+
+	service := NewService(
+		&fakeStripeClient{webhookEvent: &WebhookEvent{
+			Type:    WebhookEventTypeInvoicePaid,
+			Invoice: &InvoiceUpdated{CustomerID: "cus_123", Paid: true},
+		}},
 		repo,
+		nil,
+		issuer,
+		notifier,
+		Config{KeySeedSecret: "seed"},
+	)
+
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig"))
+	assert.Equal(t, plan.TypeFree, issuer.updatedKey.PlanType())
+	assert.Empty(t, notifier.changes)
+}
+
+func TestHandleStripeWebhook_LedgerDeduplicatesRedeliveredEvents(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	require.NoError(t, repo.Save(context.Background(), domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeDeveloper, "507f1f77bcf86cd799439013")))
+	issuer := &fakeAPIKeyIssuer{}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeDeveloper, true, domainapikey.Overage{}, mustTime())
+	ledger := newFakeStripeEventRepo()
+
+	service := NewService(
+		&fakeStripeClient{webhookEvent: &WebhookEvent{
+			ID:      "evt_1",
+			Type:    WebhookEventTypeInvoicePaymentFailed,
+			Created: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			Invoice: &InvoiceUpdated{CustomerID: "cus_123"},
+		}},
+		repo,
+		ledger,
 		issuer,
 		&fakeNotifier{},
 		Config{KeySeedSecret: "seed"},
 	)
 
-	err := service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig")
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{"id":"evt_1"}`), "sig"))
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{"id":"evt_1"}`), "sig"))
+
+	assert.Equal(t, 1, issuer.updates)
+	fulfillment, err := repo.FindLatestByCustomerID(context.Background(), "cus_123")
 	require.NoError(t, err)
-	require.NotNil(t, issuer.updatedKey)
-	assert.False(t, issuer.updatedKey.IsActive())
+	assert.Equal(t, 1, fulfillment.PaymentFailureCount())
+	entry, _ := ledger.FindByEventID(context.Background(), "evt_1")
+	require.NotNil(t, entry)
+	assert.Equal(t, domainbilling.StripeEventStatusSucceeded, entry.Status())
+	assert.Equal(t, "cus_123", entry.CustomerID())
+}
+
+func TestHandleStripeWebhook_LedgerSkipsOutOfOrderEvents(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	require.NoError(t, repo.Save(context.Background(), domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeBusiness, "507f1f77bcf86cd799439013")))
+	issuer := &fakeAPIKeyIssuer{}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeBusiness, true, domainapikey.Overage{}, mustTime())
+	ledger := newFakeStripeEventRepo()
+	cfg := Config{KeySeedSecret: "seed", PriceIDs: map[plan.Type]string{plan.TypeBusiness: "price_biz_123"}}
+	base := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	deleted := &fakeStripeClient{webhookEvent: &WebhookEvent{
+		ID:           "evt_deleted",
+		Type:         WebhookEventTypeSubscriptionDeleted,
+		Created:      base.Add(time.Minute),
+		Subscription: &SubscriptionUpdated{ID: "sub_123", CustomerID: "cus_123", PriceID: "price_biz_123", Status: "canceled"},
+	}}
+	service := NewService(deleted, repo, ledger, issuer, &fakeNotifier{}, cfg)
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+	assert.Equal(t, plan.TypeFree, issuer.updatedKey.PlanType())
+
+	// deleted より前に発生した updated が後から届いても、プランを復活させない
+	service.stripeClient = &fakeStripeClient{webhookEvent: &WebhookEvent{
+		ID:           "evt_updated",
+		Type:         WebhookEventTypeSubscriptionUpdated,
+		Created:      base,
+		Subscription: &SubscriptionUpdated{ID: "sub_123", CustomerID: "cus_123", PriceID: "price_biz_123", Status: "active"},
+	}}
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+	assert.Equal(t, plan.TypeFree, issuer.updatedKey.PlanType())
+
+	entry, _ := ledger.FindByEventID(context.Background(), "evt_updated")
+	require.NotNil(t, entry)
+	assert.Equal(t, domainbilling.StripeEventStatusSkipped, entry.Status())
+}
+
+func TestHandleStripeWebhook_OrdersEventsPerFulfillment(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	fulfillment := domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeBusiness, "507f1f77bcf86cd799439013")
+	fulfillment.LinkSubscription("sub_123")
+	require.NoError(t, repo.Save(context.Background(), fulfillment))
+	issuer := &fakeAPIKeyIssuer{}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeBusiness, true, domainapikey.Overage{}, mustTime())
+	ledger := newFakeStripeEventRepo()
+	cfg := Config{KeySeedSecret: "seed", PriceIDs: map[plan.Type]string{plan.TypeBusiness: "price_biz_123"}}
+	base := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	service := NewService(&fakeStripeClient{webhookEvent: &WebhookEvent{
+		ID:           "evt_updated",
+		Type:         WebhookEventTypeSubscriptionUpdated,
+		Created:      base.Add(time.Minute),
+		Subscription: &SubscriptionUpdated{ID: "sub_123", CustomerID: "cus_123", PriceID: "price_biz_123", Status: "active"},
+	}}, repo, ledger, issuer, &fakeNotifier{}, cfg)
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+
+	// subscription の更新より前に発生した支払い失敗は、Invoice のイベントでも適用しない
+	service.stripeClient = &fakeStripeClient{webhookEvent: &WebhookEvent{
+		ID:      "evt_failed",
+		Type:    WebhookEventTypeInvoicePaymentFailed,
+		Created: base,
+		Invoice: &InvoiceUpdated{ID: "in_123", CustomerID: "cus_123", SubscriptionID: "sub_123"},
+	}}
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+	assert.Equal(t, 0, fulfillment.PaymentFailureCount())
+	entry, _ := ledger.FindByEventID(context.Background(), "evt_failed")
+	require.NotNil(t, entry)
+	assert.Equal(t, domainbilling.StripeEventStatusSkipped, entry.Status())
+
+	// 同じ秒に発生したイベントはイベント ID の順に並べる
+	service.stripeClient = &fakeStripeClient{webhookEvent: &WebhookEvent{
+		ID:      "evt_a_failed",
+		Type:    WebhookEventTypeInvoicePaymentFailed,
+		Created: base.Add(time.Minute),
+		Invoice: &InvoiceUpdated{ID: "in_124", CustomerID: "cus_123", SubscriptionID: "sub_123"},
+	}}
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+	assert.Equal(t, 0, fulfillment.PaymentFailureCount())
+
+	service.stripeClient = &fakeStripeClient{webhookEvent: &WebhookEvent{
+		ID:      "evt_z_failed",
+		Type:    WebhookEventTypeInvoicePaymentFailed,
+		Created: base.Add(time.Minute),
+		Invoice: &InvoiceUpdated{ID: "in_125", CustomerID: "cus_123", SubscriptionID: "sub_123"},
+	}}
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+	assert.Equal(t, 1, fulfillment.PaymentFailureCount())
+	assert.Equal(t, "evt_z_failed", fulfillment.LastEventID())
+}
+
+func TestHandleStripeWebhook_RetriesWhenFulfillmentUpdatedConcurrently(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	fulfillment := domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeBusiness, "507f1f77bcf86cd799439013")
+	fulfillment.LinkSubscription("sub_123")
+	require.NoError(t, repo.Save(context.Background(), fulfillment))
+	repo.conflicts = 1
+	issuer := &fakeAPIKeyIssuer{}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeBusiness, true, domainapikey.Overage{}, mustTime())
+
+	service := NewService(&fakeStripeClient{webhookEvent: &WebhookEvent{
+		ID:           "evt_deleted",
+		Type:         WebhookEventTypeSubscriptionDeleted,
+		Created:      time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Subscription: &SubscriptionUpdated{ID: "sub_123", CustomerID: "cus_123", PriceID: "price_biz_123", Status: "canceled"},
+	}}, repo, newFakeStripeEventRepo(), issuer, nil, Config{KeySeedSecret: "seed", PriceIDs: map[plan.Type]string{plan.TypeBusiness: "price_biz_123"}})
+
+	// 競合した1回目は API キーを変更せず、読み直して適用し直す
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+	assert.Equal(t, 1, issuer.updates)
+	assert.Equal(t, plan.TypeFree, issuer.updatedKey.PlanType())
+	assert.Zero(t, repo.conflicts)
+}
+
+func TestReplayStripeEvent_ReprocessesFailedEvent(t *testing.T) {
+	t.Parallel()
+
+	repo := newFakeFulfillmentRepo()
+	require.NoError(t, repo.Save(context.Background(), domainbilling.NewCheckoutFulfillment("cs_test_123", "cus_123", "user@example.com", "Example App", plan.TypeDeveloper, "507f1f77bcf86cd799439013")))
+	issuer := &fakeAPIKeyIssuer{updateErr: errors.New("mongo unavailable")}
+	issuer.key, _ = domainapikey.Reconstruct("507f1f77bcf86cd799439013", "ik_live_12345678", "hash", "ik_live_1234****5678", "user@example.com", "Example App", plan.TypeDeveloper, true, domainapikey.Overage{}, mustTime())
+	ledger := newFakeStripeEventRepo()
 
-	service.stripeClient = &fakeStripeClient{
+	service := NewService(
+		&fakeStripeClient{webhookEvent: &WebhookEvent{
+			ID:      "evt_paid",
+			Type:    WebhookEventTypeInvoicePaid,
+			Created: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			Invoice: &InvoiceUpdated{CustomerID: "cus_123", Paid: true},
+		}},
+		repo,
+		ledger,
+		issuer,
+		&fakeNotifier{},
+		Config{KeySeedSecret: "seed"},
+	)
+
+	err := service.HandleStripeWebhook(context.Background(), []byte(`{"id":"evt_paid"}`), "sig")
+	require.Error(t, err)
+
+	failed, _, err := service.ListStripeEvents(context.Background(), "failed", 20, 0)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	assert.Equal(t, "evt_paid", failed[0].EventID())
+	assert.Contains(t, failed[0].ErrorMsg(), "mongo unavailable")
+
+	issuer.updateErr = nil
+	replayed, err := service.ReplayStripeEvent(context.Background(), "evt_paid")
+	require.NoError(t, err)
+	assert.Equal(t, domainbilling.StripeEventStatusSucceeded, replayed.Status())
+	assert.Equal(t, 2, replayed.Attempts())
+
+	_, err = service.ReplayStripeEvent(context.Background(), "evt_paid")
+	require.Error(t, err)
+	_, err = service.ReplayStripeEvent(context.Background(), "evt_missing")
+	require.Error(t, err)
+}
+
+func upgradeTestKey(t *testing.T, planType plan.Type) *domainapikey.APIKey {
+	t.Helper()
+	key, err := domainapikey.Reconstruct(
+		"507f1f77bcf86cd799439099",
+		"aabbccdd",
+		"hash",
+		"ik_live_aabb...",
+		"user@example.com",
+		"Example App",
+		planType,
+		true,
+		domainapikey.Overage{},
+		mustTime(),
+	)
+	require.NoError(t, err)
+	return key
+}
+
+func TestCreateUpgradeURL(t *testing.T) {
+	t.Parallel()
+
+	upgradeConfig := func() Config {
+		return Config{
+			PriceIDs: map[plan.Type]string{
+				plan.TypeDeveloper: "price_dev",
+				plan.TypeBusiness:  "price_biz",
+			},
+			UpgradeSuccessURL: "https://app.example.com/billing/success",
+			UpgradeCancelURL:  "https://app.example.com/billing/cancel",
+		}
+	}
+
+	t.Run("サブスクリプションのないキーは同じキーを指定した Checkout Session を作成する", func(t *testing.T) {
+		t.Parallel()
+		stripeClient := &fakeStripeClient{}
+		service := NewService(stripeClient, newFakeFulfillmentRepo(), nil, &fakeAPIKeyIssuer{}, &fakeNotifier{}, upgradeConfig())
+
+		url, err := service.CreateUpgradeURL(context.Background(), upgradeTestKey(t, plan.TypeFree))
+		require.NoError(t, err)
+		assert.Equal(t, "https://checkout.stripe.test/session", url)
+		require.NotNil(t, stripeClient.checkoutInput)
+		assert.Equal(t, "price_dev", stripeClient.checkoutInput.PriceID)
+		assert.Equal(t, "developer", stripeClient.checkoutInput.PlanType)
+		assert.Equal(t, "507f1f77bcf86cd799439099", stripeClient.checkoutInput.APIKeyID)
+		assert.Empty(t, stripeClient.checkoutInput.CustomerID)
+		assert.Equal(t, "https://app.example.com/billing/success", stripeClient.checkoutInput.SuccessURL)
+	})
+
+	t.Run("有料サブスクリプションのあるキーは Customer Portal でプランを変更させる", func(t *testing.T) {
+		t.Parallel()
+		stripeClient := &fakeStripeClient{}
+		repo := newFakeFulfillmentRepo()
+		require.NoError(t, repo.Save(context.Background(), domainbilling.NewCheckoutFulfillment(
+			"cs_prev", "cus_123", "user@example.com", "Example App", plan.TypeDeveloper, "507f1f77bcf86cd799439099",
+		)))
+		service := NewService(stripeClient, repo, nil, &fakeAPIKeyIssuer{}, &fakeNotifier{}, upgradeConfig())
+
+		url, err := service.CreateUpgradeURL(context.Background(), upgradeTestKey(t, plan.TypeDeveloper))
+		require.NoError(t, err)
+		assert.Equal(t, "https://billing.stripe.test/session", url)
+		assert.Nil(t, stripeClient.checkoutInput)
+		require.NotNil(t, stripeClient.portalInput)
+		assert.Equal(t, "cus_123", stripeClient.portalInput.CustomerID)
+		assert.Equal(t, "https://app.example.com/billing/success", stripeClient.portalInput.ReturnURL)
+	})
+
+	t.Run("解約済みのキーは既存の顧客で新しい Checkout Session を作成する", func(t *testing.T) {
+		t.Parallel()
+		stripeClient := &fakeStripeClient{}
+		repo := newFakeFulfillmentRepo()
+		canceled := domainbilling.NewCheckoutFulfillment(
+			"cs_prev", "cus_123", "user@example.com", "Example App", plan.TypeDeveloper, "507f1f77bcf86cd799439099",
+		)
+		canceled.Cancel(time.Now())
+		require.NoError(t, repo.Save(context.Background(), canceled))
+		service := NewService(stripeClient, repo, nil, &fakeAPIKeyIssuer{}, &fakeNotifier{}, upgradeConfig())
+
+		_, err := service.CreateUpgradeURL(context.Background(), upgradeTestKey(t, plan.TypeFree))
+		require.NoError(t, err)
+		assert.Nil(t, stripeClient.portalInput)
+		require.NotNil(t, stripeClient.checkoutInput)
+		assert.Equal(t, "cus_123", stripeClient.checkoutInput.CustomerID)
+		assert.Equal(t, "507f1f77bcf86cd799439099", stripeClient.checkoutInput.APIKeyID)
+	})
+
+	t.Run("最上位プランや戻り先未設定ではリンクを生成しない", func(t *testing.T) {
+		t.Parallel()
+		stripeClient := &fakeStripeClient{}
+		cfg := upgradeConfig()
+		service := NewService(stripeClient, newFakeFulfillmentRepo(), nil, &fakeAPIKeyIssuer{}, &fakeNotifier{}, cfg)
+
+		url, err := service.CreateUpgradeURL(context.Background(), upgradeTestKey(t, plan.TypeBusiness))
+		require.NoError(t, err)
+		assert.Empty(t, url)
+
+		cfg.UpgradeCancelURL = ""
+		service = NewService(stripeClient, newFakeFulfillmentRepo(), nil, &fakeAPIKeyIssuer{}, &fakeNotifier{}, cfg)
+		url, err = service.CreateUpgradeURL(context.Background(), upgradeTestKey(t, plan.TypeDeveloper))
+		require.NoError(t, err)
+		assert.Empty(t, url)
+		assert.Nil(t, stripeClient.checkoutInput)
+		assert.Nil(t, stripeClient.portalInput)
+	})
+}
+
+func TestHandleStripeWebhook_UpgradeCheckoutChangesExistingKeyPlan(t *testing.T) {
+	t.Parallel()
+
+	stripeClient := &fakeStripeClient{
 		webhookEvent: &WebhookEvent{
-			Type: WebhookEventTypeInvoicePaid,
-			Invoice: &InvoiceUpdated{
+			Type: WebhookEventTypeCheckoutSessionCompleted,
+			CheckoutSession: &CheckoutSessionCompleted{
+				SessionID:  "cs_upgrade",
 				CustomerID: "cus_123",
-				Paid:       true,
+				Email:      "user@example.com",
+				Name:       "Example App",
+				PlanType:   plan.TypeBusiness,
+				APIKeyID:   "507f1f77bcf86cd799439099",
 			},
 		},
 	}
-	err = service.HandleStripeWebhook(context.Background(), []byte("{}"), "sig")
-	require.NoError(t, err)
-	assert.True(t, issuer.updatedKey.IsActive())
-	assert.Equal(t, plan.TypeDeveloper, issuer.updatedKey.PlanType())
+	repo := newFakeFulfillmentRepo()
+	issuer := &fakeAPIKeyIssuer{key: upgradeTestKey(t, plan.TypeDeveloper)}
+	notifier := &fakeNotifier{}
+	service := NewService(stripeClient, repo, nil, issuer, notifier, Config{
+		KeySeedSecret: "seed",
+		PriceIDs:      map[plan.Type]string{plan.TypeBusiness: "price_biz"},
+	})
+
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+	require.NoError(t, service.HandleStripeWebhook(context.Background(), []byte(`{}`), "sig"))
+
+	assert.Equal(t, 0, issuer.calls)
+	assert.Equal(t, 1, issuer.updates)
+	assert.Equal(t, plan.TypeBusiness, issuer.key.PlanType())
+	assert.Equal(t, 0, notifier.calls)
+	fulfillment := repo.bySession["cs_upgrade"]
+	require.NotNil(t, fulfillment)
+	assert.Equal(t, "507f1f77bcf86cd799439099", fulfillment.APIKeyID())
+	assert.True(t, fulfillment.Notified())
 }
 
 func mustTime() (tm time.Time) {
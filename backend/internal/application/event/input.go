@@ -1,5 +1,7 @@
 package event
 
+import "time"
+
 // PerformerInput はパフォーマー入力データ
 type PerformerInput struct {
 	PerformerID   string
@@ -18,6 +20,11 @@ type CreateInput struct {
 	OfficialURL   *string
 	Description   *string
 	Tags          []string
+	SeriesID      *string // 指定時はツアーの公演として作成し、ツアーの出演者・タグを引き継ぐ
+	Strict        bool    // true の場合は日程が重複するイベントがあれば作成しない
+	// CapacityConfigurationID は公演で使った会場の収容構成のID（会場の指定が必須）
+	CapacityConfigurationID *string
+	Streaming               *StreamingInput // 配信情報（会場も指定するとハイブリッド開催）
 }
 
 // UpdateInput はイベント更新の入力
@@ -30,6 +37,26 @@ type UpdateInput struct {
 	TicketURL     *string
 	OfficialURL   *string
 	Description   *string
+	SeriesID      *string // 空文字でツアーから外す（元のツアーから引き継いだ出演者・タグも外す）
+	Strict        bool    // true の場合は日程が重複するイベントがあれば更新しない
+	// CapacityConfigurationID は公演で使った会場の収容構成のID（空文字で解除。会場を変更すると省略時は解除される）
+	CapacityConfigurationID *string
+}
+
+// SetlistEntryInput はセットリストの1曲の入力。収録曲はリリースID + トラック番号で参照し、それ以外は曲名で登録する
+type SetlistEntryInput struct {
+	ReleaseID    *string
+	TrackNumber  *int
+	Title        string
+	IsEncore     bool
+	PerformerIDs []string
+	Note         *string
+}
+
+// UpdateSetlistInput はセットリスト更新の入力（演奏順に並べた全曲で置き換える）
+type UpdateSetlistInput struct {
+	EventID string
+	Entries []SetlistEntryInput
 }
 
 // AddPerformerInput はパフォーマー追加の入力
@@ -44,3 +71,39 @@ type RemovePerformerInput struct {
 	EventID     string
 	PerformerID string
 }
+
+// RecordAttendanceInput は動員実績の記録の入力
+type RecordAttendanceInput struct {
+	EventID    string
+	SoldOut    bool
+	Attendance *int
+}
+
+// CapacityReportInput は収容人数レポートの入力。ツアーか出演者のどちらか一方を指定する
+type CapacityReportInput struct {
+	SeriesID    *string
+	PerformerID *string
+	From        time.Time
+	To          time.Time // この時刻を含まない
+}
+
+// PriceTierInput は配信チケットの価格区分の入力
+type PriceTierInput struct {
+	Name  string
+	Price int
+}
+
+// StreamingInput は配信情報の入力
+type StreamingInput struct {
+	Platform     string
+	URL          *string
+	TicketURL    *string
+	ArchiveUntil *string // RFC3339形式
+	PriceTiers   []PriceTierInput
+}
+
+// UpdateStreamingInput は配信情報の更新の入力（Streaming が nil なら配信情報を削除する）
+type UpdateStreamingInput struct {
+	EventID   string
+	Streaming *StreamingInput
+}
@@ -2,12 +2,17 @@ package event
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/domain/release"
+	"github.com/kuro48/idol-api/internal/domain/tour"
+	"github.com/kuro48/idol-api/internal/domain/venue"
 	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
 	sharedid "github.com/kuro48/idol-api/internal/shared/id"
 )
@@ -15,6 +20,9 @@ import (
 // ApplicationService はイベントアプリケーションサービス
 type ApplicationService struct {
 	repository event.Repository
+	tours      tour.Repository
+	venues     VenueCatalog
+	tracks     TrackCatalog
 	publisher  WebhookPublisher
 }
 
@@ -23,10 +31,23 @@ type WebhookPublisher interface {
 	Publish(ctx context.Context, event domainWebhook.EventType, payload interface{}) error
 }
 
+// TrackCatalog はセットリストが参照する収録曲を引き当てる契約
+type TrackCatalog interface {
+	FindByID(ctx context.Context, id release.ReleaseID) (*release.Release, error)
+}
+
+// VenueCatalog は公演の収容構成・収容人数を引き当てる契約
+type VenueCatalog interface {
+	FindByID(ctx context.Context, id venue.VenueID) (*venue.Venue, error)
+}
+
 // NewApplicationService はアプリケーションサービスを作成する
-func NewApplicationService(repository event.Repository, publisher WebhookPublisher) *ApplicationService {
+func NewApplicationService(repository event.Repository, tours tour.Repository, venues VenueCatalog, tracks TrackCatalog, publisher WebhookPublisher) *ApplicationService {
 	return &ApplicationService{
 		repository: repository,
+		tours:      tours,
+		venues:     venues,
+		tracks:     tracks,
 		publisher:  publisher,
 	}
 }
@@ -39,6 +60,18 @@ func (s *ApplicationService) CreateEvent(ctx context.Context, input CreateInput)
 		return nil, fmt.Errorf("タイトルの生成エラー: %w", err)
 	}
 
+	// ツアーの公演はイベントタイプを省略するとツアーのものを使う
+	var series *tour.Tour
+	if input.SeriesID != nil {
+		series, err = s.findSeries(ctx, *input.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+		if input.EventType == "" {
+			input.EventType = series.EventType().Value()
+		}
+	}
+
 	eventType, err := event.NewEventType(input.EventType)
 	if err != nil {
 		return nil, fmt.Errorf("イベントタイプの生成エラー: %w", err)
@@ -100,6 +133,30 @@ func (s *ApplicationService) CreateEvent(ctx context.Context, input CreateInput)
 		}
 	}
 
+	if series != nil {
+		newEvent.JoinSeries(series.ID().Value(), series.Performers(), series.Tags())
+	}
+
+	if err := s.applyCapacityConfiguration(ctx, newEvent, input.CapacityConfigurationID, false); err != nil {
+		return nil, err
+	}
+
+	if input.Streaming != nil {
+		streaming, err := buildStreaming(*input.Streaming)
+		if err != nil {
+			return nil, err
+		}
+		if err := newEvent.SetStreaming(&streaming); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Strict {
+		if err := s.rejectConflicts(ctx, newEvent); err != nil {
+			return nil, err
+		}
+	}
+
 	// 保存
 	if err := s.repository.Save(ctx, newEvent); err != nil {
 		return nil, fmt.Errorf("イベントの保存エラー: %w", err)
@@ -200,6 +257,8 @@ func (s *ApplicationService) UpdateEvent(ctx context.Context, input UpdateInput)
 		endDateTime = &parsed
 	}
 
+	venueChanged := input.VenueID != nil && !equalStringPtr(existingEvent.VenueID(), input.VenueID)
+
 	// 更新
 	existingEvent.UpdateDetails(
 		newTitle,
@@ -211,6 +270,36 @@ func (s *ApplicationService) UpdateEvent(ctx context.Context, input UpdateInput)
 		input.Description,
 	)
 
+	// ツアーへの追加・ツアーからの除外。元のツアーから外れる場合は引き継いだ出演者・タグも外す
+	if input.SeriesID != nil {
+		if *input.SeriesID == "" {
+			existingEvent.LeaveSeries()
+		} else {
+			series, err := s.findSeries(ctx, *input.SeriesID)
+			if err != nil {
+				return err
+			}
+			existingEvent.JoinSeries(series.ID().Value(), series.Performers(), series.Tags())
+		}
+	}
+
+	if err := s.applyCapacityConfiguration(ctx, existingEvent, input.CapacityConfigurationID, venueChanged); err != nil {
+		return err
+	}
+
+	// 開始日時を変更した場合もアーカイブの視聴期限が開始日時より後かを確認する
+	if existingEvent.Streaming() != nil {
+		if err := existingEvent.SetStreaming(existingEvent.Streaming()); err != nil {
+			return err
+		}
+	}
+
+	if input.Strict {
+		if err := s.rejectConflicts(ctx, existingEvent); err != nil {
+			return err
+		}
+	}
+
 	// 保存
 	if err := s.repository.Update(ctx, existingEvent); err != nil {
 		return fmt.Errorf("イベントの更新エラー: %w", err)
@@ -303,6 +392,336 @@ func (s *ApplicationService) RemovePerformer(ctx context.Context, input RemovePe
 	return nil
 }
 
+// UpdateSetlist はイベントのセットリストを置き換える。
+// 収録曲を参照する曲は存在を確認し、曲名は収録曲のものを使う
+func (s *ApplicationService) UpdateSetlist(ctx context.Context, input UpdateSetlistInput) error {
+	id, err := event.NewEventID(input.EventID)
+	if err != nil {
+		return fmt.Errorf("IDの生成エラー: %w", err)
+	}
+
+	existingEvent, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("イベントの取得エラー: %w", err)
+	}
+
+	entries, err := s.buildSetlist(ctx, input.Entries)
+	if err != nil {
+		return err
+	}
+	if err := existingEvent.SetSetlist(entries); err != nil {
+		return err
+	}
+
+	if err := s.repository.Update(ctx, existingEvent); err != nil {
+		return fmt.Errorf("イベントの更新エラー: %w", err)
+	}
+
+	s.publishWebhook(ctx, domainWebhook.EventEventUpdated, eventWebhookPayload(existingEvent))
+
+	return nil
+}
+
+// buildSetlist はセットリストの入力を検証して曲の一覧を生成する
+func (s *ApplicationService) buildSetlist(ctx context.Context, inputs []SetlistEntryInput) ([]event.SetlistEntry, error) {
+	releases := make(map[string]*release.Release)
+	entries := make([]event.SetlistEntry, 0, len(inputs))
+	for i, in := range inputs {
+		entry, err := event.NewSetlistEntry(in.ReleaseID, in.TrackNumber, in.Title, in.IsEncore, in.PerformerIDs, in.Note)
+		if err != nil {
+			return nil, fmt.Errorf("%d曲目: %w", i+1, err)
+		}
+		if entry.IsTrack() {
+			track, err := s.findTrack(ctx, releases, *in.ReleaseID, *in.TrackNumber)
+			if err != nil {
+				return nil, fmt.Errorf("%d曲目: %w", i+1, err)
+			}
+			entry = entry.WithTitle(track.Title())
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// findTrack はリリースの収録曲を取得する。同じリリースは一度だけ読み込む
+func (s *ApplicationService) findTrack(ctx context.Context, cache map[string]*release.Release, releaseID string, trackNumber int) (release.Track, error) {
+	r, ok := cache[releaseID]
+	if !ok {
+		if s.tracks == nil {
+			return release.Track{}, errors.New("リリースが見つかりません")
+		}
+		rid, err := release.NewReleaseID(releaseID)
+		if err != nil {
+			return release.Track{}, err
+		}
+		r, err = s.tracks.FindByID(ctx, rid)
+		if err != nil {
+			return release.Track{}, fmt.Errorf("リリースの取得エラー: %w", err)
+		}
+		cache[releaseID] = r
+	}
+	for _, t := range r.Tracks() {
+		if t.TrackNumber() == trackNumber {
+			return t, nil
+		}
+	}
+	return release.Track{}, fmt.Errorf("リリースにトラック番号 %d の収録曲が見つかりません", trackNumber)
+}
+
+// applyCapacityConfiguration は公演で使った収容構成を設定し、会場に登録されている構成かを確認する。
+// 会場を変更して収容構成を指定しなかった場合は、変更前の会場の構成なので解除する
+func (s *ApplicationService) applyCapacityConfiguration(ctx context.Context, e *event.Event, configurationID *string, venueChanged bool) error {
+	if configurationID == nil {
+		if venueChanged && e.CapacityConfigurationID() != nil {
+			e.UseCapacityConfiguration(nil)
+		}
+		return nil
+	}
+	if *configurationID == "" {
+		e.UseCapacityConfiguration(nil)
+		return nil
+	}
+	if e.VenueID() == nil {
+		return errors.New("収容構成を指定するには会場が必須です")
+	}
+	v, err := s.findVenue(ctx, *e.VenueID())
+	if err != nil {
+		return err
+	}
+	if _, ok := v.Configuration(*configurationID); !ok {
+		return fmt.Errorf("会場に収容構成が見つかりません: %s", *configurationID)
+	}
+	e.UseCapacityConfiguration(configurationID)
+	return nil
+}
+
+// UpdateStreaming はイベントの配信情報を置き換える。入力が nil なら配信情報を削除する
+func (s *ApplicationService) UpdateStreaming(ctx context.Context, input UpdateStreamingInput) error {
+	existingEvent, err := s.GetEvent(ctx, input.EventID)
+	if err != nil {
+		return err
+	}
+
+	var streaming *event.Streaming
+	if input.Streaming != nil {
+		built, err := buildStreaming(*input.Streaming)
+		if err != nil {
+			return err
+		}
+		streaming = &built
+	}
+	if err := existingEvent.SetStreaming(streaming); err != nil {
+		return err
+	}
+
+	if err := s.repository.Update(ctx, existingEvent); err != nil {
+		return fmt.Errorf("イベントの更新エラー: %w", err)
+	}
+
+	s.publishWebhook(ctx, domainWebhook.EventEventUpdated, eventWebhookPayload(existingEvent))
+
+	return nil
+}
+
+// buildStreaming は配信情報の入力を検証して値オブジェクトを生成する
+func buildStreaming(input StreamingInput) (event.Streaming, error) {
+	var archiveUntil *time.Time
+	if input.ArchiveUntil != nil {
+		parsed, err := time.Parse(time.RFC3339, *input.ArchiveUntil)
+		if err != nil {
+			return event.Streaming{}, fmt.Errorf("アーカイブの視聴期限の形式が不正です: %w", err)
+		}
+		archiveUntil = &parsed
+	}
+	tiers := make([]event.PriceTier, 0, len(input.PriceTiers))
+	for _, t := range input.PriceTiers {
+		tiers = append(tiers, event.PriceTier{Name: t.Name, Price: t.Price})
+	}
+	return event.NewStreaming(event.StreamingPlatform(input.Platform), input.URL, input.TicketURL, archiveUntil, tiers)
+}
+
+// RecordAttendance はイベントの完売・動員数を記録する
+func (s *ApplicationService) RecordAttendance(ctx context.Context, input RecordAttendanceInput) error {
+	existingEvent, err := s.GetEvent(ctx, input.EventID)
+	if err != nil {
+		return err
+	}
+
+	attendance, err := event.NewAttendance(input.SoldOut, input.Attendance)
+	if err != nil {
+		return err
+	}
+	if err := existingEvent.RecordAttendance(attendance); err != nil {
+		return err
+	}
+
+	if err := s.repository.Update(ctx, existingEvent); err != nil {
+		return fmt.Errorf("イベントの更新エラー: %w", err)
+	}
+
+	s.publishWebhook(ctx, domainWebhook.EventEventUpdated, eventWebhookPayload(existingEvent))
+
+	return nil
+}
+
+// capacityReportPageSize は収容人数レポートでイベントを読み込む1ページの件数
+const capacityReportPageSize = 1000
+
+// CapacityReport はツアーまたは出演者のイベントの収容人数・動員を月ごとに集計する。
+// 収容人数は公演で使った収容構成、指定がなければ会場の収容人数を使う
+func (s *ApplicationService) CapacityReport(ctx context.Context, input CapacityReportInput) ([]event.CapacityStats, error) {
+	if (input.SeriesID == nil) == (input.PerformerID == nil) {
+		return nil, errors.New("ツアーIDと出演者IDのどちらか一方の指定が必須です")
+	}
+	from, to := input.From, input.To
+	// 期間内のイベントを取りこぼさないようページ単位ですべて読み込む
+	var events []*event.Event
+	for offset := 0; ; offset += capacityReportPageSize {
+		page, err := s.repository.Search(ctx, event.SearchCriteria{
+			SeriesID:      input.SeriesID,
+			PerformerID:   input.PerformerID,
+			StartDateFrom: &from,
+			StartDateTo:   &to,
+			Sort:          "start_date_time",
+			Order:         "asc",
+			Offset:        offset,
+			Limit:         capacityReportPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("イベントの取得エラー: %w", err)
+		}
+		events = append(events, page...)
+		if len(page) < capacityReportPageSize {
+			break
+		}
+	}
+
+	venues := make(map[string]*venue.Venue)
+	for _, e := range events {
+		if e.VenueID() == nil {
+			continue
+		}
+		if _, ok := venues[*e.VenueID()]; ok {
+			continue
+		}
+		v, err := s.findVenue(ctx, *e.VenueID())
+		if err != nil {
+			// 削除済みの会場は収容人数不明として集計し、それ以外の取得エラーは返す
+			if !strings.Contains(err.Error(), "見つかりません") {
+				return nil, err
+			}
+			venues[*e.VenueID()] = nil
+			continue
+		}
+		venues[*e.VenueID()] = v
+	}
+
+	return event.AggregateCapacity(events, input.From, input.To, func(e *event.Event) *int {
+		if e.VenueID() == nil || venues[*e.VenueID()] == nil {
+			return nil
+		}
+		return venues[*e.VenueID()].CapacityFor(e.CapacityConfigurationID())
+	}), nil
+}
+
+// findVenue は公演の会場を取得する
+func (s *ApplicationService) findVenue(ctx context.Context, id string) (*venue.Venue, error) {
+	if s.venues == nil {
+		return nil, errors.New("会場が見つかりません")
+	}
+	venueID, err := venue.NewVenueID(id)
+	if err != nil {
+		return nil, fmt.Errorf("会場IDが不正です: %w", err)
+	}
+	v, err := s.venues.FindByID(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("会場の取得エラー: %w", err)
+	}
+	return v, nil
+}
+
+// FindConflicts はイベントと日程が重複している他のイベントを取得する
+func (s *ApplicationService) FindConflicts(ctx context.Context, id string) ([]event.Conflict, error) {
+	target, err := s.GetEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.detectConflicts(ctx, target)
+}
+
+// ConflictReport は [from, to) に開催されるイベント同士の日程重複をすべて取得する
+func (s *ApplicationService) ConflictReport(ctx context.Context, from, to time.Time) ([]event.Conflict, error) {
+	events, err := s.repository.FindOverlapping(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("イベントの取得エラー: %w", err)
+	}
+	return event.FindConflicts(events), nil
+}
+
+// detectConflicts はイベントの開催時間帯に重なる他のイベントとの日程重複を取得する
+func (s *ApplicationService) detectConflicts(ctx context.Context, target *event.Event) ([]event.Conflict, error) {
+	candidates, err := s.repository.FindOverlapping(ctx, target.StartDateTime(), target.OccupiedUntil())
+	if err != nil {
+		return nil, fmt.Errorf("日程重複の確認エラー: %w", err)
+	}
+	return event.DetectConflicts(target, candidates), nil
+}
+
+// rejectConflicts は日程が重複するイベントがあればその一覧をエラーとして返す
+func (s *ApplicationService) rejectConflicts(ctx context.Context, target *event.Event) error {
+	conflicts, err := s.detectConflicts(ctx, target)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	details := make([]string, 0, len(conflicts))
+	for _, c := range conflicts {
+		subject := "出演者"
+		if c.Kind == event.ConflictKindVenue {
+			subject = "会場"
+		}
+		details = append(details, fmt.Sprintf("%s %s が「%s」(%s) と重複", subject, c.SubjectID, c.Other.Title().Value(), c.Other.ID().Value()))
+	}
+	return fmt.Errorf("日程が重複するイベントがあります: %s", strings.Join(details, "、"))
+}
+
+// completionBatchSize は自動終了で一度に読み込むイベント数
+const completionBatchSize = 100
+
+// CompleteFinishedEvents は終了時刻を過ぎた予定のイベントを終了にし、終了にした件数を返す。
+// ステータスが予定のままの場合のみ更新するため、複数レプリカで同時に実行しても通知は1回だけ
+func (s *ApplicationService) CompleteFinishedEvents(ctx context.Context, now time.Time) (int, error) {
+	completed := 0
+	for {
+		due, err := s.repository.FindDueForCompletion(ctx, now, completionBatchSize)
+		if err != nil {
+			return completed, fmt.Errorf("終了対象のイベント取得エラー: %w", err)
+		}
+
+		for _, e := range due {
+			if err := e.Complete(now); err != nil {
+				return completed, err
+			}
+			ok, err := s.repository.TransitionStatus(ctx, e, event.EventStatusScheduled)
+			if err != nil {
+				return completed, err
+			}
+			if !ok {
+				// 他のレプリカが終了にしたか、その間にステータスが変更された
+				continue
+			}
+			completed++
+			s.publishWebhook(ctx, domainWebhook.EventEventUpdated, eventWebhookPayload(e))
+		}
+
+		if len(due) < completionBatchSize {
+			return completed, nil
+		}
+	}
+}
+
 // FindUpcoming は今後開催されるイベントを取得する
 func (s *ApplicationService) FindUpcoming(ctx context.Context, limit int) ([]*event.Event, error) {
 	events, err := s.repository.FindUpcoming(ctx, limit)
@@ -313,6 +732,22 @@ func (s *ApplicationService) FindUpcoming(ctx context.Context, limit int) ([]*ev
 	return events, nil
 }
 
+// findSeries はイベントを所属させるツアーを取得する
+func (s *ApplicationService) findSeries(ctx context.Context, id string) (*tour.Tour, error) {
+	if s.tours == nil {
+		return nil, errors.New("ツアーが見つかりません")
+	}
+	tourID, err := tour.NewTourID(id)
+	if err != nil {
+		return nil, fmt.Errorf("ツアーIDが不正です: %w", err)
+	}
+	series, err := s.tours.FindByID(ctx, tourID)
+	if err != nil {
+		return nil, fmt.Errorf("ツアーの取得エラー: %w", err)
+	}
+	return series, nil
+}
+
 func (s *ApplicationService) publishWebhook(ctx context.Context, eventType domainWebhook.EventType, payload interface{}) {
 	if s.publisher == nil {
 		return
@@ -322,11 +757,19 @@ func (s *ApplicationService) publishWebhook(ctx context.Context, eventType domai
 	}
 }
 
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func eventWebhookPayload(entity *event.Event) map[string]interface{} {
 	payload := map[string]interface{}{
 		"id":              entity.ID().Value(),
 		"title":           entity.Title().Value(),
 		"event_type":      entity.EventType().Value(),
+		"status":          string(entity.Status()),
 		"start_date_time": entity.StartDateTime().Format(time.RFC3339),
 		"performer_ids":   entity.PerformerIDs(),
 		"tags":            entity.Tags(),
@@ -346,5 +789,12 @@ func eventWebhookPayload(entity *event.Event) map[string]interface{} {
 	if entity.Description() != nil {
 		payload["description"] = *entity.Description()
 	}
+	if entity.SeriesID() != nil {
+		payload["series_id"] = *entity.SeriesID()
+	}
+	payload["attendance_mode"] = string(entity.AttendanceMode())
+	if entity.Streaming() != nil {
+		payload["streaming_platform"] = string(entity.Streaming().Platform())
+	}
 	return payload
 }
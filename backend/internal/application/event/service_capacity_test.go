@@ -0,0 +1,191 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/domain/venue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type venueCatalogStub struct {
+	data map[string]*venue.Venue
+}
+
+func (s *venueCatalogStub) FindByID(_ context.Context, id venue.VenueID) (*venue.Venue, error) {
+	v, ok := s.data[id.Value()]
+	if !ok {
+		return nil, errors.New("会場が見つかりません")
+	}
+	return v, nil
+}
+
+func newVenueCatalogStub(t *testing.T) *venueCatalogStub {
+	t.Helper()
+	arena, err := venue.NewVenue("さいたまアリーナ")
+	require.NoError(t, err)
+	arenaID, err := venue.NewVenueID("venue-arena")
+	require.NoError(t, err)
+	arena.SetID(arenaID)
+	capacity := 20000
+	arena.UpdateCapacity(&capacity)
+	standing, err := venue.NewCapacityConfiguration("conf-standing", "スタンディング", venue.LayoutStanding, 30000)
+	require.NoError(t, err)
+	center, err := venue.NewCapacityConfiguration("conf-center", "センターステージ", venue.LayoutCenterStage, 25000)
+	require.NoError(t, err)
+	require.NoError(t, arena.SetConfigurations([]venue.CapacityConfiguration{standing, center}))
+
+	hall, err := venue.NewVenue("ライブハウス")
+	require.NoError(t, err)
+	hallID, err := venue.NewVenueID("venue-hall")
+	require.NoError(t, err)
+	hall.SetID(hallID)
+
+	return &venueCatalogStub{data: map[string]*venue.Venue{"venue-arena": arena, "venue-hall": hall}}
+}
+
+func TestApplicationService_CapacityConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newEventRepoStub()
+	svc := NewApplicationService(repo, nil, newVenueCatalogStub(t), nil, nil)
+	start := time.Date(2026, 8, 1, 18, 0, 0, 0, time.UTC)
+
+	input := conflictTestInput("アリーナ公演", "venue-arena", start, "idol-1")
+	missing := "conf-missing"
+	input.CapacityConfigurationID = &missing
+	_, err := svc.CreateEvent(ctx, input)
+	assert.ErrorContains(t, err, "会場に収容構成が見つかりません")
+
+	center := "conf-center"
+	input.CapacityConfigurationID = &center
+	created, err := svc.CreateEvent(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, "conf-center", *created.CapacityConfigurationID())
+
+	// 会場を変更すると変更前の会場の収容構成は解除される
+	hall := "venue-hall"
+	require.NoError(t, svc.UpdateEvent(ctx, UpdateInput{ID: created.ID().Value(), VenueID: &hall}))
+	assert.Nil(t, repo.data[created.ID().Value()].CapacityConfigurationID())
+
+	noVenue := CreateInput{Title: "配信", EventType: "online", StartDateTime: start.Format(time.RFC3339), CapacityConfigurationID: &center}
+	_, err = svc.CreateEvent(ctx, noVenue)
+	assert.ErrorContains(t, err, "会場が必須")
+}
+
+func TestApplicationService_CapacityReport(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newEventRepoStub()
+	svc := NewApplicationService(repo, nil, newVenueCatalogStub(t), nil, nil)
+
+	create := func(title, venueID string, start time.Time, configurationID *string) string {
+		input := conflictTestInput(title, venueID, start, "idol-1")
+		input.CapacityConfigurationID = configurationID
+		created, err := svc.CreateEvent(ctx, input)
+		require.NoError(t, err)
+		return created.ID().Value()
+	}
+	standing := "conf-standing"
+	day1 := create("8月1日公演", "venue-arena", time.Date(2026, 8, 1, 9, 0, 0, 0, time.UTC), &standing)
+	create("8月2日公演", "venue-arena", time.Date(2026, 8, 2, 9, 0, 0, 0, time.UTC), nil)
+	create("ライブハウス公演", "venue-hall", time.Date(2026, 8, 20, 9, 0, 0, 0, time.UTC), nil)
+	// JST では9月1日
+	create("9月公演", "venue-arena", time.Date(2026, 8, 31, 16, 0, 0, 0, time.UTC), nil)
+	cancelled := create("中止公演", "venue-arena", time.Date(2026, 8, 10, 9, 0, 0, 0, time.UTC), nil)
+	require.NoError(t, repo.data[cancelled].UpdateStatus(domain.EventStatusCancelled))
+
+	count := 29000
+	require.NoError(t, svc.RecordAttendance(ctx, RecordAttendanceInput{EventID: day1, SoldOut: true, Attendance: &count}))
+	err := svc.RecordAttendance(ctx, RecordAttendanceInput{EventID: cancelled, SoldOut: true})
+	assert.ErrorContains(t, err, "中止・延期")
+
+	jst := time.FixedZone("JST", 9*60*60)
+	performer := "idol-1"
+	stats, err := svc.CapacityReport(ctx, CapacityReportInput{
+		PerformerID: &performer,
+		From:        time.Date(2026, 8, 1, 0, 0, 0, 0, jst),
+		To:          time.Date(2026, 10, 1, 0, 0, 0, 0, jst),
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	aug := stats[0]
+	assert.Equal(t, 3, aug.Events)
+	assert.Equal(t, 30000+20000, aug.CapacityTotal) // 収容構成、なければ会場の収容人数
+	assert.Equal(t, 1, aug.UnknownCapacity)         // 収容人数未登録のライブハウス
+	assert.Equal(t, 1, aug.SoldOut)
+	assert.Equal(t, 29000, aug.AttendanceTotal)
+	assert.Equal(t, 1, aug.AttendanceRecorded)
+
+	assert.Equal(t, 1, stats[1].Events)
+	assert.Equal(t, 20000, stats[1].CapacityTotal)
+
+	_, err = svc.CapacityReport(ctx, CapacityReportInput{From: time.Now(), To: time.Now()})
+	assert.ErrorContains(t, err, "どちらか一方")
+}
+
+func TestApplicationService_CapacityReportCountsRemovedConfigurationAsUnknown(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newEventRepoStub()
+	catalog := newVenueCatalogStub(t)
+	svc := NewApplicationService(repo, nil, catalog, nil, nil)
+
+	center := "conf-center"
+	input := conflictTestInput("センターステージ公演", "venue-arena", time.Date(2026, 8, 1, 9, 0, 0, 0, time.UTC), "idol-1")
+	input.CapacityConfigurationID = &center
+	_, err := svc.CreateEvent(ctx, input)
+	require.NoError(t, err)
+
+	// イベントが参照したまま収容構成が削除された場合は会場全体の収容人数で代用しない
+	require.NoError(t, catalog.data["venue-arena"].SetConfigurations(nil))
+
+	jst := time.FixedZone("JST", 9*60*60)
+	performer := "idol-1"
+	stats, err := svc.CapacityReport(ctx, CapacityReportInput{
+		PerformerID: &performer,
+		From:        time.Date(2026, 8, 1, 0, 0, 0, 0, jst),
+		To:          time.Date(2026, 9, 1, 0, 0, 0, 0, jst),
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, 0, stats[0].CapacityTotal)
+	assert.Equal(t, 1, stats[0].UnknownCapacity)
+}
+
+type failingVenueCatalogStub struct {
+	*venueCatalogStub
+	failing bool
+}
+
+func (s *failingVenueCatalogStub) FindByID(ctx context.Context, id venue.VenueID) (*venue.Venue, error) {
+	if s.failing {
+		return nil, errors.New("会場取得エラー: connection refused")
+	}
+	return s.venueCatalogStub.FindByID(ctx, id)
+}
+
+func TestApplicationService_CapacityReportPropagatesVenueLookupErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	catalog := &failingVenueCatalogStub{venueCatalogStub: newVenueCatalogStub(t)}
+	svc := NewApplicationService(newEventRepoStub(), nil, catalog, nil, nil)
+	start := time.Date(2026, 8, 1, 9, 0, 0, 0, time.UTC)
+	_, err := svc.CreateEvent(ctx, conflictTestInput("アリーナ公演", "venue-arena", start, "idol-1"))
+	require.NoError(t, err)
+
+	// 会場の取得に失敗した場合は収容人数不明として集計せずエラーにする
+	catalog.failing = true
+	performer := "idol-1"
+	_, err = svc.CapacityReport(ctx, CapacityReportInput{PerformerID: &performer, From: start.AddDate(0, 0, -1), To: start.AddDate(0, 1, 0)})
+	assert.ErrorContains(t, err, "connection refused")
+}
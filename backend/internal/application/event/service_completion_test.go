@@ -0,0 +1,65 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/event"
+	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_CompleteFinishedEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newEventRepoStub()
+	publisher := &eventWebhookPublisherStub{}
+	svc := NewApplicationService(repo, nil, nil, nil, publisher)
+
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 8, 2, 0, 30, 0, 0, jst)
+	create := func(title string, start time.Time, end *time.Time) string {
+		input := CreateInput{Title: title, EventType: "live", StartDateTime: start.Format(time.RFC3339)}
+		if end != nil {
+			e := end.Format(time.RFC3339)
+			input.EndDateTime = &e
+		}
+		created, err := svc.CreateEvent(ctx, input)
+		require.NoError(t, err)
+		return created.ID().Value()
+	}
+
+	endedAt := now.Add(-time.Hour)
+	ended := create("終了済み", now.Add(-3*time.Hour), &endedAt)
+	// 終了日時なし: 前日（JST）開始なので日付が変わった時点で終了
+	yesterday := create("前日公演", time.Date(2026, 8, 1, 18, 0, 0, 0, jst), nil)
+	// 終了日時なし: 当日開始なので当日中は終了にしない
+	today := create("当日公演", time.Date(2026, 8, 2, 0, 10, 0, 0, jst), nil)
+	runningUntil := now.Add(time.Hour)
+	running := create("開催中", now.Add(-time.Hour), &runningUntil)
+	publisher.calls = nil
+
+	completed, err := svc.CompleteFinishedEvents(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, completed)
+
+	assert.Equal(t, domain.EventStatusCompleted, repo.data[ended].Status())
+	assert.Equal(t, domain.EventStatusCompleted, repo.data[yesterday].Status())
+	assert.Equal(t, domain.EventStatusScheduled, repo.data[today].Status())
+	assert.Equal(t, domain.EventStatusScheduled, repo.data[running].Status())
+
+	require.Len(t, publisher.calls, 2)
+	for _, call := range publisher.calls {
+		assert.Equal(t, domainWebhook.EventEventUpdated, call.event)
+		assert.Equal(t, "completed", call.payload.(map[string]interface{})["status"])
+	}
+
+	// 終了済みのイベントは再度終了にしない
+	completed, err = svc.CompleteFinishedEvents(ctx, now)
+	require.NoError(t, err)
+	assert.Zero(t, completed)
+	assert.Len(t, publisher.calls, 2)
+}
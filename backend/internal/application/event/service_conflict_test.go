@@ -0,0 +1,95 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func conflictTestInput(title, venueID string, start time.Time, performerIDs ...string) CreateInput {
+	performers := make([]PerformerInput, 0, len(performerIDs))
+	for _, id := range performerIDs {
+		performers = append(performers, PerformerInput{PerformerID: id})
+	}
+	return CreateInput{
+		Title:         title,
+		EventType:     "live",
+		StartDateTime: start.Format(time.RFC3339),
+		VenueID:       &venueID,
+		Performers:    performers,
+	}
+}
+
+func TestApplicationService_FindConflicts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc := NewApplicationService(newEventRepoStub(), nil, nil, nil, nil)
+	start := time.Date(2026, 8, 1, 18, 0, 0, 0, time.UTC)
+
+	base, err := svc.CreateEvent(ctx, conflictTestInput("渋谷公演", "venue-1", start, "idol-1", "idol-2"))
+	require.NoError(t, err)
+	// 終了日時なしは3時間とみなすため、2時間後の別会場公演と重なる
+	_, err = svc.CreateEvent(ctx, conflictTestInput("新宿公演", "venue-2", start.Add(2*time.Hour), "idol-2"))
+	require.NoError(t, err)
+	// 同じ会場の別イベント
+	_, err = svc.CreateEvent(ctx, conflictTestInput("対バン", "venue-1", start.Add(time.Hour), "idol-3"))
+	require.NoError(t, err)
+	// 時間帯が重ならない
+	_, err = svc.CreateEvent(ctx, conflictTestInput("翌日公演", "venue-2", start.Add(24*time.Hour), "idol-1"))
+	require.NoError(t, err)
+
+	conflicts, err := svc.FindConflicts(ctx, base.ID().Value())
+	require.NoError(t, err)
+	require.Len(t, conflicts, 2)
+
+	kinds := map[domain.ConflictKind]string{}
+	for _, c := range conflicts {
+		kinds[c.Kind] = c.SubjectID
+	}
+	assert.Equal(t, "idol-2", kinds[domain.ConflictKindPerformer])
+	assert.Equal(t, "venue-1", kinds[domain.ConflictKindVenue])
+
+	report, err := svc.ConflictReport(ctx, start.Add(-time.Hour), start.Add(48*time.Hour))
+	require.NoError(t, err)
+	// 渋谷-新宿（出演者）、渋谷-対バン（会場）の2件
+	assert.Len(t, report, 2)
+}
+
+func TestApplicationService_StrictRejectsConflicts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newEventRepoStub()
+	svc := NewApplicationService(repo, nil, nil, nil, nil)
+	start := time.Date(2026, 8, 1, 18, 0, 0, 0, time.UTC)
+
+	_, err := svc.CreateEvent(ctx, conflictTestInput("渋谷公演", "venue-1", start, "idol-1"))
+	require.NoError(t, err)
+
+	input := conflictTestInput("新宿公演", "venue-2", start.Add(time.Hour), "idol-1")
+	input.Strict = true
+	_, err = svc.CreateEvent(ctx, input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "日程が重複するイベントがあります")
+	assert.Len(t, repo.data, 1)
+
+	input.StartDateTime = start.Add(5 * time.Hour).Format(time.RFC3339)
+	moved, err := svc.CreateEvent(ctx, input)
+	require.NoError(t, err)
+
+	back := start.Add(time.Hour).Format(time.RFC3339)
+	err = svc.UpdateEvent(ctx, UpdateInput{ID: moved.ID().Value(), StartDateTime: &back, Strict: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "重複")
+
+	// 中止したイベントとは重複しない
+	cancelled := repo.data[moved.ID().Value()]
+	require.NoError(t, cancelled.UpdateStatus(domain.EventStatusCancelled))
+	err = svc.UpdateEvent(ctx, UpdateInput{ID: moved.ID().Value(), StartDateTime: &back, Strict: true})
+	require.NoError(t, err)
+}
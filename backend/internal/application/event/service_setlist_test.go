@@ -0,0 +1,154 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/release"
+	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type trackCatalogStub struct {
+	data  map[string]*release.Release
+	loads int
+}
+
+func (s *trackCatalogStub) FindByID(_ context.Context, id release.ReleaseID) (*release.Release, error) {
+	s.loads++
+	r, ok := s.data[id.Value()]
+	if !ok {
+		return nil, errors.New("リリースが見つかりません")
+	}
+	return r, nil
+}
+
+func newTrackCatalogStub(t *testing.T) *trackCatalogStub {
+	t.Helper()
+	id, err := release.NewReleaseID("rel-1")
+	require.NoError(t, err)
+	title, err := release.NewReleaseTitle("1stシングル")
+	require.NoError(t, err)
+	track1, err := release.NewTrack(1, "デビュー曲", nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	track2, err := release.NewTrack(2, "カップリング曲", nil, nil, nil, nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	r := release.Reconstruct(id, title, release.ReleaseTypeSingle, release.NewReleaseDate(time.Now()), nil,
+		[]release.Track{track1, track2}, nil, nil, nil, nil, nil, nil, time.Now(), time.Now())
+	return &trackCatalogStub{data: map[string]*release.Release{"rel-1": r}}
+}
+
+func setlistTestEvent(t *testing.T, svc *ApplicationService, eventType string) string {
+	t.Helper()
+	created, err := svc.CreateEvent(context.Background(), CreateInput{
+		Title:         "単独ライブ",
+		EventType:     eventType,
+		StartDateTime: time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+		Performers:    []PerformerInput{{PerformerID: "idol-1"}, {PerformerID: "idol-2"}},
+	})
+	require.NoError(t, err)
+	return created.ID().Value()
+}
+
+func TestApplicationService_UpdateSetlist(t *testing.T) {
+	t.Parallel()
+
+	repo := newEventRepoStub()
+	tracks := newTrackCatalogStub(t)
+	publisher := &eventWebhookPublisherStub{}
+	svc := NewApplicationService(repo, nil, nil, tracks, publisher)
+	eventID := setlistTestEvent(t, svc, "live")
+
+	releaseID := "rel-1"
+	one, two := 1, 2
+	err := svc.UpdateSetlist(context.Background(), UpdateSetlistInput{
+		EventID: eventID,
+		Entries: []SetlistEntryInput{
+			{ReleaseID: &releaseID, TrackNumber: &one, Title: "入力した曲名は使わない"},
+			{Title: "カバー曲", PerformerIDs: []string{"idol-2"}},
+			{ReleaseID: &releaseID, TrackNumber: &two, IsEncore: true},
+		},
+	})
+	require.NoError(t, err)
+
+	saved := repo.data[eventID]
+	require.Len(t, saved.Setlist(), 3)
+	assert.Equal(t, "デビュー曲", saved.Setlist()[0].Title())
+	assert.False(t, saved.Setlist()[1].IsTrack())
+	assert.Equal(t, []string{"idol-2"}, saved.Setlist()[1].PerformerIDs())
+	assert.Equal(t, "カップリング曲", saved.Setlist()[2].Title())
+	assert.True(t, saved.Setlist()[2].IsEncore())
+	assert.Equal(t, 1, tracks.loads)
+
+	require.Len(t, publisher.calls, 2)
+	assert.Equal(t, domainWebhook.EventEventUpdated, publisher.calls[1].event)
+}
+
+func TestApplicationService_RemovePerformerStripsSetlistEntries(t *testing.T) {
+	t.Parallel()
+
+	repo := newEventRepoStub()
+	svc := NewApplicationService(repo, nil, nil, newTrackCatalogStub(t), nil)
+	eventID := setlistTestEvent(t, svc, "live")
+
+	err := svc.UpdateSetlist(context.Background(), UpdateSetlistInput{
+		EventID: eventID,
+		Entries: []SetlistEntryInput{
+			{Title: "ユニット曲", PerformerIDs: []string{"idol-1", "idol-2"}},
+			{Title: "ソロ曲", PerformerIDs: []string{"idol-2"}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.RemovePerformer(context.Background(), RemovePerformerInput{EventID: eventID, PerformerID: "idol-2"}))
+
+	saved := repo.data[eventID]
+	assert.Equal(t, []string{"idol-1"}, saved.PerformerIDs())
+	require.Len(t, saved.Setlist(), 2)
+	assert.Equal(t, []string{"idol-1"}, saved.Setlist()[0].PerformerIDs())
+	assert.Empty(t, saved.Setlist()[1].PerformerIDs())
+
+	// 残ったセットリストはそのまま再登録できる
+	require.NoError(t, saved.SetSetlist(saved.Setlist()))
+}
+
+func TestApplicationService_UpdateSetlist_RejectsInvalidEntries(t *testing.T) {
+	t.Parallel()
+
+	releaseID := "rel-1"
+	missingID := "rel-missing"
+	one, nine := 1, 9
+
+	tests := []struct {
+		name      string
+		eventType string
+		entries   []SetlistEntryInput
+		want      string
+	}{
+		{"ライブ以外", "handshake", []SetlistEntryInput{{Title: "曲"}}, "ライブイベントにのみ"},
+		{"曲名なし", "live", []SetlistEntryInput{{}}, "1曲目: 収録曲を参照しない曲は曲名が必須です"},
+		{"トラック番号のみ", "live", []SetlistEntryInput{{TrackNumber: &one}}, "両方が必須"},
+		{"存在しないリリース", "live", []SetlistEntryInput{{ReleaseID: &missingID, TrackNumber: &one}}, "リリースが見つかりません"},
+		{"存在しないトラック", "live", []SetlistEntryInput{{ReleaseID: &releaseID, TrackNumber: &nine}}, "トラック番号 9 の収録曲が見つかりません"},
+		{"アンコール後の本編", "live", []SetlistEntryInput{{Title: "A", IsEncore: true}, {Title: "B"}}, "2曲目: 本編の曲はアンコールより前"},
+		{"出演していない出演者", "live", []SetlistEntryInput{{Title: "A", PerformerIDs: []string{"idol-9"}}}, "不正な出演者"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := newEventRepoStub()
+			svc := NewApplicationService(repo, nil, nil, newTrackCatalogStub(t), nil)
+			eventID := setlistTestEvent(t, svc, tt.eventType)
+
+			err := svc.UpdateSetlist(context.Background(), UpdateSetlistInput{EventID: eventID, Entries: tt.entries})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Empty(t, repo.data[eventID].Setlist())
+		})
+	}
+}
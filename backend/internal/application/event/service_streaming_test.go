@@ -0,0 +1,61 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_Streaming(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newEventRepoStub()
+	svc := NewApplicationService(repo, nil, nil, nil, nil)
+	start := time.Date(2026, 8, 1, 18, 0, 0, 0, time.UTC)
+	url := "https://example.com/live"
+	archiveUntil := start.AddDate(0, 0, 14).Format(time.RFC3339)
+	expired := start.Add(-time.Hour).Format(time.RFC3339)
+
+	// 会場と配信の両方があればハイブリッド開催
+	input := conflictTestInput("配信ありワンマン", "venue-1", start, "idol-1")
+	input.Streaming = &StreamingInput{
+		Platform:     "zaiko",
+		URL:          &url,
+		ArchiveUntil: &archiveUntil,
+		PriceTiers:   []PriceTierInput{{Name: "通常視聴券", Price: 3500}, {Name: "アーカイブ付き", Price: 4500}},
+	}
+	created, err := svc.CreateEvent(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AttendanceModeHybrid, created.AttendanceMode())
+	require.NotNil(t, created.Streaming())
+	assert.Len(t, created.Streaming().PriceTiers(), 2)
+	assert.True(t, created.Streaming().ArchiveAvailableAt(start.AddDate(0, 0, 7)))
+	assert.False(t, created.Streaming().ArchiveAvailableAt(start.AddDate(0, 0, 15)))
+
+	// アーカイブの視聴期限は開始日時より後
+	invalid := conflictTestInput("期限切れ", "venue-1", start, "idol-1")
+	invalid.Streaming = &StreamingInput{Platform: "youtube", ArchiveUntil: &expired}
+	_, err = svc.CreateEvent(ctx, invalid)
+	assert.ErrorContains(t, err, "アーカイブの視聴期限は開始日時より後")
+
+	_, err = svc.CreateEvent(ctx, CreateInput{Title: "配信", EventType: "online", StartDateTime: start.Format(time.RFC3339), Streaming: &StreamingInput{Platform: "unknown"}})
+	assert.ErrorContains(t, err, "無効な配信プラットフォーム")
+
+	// 配信情報を削除すると会場のみの開催に戻る
+	id := created.ID().Value()
+	require.NoError(t, svc.UpdateStreaming(ctx, UpdateStreamingInput{EventID: id}))
+	assert.Nil(t, repo.data[id].Streaming())
+	assert.Equal(t, domain.AttendanceModeOffline, repo.data[id].AttendanceMode())
+
+	// 会場のない配信イベントはオンライン
+	online, err := svc.CreateEvent(ctx, CreateInput{Title: "配信", EventType: "online", StartDateTime: start.Format(time.RFC3339)})
+	require.NoError(t, err)
+	assert.Equal(t, domain.AttendanceModeOnline, online.AttendanceMode())
+	require.NoError(t, svc.UpdateStreaming(ctx, UpdateStreamingInput{EventID: online.ID().Value(), Streaming: &StreamingInput{Platform: "showroom"}}))
+	assert.Equal(t, domain.StreamingPlatformShowroom, repo.data[online.ID().Value()].Streaming().Platform())
+}
@@ -33,8 +33,18 @@ func (r *eventRepoStub) FindByID(_ context.Context, id domain.EventID) (*domain.
 	return event, nil
 }
 
-func (r *eventRepoStub) Search(context.Context, domain.SearchCriteria) ([]*domain.Event, error) {
-	return nil, nil
+func (r *eventRepoStub) Search(_ context.Context, criteria domain.SearchCriteria) ([]*domain.Event, error) {
+	var events []*domain.Event
+	for _, e := range r.data {
+		if criteria.SeriesID != nil && (e.SeriesID() == nil || *e.SeriesID() != *criteria.SeriesID) {
+			continue
+		}
+		if criteria.PerformerID != nil && !e.HasPerformer(*criteria.PerformerID) {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, nil
 }
 
 func (r *eventRepoStub) Count(context.Context, domain.SearchCriteria) (int64, error) {
@@ -63,6 +73,35 @@ func (r *eventRepoStub) FindByPerformer(context.Context, string, int) ([]*domain
 	return nil, nil
 }
 
+func (r *eventRepoStub) FindBySeries(context.Context, string) ([]*domain.Event, error) {
+	return nil, nil
+}
+
+func (r *eventRepoStub) FindOverlapping(_ context.Context, from, to time.Time) ([]*domain.Event, error) {
+	var events []*domain.Event
+	for _, e := range r.data {
+		if e.StartDateTime().Before(to) && e.OccupiedUntil().After(from) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (r *eventRepoStub) FindDueForCompletion(_ context.Context, now time.Time, limit int) ([]*domain.Event, error) {
+	var events []*domain.Event
+	for _, e := range r.data {
+		if e.IsDueForCompletion(now) && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (r *eventRepoStub) TransitionStatus(_ context.Context, event *domain.Event, _ domain.EventStatus) (bool, error) {
+	_, ok := r.data[event.ID().Value()]
+	return ok, nil
+}
+
 type eventWebhookPublisherStub struct {
 	calls []struct {
 		event   domainWebhook.EventType
@@ -83,7 +122,7 @@ func TestApplicationService_PublishesWebhookOnCreateUpdateDeleteAndPerformerChan
 
 	repo := newEventRepoStub()
 	publisher := &eventWebhookPublisherStub{}
-	svc := NewApplicationService(repo, publisher)
+	svc := NewApplicationService(repo, nil, nil, nil, publisher)
 
 	created, err := svc.CreateEvent(context.Background(), CreateInput{
 		Title:         "単独ライブ",
@@ -0,0 +1,20 @@
+package graph
+
+// IdolGraphInput はアイドル起点の関係グラフ取得の入力
+type IdolGraphInput struct {
+	IdolID string
+	Depth  int // 0 の場合は既定値
+}
+
+// CoStarsInput は共演者取得の入力
+type CoStarsInput struct {
+	IdolID string
+	Limit  int
+}
+
+// PathInput は2アイドル間の最短経路取得の入力
+type PathInput struct {
+	FromIdolID string
+	ToIdolID   string
+	MaxDepth   int // 0 の場合は既定値
+}
@@ -0,0 +1,136 @@
+// Package graph はアイドルを起点とした関係グラフのアプリケーションサービス
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	domain "github.com/kuro48/idol-api/internal/domain/graph"
+)
+
+const (
+	// DefaultDepth は関係グラフの既定の探索ホップ数
+	DefaultDepth = 2
+	// MaxDepth は関係グラフの最大探索ホップ数
+	MaxDepth = 3
+	// MaxNodes は関係グラフに含める最大ノード数
+	MaxNodes = 300
+	// DefaultPathDepth は最短経路の既定の最大ホップ数
+	DefaultPathDepth = 4
+	// MaxPathDepth は最短経路の最大ホップ数の上限
+	MaxPathDepth = 6
+	// maxPathNodes は最短経路の探索で訪問する最大ノード数
+	maxPathNodes = 5000
+	// DefaultCoStarLimit は共演者の既定返却件数
+	DefaultCoStarLimit = 20
+	// MaxCoStarLimit は共演者の最大返却件数
+	MaxCoStarLimit = 100
+)
+
+// ApplicationService は関係グラフアプリケーションサービス
+type ApplicationService struct {
+	repository domain.Repository
+}
+
+// NewApplicationService はアプリケーションサービスを作成する
+func NewApplicationService(repository domain.Repository) *ApplicationService {
+	return &ApplicationService{repository: repository}
+}
+
+// IdolGraph はアイドルから指定ホップ以内の関係グラフを返す
+func (s *ApplicationService) IdolGraph(ctx context.Context, input IdolGraphInput) (*domain.Graph, error) {
+	depth := input.Depth
+	if depth == 0 {
+		depth = DefaultDepth
+	}
+	if depth < 1 || depth > MaxDepth {
+		return nil, fmt.Errorf("depth は1〜%dの範囲で入力してください", MaxDepth)
+	}
+
+	root, err := s.findIdol(ctx, input.IdolID)
+	if err != nil {
+		return nil, err
+	}
+	g, err := domain.Explore(ctx, s.repository, root, depth, MaxNodes)
+	if err != nil {
+		return nil, fmt.Errorf("関係グラフの取得エラー: %w", err)
+	}
+	return g, nil
+}
+
+// CoMembers は同じグループに在籍したことのあるアイドルを返す
+func (s *ApplicationService) CoMembers(ctx context.Context, idolID string) ([]domain.CoMember, error) {
+	root, err := s.findIdol(ctx, idolID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := domain.CoMembers(ctx, s.repository, root.Ref)
+	if err != nil {
+		return nil, fmt.Errorf("共演メンバーの取得エラー: %w", err)
+	}
+	return members, nil
+}
+
+// CoStars は同じイベントに出演した共演者を返す
+func (s *ApplicationService) CoStars(ctx context.Context, input CoStarsInput) ([]domain.CoStar, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultCoStarLimit
+	}
+	if limit > MaxCoStarLimit {
+		limit = MaxCoStarLimit
+	}
+
+	root, err := s.findIdol(ctx, input.IdolID)
+	if err != nil {
+		return nil, err
+	}
+	stars, err := domain.CoStars(ctx, s.repository, root.Ref, limit)
+	if err != nil {
+		return nil, fmt.Errorf("共演者の取得エラー: %w", err)
+	}
+	return stars, nil
+}
+
+// ShortestPath は2アイドル間の最短経路を返す。経路が見つからない場合は nil を返す
+func (s *ApplicationService) ShortestPath(ctx context.Context, input PathInput) (*domain.Path, error) {
+	if input.ToIdolID == "" {
+		return nil, errors.New("経路の終点アイドルID（to）は必須です")
+	}
+	maxDepth := input.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = DefaultPathDepth
+	}
+	if maxDepth < 1 || maxDepth > MaxPathDepth {
+		return nil, fmt.Errorf("max_depth は1〜%dの範囲で入力してください", MaxPathDepth)
+	}
+
+	from, err := s.findIdol(ctx, input.FromIdolID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findIdol(ctx, input.ToIdolID)
+	if err != nil {
+		return nil, err
+	}
+	path, err := domain.ShortestPath(ctx, s.repository, from, to, maxDepth, maxPathNodes)
+	if err != nil {
+		return nil, fmt.Errorf("経路の探索エラー: %w", err)
+	}
+	return path, nil
+}
+
+// findIdol は起点・終点となるアイドルのノードを取得する
+func (s *ApplicationService) findIdol(ctx context.Context, idolID string) (domain.Node, error) {
+	ref := domain.NodeRef{Type: domain.NodeIdol, ID: idolID}
+	labels, err := s.repository.FindLabels(ctx, []domain.NodeRef{ref})
+	if err != nil {
+		return domain.Node{}, fmt.Errorf("アイドルの取得エラー: %w", err)
+	}
+	label, ok := labels[ref]
+	if !ok {
+		return domain.Node{}, fmt.Errorf("アイドルが見つかりません: %s", idolID)
+	}
+	return domain.Node{Ref: ref, Label: label}, nil
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/kuro48/idol-api/internal/domain/graph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGraphRepository struct {
+	edges  []domain.Edge
+	labels map[domain.NodeRef]string
+}
+
+func (f *fakeGraphRepository) FindEdges(_ context.Context, refs []domain.NodeRef) ([]domain.Edge, error) {
+	var result []domain.Edge
+	for _, e := range f.edges {
+		for _, r := range refs {
+			if e.From == r || e.To == r {
+				result = append(result, e)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeGraphRepository) FindLabels(_ context.Context, refs []domain.NodeRef) (map[domain.NodeRef]string, error) {
+	result := make(map[domain.NodeRef]string)
+	for _, r := range refs {
+		if label, ok := f.labels[r]; ok {
+			result[r] = label
+		}
+	}
+	return result, nil
+}
+
+var (
+	idolA  = domain.NodeRef{Type: domain.NodeIdol, ID: "a"}
+	idolB  = domain.NodeRef{Type: domain.NodeIdol, ID: "b"}
+	groupG = domain.NodeRef{Type: domain.NodeGroup, ID: "g"}
+)
+
+func newTestService() *ApplicationService {
+	return NewApplicationService(&fakeGraphRepository{
+		edges: []domain.Edge{
+			{From: idolA, To: groupG, Kind: domain.EdgeMemberOf},
+			{From: idolB, To: groupG, Kind: domain.EdgeMemberOf},
+		},
+		labels: map[domain.NodeRef]string{idolA: "A", idolB: "B", groupG: "G"},
+	})
+}
+
+func TestIdolGraph_DefaultsAndValidatesDepth(t *testing.T) {
+	svc := newTestService()
+
+	g, err := svc.IdolGraph(context.Background(), IdolGraphInput{IdolID: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDepth, g.Depth)
+	assert.Len(t, g.Nodes, 3)
+
+	_, err = svc.IdolGraph(context.Background(), IdolGraphInput{IdolID: "a", Depth: MaxDepth + 1})
+	assert.ErrorContains(t, err, "入力")
+}
+
+func TestIdolGraph_UnknownIdol(t *testing.T) {
+	_, err := newTestService().IdolGraph(context.Background(), IdolGraphInput{IdolID: "missing"})
+
+	assert.ErrorContains(t, err, "見つかりません")
+}
+
+func TestShortestPath(t *testing.T) {
+	svc := newTestService()
+
+	path, err := svc.ShortestPath(context.Background(), PathInput{FromIdolID: "a", ToIdolID: "b"})
+	require.NoError(t, err)
+	require.NotNil(t, path)
+	assert.Len(t, path.Edges, 2)
+
+	_, err = svc.ShortestPath(context.Background(), PathInput{FromIdolID: "a"})
+	assert.ErrorContains(t, err, "必須")
+}
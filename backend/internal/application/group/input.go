@@ -1,16 +1,42 @@
 package group
 
+import "time"
+
 // CreateInput はグループ作成の入力
 type CreateInput struct {
 	Name          string
+	NameKana      *string
 	FormationDate *string
 	DisbandDate   *string
+	ParentGroupID *string
+	RelationType  *string
 }
 
 // UpdateInput はグループ更新の入力
 type UpdateInput struct {
 	ID            string
 	Name          *string
+	NameKana      *string // nil は変更なし、空文字は削除
 	FormationDate *string
 	DisbandDate   *string
+	ParentGroupID *string            // nil は変更なし、空文字は親グループの指定を解除
+	RelationType  *string            // nil は変更なし
+	RenamedAt     *string            // Name と併せて指定すると改名として旧名を履歴に残す
+	NameHistory   []NameHistoryInput // nil は変更なし、空スライスは履歴を削除
+}
+
+// NameHistoryInput は旧名1件の入力
+type NameHistoryInput struct {
+	Name  string
+	From  *string // "2006-01-02" or nil
+	Until string  // 改名日 "2006-01-02"
+}
+
+// AnniversariesInput は結成記念日一覧の入力（両端を含む日付、同じタイムゾーンの0時）
+type AnniversariesInput struct {
+	From   time.Time
+	To     time.Time
+	Years  *int // 周年の絞り込み（nil は絞り込みなし）
+	Offset int
+	Limit  int
 }
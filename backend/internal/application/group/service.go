@@ -2,11 +2,15 @@ package group
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/kuro48/idol-api/internal/domain/group"
 	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+	"github.com/kuro48/idol-api/internal/shared/monthday"
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
 )
 
 type ApplicationService struct {
@@ -33,6 +37,12 @@ func (s *ApplicationService) CreateGroup(ctx context.Context, input CreateInput)
 	if err != nil {
 		return nil, fmt.Errorf("名前の生成エラー: %w", err)
 	}
+	if input.NameKana != nil {
+		name, err = name.WithKana(input.NameKana)
+		if err != nil {
+			return nil, fmt.Errorf("読み仮名の生成エラー: %w", err)
+		}
+	}
 
 	// ドメインサービスで重複チェック
 	if err := s.domainService.CanCreate(ctx, name); err != nil {
@@ -63,6 +73,21 @@ func (s *ApplicationService) CreateGroup(ctx context.Context, input CreateInput)
 		}
 	}
 
+	if input.ParentGroupID != nil {
+		if input.RelationType == nil {
+			return nil, fmt.Errorf("親グループを指定する場合は関係種別が必須です")
+		}
+		parent, err := s.buildParent(ctx, nil, *input.ParentGroupID, *input.RelationType)
+		if err != nil {
+			return nil, err
+		}
+		if err := newGroup.SetParent(parent); err != nil {
+			return nil, err
+		}
+	} else if input.RelationType != nil {
+		return nil, fmt.Errorf("関係種別を指定する場合は親グループIDが必須です")
+	}
+
 	if err := s.repository.Save(ctx, newGroup); err != nil {
 		return nil, fmt.Errorf("グループの保存エラー: %w", err)
 	}
@@ -115,9 +140,20 @@ func (s *ApplicationService) UpdateGroup(ctx context.Context, input UpdateInput)
 		return fmt.Errorf("グループの取得エラー: %w", err)
 	}
 
+	// 旧名履歴の置き換えは改名より先に適用し、改名による追記を履歴の末尾に残す
+	if input.NameHistory != nil {
+		history, err := buildNameHistory(input.NameHistory)
+		if err != nil {
+			return err
+		}
+		existingGroup.SetNameHistory(history)
+	}
+
 	// 各フィールドの更新
 	if input.Name != nil {
-		name, err := group.NewGroupName(*input.Name)
+		// 読み仮名・ローマ字表記は名前変更後も引き継ぐ
+		current := existingGroup.Name()
+		name, err := group.NewGroupNameFull(*input.Name, current.Kana(), current.Latin())
 		if err != nil {
 			return fmt.Errorf("名前の生成エラー: %w", err)
 		}
@@ -131,6 +167,26 @@ func (s *ApplicationService) UpdateGroup(ctx context.Context, input UpdateInput)
 			return fmt.Errorf("同じ名前のグループが既に存在します")
 		}
 
+		if input.RenamedAt != nil {
+			renamedAt, err := time.Parse("2006-01-02", *input.RenamedAt)
+			if err != nil {
+				return fmt.Errorf("改名日の形式が不正です: %w", err)
+			}
+			if err := existingGroup.Rename(name, renamedAt); err != nil {
+				return err
+			}
+		} else if err := existingGroup.ChangeName(name); err != nil {
+			return err
+		}
+	} else if input.RenamedAt != nil {
+		return fmt.Errorf("改名日を指定する場合は名前が必須です")
+	}
+
+	if input.NameKana != nil {
+		name, err := existingGroup.Name().WithKana(input.NameKana)
+		if err != nil {
+			return fmt.Errorf("読み仮名の生成エラー: %w", err)
+		}
 		if err := existingGroup.ChangeName(name); err != nil {
 			return err
 		}
@@ -156,6 +212,10 @@ func (s *ApplicationService) UpdateGroup(ctx context.Context, input UpdateInput)
 		}
 	}
 
+	if err := s.applyParentUpdate(ctx, existingGroup, input.ParentGroupID, input.RelationType); err != nil {
+		return err
+	}
+
 	// 更新の保存
 	if err := s.repository.Update(ctx, existingGroup); err != nil {
 		return fmt.Errorf("グループの更新エラー: %w", err)
@@ -166,6 +226,31 @@ func (s *ApplicationService) UpdateGroup(ctx context.Context, input UpdateInput)
 	return nil
 }
 
+// SyncAgency は所属履歴に合わせて所属事務所（agency_id）を更新する。変化がなければ何もしない
+func (s *ApplicationService) SyncAgency(ctx context.Context, id string, agencyID *string) error {
+	groupID, err := group.NewGroupID(id)
+	if err != nil {
+		return fmt.Errorf("IDの生成エラー: %w", err)
+	}
+
+	existingGroup, err := s.repository.FindByID(ctx, groupID)
+	if err != nil {
+		return fmt.Errorf("グループの取得エラー: %w", err)
+	}
+	if current := existingGroup.AgencyID(); (current == nil && agencyID == nil) || (current != nil && agencyID != nil && *current == *agencyID) {
+		return nil
+	}
+
+	existingGroup.UpdateAgency(agencyID)
+	if err := s.repository.Update(ctx, existingGroup); err != nil {
+		return fmt.Errorf("グループの更新エラー: %w", err)
+	}
+
+	s.publishWebhook(ctx, domainWebhook.EventGroupUpdated, groupWebhookPayload(existingGroup))
+
+	return nil
+}
+
 func (s *ApplicationService) DeleteGroup(ctx context.Context, id string) error {
 	groupID, err := group.NewGroupID(id)
 	if err != nil {
@@ -195,6 +280,142 @@ func (s *ApplicationService) RestoreGroup(ctx context.Context, id string) error
 	return nil
 }
 
+// ListUnits は指定したグループを親に持つグループ（サブユニット・姉妹グループ・後継グループ）を取得する
+func (s *ApplicationService) ListUnits(ctx context.Context, id string, relationType *string) ([]*group.Group, error) {
+	parentID, err := group.NewGroupID(id)
+	if err != nil {
+		return nil, fmt.Errorf("IDの生成エラー: %w", err)
+	}
+
+	var rt *group.RelationType
+	if relationType != nil {
+		t, err := group.NewRelationType(*relationType)
+		if err != nil {
+			return nil, err
+		}
+		rt = &t
+	}
+
+	if _, err := s.repository.FindByID(ctx, parentID); err != nil {
+		return nil, fmt.Errorf("グループの取得エラー: %w", err)
+	}
+
+	units, err := s.repository.FindByParentID(ctx, parentID, rt)
+	if err != nil {
+		return nil, fmt.Errorf("子グループ一覧の取得エラー: %w", err)
+	}
+	return units, nil
+}
+
+// GetParent は指定したグループの親グループと関係種別を取得する
+func (s *ApplicationService) GetParent(ctx context.Context, id string) (*group.Group, group.RelationType, error) {
+	g, err := s.GetGroup(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if g.Parent() == nil {
+		return nil, "", fmt.Errorf("親グループが見つかりません")
+	}
+
+	parent, err := s.repository.FindByID(ctx, g.Parent().GroupID())
+	if err != nil {
+		return nil, "", fmt.Errorf("親グループの取得エラー: %w", err)
+	}
+	return parent, g.Parent().RelationType(), nil
+}
+
+// ListSubUnitIDs はメンバーを親グループに計上する直下のサブユニットのIDを取得する
+func (s *ApplicationService) ListSubUnitIDs(ctx context.Context, id string) ([]string, error) {
+	parentID, err := group.NewGroupID(id)
+	if err != nil {
+		return nil, fmt.Errorf("IDの生成エラー: %w", err)
+	}
+
+	subUnit := group.RelationSubUnit
+	units, err := s.repository.FindByParentID(ctx, parentID, &subUnit)
+	if err != nil {
+		return nil, fmt.Errorf("サブユニット一覧の取得エラー: %w", err)
+	}
+
+	ids := make([]string, 0, len(units))
+	for _, u := range units {
+		ids = append(ids, u.ID().Value())
+	}
+	return ids, nil
+}
+
+// applyParentUpdate は親グループの指定を更新する。
+// 親グループIDの空文字は指定の解除、関係種別のみの指定は既存の親グループとの関係の変更として扱う
+func (s *ApplicationService) applyParentUpdate(ctx context.Context, g *group.Group, parentGroupID, relationType *string) error {
+	if parentGroupID == nil && relationType == nil {
+		return nil
+	}
+
+	if parentGroupID != nil && *parentGroupID == "" {
+		return g.SetParent(nil)
+	}
+
+	var targetID string
+	switch {
+	case parentGroupID != nil:
+		targetID = *parentGroupID
+	case g.Parent() != nil:
+		targetID = g.Parent().GroupID().Value()
+	default:
+		return fmt.Errorf("関係種別を変更するには親グループIDが必須です")
+	}
+
+	var rt string
+	switch {
+	case relationType != nil:
+		rt = *relationType
+	case g.Parent() != nil:
+		rt = string(g.Parent().RelationType())
+	default:
+		return fmt.Errorf("親グループを指定する場合は関係種別が必須です")
+	}
+
+	id := g.ID()
+	parent, err := s.buildParent(ctx, &id, targetID, rt)
+	if err != nil {
+		return err
+	}
+	return g.SetParent(parent)
+}
+
+// buildParent は親グループへの参照を生成し、存在確認と循環チェックを行う
+func (s *ApplicationService) buildParent(ctx context.Context, id *group.GroupID, parentGroupID, relationType string) (*group.ParentRelation, error) {
+	parentID, err := group.NewGroupID(parentGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("親グループIDの生成エラー: %w", err)
+	}
+	rt, err := group.NewRelationType(relationType)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := group.NewParentRelation(parentID, rt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.domainService.ValidateParent(ctx, id, parent); err != nil {
+		return nil, err
+	}
+	return &parent, nil
+}
+
+// buildNameHistory は旧名の入力から履歴を生成する
+func buildNameHistory(inputs []NameHistoryInput) (namehistory.History, error) {
+	entries := make([]namehistory.Entry, 0, len(inputs))
+	for _, in := range inputs {
+		e, err := namehistory.ParseEntry(in.Name, in.From, in.Until)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return namehistory.New(entries)
+}
+
 func (s *ApplicationService) publishWebhook(ctx context.Context, event domainWebhook.EventType, payload interface{}) {
 	if s.publisher == nil {
 		return
@@ -209,11 +430,63 @@ func groupWebhookPayload(entity *group.Group) map[string]interface{} {
 		"id":   entity.ID().Value(),
 		"name": entity.Name().Value(),
 	}
+	if entity.Name().Kana() != nil {
+		payload["name_kana"] = *entity.Name().Kana()
+	}
 	if entity.FormationDate() != nil && !entity.FormationDate().IsEmpty() {
 		payload["formation_date"] = entity.FormationDate().String()
 	}
 	if entity.DisbandDate() != nil && !entity.DisbandDate().IsEmpty() {
 		payload["disband_date"] = entity.DisbandDate().String()
 	}
+	if p := entity.Parent(); p != nil {
+		payload["parent_group_id"] = p.GroupID().Value()
+		payload["relation_type"] = string(p.RelationType())
+	}
 	return payload
 }
+
+// Anniversaries は期間内に結成記念日を迎えるグループを日付順に offset 件目から limit 件まで返し、期間内の総件数を添える。
+// 結成した年の記念日は含めない。Years を指定した場合はその周年に限る。
+// 期間を暦年ごとに分けて、各年の件数とページをデータベースで求める
+func (s *ApplicationService) Anniversaries(ctx context.Context, input AnniversariesInput) ([]group.Anniversary, int, error) {
+	if input.To.Before(input.From) {
+		return nil, 0, errors.New("無効な期間です: 終了日は開始日以降にしてください")
+	}
+	anniversaries := []group.Anniversary{}
+	total := 0
+	offset := input.Offset
+	for _, span := range monthday.SplitByYear(input.From, input.To) {
+		// 暦年内では1グループにつき記念日は1回なので、件数がそのまま記念日の件数になる
+		year := span.From.Year()
+		criteria := group.AnniversaryCriteria{
+			Range:        monthday.Between(span.From, span.To),
+			FormedBefore: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}
+		if input.Years != nil {
+			formedFrom := time.Date(year-*input.Years, time.January, 1, 0, 0, 0, 0, time.UTC)
+			criteria.FormedFrom = &formedFrom
+			criteria.FormedBefore = formedFrom.AddDate(1, 0, 0)
+		}
+		count, err := s.repository.CountByFormationMonthDay(ctx, criteria)
+		if err != nil {
+			return nil, 0, fmt.Errorf("結成記念日の件数取得エラー: %w", err)
+		}
+		total += int(count)
+		if offset >= int(count) {
+			offset -= int(count)
+			continue
+		}
+		remaining := input.Limit - len(anniversaries)
+		if remaining <= 0 {
+			continue
+		}
+		page, err := s.repository.FindByFormationMonthDay(ctx, criteria, offset, remaining)
+		if err != nil {
+			return nil, 0, fmt.Errorf("結成記念日の検索エラー: %w", err)
+		}
+		anniversaries = append(anniversaries, group.AnniversariesBetween(page, span.From, span.To)...)
+		offset = 0
+	}
+	return anniversaries, total, nil
+}
@@ -0,0 +1,105 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_Anniversaries(t *testing.T) {
+	t.Parallel()
+
+	svc := NewApplicationService(newGroupRepoStub(), nil)
+	ctx := context.Background()
+
+	_, err := svc.CreateGroup(ctx, CreateInput{Name: "十周年グループ", FormationDate: strPtr("2016-10-20")})
+	require.NoError(t, err)
+	_, err = svc.CreateGroup(ctx, CreateInput{Name: "年末結成グループ", FormationDate: strPtr("2020-12-30")})
+	require.NoError(t, err)
+	_, err = svc.CreateGroup(ctx, CreateInput{Name: "結成日未登録"})
+	require.NoError(t, err)
+
+	jst := time.FixedZone("JST", 9*60*60)
+	got, total, err := svc.Anniversaries(ctx, AnniversariesInput{
+		From:  time.Date(2026, 10, 1, 0, 0, 0, 0, jst),
+		To:    time.Date(2026, 10, 31, 0, 0, 0, 0, jst),
+		Limit: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, got, 1)
+	assert.Equal(t, "十周年グループ", got[0].Group.Name().Value())
+	assert.Equal(t, 10, got[0].Years)
+	assert.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, jst), got[0].Date)
+
+	// 年をまたぐ期間
+	got, _, err = svc.Anniversaries(ctx, AnniversariesInput{
+		From:  time.Date(2026, 12, 25, 0, 0, 0, 0, jst),
+		To:    time.Date(2027, 1, 5, 0, 0, 0, 0, jst),
+		Limit: 20,
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, 6, got[0].Years)
+
+	// 周年の絞り込み
+	years := 10
+	got, total, err = svc.Anniversaries(ctx, AnniversariesInput{
+		From:  time.Date(2026, 1, 1, 0, 0, 0, 0, jst),
+		To:    time.Date(2026, 12, 31, 0, 0, 0, 0, jst),
+		Years: &years,
+		Limit: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, got, 1)
+	assert.Equal(t, "十周年グループ", got[0].Group.Name().Value())
+
+	_, _, err = svc.Anniversaries(ctx, AnniversariesInput{From: time.Date(2026, 10, 2, 0, 0, 0, 0, jst), To: time.Date(2026, 10, 1, 0, 0, 0, 0, jst)})
+	assert.ErrorContains(t, err, "無効な期間")
+}
+
+func TestApplicationService_AnniversariesPaginatesAcrossYears(t *testing.T) {
+	t.Parallel()
+
+	svc := NewApplicationService(newGroupRepoStub(), nil)
+	ctx := context.Background()
+
+	for i, formed := range []string{"2019-12-26", "2020-12-27", "2020-01-02", "2021-01-03"} {
+		_, err := svc.CreateGroup(ctx, CreateInput{Name: fmt.Sprintf("グループ%d", i), FormationDate: strPtr(formed)})
+		require.NoError(t, err)
+	}
+
+	jst := time.FixedZone("JST", 9*60*60)
+	period := func(offset, limit int) AnniversariesInput {
+		return AnniversariesInput{
+			From:   time.Date(2026, 12, 25, 0, 0, 0, 0, jst),
+			To:     time.Date(2027, 1, 5, 0, 0, 0, 0, jst),
+			Offset: offset,
+			Limit:  limit,
+		}
+	}
+
+	// 年をまたぐページも日付順につながる
+	got, total, err := svc.Anniversaries(ctx, period(1, 2))
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	require.Len(t, got, 2)
+	assert.Equal(t, time.Date(2026, 12, 27, 0, 0, 0, 0, jst), got[0].Date)
+	assert.Equal(t, time.Date(2027, 1, 2, 0, 0, 0, 0, jst), got[1].Date)
+
+	got, total, err = svc.Anniversaries(ctx, period(3, 2))
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	require.Len(t, got, 1)
+	assert.Equal(t, time.Date(2027, 1, 3, 0, 0, 0, 0, jst), got[0].Date)
+
+	got, total, err = svc.Anniversaries(ctx, period(4, 2))
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,108 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	domain "github.com/kuro48/idol-api/internal/domain/group"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestApplicationService_CreatesSubUnitAndListsUnits(t *testing.T) {
+	t.Parallel()
+
+	svc := NewApplicationService(newGroupRepoStub(), nil)
+	ctx := context.Background()
+
+	parent, err := svc.CreateGroup(ctx, CreateInput{Name: "本体グループ"})
+	require.NoError(t, err)
+	unit, err := svc.CreateGroup(ctx, CreateInput{Name: "ユニット", ParentGroupID: strPtr(parent.ID().Value()), RelationType: strPtr("sub_unit")})
+	require.NoError(t, err)
+	_, err = svc.CreateGroup(ctx, CreateInput{Name: "姉妹グループ", ParentGroupID: strPtr(parent.ID().Value()), RelationType: strPtr("sister")})
+	require.NoError(t, err)
+
+	units, err := svc.ListUnits(ctx, parent.ID().Value(), nil)
+	require.NoError(t, err)
+	assert.Len(t, units, 2)
+
+	subUnitIDs, err := svc.ListSubUnitIDs(ctx, parent.ID().Value())
+	require.NoError(t, err)
+	assert.Equal(t, []string{unit.ID().Value()}, subUnitIDs)
+
+	got, relation, err := svc.GetParent(ctx, unit.ID().Value())
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID().Value(), got.ID().Value())
+	assert.Equal(t, domain.RelationSubUnit, relation)
+
+	_, _, err = svc.GetParent(ctx, parent.ID().Value())
+	assert.ErrorContains(t, err, "見つかりません")
+}
+
+func TestApplicationService_RejectsInvalidParent(t *testing.T) {
+	t.Parallel()
+
+	svc := NewApplicationService(newGroupRepoStub(), nil)
+	ctx := context.Background()
+
+	a, err := svc.CreateGroup(ctx, CreateInput{Name: "グループA"})
+	require.NoError(t, err)
+	b, err := svc.CreateGroup(ctx, CreateInput{Name: "グループB", ParentGroupID: strPtr(a.ID().Value()), RelationType: strPtr("sub_unit")})
+	require.NoError(t, err)
+
+	_, err = svc.CreateGroup(ctx, CreateInput{Name: "グループC", ParentGroupID: strPtr("missing"), RelationType: strPtr("sub_unit")})
+	assert.ErrorContains(t, err, "無効な親グループ")
+
+	_, err = svc.CreateGroup(ctx, CreateInput{Name: "グループD", ParentGroupID: strPtr(a.ID().Value())})
+	assert.ErrorContains(t, err, "関係種別が必須")
+
+	err = svc.UpdateGroup(ctx, UpdateInput{ID: a.ID().Value(), ParentGroupID: strPtr(b.ID().Value()), RelationType: strPtr("sister")})
+	assert.ErrorContains(t, err, "循環")
+
+	err = svc.UpdateGroup(ctx, UpdateInput{ID: a.ID().Value(), ParentGroupID: strPtr(a.ID().Value()), RelationType: strPtr("sister")})
+	assert.ErrorContains(t, err, "不正な親グループ指定")
+
+	err = svc.UpdateGroup(ctx, UpdateInput{ID: b.ID().Value(), RelationType: strPtr("successor")})
+	require.NoError(t, err)
+	_, relation, err := svc.GetParent(ctx, b.ID().Value())
+	require.NoError(t, err)
+	assert.Equal(t, domain.RelationSuccessor, relation)
+
+	err = svc.UpdateGroup(ctx, UpdateInput{ID: b.ID().Value(), ParentGroupID: strPtr("")})
+	require.NoError(t, err)
+	_, _, err = svc.GetParent(ctx, b.ID().Value())
+	assert.ErrorContains(t, err, "見つかりません")
+}
+
+// failingParentRepo は親グループの取得だけ失敗するリポジトリ
+type failingParentRepo struct {
+	*groupRepoStub
+	parentID string
+}
+
+func (r *failingParentRepo) FindByID(ctx context.Context, id domain.GroupID) (*domain.Group, error) {
+	if id.Value() == r.parentID {
+		return nil, errors.New("接続がタイムアウトしました")
+	}
+	return r.groupRepoStub.FindByID(ctx, id)
+}
+
+func TestApplicationService_ParentLookupFailureIsNotReportedAsMissing(t *testing.T) {
+	t.Parallel()
+
+	repo := &failingParentRepo{groupRepoStub: newGroupRepoStub()}
+	svc := NewApplicationService(repo, nil)
+	ctx := context.Background()
+
+	parent, err := svc.CreateGroup(ctx, CreateInput{Name: "本体グループ"})
+	require.NoError(t, err)
+	repo.parentID = parent.ID().Value()
+
+	_, err = svc.CreateGroup(ctx, CreateInput{Name: "ユニット", ParentGroupID: strPtr(parent.ID().Value()), RelationType: strPtr("sub_unit")})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "無効な親グループ")
+	assert.ErrorContains(t, err, "タイムアウト")
+}
@@ -0,0 +1,73 @@
+package group
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationService_RenameRecordsNameHistory(t *testing.T) {
+	t.Parallel()
+
+	svc := NewApplicationService(newGroupRepoStub(), nil)
+	ctx := context.Background()
+
+	created, err := svc.CreateGroup(ctx, CreateInput{Name: "旧グループ名", FormationDate: strPtr("2015-04-01")})
+	require.NoError(t, err)
+	id := created.ID().Value()
+
+	require.NoError(t, svc.UpdateGroup(ctx, UpdateInput{ID: id, Name: strPtr("新グループ名"), RenamedAt: strPtr("2020-10-01")}))
+
+	got, err := svc.GetGroup(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, got.NameHistory(), 1)
+	entry := got.NameHistory()[0]
+	assert.Equal(t, "旧グループ名", entry.Name())
+	require.NotNil(t, entry.From())
+	assert.Equal(t, "2015-04-01", entry.From().Format("2006-01-02"))
+	assert.Equal(t, "旧グループ名", got.NameAt(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "新グループ名", got.NameAt(time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)))
+
+	err = svc.UpdateGroup(ctx, UpdateInput{ID: id, Name: strPtr("新グループ名"), RenamedAt: strPtr("2021-01-01")})
+	assert.ErrorContains(t, err, "不正な改名")
+
+	err = svc.UpdateGroup(ctx, UpdateInput{ID: id, Name: strPtr("別名"), RenamedAt: strPtr("2019-01-01")})
+	assert.ErrorContains(t, err, "不正な改名日")
+
+	err = svc.UpdateGroup(ctx, UpdateInput{ID: id, RenamedAt: strPtr("2022-01-01")})
+	assert.ErrorContains(t, err, "名前が必須")
+}
+
+func TestApplicationService_ReplacesNameHistory(t *testing.T) {
+	t.Parallel()
+
+	svc := NewApplicationService(newGroupRepoStub(), nil)
+	ctx := context.Background()
+
+	created, err := svc.CreateGroup(ctx, CreateInput{Name: "現グループ名"})
+	require.NoError(t, err)
+	id := created.ID().Value()
+
+	err = svc.UpdateGroup(ctx, UpdateInput{ID: id, NameHistory: []NameHistoryInput{
+		{Name: "二代目", Until: "2020-01-01"},
+		{Name: "初代", Until: "2020-01-01"},
+	}})
+	assert.ErrorContains(t, err, "不正な履歴")
+
+	require.NoError(t, svc.UpdateGroup(ctx, UpdateInput{ID: id, NameHistory: []NameHistoryInput{
+		{Name: "二代目", From: strPtr("2018-01-01"), Until: "2020-01-01"},
+		{Name: "初代", Until: "2018-01-01"},
+	}}))
+	got, err := svc.GetGroup(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "初代", got.NameAt(time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "二代目", got.NameAt(time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)))
+
+	require.NoError(t, svc.UpdateGroup(ctx, UpdateInput{ID: id, NameHistory: []NameHistoryInput{}}))
+	got, err = svc.GetGroup(ctx, id)
+	require.NoError(t, err)
+	assert.Empty(t, got.NameHistory())
+}
@@ -3,6 +3,8 @@ package group
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 	"testing"
 
 	domain "github.com/kuro48/idol-api/internal/domain/group"
@@ -21,7 +23,7 @@ func newGroupRepoStub() *groupRepoStub {
 
 func (r *groupRepoStub) Save(_ context.Context, g *domain.Group) error {
 	if g.ID().Value() == "" {
-		id, _ := domain.NewGroupID("group-1")
+		id, _ := domain.NewGroupID(fmt.Sprintf("group-%d", len(r.data)+1))
 		g.SetID(id)
 	}
 	r.data[g.ID().Value()] = g
@@ -31,7 +33,7 @@ func (r *groupRepoStub) Save(_ context.Context, g *domain.Group) error {
 func (r *groupRepoStub) FindByID(_ context.Context, id domain.GroupID) (*domain.Group, error) {
 	group, ok := r.data[id.Value()]
 	if !ok {
-		return nil, errors.New("not found")
+		return nil, errors.New("グループが見つかりません")
 	}
 	return group, nil
 }
@@ -44,6 +46,21 @@ func (r *groupRepoStub) FindWithPagination(context.Context, domain.SearchOptions
 	return nil, nil
 }
 
+func (r *groupRepoStub) FindByParentID(_ context.Context, parentID domain.GroupID, relationType *domain.RelationType) ([]*domain.Group, error) {
+	var groups []*domain.Group
+	for _, g := range r.data {
+		p := g.Parent()
+		if p == nil || !p.GroupID().Equals(parentID) {
+			continue
+		}
+		if relationType != nil && p.RelationType() != *relationType {
+			continue
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
 func (r *groupRepoStub) Update(_ context.Context, g *domain.Group) error {
 	r.data[g.ID().Value()] = g
 	return nil
@@ -67,6 +84,43 @@ func (r *groupRepoStub) ExistsByName(_ context.Context, name domain.GroupName) (
 	return false, nil
 }
 
+func (r *groupRepoStub) FindByFormationMonthDay(_ context.Context, criteria domain.AnniversaryCriteria, offset, limit int) ([]*domain.Group, error) {
+	groups := r.matchAnniversary(criteria)
+	if offset >= len(groups) {
+		return nil, nil
+	}
+	return groups[offset:min(offset+limit, len(groups))], nil
+}
+
+func (r *groupRepoStub) CountByFormationMonthDay(_ context.Context, criteria domain.AnniversaryCriteria) (int64, error) {
+	return int64(len(r.matchAnniversary(criteria))), nil
+}
+
+// matchAnniversary は条件に合うグループを月日・名前・ID順に返す
+func (r *groupRepoStub) matchAnniversary(criteria domain.AnniversaryCriteria) []*domain.Group {
+	var groups []*domain.Group
+	for _, g := range r.data {
+		f := g.FormationDate()
+		if f == nil || !criteria.Range.Contains(f.MonthDay()) || !f.Value().Before(criteria.FormedBefore) {
+			continue
+		}
+		if criteria.FormedFrom != nil && f.Value().Before(*criteria.FormedFrom) {
+			continue
+		}
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if mi, mj := groups[i].FormationDate().MonthDay().String(), groups[j].FormationDate().MonthDay().String(); mi != mj {
+			return mi < mj
+		}
+		if groups[i].Name().Value() != groups[j].Name().Value() {
+			return groups[i].Name().Value() < groups[j].Name().Value()
+		}
+		return groups[i].ID().Value() < groups[j].ID().Value()
+	})
+	return groups
+}
+
 type groupWebhookPublisherStub struct {
 	calls []struct {
 		event   domainWebhook.EventType
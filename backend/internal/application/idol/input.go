@@ -1,9 +1,12 @@
 package idol
 
+import "time"
+
 // CreateInput はアイドル作成の入力
 // usecase層から渡される前提のため、HTTP由来のタグは持たない
 type CreateInput struct {
 	Name      string
+	NameKana  *string
 	Birthdate *string
 	AgencyID  *string
 	Aliases   []string
@@ -12,11 +15,14 @@ type CreateInput struct {
 
 // UpdateInput はアイドル更新の入力
 type UpdateInput struct {
-	ID        string
-	Name      *string
-	Birthdate *string
-	AgencyID  *string
-	Aliases   []string
+	ID          string
+	Name        *string
+	NameKana    *string // nil は変更なし、空文字は削除
+	Birthdate   *string
+	AgencyID    *string
+	Aliases     []string
+	RenamedAt   *string            // Name と併せて指定すると改名として旧芸名を履歴に残す
+	NameHistory []NameHistoryInput // nil は変更なし、空スライスは履歴を削除
 }
 
 // UpdateSocialLinksInput はSNS/外部リンク更新の入力
@@ -36,3 +42,18 @@ type UpdateExternalIDsInput struct {
 	ID          string
 	ExternalIDs map[string]string // キーは ExternalIDKind の文字列値
 }
+
+// NameHistoryInput は旧名1件の入力
+type NameHistoryInput struct {
+	Name  string
+	From  *string // "2006-01-02" or nil
+	Until string  // 改名日 "2006-01-02"
+}
+
+// BirthdaysInput は誕生日一覧の入力（両端を含む日付、同じタイムゾーンの0時）
+type BirthdaysInput struct {
+	From   time.Time
+	To     time.Time
+	Offset int
+	Limit  int
+}
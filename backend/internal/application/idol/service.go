@@ -2,12 +2,16 @@ package idol
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/kuro48/idol-api/internal/domain/idol"
 	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+	"github.com/kuro48/idol-api/internal/shared/monthday"
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
 )
 
 // ApplicationService はアイドルアプリケーションサービス
@@ -15,6 +19,12 @@ type ApplicationService struct {
 	repository    idol.Repository
 	domainService *idol.DomainService
 	publisher     WebhookPublisher
+	affiliations  AffiliationRecorder
+}
+
+// AffiliationRecorder は所属事務所の所属履歴を登録する契約
+type AffiliationRecorder interface {
+	RecordIdolAffiliation(ctx context.Context, idolID, agencyID string) error
 }
 
 // WebhookPublisher はアイドル変更イベントを通知する契約
@@ -31,6 +41,13 @@ func NewApplicationService(repository idol.Repository, publisher WebhookPublishe
 	}
 }
 
+// WithAffiliationRecorder は所属履歴を登録する契約を設定する。
+// 設定すると、agency_id を指定した作成で所属日未定・退所日なしの所属履歴を合わせて登録する
+func (s *ApplicationService) WithAffiliationRecorder(affiliations AffiliationRecorder) *ApplicationService {
+	s.affiliations = affiliations
+	return s
+}
+
 // CreateIdol はアイドルを作成する
 func (s *ApplicationService) CreateIdol(ctx context.Context, input CreateInput) (*idol.Idol, error) {
 	// 値オブジェクトの生成
@@ -38,6 +55,12 @@ func (s *ApplicationService) CreateIdol(ctx context.Context, input CreateInput)
 	if err != nil {
 		return nil, fmt.Errorf("名前の生成エラー: %w", err)
 	}
+	if input.NameKana != nil {
+		name, err = name.WithKana(input.NameKana)
+		if err != nil {
+			return nil, fmt.Errorf("読み仮名の生成エラー: %w", err)
+		}
+	}
 
 	// ドメインサービスで重複チェック
 	if err := s.domainService.CanCreate(ctx, name); err != nil {
@@ -79,6 +102,13 @@ func (s *ApplicationService) CreateIdol(ctx context.Context, input CreateInput)
 		return nil, fmt.Errorf("アイドルの保存エラー: %w", err)
 	}
 
+	// 所属事務所は所属履歴が正のため、agency_id だけが残らないよう所属履歴も登録する
+	if input.AgencyID != nil && s.affiliations != nil {
+		if err := s.affiliations.RecordIdolAffiliation(ctx, newIdol.ID().Value(), *input.AgencyID); err != nil {
+			return nil, fmt.Errorf("所属履歴の登録エラー: %w", err)
+		}
+	}
+
 	s.publishWebhook(ctx, domainWebhook.EventIdolCreated, idolWebhookPayload(newIdol))
 
 	return newIdol, nil
@@ -121,9 +151,20 @@ func (s *ApplicationService) UpdateIdol(ctx context.Context, input UpdateInput)
 		return fmt.Errorf("アイドルの取得エラー: %w", err)
 	}
 
+	// 旧名履歴の置き換えは改名より先に適用し、改名による追記を履歴の末尾に残す
+	if input.NameHistory != nil {
+		history, err := buildNameHistory(input.NameHistory)
+		if err != nil {
+			return err
+		}
+		existingIdol.SetNameHistory(history)
+	}
+
 	// 各フィールドの更新
 	if input.Name != nil {
-		name, err := idol.NewIdolName(*input.Name)
+		// 読み仮名・ローマ字表記は名前変更後も引き継ぐ
+		current := existingIdol.Name()
+		name, err := idol.NewIdolNameFull(*input.Name, current.Kana(), current.Latin())
 		if err != nil {
 			return fmt.Errorf("名前の生成エラー: %w", err)
 		}
@@ -137,6 +178,26 @@ func (s *ApplicationService) UpdateIdol(ctx context.Context, input UpdateInput)
 			return fmt.Errorf("同じ名前のアイドルが既に存在します")
 		}
 
+		if input.RenamedAt != nil {
+			renamedAt, err := time.Parse("2006-01-02", *input.RenamedAt)
+			if err != nil {
+				return fmt.Errorf("改名日の形式が不正です: %w", err)
+			}
+			if err := existingIdol.Rename(name, renamedAt); err != nil {
+				return err
+			}
+		} else if err := existingIdol.ChangeName(name); err != nil {
+			return err
+		}
+	} else if input.RenamedAt != nil {
+		return fmt.Errorf("改名日を指定する場合は名前が必須です")
+	}
+
+	if input.NameKana != nil {
+		name, err := existingIdol.Name().WithKana(input.NameKana)
+		if err != nil {
+			return fmt.Errorf("読み仮名の生成エラー: %w", err)
+		}
 		if err := existingIdol.ChangeName(name); err != nil {
 			return err
 		}
@@ -152,8 +213,9 @@ func (s *ApplicationService) UpdateIdol(ctx context.Context, input UpdateInput)
 		existingIdol.UpdateBirthdate(nil)
 	}
 
-	if input.AgencyID != nil {
-		existingIdol.UpdateAgency(input.AgencyID)
+	// 所属事務所は所属履歴が正とし、agency_id は所属履歴の登録・更新時に同期する（SyncAgency）
+	if input.AgencyID != nil && !sameAgency(existingIdol.AgencyID(), input.AgencyID) {
+		return fmt.Errorf("agency_id の直接変更は無効です。所属事務所の変更は所属履歴（/affiliations）で登録してください")
 	}
 
 	if input.Aliases != nil {
@@ -170,6 +232,43 @@ func (s *ApplicationService) UpdateIdol(ctx context.Context, input UpdateInput)
 	return nil
 }
 
+// SyncAgency は所属履歴に合わせて所属事務所（agency_id）を更新する。変化がなければ何もしない
+func (s *ApplicationService) SyncAgency(ctx context.Context, id string, agencyID *string) error {
+	idolID, err := idol.NewIdolID(id)
+	if err != nil {
+		return fmt.Errorf("IDの生成エラー: %w", err)
+	}
+
+	existingIdol, err := s.repository.FindByID(ctx, idolID)
+	if err != nil {
+		return fmt.Errorf("アイドルの取得エラー: %w", err)
+	}
+	if sameAgency(existingIdol.AgencyID(), agencyID) {
+		return nil
+	}
+
+	existingIdol.UpdateAgency(agencyID)
+	if err := s.repository.Update(ctx, existingIdol); err != nil {
+		return fmt.Errorf("アイドルの更新エラー: %w", err)
+	}
+
+	s.publishWebhook(ctx, domainWebhook.EventIdolUpdated, idolWebhookPayload(existingIdol))
+
+	return nil
+}
+
+// sameAgency は所属事務所の指定が同じかを返す。未所属（nil）と空文字は同じとみなす
+func sameAgency(current, requested *string) bool {
+	var c, r string
+	if current != nil {
+		c = *current
+	}
+	if requested != nil {
+		r = *requested
+	}
+	return c == r
+}
+
 // DeleteIdol はアイドルを削除する
 func (s *ApplicationService) DeleteIdol(ctx context.Context, id string) error {
 	idolID, err := idol.NewIdolID(id)
@@ -266,6 +365,19 @@ func (s *ApplicationService) UpdateSocialLinks(ctx context.Context, input Update
 	return nil
 }
 
+// buildNameHistory は旧名の入力から履歴を生成する
+func buildNameHistory(inputs []NameHistoryInput) (namehistory.History, error) {
+	entries := make([]namehistory.Entry, 0, len(inputs))
+	for _, in := range inputs {
+		e, err := namehistory.ParseEntry(in.Name, in.From, in.Until)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return namehistory.New(entries)
+}
+
 func (s *ApplicationService) publishWebhook(ctx context.Context, event domainWebhook.EventType, payload interface{}) {
 	if s.publisher == nil {
 		return
@@ -282,6 +394,9 @@ func idolWebhookPayload(entity *idol.Idol) map[string]interface{} {
 		"aliases": entity.Aliases(),
 		"tag_ids": entity.TagIDs(),
 	}
+	if entity.Name().Kana() != nil {
+		payload["name_kana"] = *entity.Name().Kana()
+	}
 	if entity.Birthdate() != nil && !entity.Birthdate().IsEmpty() {
 		payload["birthdate"] = entity.Birthdate().String()
 	}
@@ -368,3 +483,41 @@ func (s *ApplicationService) UpdateExternalIDs(ctx context.Context, input Update
 
 	return nil
 }
+
+// Birthdays は期間内に誕生日を迎えるアイドルを日付順に offset 件目から limit 件まで返し、期間内の総件数を添える。
+// 閏日生まれは閏年以外の年で2月28日とする。期間を暦年ごとに分けて、各年の件数とページをデータベースで求める
+func (s *ApplicationService) Birthdays(ctx context.Context, input BirthdaysInput) ([]idol.Birthday, int, error) {
+	if input.To.Before(input.From) {
+		return nil, 0, errors.New("無効な期間です: 終了日は開始日以降にしてください")
+	}
+	birthdays := []idol.Birthday{}
+	total := 0
+	offset := input.Offset
+	for _, span := range monthday.SplitByYear(input.From, input.To) {
+		// 暦年内では1人につき誕生日は1回なので、件数がそのまま誕生日の件数になる
+		criteria := idol.BirthdayCriteria{
+			Range:      monthday.Between(span.From, span.To),
+			BornBefore: time.Date(span.From.Year(), time.January, 1, 0, 0, 0, 0, time.UTC),
+		}
+		count, err := s.repository.CountByBirthMonthDay(ctx, criteria)
+		if err != nil {
+			return nil, 0, fmt.Errorf("誕生日の件数取得エラー: %w", err)
+		}
+		total += int(count)
+		if offset >= int(count) {
+			offset -= int(count)
+			continue
+		}
+		remaining := input.Limit - len(birthdays)
+		if remaining <= 0 {
+			continue
+		}
+		page, err := s.repository.FindByBirthMonthDay(ctx, criteria, offset, remaining)
+		if err != nil {
+			return nil, 0, fmt.Errorf("誕生日の検索エラー: %w", err)
+		}
+		birthdays = append(birthdays, idol.BirthdaysBetween(page, span.From, span.To)...)
+		offset = 0
+	}
+	return birthdays, total, nil
+}
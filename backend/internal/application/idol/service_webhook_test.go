@@ -3,6 +3,7 @@ package idol
 import (
 	"context"
 	"errors"
+	"sort"
 	"testing"
 
 	domain "github.com/kuro48/idol-api/internal/domain/idol"
@@ -79,6 +80,39 @@ func (r *idolRepoStub) FindByExternalID(context.Context, domain.ExternalIDKind,
 	return nil, nil
 }
 
+func (r *idolRepoStub) FindByBirthMonthDay(_ context.Context, criteria domain.BirthdayCriteria, offset, limit int) ([]*domain.Idol, error) {
+	idols := r.matchBirthday(criteria)
+	if offset >= len(idols) {
+		return nil, nil
+	}
+	return idols[offset:min(offset+limit, len(idols))], nil
+}
+
+func (r *idolRepoStub) CountByBirthMonthDay(_ context.Context, criteria domain.BirthdayCriteria) (int64, error) {
+	return int64(len(r.matchBirthday(criteria))), nil
+}
+
+// matchBirthday は条件に合うアイドルを月日・名前・ID順に返す
+func (r *idolRepoStub) matchBirthday(criteria domain.BirthdayCriteria) []*domain.Idol {
+	var idols []*domain.Idol
+	for _, i := range r.data {
+		b := i.Birthdate()
+		if b != nil && criteria.Range.Contains(b.MonthDay()) && b.Value().Before(criteria.BornBefore) {
+			idols = append(idols, i)
+		}
+	}
+	sort.Slice(idols, func(a, b int) bool {
+		if ma, mb := idols[a].Birthdate().MonthDay().String(), idols[b].Birthdate().MonthDay().String(); ma != mb {
+			return ma < mb
+		}
+		if idols[a].Name().Value() != idols[b].Name().Value() {
+			return idols[a].Name().Value() < idols[b].Name().Value()
+		}
+		return idols[a].ID().Value() < idols[b].ID().Value()
+	})
+	return idols
+}
+
 type webhookPublishCall struct {
 	event   domainWebhook.EventType
 	payload interface{}
@@ -130,3 +164,53 @@ func TestApplicationService_PublishesWebhookOnCreateUpdateDelete(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, created.ID().Value(), payload["id"])
 }
+
+func TestApplicationService_UpdateIdolRejectsAgencyChange(t *testing.T) {
+	t.Parallel()
+
+	repo := newIdolRepoStub()
+	svc := NewApplicationService(repo, nil)
+
+	agencyA, agencyB := "agency-a", "agency-b"
+	created, err := svc.CreateIdol(context.Background(), CreateInput{Name: "星野みく", AgencyID: &agencyA})
+	require.NoError(t, err)
+
+	// 現在と同じ所属事務所の指定は受け付ける
+	require.NoError(t, svc.UpdateIdol(context.Background(), UpdateInput{ID: created.ID().Value(), AgencyID: &agencyA}))
+
+	err = svc.UpdateIdol(context.Background(), UpdateInput{ID: created.ID().Value(), AgencyID: &agencyB})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "無効")
+	assert.Equal(t, agencyA, *repo.data[created.ID().Value()].AgencyID())
+
+	// 所属履歴からの同期では変更できる
+	require.NoError(t, svc.SyncAgency(context.Background(), created.ID().Value(), &agencyB))
+	assert.Equal(t, agencyB, *repo.data[created.ID().Value()].AgencyID())
+}
+
+type affiliationRecorderStub struct {
+	recorded [][2]string // {idolID, agencyID}
+}
+
+func (r *affiliationRecorderStub) RecordIdolAffiliation(_ context.Context, idolID, agencyID string) error {
+	r.recorded = append(r.recorded, [2]string{idolID, agencyID})
+	return nil
+}
+
+func TestApplicationService_CreateIdolRecordsAffiliation(t *testing.T) {
+	t.Parallel()
+
+	repo := newIdolRepoStub()
+	recorder := &affiliationRecorderStub{}
+	svc := NewApplicationService(repo, nil).WithAffiliationRecorder(recorder)
+
+	agencyID := "agency-a"
+	created, err := svc.CreateIdol(context.Background(), CreateInput{Name: "星野みく", AgencyID: &agencyID})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]string{{created.ID().Value(), agencyID}}, recorder.recorded)
+
+	// 事務所を指定しなければ所属履歴は登録しない
+	_, err = svc.CreateIdol(context.Background(), CreateInput{Name: "月城れい"})
+	require.NoError(t, err)
+	assert.Len(t, recorder.recorded, 1)
+}
@@ -65,6 +65,7 @@ func (s *ApplicationService) RecoverStuckJobs(ctx context.Context) error {
 // BulkImportItem はバルクインポートの1件分のデータ
 type BulkImportItem struct {
 	Name      string   `json:"name"`
+	NameKana  string   `json:"name_kana,omitempty"`
 	Birthdate string   `json:"birthdate,omitempty"`
 	AgencyID  string   `json:"agency_id,omitempty"`
 	Aliases   []string `json:"aliases,omitempty"`
@@ -200,6 +201,7 @@ func (s *ApplicationService) processBulkImport(ctx context.Context, payload Bulk
 	for idx, item := range payload.Items {
 		_, err := s.idolImporter.CreateIdol(ctx, appIdol.CreateInput{
 			Name:      item.Name,
+			NameKana:  optionalString(item.NameKana),
 			Birthdate: optionalString(item.Birthdate),
 			AgencyID:  optionalString(item.AgencyID),
 			Aliases:   item.Aliases,
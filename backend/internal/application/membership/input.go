@@ -1,15 +1,17 @@
 package membership
 
 type CreateInput struct {
-	IdolID   string
-	GroupID  string
-	Role     string
-	JoinedAt *string // "2006-01-02" or nil
+	IdolID     string
+	GroupID    string
+	Role       string
+	Generation *string // "1期生" など。nil は指定なし
+	JoinedAt   *string // "2006-01-02" or nil
 }
 
 type UpdateInput struct {
-	ID       string
-	Role     *string
-	JoinedAt *string // "2006-01-02" or nil; omitted means no change
-	LeftAt   *string // "2006-01-02" or nil; empty string means clear
+	ID         string
+	Role       *string
+	Generation *string // omitted means no change; empty string means clear
+	JoinedAt   *string // "2006-01-02" or nil; omitted means no change
+	LeftAt     *string // "2006-01-02" or nil; empty string means clear
 }
@@ -36,6 +36,9 @@ func (s *ApplicationService) CreateMembership(ctx context.Context, input CreateI
 	if err != nil {
 		return nil, err
 	}
+	if err := m.UpdateGeneration(input.Generation); err != nil {
+		return nil, err
+	}
 
 	_ = audit.ActorFrom(ctx) // propagated to repository layer
 
@@ -68,8 +71,9 @@ func (s *ApplicationService) ListByIdolID(ctx context.Context, idolID string) ([
 	return ms, nil
 }
 
-func (s *ApplicationService) ListByGroupID(ctx context.Context, groupID string) ([]*membership.Membership, error) {
-	ms, err := s.repository.FindByGroupID(ctx, groupID)
+// ListByGroupIDs は複数グループ（親グループとそのサブユニットなど）のメンバーシップをまとめて取得する
+func (s *ApplicationService) ListByGroupIDs(ctx context.Context, groupIDs []string) ([]*membership.Membership, error) {
+	ms, err := s.repository.FindByGroupIDs(ctx, groupIDs)
 	if err != nil {
 		return nil, fmt.Errorf("グループのメンバーシップ取得エラー: %w", err)
 	}
@@ -109,6 +113,12 @@ func (s *ApplicationService) UpdateMembership(ctx context.Context, input UpdateI
 		}
 	}
 
+	if input.Generation != nil {
+		if err := m.UpdateGeneration(input.Generation); err != nil {
+			return err
+		}
+	}
+
 	if input.JoinedAt != nil {
 		t, err := time.Parse("2006-01-02", *input.JoinedAt)
 		if err != nil {
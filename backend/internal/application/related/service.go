@@ -0,0 +1,91 @@
+// Package related は include パラメータで展開する関連データの読み込みを担うアプリケーションサービス
+package related
+
+import (
+	"context"
+
+	domain "github.com/kuro48/idol-api/internal/domain/related"
+	"github.com/kuro48/idol-api/internal/shared/geo"
+)
+
+// ApplicationService は関連データ読み込みアプリケーションサービス
+type ApplicationService struct {
+	repository domain.Repository
+}
+
+// NewApplicationService はアプリケーションサービスを作成する
+func NewApplicationService(repository domain.Repository) *ApplicationService {
+	return &ApplicationService{repository: repository}
+}
+
+// FindAgencies は事務所をIDでまとめて取得する
+func (s *ApplicationService) FindAgencies(ctx context.Context, ids []string) (map[string]domain.AgencySummary, error) {
+	return s.repository.FindAgencies(ctx, unique(ids))
+}
+
+// FindIdols はアイドルをIDでまとめて取得する
+func (s *ApplicationService) FindIdols(ctx context.Context, ids []string) (map[string]domain.IdolSummary, error) {
+	return s.repository.FindIdols(ctx, unique(ids))
+}
+
+// FindGroups はグループをIDでまとめて取得する
+func (s *ApplicationService) FindGroups(ctx context.Context, ids []string) (map[string]domain.GroupSummary, error) {
+	return s.repository.FindGroups(ctx, unique(ids))
+}
+
+// FindTags はタグをIDでまとめて取得する
+func (s *ApplicationService) FindTags(ctx context.Context, ids []string) (map[string]domain.TagSummary, error) {
+	return s.repository.FindTags(ctx, unique(ids))
+}
+
+// FindVenues は会場をIDでまとめて取得する
+func (s *ApplicationService) FindVenues(ctx context.Context, ids []string) (map[string]domain.VenueSummary, error) {
+	return s.repository.FindVenues(ctx, unique(ids))
+}
+
+// FindVenuesNear は中心から半径以内の会場を距離（km）付きで取得する
+func (s *ApplicationService) FindVenuesNear(ctx context.Context, center geo.Point, radiusKm float64, limit int) (map[string]float64, error) {
+	return s.repository.FindVenuesNear(ctx, center, radiusKm, limit)
+}
+
+// FindSubUnits は親グループごとの直下のサブユニットIDをまとめて取得する
+func (s *ApplicationService) FindSubUnits(ctx context.Context, parentIDs []string) (map[string][]string, error) {
+	return s.repository.FindSubUnits(ctx, unique(parentIDs))
+}
+
+// FindMembershipsByIdols はアイドルごとのメンバーシップを最大 limit 件ずつ取得する
+func (s *ApplicationService) FindMembershipsByIdols(ctx context.Context, idolIDs []string, limit int) (map[string][]domain.MembershipSummary, error) {
+	return s.repository.FindMembershipsByIdols(ctx, unique(idolIDs), limit)
+}
+
+// FindMembershipsByGroups はグループごとのメンバーシップを最大 limit 件ずつ取得する
+func (s *ApplicationService) FindMembershipsByGroups(ctx context.Context, groupIDs []string, limit int) (map[string][]domain.MembershipSummary, error) {
+	return s.repository.FindMembershipsByGroups(ctx, unique(groupIDs), limit)
+}
+
+// FindReleasesByArtists はアーティストごとのリリースを最大 limit 件ずつ取得する
+func (s *ApplicationService) FindReleasesByArtists(ctx context.Context, artistIDs []string, limit int) (map[string][]domain.ReleaseSummary, error) {
+	return s.repository.FindReleasesByArtists(ctx, unique(artistIDs), limit)
+}
+
+// FindEventsByPerformers は出演者ごとのイベントを最大 limit 件ずつ取得する
+func (s *ApplicationService) FindEventsByPerformers(ctx context.Context, performerIDs []string, limit int) (map[string][]domain.EventSummary, error) {
+	return s.repository.FindEventsByPerformers(ctx, unique(performerIDs), limit)
+}
+
+// unique は空文字と重複を除いたIDを返す
+func unique(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
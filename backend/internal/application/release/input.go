@@ -77,3 +77,37 @@ type UpdateExternalIDsInput struct {
 	ID          string
 	ExternalIDs map[string]string // キーは ReleaseExternalIDKind の文字列値
 }
+
+// EditionTrackInput はエディション収録曲の入力。
+// SharedTrackNumber（リリース共通の収録曲の参照）と Track（エディション固有の楽曲）のどちらか一方を指定する
+type EditionTrackInput struct {
+	DiscNumber        int // 省略時は 1
+	TrackNumber       int // 共通曲を参照する場合のエディション内の曲順（固有の楽曲は Track.TrackNumber を使い、指定する場合は一致させる）
+	SharedTrackNumber *int
+	Track             *TrackInput
+}
+
+// BonusMediaInput は特典の入力
+type BonusMediaInput struct {
+	Type        string
+	Title       string
+	Description *string
+}
+
+// EditionInput はエディションの入力
+type EditionInput struct {
+	ID          *string // 既存エディションを更新する場合に指定
+	Name        string
+	EditionType string
+	JANCode     *string
+	UPC         *string
+	Price       *int
+	Tracks      []EditionTrackInput
+	BonusMedia  []BonusMediaInput
+}
+
+// UpdateEditionsInput はエディション一括更新の入力
+type UpdateEditionsInput struct {
+	ID       string
+	Editions []EditionInput
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kuro48/idol-api/internal/domain/release"
 	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+	sharedid "github.com/kuro48/idol-api/internal/shared/id"
 )
 
 // WebhookPublisher はリリース変更イベントを通知する契約
@@ -15,11 +16,17 @@ type WebhookPublisher interface {
 	Publish(ctx context.Context, event domainWebhook.EventType, payload interface{}) error
 }
 
+// SetlistReferences はイベントのセットリストから参照されている収録曲を調べる契約
+type SetlistReferences interface {
+	FindSetlistTrackNumbers(ctx context.Context, releaseID string) ([]int, error)
+}
+
 // ApplicationService はリリースアプリケーションサービス
 type ApplicationService struct {
 	repository    release.Repository
 	domainService *release.DomainService
 	publisher     WebhookPublisher
+	setlists      SetlistReferences
 }
 
 func NewApplicationService(repository release.Repository, publisher WebhookPublisher) *ApplicationService {
@@ -30,6 +37,13 @@ func NewApplicationService(repository release.Repository, publisher WebhookPubli
 	}
 }
 
+// WithSetlistReferences はセットリストから参照されている収録曲を調べる契約を設定する
+// 設定すると、セットリストが参照しているトラック番号を収録曲の更新で削除できなくなる
+func (s *ApplicationService) WithSetlistReferences(setlists SetlistReferences) *ApplicationService {
+	s.setlists = setlists
+	return s
+}
+
 // CreateRelease はリリースを作成する
 func (s *ApplicationService) CreateRelease(ctx context.Context, input CreateInput) (*release.Release, error) {
 	title, err := release.NewReleaseTitle(input.Title)
@@ -159,6 +173,9 @@ func (s *ApplicationService) UpdateRelease(ctx context.Context, input UpdateInpu
 		if err != nil {
 			return err
 		}
+		if err := s.ensureSetlistTracksKept(ctx, r.ID().Value(), tracks); err != nil {
+			return err
+		}
 		if err := r.SetTracks(tracks); err != nil {
 			return fmt.Errorf("収録曲エラー: %w", err)
 		}
@@ -303,6 +320,77 @@ func (s *ApplicationService) UpdateExternalIDs(ctx context.Context, input Update
 	return nil
 }
 
+// UpdateEditions はリリースのエディションを置き換える。
+// 既存のエディションは ID を指定して更新し、JANコード・UPCは全リリースを通して一意にする
+func (s *ApplicationService) UpdateEditions(ctx context.Context, input UpdateEditionsInput) (*release.Release, error) {
+	r, err := s.GetRelease(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	editions := make([]release.Edition, 0, len(input.Editions))
+	for i, in := range input.Editions {
+		id := sharedid.Generate()
+		if in.ID != nil {
+			if _, ok := r.Edition(*in.ID); !ok {
+				return nil, fmt.Errorf("%d件目: エディションが見つかりません: %s", i+1, *in.ID)
+			}
+			id = *in.ID
+		}
+		edition, err := buildEdition(id, in)
+		if err != nil {
+			return nil, fmt.Errorf("%d件目のエディションの入力が不正です: %w", i+1, err)
+		}
+		if code := edition.JANCode(); code != nil {
+			existing, err := s.repository.FindByEditionJANCode(ctx, *code)
+			if err != nil {
+				return nil, fmt.Errorf("JANコード重複チェックエラー: %w", err)
+			}
+			if existing != nil && existing.ID().Value() != input.ID {
+				return nil, fmt.Errorf("JANコード '%s' は既に別のリリースに登録されています", *code)
+			}
+		}
+		if code := edition.UPC(); code != nil {
+			existing, err := s.repository.FindByEditionUPC(ctx, *code)
+			if err != nil {
+				return nil, fmt.Errorf("UPC重複チェックエラー: %w", err)
+			}
+			if existing != nil && existing.ID().Value() != input.ID {
+				return nil, fmt.Errorf("UPC '%s' は既に別のリリースに登録されています", *code)
+			}
+		}
+		editions = append(editions, edition)
+	}
+	if err := r.SetEditions(editions); err != nil {
+		return nil, fmt.Errorf("エディションの入力が不正です: %w", err)
+	}
+
+	if err := s.repository.Update(ctx, r); err != nil {
+		return nil, fmt.Errorf("リリース更新エラー: %w", err)
+	}
+	s.publishWebhook(ctx, domainWebhook.EventReleaseUpdated, releaseWebhookPayload(r))
+	return r, nil
+}
+
+// FindEditionByJANCode はJANコードからエディションとその親リリースを取得する
+func (s *ApplicationService) FindEditionByJANCode(ctx context.Context, janCode string) (*release.Release, release.Edition, error) {
+	if !release.IsValidJANCode(janCode) {
+		return nil, release.Edition{}, fmt.Errorf("JANコードの形式が不正です（8桁または13桁、チェックディジット込み）: %s", janCode)
+	}
+	r, err := s.repository.FindByEditionJANCode(ctx, janCode)
+	if err != nil {
+		return nil, release.Edition{}, fmt.Errorf("リリース取得エラー: %w", err)
+	}
+	if r == nil {
+		return nil, release.Edition{}, fmt.Errorf("JANコード '%s' のエディションが見つかりません", janCode)
+	}
+	edition, ok := r.EditionByJANCode(janCode)
+	if !ok {
+		return nil, release.Edition{}, fmt.Errorf("JANコード '%s' のエディションが見つかりません", janCode)
+	}
+	return r, edition, nil
+}
+
 func (s *ApplicationService) publishWebhook(ctx context.Context, event domainWebhook.EventType, payload interface{}) {
 	if s.publisher == nil {
 		return
@@ -333,6 +421,28 @@ func buildArtistRefs(inputs []ArtistRefInput) ([]release.ArtistRef, error) {
 	return refs, nil
 }
 
+// ensureSetlistTracksKept はセットリストが参照しているトラック番号が更新後の収録曲に残っていることを確認する。
+// セットリストはリリースIDとトラック番号で収録曲を参照するため、番号が消えると参照先を失う
+func (s *ApplicationService) ensureSetlistTracksKept(ctx context.Context, releaseID string, tracks []release.Track) error {
+	if s.setlists == nil {
+		return nil
+	}
+	referenced, err := s.setlists.FindSetlistTrackNumbers(ctx, releaseID)
+	if err != nil {
+		return fmt.Errorf("セットリストの参照確認エラー: %w", err)
+	}
+	kept := make(map[int]struct{}, len(tracks))
+	for _, t := range tracks {
+		kept[t.TrackNumber()] = struct{}{}
+	}
+	for _, number := range referenced {
+		if _, ok := kept[number]; !ok {
+			return fmt.Errorf("トラック番号 %d は既にイベントのセットリストから参照されているため削除できません", number)
+		}
+	}
+	return nil
+}
+
 func buildTracks(inputs []TrackInput) ([]release.Track, error) {
 	tracks := make([]release.Track, 0, len(inputs))
 	for _, t := range inputs {
@@ -349,6 +459,50 @@ func buildTracks(inputs []TrackInput) ([]release.Track, error) {
 	return tracks, nil
 }
 
+func buildEdition(id string, input EditionInput) (release.Edition, error) {
+	tracks := make([]release.EditionTrack, 0, len(input.Tracks))
+	for _, t := range input.Tracks {
+		track, err := buildEditionTrack(t)
+		if err != nil {
+			return release.Edition{}, err
+		}
+		tracks = append(tracks, track)
+	}
+	bonusMedia := make([]release.BonusMedia, 0, len(input.BonusMedia))
+	for _, b := range input.BonusMedia {
+		media, err := release.NewBonusMedia(release.BonusMediaType(b.Type), b.Title, b.Description)
+		if err != nil {
+			return release.Edition{}, fmt.Errorf("特典エラー: %w", err)
+		}
+		bonusMedia = append(bonusMedia, media)
+	}
+	return release.NewEdition(id, input.Name, release.EditionType(input.EditionType), input.JANCode, input.UPC, input.Price, tracks, bonusMedia)
+}
+
+func buildEditionTrack(input EditionTrackInput) (release.EditionTrack, error) {
+	discNumber := input.DiscNumber
+	if discNumber == 0 {
+		discNumber = 1
+	}
+	switch {
+	case input.SharedTrackNumber != nil && input.Track != nil:
+		return release.EditionTrack{}, fmt.Errorf("収録曲は共通曲の参照とエディション固有の楽曲のどちらか一方を入力してください (track %d)", input.TrackNumber)
+	case input.SharedTrackNumber != nil:
+		return release.NewSharedEditionTrack(discNumber, input.TrackNumber, *input.SharedTrackNumber)
+	case input.Track != nil:
+		if input.TrackNumber != 0 && input.TrackNumber != input.Track.TrackNumber {
+			return release.EditionTrack{}, fmt.Errorf("エディション固有の楽曲のトラック番号が曲順と一致しません (track %d)", input.TrackNumber)
+		}
+		tracks, err := buildTracks([]TrackInput{*input.Track})
+		if err != nil {
+			return release.EditionTrack{}, err
+		}
+		return release.NewExclusiveEditionTrack(discNumber, tracks[0])
+	default:
+		return release.EditionTrack{}, fmt.Errorf("収録曲は共通曲の参照かエディション固有の楽曲の入力が必須です (track %d)", input.TrackNumber)
+	}
+}
+
 func buildTrackParticipants(inputs []TrackParticipantInput) ([]release.TrackParticipant, error) {
 	if inputs == nil {
 		return nil, nil
@@ -0,0 +1,288 @@
+package release
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEditionTestRelease(t *testing.T, svc *ApplicationService, title string) string {
+	t.Helper()
+	created, err := svc.CreateRelease(context.Background(), CreateInput{
+		Title:       title,
+		ReleaseType: "single",
+		ReleaseDate: "2026-05-08",
+		Artists: []ArtistRefInput{
+			{Kind: "group", ID: "group-1", Role: "main"},
+		},
+		Tracks: []TrackInput{
+			{TrackNumber: 1, Title: "表題曲"},
+			{TrackNumber: 2, Title: "カップリング曲"},
+		},
+	})
+	require.NoError(t, err)
+	return created.ID().Value()
+}
+
+func TestUpdateEditionsStoresSharedAndExclusiveTracks(t *testing.T) {
+	svc := NewApplicationService(newInMemoryReleaseRepo(), nil)
+	id := createEditionTestRelease(t, svc, "エディション登録")
+	jan := "4580000000010"
+	price := 1980
+	one, two := 1, 2
+	description := "表題曲ミュージックビデオ"
+
+	r, err := svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID: id,
+		Editions: []EditionInput{
+			{
+				Name:        "初回限定盤A",
+				EditionType: "first_press_limited",
+				JANCode:     &jan,
+				Price:       &price,
+				Tracks: []EditionTrackInput{
+					{TrackNumber: 1, SharedTrackNumber: &one},
+					{TrackNumber: 2, SharedTrackNumber: &two},
+					{Track: &TrackInput{TrackNumber: 3, Title: "Type-A収録曲"}},
+				},
+				BonusMedia: []BonusMediaInput{
+					{Type: "blu_ray", Title: "特典Blu-ray", Description: &description},
+				},
+			},
+			{Name: "通常盤", EditionType: "regular"},
+		},
+	})
+
+	require.NoError(t, err)
+	editions := r.Editions()
+	require.Len(t, editions, 2)
+	limited := editions[0]
+	assert.NotEmpty(t, limited.ID())
+	assert.Equal(t, jan, *limited.JANCode())
+	assert.Equal(t, price, *limited.Price())
+	require.Len(t, limited.Tracks(), 3)
+
+	shared, ok := r.EditionTrackOf(limited.Tracks()[1])
+	require.True(t, ok)
+	assert.Equal(t, "カップリング曲", shared.Title())
+	exclusive, ok := r.EditionTrackOf(limited.Tracks()[2])
+	require.True(t, ok)
+	assert.Equal(t, "Type-A収録曲", exclusive.Title())
+	assert.Equal(t, 1, limited.Tracks()[2].DiscNumber())
+
+	require.Len(t, limited.BonusMedia(), 1)
+	assert.Equal(t, "blu_ray", string(limited.BonusMedia()[0].MediaType()))
+
+	// 既存エディションは ID 指定で同じIDのまま更新できる
+	limitedID := limited.ID()
+	r, err = svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID:       id,
+		Editions: []EditionInput{{ID: &limitedID, Name: "初回限定盤A", EditionType: "first_press_limited", JANCode: &jan}},
+	})
+	require.NoError(t, err)
+	require.Len(t, r.Editions(), 1)
+	assert.Equal(t, limitedID, r.Editions()[0].ID())
+}
+
+func TestUpdateEditionsValidatesInput(t *testing.T) {
+	svc := NewApplicationService(newInMemoryReleaseRepo(), nil)
+	id := createEditionTestRelease(t, svc, "エディション検証")
+	one, missing := 1, 9
+	badJAN := "4580000000011"
+	jan := "4580000000010"
+	unknownID := "edition-unknown"
+
+	tests := []struct {
+		name     string
+		editions []EditionInput
+		wantErr  string
+	}{
+		{
+			name:     "JANコードのチェックディジット不一致",
+			editions: []EditionInput{{Name: "通常盤", EditionType: "regular", JANCode: &badJAN}},
+			wantErr:  "JANコードの形式が不正です",
+		},
+		{
+			name:     "未定義のエディション種別",
+			editions: []EditionInput{{Name: "通常盤", EditionType: "deluxe"}},
+			wantErr:  "無効なエディション種別です",
+		},
+		{
+			name: "リリース内でのJANコード重複",
+			editions: []EditionInput{
+				{Name: "初回限定盤A", EditionType: "first_press_limited", JANCode: &jan},
+				{Name: "初回限定盤B", EditionType: "first_press_limited", JANCode: &jan},
+			},
+			wantErr: "JANコード・UPCが重複しています",
+		},
+		{
+			name: "未登録の共通曲を参照",
+			editions: []EditionInput{{Name: "通常盤", EditionType: "regular", Tracks: []EditionTrackInput{
+				{TrackNumber: 1, SharedTrackNumber: &missing},
+			}}},
+			wantErr: "参照トラック番号が無効です",
+		},
+		{
+			name: "共通曲の参照と固有の楽曲を同時に指定",
+			editions: []EditionInput{{Name: "通常盤", EditionType: "regular", Tracks: []EditionTrackInput{
+				{TrackNumber: 1, SharedTrackNumber: &one, Track: &TrackInput{TrackNumber: 1, Title: "固有曲"}},
+			}}},
+			wantErr: "どちらか一方を入力してください",
+		},
+		{
+			name: "固有の楽曲のトラック番号が曲順と不一致",
+			editions: []EditionInput{{Name: "通常盤", EditionType: "regular", Tracks: []EditionTrackInput{
+				{TrackNumber: 4, Track: &TrackInput{TrackNumber: 3, Title: "固有曲"}},
+			}}},
+			wantErr: "トラック番号が曲順と一致しません",
+		},
+		{
+			name:     "存在しないエディションIDを指定",
+			editions: []EditionInput{{ID: &unknownID, Name: "通常盤", EditionType: "regular"}},
+			wantErr:  "エディションが見つかりません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.UpdateEditions(context.Background(), UpdateEditionsInput{ID: id, Editions: tt.editions})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestUpdateEditionsRejectsJANCodeOfAnotherRelease(t *testing.T) {
+	svc := NewApplicationService(newInMemoryReleaseRepo(), nil)
+	first := createEditionTestRelease(t, svc, "先行リリース")
+	second := createEditionTestRelease(t, svc, "後続リリース")
+	jan := "4901234567894"
+
+	_, err := svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID:       first,
+		Editions: []EditionInput{{Name: "通常盤", EditionType: "regular", JANCode: &jan}},
+	})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID:       second,
+		Editions: []EditionInput{{Name: "通常盤", EditionType: "regular", JANCode: &jan}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "既に別のリリースに登録されています")
+}
+
+func TestUpdateEditionsRejectsUPCOfAnotherRelease(t *testing.T) {
+	svc := NewApplicationService(newInMemoryReleaseRepo(), nil)
+	first := createEditionTestRelease(t, svc, "先行リリース")
+	second := createEditionTestRelease(t, svc, "後続リリース")
+	upc := "012345678905"
+
+	_, err := svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID:       first,
+		Editions: []EditionInput{{Name: "通常盤", EditionType: "regular", UPC: &upc}},
+	})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID:       second,
+		Editions: []EditionInput{{Name: "通常盤", EditionType: "regular", UPC: &upc}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "UPC '012345678905' は既に別のリリースに登録されています")
+}
+
+func TestFindEditionByJANCode(t *testing.T) {
+	svc := NewApplicationService(newInMemoryReleaseRepo(), nil)
+	id := createEditionTestRelease(t, svc, "JANコード検索")
+	janA, janB := "4580000000010", "4580000000027"
+
+	_, err := svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID: id,
+		Editions: []EditionInput{
+			{Name: "初回限定盤A", EditionType: "first_press_limited", JANCode: &janA},
+			{Name: "劇場盤", EditionType: "theater", JANCode: &janB},
+		},
+	})
+	require.NoError(t, err)
+
+	r, edition, err := svc.FindEditionByJANCode(context.Background(), janB)
+	require.NoError(t, err)
+	assert.Equal(t, id, r.ID().Value())
+	assert.Equal(t, "劇場盤", edition.Name())
+
+	_, _, err = svc.FindEditionByJANCode(context.Background(), "4901234567894")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "見つかりません")
+
+	// 短縮タイプ（8桁）のJANコードも形式として受け付ける
+	_, _, err = svc.FindEditionByJANCode(context.Background(), "49012347")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "見つかりません")
+
+	_, _, err = svc.FindEditionByJANCode(context.Background(), "12345")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "形式が不正です")
+}
+
+func TestUpdateReleaseRejectsRemovingTrackReferencedByEdition(t *testing.T) {
+	svc := NewApplicationService(newInMemoryReleaseRepo(), nil)
+	id := createEditionTestRelease(t, svc, "参照中の収録曲")
+	two := 2
+
+	_, err := svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID: id,
+		Editions: []EditionInput{{Name: "通常盤", EditionType: "regular", Tracks: []EditionTrackInput{
+			{TrackNumber: 1, SharedTrackNumber: &two},
+		}}},
+	})
+	require.NoError(t, err)
+
+	err = svc.UpdateRelease(context.Background(), UpdateInput{
+		ID:     id,
+		Tracks: []TrackInput{{TrackNumber: 1, Title: "表題曲"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "トラック番号 2 は既にエディション '通常盤' から参照されている")
+}
+
+func TestUpdateReleaseAllowsEditingTrackReferencedByEdition(t *testing.T) {
+	repo := newInMemoryReleaseRepo()
+	svc := NewApplicationService(repo, nil)
+	id := createEditionTestRelease(t, svc, "参照中の収録曲の修正")
+	two := 2
+
+	_, err := svc.UpdateEditions(context.Background(), UpdateEditionsInput{
+		ID: id,
+		Editions: []EditionInput{{Name: "通常盤", EditionType: "regular", Tracks: []EditionTrackInput{
+			{TrackNumber: 1, SharedTrackNumber: &two},
+		}}},
+	})
+	require.NoError(t, err)
+
+	// 参照中のトラック番号が残っていれば曲名の誤記修正もできる
+	duration := 245
+	err = svc.UpdateRelease(context.Background(), UpdateInput{
+		ID: id,
+		Tracks: []TrackInput{
+			{TrackNumber: 1, Title: "表題曲"},
+			{TrackNumber: 2, Title: "カップリング曲（修正）", DurationSec: &duration},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "カップリング曲（修正）", repo.data[id].Tracks()[1].Title())
+}
+
+func TestUpdateExternalIDsRejectsReleaseJANCode(t *testing.T) {
+	svc := NewApplicationService(newInMemoryReleaseRepo(), nil)
+	id := createEditionTestRelease(t, svc, "外部IDのJANコード")
+
+	err := svc.UpdateExternalIDs(context.Background(), UpdateExternalIDsInput{
+		ID:          id,
+		ExternalIDs: map[string]string{"jan_code": "4580000000010"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "無効な外部ID種別です")
+}
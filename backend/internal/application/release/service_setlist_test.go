@@ -0,0 +1,41 @@
+package release
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type setlistReferencesStub struct {
+	numbers map[string][]int
+}
+
+func (s *setlistReferencesStub) FindSetlistTrackNumbers(_ context.Context, releaseID string) ([]int, error) {
+	return s.numbers[releaseID], nil
+}
+
+func TestUpdateReleaseRejectsRemovingTrackReferencedBySetlist(t *testing.T) {
+	setlists := &setlistReferencesStub{numbers: map[string][]int{}}
+	svc := NewApplicationService(newInMemoryReleaseRepo(), nil).WithSetlistReferences(setlists)
+	id := createEditionTestRelease(t, svc, "セットリストで演奏された収録曲")
+	setlists.numbers[id] = []int{2}
+
+	err := svc.UpdateRelease(context.Background(), UpdateInput{
+		ID:     id,
+		Tracks: []TrackInput{{TrackNumber: 1, Title: "表題曲"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "トラック番号 2 は既にイベントのセットリストから参照されている")
+
+	// 参照中のトラック番号が残っていれば曲情報は更新できる
+	err = svc.UpdateRelease(context.Background(), UpdateInput{
+		ID: id,
+		Tracks: []TrackInput{
+			{TrackNumber: 1, Title: "表題曲"},
+			{TrackNumber: 2, Title: "カップリング曲（修正）"},
+		},
+	})
+	require.NoError(t, err)
+}
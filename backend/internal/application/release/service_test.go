@@ -95,6 +95,26 @@ func (r *inMemoryReleaseRepo) FindByArtistID(_ context.Context, artistID string)
 	return result, nil
 }
 
+func (r *inMemoryReleaseRepo) FindByEditionJANCode(_ context.Context, janCode string) (*domainRelease.Release, error) {
+	for _, rel := range r.data {
+		if _, ok := rel.EditionByJANCode(janCode); ok {
+			return rel, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *inMemoryReleaseRepo) FindByEditionUPC(_ context.Context, upc string) (*domainRelease.Release, error) {
+	for _, rel := range r.data {
+		for _, e := range rel.Editions() {
+			if e.UPC() != nil && *e.UPC() == upc {
+				return rel, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
 func TestCreateReleaseRejectsDuplicateTrackNumbers(t *testing.T) {
 	svc := NewApplicationService(newInMemoryReleaseRepo(), nil)
 
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// LeaseStore はタスク実行権（リース）を取得する契約。
+// 有効なリースを他の保持者が持っている間は取得できない
+type LeaseStore interface {
+	TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+}
+
+// Task は一定間隔で実行する時刻起点の処理（イベントの自動終了、SLA リマインドなど）
+type Task struct {
+	Name     string        // リース名を兼ねるため、レプリカ間で同じ処理には同じ名前を付ける
+	Interval time.Duration // 実行間隔。リースの有効期間にも使う
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler は登録したタスクを定期実行するバックグラウンドワーカー。
+// 実行前にタスクごとのリースを取得するため、複数レプリカで動かしても同じ間隔内に実行されるのは1回だけ
+type Scheduler struct {
+	leases LeaseStore
+	holder string
+	tasks  []Task
+	wg     sync.WaitGroup
+}
+
+// NewScheduler は Scheduler を作成する。holder はレプリカごとに一意な識別子
+func NewScheduler(leases LeaseStore, holder string) *Scheduler {
+	return &Scheduler{leases: leases, holder: holder}
+}
+
+// Register はタスクを登録する。Start より前に呼ぶ
+func (s *Scheduler) Register(task Task) error {
+	if task.Name == "" || task.Run == nil {
+		return errors.New("タスク名と処理は必須です")
+	}
+	if task.Interval <= 0 {
+		return errors.New("タスクの実行間隔は正の値である必要があります")
+	}
+	s.tasks = append(s.tasks, task)
+	return nil
+}
+
+// Start は登録済みのタスクごとにワーカーを起動する。
+// ctx がキャンセルされるとワーカーは停止し、Shutdown() の待機対象に含まれる。
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, task := range s.tasks {
+		s.wg.Add(1)
+		go func(task Task) {
+			defer s.wg.Done()
+			ticker := time.NewTicker(task.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := s.RunTask(ctx, task); err != nil {
+						slog.Error("スケジューラのタスク実行エラー", "task", task.Name, "error", err)
+					}
+				}
+			}
+		}(task)
+	}
+}
+
+// Shutdown は実行中のタスクが完了するまで待機する。
+func (s *Scheduler) Shutdown() {
+	s.wg.Wait()
+}
+
+// RunTask はリースを取得できた場合のみタスクを実行する。
+// リースは実行後も解放せず有効期間まで保持し、他のレプリカが同じ間隔内に重ねて実行しないようにする
+func (s *Scheduler) RunTask(ctx context.Context, task Task) error {
+	acquired, err := s.leases.TryAcquire(ctx, task.Name, s.holder, task.Interval)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		// 他のレプリカが実行中または実行済み
+		return nil
+	}
+	return task.Run(ctx)
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// leaseStoreStub はリースの保持者と期限をメモリ上で管理する
+type leaseStoreStub struct {
+	holders map[string]string
+	expires map[string]time.Time
+	now     time.Time
+}
+
+func newLeaseStoreStub() *leaseStoreStub {
+	return &leaseStoreStub{holders: map[string]string{}, expires: map[string]time.Time{}, now: time.Now()}
+}
+
+func (s *leaseStoreStub) TryAcquire(_ context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	if h, ok := s.holders[name]; ok && h != holder && s.now.Before(s.expires[name]) {
+		return false, nil
+	}
+	s.holders[name] = holder
+	s.expires[name] = s.now.Add(ttl)
+	return true, nil
+}
+
+func TestScheduler_RunTaskOnlyOnReplicaHoldingLease(t *testing.T) {
+	leases := newLeaseStoreStub()
+	replicaA := NewScheduler(leases, "replica-a")
+	replicaB := NewScheduler(leases, "replica-b")
+
+	runs := 0
+	task := Task{Name: "event.complete", Interval: time.Minute, Run: func(context.Context) error {
+		runs++
+		return nil
+	}}
+
+	require.NoError(t, replicaA.RunTask(context.Background(), task))
+	require.NoError(t, replicaB.RunTask(context.Background(), task))
+	assert.Equal(t, 1, runs)
+
+	// リースの期限が切れると別のレプリカが実行できる
+	leases.now = leases.now.Add(time.Minute)
+	require.NoError(t, replicaB.RunTask(context.Background(), task))
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, "replica-b", leases.holders["event.complete"])
+}
+
+func TestScheduler_RunTaskReturnsTaskError(t *testing.T) {
+	s := NewScheduler(newLeaseStoreStub(), "replica-a")
+	err := s.RunTask(context.Background(), Task{Name: "failing", Interval: time.Minute, Run: func(context.Context) error {
+		return errors.New("失敗")
+	}})
+	assert.EqualError(t, err, "失敗")
+}
+
+func TestScheduler_RegisterValidatesTask(t *testing.T) {
+	s := NewScheduler(newLeaseStoreStub(), "replica-a")
+	noop := func(context.Context) error { return nil }
+
+	assert.Error(t, s.Register(Task{Interval: time.Minute, Run: noop}))
+	assert.Error(t, s.Register(Task{Name: "noop", Run: noop}))
+	assert.Error(t, s.Register(Task{Name: "noop", Interval: time.Minute}))
+	assert.NoError(t, s.Register(Task{Name: "noop", Interval: time.Minute, Run: noop}))
+}
@@ -0,0 +1,15 @@
+package search
+
+// SearchInput は横断検索の入力
+type SearchInput struct {
+	Query string
+	Types []string // 空の場合は全種別
+	Limit int
+}
+
+// SuggestInput は入力補完の入力
+type SuggestInput struct {
+	Query string
+	Types []string // 空の場合は全種別
+	Limit int
+}
@@ -0,0 +1,139 @@
+// Package search は横断検索のアプリケーションサービス
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domain "github.com/kuro48/idol-api/internal/domain/search"
+	"github.com/kuro48/idol-api/internal/shared/ttlcache"
+)
+
+const (
+	// DefaultLimit は返却するヒット件数の既定値
+	DefaultLimit = 20
+	// MaxLimit は返却するヒット件数の上限
+	MaxLimit = 50
+	// MaxQueryLength は検索語の最大文字数
+	MaxQueryLength = 100
+	// candidatesPerType は種別ごとに取得する候補数の上限（ランキング前）
+	candidatesPerType = 50
+)
+
+// ApplicationService は横断検索アプリケーションサービス
+type ApplicationService struct {
+	repository        domain.Repository
+	suggestRepository domain.SuggestRepository
+	suggestCache      *ttlcache.Cache[string, []domain.Suggestion]
+}
+
+// NewApplicationService はアプリケーションサービスを作成する。
+// suggestCacheTTL が 0 以下の場合は入力補完の結果をキャッシュしない
+func NewApplicationService(repository domain.Repository, suggestRepository domain.SuggestRepository, suggestCacheTTL time.Duration) *ApplicationService {
+	return &ApplicationService{
+		repository:        repository,
+		suggestRepository: suggestRepository,
+		suggestCache:      ttlcache.New[string, []domain.Suggestion](suggestCacheCapacity, suggestCacheTTL),
+	}
+}
+
+// SearchResult は横断検索の結果
+type SearchResult struct {
+	Types  []domain.EntityType
+	Hits   []domain.Hit              // スコア順、Limit 件まで
+	Facets map[domain.EntityType]int // 種別ごとの総ヒット件数（Limit 適用前）
+	Total  int
+	Limit  int
+}
+
+// Search は全集約を横断して検索語に一致するものをランキングして返す
+func (s *ApplicationService) Search(ctx context.Context, input SearchInput) (*SearchResult, error) {
+	query, err := validateQuery(input.Query, MaxQueryLength)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := parseTypes(input.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	candidates, err := s.repository.FindCandidates(ctx, domain.Criteria{
+		Query: query,
+		Types: types,
+		Limit: candidatesPerType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("検索候補の取得エラー: %w", err)
+	}
+
+	counts, err := s.repository.CountMatches(ctx, domain.Criteria{Query: query, Types: types})
+	if err != nil {
+		return nil, fmt.Errorf("検索件数の取得エラー: %w", err)
+	}
+
+	hits := domain.Rank(query, candidates)
+	facets := domain.CountByType(hits)
+	total := 0
+	for _, t := range types {
+		// 件数クエリは類似一致を含まないため、取得済みの候補のほうが多ければそちらを採る
+		facets[t] = max(facets[t], counts[t])
+		total += facets[t]
+	}
+	result := &SearchResult{
+		Types:  types,
+		Facets: facets,
+		Total:  total,
+		Limit:  limit,
+	}
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	result.Hits = hits
+	return result, nil
+}
+
+// validateQuery は検索語の前後の空白を除去し、必須・文字数を検証する
+func validateQuery(value string, maxLength int) (string, error) {
+	query := strings.TrimSpace(value)
+	if query == "" {
+		return "", errors.New("検索語は必須です")
+	}
+	if utf8.RuneCountInString(query) > maxLength {
+		return "", fmt.Errorf("検索語は%d文字以内で入力してください", maxLength)
+	}
+	return query, nil
+}
+
+// parseTypes は検索対象種別を検証する。未指定の場合は全種別を返す
+func parseTypes(values []string) ([]domain.EntityType, error) {
+	if len(values) == 0 {
+		return domain.AllEntityTypes(), nil
+	}
+	types := make([]domain.EntityType, 0, len(values))
+	seen := make(map[domain.EntityType]struct{}, len(values))
+	for _, v := range values {
+		t, err := domain.ParseEntityType(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		types = append(types, t)
+	}
+	return types, nil
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/kuro48/idol-api/internal/domain/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSearchRepository struct {
+	candidates []domain.Candidate
+	counts     map[domain.EntityType]int
+	criteria   domain.Criteria
+}
+
+func (f *fakeSearchRepository) FindCandidates(_ context.Context, criteria domain.Criteria) ([]domain.Candidate, error) {
+	f.criteria = criteria
+	return f.candidates, nil
+}
+
+func (f *fakeSearchRepository) CountMatches(_ context.Context, _ domain.Criteria) (map[domain.EntityType]int, error) {
+	return f.counts, nil
+}
+
+func nameCandidate(t domain.EntityType, id, name string) domain.Candidate {
+	return domain.Candidate{Type: t, ID: id, Name: name, Fields: []domain.Field{{Name: "name", Value: name}}}
+}
+
+func TestSearch_RanksAndCountsFacetsBeforeLimit(t *testing.T) {
+	repo := &fakeSearchRepository{candidates: []domain.Candidate{
+		nameCandidate(domain.TypeGroup, "g1", "さくら学院"),
+		nameCandidate(domain.TypeIdol, "i1", "さくら"),
+		nameCandidate(domain.TypeRelease, "r1", "はるさくら"),
+	}}
+	svc := NewApplicationService(repo, nil, 0)
+
+	result, err := svc.Search(context.Background(), SearchInput{Query: " サクラ ", Limit: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, "サクラ", repo.criteria.Query)
+	assert.Equal(t, domain.AllEntityTypes(), repo.criteria.Types)
+	require.Len(t, result.Hits, 2)
+	assert.Equal(t, "i1", result.Hits[0].Candidate.ID)
+	assert.Equal(t, "g1", result.Hits[1].Candidate.ID)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 1, result.Facets[domain.TypeRelease])
+}
+
+func TestSearch_FacetsCountMatchesBeyondCandidateLimit(t *testing.T) {
+	repo := &fakeSearchRepository{
+		candidates: []domain.Candidate{
+			nameCandidate(domain.TypeIdol, "i1", "さくら"),
+			nameCandidate(domain.TypeGroup, "g1", "さくら学院"),
+		},
+		counts: map[domain.EntityType]int{domain.TypeIdol: 120, domain.TypeGroup: 0},
+	}
+	svc := NewApplicationService(repo, nil, 0)
+
+	result, err := svc.Search(context.Background(), SearchInput{Query: "さくら", Types: []string{"idol", "group"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 120, result.Facets[domain.TypeIdol])
+	assert.Equal(t, 1, result.Facets[domain.TypeGroup])
+	assert.Equal(t, 121, result.Total)
+}
+
+func TestSearch_ValidatesInput(t *testing.T) {
+	svc := NewApplicationService(&fakeSearchRepository{}, nil, 0)
+
+	_, err := svc.Search(context.Background(), SearchInput{Query: "  "})
+	assert.ErrorContains(t, err, "必須")
+
+	_, err = svc.Search(context.Background(), SearchInput{Query: "さくら", Types: []string{"song"}})
+	assert.ErrorContains(t, err, "無効な検索対象タイプ")
+}
+
+func TestSearch_FiltersTypes(t *testing.T) {
+	repo := &fakeSearchRepository{}
+	svc := NewApplicationService(repo, nil, 0)
+
+	_, err := svc.Search(context.Background(), SearchInput{Query: "さくら", Types: []string{"idol", "venue", "idol"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.EntityType{domain.TypeIdol, domain.TypeVenue}, repo.criteria.Types)
+}
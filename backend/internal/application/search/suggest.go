@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	domain "github.com/kuro48/idol-api/internal/domain/search"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
+)
+
+const (
+	// DefaultSuggestLimit は入力補完で返す候補数の既定値
+	DefaultSuggestLimit = 10
+	// MaxSuggestLimit は入力補完で返す候補数の上限
+	MaxSuggestLimit = 20
+	// MaxSuggestQueryLength は入力補完の検索語の最大文字数
+	MaxSuggestQueryLength = 50
+	// suggestCandidateFactor は種別ごとに返却件数の何倍の候補を取得してランキングするか
+	suggestCandidateFactor = 3
+	// suggestCacheCapacity は入力補完キャッシュに保持する検索語の最大数
+	suggestCacheCapacity = 1024
+)
+
+// SuggestResult は入力補完の結果
+type SuggestResult struct {
+	Suggestions []domain.Suggestion // 一致度順、Limit 件まで
+	Limit       int
+}
+
+// Suggest は名前・読み・別名の前方一致で入力補完候補を返す。
+// 同じ検索語（正規化後）・種別・件数の結果は短時間プロセス内にキャッシュする
+func (s *ApplicationService) Suggest(ctx context.Context, input SuggestInput) (*SuggestResult, error) {
+	query, err := validateQuery(input.Query, MaxSuggestQueryLength)
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := parseTypes(input.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultSuggestLimit
+	}
+	if limit > MaxSuggestLimit {
+		limit = MaxSuggestLimit
+	}
+
+	key := suggestCacheKey(query, types, limit)
+	if cached, ok := s.suggestCache.Get(key); ok {
+		return &SuggestResult{Suggestions: cached, Limit: limit}, nil
+	}
+
+	candidates, err := s.suggestRepository.FindByPrefix(ctx, domain.SuggestCriteria{
+		Query: query,
+		Types: types,
+		Limit: limit * suggestCandidateFactor,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("入力補完候補の取得エラー: %w", err)
+	}
+
+	suggestions := domain.RankSuggestions(query, candidates, limit)
+	s.suggestCache.Set(key, suggestions)
+	return &SuggestResult{Suggestions: suggestions, Limit: limit}, nil
+}
+
+// suggestCacheKey は表記ゆれと種別の指定順を吸収したキャッシュキーを返す（"サクラ" と "さくら" は同じキーになる）
+func suggestCacheKey(query string, types []domain.EntityType, limit int) string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, string(t))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",") + "|" + searchkey.Normalize(query) + "|" + strconv.Itoa(limit)
+}
@@ -0,0 +1,67 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSuggestRepository struct {
+	suggestions []domain.Suggestion
+	criteria    domain.SuggestCriteria
+	calls       int
+}
+
+func (f *fakeSuggestRepository) FindByPrefix(_ context.Context, criteria domain.SuggestCriteria) ([]domain.Suggestion, error) {
+	f.criteria = criteria
+	f.calls++
+	return f.suggestions, nil
+}
+
+func TestSuggest_RanksPrefixMatchesWithinLimit(t *testing.T) {
+	repo := &fakeSuggestRepository{suggestions: []domain.Suggestion{
+		{Candidate: nameCandidate(domain.TypeGroup, "g1", "さくら学院"), Hint: domain.Hint{FormationYear: 2010}},
+		{Candidate: nameCandidate(domain.TypeIdol, "i1", "さくら"), Hint: domain.Hint{AgencyName: "アミューズ", BirthYear: 2001}},
+		{Candidate: nameCandidate(domain.TypeVenue, "v1", "さくらホール")},
+	}}
+	svc := NewApplicationService(&fakeSearchRepository{}, repo, 0)
+
+	result, err := svc.Suggest(context.Background(), SuggestInput{Query: "サクラ", Limit: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, 6, repo.criteria.Limit)
+	require.Len(t, result.Suggestions, 2)
+	assert.Equal(t, "i1", result.Suggestions[0].ID)
+	assert.Equal(t, "アミューズ", result.Suggestions[0].Hint.AgencyName)
+	assert.Equal(t, 2, result.Limit)
+}
+
+func TestSuggest_CachesByNormalizedQuery(t *testing.T) {
+	repo := &fakeSuggestRepository{suggestions: []domain.Suggestion{
+		{Candidate: nameCandidate(domain.TypeIdol, "i1", "さくら")},
+	}}
+	svc := NewApplicationService(&fakeSearchRepository{}, repo, time.Minute)
+
+	_, err := svc.Suggest(context.Background(), SuggestInput{Query: "さくら", Types: []string{"idol", "group"}})
+	require.NoError(t, err)
+	result, err := svc.Suggest(context.Background(), SuggestInput{Query: "サクラ", Types: []string{"group", "idol"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, repo.calls)
+	require.Len(t, result.Suggestions, 1)
+	assert.Equal(t, "i1", result.Suggestions[0].ID)
+}
+
+func TestSuggest_ValidatesInput(t *testing.T) {
+	svc := NewApplicationService(&fakeSearchRepository{}, &fakeSuggestRepository{}, 0)
+
+	_, err := svc.Suggest(context.Background(), SuggestInput{Query: ""})
+	assert.ErrorContains(t, err, "必須")
+
+	_, err = svc.Suggest(context.Background(), SuggestInput{Query: "さくら", Types: []string{"song"}})
+	assert.ErrorContains(t, err, "無効な検索対象タイプ")
+}
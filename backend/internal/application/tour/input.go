@@ -0,0 +1,28 @@
+package tour
+
+// PerformerInput は出演者の入力データ
+type PerformerInput struct {
+	PerformerID   string
+	BillingStatus string
+}
+
+// CreateInput はツアー作成の入力
+type CreateInput struct {
+	Title       string
+	EventType   string
+	Performers  []PerformerInput
+	Tags        []string
+	OfficialURL *string
+	Description *string
+}
+
+// UpdateInput はツアー更新の入力。Performers・Tags は nil の場合は変更しない
+type UpdateInput struct {
+	ID          string
+	Title       *string
+	EventType   *string
+	Performers  []PerformerInput
+	Tags        []string
+	OfficialURL *string
+	Description *string
+}
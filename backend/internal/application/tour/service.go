@@ -0,0 +1,225 @@
+package tour
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/domain/tour"
+	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+	sharedid "github.com/kuro48/idol-api/internal/shared/id"
+)
+
+// WebhookPublisher はツアー変更を通知する契約
+type WebhookPublisher interface {
+	Publish(ctx context.Context, event domainWebhook.EventType, payload interface{}) error
+}
+
+// ApplicationService はツアーのアプリケーションサービス。
+// ツアーの出演者・タグの変更は配下のイベントにまとめて反映し、Webhook はツアー単位で1件だけ通知する
+type ApplicationService struct {
+	repository tour.Repository
+	events     event.Repository
+	publisher  WebhookPublisher
+}
+
+func NewApplicationService(repository tour.Repository, events event.Repository, publisher WebhookPublisher) *ApplicationService {
+	return &ApplicationService{repository: repository, events: events, publisher: publisher}
+}
+
+// CreateTour はツアーを作成する
+func (s *ApplicationService) CreateTour(ctx context.Context, input CreateInput) (*tour.Tour, error) {
+	eventType, err := event.NewEventType(input.EventType)
+	if err != nil {
+		return nil, fmt.Errorf("イベントタイプの生成エラー: %w", err)
+	}
+	t, err := tour.NewTour(input.Title, eventType)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.UpdateDetails(nil, nil, input.OfficialURL, input.Description); err != nil {
+		return nil, err
+	}
+	performers, err := toPerformers(input.Performers)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.ReplacePerformers(performers); err != nil {
+		return nil, err
+	}
+	t.ReplaceTags(input.Tags)
+
+	id, err := tour.NewTourID(sharedid.Generate())
+	if err != nil {
+		return nil, fmt.Errorf("IDの生成エラー: %w", err)
+	}
+	t.SetID(id)
+
+	if err := s.repository.Save(ctx, t); err != nil {
+		return nil, fmt.Errorf("ツアーの保存エラー: %w", err)
+	}
+
+	s.publishWebhook(ctx, domainWebhook.EventTourCreated, tourWebhookPayload(t, nil))
+	return t, nil
+}
+
+// GetTour はツアーを取得する
+func (s *ApplicationService) GetTour(ctx context.Context, id string) (*tour.Tour, error) {
+	tourID, err := tour.NewTourID(id)
+	if err != nil {
+		return nil, fmt.Errorf("IDの生成エラー: %w", err)
+	}
+	t, err := s.repository.FindByID(ctx, tourID)
+	if err != nil {
+		return nil, fmt.Errorf("ツアーの取得エラー: %w", err)
+	}
+	return t, nil
+}
+
+// SearchTours は条件に合うツアーと総件数を取得する
+func (s *ApplicationService) SearchTours(ctx context.Context, criteria tour.SearchCriteria) ([]*tour.Tour, int64, error) {
+	tours, err := s.repository.Search(ctx, criteria)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ツアー検索エラー: %w", err)
+	}
+	total, err := s.repository.Count(ctx, criteria)
+	if err != nil {
+		return nil, 0, fmt.Errorf("件数取得エラー: %w", err)
+	}
+	return tours, total, nil
+}
+
+// UpdateTour はツアーを更新する。
+// 出演者・タグを変更した場合、外れたものは配下のイベントから取り除き、加わったものは各イベントに引き継ぐ
+func (s *ApplicationService) UpdateTour(ctx context.Context, input UpdateInput) error {
+	t, err := s.GetTour(ctx, input.ID)
+	if err != nil {
+		return err
+	}
+
+	var eventType *event.EventType
+	if input.EventType != nil {
+		et, err := event.NewEventType(*input.EventType)
+		if err != nil {
+			return fmt.Errorf("イベントタイプの生成エラー: %w", err)
+		}
+		eventType = &et
+	}
+	if err := t.UpdateDetails(input.Title, eventType, input.OfficialURL, input.Description); err != nil {
+		return err
+	}
+
+	var removedPerformers, removedTags []string
+	if input.Performers != nil {
+		performers, err := toPerformers(input.Performers)
+		if err != nil {
+			return err
+		}
+		if removedPerformers, err = t.ReplacePerformers(performers); err != nil {
+			return err
+		}
+	}
+	if input.Tags != nil {
+		removedTags = t.ReplaceTags(input.Tags)
+	}
+
+	if err := s.repository.Update(ctx, t); err != nil {
+		return fmt.Errorf("ツアーの更新エラー: %w", err)
+	}
+
+	var eventIDs []string
+	if input.Performers != nil || input.Tags != nil {
+		eventIDs, err = s.propagate(ctx, t, removedPerformers, removedTags)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.publishWebhook(ctx, domainWebhook.EventTourUpdated, tourWebhookPayload(t, eventIDs))
+	return nil
+}
+
+// DeleteTour はツアーを削除する。配下のイベントは削除せずツアーから外し、引き継いだ出演者・タグを取り除く
+func (s *ApplicationService) DeleteTour(ctx context.Context, id string) error {
+	t, err := s.GetTour(ctx, id)
+	if err != nil {
+		return err
+	}
+	children, err := s.events.FindBySeries(ctx, t.ID().Value())
+	if err != nil {
+		return err
+	}
+	eventIDs := make([]string, 0, len(children))
+	for _, e := range children {
+		e.LeaveSeries()
+		if err := s.events.Update(ctx, e); err != nil {
+			return fmt.Errorf("イベントの更新エラー: %w", err)
+		}
+		eventIDs = append(eventIDs, e.ID().Value())
+	}
+	if err := s.repository.Delete(ctx, t.ID()); err != nil {
+		return fmt.Errorf("ツアーの削除エラー: %w", err)
+	}
+
+	s.publishWebhook(ctx, domainWebhook.EventTourDeleted, map[string]interface{}{"id": t.ID().Value(), "event_ids": eventIDs})
+	return nil
+}
+
+// propagate はツアーの出演者・タグを配下のイベントに反映し、更新したイベントのIDを返す
+func (s *ApplicationService) propagate(ctx context.Context, t *tour.Tour, removedPerformers, removedTags []string) ([]string, error) {
+	children, err := s.events.FindBySeries(ctx, t.ID().Value())
+	if err != nil {
+		return nil, err
+	}
+	eventIDs := make([]string, 0, len(children))
+	for _, e := range children {
+		// 公演側で登録した出演者・タグは残し、ツアーから引き継いだものだけを外す
+		e.DropSeriesInheritance(removedPerformers, removedTags)
+		e.InheritFromSeries(t.Performers(), t.Tags())
+		if err := s.events.Update(ctx, e); err != nil {
+			return nil, fmt.Errorf("イベントの更新エラー: %w", err)
+		}
+		eventIDs = append(eventIDs, e.ID().Value())
+	}
+	return eventIDs, nil
+}
+
+func toPerformers(inputs []PerformerInput) ([]event.Performer, error) {
+	performers := make([]event.Performer, 0, len(inputs))
+	for _, in := range inputs {
+		p, err := event.NewPerformer(in.PerformerID, in.BillingStatus)
+		if err != nil {
+			return nil, fmt.Errorf("パフォーマー生成エラー: %w", err)
+		}
+		performers = append(performers, p)
+	}
+	return performers, nil
+}
+
+func (s *ApplicationService) publishWebhook(ctx context.Context, eventType domainWebhook.EventType, payload interface{}) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.Publish(ctx, eventType, payload); err != nil {
+		slog.Error("ツアーWebhook配信キュー投入に失敗しました", "event", eventType, "error", err)
+	}
+}
+
+func tourWebhookPayload(t *tour.Tour, eventIDs []string) map[string]interface{} {
+	performerIDs := make([]string, 0, len(t.Performers()))
+	for _, p := range t.Performers() {
+		performerIDs = append(performerIDs, p.PerformerID)
+	}
+	payload := map[string]interface{}{
+		"id":            t.ID().Value(),
+		"title":         t.Title(),
+		"event_type":    t.EventType().Value(),
+		"performer_ids": performerIDs,
+		"tags":          t.Tags(),
+	}
+	if eventIDs != nil {
+		payload["updated_event_ids"] = eventIDs
+	}
+	return payload
+}
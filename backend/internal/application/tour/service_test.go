@@ -0,0 +1,266 @@
+package tour
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appEvent "github.com/kuro48/idol-api/internal/application/event"
+	"github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/domain/tour"
+	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tourRepoStub struct {
+	data map[string]*tour.Tour
+}
+
+func (r *tourRepoStub) Save(_ context.Context, t *tour.Tour) error {
+	r.data[t.ID().Value()] = t
+	return nil
+}
+
+func (r *tourRepoStub) FindByID(_ context.Context, id tour.TourID) (*tour.Tour, error) {
+	t, ok := r.data[id.Value()]
+	if !ok {
+		return nil, errors.New("ツアーが見つかりません")
+	}
+	return t, nil
+}
+
+func (r *tourRepoStub) Search(context.Context, tour.SearchCriteria) ([]*tour.Tour, error) {
+	return nil, nil
+}
+
+func (r *tourRepoStub) Count(context.Context, tour.SearchCriteria) (int64, error) {
+	return 0, nil
+}
+
+func (r *tourRepoStub) Update(_ context.Context, t *tour.Tour) error {
+	r.data[t.ID().Value()] = t
+	return nil
+}
+
+func (r *tourRepoStub) Delete(_ context.Context, id tour.TourID) error {
+	delete(r.data, id.Value())
+	return nil
+}
+
+type eventRepoStub struct {
+	event.Repository
+	data    map[string]*event.Event
+	updates int
+}
+
+func (r *eventRepoStub) Save(_ context.Context, e *event.Event) error {
+	r.data[e.ID().Value()] = e
+	return nil
+}
+
+func (r *eventRepoStub) FindByID(_ context.Context, id event.EventID) (*event.Event, error) {
+	e, ok := r.data[id.Value()]
+	if !ok {
+		return nil, errors.New("イベントが見つかりません")
+	}
+	return e, nil
+}
+
+func (r *eventRepoStub) Update(_ context.Context, e *event.Event) error {
+	r.updates++
+	r.data[e.ID().Value()] = e
+	return nil
+}
+
+func (r *eventRepoStub) FindBySeries(_ context.Context, seriesID string) ([]*event.Event, error) {
+	var found []*event.Event
+	for _, e := range r.data {
+		if e.SeriesID() != nil && *e.SeriesID() == seriesID {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+type publisherStub struct {
+	events []domainWebhook.EventType
+}
+
+func (p *publisherStub) Publish(_ context.Context, e domainWebhook.EventType, _ interface{}) error {
+	p.events = append(p.events, e)
+	return nil
+}
+
+func TestApplicationService_TourPerformersAndTagsFlowIntoEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tours := &tourRepoStub{data: map[string]*tour.Tour{}}
+	events := &eventRepoStub{data: map[string]*event.Event{}}
+	publisher := &publisherStub{}
+	svc := NewApplicationService(tours, events, publisher)
+	eventSvc := appEvent.NewApplicationService(events, tours, nil, nil, nil)
+
+	created, err := svc.CreateTour(ctx, CreateInput{
+		Title:      "全国ツアー2025",
+		EventType:  "live",
+		Performers: []PerformerInput{{PerformerID: "group-1", BillingStatus: "headliner"}},
+		Tags:       []string{"tour"},
+	})
+	require.NoError(t, err)
+	tourID := created.ID().Value()
+
+	var dates []*event.Event
+	for i := 0; i < 3; i++ {
+		e, err := eventSvc.CreateEvent(ctx, appEvent.CreateInput{
+			Title:         "全国ツアー2025 公演",
+			StartDateTime: time.Date(2025, 6, 1+i, 18, 0, 0, 0, time.UTC).Format(time.RFC3339),
+			Performers:    []appEvent.PerformerInput{{PerformerID: "guest-1"}},
+			SeriesID:      &tourID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "live", e.EventType().Value(), "イベントタイプはツアーから引き継ぐ")
+		assert.ElementsMatch(t, []string{"guest-1", "group-1"}, e.PerformerIDs())
+		assert.Equal(t, []string{"tour"}, e.Tags())
+		dates = append(dates, e)
+	}
+
+	require.NoError(t, svc.UpdateTour(ctx, UpdateInput{
+		ID:         tourID,
+		Performers: []PerformerInput{{PerformerID: "group-2"}},
+		Tags:       []string{"tour", "2025"},
+	}))
+
+	for _, d := range dates {
+		got := events.data[d.ID().Value()]
+		assert.ElementsMatch(t, []string{"guest-1", "group-2"}, got.PerformerIDs())
+		assert.ElementsMatch(t, []string{"tour", "2025"}, got.Tags())
+	}
+	// 公演ごとではなくツアー単位で通知する
+	assert.Equal(t, []domainWebhook.EventType{domainWebhook.EventTourCreated, domainWebhook.EventTourUpdated}, publisher.events)
+
+	require.NoError(t, svc.DeleteTour(ctx, tourID))
+	for _, d := range dates {
+		got := events.data[d.ID().Value()]
+		assert.Nil(t, got.SeriesID())
+		assert.Equal(t, []string{"guest-1"}, got.PerformerIDs(), "ツアーから引き継いだ出演者は外れる")
+		assert.Empty(t, got.Tags())
+	}
+	assert.Equal(t, domainWebhook.EventTourDeleted, publisher.events[len(publisher.events)-1])
+}
+
+func TestApplicationService_CreateEventInUnknownTour(t *testing.T) {
+	t.Parallel()
+
+	tours := &tourRepoStub{data: map[string]*tour.Tour{}}
+	eventSvc := appEvent.NewApplicationService(&eventRepoStub{data: map[string]*event.Event{}}, tours, nil, nil, nil)
+	missing := "missing"
+
+	_, err := eventSvc.CreateEvent(context.Background(), appEvent.CreateInput{
+		Title:         "公演",
+		StartDateTime: time.Now().Format(time.RFC3339),
+		SeriesID:      &missing,
+	})
+	assert.ErrorContains(t, err, "見つかりません")
+}
+
+func TestApplicationService_EventLeavingTourDropsInheritedPerformersAndTags(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tours := &tourRepoStub{data: map[string]*tour.Tour{}}
+	events := &eventRepoStub{data: map[string]*event.Event{}}
+	svc := NewApplicationService(tours, events, nil)
+	eventSvc := appEvent.NewApplicationService(events, tours, nil, nil, nil)
+
+	first, err := svc.CreateTour(ctx, CreateInput{
+		Title:      "春ツアー",
+		EventType:  "live",
+		Performers: []PerformerInput{{PerformerID: "group-1"}},
+		Tags:       []string{"spring"},
+	})
+	require.NoError(t, err)
+	second, err := svc.CreateTour(ctx, CreateInput{
+		Title:      "秋ツアー",
+		EventType:  "live",
+		Performers: []PerformerInput{{PerformerID: "group-2"}},
+		Tags:       []string{"autumn"},
+	})
+	require.NoError(t, err)
+	firstID, secondID := first.ID().Value(), second.ID().Value()
+
+	e, err := eventSvc.CreateEvent(ctx, appEvent.CreateInput{
+		Title:         "公演",
+		StartDateTime: time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		Performers:    []appEvent.PerformerInput{{PerformerID: "guest-1"}},
+		SeriesID:      &firstID,
+	})
+	require.NoError(t, err)
+
+	// 別のツアーへ移すと元のツアーから引き継いだ出演者・タグは外れる
+	require.NoError(t, eventSvc.UpdateEvent(ctx, appEvent.UpdateInput{ID: e.ID().Value(), SeriesID: &secondID}))
+	got := events.data[e.ID().Value()]
+	assert.ElementsMatch(t, []string{"guest-1", "group-2"}, got.PerformerIDs())
+	assert.Equal(t, []string{"autumn"}, got.Tags())
+
+	leave := ""
+	require.NoError(t, eventSvc.UpdateEvent(ctx, appEvent.UpdateInput{ID: e.ID().Value(), SeriesID: &leave}))
+	got = events.data[e.ID().Value()]
+	assert.Nil(t, got.SeriesID())
+	assert.Equal(t, []string{"guest-1"}, got.PerformerIDs())
+	assert.Empty(t, got.Tags())
+}
+
+func TestApplicationService_EventOwnedPerformersAndTagsSurviveTourChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tours := &tourRepoStub{data: map[string]*tour.Tour{}}
+	events := &eventRepoStub{data: map[string]*event.Event{}}
+	svc := NewApplicationService(tours, events, nil)
+	eventSvc := appEvent.NewApplicationService(events, tours, nil, nil, nil)
+
+	created, err := svc.CreateTour(ctx, CreateInput{
+		Title:      "夏ツアー",
+		EventType:  "live",
+		Performers: []PerformerInput{{PerformerID: "group-1"}, {PerformerID: "group-2"}},
+		Tags:       []string{"summer", "live"},
+	})
+	require.NoError(t, err)
+	tourID := created.ID().Value()
+
+	// 公演側で先に登録した出演者・タグはツアーと重なっても公演のものとして扱う
+	e, err := eventSvc.CreateEvent(ctx, appEvent.CreateInput{
+		Title:         "公演",
+		StartDateTime: time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		Performers:    []appEvent.PerformerInput{{PerformerID: "group-1", BillingStatus: "guest"}},
+		Tags:          []string{"live"},
+		SeriesID:      &tourID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"group-2"}, e.InheritedPerformerIDs())
+	assert.Equal(t, []string{"summer"}, e.InheritedTags())
+
+	// ツアーから外した出演者・タグでも公演側で登録したものは残す
+	require.NoError(t, svc.UpdateTour(ctx, UpdateInput{
+		ID:         tourID,
+		Performers: []PerformerInput{},
+		Tags:       []string{},
+	}))
+	got := events.data[e.ID().Value()]
+	assert.Equal(t, []string{"group-1"}, got.PerformerIDs())
+	assert.Equal(t, []string{"live"}, got.Tags())
+
+	require.NoError(t, svc.UpdateTour(ctx, UpdateInput{
+		ID:         tourID,
+		Performers: []PerformerInput{{PerformerID: "group-1"}, {PerformerID: "group-3"}},
+		Tags:       []string{"summer"},
+	}))
+	require.NoError(t, svc.DeleteTour(ctx, tourID))
+	got = events.data[e.ID().Value()]
+	assert.Nil(t, got.SeriesID())
+	assert.Equal(t, []string{"group-1"}, got.PerformerIDs())
+	assert.Equal(t, []string{"live"}, got.Tags())
+}
@@ -0,0 +1,237 @@
+package usage
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	domainapikey "github.com/kuro48/idol-api/internal/domain/apikey"
+	domainusage "github.com/kuro48/idol-api/internal/domain/usage"
+	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+)
+
+const (
+	// maxConcurrentAlerts はしきい値通知の最大同時処理数
+	maxConcurrentAlerts = 20
+	// alertTimeout はしきい値通知1件あたりのタイムアウト
+	alertTimeout = 15 * time.Second
+	// emailRetryLease は再送のために確保したメールを他のレプリカが確保できるようになるまでの時間
+	emailRetryLease = 5 * time.Minute
+	// emailRetryBatchSize は1回の再送処理で扱うメールの最大件数
+	emailRetryBatchSize = 100
+)
+
+// ThresholdNotifier はしきい値到達をメール通知する契約
+type ThresholdNotifier interface {
+	NotifyUsageThreshold(ctx context.Context, alert domainusage.ThresholdAlert) error
+}
+
+// WebhookPublisher はしきい値到達イベントを配信する契約
+type WebhookPublisher interface {
+	Publish(ctx context.Context, event domainWebhook.EventType, payload interface{}) error
+}
+
+// UpgradeLinkProvider はAPIキーのアップグレード用リンクを生成する契約
+type UpgradeLinkProvider interface {
+	CreateUpgradeURL(ctx context.Context, key *domainapikey.APIKey) (string, error)
+}
+
+// AlertService は月次使用量のしきい値到達を通知するアプリケーションサービス
+// outbox / notifier / publisher / upgradeLinks はいずれも nil 可（未設定の経路は使わない）
+type AlertService struct {
+	usageRepo    domainusage.Repository
+	outbox       domainusage.ThresholdEmailRepository
+	notifier     ThresholdNotifier
+	publisher    WebhookPublisher
+	upgradeLinks UpgradeLinkProvider
+	sem          chan struct{}
+	wg           sync.WaitGroup
+	now          func() time.Time
+}
+
+// NewAlertService はしきい値通知サービスを作成する
+// outbox が nil の場合、送信に失敗したメールは再送しない
+func NewAlertService(
+	usageRepo domainusage.Repository,
+	outbox domainusage.ThresholdEmailRepository,
+	notifier ThresholdNotifier,
+	publisher WebhookPublisher,
+	upgradeLinks UpgradeLinkProvider,
+) *AlertService {
+	return &AlertService{
+		usageRepo:    usageRepo,
+		outbox:       outbox,
+		notifier:     notifier,
+		publisher:    publisher,
+		upgradeLinks: upgradeLinks,
+		sem:          make(chan struct{}, maxConcurrentAlerts),
+		now:          time.Now,
+	}
+}
+
+// CheckThresholds は未通知のしきい値があれば非同期で通知する（非ブロッキング、上限超過時はスキップ）
+// スキップされた場合も通知済みフラグは立たないため、次のリクエストで再評価される
+func (s *AlertService) CheckThresholds(ctx context.Context, key *domainapikey.APIKey, monthly *domainusage.MonthlyUsage) {
+	if len(monthly.PendingThresholds()) == 0 {
+		return
+	}
+
+	select {
+	case s.sem <- struct{}{}:
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { <-s.sem }()
+			alertCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), alertTimeout)
+			defer cancel()
+			s.notifyThresholds(alertCtx, key, monthly)
+		}()
+	default:
+		slog.Warn("しきい値通知をスキップします（同時処理数上限）", "key_prefix", key.Prefix())
+	}
+}
+
+// StartRetryWorker は送信に失敗したしきい値到達メールを定期的に再送するバックグラウンドワーカーを起動する
+// ctx がキャンセルされるとワーカーは停止し、Shutdown() の待機対象に含まれる
+func (s *AlertService) StartRetryWorker(ctx context.Context, interval time.Duration) {
+	if s.outbox == nil || s.notifier == nil {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.RetryPendingEmails(ctx); err != nil {
+					slog.Error("しきい値到達メールの再送ワーカーエラー", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// Shutdown はインフライトの通知処理が完了するまで待機する
+func (s *AlertService) Shutdown() {
+	s.wg.Wait()
+}
+
+// notifyThresholds は未通知のしきい値を確保し、最も高いしきい値についてのみ通知する
+// 同時に複数のしきい値を跨いだ場合（月途中のプラン変更など）に通知が重ならないようにするため。
+// 確保したしきい値は解放しない。Webhook 配信とアップグレードリンクの生成は確保した1回だけ行い、
+// メールの送信に失敗した場合は送信箱へ積んで RetryPendingEmails で再送する
+func (s *AlertService) notifyThresholds(ctx context.Context, key *domainapikey.APIKey, monthly *domainusage.MonthlyUsage) {
+	highest := 0
+	for _, threshold := range monthly.PendingThresholds() {
+		ok, err := s.usageRepo.ClaimThreshold(ctx, monthly.KeyPrefix(), monthly.YearMonth(), threshold)
+		if err != nil {
+			slog.Error("しきい値通知フラグの更新に失敗しました", "error", err, "key_prefix", monthly.KeyPrefix(), "threshold", threshold)
+			continue
+		}
+		if ok && threshold > highest {
+			highest = threshold
+		}
+	}
+	if highest == 0 {
+		return
+	}
+
+	alert := domainusage.ThresholdAlert{
+		To:        key.Email(),
+		KeyName:   key.Name(),
+		MaskedKey: key.MaskedKey(),
+		PlanType:  string(key.PlanType()),
+		Threshold: highest,
+		Count:     monthly.Count(),
+		Limit:     monthly.Limit(),
+		YearMonth: monthly.YearMonth(),
+		Overage:   key.OverageActive(),
+	}
+	if s.upgradeLinks != nil {
+		url, err := s.upgradeLinks.CreateUpgradeURL(ctx, key)
+		if err != nil {
+			slog.Warn("アップグレードリンクの生成に失敗しました（リンクなしで通知）", "error", err, "key_prefix", key.Prefix())
+		} else {
+			alert.UpgradeURL = url
+		}
+	}
+
+	s.publish(ctx, key, monthly, highest)
+
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.NotifyUsageThreshold(ctx, alert); err != nil {
+		slog.Error("しきい値到達メールの送信に失敗しました", "error", err, "key_prefix", key.Prefix(), "threshold", highest)
+		s.enqueueEmail(ctx, domainusage.NewThresholdEmail(key.Prefix(), alert, err.Error(), s.now()))
+	}
+}
+
+// publish はしきい値到達を Webhook で配信する（配信の再試行は Webhook サービスが行う）
+func (s *AlertService) publish(ctx context.Context, key *domainapikey.APIKey, monthly *domainusage.MonthlyUsage, highest int) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.Publish(ctx, domainWebhook.EventUsageThresholdReached, map[string]interface{}{
+		"key_id":     key.ID(),
+		"masked_key": key.MaskedKey(),
+		"plan_type":  string(key.PlanType()),
+		"threshold":  highest,
+		"count":      monthly.Count(),
+		"limit":      monthly.Limit(),
+		"year_month": monthly.YearMonth(),
+	}); err != nil {
+		slog.Warn("しきい値到達Webhookの配信に失敗しました", "error", err, "key_prefix", key.Prefix())
+	}
+}
+
+// enqueueEmail は送信に失敗したメールを送信箱へ積む
+func (s *AlertService) enqueueEmail(ctx context.Context, email *domainusage.ThresholdEmail) {
+	if s.outbox == nil {
+		return
+	}
+	if err := s.outbox.Save(ctx, email); err != nil {
+		slog.Error("しきい値到達メールの再送登録に失敗しました", "error", err, "key_prefix", email.KeyPrefix(), "threshold", email.Alert().Threshold)
+	}
+}
+
+// RetryPendingEmails は再送時刻を過ぎたしきい値到達メールを再送する
+// 送信できたメールは送信箱から消し、失敗したメールはバックオフして次回に回す
+func (s *AlertService) RetryPendingEmails(ctx context.Context) error {
+	if s.outbox == nil || s.notifier == nil {
+		return nil
+	}
+	for range emailRetryBatchSize {
+		email, err := s.outbox.ClaimDue(ctx, s.now(), emailRetryLease)
+		if err != nil {
+			return err
+		}
+		if email == nil {
+			return nil
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, alertTimeout)
+		sendErr := s.notifier.NotifyUsageThreshold(sendCtx, email.Alert())
+		cancel()
+		if sendErr == nil {
+			if err := s.outbox.Delete(ctx, email.ID()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		email.MarkFailed(sendErr.Error(), s.now())
+		if !email.CanRetry() {
+			slog.Error("しきい値到達メールの再送を打ち切りました", "error", sendErr, "key_prefix", email.KeyPrefix(), "threshold", email.Alert().Threshold, "attempts", email.Attempts())
+		}
+		if err := s.outbox.Update(ctx, email); err != nil {
+			return err
+		}
+	}
+	return nil
+}
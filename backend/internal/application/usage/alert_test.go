@@ -0,0 +1,245 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainapikey "github.com/kuro48/idol-api/internal/domain/apikey"
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	domainusage "github.com/kuro48/idol-api/internal/domain/usage"
+	domainWebhook "github.com/kuro48/idol-api/internal/domain/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeThresholdNotifier struct {
+	notifications []domainusage.ThresholdAlert
+	err           error
+}
+
+func (f *fakeThresholdNotifier) NotifyUsageThreshold(_ context.Context, n domainusage.ThresholdAlert) error {
+	f.notifications = append(f.notifications, n)
+	return f.err
+}
+
+type fakePublisher struct {
+	events   []domainWebhook.EventType
+	payloads []interface{}
+}
+
+func (f *fakePublisher) Publish(_ context.Context, event domainWebhook.EventType, payload interface{}) error {
+	f.events = append(f.events, event)
+	f.payloads = append(f.payloads, payload)
+	return nil
+}
+
+type fakeThresholdEmailRepo struct {
+	emails  map[string]*domainusage.ThresholdEmail
+	deleted []string
+}
+
+func newFakeThresholdEmailRepo() *fakeThresholdEmailRepo {
+	return &fakeThresholdEmailRepo{emails: map[string]*domainusage.ThresholdEmail{}}
+}
+
+func (f *fakeThresholdEmailRepo) Save(_ context.Context, email *domainusage.ThresholdEmail) error {
+	f.emails[email.ID()] = email
+	return nil
+}
+
+func (f *fakeThresholdEmailRepo) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (*domainusage.ThresholdEmail, error) {
+	for _, email := range f.emails {
+		next := email.NextRetryAt()
+		if next == nil || next.After(now) {
+			continue
+		}
+		claimedUntil := now.Add(lease)
+		return domainusage.ReconstructThresholdEmail(email.ID(), email.KeyPrefix(), email.Alert(), email.Attempts(), email.MaxAttempts(), email.LastError(), &claimedUntil, email.CreatedAt()), nil
+	}
+	return nil, nil
+}
+
+func (f *fakeThresholdEmailRepo) Update(_ context.Context, email *domainusage.ThresholdEmail) error {
+	f.emails[email.ID()] = email
+	return nil
+}
+
+func (f *fakeThresholdEmailRepo) Delete(_ context.Context, id string) error {
+	delete(f.emails, id)
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+type fakeUpgradeLinks struct {
+	current plan.Type
+	calls   int
+	err     error
+}
+
+func (f *fakeUpgradeLinks) CreateUpgradeURL(_ context.Context, key *domainapikey.APIKey) (string, error) {
+	f.current = key.PlanType()
+	f.calls++
+	if f.err != nil {
+		return "", f.err
+	}
+	return "https://checkout.stripe.test/upgrade", nil
+}
+
+func alertTestKey(t *testing.T) *domainapikey.APIKey {
+	t.Helper()
+	return newTestKey(t, "aabbccddeeff001122334455", "ik_live_1111111111111111111111111111111111111111111111111", plan.TypeDeveloper)
+}
+
+func TestAlertService_NotifyThresholds(t *testing.T) {
+	t.Run("80%到達でメールとWebhookを1回だけ送る", func(t *testing.T) {
+		repo := &fakeUsageRepo{counts: map[string]int{}}
+		notifier := &fakeThresholdNotifier{}
+		publisher := &fakePublisher{}
+		links := &fakeUpgradeLinks{}
+		svc := NewAlertService(repo, nil, notifier, publisher, links)
+		key := alertTestKey(t)
+
+		monthly := domainusage.Reconstruct(key.Prefix(), "2026-04", 40_000, 50_000, nil, time.Now())
+		svc.notifyThresholds(context.Background(), key, monthly)
+		svc.notifyThresholds(context.Background(), key, monthly)
+
+		require.Len(t, notifier.notifications, 1)
+		n := notifier.notifications[0]
+		assert.Equal(t, 80, n.Threshold)
+		assert.Equal(t, "owner@example.com", n.To)
+		assert.Equal(t, "https://checkout.stripe.test/upgrade", n.UpgradeURL)
+		assert.Equal(t, plan.TypeDeveloper, links.current)
+		assert.Equal(t, []domainWebhook.EventType{domainWebhook.EventUsageThresholdReached}, publisher.events)
+	})
+
+	t.Run("複数しきい値を同時に跨いだ場合は最上位のみ通知する", func(t *testing.T) {
+		repo := &fakeUsageRepo{counts: map[string]int{}}
+		notifier := &fakeThresholdNotifier{}
+		svc := NewAlertService(repo, nil, notifier, nil, nil)
+		key := alertTestKey(t)
+
+		monthly := domainusage.Reconstruct(key.Prefix(), "2026-04", 50_000, 50_000, nil, time.Now())
+		svc.notifyThresholds(context.Background(), key, monthly)
+
+		require.Len(t, notifier.notifications, 1)
+		assert.Equal(t, 100, notifier.notifications[0].Threshold)
+		assert.Empty(t, notifier.notifications[0].UpgradeURL)
+		assert.True(t, repo.claimed[80])
+		assert.True(t, repo.claimed[95])
+		assert.True(t, repo.claimed[100])
+	})
+
+	t.Run("メール送信に失敗してもしきい値は確保したままWebhookとリンク生成は1回だけ行う", func(t *testing.T) {
+		repo := &fakeUsageRepo{counts: map[string]int{}}
+		outbox := newFakeThresholdEmailRepo()
+		notifier := &fakeThresholdNotifier{err: errors.New("smtp down")}
+		publisher := &fakePublisher{}
+		links := &fakeUpgradeLinks{}
+		svc := NewAlertService(repo, outbox, notifier, publisher, links)
+		key := alertTestKey(t)
+
+		monthly := domainusage.Reconstruct(key.Prefix(), "2026-04", 50_000, 50_000, nil, time.Now())
+		svc.notifyThresholds(context.Background(), key, monthly)
+		svc.notifyThresholds(context.Background(), key, monthly)
+
+		assert.True(t, repo.claimed[100])
+		assert.Len(t, publisher.events, 1)
+		assert.Equal(t, 1, links.calls)
+		require.Len(t, notifier.notifications, 1)
+		require.Len(t, outbox.emails, 1)
+		for _, email := range outbox.emails {
+			assert.Equal(t, 1, email.Attempts())
+			assert.Equal(t, 100, email.Alert().Threshold)
+			assert.Equal(t, "https://checkout.stripe.test/upgrade", email.Alert().UpgradeURL)
+			assert.True(t, email.CanRetry())
+		}
+	})
+
+	t.Run("アップグレードリンク生成に失敗してもリンクなしで通知する", func(t *testing.T) {
+		notifier := &fakeThresholdNotifier{}
+		svc := NewAlertService(&fakeUsageRepo{counts: map[string]int{}}, nil, notifier, nil, &fakeUpgradeLinks{err: errors.New("stripe down")})
+		key := alertTestKey(t)
+
+		monthly := domainusage.Reconstruct(key.Prefix(), "2026-04", 47_500, 50_000, []int{80}, time.Now())
+		svc.notifyThresholds(context.Background(), key, monthly)
+
+		require.Len(t, notifier.notifications, 1)
+		assert.Equal(t, 95, notifier.notifications[0].Threshold)
+		assert.Empty(t, notifier.notifications[0].UpgradeURL)
+	})
+}
+
+func TestAlertService_CheckThresholds(t *testing.T) {
+	t.Run("未到達の場合は何もしない", func(t *testing.T) {
+		repo := &fakeUsageRepo{counts: map[string]int{}}
+		notifier := &fakeThresholdNotifier{}
+		svc := NewAlertService(repo, nil, notifier, nil, nil)
+		key := alertTestKey(t)
+
+		svc.CheckThresholds(context.Background(), key, domainusage.Reconstruct(key.Prefix(), "2026-04", 10, 50_000, nil, time.Now()))
+		svc.Shutdown()
+
+		assert.Empty(t, notifier.notifications)
+		assert.Empty(t, repo.claimed)
+	})
+
+	t.Run("到達時は非同期で通知しShutdownで完了を待つ", func(t *testing.T) {
+		notifier := &fakeThresholdNotifier{}
+		svc := NewAlertService(&fakeUsageRepo{counts: map[string]int{}}, nil, notifier, nil, nil)
+		key := alertTestKey(t)
+
+		svc.CheckThresholds(context.Background(), key, domainusage.Reconstruct(key.Prefix(), "2026-04", 40_000, 50_000, nil, time.Now()))
+		svc.Shutdown()
+
+		require.Len(t, notifier.notifications, 1)
+	})
+}
+
+func TestAlertService_RetryPendingEmails(t *testing.T) {
+	t.Run("再送時刻を過ぎたメールだけを送信箱から再送する", func(t *testing.T) {
+		outbox := newFakeThresholdEmailRepo()
+		notifier := &fakeThresholdNotifier{}
+		svc := NewAlertService(&fakeUsageRepo{counts: map[string]int{}}, outbox, notifier, nil, nil)
+		now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		alert := domainusage.ThresholdAlert{To: "owner@example.com", Threshold: 95, YearMonth: "2026-04", UpgradeURL: "https://billing.stripe.test/portal"}
+		email := domainusage.NewThresholdEmail("aabbccdd", alert, "smtp down", now)
+		require.NoError(t, outbox.Save(context.Background(), email))
+
+		require.NoError(t, svc.RetryPendingEmails(context.Background()))
+		assert.Empty(t, notifier.notifications)
+
+		now = now.Add(time.Minute)
+		require.NoError(t, svc.RetryPendingEmails(context.Background()))
+		require.Len(t, notifier.notifications, 1)
+		assert.Equal(t, 95, notifier.notifications[0].Threshold)
+		assert.Equal(t, "https://billing.stripe.test/portal", notifier.notifications[0].UpgradeURL)
+		assert.Equal(t, []string{email.ID()}, outbox.deleted)
+		assert.Empty(t, outbox.emails)
+	})
+
+	t.Run("再送に失敗したメールはバックオフし上限回数で打ち切る", func(t *testing.T) {
+		outbox := newFakeThresholdEmailRepo()
+		notifier := &fakeThresholdNotifier{err: errors.New("smtp down")}
+		svc := NewAlertService(&fakeUsageRepo{counts: map[string]int{}}, outbox, notifier, nil, nil)
+		now := time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		email := domainusage.NewThresholdEmail("aabbccdd", domainusage.ThresholdAlert{Threshold: 80, YearMonth: "2026-04"}, "smtp down", now)
+		require.NoError(t, outbox.Save(context.Background(), email))
+
+		for range email.MaxAttempts() {
+			now = now.Add(7 * time.Hour)
+			require.NoError(t, svc.RetryPendingEmails(context.Background()))
+		}
+
+		stored := outbox.emails[email.ID()]
+		require.NotNil(t, stored)
+		assert.Equal(t, stored.MaxAttempts(), stored.Attempts())
+		assert.False(t, stored.CanRetry())
+		assert.Len(t, notifier.notifications, stored.MaxAttempts()-1)
+	})
+}
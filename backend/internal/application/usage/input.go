@@ -0,0 +1,7 @@
+package usage
+
+// GetOwnerUsageInput はAPIキー所有者の利用状況取得の入力
+type GetOwnerUsageInput struct {
+	Email string // 所有者メールアドレス
+	KeyID string // 指定時はこのAPIキーのみを対象とする（任意）
+}
@@ -0,0 +1,111 @@
+// Package usage はAPIキー所有者向けの利用状況レポートを提供するアプリケーションサービス
+package usage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainAnalytics "github.com/kuro48/idol-api/internal/domain/analytics"
+	domainapikey "github.com/kuro48/idol-api/internal/domain/apikey"
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	domainusage "github.com/kuro48/idol-api/internal/domain/usage"
+)
+
+// topEndpointsLimit はレポートに含める上位エンドポイント数
+const topEndpointsLimit = 10
+
+// KeyUsageReport はAPIキー1件分の当月利用レポート
+type KeyUsageReport struct {
+	Key        *domainapikey.APIKey
+	Monthly    *domainusage.MonthlyUsage
+	Overage    *domainusage.OverageUsage
+	Detail     *domainAnalytics.KeyUsageDetail
+	PeriodFrom time.Time
+	PeriodTo   time.Time
+}
+
+// ApplicationService はAPIキー利用状況のアプリケーションサービス
+type ApplicationService struct {
+	apikeyRepo    domainapikey.Repository
+	usageRepo     domainusage.Repository
+	analyticsRepo domainAnalytics.UsageRepository
+	now           func() time.Time
+}
+
+// NewApplicationService はアプリケーションサービスを作成する
+func NewApplicationService(
+	apikeyRepo domainapikey.Repository,
+	usageRepo domainusage.Repository,
+	analyticsRepo domainAnalytics.UsageRepository,
+) *ApplicationService {
+	return &ApplicationService{
+		apikeyRepo:    apikeyRepo,
+		usageRepo:     usageRepo,
+		analyticsRepo: analyticsRepo,
+		now:           time.Now,
+	}
+}
+
+// GetOwnerUsage は所有者メールアドレスに紐づくAPIキーの当月利用レポートを返す
+func (s *ApplicationService) GetOwnerUsage(ctx context.Context, input GetOwnerUsageInput) ([]*KeyUsageReport, error) {
+	if input.Email == "" {
+		return nil, fmt.Errorf("メールアドレスは必須です")
+	}
+
+	keys, err := s.apikeyRepo.FindByEmail(ctx, input.Email)
+	if err != nil {
+		return nil, fmt.Errorf("APIキーの取得に失敗しました: %w", err)
+	}
+
+	if input.KeyID != "" {
+		keys = filterKeyByID(keys, input.KeyID)
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("APIキーが見つかりません: %s", input.KeyID)
+		}
+	}
+
+	now := s.now()
+	from := domainusage.MonthStartOf(now)
+	yearMonth := domainusage.YearMonthOf(now)
+
+	reports := make([]*KeyUsageReport, 0, len(keys))
+	for _, key := range keys {
+		limits := plan.GetLimits(key.PlanType())
+
+		monthly, err := s.usageRepo.Get(ctx, key.Prefix(), yearMonth, limits.MonthlyRequests)
+		if err != nil {
+			return nil, fmt.Errorf("月次使用量の取得に失敗しました: %w", err)
+		}
+
+		overage, err := s.usageRepo.GetOverage(ctx, key.Prefix(), yearMonth)
+		if err != nil {
+			return nil, fmt.Errorf("超過使用量の取得に失敗しました: %w", err)
+		}
+
+		detail, err := s.analyticsRepo.AggregateByKeyPrefix(ctx, key.Prefix(), from, now, topEndpointsLimit)
+		if err != nil {
+			return nil, fmt.Errorf("利用明細の集計に失敗しました: %w", err)
+		}
+
+		reports = append(reports, &KeyUsageReport{
+			Key:        key,
+			Monthly:    monthly,
+			Overage:    overage,
+			Detail:     detail,
+			PeriodFrom: from,
+			PeriodTo:   now,
+		})
+	}
+
+	return reports, nil
+}
+
+func filterKeyByID(keys []*domainapikey.APIKey, keyID string) []*domainapikey.APIKey {
+	for _, k := range keys {
+		if k.ID() == keyID {
+			return []*domainapikey.APIKey{k}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainAnalytics "github.com/kuro48/idol-api/internal/domain/analytics"
+	domainapikey "github.com/kuro48/idol-api/internal/domain/apikey"
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	domainusage "github.com/kuro48/idol-api/internal/domain/usage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAPIKeyRepo struct {
+	keys []*domainapikey.APIKey
+	err  error
+}
+
+func (f *fakeAPIKeyRepo) Save(_ context.Context, _ *domainapikey.APIKey) error { return nil }
+func (f *fakeAPIKeyRepo) FindByPrefix(_ context.Context, _ string) ([]*domainapikey.APIKey, error) {
+	return nil, nil
+}
+func (f *fakeAPIKeyRepo) FindByID(_ context.Context, _ string) (*domainapikey.APIKey, error) {
+	return nil, nil
+}
+func (f *fakeAPIKeyRepo) FindByEmail(_ context.Context, _ string) ([]*domainapikey.APIKey, error) {
+	return f.keys, f.err
+}
+func (f *fakeAPIKeyRepo) Update(_ context.Context, _ *domainapikey.APIKey) error { return nil }
+
+type fakeUsageRepo struct {
+	counts  map[string]int
+	claimed map[int]bool
+}
+
+func (f *fakeUsageRepo) IncrementAndGet(_ context.Context, keyPrefix, yearMonth string, limit int) (*domainusage.MonthlyUsage, error) {
+	f.counts[keyPrefix]++
+	return domainusage.Reconstruct(keyPrefix, yearMonth, f.counts[keyPrefix], limit, nil, time.Now()), nil
+}
+
+func (f *fakeUsageRepo) Get(_ context.Context, keyPrefix, yearMonth string, limit int) (*domainusage.MonthlyUsage, error) {
+	return domainusage.Reconstruct(keyPrefix, yearMonth, f.counts[keyPrefix], limit, nil, time.Now()), nil
+}
+
+func (f *fakeUsageRepo) ClaimThreshold(_ context.Context, _, _ string, threshold int) (bool, error) {
+	if f.claimed == nil {
+		f.claimed = make(map[int]bool)
+	}
+	if f.claimed[threshold] {
+		return false, nil
+	}
+	f.claimed[threshold] = true
+	return true, nil
+}
+
+func (f *fakeUsageRepo) IncrementOverage(_ context.Context, _, _ string) error { return nil }
+
+func (f *fakeUsageRepo) GetOverage(_ context.Context, keyPrefix, yearMonth string) (*domainusage.OverageUsage, error) {
+	return domainusage.ReconstructOverage(keyPrefix, yearMonth, 0, 0, nil), nil
+}
+
+func (f *fakeUsageRepo) ListUnreportedOverages(_ context.Context, _ string) ([]*domainusage.OverageUsage, error) {
+	return nil, nil
+}
+
+func (f *fakeUsageRepo) ClaimOverageReport(_ context.Context, _, _ string, _ domainusage.PendingOverageReport) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeUsageRepo) CompleteOverageReport(_ context.Context, _, _, _ string) error { return nil }
+
+type fakeAnalyticsRepo struct {
+	from, to time.Time
+	details  map[string]*domainAnalytics.KeyUsageDetail
+}
+
+func (f *fakeAnalyticsRepo) Save(_ context.Context, _ *domainAnalytics.APIUsageRecord) error {
+	return nil
+}
+func (f *fakeAnalyticsRepo) FindByMaskedKey(_ context.Context, _ string, _, _ time.Time) ([]*domainAnalytics.APIUsageRecord, error) {
+	return nil, nil
+}
+func (f *fakeAnalyticsRepo) AggregateByKey(_ context.Context, _, _ time.Time) ([]*domainAnalytics.KeyUsageSummary, error) {
+	return nil, nil
+}
+func (f *fakeAnalyticsRepo) AggregateByKeyPrefix(_ context.Context, keyPrefix string, from, to time.Time, _ int) (*domainAnalytics.KeyUsageDetail, error) {
+	f.from, f.to = from, to
+	if d, ok := f.details[keyPrefix]; ok {
+		return d, nil
+	}
+	return &domainAnalytics.KeyUsageDetail{KeyPrefix: keyPrefix}, nil
+}
+
+func newTestKey(t *testing.T, id, rawKey string, planType plan.Type) *domainapikey.APIKey {
+	t.Helper()
+	k, err := domainapikey.New(id, rawKey, "owner@example.com", "app", planType)
+	require.NoError(t, err)
+	return k
+}
+
+func TestGetOwnerUsage(t *testing.T) {
+	devKey := newTestKey(t, "aabbccddeeff001122334455", "ik_live_1111111111111111111111111111111111111111111111111", plan.TypeDeveloper)
+	freeKey := newTestKey(t, "bbbbccddeeff001122334455", "ik_live_2222222222222222222222222222222222222222222222222", plan.TypeFree)
+
+	t.Run("所有キーごとに当月の使用量と利用明細を返す", func(t *testing.T) {
+		usageRepo := &fakeUsageRepo{counts: map[string]int{devKey.Prefix(): 1200}}
+		analyticsRepo := &fakeAnalyticsRepo{details: map[string]*domainAnalytics.KeyUsageDetail{
+			devKey.Prefix(): {KeyPrefix: devKey.Prefix(), TotalRequests: 1200, ErrorCount: 12},
+		}}
+		svc := NewApplicationService(&fakeAPIKeyRepo{keys: []*domainapikey.APIKey{devKey, freeKey}}, usageRepo, analyticsRepo)
+		svc.now = func() time.Time { return time.Date(2026, 4, 15, 9, 30, 0, 0, time.UTC) }
+
+		reports, err := svc.GetOwnerUsage(context.Background(), GetOwnerUsageInput{Email: "owner@example.com"})
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+
+		assert.Equal(t, 1200, reports[0].Monthly.Count())
+		assert.Equal(t, 50_000, reports[0].Monthly.Limit())
+		assert.Equal(t, "2026-04", reports[0].Monthly.YearMonth())
+		assert.Equal(t, int64(12), reports[0].Detail.ErrorCount)
+		assert.Equal(t, 1_000, reports[1].Monthly.Limit())
+
+		assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), analyticsRepo.from)
+		assert.Equal(t, time.Date(2026, 4, 15, 9, 30, 0, 0, time.UTC), analyticsRepo.to)
+	})
+
+	t.Run("key_id指定時は該当キーのみ返す", func(t *testing.T) {
+		svc := NewApplicationService(&fakeAPIKeyRepo{keys: []*domainapikey.APIKey{devKey, freeKey}}, &fakeUsageRepo{counts: map[string]int{}}, &fakeAnalyticsRepo{})
+
+		reports, err := svc.GetOwnerUsage(context.Background(), GetOwnerUsageInput{Email: "owner@example.com", KeyID: freeKey.ID()})
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, freeKey.ID(), reports[0].Key.ID())
+	})
+
+	t.Run("他人のkey_idは見つからないエラーになる", func(t *testing.T) {
+		svc := NewApplicationService(&fakeAPIKeyRepo{keys: []*domainapikey.APIKey{devKey}}, &fakeUsageRepo{counts: map[string]int{}}, &fakeAnalyticsRepo{})
+
+		_, err := svc.GetOwnerUsage(context.Background(), GetOwnerUsageInput{Email: "owner@example.com", KeyID: "ffffffffffffffffffffffff"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "見つかりません")
+	})
+
+	t.Run("メールアドレス未指定はエラー", func(t *testing.T) {
+		svc := NewApplicationService(&fakeAPIKeyRepo{}, &fakeUsageRepo{}, &fakeAnalyticsRepo{})
+
+		_, err := svc.GetOwnerUsage(context.Background(), GetOwnerUsageInput{})
+		require.Error(t, err)
+	})
+
+	t.Run("APIキー取得失敗はエラーを返す", func(t *testing.T) {
+		svc := NewApplicationService(&fakeAPIKeyRepo{err: errors.New("db down")}, &fakeUsageRepo{}, &fakeAnalyticsRepo{})
+
+		_, err := svc.GetOwnerUsage(context.Background(), GetOwnerUsageInput{Email: "owner@example.com"})
+		require.Error(t, err)
+	})
+}
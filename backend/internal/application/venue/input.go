@@ -9,16 +9,56 @@ type CreateInput struct {
 	Address     *string
 	Capacity    *int
 	OfficialURL *string
+	Latitude    *float64 // 緯度・経度は両方指定する
+	Longitude   *float64
 }
 
 // UpdateInput は会場更新の入力データ
 type UpdateInput struct {
-	ID          string
-	Name        *string
-	NameEn      *string
-	Prefecture  *string
-	City        *string
-	Address     *string
-	Capacity    *int
-	OfficialURL *string
+	ID            string
+	Name          *string
+	NameEn        *string
+	Prefecture    *string
+	City          *string
+	Address       *string
+	Capacity      *int
+	OfficialURL   *string
+	Latitude      *float64 // 緯度・経度は両方指定する
+	Longitude     *float64
+	ClearLocation bool // 座標を削除する
+}
+
+// LocationInput は会場の座標を一括登録する1件分の入力データ
+type LocationInput struct {
+	VenueID   string
+	Latitude  float64
+	Longitude float64
+}
+
+// LocationResult は座標の一括登録結果
+type LocationResult struct {
+	Processed int
+	Success   int
+	Errors    []LocationError
+}
+
+// LocationError は座標の一括登録で失敗した1件
+type LocationError struct {
+	Index   int
+	VenueID string
+	Error   string
+}
+
+// ConfigurationInput は収容構成1件分の入力データ。ID を省略すると新しい構成として追加する
+type ConfigurationInput struct {
+	ID       *string
+	Name     string
+	Layout   string
+	Capacity int
+}
+
+// UpdateConfigurationsInput は収容構成の置き換えの入力データ
+type UpdateConfigurationsInput struct {
+	VenueID        string
+	Configurations []ConfigurationInput
 }
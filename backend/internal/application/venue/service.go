@@ -2,21 +2,37 @@ package venue
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/kuro48/idol-api/internal/domain/venue"
 	"github.com/kuro48/idol-api/internal/shared/audit"
+	"github.com/kuro48/idol-api/internal/shared/geo"
+	sharedid "github.com/kuro48/idol-api/internal/shared/id"
 )
 
+// ConfigurationReferences はイベントから参照されている収容構成を調べる契約
+type ConfigurationReferences interface {
+	FindCapacityConfigurationIDs(ctx context.Context, venueID string) ([]string, error)
+}
+
 // ApplicationService は会場に関するアプリケーションサービス
 type ApplicationService struct {
 	repository venue.Repository
+	references ConfigurationReferences
 }
 
 func NewApplicationService(repo venue.Repository) *ApplicationService {
 	return &ApplicationService{repository: repo}
 }
 
+// WithConfigurationReferences はイベントから参照されている収容構成を調べる契約を設定する
+// 設定すると、イベントが参照している収容構成を置き換えで削除できなくなる
+func (s *ApplicationService) WithConfigurationReferences(references ConfigurationReferences) *ApplicationService {
+	s.references = references
+	return s
+}
+
 func (s *ApplicationService) CreateVenue(ctx context.Context, input CreateInput) (*venue.Venue, error) {
 	v, err := venue.NewVenue(input.Name)
 	if err != nil {
@@ -41,6 +57,13 @@ func (s *ApplicationService) CreateVenue(ctx context.Context, input CreateInput)
 	if input.OfficialURL != nil {
 		v.UpdateOfficialURL(input.OfficialURL)
 	}
+	location, err := locationOf(input.Latitude, input.Longitude)
+	if err != nil {
+		return nil, err
+	}
+	if location != nil {
+		v.UpdateLocation(location)
+	}
 
 	_ = audit.ActorFrom(ctx) // 監査情報はリポジトリ層で使用
 
@@ -111,6 +134,15 @@ func (s *ApplicationService) UpdateVenue(ctx context.Context, input UpdateInput)
 	if input.OfficialURL != nil {
 		v.UpdateOfficialURL(input.OfficialURL)
 	}
+	location, err := locationOf(input.Latitude, input.Longitude)
+	if err != nil {
+		return err
+	}
+	if location != nil {
+		v.UpdateLocation(location)
+	} else if input.ClearLocation {
+		v.UpdateLocation(nil)
+	}
 
 	if err := s.repository.Update(ctx, v); err != nil {
 		return fmt.Errorf("会場の更新エラー: %w", err)
@@ -119,6 +151,113 @@ func (s *ApplicationService) UpdateVenue(ctx context.Context, input UpdateInput)
 	return nil
 }
 
+// UpdateLocations は複数の会場の座標をまとめて登録する。
+// 失敗した会場はスキップして処理を続け、結果に理由を記録する
+func (s *ApplicationService) UpdateLocations(ctx context.Context, inputs []LocationInput) *LocationResult {
+	result := &LocationResult{Processed: len(inputs), Errors: make([]LocationError, 0)}
+	for i, in := range inputs {
+		if err := s.updateLocation(ctx, in); err != nil {
+			result.Errors = append(result.Errors, LocationError{Index: i, VenueID: in.VenueID, Error: err.Error()})
+			continue
+		}
+		result.Success++
+	}
+	return result
+}
+
+func (s *ApplicationService) updateLocation(ctx context.Context, input LocationInput) error {
+	location, err := geo.NewPoint(input.Latitude, input.Longitude)
+	if err != nil {
+		return err
+	}
+	vid, err := venue.NewVenueID(input.VenueID)
+	if err != nil {
+		return fmt.Errorf("IDの生成エラー: %w", err)
+	}
+	v, err := s.repository.FindByID(ctx, vid)
+	if err != nil {
+		return fmt.Errorf("会場の取得エラー: %w", err)
+	}
+	v.UpdateLocation(&location)
+	if err := s.repository.Update(ctx, v); err != nil {
+		return fmt.Errorf("会場の更新エラー: %w", err)
+	}
+	return nil
+}
+
+// UpdateConfigurations は会場の収容構成を置き換える。
+// 既存の構成は ID を指定して更新し、イベントからの参照を保つ
+func (s *ApplicationService) UpdateConfigurations(ctx context.Context, input UpdateConfigurationsInput) (*venue.Venue, error) {
+	v, err := s.GetVenue(ctx, input.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make([]venue.CapacityConfiguration, 0, len(input.Configurations))
+	for i, in := range input.Configurations {
+		id := sharedid.Generate()
+		if in.ID != nil {
+			if _, ok := v.Configuration(*in.ID); !ok {
+				return nil, fmt.Errorf("%d件目: 収容構成が見つかりません: %s", i+1, *in.ID)
+			}
+			id = *in.ID
+		}
+		config, err := venue.NewCapacityConfiguration(id, in.Name, venue.Layout(in.Layout), in.Capacity)
+		if err != nil {
+			return nil, fmt.Errorf("%d件目: %w", i+1, err)
+		}
+		configs = append(configs, config)
+	}
+	if err := s.ensureReferencedConfigurationsKept(ctx, input.VenueID, configs); err != nil {
+		return nil, err
+	}
+	if err := v.SetConfigurations(configs); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, v); err != nil {
+		return nil, fmt.Errorf("会場の更新エラー: %w", err)
+	}
+	return v, nil
+}
+
+// ensureReferencedConfigurationsKept はイベントが参照している収容構成が置き換え後にも残っていることを確認する。
+// 参照先の構成が消えるとイベントの収容人数が分からなくなる
+func (s *ApplicationService) ensureReferencedConfigurationsKept(ctx context.Context, venueID string, configs []venue.CapacityConfiguration) error {
+	if s.references == nil {
+		return nil
+	}
+	referenced, err := s.references.FindCapacityConfigurationIDs(ctx, venueID)
+	if err != nil {
+		return fmt.Errorf("収容構成の参照確認エラー: %w", err)
+	}
+	kept := make(map[string]struct{}, len(configs))
+	for _, c := range configs {
+		kept[c.ID()] = struct{}{}
+	}
+	for _, id := range referenced {
+		if _, ok := kept[id]; !ok {
+			return fmt.Errorf("収容構成 %s は既にイベントから参照されているため削除できません", id)
+		}
+	}
+	return nil
+}
+
+// locationOf は入力の緯度・経度から座標を生成する。どちらも未指定なら nil を返す
+func locationOf(latitude, longitude *float64) (*geo.Point, error) {
+	if latitude == nil && longitude == nil {
+		return nil, nil
+	}
+	if latitude == nil || longitude == nil {
+		return nil, errors.New("緯度と経度は両方の指定が必須です")
+	}
+	p, err := geo.NewPoint(*latitude, *longitude)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
 func (s *ApplicationService) DeleteVenue(ctx context.Context, id string) error {
 	vid, err := venue.NewVenueID(id)
 	if err != nil {
@@ -0,0 +1,90 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kuro48/idol-api/internal/domain/venue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type venueRepoStub struct {
+	data map[string]*venue.Venue
+}
+
+func (r *venueRepoStub) Save(_ context.Context, v *venue.Venue) error {
+	id, _ := venue.NewVenueID("venue-1")
+	v.SetID(id)
+	r.data[id.Value()] = v
+	return nil
+}
+
+func (r *venueRepoStub) FindByID(_ context.Context, id venue.VenueID) (*venue.Venue, error) {
+	v, ok := r.data[id.Value()]
+	if !ok {
+		return nil, errors.New("会場が見つかりません")
+	}
+	return v, nil
+}
+
+func (r *venueRepoStub) Search(context.Context, venue.SearchCriteria) ([]*venue.Venue, error) {
+	return nil, nil
+}
+
+func (r *venueRepoStub) Count(context.Context, venue.SearchCriteria) (int64, error) {
+	return 0, nil
+}
+
+func (r *venueRepoStub) Update(_ context.Context, v *venue.Venue) error {
+	r.data[v.ID().Value()] = v
+	return nil
+}
+
+func (r *venueRepoStub) Delete(_ context.Context, id venue.VenueID) error {
+	delete(r.data, id.Value())
+	return nil
+}
+
+type configurationReferencesStub struct {
+	ids []string
+}
+
+func (s *configurationReferencesStub) FindCapacityConfigurationIDs(context.Context, string) ([]string, error) {
+	return s.ids, nil
+}
+
+func TestUpdateConfigurationsRejectsRemovingConfigurationReferencedByEvent(t *testing.T) {
+	ctx := context.Background()
+	references := &configurationReferencesStub{}
+	svc := NewApplicationService(&venueRepoStub{data: map[string]*venue.Venue{}}).WithConfigurationReferences(references)
+	created, err := svc.CreateVenue(ctx, CreateInput{Name: "さいたまアリーナ"})
+	require.NoError(t, err)
+	venueID := created.ID().Value()
+
+	v, err := svc.UpdateConfigurations(ctx, UpdateConfigurationsInput{
+		VenueID: venueID,
+		Configurations: []ConfigurationInput{
+			{Name: "スタンディング", Layout: string(venue.LayoutStanding), Capacity: 30000},
+			{Name: "センターステージ", Layout: string(venue.LayoutCenterStage), Capacity: 25000},
+		},
+	})
+	require.NoError(t, err)
+	standing, center := v.Configurations()[0].ID(), v.Configurations()[1].ID()
+	references.ids = []string{center}
+
+	_, err = svc.UpdateConfigurations(ctx, UpdateConfigurationsInput{
+		VenueID:        venueID,
+		Configurations: []ConfigurationInput{{ID: &standing, Name: "スタンディング", Layout: string(venue.LayoutStanding), Capacity: 30000}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "既にイベントから参照されている")
+
+	// 参照中の構成が残っていれば収容人数は変更できる
+	_, err = svc.UpdateConfigurations(ctx, UpdateConfigurationsInput{
+		VenueID:        venueID,
+		Configurations: []ConfigurationInput{{ID: &center, Name: "センターステージ", Layout: string(venue.LayoutCenterStage), Capacity: 24000}},
+	})
+	require.NoError(t, err)
+}
@@ -41,6 +41,17 @@ type Config struct {
 	StripeKeySeedSecret  string // 決済完了時のAPIキー決定生成用シークレット
 	StripePriceDeveloper string // Developer プランの Stripe Price ID
 	StripePriceBusiness  string // Business プランの Stripe Price ID
+	// 使用量しきい値通知に含めるアップグレード Checkout の戻り先（どちらか空ならリンクなし）
+	StripeUpgradeSuccessURL string // STRIPE_UPGRADE_SUCCESS_URL
+	StripeUpgradeCancelURL  string // STRIPE_UPGRADE_CANCEL_URL
+	// 支払い失敗から free プランへ降格するまでの猶予期間（STRIPE_PAYMENT_GRACE_PERIOD_DAYS、デフォルト: 7日）
+	StripePaymentGracePeriod time.Duration
+	// Business プランの超過利用（従量課金）設定
+	StripeOverageMeterEvent string // 超過リクエストを報告する Billing Meter のイベント名（STRIPE_OVERAGE_METER_EVENT）
+	StripePriceOverage      string // Billing Meter に紐づく metered price の ID（STRIPE_PRICE_OVERAGE、空なら従量課金は無効）
+	OverageHardCap          int    // 超過利用を含む月間リクエスト数のシステム上限（BILLING_OVERAGE_HARD_CAP、0 で無制限）
+	// 入力補完（/suggest）結果のプロセス内キャッシュ保持期間（SUGGEST_CACHE_TTL_SECONDS、デフォルト: 30秒、0 で無効）
+	SuggestCacheTTL time.Duration
 }
 
 // ValidationError は設定バリデーションエラー
@@ -89,6 +100,21 @@ func Load() (*Config, error) {
 		publicMutationRateLimitBurst = 3
 	}
 
+	paymentGraceDays, err := strconv.Atoi(getEnv("STRIPE_PAYMENT_GRACE_PERIOD_DAYS", "7"))
+	if err != nil || paymentGraceDays <= 0 {
+		paymentGraceDays = 7
+	}
+
+	overageHardCap, err := strconv.Atoi(getEnv("BILLING_OVERAGE_HARD_CAP", "10000000"))
+	if err != nil || overageHardCap < 0 {
+		overageHardCap = 10000000
+	}
+
+	suggestCacheTTLSec, err := strconv.Atoi(getEnv("SUGGEST_CACHE_TTL_SECONDS", "30"))
+	if err != nil || suggestCacheTTLSec < 0 {
+		suggestCacheTTLSec = 30
+	}
+
 	cfg := &Config{
 		MongoDBURI:                   getEnv("MONGODB_URI", "mongodb://localhost:27017"),
 		MongoDBDatabase:              getEnv("MONGODB_DATABASE", "idol_database"),
@@ -115,6 +141,13 @@ func Load() (*Config, error) {
 		StripeKeySeedSecret:          getEnv("STRIPE_KEY_SEED_SECRET", ""),
 		StripePriceDeveloper:         getEnv("STRIPE_PRICE_DEVELOPER", ""),
 		StripePriceBusiness:          getEnv("STRIPE_PRICE_BUSINESS", ""),
+		StripeUpgradeSuccessURL:      getEnv("STRIPE_UPGRADE_SUCCESS_URL", ""),
+		StripeUpgradeCancelURL:       getEnv("STRIPE_UPGRADE_CANCEL_URL", ""),
+		StripePaymentGracePeriod:     time.Duration(paymentGraceDays) * 24 * time.Hour,
+		StripeOverageMeterEvent:      getEnv("STRIPE_OVERAGE_METER_EVENT", "api_overage_requests"),
+		StripePriceOverage:           getEnv("STRIPE_PRICE_OVERAGE", ""),
+		OverageHardCap:               overageHardCap,
+		SuggestCacheTTL:              time.Duration(suggestCacheTTLSec) * time.Second,
 	}
 
 	// バリデーション実行
@@ -0,0 +1,156 @@
+// Package affiliation はアイドル・グループの事務所所属履歴（所属期間・契約形態付き）を扱う
+package affiliation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/source"
+)
+
+// Affiliation はタレント（アイドルまたはグループ）の事務所への所属1件。
+// 所属期間は [joinedAt, leftAt) で、joinedAt が nil の場合は所属開始日不明、leftAt が nil の場合は現在も所属中を表す
+type Affiliation struct {
+	id           AffiliationID
+	talentType   TalentType
+	talentID     string
+	agencyID     string
+	contractType ContractType
+	joinedAt     *time.Time
+	leftAt       *time.Time
+	sources      []source.Source
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+func NewAffiliation(talentType TalentType, talentID, agencyID string, contractType ContractType, joinedAt *time.Time) (*Affiliation, error) {
+	if !talentType.IsValid() {
+		return nil, errors.New("無効なタレント種別です")
+	}
+	if talentID == "" {
+		return nil, errors.New("タレントIDは必須です")
+	}
+	if agencyID == "" {
+		return nil, errors.New("事務所IDは必須です")
+	}
+	if !contractType.IsValid() {
+		return nil, errors.New("無効な契約形態です")
+	}
+	now := time.Now()
+	return &Affiliation{
+		talentType:   talentType,
+		talentID:     talentID,
+		agencyID:     agencyID,
+		contractType: contractType,
+		joinedAt:     joinedAt,
+		createdAt:    now,
+		updatedAt:    now,
+	}, nil
+}
+
+func Reconstruct(
+	id AffiliationID,
+	talentType TalentType,
+	talentID, agencyID string,
+	contractType ContractType,
+	joinedAt, leftAt *time.Time,
+	sources []source.Source,
+	createdAt, updatedAt time.Time,
+) *Affiliation {
+	return &Affiliation{
+		id:           id,
+		talentType:   talentType,
+		talentID:     talentID,
+		agencyID:     agencyID,
+		contractType: contractType,
+		joinedAt:     joinedAt,
+		leftAt:       leftAt,
+		sources:      sources,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (a *Affiliation) ID() AffiliationID          { return a.id }
+func (a *Affiliation) TalentType() TalentType     { return a.talentType }
+func (a *Affiliation) TalentID() string           { return a.talentID }
+func (a *Affiliation) AgencyID() string           { return a.agencyID }
+func (a *Affiliation) ContractType() ContractType { return a.contractType }
+func (a *Affiliation) JoinedAt() *time.Time       { return a.joinedAt }
+func (a *Affiliation) LeftAt() *time.Time         { return a.leftAt }
+func (a *Affiliation) IsActive() bool             { return a.leftAt == nil }
+func (a *Affiliation) CreatedAt() time.Time       { return a.createdAt }
+func (a *Affiliation) UpdatedAt() time.Time       { return a.updatedAt }
+func (a *Affiliation) Sources() []source.Source {
+	if a.sources == nil {
+		return []source.Source{}
+	}
+	return a.sources
+}
+
+func (a *Affiliation) SetID(id AffiliationID) {
+	a.id = id
+}
+
+// ActiveAt は指定日に所属していたかを返す
+func (a *Affiliation) ActiveAt(at time.Time) bool {
+	if a.joinedAt != nil && at.Before(*a.joinedAt) {
+		return false
+	}
+	return a.leftAt == nil || at.Before(*a.leftAt)
+}
+
+// Overlaps は同じタレントの同じ事務所への所属期間が重なっているかを返す
+func (a *Affiliation) Overlaps(other *Affiliation) bool {
+	if a.talentType != other.talentType || a.talentID != other.talentID || a.agencyID != other.agencyID {
+		return false
+	}
+	// [a.joinedAt, a.leftAt) と [other.joinedAt, other.leftAt) の交差判定（nil は無限遠）
+	if a.leftAt != nil && other.joinedAt != nil && !other.joinedAt.Before(*a.leftAt) {
+		return false
+	}
+	if other.leftAt != nil && a.joinedAt != nil && !a.joinedAt.Before(*other.leftAt) {
+		return false
+	}
+	return true
+}
+
+func (a *Affiliation) UpdateContractType(contractType ContractType) error {
+	if !contractType.IsValid() {
+		return errors.New("無効な契約形態です")
+	}
+	a.contractType = contractType
+	a.updatedAt = time.Now()
+	return nil
+}
+
+func (a *Affiliation) UpdateJoinedAt(t *time.Time) error {
+	if t != nil && a.leftAt != nil && !t.Before(*a.leftAt) {
+		return errors.New("所属日は退所日より前である必要があります（不正な期間）")
+	}
+	a.joinedAt = t
+	a.updatedAt = time.Now()
+	return nil
+}
+
+func (a *Affiliation) Leave(leftAt time.Time) error {
+	if a.leftAt != nil {
+		return errors.New("既に退所済みです")
+	}
+	if a.joinedAt != nil && !leftAt.After(*a.joinedAt) {
+		return errors.New("退所日は所属日より後である必要があります（不正な期間）")
+	}
+	a.leftAt = &leftAt
+	a.updatedAt = time.Now()
+	return nil
+}
+
+func (a *Affiliation) ClearLeftAt() {
+	a.leftAt = nil
+	a.updatedAt = time.Now()
+}
+
+func (a *Affiliation) SetSources(sources []source.Source) {
+	a.sources = sources
+	a.updatedAt = time.Now()
+}
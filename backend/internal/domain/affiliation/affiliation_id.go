@@ -0,0 +1,22 @@
+package affiliation
+
+import "errors"
+
+type AffiliationID struct {
+	value string
+}
+
+func NewAffiliationID(value string) (AffiliationID, error) {
+	if value == "" {
+		return AffiliationID{}, errors.New("所属履歴IDは空にできません")
+	}
+	return AffiliationID{value: value}, nil
+}
+
+func (id AffiliationID) Value() string {
+	return id.value
+}
+
+func (id AffiliationID) Equals(other AffiliationID) bool {
+	return id.value == other.value
+}
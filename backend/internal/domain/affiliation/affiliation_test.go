@@ -0,0 +1,60 @@
+package affiliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) *time.Time {
+	t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func TestAffiliation_ActiveAt(t *testing.T) {
+	a, err := NewAffiliation(TalentIdol, "idol-1", "agency-1", ContractExclusive, date(2018, 4, 1))
+	require.NoError(t, err)
+
+	assert.False(t, a.ActiveAt(*date(2018, 3, 31)))
+	assert.True(t, a.ActiveAt(*date(2018, 4, 1)))
+	assert.True(t, a.ActiveAt(*date(2030, 1, 1)))
+
+	require.NoError(t, a.Leave(*date(2022, 3, 31)))
+	assert.True(t, a.ActiveAt(*date(2022, 3, 30)))
+	assert.False(t, a.ActiveAt(*date(2022, 3, 31)))
+	assert.ErrorContains(t, a.Leave(*date(2023, 1, 1)), "既に退所済み")
+}
+
+func TestAffiliation_Overlaps(t *testing.T) {
+	first, err := NewAffiliation(TalentIdol, "idol-1", "agency-1", ContractExclusive, date(2018, 4, 1))
+	require.NoError(t, err)
+	require.NoError(t, first.Leave(*date(2020, 4, 1)))
+
+	// 退所日当日からの再所属は重ならない
+	rejoined, err := NewAffiliation(TalentIdol, "idol-1", "agency-1", ContractPartnership, date(2020, 4, 1))
+	require.NoError(t, err)
+	assert.False(t, first.Overlaps(rejoined))
+
+	unknownStart, err := NewAffiliation(TalentIdol, "idol-1", "agency-1", ContractExclusive, nil)
+	require.NoError(t, err)
+	assert.True(t, first.Overlaps(unknownStart))
+	assert.True(t, rejoined.Overlaps(unknownStart))
+
+	otherAgency, err := NewAffiliation(TalentIdol, "idol-1", "agency-2", ContractExclusive, date(2019, 1, 1))
+	require.NoError(t, err)
+	assert.False(t, first.Overlaps(otherAgency))
+}
+
+func TestNewAffiliation_Validation(t *testing.T) {
+	_, err := NewAffiliation(TalentType("agency"), "x", "agency-1", ContractExclusive, nil)
+	assert.ErrorContains(t, err, "無効なタレント種別")
+
+	_, err = NewAffiliation(TalentGroup, "group-1", "", ContractExclusive, nil)
+	assert.ErrorContains(t, err, "事務所IDは必須")
+
+	a, err := NewAffiliation(TalentGroup, "group-1", "agency-1", ContractTrainee, date(2020, 1, 1))
+	require.NoError(t, err)
+	assert.ErrorContains(t, a.Leave(*date(2020, 1, 1)), "不正な期間")
+}
@@ -0,0 +1,17 @@
+package affiliation
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Save(ctx context.Context, a *Affiliation) error
+	FindByID(ctx context.Context, id AffiliationID) (*Affiliation, error)
+	// FindByTalent はタレントの所属履歴を所属日の古い順に取得する
+	FindByTalent(ctx context.Context, talentType TalentType, talentID string) ([]*Affiliation, error)
+	// FindByAgencyID は事務所の所属履歴を取得する。at を指定した場合はその日に所属していたものに絞る
+	FindByAgencyID(ctx context.Context, agencyID string, at *time.Time) ([]*Affiliation, error)
+	Update(ctx context.Context, a *Affiliation) error
+	Delete(ctx context.Context, id AffiliationID) error
+}
@@ -0,0 +1,57 @@
+package affiliation
+
+import "errors"
+
+// TalentType は事務所に所属するタレントの種別
+type TalentType string
+
+const (
+	TalentIdol  TalentType = "idol"
+	TalentGroup TalentType = "group"
+)
+
+func NewTalentType(s string) (TalentType, error) {
+	t := TalentType(s)
+	if !t.IsValid() {
+		return "", errors.New("無効なタレント種別です: idol / group のいずれかを指定してください")
+	}
+	return t, nil
+}
+
+func (t TalentType) IsValid() bool {
+	return t == TalentIdol || t == TalentGroup
+}
+
+func (t TalentType) String() string {
+	return string(t)
+}
+
+// ContractType は事務所との契約形態
+type ContractType string
+
+const (
+	ContractExclusive   ContractType = "exclusive"   // 専属契約
+	ContractPartnership ContractType = "partnership" // 業務提携
+	ContractTrainee     ContractType = "trainee"     // 研修生・育成契約
+	ContractOther       ContractType = "other"
+)
+
+func NewContractType(s string) (ContractType, error) {
+	c := ContractType(s)
+	if !c.IsValid() {
+		return "", errors.New("無効な契約形態です: " + s)
+	}
+	return c, nil
+}
+
+func (c ContractType) IsValid() bool {
+	switch c {
+	case ContractExclusive, ContractPartnership, ContractTrainee, ContractOther:
+		return true
+	}
+	return false
+}
+
+func (c ContractType) String() string {
+	return string(c)
+}
@@ -1,12 +1,17 @@
 package agency
 
-import "time"
+import (
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/kana"
+)
 
 // Agency は事務所エンティティ
 type Agency struct {
 	id              AgencyID
 	name            AgencyName
 	nameEn          *string    // 英語名（オプション）
+	nameKana        *string    // 読み仮名（オプション）
 	foundedDate     *time.Time // 設立日（オプション）
 	country         Country
 	officialWebsite *string // 公式サイトURL
@@ -36,6 +41,7 @@ func NewAgency(
 func (a *Agency) ID() AgencyID             { return a.id }
 func (a *Agency) Name() AgencyName         { return a.name }
 func (a *Agency) NameEn() *string          { return a.nameEn }
+func (a *Agency) NameKana() *string        { return a.nameKana }
 func (a *Agency) FoundedDate() *time.Time  { return a.foundedDate }
 func (a *Agency) Country() Country         { return a.country }
 func (a *Agency) OfficialWebsite() *string { return a.officialWebsite }
@@ -63,3 +69,18 @@ func (a *Agency) UpdateDetails(
 	a.logoURL = logoURL
 	a.updatedAt = time.Now()
 }
+
+// ChangeNameKana は読み仮名を変更する。nil または空文字を指定すると読み仮名を削除する
+func (a *Agency) ChangeNameKana(reading *string) error {
+	if reading != nil && *reading == "" {
+		reading = nil
+	}
+	if reading != nil {
+		if err := kana.ValidateReading(*reading); err != nil {
+			return err
+		}
+	}
+	a.nameKana = reading
+	a.updatedAt = time.Now()
+	return nil
+}
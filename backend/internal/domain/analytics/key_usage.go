@@ -0,0 +1,76 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+)
+
+// DailyUsage は日別（UTC）の利用件数
+type DailyUsage struct {
+	Date     string // "YYYY-MM-DD" 形式
+	Requests int64
+	Errors   int64
+}
+
+// EndpointUsage はエンドポイント別の利用件数
+type EndpointUsage struct {
+	Method       string
+	Endpoint     string
+	Requests     int64
+	Errors       int64
+	AvgLatencyMs float64
+}
+
+// LatencyBucket はレイテンシ値ごとのリクエスト件数（パーセンタイル算出用）
+type LatencyBucket struct {
+	LatencyMs int64
+	Count     int64
+}
+
+// KeyUsageDetail はAPIキー単位の利用明細
+type KeyUsageDetail struct {
+	KeyPrefix        string
+	TotalRequests    int64
+	ErrorCount       int64
+	Daily            []DailyUsage
+	TopEndpoints     []EndpointUsage
+	LatencyHistogram []LatencyBucket
+}
+
+// ErrorRate はエラー（ステータス400以上）の割合を 0〜1 で返す
+func (d *KeyUsageDetail) ErrorRate() float64 {
+	if d.TotalRequests == 0 {
+		return 0
+	}
+	return float64(d.ErrorCount) / float64(d.TotalRequests)
+}
+
+// LatencyPercentile はレイテンシのパーセンタイル値（ミリ秒）を nearest-rank 法で返す
+// p は 0〜100 の範囲で指定する。記録がない場合は 0 を返す
+func (d *KeyUsageDetail) LatencyPercentile(p float64) int64 {
+	var total int64
+	for _, b := range d.LatencyHistogram {
+		total += b.Count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	buckets := make([]LatencyBucket, len(d.LatencyHistogram))
+	copy(buckets, d.LatencyHistogram)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].LatencyMs < buckets[j].LatencyMs })
+
+	rank := int64(math.Ceil(p / 100 * float64(total)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var cumulative int64
+	for _, b := range buckets {
+		cumulative += b.Count
+		if cumulative >= rank {
+			return b.LatencyMs
+		}
+	}
+	return buckets[len(buckets)-1].LatencyMs
+}
@@ -0,0 +1,37 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyUsageDetail_LatencyPercentile(t *testing.T) {
+	detail := &KeyUsageDetail{
+		TotalRequests: 100,
+		LatencyHistogram: []LatencyBucket{
+			{LatencyMs: 300, Count: 5},
+			{LatencyMs: 10, Count: 50},
+			{LatencyMs: 40, Count: 40},
+			{LatencyMs: 120, Count: 5},
+		},
+	}
+
+	assert.Equal(t, int64(10), detail.LatencyPercentile(50))
+	assert.Equal(t, int64(120), detail.LatencyPercentile(95))
+	assert.Equal(t, int64(300), detail.LatencyPercentile(100))
+	assert.Equal(t, int64(10), detail.LatencyPercentile(0))
+}
+
+func TestKeyUsageDetail_EmptyValues(t *testing.T) {
+	detail := &KeyUsageDetail{}
+
+	assert.Equal(t, int64(0), detail.LatencyPercentile(95))
+	assert.Equal(t, 0.0, detail.ErrorRate())
+}
+
+func TestKeyUsageDetail_ErrorRate(t *testing.T) {
+	detail := &KeyUsageDetail{TotalRequests: 200, ErrorCount: 5}
+
+	assert.InDelta(t, 0.025, detail.ErrorRate(), 1e-9)
+}
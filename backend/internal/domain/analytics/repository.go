@@ -20,4 +20,7 @@ type UsageRepository interface {
 	Save(ctx context.Context, record *APIUsageRecord) error
 	FindByMaskedKey(ctx context.Context, maskedKey string, from, to time.Time) ([]*APIUsageRecord, error)
 	AggregateByKey(ctx context.Context, from, to time.Time) ([]*KeyUsageSummary, error)
+	// AggregateByKeyPrefix はAPIキープレフィックス単位の利用明細を集計する
+	// topEndpoints はリクエスト数上位のエンドポイントを何件返すか
+	AggregateByKeyPrefix(ctx context.Context, keyPrefix string, from, to time.Time, topEndpoints int) (*KeyUsageDetail, error)
 }
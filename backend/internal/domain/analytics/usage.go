@@ -6,6 +6,7 @@ import "time"
 type APIUsageRecord struct {
 	ID         string
 	MaskedKey  string // 表示用マスク済みキー（例: sk-t****word）
+	KeyPrefix  string // プラン認証済みAPIキーのルックアップ用プレフィックス（未認証は空）
 	Endpoint   string
 	Method     string
 	StatusCode int
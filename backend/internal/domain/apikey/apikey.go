@@ -34,6 +34,7 @@ type APIKey struct {
 	name      string // キーの説明（例: "My Production App"）
 	planType  plan.Type
 	isActive  bool
+	overage   Overage
 	createdAt time.Time
 }
 
@@ -117,7 +118,7 @@ func New(id, rawKey, email, name string, planType plan.Type) (*APIKey, error) {
 }
 
 // Reconstruct はDBから取得したデータでAPIKeyを再構築する
-func Reconstruct(id, prefix, keyHash, maskedKey, email, name string, planType plan.Type, isActive bool, createdAt time.Time) (*APIKey, error) {
+func Reconstruct(id, prefix, keyHash, maskedKey, email, name string, planType plan.Type, isActive bool, overage Overage, createdAt time.Time) (*APIKey, error) {
 	if !objectIDPattern.MatchString(id) {
 		return nil, errors.New("無効なAPIキーIDです")
 	}
@@ -130,6 +131,7 @@ func Reconstruct(id, prefix, keyHash, maskedKey, email, name string, planType pl
 		name:      name,
 		planType:  planType,
 		isActive:  isActive,
+		overage:   overage,
 		createdAt: createdAt,
 	}, nil
 }
@@ -168,4 +170,5 @@ func (k *APIKey) Email() string        { return k.email }
 func (k *APIKey) Name() string         { return k.name }
 func (k *APIKey) PlanType() plan.Type  { return k.planType }
 func (k *APIKey) IsActive() bool       { return k.isActive }
+func (k *APIKey) Overage() Overage     { return k.overage }
 func (k *APIKey) CreatedAt() time.Time { return k.createdAt }
@@ -0,0 +1,50 @@
+package apikey
+
+import (
+	"errors"
+
+	"github.com/kuro48/idol-api/internal/domain/plan"
+)
+
+// Overage は月間上限を超えたリクエストを従量課金で継続する設定
+type Overage struct {
+	Enabled bool
+	// HardCap は超過分を含めた月間リクエスト総数の上限（0はシステム既定値）
+	HardCap int
+}
+
+// OverageActive は超過リクエストを従量課金で受け付けるかを返す
+// 設定が有効でも従量課金に対応しないプランへ変更された場合は false
+func (k *APIKey) OverageActive() bool {
+	return k.overage.Enabled && plan.OverageAllowed(k.planType)
+}
+
+// OverageHardCap は適用する月間リクエスト総数の上限を返す
+// キー個別の上限はシステム上限を超えられない（どちらも0なら無制限）
+func (k *APIKey) OverageHardCap(systemCap int) int {
+	hardCap := k.overage.HardCap
+	if hardCap == 0 || (systemCap > 0 && hardCap > systemCap) {
+		return systemCap
+	}
+	return hardCap
+}
+
+// EnableOverage は従量課金での超過利用を有効にする
+func (k *APIKey) EnableOverage(hardCap int) error {
+	if !plan.OverageAllowed(k.planType) {
+		return errors.New("無効な操作です: 従量課金は Business プランのみ利用できます")
+	}
+	if hardCap < 0 {
+		return errors.New("無効な上限です: 0以上で指定してください（0はシステム既定値）")
+	}
+	if hardCap > 0 && hardCap <= plan.GetLimits(k.planType).MonthlyRequests {
+		return errors.New("無効な上限です: プランの月間リクエスト数より大きい値を指定してください")
+	}
+	k.overage = Overage{Enabled: true, HardCap: hardCap}
+	return nil
+}
+
+// DisableOverage は従量課金での超過利用を無効にする
+func (k *APIKey) DisableOverage() {
+	k.overage = Overage{}
+}
@@ -8,14 +8,18 @@ import (
 
 // CheckoutFulfillment は Stripe Checkout 完了後のプロビジョニング状態を保持する。
 type CheckoutFulfillment struct {
-	sessionID  string
-	customerID string
-	email      string
-	name       string
-	planType   plan.Type
-	apiKeyID   string
-	notifiedAt *time.Time
-	createdAt  time.Time
+	sessionID      string
+	customerID     string
+	subscriptionID string // 紐づく Stripe Subscription ID（紐づけ前に作成した fulfillment では空）
+	email          string
+	name           string
+	planType       plan.Type
+	apiKeyID       string
+	notifiedAt     *time.Time
+	createdAt      time.Time
+	revision       int // 保存済みの版（更新は読み込んだ版が変わっていない場合のみ行う）
+
+	subscription SubscriptionState
 }
 
 // NewCheckoutFulfillment は新しい fulfillment を作成する。
@@ -33,45 +37,54 @@ func NewCheckoutFulfillment(sessionID, customerID, email, name string, planType
 
 // ReconstructCheckoutFulfillment は永続化データから再構築する。
 func ReconstructCheckoutFulfillment(
-	sessionID, customerID, email, name string,
+	sessionID, customerID, subscriptionID, email, name string,
 	planType plan.Type,
 	apiKeyID string,
 	notifiedAt *time.Time,
 	createdAt time.Time,
+	revision int,
+	subscription SubscriptionState,
 ) *CheckoutFulfillment {
 	return &CheckoutFulfillment{
-		sessionID:  sessionID,
-		customerID: customerID,
-		email:      email,
-		name:       name,
-		planType:   planType,
-		apiKeyID:   apiKeyID,
-		notifiedAt: notifiedAt,
-		createdAt:  createdAt,
+		sessionID:      sessionID,
+		customerID:     customerID,
+		subscriptionID: subscriptionID,
+		email:          email,
+		name:           name,
+		planType:       planType,
+		apiKeyID:       apiKeyID,
+		notifiedAt:     notifiedAt,
+		createdAt:      createdAt,
+		revision:       revision,
+		subscription:   subscription,
 	}
 }
 
 func (f *CheckoutFulfillment) SessionID() string      { return f.sessionID }
 func (f *CheckoutFulfillment) CustomerID() string     { return f.customerID }
+func (f *CheckoutFulfillment) SubscriptionID() string { return f.subscriptionID }
 func (f *CheckoutFulfillment) Email() string          { return f.email }
 func (f *CheckoutFulfillment) Name() string           { return f.name }
 func (f *CheckoutFulfillment) PlanType() plan.Type    { return f.planType }
 func (f *CheckoutFulfillment) APIKeyID() string       { return f.apiKeyID }
 func (f *CheckoutFulfillment) NotifiedAt() *time.Time { return f.notifiedAt }
 func (f *CheckoutFulfillment) CreatedAt() time.Time   { return f.createdAt }
+func (f *CheckoutFulfillment) Revision() int          { return f.revision }
 
 // Notified は API キー通知済みかを返す。
 func (f *CheckoutFulfillment) Notified() bool {
 	return f.notifiedAt != nil
 }
 
+// LinkSubscription は fulfillment を Stripe Subscription に紐づける。紐づけ済みなら変更しない。
+func (f *CheckoutFulfillment) LinkSubscription(subscriptionID string) {
+	if f.subscriptionID == "" {
+		f.subscriptionID = subscriptionID
+	}
+}
+
 // MarkNotified は通知済みにする。
 func (f *CheckoutFulfillment) MarkNotified() {
 	now := time.Now()
 	f.notifiedAt = &now
 }
-
-// UpdatePlanType は現在の契約プランを同期する。
-func (f *CheckoutFulfillment) UpdatePlanType(planType plan.Type) {
-	f.planType = planType
-}
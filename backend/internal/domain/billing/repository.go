@@ -1,6 +1,13 @@
 package billing
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrFulfillmentConflict は読み込んだ後に他の処理が fulfillment を更新していたことを表す。
+var ErrFulfillmentConflict = errors.New("billing fulfillment は他の処理で更新されています")
 
 // FulfillmentRepository は Checkout fulfillment の永続化契約。
 type FulfillmentRepository interface {
@@ -8,5 +15,26 @@ type FulfillmentRepository interface {
 	FindBySessionID(ctx context.Context, sessionID string) (*CheckoutFulfillment, error)
 	FindLatestByEmail(ctx context.Context, email string) (*CheckoutFulfillment, error)
 	FindLatestByCustomerID(ctx context.Context, customerID string) (*CheckoutFulfillment, error)
+	// FindBySubscriptionID は Stripe Subscription に紐づく fulfillment を返す。
+	FindBySubscriptionID(ctx context.Context, subscriptionID string) (*CheckoutFulfillment, error)
+	// FindLatestUnlinkedByCustomerID は Subscription に紐づいていない（紐づけ前に作成した）最新 fulfillment を返す。
+	FindLatestUnlinkedByCustomerID(ctx context.Context, customerID string) (*CheckoutFulfillment, error)
+	FindLatestByAPIKeyID(ctx context.Context, apiKeyID string) (*CheckoutFulfillment, error)
+	// FindGraceExpired は failedBefore 以前から支払い失敗が続き、まだ降格していない fulfillment を返す。
+	FindGraceExpired(ctx context.Context, failedBefore time.Time, limit int) ([]*CheckoutFulfillment, error)
+	// Update は読み込んだ版から変わっていない fulfillment を更新する。
+	// 他の処理が先に更新していた場合は ErrFulfillmentConflict を返す（読み直してから適用し直すこと）。
 	Update(ctx context.Context, fulfillment *CheckoutFulfillment) error
 }
+
+// StripeEventRepository は Stripe Webhook イベント台帳の永続化契約。
+type StripeEventRepository interface {
+	// Claim はイベントを処理中として確保する。未記録なら新規に記録し、
+	// 失敗済みまたは staleBefore より前から処理中のままのイベントは引き継ぐ。
+	// 処理済み・他で処理中の場合は false を返す。
+	Claim(ctx context.Context, event *StripeEvent, staleBefore time.Time) (bool, error)
+	FindByEventID(ctx context.Context, eventID string) (*StripeEvent, error)
+	Update(ctx context.Context, event *StripeEvent) error
+	// List は処理状態で絞り込んだイベントを受信日時の新しい順に返す。status が空なら全件。
+	List(ctx context.Context, status StripeEventStatus, limit, offset int) ([]*StripeEvent, int64, error)
+}
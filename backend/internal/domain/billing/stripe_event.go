@@ -0,0 +1,142 @@
+package billing
+
+import (
+	"errors"
+	"time"
+)
+
+// StripeEventStatus は Stripe Webhook イベントの処理状態。
+type StripeEventStatus string
+
+const (
+	StripeEventStatusProcessing StripeEventStatus = "processing"
+	StripeEventStatusSucceeded  StripeEventStatus = "succeeded"
+	StripeEventStatusFailed     StripeEventStatus = "failed"
+	// StripeEventStatusSkipped は同じ fulfillment により新しいイベントが適用済みのため処理しなかったイベント。
+	StripeEventStatusSkipped StripeEventStatus = "skipped"
+)
+
+// IsValidStripeEventStatus は処理状態が既知の値かを返す。
+func IsValidStripeEventStatus(status StripeEventStatus) bool {
+	switch status {
+	case StripeEventStatusProcessing, StripeEventStatusSucceeded, StripeEventStatusFailed, StripeEventStatusSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// StripeEvent は処理済み Stripe Webhook イベントの台帳エントリ。
+// 再送の重複排除・失敗イベントの再実行に使う（発生順の制御は fulfillment が行う）。
+type StripeEvent struct {
+	eventID       string
+	eventType     string
+	customerID    string
+	objectID      string    // 対象の Stripe オブジェクト ID
+	created       time.Time // Stripe 側のイベント発生時刻
+	payload       []byte    // 再実行用の生ペイロード
+	status        StripeEventStatus
+	errorMsg      string
+	attempts      int
+	receivedAt    time.Time
+	lastAttemptAt *time.Time
+	processedAt   *time.Time
+}
+
+// NewStripeEvent は受信した Stripe イベントの台帳エントリを作成する。
+func NewStripeEvent(eventID, eventType, customerID, objectID string, created time.Time, payload []byte) *StripeEvent {
+	return &StripeEvent{
+		eventID:    eventID,
+		eventType:  eventType,
+		customerID: customerID,
+		objectID:   objectID,
+		created:    created,
+		payload:    payload,
+		status:     StripeEventStatusProcessing,
+		receivedAt: time.Now(),
+	}
+}
+
+// ReconstructStripeEvent は永続化データから再構築する。
+func ReconstructStripeEvent(
+	eventID, eventType, customerID, objectID string,
+	created time.Time,
+	payload []byte,
+	status StripeEventStatus,
+	errorMsg string,
+	attempts int,
+	receivedAt time.Time,
+	lastAttemptAt *time.Time,
+	processedAt *time.Time,
+) *StripeEvent {
+	return &StripeEvent{
+		eventID:       eventID,
+		eventType:     eventType,
+		customerID:    customerID,
+		objectID:      objectID,
+		created:       created,
+		payload:       payload,
+		status:        status,
+		errorMsg:      errorMsg,
+		attempts:      attempts,
+		receivedAt:    receivedAt,
+		lastAttemptAt: lastAttemptAt,
+		processedAt:   processedAt,
+	}
+}
+
+func (e *StripeEvent) EventID() string           { return e.eventID }
+func (e *StripeEvent) EventType() string         { return e.eventType }
+func (e *StripeEvent) CustomerID() string        { return e.customerID }
+func (e *StripeEvent) ObjectID() string          { return e.objectID }
+func (e *StripeEvent) Created() time.Time        { return e.created }
+func (e *StripeEvent) Payload() []byte           { return e.payload }
+func (e *StripeEvent) Status() StripeEventStatus { return e.status }
+func (e *StripeEvent) ErrorMsg() string          { return e.errorMsg }
+func (e *StripeEvent) Attempts() int             { return e.attempts }
+func (e *StripeEvent) ReceivedAt() time.Time     { return e.receivedAt }
+func (e *StripeEvent) LastAttemptAt() *time.Time { return e.lastAttemptAt }
+func (e *StripeEvent) ProcessedAt() *time.Time   { return e.processedAt }
+
+// Failed は処理に失敗したイベントかを返す。
+func (e *StripeEvent) Failed() bool {
+	return e.status == StripeEventStatusFailed
+}
+
+// Done は処理済み（成功またはスキップ）で再処理不要かを返す。
+func (e *StripeEvent) Done() bool {
+	return e.status == StripeEventStatusSucceeded || e.status == StripeEventStatusSkipped
+}
+
+// StartAttempt は処理を開始する。失敗済みイベントの再実行もここから始める。
+func (e *StripeEvent) StartAttempt(at time.Time) error {
+	if e.Done() {
+		return errors.New("処理済みのイベントは再実行できません")
+	}
+	e.status = StripeEventStatusProcessing
+	e.errorMsg = ""
+	e.attempts++
+	e.lastAttemptAt = &at
+	return nil
+}
+
+// MarkSucceeded は処理成功を記録する。
+func (e *StripeEvent) MarkSucceeded(at time.Time) {
+	e.status = StripeEventStatusSucceeded
+	e.errorMsg = ""
+	e.processedAt = &at
+}
+
+// MarkSkipped はより新しいイベントが適用済みのため処理しなかったことを記録する。
+func (e *StripeEvent) MarkSkipped(reason string, at time.Time) {
+	e.status = StripeEventStatusSkipped
+	e.errorMsg = reason
+	e.processedAt = &at
+}
+
+// MarkFailed は処理失敗を記録する。
+func (e *StripeEvent) MarkFailed(errMsg string, at time.Time) {
+	e.status = StripeEventStatusFailed
+	e.errorMsg = errMsg
+	e.processedAt = &at
+}
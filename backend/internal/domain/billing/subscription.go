@@ -0,0 +1,220 @@
+package billing
+
+import (
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/plan"
+)
+
+// TransitionType はサブスクリプション状態遷移の種別。
+type TransitionType string
+
+const (
+	// TransitionPlanChanged は Customer Portal 等によるプラン変更。
+	TransitionPlanChanged TransitionType = "plan_changed"
+	// TransitionCanceled はサブスクリプション解約によるダウングレード。
+	TransitionCanceled TransitionType = "canceled"
+	// TransitionPaymentFailed は支払い失敗（猶予期間中）。
+	TransitionPaymentFailed TransitionType = "payment_failed"
+	// TransitionGraceExpired は猶予期間経過後の支払い失敗によるダウングレード。
+	TransitionGraceExpired TransitionType = "grace_expired"
+	// TransitionRestored は支払い回復によるプラン復元。
+	TransitionRestored TransitionType = "restored"
+)
+
+// DowngradeReason は API キーが free プランへ降格されている理由。
+type DowngradeReason string
+
+const (
+	DowngradeReasonNone          DowngradeReason = ""
+	DowngradeReasonCanceled      DowngradeReason = "canceled"
+	DowngradeReasonPaymentFailed DowngradeReason = "payment_failed"
+)
+
+// SubscriptionTransition はサブスクリプション状態遷移の履歴1件。
+type SubscriptionTransition struct {
+	Type       TransitionType
+	FromPlan   plan.Type
+	ToPlan     plan.Type
+	Status     string
+	OccurredAt time.Time
+}
+
+// SubscriptionState は fulfillment が保持するサブスクリプション状態。
+type SubscriptionState struct {
+	Status              string
+	PaymentFailureCount int
+	PaymentFailedAt     *time.Time // 連続失敗の起点（猶予期間の計算に使う）
+	DowngradeReason     DowngradeReason
+	History             []SubscriptionTransition
+	// LastEventID / LastEventAt は最後に適用した Stripe イベント（発生順の判定に使う）
+	LastEventID string
+	LastEventAt *time.Time
+}
+
+// SubscriptionStatus は直近に同期した Stripe のサブスクリプションステータスを返す。
+func (f *CheckoutFulfillment) SubscriptionStatus() string { return f.subscription.Status }
+
+// PaymentFailureCount は連続した支払い失敗回数を返す。
+func (f *CheckoutFulfillment) PaymentFailureCount() int { return f.subscription.PaymentFailureCount }
+
+// PaymentFailedAt は連続した支払い失敗の起点を返す。
+func (f *CheckoutFulfillment) PaymentFailedAt() *time.Time { return f.subscription.PaymentFailedAt }
+
+// LastEventID は最後に適用した Stripe イベントの ID を返す。
+func (f *CheckoutFulfillment) LastEventID() string { return f.subscription.LastEventID }
+
+// LastEventAt は最後に適用した Stripe イベントの発生時刻を返す。
+func (f *CheckoutFulfillment) LastEventAt() *time.Time { return f.subscription.LastEventAt }
+
+// DowngradeReason は降格中の理由を返す。降格していなければ空文字。
+func (f *CheckoutFulfillment) DowngradeReason() DowngradeReason {
+	return f.subscription.DowngradeReason
+}
+
+// History は状態遷移履歴のコピーを返す。
+func (f *CheckoutFulfillment) History() []SubscriptionTransition {
+	history := make([]SubscriptionTransition, len(f.subscription.History))
+	copy(history, f.subscription.History)
+	return history
+}
+
+// Downgraded は API キーが free プランへ降格されているかを返す。
+func (f *CheckoutFulfillment) Downgraded() bool {
+	return f.subscription.DowngradeReason != DowngradeReasonNone
+}
+
+// EffectivePlanType は API キーに適用すべきプランを返す。
+// 降格中は契約プランにかかわらず free となる。
+func (f *CheckoutFulfillment) EffectivePlanType() plan.Type {
+	if f.Downgraded() {
+		return plan.TypeFree
+	}
+	return f.planType
+}
+
+// GraceDeadline は支払い失敗の猶予期限を返す。失敗中でなければ nil。
+func (f *CheckoutFulfillment) GraceDeadline(gracePeriod time.Duration) *time.Time {
+	if f.subscription.PaymentFailedAt == nil {
+		return nil
+	}
+	deadline := f.subscription.PaymentFailedAt.Add(gracePeriod)
+	return &deadline
+}
+
+// ObserveEvent は Stripe イベントを発生順に適用できるかを判定し、できる場合は最後に適用したイベントとして記録する。
+// Subscription と Invoice のイベントは同じ状態を変更するため、種別をまたいで発生順に並べる。
+// 最後に適用したイベントより前に発生したイベントは false を返す。発生時刻が同じ秒の場合は
+// どのレプリカでも同じ順になるようイベント ID の順とする。発生時刻のないイベントは順序を判定しない。
+func (f *CheckoutFulfillment) ObserveEvent(eventID string, created time.Time) bool {
+	if created.IsZero() {
+		return true
+	}
+	if last := f.subscription.LastEventAt; last != nil {
+		if created.Before(*last) || (created.Equal(*last) && eventID <= f.subscription.LastEventID) {
+			return false
+		}
+	}
+	f.subscription.LastEventID = eventID
+	f.subscription.LastEventAt = &created
+	return true
+}
+
+// SyncSubscriptionStatus は Stripe のサブスクリプションステータスを記録する。
+func (f *CheckoutFulfillment) SyncSubscriptionStatus(status string) {
+	f.subscription.Status = status
+}
+
+// ChangePlan は契約プランを変更し履歴に記録する。変更がなければ false を返す。
+func (f *CheckoutFulfillment) ChangePlan(planType plan.Type, at time.Time) bool {
+	if f.planType == planType {
+		return false
+	}
+	f.record(TransitionPlanChanged, f.EffectivePlanType(), effectivePlan(planType, f.subscription.DowngradeReason), at)
+	f.planType = planType
+	return true
+}
+
+// Cancel は解約により free プランへ降格する。既に解約済みなら false を返す。
+func (f *CheckoutFulfillment) Cancel(at time.Time) bool {
+	if f.subscription.DowngradeReason == DowngradeReasonCanceled {
+		return false
+	}
+	f.subscription.Status = "canceled"
+	f.record(TransitionCanceled, f.EffectivePlanType(), plan.TypeFree, at)
+	f.subscription.DowngradeReason = DowngradeReasonCanceled
+	f.subscription.PaymentFailureCount = 0
+	f.subscription.PaymentFailedAt = nil
+	return true
+}
+
+// RecordPaymentFailure は支払い失敗を記録する。
+// 猶予期間を過ぎた失敗であれば free プランへ降格し true を返す。
+// 解約済み・降格済みの場合は回数のみ加算する。
+func (f *CheckoutFulfillment) RecordPaymentFailure(at time.Time, gracePeriod time.Duration) (downgraded bool) {
+	if f.subscription.DowngradeReason == DowngradeReasonCanceled {
+		return false
+	}
+	f.subscription.PaymentFailureCount++
+	if f.subscription.PaymentFailedAt == nil {
+		failedAt := at
+		f.subscription.PaymentFailedAt = &failedAt
+	}
+	if f.Downgraded() {
+		return false
+	}
+
+	if f.subscription.PaymentFailureCount > 1 && f.ExpireGracePeriod(at, gracePeriod) {
+		return true
+	}
+	f.record(TransitionPaymentFailed, f.planType, f.planType, at)
+	return false
+}
+
+// ExpireGracePeriod は支払い失敗の猶予期限を過ぎていれば free プランへ降格し true を返す。
+// 次の失敗 Webhook を待たずに期限切れを反映するため、スケジューラからも呼ばれる。
+func (f *CheckoutFulfillment) ExpireGracePeriod(at time.Time, gracePeriod time.Duration) bool {
+	if f.Downgraded() {
+		return false
+	}
+	deadline := f.GraceDeadline(gracePeriod)
+	if deadline == nil || at.Before(*deadline) {
+		return false
+	}
+	f.record(TransitionGraceExpired, f.planType, plan.TypeFree, at)
+	f.subscription.DowngradeReason = DowngradeReasonPaymentFailed
+	return true
+}
+
+// RecoverPayment は支払い回復を記録し、支払い失敗による降格を解除する。
+// 状態が変化しなければ false を返す。解約済みの場合は復元しない。
+func (f *CheckoutFulfillment) RecoverPayment(at time.Time) bool {
+	if f.subscription.DowngradeReason == DowngradeReasonCanceled {
+		return false
+	}
+	if f.subscription.PaymentFailureCount == 0 && !f.Downgraded() {
+		return false
+	}
+	f.record(TransitionRestored, f.EffectivePlanType(), f.planType, at)
+	f.subscription.DowngradeReason = DowngradeReasonNone
+	f.subscription.PaymentFailureCount = 0
+	f.subscription.PaymentFailedAt = nil
+	return true
+}
+
+func (f *CheckoutFulfillment) record(transitionType TransitionType, from, to plan.Type, at time.Time) {
+	f.subscription.History = append(f.subscription.History, SubscriptionTransition{
+		Type:       transitionType,
+		FromPlan:   from,
+		ToPlan:     to,
+		Status:     f.subscription.Status,
+		OccurredAt: at,
+	})
+}
+
+func effectivePlan(planType plan.Type, reason DowngradeReason) plan.Type {
+	if reason != DowngradeReasonNone {
+		return plan.TypeFree
+	}
+	return planType
+}
@@ -0,0 +1,109 @@
+package event
+
+import (
+	"errors"
+	"time"
+)
+
+// Attendance はイベントの動員実績（値オブジェクト）
+type Attendance struct {
+	soldOut bool
+	count   *int // 動員数（未公表の場合は nil）
+}
+
+// NewAttendance は動員実績を生成する
+func NewAttendance(soldOut bool, count *int) (Attendance, error) {
+	if count != nil && *count < 0 {
+		return Attendance{}, errors.New("動員数は0以上である必要があります")
+	}
+	return Attendance{soldOut: soldOut, count: count}, nil
+}
+
+// ReconstructAttendance は永続化層から動員実績を再構築する（バリデーションなし）
+func ReconstructAttendance(soldOut bool, count *int) Attendance {
+	return Attendance{soldOut: soldOut, count: count}
+}
+
+func (a Attendance) SoldOut() bool { return a.soldOut }
+func (a Attendance) Count() *int   { return a.count }
+
+// CapacityConfigurationID は公演で使った会場の収容構成のIDを返す
+func (e *Event) CapacityConfigurationID() *string {
+	return e.capacityConfigurationID
+}
+
+// Attendance は記録済みの動員実績を返す（未記録は nil）
+func (e *Event) Attendance() *Attendance {
+	return e.attendance
+}
+
+// UseCapacityConfiguration は公演で使った会場の収容構成を設定する（nil で解除）
+func (e *Event) UseCapacityConfiguration(configurationID *string) {
+	e.capacityConfigurationID = configurationID
+	e.updatedAt = time.Now()
+}
+
+// RecordAttendance は完売・動員数を記録する。中止・延期したイベントには記録できない
+func (e *Event) RecordAttendance(attendance Attendance) error {
+	if !e.occupiesSchedule() {
+		return errors.New("中止・延期したイベントには動員を記録できません")
+	}
+	e.attendance = &attendance
+	e.updatedAt = time.Now()
+	return nil
+}
+
+// CapacityStats は期間ごとのイベントの収容人数・動員の集計
+type CapacityStats struct {
+	Period             time.Time // 期間の開始（月初）
+	Events             int
+	CapacityTotal      int
+	UnknownCapacity    int // 収容人数が分からないイベント数（会場・収容構成が未登録）
+	AttendanceTotal    int
+	AttendanceRecorded int // 動員数を記録済みのイベント数
+	SoldOut            int
+}
+
+// MonthOf は時刻が属する暦月（Asia/Tokyo）の月初を返す
+func MonthOf(t time.Time) time.Time {
+	t = t.In(jst)
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, jst)
+}
+
+// AggregateCapacity は [from, to) に開始するイベントを暦月（Asia/Tokyo）ごとに集計する。
+// イベントのない月も0件として含め、中止・延期したイベントは集計しない。capacityOf はイベントの収容人数を返す（不明は nil）
+func AggregateCapacity(events []*Event, from, to time.Time, capacityOf func(*Event) *int) []CapacityStats {
+	var stats []CapacityStats
+	index := make(map[time.Time]int)
+	for m := MonthOf(from); m.Before(to); m = m.AddDate(0, 1, 0) {
+		index[m] = len(stats)
+		stats = append(stats, CapacityStats{Period: m})
+	}
+
+	for _, e := range events {
+		if !e.occupiesSchedule() || e.startDateTime.Before(from) || !e.startDateTime.Before(to) {
+			continue
+		}
+		i, ok := index[MonthOf(e.startDateTime)]
+		if !ok {
+			continue
+		}
+		st := &stats[i]
+		st.Events++
+		if capacity := capacityOf(e); capacity != nil {
+			st.CapacityTotal += *capacity
+		} else {
+			st.UnknownCapacity++
+		}
+		if e.attendance != nil {
+			if e.attendance.soldOut {
+				st.SoldOut++
+			}
+			if e.attendance.count != nil {
+				st.AttendanceTotal += *e.attendance.count
+				st.AttendanceRecorded++
+			}
+		}
+	}
+	return stats
+}
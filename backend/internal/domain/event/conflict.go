@@ -0,0 +1,96 @@
+package event
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultEventDuration は終了日時が未登録のイベントを占有しているとみなす時間
+const DefaultEventDuration = 3 * time.Hour
+
+// ConflictKind は日程重複の種類
+type ConflictKind string
+
+const (
+	// ConflictKindPerformer は同じ出演者が別会場のイベントと時間帯が重なっている
+	ConflictKindPerformer ConflictKind = "performer"
+	// ConflictKindVenue は同じ会場で時間帯が重なるイベントがある
+	ConflictKindVenue ConflictKind = "venue"
+)
+
+// Conflict は2つのイベントの日程重複
+type Conflict struct {
+	Kind      ConflictKind
+	SubjectID string // 重複している出演者ID または会場ID
+	Event     *Event
+	Other     *Event
+}
+
+// OccupiedUntil はイベントが出演者・会場を占有する終了時刻を返す（終了日時が未登録なら既定の所要時間で補う）
+func (e *Event) OccupiedUntil() time.Time {
+	if e.endDateTime != nil {
+		return *e.endDateTime
+	}
+	return e.startDateTime.Add(DefaultEventDuration)
+}
+
+// occupiesSchedule は日程重複の判定対象になるか（中止・延期のイベントは対象外）
+func (e *Event) occupiesSchedule() bool {
+	return e.status != EventStatusCancelled && e.status != EventStatusPostponed
+}
+
+// Overlaps は別のイベントと開催時間帯が重なっているかを返す
+func (e *Event) Overlaps(other *Event) bool {
+	if e.id.Value() == other.id.Value() || !e.occupiesSchedule() || !other.occupiesSchedule() {
+		return false
+	}
+	return e.startDateTime.Before(other.OccupiedUntil()) && other.startDateTime.Before(e.OccupiedUntil())
+}
+
+// ConflictsWith は別のイベントとの日程重複を返す。
+// 同じ会場なら会場の重複のみ、別会場なら共通する出演者ごとに出演者の重複として扱う
+func (e *Event) ConflictsWith(other *Event) []Conflict {
+	if !e.Overlaps(other) {
+		return nil
+	}
+	if e.venueID != nil && other.venueID != nil && *e.venueID == *other.venueID {
+		return []Conflict{{Kind: ConflictKindVenue, SubjectID: *e.venueID, Event: e, Other: other}}
+	}
+	var conflicts []Conflict
+	for _, p := range e.performers {
+		if other.HasPerformer(p.PerformerID) {
+			conflicts = append(conflicts, Conflict{Kind: ConflictKindPerformer, SubjectID: p.PerformerID, Event: e, Other: other})
+		}
+	}
+	return conflicts
+}
+
+// DetectConflicts はイベントと候補のイベント群との日程重複を返す
+func DetectConflicts(target *Event, candidates []*Event) []Conflict {
+	var conflicts []Conflict
+	for _, c := range candidates {
+		conflicts = append(conflicts, target.ConflictsWith(c)...)
+	}
+	return conflicts
+}
+
+// FindConflicts はイベント群の中で日程が重複している組み合わせをすべて返す。
+// 開始日時順に並べ、時間帯が重なりうる後続のイベントとだけ比較する
+func FindConflicts(events []*Event) []Conflict {
+	sorted := append([]*Event{}, events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].startDateTime.Before(sorted[j].startDateTime)
+	})
+
+	var conflicts []Conflict
+	for i, e := range sorted {
+		until := e.OccupiedUntil()
+		for _, other := range sorted[i+1:] {
+			if !other.startDateTime.Before(until) {
+				break
+			}
+			conflicts = append(conflicts, e.ConflictsWith(other)...)
+		}
+	}
+	return conflicts
+}
@@ -5,6 +5,9 @@ import (
 	"time"
 )
 
+// jst は終了日時のないイベントを終了扱いにする日付の境界（Asia/Tokyo、夏時間なしの UTC+9）
+var jst = time.FixedZone("JST", 9*60*60)
+
 // Event はイベント集約のルートエンティティ
 type Event struct {
 	id            EventID
@@ -19,8 +22,19 @@ type Event struct {
 	officialURL   *string
 	description   *string
 	tags          []string
-	createdAt     time.Time
-	updatedAt     time.Time
+	seriesID      *string // 所属するツアー（イベントシリーズ）のID
+	// inheritedPerformerIDs / inheritedTags はツアーから引き継いだ出演者・タグ。
+	// ツアーから外れるときはこれだけを取り除き、公演側で登録したものは残す
+	inheritedPerformerIDs []string
+	inheritedTags         []string
+	setlist               []SetlistEntry
+	// capacityConfigurationID は公演で使った会場の収容構成のID
+	capacityConfigurationID *string
+	attendance              *Attendance // 完売・動員数（未記録は nil）
+	streaming               *Streaming  // 配信情報（会場もあればハイブリッド開催）
+	version                 int         // 改訂番号。永続化層が更新のたびに加算する（カレンダー配信の SEQUENCE に使う）
+	createdAt               time.Time
+	updatedAt               time.Time
 }
 
 // NewEvent は新しいイベントを作成する
@@ -57,6 +71,14 @@ func Reconstruct(
 	officialURL *string,
 	description *string,
 	tags []string,
+	seriesID *string,
+	inheritedPerformerIDs []string,
+	inheritedTags []string,
+	setlist []SetlistEntry,
+	capacityConfigurationID *string,
+	attendance *Attendance,
+	streaming *Streaming,
+	version int,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) *Event {
@@ -64,20 +86,28 @@ func Reconstruct(
 		performers = []Performer{}
 	}
 	return &Event{
-		id:            id,
-		title:         title,
-		eventType:     eventType,
-		status:        status,
-		startDateTime: startDateTime,
-		endDateTime:   endDateTime,
-		venueID:       venueID,
-		performers:    performers,
-		ticketURL:     ticketURL,
-		officialURL:   officialURL,
-		description:   description,
-		tags:          tags,
-		createdAt:     createdAt,
-		updatedAt:     updatedAt,
+		id:                      id,
+		title:                   title,
+		eventType:               eventType,
+		status:                  status,
+		startDateTime:           startDateTime,
+		endDateTime:             endDateTime,
+		venueID:                 venueID,
+		performers:              performers,
+		ticketURL:               ticketURL,
+		officialURL:             officialURL,
+		description:             description,
+		tags:                    tags,
+		seriesID:                seriesID,
+		inheritedPerformerIDs:   inheritedPerformerIDs,
+		inheritedTags:           inheritedTags,
+		setlist:                 setlist,
+		capacityConfigurationID: capacityConfigurationID,
+		attendance:              attendance,
+		streaming:               streaming,
+		version:                 version,
+		createdAt:               createdAt,
+		updatedAt:               updatedAt,
 	}
 }
 
@@ -127,6 +157,16 @@ func (e *Event) PerformerIDs() []string {
 	return ids
 }
 
+// HasPerformer は出演者に含まれているかを返す
+func (e *Event) HasPerformer(performerID string) bool {
+	for _, p := range e.performers {
+		if p.PerformerID == performerID {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Event) TicketURL() *string {
 	return e.ticketURL
 }
@@ -143,6 +183,29 @@ func (e *Event) Tags() []string {
 	return e.tags
 }
 
+func (e *Event) SeriesID() *string {
+	return e.seriesID
+}
+
+// InheritedPerformerIDs はツアーから引き継いだ出演者のIDを返す
+func (e *Event) InheritedPerformerIDs() []string {
+	return e.inheritedPerformerIDs
+}
+
+// InheritedTags はツアーから引き継いだタグを返す
+func (e *Event) InheritedTags() []string {
+	return e.inheritedTags
+}
+
+// Setlist は演奏順のセットリストを返す
+func (e *Event) Setlist() []SetlistEntry {
+	return e.setlist
+}
+
+func (e *Event) Version() int {
+	return e.version
+}
+
 func (e *Event) CreatedAt() time.Time {
 	return e.createdAt
 }
@@ -214,7 +277,9 @@ func (e *Event) AddPerformer(performer Performer) error {
 	return nil
 }
 
-// RemovePerformer はパフォーマーを削除する
+// RemovePerformer はパフォーマーを削除する。
+// セットリストの曲ごとの出演者からも取り除き、イベントの出演者でない出演者が曲に残らないようにする
+// （その出演者だけが披露した曲は出演者の指定がなくなり、出演者全員の曲として扱われる）
 func (e *Event) RemovePerformer(performerID string) {
 	newPerformers := make([]Performer, 0, len(e.performers))
 	for _, p := range e.performers {
@@ -223,6 +288,10 @@ func (e *Event) RemovePerformer(performerID string) {
 		}
 	}
 	e.performers = newPerformers
+	e.inheritedPerformerIDs = removeString(e.inheritedPerformerIDs, performerID)
+	for i, entry := range e.setlist {
+		e.setlist[i] = entry.withoutPerformer(performerID)
+	}
 	e.updatedAt = time.Now()
 }
 
@@ -247,9 +316,78 @@ func (e *Event) RemoveTag(tag string) {
 			break
 		}
 	}
+	e.inheritedTags = removeString(e.inheritedTags, tag)
+	e.updatedAt = time.Now()
+}
+
+// JoinSeries はツアーの1公演にし、ツアーの出演者・タグのうち未登録のものを引き継ぐ。
+// 別のツアーから移る場合は元のツアーから引き継いだ出演者・タグを先に取り除く
+func (e *Event) JoinSeries(seriesID string, performers []Performer, tags []string) {
+	if e.seriesID != nil && *e.seriesID != seriesID {
+		e.DropSeriesInheritance(e.inheritedPerformerIDs, e.inheritedTags)
+	}
+	e.seriesID = &seriesID
+	e.InheritFromSeries(performers, tags)
+}
+
+// LeaveSeries はツアーから外し、ツアーから引き継いだ出演者・タグを取り除く。公演側で登録したものは残す
+func (e *Event) LeaveSeries() {
+	e.DropSeriesInheritance(e.inheritedPerformerIDs, e.inheritedTags)
+	e.seriesID = nil
+	e.updatedAt = time.Now()
+}
+
+// DropSeriesInheritance は指定した出演者・タグのうちツアーから引き継いだものだけを取り除く
+func (e *Event) DropSeriesInheritance(performerIDs []string, tags []string) {
+	// RemovePerformer / RemoveTag が引き継ぎの記録も消すため、走査前に複製する
+	for _, id := range append([]string(nil), performerIDs...) {
+		if containsString(e.inheritedPerformerIDs, id) {
+			e.RemovePerformer(id)
+		}
+	}
+	for _, t := range append([]string(nil), tags...) {
+		if containsString(e.inheritedTags, t) {
+			e.RemoveTag(t)
+		}
+	}
+	e.updatedAt = time.Now()
+}
+
+// InheritFromSeries はツアーの出演者・タグのうち未登録のものを追加し、引き継いだものとして記録する
+func (e *Event) InheritFromSeries(performers []Performer, tags []string) {
+	for _, p := range performers {
+		// 登録済みの出演者は公演ごとのビリングを優先する
+		if err := e.AddPerformer(p); err == nil {
+			e.inheritedPerformerIDs = append(e.inheritedPerformerIDs, p.PerformerID)
+		}
+	}
+	for _, t := range tags {
+		if err := e.AddTag(t); err == nil {
+			e.inheritedTags = append(e.inheritedTags, t)
+		}
+	}
 	e.updatedAt = time.Now()
 }
 
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, target string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != target {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 // IsUpcoming はイベントが今後開催されるかチェックする
 func (e *Event) IsUpcoming() bool {
 	return e.startDateTime.After(time.Now())
@@ -274,6 +412,42 @@ func (e *Event) IsOngoing() bool {
 		e.startDateTime.YearDay() == now.YearDay()
 }
 
+// CompletesAt はイベントを終了扱いにする時刻を返す。
+// 終了日時が未登録の場合は開始日（Asia/Tokyo）の終わりとする
+func (e *Event) CompletesAt() time.Time {
+	if e.endDateTime != nil {
+		return *e.endDateTime
+	}
+	return startOfDay(e.startDateTime).AddDate(0, 0, 1)
+}
+
+// UndatedCompletionCutoff は終了日時が未登録のイベントのうち、now の時点で終了扱いになるものの開始日時の上限を返す。
+// この時刻より前に開始したイベントは CompletesAt を過ぎている（当日0時、Asia/Tokyo）
+func UndatedCompletionCutoff(now time.Time) time.Time {
+	return startOfDay(now)
+}
+
+// startOfDay は t の日付（Asia/Tokyo）の0時を返す
+func startOfDay(t time.Time) time.Time {
+	t = t.In(jst)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, jst)
+}
+
+// IsDueForCompletion は予定のイベントが終了時刻を過ぎているかを返す
+func (e *Event) IsDueForCompletion(now time.Time) bool {
+	return e.status == EventStatusScheduled && !now.Before(e.CompletesAt())
+}
+
+// Complete は終了時刻を過ぎた予定のイベントを終了にする
+func (e *Event) Complete(now time.Time) error {
+	if !e.IsDueForCompletion(now) {
+		return errors.New("終了時刻を過ぎた予定のイベントのみ終了にできます")
+	}
+	e.status = EventStatusCompleted
+	e.updatedAt = now
+	return nil
+}
+
 // Validate はイベントの状態が有効かを検証する
 func (e *Event) Validate() error {
 	if e.title.Value() == "" {
@@ -7,18 +7,34 @@ import (
 
 // SearchCriteria はイベント検索条件
 type SearchCriteria struct {
-	EventType     *EventType
-	StartDateFrom *time.Time
-	StartDateTo   *time.Time
-	VenueID       *string
-	PerformerID   *string
-	Tags          []string
-	Prefecture    *string // 会場の都道府県（将来実装）
+	EventType      *EventType
+	StartDateFrom  *time.Time
+	StartDateTo    *time.Time
+	VenueID        *string
+	VenueIDs       []string // いずれかの会場で開催（周辺検索で使う）
+	PerformerID    *string
+	Tags           []string
+	SeriesID       *string   // ツアー（イベントシリーズ）ID
+	PerformedTrack *TrackRef // セットリストで演奏された収録曲
+	Prefecture     *string   // 会場の都道府県（将来実装）
+	Online         *bool     // true: 配信のあるイベント（オンラインイベントを含む）、false: 会場のみのイベント
+	// ArchiveAvailableAt を指定すると、その時刻にアーカイブを視聴できるイベントに絞り込む
+	ArchiveAvailableAt *time.Time
 
 	Sort   string
 	Order  string
 	Offset int
 	Limit  int
+
+	// Omit は一覧で取得を省略する任意属性（setlist）。
+	// fields 指定でレスポンスに含めない属性の読み込みを避けるために使う
+	Omit []string
+}
+
+// TrackRef はリリースの収録曲への参照
+type TrackRef struct {
+	ReleaseID   string
+	TrackNumber int
 }
 
 // Repository はイベント集約のリポジトリインターフェース
@@ -49,4 +65,18 @@ type Repository interface {
 
 	// FindByPerformer はパフォーマーIDでイベントを検索する
 	FindByPerformer(ctx context.Context, performerID string, limit int) ([]*Event, error)
+
+	// FindBySeries はツアーに属するイベントを開始日時順にすべて取得する
+	FindBySeries(ctx context.Context, seriesID string) ([]*Event, error)
+
+	// FindOverlapping は開催時間帯が [from, to) と重なるイベントを開始日時順にすべて取得する。
+	// 終了日時が未登録のイベントは DefaultEventDuration だけ続くものとして扱う
+	FindOverlapping(ctx context.Context, from, to time.Time) ([]*Event, error)
+
+	// FindDueForCompletion は now の時点で終了時刻（CompletesAt）を過ぎている予定のイベントを最大 limit 件取得する
+	FindDueForCompletion(ctx context.Context, now time.Time, limit int) ([]*Event, error)
+
+	// TransitionStatus はステータスが from のままの場合のみイベントのステータスを現在の値に更新する。
+	// 同時に呼ばれても true を返すのは1回だけ
+	TransitionStatus(ctx context.Context, event *Event, from EventStatus) (bool, error)
 }
@@ -0,0 +1,115 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetlistEntry はセットリストの1曲（値オブジェクト）。
+// リリースの収録曲（リリースID + トラック番号）を参照するか、カバー曲などは曲名のみで登録する
+type SetlistEntry struct {
+	releaseID    *string
+	trackNumber  *int
+	title        string   // 曲名（収録曲を参照する場合は登録時点の曲名）
+	isEncore     bool     // アンコールで演奏した曲
+	performerIDs []string // この曲を披露した出演者（空の場合は出演者全員）
+	note         *string
+}
+
+// NewSetlistEntry はセットリストの1曲を生成する
+func NewSetlistEntry(releaseID *string, trackNumber *int, title string, isEncore bool, performerIDs []string, note *string) (SetlistEntry, error) {
+	if (releaseID == nil) != (trackNumber == nil) {
+		return SetlistEntry{}, errors.New("収録曲を参照する場合はリリースIDとトラック番号の両方が必須です")
+	}
+	if trackNumber != nil && *trackNumber < 1 {
+		return SetlistEntry{}, fmt.Errorf("トラック番号は1以上である必要があります: %d", *trackNumber)
+	}
+	title = strings.TrimSpace(title)
+	if releaseID == nil && title == "" {
+		return SetlistEntry{}, errors.New("収録曲を参照しない曲は曲名が必須です")
+	}
+	seen := make(map[string]struct{}, len(performerIDs))
+	for _, id := range performerIDs {
+		if _, ok := seen[id]; ok {
+			return SetlistEntry{}, fmt.Errorf("曲の出演者IDが重複しています: %s", id)
+		}
+		seen[id] = struct{}{}
+	}
+	return SetlistEntry{
+		releaseID:    releaseID,
+		trackNumber:  trackNumber,
+		title:        title,
+		isEncore:     isEncore,
+		performerIDs: performerIDs,
+		note:         note,
+	}, nil
+}
+
+// ReconstructSetlistEntry は永続化層からセットリストの1曲を再構築する（バリデーションなし）
+func ReconstructSetlistEntry(releaseID *string, trackNumber *int, title string, isEncore bool, performerIDs []string, note *string) SetlistEntry {
+	return SetlistEntry{
+		releaseID:    releaseID,
+		trackNumber:  trackNumber,
+		title:        title,
+		isEncore:     isEncore,
+		performerIDs: performerIDs,
+		note:         note,
+	}
+}
+
+func (s SetlistEntry) ReleaseID() *string     { return s.releaseID }
+func (s SetlistEntry) TrackNumber() *int      { return s.trackNumber }
+func (s SetlistEntry) Title() string          { return s.title }
+func (s SetlistEntry) IsEncore() bool         { return s.isEncore }
+func (s SetlistEntry) PerformerIDs() []string { return s.performerIDs }
+func (s SetlistEntry) Note() *string          { return s.note }
+
+// IsTrack は収録曲を参照する曲かを返す
+func (s SetlistEntry) IsTrack() bool {
+	return s.releaseID != nil
+}
+
+// WithTitle は曲名を差し替えたコピーを返す（収録曲の曲名で補完する場合に使う）
+func (s SetlistEntry) WithTitle(title string) SetlistEntry {
+	s.title = title
+	return s
+}
+
+// withoutPerformer は曲の出演者から performerID を除いたコピーを返す
+func (s SetlistEntry) withoutPerformer(performerID string) SetlistEntry {
+	if !containsString(s.performerIDs, performerID) {
+		return s
+	}
+	s.performerIDs = removeString(s.performerIDs, performerID)
+	return s
+}
+
+// SetSetlist は演奏順に並んだセットリストを設定する。
+// ライブイベントのみ登録でき、本編の曲はアンコールより前、曲ごとの出演者はイベントの出演者に含まれている必要がある
+func (e *Event) SetSetlist(entries []SetlistEntry) error {
+	if len(entries) > 0 && e.eventType.Value() != EventTypeLive {
+		return errors.New("セットリストはライブイベントにのみ登録できます（無効なイベントタイプ）")
+	}
+	performers := make(map[string]struct{}, len(e.performers))
+	for _, p := range e.performers {
+		performers[p.PerformerID] = struct{}{}
+	}
+	encore := false
+	for i, entry := range entries {
+		if entry.isEncore {
+			encore = true
+		} else if encore {
+			return fmt.Errorf("%d曲目: 本編の曲はアンコールより前である必要があります（不正な曲順）", i+1)
+		}
+		for _, id := range entry.performerIDs {
+			if _, ok := performers[id]; !ok {
+				return fmt.Errorf("%d曲目: 出演者 %s はイベントの出演者ではありません（不正な出演者）", i+1, id)
+			}
+		}
+	}
+	e.setlist = append([]SetlistEntry{}, entries...)
+	e.updatedAt = time.Now()
+	return nil
+}
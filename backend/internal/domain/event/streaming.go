@@ -0,0 +1,151 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxPriceTiers は配信チケットの価格区分の上限
+const maxPriceTiers = 10
+
+// StreamingPlatform は配信プラットフォーム
+type StreamingPlatform string
+
+const (
+	StreamingPlatformYouTube       StreamingPlatform = "youtube"
+	StreamingPlatformNiconico      StreamingPlatform = "niconico"
+	StreamingPlatformShowroom      StreamingPlatform = "showroom"
+	StreamingPlatformTwitCasting   StreamingPlatform = "twitcasting"
+	StreamingPlatformZaiko         StreamingPlatform = "zaiko"
+	StreamingPlatformStageCrowd    StreamingPlatform = "stagecrowd"
+	StreamingPlatformStreamingPlus StreamingPlatform = "streaming_plus" // Streaming+（イープラス）
+	StreamingPlatformAbema         StreamingPlatform = "abema"
+	StreamingPlatformOther         StreamingPlatform = "other"
+)
+
+// StreamingPlatforms は指定できる配信プラットフォームの一覧
+var StreamingPlatforms = []StreamingPlatform{
+	StreamingPlatformYouTube,
+	StreamingPlatformNiconico,
+	StreamingPlatformShowroom,
+	StreamingPlatformTwitCasting,
+	StreamingPlatformZaiko,
+	StreamingPlatformStageCrowd,
+	StreamingPlatformStreamingPlus,
+	StreamingPlatformAbema,
+	StreamingPlatformOther,
+}
+
+// IsValid は定義済みの配信プラットフォームかを返す
+func (p StreamingPlatform) IsValid() bool {
+	for _, v := range StreamingPlatforms {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
+
+// PriceTier は配信チケットの価格区分（円）
+type PriceTier struct {
+	Name  string // 例: 通常視聴券、アーカイブ付き
+	Price int
+}
+
+// Streaming はイベントの配信情報（値オブジェクト）
+type Streaming struct {
+	platform     StreamingPlatform
+	url          *string    // 配信ページのURL
+	ticketURL    *string    // 視聴チケットの購入URL
+	archiveUntil *time.Time // アーカイブの視聴期限（アーカイブなしは nil）
+	priceTiers   []PriceTier
+}
+
+// NewStreaming は配信情報を生成する
+func NewStreaming(platform StreamingPlatform, url, ticketURL *string, archiveUntil *time.Time, priceTiers []PriceTier) (Streaming, error) {
+	if !platform.IsValid() {
+		return Streaming{}, fmt.Errorf("無効な配信プラットフォームです: %s", platform)
+	}
+	if len(priceTiers) > maxPriceTiers {
+		return Streaming{}, fmt.Errorf("価格区分は%d件までです", maxPriceTiers)
+	}
+	tiers := make([]PriceTier, 0, len(priceTiers))
+	seen := make(map[string]struct{}, len(priceTiers))
+	for _, t := range priceTiers {
+		name := strings.TrimSpace(t.Name)
+		if name == "" {
+			return Streaming{}, errors.New("価格区分の名前は必須です")
+		}
+		if t.Price < 0 {
+			return Streaming{}, fmt.Errorf("価格は0以上である必要があります: %s", name)
+		}
+		if _, ok := seen[name]; ok {
+			return Streaming{}, fmt.Errorf("価格区分の名前が重複しています: %s", name)
+		}
+		seen[name] = struct{}{}
+		tiers = append(tiers, PriceTier{Name: name, Price: t.Price})
+	}
+	return Streaming{platform: platform, url: url, ticketURL: ticketURL, archiveUntil: archiveUntil, priceTiers: tiers}, nil
+}
+
+// ReconstructStreaming は永続化層から配信情報を再構築する（バリデーションなし）
+func ReconstructStreaming(platform StreamingPlatform, url, ticketURL *string, archiveUntil *time.Time, priceTiers []PriceTier) Streaming {
+	return Streaming{platform: platform, url: url, ticketURL: ticketURL, archiveUntil: archiveUntil, priceTiers: priceTiers}
+}
+
+func (s Streaming) Platform() StreamingPlatform { return s.platform }
+func (s Streaming) URL() *string                { return s.url }
+func (s Streaming) TicketURL() *string          { return s.ticketURL }
+func (s Streaming) ArchiveUntil() *time.Time    { return s.archiveUntil }
+
+func (s Streaming) PriceTiers() []PriceTier {
+	if s.priceTiers == nil {
+		return []PriceTier{}
+	}
+	return s.priceTiers
+}
+
+// ArchiveAvailableAt は指定時刻にアーカイブを視聴できるかを返す
+func (s Streaming) ArchiveAvailableAt(now time.Time) bool {
+	return s.archiveUntil != nil && now.Before(*s.archiveUntil)
+}
+
+// AttendanceMode はイベントへの参加形態
+type AttendanceMode string
+
+const (
+	AttendanceModeOffline AttendanceMode = "offline" // 会場のみ
+	AttendanceModeOnline  AttendanceMode = "online"  // 配信のみ
+	AttendanceModeHybrid  AttendanceMode = "hybrid"  // 会場と配信の両方
+)
+
+// Streaming は配信情報を返す（配信なしは nil）
+func (e *Event) Streaming() *Streaming {
+	return e.streaming
+}
+
+// SetStreaming は配信情報を設定する（nil で解除）。アーカイブの視聴期限は開始日時より後にする
+func (e *Event) SetStreaming(streaming *Streaming) error {
+	if streaming != nil && streaming.archiveUntil != nil && !streaming.archiveUntil.After(e.startDateTime) {
+		return errors.New("アーカイブの視聴期限は開始日時より後にしてください")
+	}
+	e.streaming = streaming
+	e.updatedAt = time.Now()
+	return nil
+}
+
+// AttendanceMode は参加形態を返す。
+// 配信情報があれば会場の有無でオンライン・ハイブリッドを判定し、配信情報のないオンラインイベントもオンラインとする
+func (e *Event) AttendanceMode() AttendanceMode {
+	online := e.streaming != nil || e.eventType.Value() == EventTypeOnline
+	switch {
+	case online && e.venueID != nil && e.streaming != nil:
+		return AttendanceModeHybrid
+	case online:
+		return AttendanceModeOnline
+	default:
+		return AttendanceModeOffline
+	}
+}
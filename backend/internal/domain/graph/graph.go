@@ -0,0 +1,93 @@
+// Package graph はアイドル・グループ・事務所・リリース・イベントの関係グラフのドメインモデル
+package graph
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrTooManyEdges は1ホップ分のエッジが取得件数の上限を超え、隣接関係を取得しきれなかったことを表す
+var ErrTooManyEdges = errors.New("関係が多すぎるため関係グラフを取得できません")
+
+// NodeType はグラフのノード種別
+type NodeType string
+
+const (
+	NodeIdol    NodeType = "idol"
+	NodeGroup   NodeType = "group"
+	NodeAgency  NodeType = "agency"
+	NodeRelease NodeType = "release"
+	NodeEvent   NodeType = "event"
+)
+
+// NodeRef はノードの参照（種別とID）
+type NodeRef struct {
+	Type NodeType
+	ID   string
+}
+
+// Key は種別をまたいで一意なノードのキーを返す（例: "idol:abc"）
+func (r NodeRef) Key() string {
+	return string(r.Type) + ":" + r.ID
+}
+
+// Node は表示名付きのノード
+type Node struct {
+	Ref   NodeRef
+	Label string
+}
+
+// EdgeKind は関係の種類
+type EdgeKind string
+
+const (
+	EdgeMemberOf       EdgeKind = "member_of"       // アイドル → グループ（メンバーシップ）
+	EdgeAffiliatedWith EdgeKind = "affiliated_with" // アイドル・グループ → 事務所
+	EdgeCreditedOn     EdgeKind = "credited_on"     // アイドル・グループ → リリース（アーティスト参照）
+	EdgeParticipatedIn EdgeKind = "participated_in" // アイドル → リリース（楽曲参加）
+	EdgePerformedAt    EdgeKind = "performed_at"    // アイドル・グループ → イベント（出演）
+)
+
+// Edge は2ノード間の関係。From はアイドル・グループ側、To は所属先・作品・イベント側
+type Edge struct {
+	From  NodeRef
+	To    NodeRef
+	Kind  EdgeKind
+	Role  string     // メンバーシップの役割・アーティスト参照の役割・出演のビリング（空可）
+	Since *time.Time // 在籍開始日（メンバーシップ）
+	Until *time.Time // 在籍終了日（メンバーシップ、在籍中は nil）
+	At    *time.Time // 発売日（リリース）・開催日時（イベント）
+}
+
+// key はエッジの重複判定に使うキーを返す
+func (e Edge) key() string {
+	return e.From.Key() + ">" + string(e.Kind) + ">" + e.To.Key()
+}
+
+// Other は ref と反対側のノードを返す
+func (e Edge) Other(ref NodeRef) NodeRef {
+	if e.From == ref {
+		return e.To
+	}
+	return e.From
+}
+
+// Graph は起点から一定ホップ以内の部分グラフ
+type Graph struct {
+	Root      NodeRef
+	Depth     int
+	Nodes     []Node // 起点からの距離順
+	Edges     []Edge
+	Truncated bool // ノード数・エッジ取得件数の上限で探索を打ち切った場合 true
+}
+
+// Repository は関係グラフの隣接情報を取得するリポジトリ
+type Repository interface {
+	// FindEdges は指定ノードのいずれかに接続するエッジを返す。
+	// 1ホップ分をまとめて取得するため、複数ノードを一度に受け取る。
+	// 取得件数の上限を超えた場合は一部だけを返さず ErrTooManyEdges を返す
+	FindEdges(ctx context.Context, refs []NodeRef) ([]Edge, error)
+	// FindLabels は存在するノードの表示名を返す。削除済み・存在しないノードは結果に含めない
+	FindLabels(ctx context.Context, refs []NodeRef) (map[NodeRef]string, error)
+}
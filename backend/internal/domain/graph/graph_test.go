@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRepository struct {
+	edges     []Edge
+	labels    map[NodeRef]string
+	calls     int
+	edgeLimit int // 0 以外なら1回の FindEdges で返すエッジ数の上限
+}
+
+func (f *fakeRepository) FindEdges(_ context.Context, refs []NodeRef) ([]Edge, error) {
+	f.calls++
+	set := make(map[NodeRef]bool, len(refs))
+	for _, r := range refs {
+		set[r] = true
+	}
+	var result []Edge
+	for _, e := range f.edges {
+		if set[e.From] || set[e.To] {
+			result = append(result, e)
+		}
+	}
+	if f.edgeLimit > 0 && len(result) > f.edgeLimit {
+		return nil, ErrTooManyEdges
+	}
+	return result, nil
+}
+
+func (f *fakeRepository) FindLabels(_ context.Context, refs []NodeRef) (map[NodeRef]string, error) {
+	result := make(map[NodeRef]string)
+	for _, r := range refs {
+		if label, ok := f.labels[r]; ok {
+			result[r] = label
+		}
+	}
+	return result, nil
+}
+
+func idol(id string) NodeRef  { return NodeRef{Type: NodeIdol, ID: id} }
+func group(id string) NodeRef { return NodeRef{Type: NodeGroup, ID: id} }
+func event(id string) NodeRef { return NodeRef{Type: NodeEvent, ID: id} }
+
+func date(year int) *time.Time {
+	t := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &t
+}
+
+func member(i, g NodeRef, since, until *time.Time) Edge {
+	return Edge{From: i, To: g, Kind: EdgeMemberOf, Since: since, Until: until}
+}
+
+func performed(p, e NodeRef, at *time.Time) Edge {
+	return Edge{From: p, To: e, Kind: EdgePerformedAt, At: at}
+}
+
+// テスト用のグラフ:
+// a, b は g1 に同時在籍、c は a 卒業後に g1 へ加入、d は g2 で b と在籍。
+// e1 には a と x が出演、e2 には g1（a 在籍中）と x・y が出演。deleted は削除済み
+func sampleRepository() *fakeRepository {
+	return &fakeRepository{
+		edges: []Edge{
+			member(idol("a"), group("g1"), date(2010), date(2015)),
+			member(idol("b"), group("g1"), date(2012), nil),
+			member(idol("c"), group("g1"), date(2018), nil),
+			member(idol("b"), group("g2"), date(2016), nil),
+			member(idol("d"), group("g2"), date(2016), nil),
+			member(idol("deleted"), group("g1"), date(2011), nil),
+			performed(idol("a"), event("e1"), date(2020)),
+			performed(idol("x"), event("e1"), date(2020)),
+			performed(group("g1"), event("e2"), date(2013)),
+			performed(idol("x"), event("e2"), date(2013)),
+			performed(idol("y"), event("e2"), date(2013)),
+		},
+		labels: map[NodeRef]string{
+			idol("a"): "A", idol("b"): "B", idol("c"): "C", idol("d"): "D",
+			idol("x"): "X", idol("y"): "Y",
+			group("g1"): "G1", group("g2"): "G2",
+			event("e1"): "E1", event("e2"): "E2",
+		},
+	}
+}
+
+func TestExplore_CollectsNodesWithinDepth(t *testing.T) {
+	repo := sampleRepository()
+
+	g, err := Explore(context.Background(), repo, Node{Ref: idol("a"), Label: "A"}, 1, 100)
+
+	require.NoError(t, err)
+	var keys []string
+	for _, n := range g.Nodes {
+		keys = append(keys, n.Ref.Key())
+	}
+	assert.Equal(t, []string{"idol:a", "group:g1", "event:e1"}, keys)
+	assert.Len(t, g.Edges, 2)
+	assert.False(t, g.Truncated)
+}
+
+func TestExplore_SkipsDeletedNodesAndTruncates(t *testing.T) {
+	repo := sampleRepository()
+
+	g, err := Explore(context.Background(), repo, Node{Ref: idol("a"), Label: "A"}, 2, 4)
+
+	require.NoError(t, err)
+	assert.Len(t, g.Nodes, 4)
+	assert.True(t, g.Truncated)
+	for _, n := range g.Nodes {
+		assert.NotEqual(t, "deleted", n.Ref.ID)
+	}
+	for _, e := range g.Edges {
+		assert.NotEqual(t, "deleted", e.From.ID)
+	}
+}
+
+func TestExplore_TruncatesWhenEdgesExceedLimit(t *testing.T) {
+	repo := sampleRepository()
+	repo.edgeLimit = 2
+
+	g, err := Explore(context.Background(), repo, Node{Ref: idol("a"), Label: "A"}, 2, 100)
+
+	require.NoError(t, err)
+	var keys []string
+	for _, n := range g.Nodes {
+		keys = append(keys, n.Ref.Key())
+	}
+	assert.Equal(t, []string{"idol:a", "group:g1", "event:e1"}, keys)
+	assert.True(t, g.Truncated)
+}
+
+func TestShortestPath(t *testing.T) {
+	repo := sampleRepository()
+
+	path, err := ShortestPath(context.Background(), repo, Node{Ref: idol("a"), Label: "A"}, Node{Ref: idol("d"), Label: "D"}, 6, 100)
+
+	require.NoError(t, err)
+	require.NotNil(t, path)
+	var ids []string
+	for _, n := range path.Nodes {
+		ids = append(ids, n.Ref.ID)
+	}
+	assert.Equal(t, []string{"a", "g1", "b", "g2", "d"}, ids)
+	require.Len(t, path.Edges, 4)
+	assert.Equal(t, EdgeMemberOf, path.Edges[0].Kind)
+
+	path, err = ShortestPath(context.Background(), repo, Node{Ref: idol("a"), Label: "A"}, Node{Ref: idol("d"), Label: "D"}, 3, 100)
+	require.NoError(t, err)
+	assert.Nil(t, path)
+
+	repo.edgeLimit = 2
+	path, err = ShortestPath(context.Background(), repo, Node{Ref: idol("a"), Label: "A"}, Node{Ref: idol("d"), Label: "D"}, 6, 100)
+	require.NoError(t, err)
+	assert.Nil(t, path)
+}
+
+func TestCoMembers_MarksOverlappingPeriods(t *testing.T) {
+	repo := sampleRepository()
+
+	members, err := CoMembers(context.Background(), repo, idol("a"))
+
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "b", members[0].Idol.Ref.ID)
+	assert.True(t, members[0].Groups[0].Overlapped)
+	assert.Equal(t, "c", members[1].Idol.Ref.ID)
+	assert.False(t, members[1].Groups[0].Overlapped)
+}
+
+func TestCoStars_IncludesGroupAppearancesDuringMembership(t *testing.T) {
+	repo := sampleRepository()
+
+	stars, err := CoStars(context.Background(), repo, idol("a"), 10)
+
+	require.NoError(t, err)
+	require.Len(t, stars, 2)
+	assert.Equal(t, "x", stars[0].Performer.Ref.ID)
+	assert.Equal(t, 2, stars[0].EventCount)
+	assert.Equal(t, "e1", stars[0].Events[0].Ref.ID)
+	assert.Equal(t, "y", stars[1].Performer.Ref.ID)
+
+	// c の在籍前のグループ出演は共演に含めない
+	stars, err = CoStars(context.Background(), repo, idol("c"), 10)
+	require.NoError(t, err)
+	assert.Empty(t, stars)
+}
@@ -0,0 +1,263 @@
+package graph
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// maxCoStarEvents は共演者ごとに返す共演イベントの最大件数
+const maxCoStarEvents = 5
+
+// SharedGroup は共通して在籍したグループ
+type SharedGroup struct {
+	Group      Node
+	Overlapped bool // 在籍期間が重なっている（同時に在籍していた）
+}
+
+// CoMember は同じグループに在籍したことのあるアイドル
+type CoMember struct {
+	Idol   Node
+	Groups []SharedGroup
+}
+
+// CoStar は同じイベントに出演した共演者（アイドルまたはグループ）
+type CoStar struct {
+	Performer  Node
+	EventCount int
+	Events     []Node // 開催日時の新しい順、最大5件
+}
+
+// CoMembers は idol と同じグループに在籍したことのあるアイドルを返す。
+// 同時在籍したグループが多い順、共通グループが多い順、表示名順に並べる
+func CoMembers(ctx context.Context, repo Repository, idol NodeRef) ([]CoMember, error) {
+	own, err := repo.FindEdges(ctx, []NodeRef{idol})
+	if err != nil {
+		return nil, err
+	}
+	mine := make(map[NodeRef][]Edge)
+	var groups []NodeRef
+	for _, e := range own {
+		if e.Kind != EdgeMemberOf || e.From != idol {
+			continue
+		}
+		if _, ok := mine[e.To]; !ok {
+			groups = append(groups, e.To)
+		}
+		mine[e.To] = append(mine[e.To], e)
+	}
+	if len(groups) == 0 {
+		return []CoMember{}, nil
+	}
+
+	edges, err := repo.FindEdges(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+	shared := make(map[NodeRef]map[NodeRef]bool) // アイドル → グループ → 同時在籍
+	var idols []NodeRef
+	for _, e := range edges {
+		memberships, ok := mine[e.To]
+		if e.Kind != EdgeMemberOf || e.From == idol || !ok {
+			continue
+		}
+		if _, ok := shared[e.From]; !ok {
+			shared[e.From] = make(map[NodeRef]bool)
+			idols = append(idols, e.From)
+		}
+		for _, m := range memberships {
+			if periodsOverlap(m, e) {
+				shared[e.From][e.To] = true
+			}
+		}
+		if _, ok := shared[e.From][e.To]; !ok {
+			shared[e.From][e.To] = false
+		}
+	}
+
+	labels, err := repo.FindLabels(ctx, append(append([]NodeRef{}, idols...), groups...))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]CoMember, 0, len(idols))
+	for _, ref := range idols {
+		label, ok := labels[ref]
+		if !ok {
+			continue
+		}
+		member := CoMember{Idol: Node{Ref: ref, Label: label}}
+		for _, group := range groups {
+			overlapped, ok := shared[ref][group]
+			groupLabel, exists := labels[group]
+			if !ok || !exists {
+				continue
+			}
+			member.Groups = append(member.Groups, SharedGroup{Group: Node{Ref: group, Label: groupLabel}, Overlapped: overlapped})
+		}
+		if len(member.Groups) > 0 {
+			result = append(result, member)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		oi, oj := overlappedCount(result[i]), overlappedCount(result[j])
+		if oi != oj {
+			return oi > oj
+		}
+		if len(result[i].Groups) != len(result[j].Groups) {
+			return len(result[i].Groups) > len(result[j].Groups)
+		}
+		return result[i].Idol.Label < result[j].Idol.Label
+	})
+	return result, nil
+}
+
+// CoStars は idol と同じイベントに出演した共演者を共演回数の多い順に最大 limit 件返す。
+// idol 本人の出演に加え、在籍期間中の所属グループとしての出演も対象とする
+func CoStars(ctx context.Context, repo Repository, idol NodeRef, limit int) ([]CoStar, error) {
+	own, err := repo.FindEdges(ctx, []NodeRef{idol})
+	if err != nil {
+		return nil, err
+	}
+
+	self := map[NodeRef]bool{idol: true}
+	events := make(map[NodeRef]*time.Time)
+	var eventRefs []NodeRef
+	addEvent := func(e Edge) {
+		if _, ok := events[e.To]; !ok {
+			events[e.To] = e.At
+			eventRefs = append(eventRefs, e.To)
+		}
+	}
+
+	memberships := make(map[NodeRef][]Edge)
+	var groups []NodeRef
+	for _, e := range own {
+		switch {
+		case e.Kind == EdgePerformedAt && e.From == idol:
+			addEvent(e)
+		case e.Kind == EdgeMemberOf && e.From == idol:
+			if _, ok := memberships[e.To]; !ok {
+				groups = append(groups, e.To)
+			}
+			memberships[e.To] = append(memberships[e.To], e)
+			self[e.To] = true
+		}
+	}
+
+	if len(groups) > 0 {
+		groupEdges, err := repo.FindEdges(ctx, groups)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range groupEdges {
+			ms, ok := memberships[e.From]
+			if e.Kind != EdgePerformedAt || !ok {
+				continue
+			}
+			for _, m := range ms {
+				if withinPeriod(e.At, m) {
+					addEvent(e)
+					break
+				}
+			}
+		}
+	}
+	if len(eventRefs) == 0 {
+		return []CoStar{}, nil
+	}
+
+	eventEdges, err := repo.FindEdges(ctx, eventRefs)
+	if err != nil {
+		return nil, err
+	}
+	appearances := make(map[NodeRef]map[NodeRef]bool)
+	var performers []NodeRef
+	for _, e := range eventEdges {
+		if _, ok := events[e.To]; e.Kind != EdgePerformedAt || !ok || self[e.From] {
+			continue
+		}
+		if _, ok := appearances[e.From]; !ok {
+			appearances[e.From] = make(map[NodeRef]bool)
+			performers = append(performers, e.From)
+		}
+		appearances[e.From][e.To] = true
+	}
+
+	labels, err := repo.FindLabels(ctx, append(append([]NodeRef{}, performers...), eventRefs...))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]CoStar, 0, len(performers))
+	for _, ref := range performers {
+		label, ok := labels[ref]
+		if !ok {
+			continue
+		}
+		star := CoStar{Performer: Node{Ref: ref, Label: label}}
+		for event := range appearances[ref] {
+			if eventLabel, ok := labels[event]; ok {
+				star.Events = append(star.Events, Node{Ref: event, Label: eventLabel})
+			}
+		}
+		if len(star.Events) == 0 {
+			continue
+		}
+		star.EventCount = len(star.Events)
+		sort.Slice(star.Events, func(i, j int) bool {
+			a, b := events[star.Events[i].Ref], events[star.Events[j].Ref]
+			if a == nil || b == nil {
+				return b == nil && a != nil
+			}
+			return a.After(*b)
+		})
+		if len(star.Events) > maxCoStarEvents {
+			star.Events = star.Events[:maxCoStarEvents]
+		}
+		result = append(result, star)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].EventCount != result[j].EventCount {
+			return result[i].EventCount > result[j].EventCount
+		}
+		return result[i].Performer.Label < result[j].Performer.Label
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func overlappedCount(m CoMember) int {
+	n := 0
+	for _, g := range m.Groups {
+		if g.Overlapped {
+			n++
+		}
+	}
+	return n
+}
+
+// periodsOverlap は2つのメンバーシップの在籍期間が重なるかを返す。開始日不明は最古、終了日なしは在籍中として扱う
+func periodsOverlap(a, b Edge) bool {
+	return !after(a.Since, b.Until) && !after(b.Since, a.Until)
+}
+
+// withinPeriod は日時 at がメンバーシップの在籍期間内かを返す。日時不明の場合は期間内として扱う
+func withinPeriod(at *time.Time, m Edge) bool {
+	if at == nil {
+		return true
+	}
+	return !after(m.Since, at) && !after(at, m.Until)
+}
+
+// after は start が end より後かを返す。start が nil なら最古、end が nil なら無期限として扱う
+func after(start, end *time.Time) bool {
+	if start == nil || end == nil {
+		return false
+	}
+	return start.After(*end)
+}
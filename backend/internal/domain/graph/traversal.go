@@ -0,0 +1,209 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// link は探索で辿ったエッジ。from は探索済み側、to は辿った先のノード
+type link struct {
+	edge Edge
+	from NodeRef
+	to   NodeRef
+}
+
+// step は1ホップ分の探索結果
+type step struct {
+	links []link
+	nodes []Node // 新たに到達した（存在が確認できた）ノード
+}
+
+// expand は frontier から1ホップ先のエッジとノードを取得する。
+// 削除済み・存在しないノードへのエッジは除外する
+func expand(ctx context.Context, repo Repository, frontier []NodeRef, visited map[NodeRef]bool) (step, error) {
+	edges, err := repo.FindEdges(ctx, frontier)
+	if err != nil {
+		return step{}, err
+	}
+
+	inFrontier := make(map[NodeRef]bool, len(frontier))
+	for _, ref := range frontier {
+		inFrontier[ref] = true
+	}
+
+	var links []link
+	var unknown []NodeRef
+	pending := make(map[NodeRef]bool)
+	for _, e := range edges {
+		var l link
+		switch {
+		case inFrontier[e.From]:
+			l = link{edge: e, from: e.From, to: e.To}
+		case inFrontier[e.To]:
+			l = link{edge: e, from: e.To, to: e.From}
+		default:
+			continue
+		}
+		links = append(links, l)
+		if !visited[l.to] && !pending[l.to] {
+			pending[l.to] = true
+			unknown = append(unknown, l.to)
+		}
+	}
+
+	labels := map[NodeRef]string{}
+	if len(unknown) > 0 {
+		if labels, err = repo.FindLabels(ctx, unknown); err != nil {
+			return step{}, err
+		}
+	}
+
+	result := step{}
+	for _, ref := range unknown {
+		if label, ok := labels[ref]; ok {
+			result.nodes = append(result.nodes, Node{Ref: ref, Label: label})
+		}
+	}
+	sortNodes(result.nodes)
+	for _, l := range links {
+		if _, ok := labels[l.to]; ok || visited[l.to] {
+			result.links = append(result.links, l)
+		}
+	}
+	return result, nil
+}
+
+// Explore は root から depth ホップ以内のノードとエッジを幅優先で収集する。
+// ノード数が maxNodes に達した時点、またはエッジが取得件数の上限を超えた時点で
+// それ以上のノードを加えず Truncated を立てる
+func Explore(ctx context.Context, repo Repository, root Node, depth, maxNodes int) (*Graph, error) {
+	g := &Graph{Root: root.Ref, Depth: depth, Nodes: []Node{root}}
+	visited := map[NodeRef]bool{root.Ref: true}
+	seenEdges := make(map[string]bool)
+
+	frontier := []NodeRef{root.Ref}
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		s, err := expand(ctx, repo, frontier, visited)
+		if errors.Is(err, ErrTooManyEdges) {
+			g.Truncated = true
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var next []NodeRef
+		for _, n := range s.nodes {
+			if len(g.Nodes) >= maxNodes {
+				g.Truncated = true
+				break
+			}
+			visited[n.Ref] = true
+			g.Nodes = append(g.Nodes, n)
+			next = append(next, n.Ref)
+		}
+		for _, l := range s.links {
+			// 上限で加えなかったノードへのエッジは含めない
+			if !visited[l.to] || seenEdges[l.edge.key()] {
+				continue
+			}
+			seenEdges[l.edge.key()] = true
+			g.Edges = append(g.Edges, l.edge)
+		}
+		frontier = next
+	}
+	return g, nil
+}
+
+// Path は2ノード間の経路。Edges[i] は Nodes[i] と Nodes[i+1] を結ぶ
+type Path struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// ShortestPath は from から to への最短経路を幅優先で探索する。
+// maxDepth ホップ以内、探索ノード数 maxNodes 以内に見つからない場合や、
+// エッジが取得件数の上限を超えてそれ以上探索できない場合は nil を返す
+func ShortestPath(ctx context.Context, repo Repository, from, to Node, maxDepth, maxNodes int) (*Path, error) {
+	if from.Ref == to.Ref {
+		return &Path{Nodes: []Node{from}}, nil
+	}
+
+	nodes := map[NodeRef]Node{from.Ref: from}
+	parents := make(map[NodeRef]link)
+	visited := map[NodeRef]bool{from.Ref: true}
+
+	frontier := []NodeRef{from.Ref}
+	for hop := 0; hop < maxDepth && len(frontier) > 0; hop++ {
+		s, err := expand(ctx, repo, frontier, visited)
+		if errors.Is(err, ErrTooManyEdges) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range s.nodes {
+			nodes[n.Ref] = n
+		}
+
+		var next []NodeRef
+		for _, l := range s.links {
+			if visited[l.to] {
+				continue
+			}
+			visited[l.to] = true
+			parents[l.to] = l
+			next = append(next, l.to)
+			if l.to == to.Ref {
+				return buildPath(nodes, parents, from.Ref, to.Ref), nil
+			}
+		}
+		if len(visited) >= maxNodes {
+			return nil, nil
+		}
+		frontier = next
+	}
+	return nil, nil
+}
+
+// buildPath は親へのリンクを辿って経路を復元する
+func buildPath(nodes map[NodeRef]Node, parents map[NodeRef]link, from, to NodeRef) *Path {
+	path := &Path{}
+	for ref := to; ref != from; ref = parents[ref].from {
+		path.Nodes = append(path.Nodes, nodes[ref])
+		path.Edges = append(path.Edges, parents[ref].edge)
+	}
+	path.Nodes = append(path.Nodes, nodes[from])
+
+	for i, j := 0, len(path.Nodes)-1; i < j; i, j = i+1, j-1 {
+		path.Nodes[i], path.Nodes[j] = path.Nodes[j], path.Nodes[i]
+	}
+	for i, j := 0, len(path.Edges)-1; i < j; i, j = i+1, j-1 {
+		path.Edges[i], path.Edges[j] = path.Edges[j], path.Edges[i]
+	}
+	return path
+}
+
+// nodeTypeOrder はノードの並び順（アイドル → グループ → 事務所 → リリース → イベント）
+var nodeTypeOrder = map[NodeType]int{
+	NodeIdol:    0,
+	NodeGroup:   1,
+	NodeAgency:  2,
+	NodeRelease: 3,
+	NodeEvent:   4,
+}
+
+// sortNodes は種別・表示名・IDの順に並べる
+func sortNodes(nodes []Node) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if a.Ref.Type != b.Ref.Type {
+			return nodeTypeOrder[a.Ref.Type] < nodeTypeOrder[b.Ref.Type]
+		}
+		if a.Label != b.Label {
+			return a.Label < b.Label
+		}
+		return a.Ref.ID < b.Ref.ID
+	})
+}
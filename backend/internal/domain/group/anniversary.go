@@ -0,0 +1,45 @@
+package group
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/monthday"
+)
+
+// MonthDay は結成記念日の月日を返す
+func (f FormationDate) MonthDay() monthday.MonthDay {
+	return monthday.Of(f.value)
+}
+
+// Anniversary はグループのある年の結成記念日
+type Anniversary struct {
+	Group *Group
+	Date  time.Time // 閏日結成は閏年以外の年で2月28日
+	Years int       // 結成からの年数（周年）
+}
+
+// AnniversariesBetween は from〜to（両端を含む日付）に結成記念日を迎えるグループを日付・名前順で返す
+func AnniversariesBetween(groups []*Group, from, to time.Time) []Anniversary {
+	anniversaries := []Anniversary{}
+	for _, g := range groups {
+		if g.formationDate == nil || g.formationDate.IsEmpty() {
+			continue
+		}
+		origin := g.formationDate.Value()
+		for _, d := range monthday.Occurrences(origin, from, to) {
+			anniversaries = append(anniversaries, Anniversary{Group: g, Date: d, Years: d.Year() - origin.Year()})
+		}
+	}
+	sort.SliceStable(anniversaries, func(a, b int) bool {
+		if !anniversaries[a].Date.Equal(anniversaries[b].Date) {
+			return anniversaries[a].Date.Before(anniversaries[b].Date)
+		}
+		// 閏年以外の2月28日は2月29日結成を後にする（リポジトリの月日順と揃える）
+		if ma, mb := anniversaries[a].Group.formationDate.MonthDay().String(), anniversaries[b].Group.formationDate.MonthDay().String(); ma != mb {
+			return ma < mb
+		}
+		return anniversaries[a].Group.name.Value() < anniversaries[b].Group.name.Value()
+	})
+	return anniversaries
+}
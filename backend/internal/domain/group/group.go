@@ -4,16 +4,19 @@ import (
 	"errors"
 	"time"
 
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
 	"github.com/kuro48/idol-api/internal/shared/source"
 )
 
 type Group struct {
 	id            GroupID
 	name          GroupName
+	nameHistory   namehistory.History
 	status        GroupStatus
 	formationDate *FormationDate
 	disbandDate   *DisbandDate
-	agencyID      *string
+	agencyID      *string // 現在の所属事務所ID（移籍を含む所属履歴は affiliation 集約で管理）
+	parent        *ParentRelation
 	logoURL       *string
 	externalIDs   *ExternalIDs
 	sources       []source.Source
@@ -40,10 +43,12 @@ func NewGroup(
 func Reconstruct(
 	id GroupID,
 	name GroupName,
+	nameHistory namehistory.History,
 	status GroupStatus,
 	formationDate *FormationDate,
 	disbandDate *DisbandDate,
 	agencyID *string,
+	parent *ParentRelation,
 	logoURL *string,
 	externalIDs *ExternalIDs,
 	sources []source.Source,
@@ -53,10 +58,12 @@ func Reconstruct(
 	return &Group{
 		id:            id,
 		name:          name,
+		nameHistory:   nameHistory,
 		status:        status,
 		formationDate: formationDate,
 		disbandDate:   disbandDate,
 		agencyID:      agencyID,
+		parent:        parent,
 		logoURL:       logoURL,
 		externalIDs:   externalIDs,
 		sources:       sources,
@@ -73,6 +80,16 @@ func (g *Group) Name() GroupName {
 	return g.name
 }
 
+// NameHistory は改名前の旧名の履歴を返す
+func (g *Group) NameHistory() namehistory.History {
+	return g.nameHistory
+}
+
+// NameAt は指定日時点のグループ名を返す
+func (g *Group) NameAt(at time.Time) string {
+	return g.nameHistory.NameAt(at, g.name.Value())
+}
+
 func (g *Group) FormationDate() *FormationDate {
 	return g.formationDate
 }
@@ -89,6 +106,11 @@ func (g *Group) AgencyID() *string {
 	return g.agencyID
 }
 
+// Parent は親グループへの参照を返す。親グループがない場合は nil
+func (g *Group) Parent() *ParentRelation {
+	return g.parent
+}
+
 func (g *Group) LogoURL() *string {
 	return g.logoURL
 }
@@ -133,6 +155,34 @@ func (g *Group) ChangeName(name GroupName) error {
 	return nil
 }
 
+// Rename は改名日とともに名前を変更し、変更前の名前を旧名として履歴に残す。
+// 表記の訂正など履歴に残さない変更は ChangeName を使う
+func (g *Group) Rename(name GroupName, renamedAt time.Time) error {
+	if name.Value() == g.name.Value() {
+		return errors.New("改名後の名前が現在の名前と同じです（不正な改名）")
+	}
+	var since *time.Time
+	if g.formationDate != nil {
+		t := g.formationDate.Value()
+		since = &t
+	}
+	history, err := g.nameHistory.Record(g.name.Value(), since, renamedAt)
+	if err != nil {
+		return err
+	}
+	if err := g.ChangeName(name); err != nil {
+		return err
+	}
+	g.nameHistory = history
+	return nil
+}
+
+// SetNameHistory は旧名の履歴を置き換える
+func (g *Group) SetNameHistory(history namehistory.History) {
+	g.nameHistory = history
+	g.updatedAt = time.Now()
+}
+
 func (g *Group) UpdateFormationDate(formationDate FormationDate) error {
 	g.formationDate = &formationDate
 	g.updatedAt = time.Now()
@@ -181,6 +231,16 @@ func (g *Group) UpdateAgency(agencyID *string) {
 	g.updatedAt = time.Now()
 }
 
+// SetParent は親グループへの参照を設定する。nil を渡すと親グループの指定を解除する
+func (g *Group) SetParent(parent *ParentRelation) error {
+	if parent != nil && g.id.Value() != "" && parent.GroupID().Equals(g.id) {
+		return errors.New("不正な親グループ指定です: グループ自身は親グループにできません")
+	}
+	g.parent = parent
+	g.updatedAt = time.Now()
+	return nil
+}
+
 // UpdateExternalIDs は外部IDマッピングを更新する
 func (g *Group) UpdateExternalIDs(ids *ExternalIDs) {
 	g.externalIDs = ids
@@ -2,6 +2,8 @@ package group
 
 import (
 	"errors"
+
+	"github.com/kuro48/idol-api/internal/shared/kana"
 )
 
 // GroupName はグループ名（正式名称・読み仮名・ローマ字表記を含む）
@@ -22,22 +24,38 @@ func NewGroupName(value string) (GroupName, error) {
 }
 
 // NewGroupNameFull は読み仮名・ローマ字表記を含む名前を生成する
-func NewGroupNameFull(value string, kana, latin *string) (GroupName, error) {
+func NewGroupNameFull(value string, reading, latin *string) (GroupName, error) {
 	name, err := NewGroupName(value)
 	if err != nil {
 		return GroupName{}, err
 	}
-	if kana != nil && len(*kana) > 200 {
-		return GroupName{}, errors.New("読み仮名は200文字以内である必要があります")
+	if reading != nil {
+		if err := kana.ValidateReading(*reading); err != nil {
+			return GroupName{}, err
+		}
 	}
 	if latin != nil && len(*latin) > 200 {
 		return GroupName{}, errors.New("ローマ字表記は200文字以内である必要があります")
 	}
-	name.kana = kana
+	name.kana = reading
 	name.latin = latin
 	return name, nil
 }
 
+// WithKana は読み仮名を差し替えた名前を返す。nil または空文字を指定すると読み仮名を削除する
+func (g GroupName) WithKana(reading *string) (GroupName, error) {
+	if reading != nil && *reading == "" {
+		reading = nil
+	}
+	if reading != nil {
+		if err := kana.ValidateReading(*reading); err != nil {
+			return GroupName{}, err
+		}
+	}
+	g.kana = reading
+	return g, nil
+}
+
 func (g GroupName) Value() string  { return g.value }
 func (g GroupName) Kana() *string  { return g.kana }
 func (g GroupName) Latin() *string { return g.latin }
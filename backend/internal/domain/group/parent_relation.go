@@ -0,0 +1,54 @@
+package group
+
+import "errors"
+
+// RelationType は親グループとの関係種別
+type RelationType string
+
+const (
+	RelationSubUnit   RelationType = "sub_unit"  // 親グループから派生したユニット（メンバーは親グループにも計上する）
+	RelationSister    RelationType = "sister"    // 姉妹グループ
+	RelationSuccessor RelationType = "successor" // 後継グループ
+)
+
+func NewRelationType(s string) (RelationType, error) {
+	switch RelationType(s) {
+	case RelationSubUnit, RelationSister, RelationSuccessor:
+		return RelationType(s), nil
+	}
+	return "", errors.New("無効な関係種別です: sub_unit / sister / successor のいずれかを指定してください")
+}
+
+func (t RelationType) IsValid() bool {
+	_, err := NewRelationType(string(t))
+	return err == nil
+}
+
+// RollsUpMembers は子グループのメンバーシップを親グループのメンバーに計上する関係かを返す
+func (t RelationType) RollsUpMembers() bool {
+	return t == RelationSubUnit
+}
+
+// ParentRelation は親グループへの参照と関係種別
+type ParentRelation struct {
+	groupID      GroupID
+	relationType RelationType
+}
+
+func NewParentRelation(groupID GroupID, relationType RelationType) (ParentRelation, error) {
+	if groupID.Value() == "" {
+		return ParentRelation{}, errors.New("親グループIDは必須です")
+	}
+	if !relationType.IsValid() {
+		return ParentRelation{}, errors.New("無効な関係種別です")
+	}
+	return ParentRelation{groupID: groupID, relationType: relationType}, nil
+}
+
+func (p ParentRelation) GroupID() GroupID {
+	return p.groupID
+}
+
+func (p ParentRelation) RelationType() RelationType {
+	return p.relationType
+}
@@ -1,6 +1,11 @@
 package group
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/monthday"
+)
 
 // SearchOptions は検索オプション
 type SearchOptions struct {
@@ -9,6 +14,10 @@ type SearchOptions struct {
 	Order string
 	Page  int
 	Limit int
+
+	// Omit は一覧で取得を省略する任意属性（name_history）。
+	// fields 指定でレスポンスに含めない属性の読み込みを避けるために使う
+	Omit []string
 }
 
 // SearchResult は検索結果
@@ -30,6 +39,9 @@ type Repository interface {
 	// FindWithPagination はページネーション付きでグループを検索する
 	FindWithPagination(ctx context.Context, opts SearchOptions) (*SearchResult, error)
 
+	// FindByParentID は指定したグループを親に持つグループを検索する。relationType が nil の場合は関係種別を問わない
+	FindByParentID(ctx context.Context, parentID GroupID, relationType *RelationType) ([]*Group, error)
+
 	// Update は既存のグループを更新する
 	Update(ctx context.Context, group *Group) error
 
@@ -41,4 +53,17 @@ type Repository interface {
 
 	// ExistsByName は同じ名前のグループが存在するかチェック
 	ExistsByName(ctx context.Context, name GroupName) (bool, error)
+
+	// FindByFormationMonthDay は条件に合うグループを結成日の月日・名前順に offset 件目から limit 件まで検索する
+	FindByFormationMonthDay(ctx context.Context, criteria AnniversaryCriteria, offset, limit int) ([]*Group, error)
+
+	// CountByFormationMonthDay は条件に合うグループの件数を返す
+	CountByFormationMonthDay(ctx context.Context, criteria AnniversaryCriteria) (int64, error)
+}
+
+// AnniversaryCriteria は結成記念日の検索条件
+type AnniversaryCriteria struct {
+	Range        monthday.Range // 結成日の月日の範囲
+	FormedFrom   *time.Time     // この日時以降に結成したグループに限る（nil は制限なし）
+	FormedBefore time.Time      // この日時より前に結成したグループに限る
 }
@@ -3,8 +3,13 @@ package group
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 )
 
+// MaxHierarchyDepth は親グループをたどれる階層の上限
+const MaxHierarchyDepth = 5
+
 // DomainService はグループドメインのドメインサービス
 type DomainService struct {
 	repository Repository
@@ -51,3 +56,27 @@ func (s *DomainService) IsDuplicateName(ctx context.Context, name GroupName, exc
 
 	return false, nil
 }
+
+// ValidateParent は親グループの指定が有効かを判定する。
+// 親グループが存在しない場合や、親をたどった先に自分自身が現れる（階層が循環する）場合はエラーにする
+func (s *DomainService) ValidateParent(ctx context.Context, id *GroupID, parent ParentRelation) error {
+	current := parent.GroupID()
+	for depth := 0; depth < MaxHierarchyDepth; depth++ {
+		if id != nil && current.Equals(*id) {
+			return errors.New("不正な親グループ指定です: グループ階層が循環します")
+		}
+		g, err := s.repository.FindByID(ctx, current)
+		if err != nil {
+			// 指定された親グループ自体が存在しない場合だけ入力エラーにし、それ以外の取得エラーはそのまま返す
+			if depth == 0 && strings.Contains(err.Error(), "見つかりません") {
+				return errors.New("無効な親グループです: 指定されたグループは存在しません")
+			}
+			return fmt.Errorf("親グループの取得エラー: %w", err)
+		}
+		if g.Parent() == nil {
+			return nil
+		}
+		current = g.Parent().GroupID()
+	}
+	return fmt.Errorf("不正な親グループ指定です: グループ階層は%d段までです", MaxHierarchyDepth)
+}
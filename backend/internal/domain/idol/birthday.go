@@ -0,0 +1,45 @@
+package idol
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/monthday"
+)
+
+// MonthDay は誕生日の月日を返す
+func (b Birthdate) MonthDay() monthday.MonthDay {
+	return monthday.Of(b.value)
+}
+
+// Birthday はアイドルのある年の誕生日
+type Birthday struct {
+	Idol *Idol
+	Date time.Time // 閏日生まれは閏年以外の年で2月28日
+	Age  int       // この誕生日で迎える年齢
+}
+
+// BirthdaysBetween は from〜to（両端を含む日付）に誕生日を迎えるアイドルを日付・名前順で返す
+func BirthdaysBetween(idols []*Idol, from, to time.Time) []Birthday {
+	birthdays := []Birthday{}
+	for _, i := range idols {
+		if i.birthdate == nil || i.birthdate.IsEmpty() {
+			continue
+		}
+		origin := i.birthdate.Value()
+		for _, d := range monthday.Occurrences(origin, from, to) {
+			birthdays = append(birthdays, Birthday{Idol: i, Date: d, Age: d.Year() - origin.Year()})
+		}
+	}
+	sort.SliceStable(birthdays, func(a, b int) bool {
+		if !birthdays[a].Date.Equal(birthdays[b].Date) {
+			return birthdays[a].Date.Before(birthdays[b].Date)
+		}
+		// 閏年以外の2月28日は2月29日生まれを後にする（リポジトリの月日順と揃える）
+		if ma, mb := birthdays[a].Idol.birthdate.MonthDay().String(), birthdays[b].Idol.birthdate.MonthDay().String(); ma != mb {
+			return ma < mb
+		}
+		return birthdays[a].Idol.name.Value() < birthdays[b].Idol.name.Value()
+	})
+	return birthdays
+}
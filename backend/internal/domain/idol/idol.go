@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
 	"github.com/kuro48/idol-api/internal/shared/source"
 )
 
@@ -11,9 +12,10 @@ import (
 type Idol struct {
 	id          IdolID
 	name        IdolName
+	nameHistory namehistory.History
 	birthdate   *Birthdate
 	status      IdolStatus
-	agencyID    *string      // 所属事務所ID（Phase2でMembershipに移行予定）
+	agencyID    *string      // 現在の所属事務所ID（移籍を含む所属履歴は affiliation 集約で管理）
 	socialLinks *SocialLinks
 	externalIDs *ExternalIDs
 	profileImageURL *string
@@ -44,6 +46,7 @@ func NewIdol(
 func Reconstruct(
 	id IdolID,
 	name IdolName,
+	nameHistory namehistory.History,
 	birthdate *Birthdate,
 	status IdolStatus,
 	agencyID *string,
@@ -59,6 +62,7 @@ func Reconstruct(
 	return &Idol{
 		id:              id,
 		name:            name,
+		nameHistory:     nameHistory,
 		birthdate:       birthdate,
 		status:          status,
 		agencyID:        agencyID,
@@ -83,6 +87,16 @@ func (i *Idol) Name() IdolName {
 	return i.name
 }
 
+// NameHistory は改名前の旧芸名の履歴を返す
+func (i *Idol) NameHistory() namehistory.History {
+	return i.nameHistory
+}
+
+// NameAt は指定日時点の芸名を返す
+func (i *Idol) NameAt(at time.Time) string {
+	return i.nameHistory.NameAt(at, i.name.Value())
+}
+
 func (i *Idol) Birthdate() *Birthdate {
 	return i.birthdate
 }
@@ -149,6 +163,29 @@ func (i *Idol) ChangeName(name IdolName) error {
 }
 
 // UpdateBirthdate は生年月日を更新する
+// Rename は改名日とともに芸名を変更し、変更前の芸名を旧名として履歴に残す。
+// 表記の訂正など履歴に残さない変更は ChangeName を使う
+func (i *Idol) Rename(name IdolName, renamedAt time.Time) error {
+	if name.Value() == i.name.Value() {
+		return errors.New("改名後の名前が現在の名前と同じです（不正な改名）")
+	}
+	history, err := i.nameHistory.Record(i.name.Value(), nil, renamedAt)
+	if err != nil {
+		return err
+	}
+	if err := i.ChangeName(name); err != nil {
+		return err
+	}
+	i.nameHistory = history
+	return nil
+}
+
+// SetNameHistory は旧芸名の履歴を置き換える
+func (i *Idol) SetNameHistory(history namehistory.History) {
+	i.nameHistory = history
+	i.updatedAt = time.Now()
+}
+
 func (i *Idol) UpdateBirthdate(birthdate *Birthdate) {
 	i.birthdate = birthdate
 	i.updatedAt = time.Now()
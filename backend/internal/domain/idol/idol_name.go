@@ -2,6 +2,8 @@ package idol
 
 import (
 	"errors"
+
+	"github.com/kuro48/idol-api/internal/shared/kana"
 )
 
 // IdolName はアイドルの名前（正式名称・読み仮名・ローマ字表記を含む）
@@ -22,22 +24,38 @@ func NewIdolName(value string) (IdolName, error) {
 }
 
 // NewIdolNameFull は読み仮名・ローマ字表記を含む名前を生成する
-func NewIdolNameFull(value string, kana, latin *string) (IdolName, error) {
+func NewIdolNameFull(value string, reading, latin *string) (IdolName, error) {
 	name, err := NewIdolName(value)
 	if err != nil {
 		return IdolName{}, err
 	}
-	if kana != nil && len(*kana) > 200 {
-		return IdolName{}, errors.New("読み仮名は200文字以内である必要があります")
+	if reading != nil {
+		if err := kana.ValidateReading(*reading); err != nil {
+			return IdolName{}, err
+		}
 	}
 	if latin != nil && len(*latin) > 200 {
 		return IdolName{}, errors.New("ローマ字表記は200文字以内である必要があります")
 	}
-	name.kana = kana
+	name.kana = reading
 	name.latin = latin
 	return name, nil
 }
 
+// WithKana は読み仮名を差し替えた名前を返す。nil または空文字を指定すると読み仮名を削除する
+func (n IdolName) WithKana(reading *string) (IdolName, error) {
+	if reading != nil && *reading == "" {
+		reading = nil
+	}
+	if reading != nil {
+		if err := kana.ValidateReading(*reading); err != nil {
+			return IdolName{}, err
+		}
+	}
+	n.kana = reading
+	return n, nil
+}
+
 func (n IdolName) Value() string  { return n.value }
 func (n IdolName) Kana() *string  { return n.kana }
 func (n IdolName) Latin() *string { return n.latin }
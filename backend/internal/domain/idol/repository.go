@@ -1,6 +1,11 @@
 package idol
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/monthday"
+)
 
 // Repository はアイドル集約のリポジトリインターフェース
 type Repository interface {
@@ -34,4 +39,16 @@ type Repository interface {
 
 	// FindByExternalID は外部IDでアイドルを検索する（一意制約チェック用）
 	FindByExternalID(ctx context.Context, kind ExternalIDKind, value string) (*Idol, error)
+
+	// FindByBirthMonthDay は条件に合うアイドルを誕生日の月日・名前順に offset 件目から limit 件まで検索する
+	FindByBirthMonthDay(ctx context.Context, criteria BirthdayCriteria, offset, limit int) ([]*Idol, error)
+
+	// CountByBirthMonthDay は条件に合うアイドルの件数を返す
+	CountByBirthMonthDay(ctx context.Context, criteria BirthdayCriteria) (int64, error)
+}
+
+// BirthdayCriteria は誕生日の検索条件
+type BirthdayCriteria struct {
+	Range      monthday.Range // 誕生日の月日の範囲
+	BornBefore time.Time      // この日時より前に生まれたアイドルに限る
 }
@@ -15,4 +15,8 @@ type SearchCriteria struct {
 
 	Offset int
 	Limit  int
+
+	// Omit は一覧で取得を省略する任意属性（social_links, external_ids, aliases）。
+	// fields 指定でレスポンスに含めない属性の読み込みを避けるために使う
+	Omit []string
 }
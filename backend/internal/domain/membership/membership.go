@@ -2,21 +2,27 @@ package membership
 
 import (
 	"errors"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/kuro48/idol-api/internal/shared/source"
 )
 
+// MaxGenerationLength は期生ラベルの最大文字数
+const MaxGenerationLength = 50
+
 type Membership struct {
-	id        MembershipID
-	idolID    string
-	groupID   string
-	role      Role
-	joinedAt  *time.Time
-	leftAt    *time.Time
-	sources   []source.Source
-	createdAt time.Time
-	updatedAt time.Time
+	id         MembershipID
+	idolID     string
+	groupID    string
+	role       Role
+	generation *string
+	joinedAt   *time.Time
+	leftAt     *time.Time
+	sources    []source.Source
+	createdAt  time.Time
+	updatedAt  time.Time
 }
 
 func NewMembership(idolID, groupID string, role Role, joinedAt *time.Time) (*Membership, error) {
@@ -41,20 +47,22 @@ func Reconstruct(
 	id MembershipID,
 	idolID, groupID string,
 	role Role,
+	generation *string,
 	joinedAt, leftAt *time.Time,
 	sources []source.Source,
 	createdAt, updatedAt time.Time,
 ) *Membership {
 	return &Membership{
-		id:        id,
-		idolID:    idolID,
-		groupID:   groupID,
-		role:      role,
-		joinedAt:  joinedAt,
-		leftAt:    leftAt,
-		sources:   sources,
-		createdAt: createdAt,
-		updatedAt: updatedAt,
+		id:         id,
+		idolID:     idolID,
+		groupID:    groupID,
+		role:       role,
+		generation: generation,
+		joinedAt:   joinedAt,
+		leftAt:     leftAt,
+		sources:    sources,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
 	}
 }
 
@@ -62,6 +70,7 @@ func (m *Membership) ID() MembershipID     { return m.id }
 func (m *Membership) IdolID() string       { return m.idolID }
 func (m *Membership) GroupID() string      { return m.groupID }
 func (m *Membership) Role() Role           { return m.role }
+func (m *Membership) Generation() *string  { return m.generation }
 func (m *Membership) JoinedAt() *time.Time { return m.joinedAt }
 func (m *Membership) LeftAt() *time.Time   { return m.leftAt }
 func (m *Membership) IsActive() bool       { return m.leftAt == nil }
@@ -87,6 +96,22 @@ func (m *Membership) UpdateRole(role Role) error {
 	return nil
 }
 
+// UpdateGeneration は期生ラベル（"1期生" など）を更新する。nil または空文字で解除する
+func (m *Membership) UpdateGeneration(label *string) error {
+	if label == nil || strings.TrimSpace(*label) == "" {
+		m.generation = nil
+		m.updatedAt = time.Now()
+		return nil
+	}
+	v := strings.TrimSpace(*label)
+	if utf8.RuneCountInString(v) > MaxGenerationLength {
+		return errors.New("期生ラベルは50文字以内で入力してください")
+	}
+	m.generation = &v
+	m.updatedAt = time.Now()
+	return nil
+}
+
 func (m *Membership) UpdateJoinedAt(t *time.Time) {
 	m.joinedAt = t
 	m.updatedAt = time.Now()
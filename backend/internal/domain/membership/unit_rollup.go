@@ -0,0 +1,46 @@
+package membership
+
+import "slices"
+
+// UnitRollUp は親グループのメンバーとしてまとめたメンバーシップ
+type UnitRollUp[T any] struct {
+	Membership T
+	UnitIDs    []string // 同じアイドルが所属しているサブユニット
+}
+
+// RollUpSubUnits は親グループ groupID と直下のサブユニットのメンバーシップを、同じアイドルごとに1件にまとめる。
+// 親グループのメンバーシップはすべて残し、サブユニットの所属は同じアイドルの最初の親グループのメンバーシップの UnitIDs に記録する。
+// 親グループに所属していないアイドルは最初に見つかったサブユニットのメンバーシップを残す。並び順は入力順を保つ。
+// ref はメンバーシップのアイドルIDと所属グループIDを返す
+func RollUpSubUnits[T any](groupID string, memberships []T, ref func(T) (idolID, groupID string)) []UnitRollUp[T] {
+	byIdol := make(map[string]int)
+	for i, m := range memberships {
+		idolID, memberGroupID := ref(m)
+		if _, ok := byIdol[idolID]; !ok && memberGroupID == groupID {
+			byIdol[idolID] = i
+		}
+	}
+
+	result := make([]UnitRollUp[T], 0, len(memberships))
+	kept := make(map[int]int) // 入力の位置 → result の位置
+	for i, m := range memberships {
+		idolID, memberGroupID := ref(m)
+		if memberGroupID == groupID {
+			kept[i] = len(result)
+			result = append(result, UnitRollUp[T]{Membership: m})
+			continue
+		}
+		first, ok := byIdol[idolID]
+		if !ok {
+			first = i
+			byIdol[idolID] = i
+			kept[i] = len(result)
+			result = append(result, UnitRollUp[T]{Membership: m})
+		}
+		unit := &result[kept[first]]
+		if !slices.Contains(unit.UnitIDs, memberGroupID) {
+			unit.UnitIDs = append(unit.UnitIDs, memberGroupID)
+		}
+	}
+	return result
+}
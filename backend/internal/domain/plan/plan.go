@@ -16,17 +16,27 @@ type Limits struct {
 	MonthlyRequests int
 	// WriteEnabled は write スコープ（POST/PUT/DELETE）が使えるか
 	WriteEnabled bool
+	// Include は include パラメータで展開できる関連データの上限
+	Include IncludeLimits
+}
+
+// IncludeLimits は include パラメータによる関連データ展開の上限
+type IncludeLimits struct {
+	// MaxTargets は1リクエストで指定できる include 対象の数
+	MaxTargets int
+	// MaxItems は include 対象ごと・親リソースごとに展開する関連データの最大件数
+	MaxItems int
 }
 
 // GetLimits はプランの制限値を返す
 func GetLimits(t Type) Limits {
 	switch t {
 	case TypeDeveloper:
-		return Limits{MonthlyRequests: 50_000, WriteEnabled: true}
+		return Limits{MonthlyRequests: 50_000, WriteEnabled: true, Include: IncludeLimits{MaxTargets: 5, MaxItems: 50}}
 	case TypeBusiness:
-		return Limits{MonthlyRequests: 500_000, WriteEnabled: true}
-	default: // TypeFree
-		return Limits{MonthlyRequests: 1_000, WriteEnabled: false}
+		return Limits{MonthlyRequests: 500_000, WriteEnabled: true, Include: IncludeLimits{MaxTargets: 10, MaxItems: 200}}
+	default: // TypeFree（APIキーなしの公開アクセスを含む）
+		return Limits{MonthlyRequests: 1_000, WriteEnabled: false, Include: IncludeLimits{MaxTargets: 2, MaxItems: 10}}
 	}
 }
 
@@ -52,6 +62,24 @@ func IsValid(t Type) bool {
 	}
 }
 
+// OverageAllowed は月間上限を超えた分を従量課金で継続利用できるプランかを返す
+func OverageAllowed(t Type) bool {
+	return t == TypeBusiness
+}
+
+// UpgradeTarget はプランの1段上のアップグレード先を返す
+// 最上位プランの場合は false を返す
+func UpgradeTarget(t Type) (Type, bool) {
+	switch t {
+	case TypeFree:
+		return TypeDeveloper, true
+	case TypeDeveloper:
+		return TypeBusiness, true
+	default:
+		return "", false
+	}
+}
+
 // StripePriceIDKey は各プランに対応する Stripe Price ID の環境変数キーを返す
 func StripePriceIDKey(t Type) string {
 	switch t {
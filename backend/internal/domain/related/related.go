@@ -0,0 +1,182 @@
+// Package related は include パラメータで展開する関連データの読み取りモデル
+package related
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	"github.com/kuro48/idol-api/internal/shared/geo"
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
+)
+
+// AgencySummary は展開用の事務所情報
+type AgencySummary struct {
+	ID              string
+	Name            string
+	NameEn          *string
+	Country         string
+	OfficialWebsite *string
+	LogoURL         *string
+}
+
+// IdolSummary は展開用のアイドル情報
+type IdolSummary struct {
+	ID          string
+	Name        string
+	NameKana    *string
+	NameHistory namehistory.History
+}
+
+// NameAt は指定日時点の芸名を返す
+func (s IdolSummary) NameAt(at time.Time) string {
+	return s.NameHistory.NameAt(at, s.Name)
+}
+
+// NameAsOf は at 時点の名前を返す。時点が不明（nil）なら現在の名前を返す
+func (s IdolSummary) NameAsOf(at *time.Time) string {
+	if at == nil {
+		return s.Name
+	}
+	return s.NameAt(*at)
+}
+
+// GroupSummary は展開用のグループ情報
+type GroupSummary struct {
+	ID          string
+	Name        string
+	NameKana    *string
+	AgencyID    *string
+	NameHistory namehistory.History
+}
+
+// NameAt は指定日時点のグループ名を返す
+func (s GroupSummary) NameAt(at time.Time) string {
+	return s.NameHistory.NameAt(at, s.Name)
+}
+
+// NameAsOf は at 時点の名前を返す。時点が不明（nil）なら現在の名前を返す
+func (s GroupSummary) NameAsOf(at *time.Time) string {
+	if at == nil {
+		return s.Name
+	}
+	return s.NameAt(*at)
+}
+
+// MembershipSummary は展開用のメンバーシップ情報
+type MembershipSummary struct {
+	ID         string
+	IdolID     string
+	GroupID    string
+	Role       string
+	Generation *string
+	JoinedAt   *time.Time
+	LeftAt     *time.Time
+}
+
+// ReleaseSummary は展開用のリリース情報
+type ReleaseSummary struct {
+	ID          string
+	Title       string
+	ReleaseType string
+	ReleaseDate time.Time
+}
+
+// EventSummary は展開用のイベント情報
+type EventSummary struct {
+	ID            string
+	Title         string
+	EventType     string
+	Status        string
+	StartDateTime time.Time
+	VenueID       *string
+}
+
+// TagSummary は展開用のタグ情報
+type TagSummary struct {
+	ID       string
+	Name     string
+	Category string
+}
+
+// VenueSummary は展開用の会場情報
+type VenueSummary struct {
+	ID         string
+	Name       string
+	Prefecture *string
+	City       *string
+	Address    *string
+}
+
+// Repository は関連データをまとめて取得するリポジトリ。
+// 一覧の各要素ごとに問い合わせる N+1 を避けるため、すべて複数IDを受け取る。
+// 削除済みのデータは結果に含めない
+type Repository interface {
+	FindAgencies(ctx context.Context, ids []string) (map[string]AgencySummary, error)
+	FindIdols(ctx context.Context, ids []string) (map[string]IdolSummary, error)
+	FindGroups(ctx context.Context, ids []string) (map[string]GroupSummary, error)
+	FindTags(ctx context.Context, ids []string) (map[string]TagSummary, error)
+	FindVenues(ctx context.Context, ids []string) (map[string]VenueSummary, error)
+	// FindVenuesNear は中心から半径 radiusKm 以内の会場を近い順に最大 limit 件、会場IDごとの距離（km）で返す
+	FindVenuesNear(ctx context.Context, center geo.Point, radiusKm float64, limit int) (map[string]float64, error)
+	// FindSubUnits は親グループごとに、メンバーを親グループに計上する直下のサブユニットのIDを返す
+	FindSubUnits(ctx context.Context, parentIDs []string) (map[string][]string, error)
+	// FindMembershipsByIdols はアイドルごとのメンバーシップを加入日の新しい順に最大 limit 件返す
+	FindMembershipsByIdols(ctx context.Context, idolIDs []string, limit int) (map[string][]MembershipSummary, error)
+	// FindMembershipsByGroups はグループごとのメンバーシップを加入日の古い順に最大 limit 件返す
+	FindMembershipsByGroups(ctx context.Context, groupIDs []string, limit int) (map[string][]MembershipSummary, error)
+	// FindReleasesByArtists はアーティスト（アイドル・グループ）ごとのリリースを発売日の新しい順に最大 limit 件返す
+	FindReleasesByArtists(ctx context.Context, artistIDs []string, limit int) (map[string][]ReleaseSummary, error)
+	// FindEventsByPerformers は出演者ごとのイベントを開催日時の新しい順に最大 limit 件返す
+	FindEventsByPerformers(ctx context.Context, performerIDs []string, limit int) (map[string][]EventSummary, error)
+}
+
+// ParseIncludes はカンマ区切りの include 指定を検証し、重複を除いた対象を返す。
+// 未対応の対象やプランの上限を超える指定はエラーにする
+func ParseIncludes(raw *string, allowed []string, limits plan.IncludeLimits) ([]string, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil, nil
+	}
+
+	var targets []string
+	seen := make(map[string]bool)
+	for _, v := range strings.Split(*raw, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		if !contains(allowed, v) {
+			return nil, fmt.Errorf("無効な include 対象です: %s（指定可能: %s）", v, strings.Join(allowed, ","))
+		}
+		seen[v] = true
+		targets = append(targets, v)
+	}
+	if limits = EffectiveLimits(limits); len(targets) > limits.MaxTargets {
+		return nil, fmt.Errorf("無効な include 指定です: 現在のプランで同時に指定できるのは%d件までです", limits.MaxTargets)
+	}
+	return targets, nil
+}
+
+// EffectiveLimits は上限が未設定の場合に匿名アクセスと同じ Free プランの上限を返す
+func EffectiveLimits(limits plan.IncludeLimits) plan.IncludeLimits {
+	if limits.MaxTargets <= 0 || limits.MaxItems <= 0 {
+		return plan.GetLimits(plan.TypeFree).Include
+	}
+	return limits
+}
+
+// Has は include 対象に target が含まれるかを返す
+func Has(targets []string, target string) bool {
+	return contains(targets, target)
+}
+
+func contains(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package related
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr(s string) *string { return &s }
+
+func TestParseIncludes(t *testing.T) {
+	allowed := []string{"agency", "groups", "tags"}
+	limits := plan.IncludeLimits{MaxTargets: 2, MaxItems: 10}
+
+	targets, err := ParseIncludes(ptr(" agency, groups ,agency,"), allowed, limits)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"agency", "groups"}, targets)
+
+	targets, err = ParseIncludes(nil, allowed, limits)
+	require.NoError(t, err)
+	assert.Empty(t, targets)
+}
+
+func TestParseIncludes_RejectsUnknownAndOverLimit(t *testing.T) {
+	allowed := []string{"agency", "groups", "tags"}
+
+	_, err := ParseIncludes(ptr("songs"), allowed, plan.IncludeLimits{MaxTargets: 2})
+	assert.ErrorContains(t, err, "無効な include 対象です: songs")
+
+	_, err = ParseIncludes(ptr("agency,groups,tags"), allowed, plan.GetLimits(plan.TypeFree).Include)
+	assert.ErrorContains(t, err, "2件まで")
+
+	_, err = ParseIncludes(ptr("agency,groups,tags"), allowed, plan.GetLimits(plan.TypeDeveloper).Include)
+	assert.NoError(t, err)
+}
+
+func TestEffectiveLimits_DefaultsToFree(t *testing.T) {
+	assert.Equal(t, plan.GetLimits(plan.TypeFree).Include, EffectiveLimits(plan.IncludeLimits{}))
+
+	business := plan.GetLimits(plan.TypeBusiness).Include
+	assert.Equal(t, business, EffectiveLimits(business))
+}
+
+func TestGroupSummary_NameAt(t *testing.T) {
+	renamedAt := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	summary := GroupSummary{
+		ID:          "g1",
+		Name:        "新グループ名",
+		NameHistory: namehistory.History{namehistory.ReconstructEntry("旧グループ名", nil, renamedAt)},
+	}
+
+	assert.Equal(t, "旧グループ名", summary.NameAt(renamedAt.AddDate(0, 0, -1)))
+	assert.Equal(t, "新グループ名", summary.NameAt(renamedAt))
+	assert.Equal(t, "新グループ名", GroupSummary{Name: "新グループ名"}.NameAt(renamedAt.AddDate(-5, 0, 0)))
+}
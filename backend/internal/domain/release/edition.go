@@ -0,0 +1,375 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// maxEditions は1リリースに登録できるエディションの上限
+	maxEditions = 20
+	// maxBonusMedia は1エディションに登録できる特典の上限
+	maxBonusMedia = 20
+)
+
+// EditionType はエディション（盤）の種別
+type EditionType string
+
+const (
+	EditionTypeFirstPressLimited EditionType = "first_press_limited" // 初回限定盤・初回生産限定盤
+	EditionTypeLimited           EditionType = "limited"             // 期間生産限定盤など
+	EditionTypeRegular           EditionType = "regular"             // 通常盤
+	EditionTypeTheater           EditionType = "theater"             // 劇場盤
+	EditionTypeVenue             EditionType = "venue"               // 会場限定盤
+	EditionTypeOther             EditionType = "other"
+)
+
+// IsValid はエディション種別が定義済みの値かを返す
+func (t EditionType) IsValid() bool {
+	switch t {
+	case EditionTypeFirstPressLimited, EditionTypeLimited, EditionTypeRegular,
+		EditionTypeTheater, EditionTypeVenue, EditionTypeOther:
+		return true
+	}
+	return false
+}
+
+// BonusMediaType は特典の種別
+type BonusMediaType string
+
+const (
+	BonusMediaDVD        BonusMediaType = "dvd"
+	BonusMediaBluRay     BonusMediaType = "blu_ray"
+	BonusMediaCD         BonusMediaType = "cd"
+	BonusMediaPhotobook  BonusMediaType = "photobook"
+	BonusMediaPhotoCard  BonusMediaType = "photo_card"
+	BonusMediaSerialCode BonusMediaType = "serial_code" // イベント応募券・特典会参加券など
+	BonusMediaGoods      BonusMediaType = "goods"
+	BonusMediaOther      BonusMediaType = "other"
+)
+
+// IsValid は特典種別が定義済みの値かを返す
+func (t BonusMediaType) IsValid() bool {
+	switch t {
+	case BonusMediaDVD, BonusMediaBluRay, BonusMediaCD, BonusMediaPhotobook,
+		BonusMediaPhotoCard, BonusMediaSerialCode, BonusMediaGoods, BonusMediaOther:
+		return true
+	}
+	return false
+}
+
+// BonusMedia はエディションに付属する特典（値オブジェクト）
+type BonusMedia struct {
+	mediaType   BonusMediaType
+	title       string
+	description *string
+}
+
+// NewBonusMedia は特典を生成する
+func NewBonusMedia(mediaType BonusMediaType, title string, description *string) (BonusMedia, error) {
+	if !mediaType.IsValid() {
+		return BonusMedia{}, fmt.Errorf("無効な特典種別です: %s", mediaType)
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return BonusMedia{}, errors.New("特典名は必須です")
+	}
+	if len([]rune(title)) > 200 {
+		return BonusMedia{}, errors.New("特典名は200文字以内で入力してください")
+	}
+	if description != nil && len([]rune(*description)) > 1000 {
+		return BonusMedia{}, errors.New("特典の説明は1000文字以内で入力してください")
+	}
+	return BonusMedia{mediaType: mediaType, title: title, description: description}, nil
+}
+
+// ReconstructBonusMedia は永続化層から特典を再構築する（バリデーションなし）
+func ReconstructBonusMedia(mediaType BonusMediaType, title string, description *string) BonusMedia {
+	return BonusMedia{mediaType: mediaType, title: title, description: description}
+}
+
+func (b BonusMedia) MediaType() BonusMediaType { return b.mediaType }
+func (b BonusMedia) Title() string             { return b.title }
+func (b BonusMedia) Description() *string      { return b.description }
+
+// EditionTrack はエディションの収録曲（値オブジェクト）。
+// リリース共通の収録曲をトラック番号で参照するか、エディション固有の楽曲を持つ
+type EditionTrack struct {
+	discNumber        int
+	trackNumber       int
+	sharedTrackNumber *int
+	track             *Track
+}
+
+// NewSharedEditionTrack はリリース共通の収録曲を参照するエディション収録曲を生成する
+func NewSharedEditionTrack(discNumber, trackNumber, sharedTrackNumber int) (EditionTrack, error) {
+	if err := validateEditionTrackPosition(discNumber, trackNumber); err != nil {
+		return EditionTrack{}, err
+	}
+	if sharedTrackNumber < 1 {
+		return EditionTrack{}, fmt.Errorf("参照するトラック番号は1以上である必要があります: %d", sharedTrackNumber)
+	}
+	return EditionTrack{discNumber: discNumber, trackNumber: trackNumber, sharedTrackNumber: &sharedTrackNumber}, nil
+}
+
+// NewExclusiveEditionTrack はエディション固有の楽曲を生成する。曲順は楽曲のトラック番号を使う
+func NewExclusiveEditionTrack(discNumber int, track Track) (EditionTrack, error) {
+	if err := validateEditionTrackPosition(discNumber, track.TrackNumber()); err != nil {
+		return EditionTrack{}, err
+	}
+	return EditionTrack{discNumber: discNumber, trackNumber: track.TrackNumber(), track: &track}, nil
+}
+
+func validateEditionTrackPosition(discNumber, trackNumber int) error {
+	if discNumber < 1 {
+		return fmt.Errorf("ディスク番号は1以上である必要があります: %d", discNumber)
+	}
+	if trackNumber < 1 {
+		return fmt.Errorf("トラック番号は1以上である必要があります: %d", trackNumber)
+	}
+	return nil
+}
+
+func (t EditionTrack) DiscNumber() int         { return t.discNumber }
+func (t EditionTrack) TrackNumber() int        { return t.trackNumber }
+func (t EditionTrack) SharedTrackNumber() *int { return t.sharedTrackNumber }
+func (t EditionTrack) Track() *Track           { return t.track }
+
+// IsShared はリリース共通の収録曲を参照しているかを返す
+func (t EditionTrack) IsShared() bool { return t.sharedTrackNumber != nil }
+
+// Edition はリリースのエディション（初回限定盤A・通常盤・劇場盤など）。
+// JANコード・価格・収録曲・特典は盤ごとに異なる
+type Edition struct {
+	id          string
+	name        string
+	editionType EditionType
+	janCode     *string
+	upc         *string
+	price       *int // 税込価格（円）
+	tracks      []EditionTrack
+	bonusMedia  []BonusMedia
+}
+
+// NewEdition はエディションを生成する
+func NewEdition(id, name string, editionType EditionType, janCode, upc *string, price *int, tracks []EditionTrack, bonusMedia []BonusMedia) (Edition, error) {
+	if id == "" {
+		return Edition{}, errors.New("エディションIDは必須です")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Edition{}, errors.New("エディション名は必須です")
+	}
+	if len([]rune(name)) > 100 {
+		return Edition{}, errors.New("エディション名は100文字以内で入力してください")
+	}
+	if !editionType.IsValid() {
+		return Edition{}, fmt.Errorf("無効なエディション種別です: %s", editionType)
+	}
+	if janCode != nil && !IsValidJANCode(*janCode) {
+		return Edition{}, fmt.Errorf("JANコードの形式が不正です（8桁または13桁、チェックディジット込み）: %s", *janCode)
+	}
+	if upc != nil && !IsValidUPC(*upc) {
+		return Edition{}, fmt.Errorf("UPCの形式が不正です（12桁、チェックディジット込み）: %s", *upc)
+	}
+	if price != nil && *price < 0 {
+		return Edition{}, errors.New("価格は0以上である必要があります")
+	}
+	seen := make(map[[2]int]struct{}, len(tracks))
+	for _, t := range tracks {
+		key := [2]int{t.discNumber, t.trackNumber}
+		if _, exists := seen[key]; exists {
+			return Edition{}, fmt.Errorf("エディション内でトラック番号が重複しています: Disc%d-%d", t.discNumber, t.trackNumber)
+		}
+		seen[key] = struct{}{}
+	}
+	if len(bonusMedia) > maxBonusMedia {
+		return Edition{}, fmt.Errorf("特典は%d件までです", maxBonusMedia)
+	}
+	return Edition{
+		id:          id,
+		name:        name,
+		editionType: editionType,
+		janCode:     janCode,
+		upc:         upc,
+		price:       price,
+		tracks:      tracks,
+		bonusMedia:  bonusMedia,
+	}, nil
+}
+
+// ReconstructEdition は永続化層からエディションを再構築する（バリデーションなし）
+func ReconstructEdition(id, name string, editionType EditionType, janCode, upc *string, price *int, tracks []EditionTrack, bonusMedia []BonusMedia) Edition {
+	return Edition{
+		id:          id,
+		name:        name,
+		editionType: editionType,
+		janCode:     janCode,
+		upc:         upc,
+		price:       price,
+		tracks:      tracks,
+		bonusMedia:  bonusMedia,
+	}
+}
+
+// ReconstructEditionTrack は永続化層からエディション収録曲を再構築する（バリデーションなし）
+func ReconstructEditionTrack(discNumber, trackNumber int, sharedTrackNumber *int, track *Track) EditionTrack {
+	return EditionTrack{discNumber: discNumber, trackNumber: trackNumber, sharedTrackNumber: sharedTrackNumber, track: track}
+}
+
+func (e Edition) ID() string               { return e.id }
+func (e Edition) Name() string             { return e.name }
+func (e Edition) EditionType() EditionType { return e.editionType }
+func (e Edition) JANCode() *string         { return e.janCode }
+func (e Edition) UPC() *string             { return e.upc }
+func (e Edition) Price() *int              { return e.price }
+
+func (e Edition) Tracks() []EditionTrack {
+	if e.tracks == nil {
+		return []EditionTrack{}
+	}
+	return e.tracks
+}
+
+func (e Edition) BonusMedia() []BonusMedia {
+	if e.bonusMedia == nil {
+		return []BonusMedia{}
+	}
+	return e.bonusMedia
+}
+
+// Editions は登録済みのエディションを返す
+func (r *Release) Editions() []Edition {
+	if r.editions == nil {
+		return []Edition{}
+	}
+	return r.editions
+}
+
+// Edition はIDでエディションを取得する
+func (r *Release) Edition(id string) (Edition, bool) {
+	for _, e := range r.editions {
+		if e.id == id {
+			return e, true
+		}
+	}
+	return Edition{}, false
+}
+
+// EditionByJANCode はJANコードでエディションを取得する
+func (r *Release) EditionByJANCode(janCode string) (Edition, bool) {
+	for _, e := range r.editions {
+		if e.janCode != nil && *e.janCode == janCode {
+			return e, true
+		}
+	}
+	return Edition{}, false
+}
+
+// SetEditions はエディションを置き換える。
+// ID・名前・JANコード・UPCはリリース内で一意にし、共通収録曲の参照先は登録済みのトラック番号に限る
+func (r *Release) SetEditions(editions []Edition) error {
+	if len(editions) > maxEditions {
+		return fmt.Errorf("エディションは%d件までです", maxEditions)
+	}
+	trackNumbers := make(map[int]struct{}, len(r.tracks))
+	for _, t := range r.tracks {
+		trackNumbers[t.TrackNumber()] = struct{}{}
+	}
+	ids := make(map[string]struct{}, len(editions))
+	names := make(map[string]struct{}, len(editions))
+	codes := make(map[string]struct{}, len(editions))
+	for _, e := range editions {
+		if _, ok := ids[e.id]; ok {
+			return fmt.Errorf("エディションIDが重複しています: %s", e.id)
+		}
+		if _, ok := names[e.name]; ok {
+			return fmt.Errorf("エディション名が重複しています: %s", e.name)
+		}
+		for _, code := range []*string{e.janCode, e.upc} {
+			if code == nil {
+				continue
+			}
+			if _, ok := codes[*code]; ok {
+				return fmt.Errorf("JANコード・UPCが重複しています: %s", *code)
+			}
+			codes[*code] = struct{}{}
+		}
+		for _, t := range e.tracks {
+			if t.sharedTrackNumber == nil {
+				continue
+			}
+			if _, ok := trackNumbers[*t.sharedTrackNumber]; !ok {
+				return fmt.Errorf("エディション '%s' の参照トラック番号が無効です（リリースに未登録）: %d", e.name, *t.sharedTrackNumber)
+			}
+		}
+		ids[e.id] = struct{}{}
+		names[e.name] = struct{}{}
+	}
+	r.editions = editions
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// EditionTrackOf はエディション収録曲の楽曲を返す。共通収録曲の参照はリリースの収録曲に解決する
+func (r *Release) EditionTrackOf(t EditionTrack) (Track, bool) {
+	if t.track != nil {
+		return *t.track, true
+	}
+	if t.sharedTrackNumber == nil {
+		return Track{}, false
+	}
+	for _, track := range r.tracks {
+		if track.TrackNumber() == *t.sharedTrackNumber {
+			return track, true
+		}
+	}
+	return Track{}, false
+}
+
+// referencedTrackNumbers はエディションが参照している共通収録曲のトラック番号を返す
+func (r *Release) referencedTrackNumbers() map[int]string {
+	refs := make(map[int]string)
+	for _, e := range r.editions {
+		for _, t := range e.tracks {
+			if t.sharedTrackNumber != nil {
+				refs[*t.sharedTrackNumber] = e.name
+			}
+		}
+	}
+	return refs
+}
+
+// IsValidJANCode はJANコード（GTIN-13 / GTIN-8）の桁数とチェックディジットを検証する
+func IsValidJANCode(code string) bool {
+	return (len(code) == 13 || len(code) == 8) && validGTIN(code)
+}
+
+// IsValidUPC はUPC（GTIN-12）の桁数とチェックディジットを検証する
+func IsValidUPC(code string) bool {
+	return len(code) == 12 && validGTIN(code)
+}
+
+// validGTIN は数字列の末尾がGTINのチェックディジット（モジュラス10 ウェイト3-1）と一致するかを返す
+func validGTIN(code string) bool {
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		c := code[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		// チェックディジットの直前から左へ 3, 1, 3, 1... の重みを掛ける
+		if (len(code)-2-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	last := code[len(code)-1]
+	if last < '0' || last > '9' {
+		return false
+	}
+	return (10-sum%10)%10 == int(last-'0')
+}
@@ -5,21 +5,18 @@ import (
 	"regexp"
 )
 
-// ReleaseExternalIDKind はリリースの外部ID種別
+// ReleaseExternalIDKind はリリースの外部ID種別。
+// JANコード・UPCは盤ごとに異なるため、リリースではなくエディション（Edition）に持たせる
 type ReleaseExternalIDKind string
 
 const (
 	ReleaseExternalIDSpotifyAlbum    ReleaseExternalIDKind = "spotify_album_id"
 	ReleaseExternalIDAppleMusicAlbum ReleaseExternalIDKind = "apple_music_album_id"
-	ReleaseExternalIDUPC             ReleaseExternalIDKind = "upc"
-	ReleaseExternalIDJANCode         ReleaseExternalIDKind = "jan_code"
 )
 
 var validReleaseExternalIDKinds = map[ReleaseExternalIDKind]struct{}{
 	ReleaseExternalIDSpotifyAlbum:    {},
 	ReleaseExternalIDAppleMusicAlbum: {},
-	ReleaseExternalIDUPC:             {},
-	ReleaseExternalIDJANCode:         {},
 }
 
 var validReleaseIDPattern = regexp.MustCompile(`^[a-zA-Z0-9._\-@]{1,256}$`)
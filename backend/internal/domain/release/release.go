@@ -14,6 +14,7 @@ type Release struct {
 	releaseDate    ReleaseDate
 	artists        []ArtistRef
 	tracks         []Track
+	editions       []Edition
 	streamingLinks *StreamingLinks
 	externalIDs    *ReleaseExternalIDs
 	coverImageURL  *string
@@ -55,6 +56,7 @@ func Reconstruct(
 	releaseDate ReleaseDate,
 	artists []ArtistRef,
 	tracks []Track,
+	editions []Edition,
 	streamingLinks *StreamingLinks,
 	externalIDs *ReleaseExternalIDs,
 	coverImageURL *string,
@@ -70,6 +72,7 @@ func Reconstruct(
 		releaseDate:    releaseDate,
 		artists:        artists,
 		tracks:         tracks,
+		editions:       editions,
 		streamingLinks: streamingLinks,
 		externalIDs:    externalIDs,
 		coverImageURL:  coverImageURL,
@@ -151,7 +154,8 @@ func (r *Release) SetArtists(artists []ArtistRef) error {
 	return nil
 }
 
-// SetTracks は収録曲リストを設定する（trackNumber の一意性を検証）
+// SetTracks は収録曲リストを設定する（trackNumber の一意性を検証）。
+// エディションが参照しているトラック番号は削除できない。曲名の誤記修正などトラックの内容は変更できる
 func (r *Release) SetTracks(tracks []Track) error {
 	seen := make(map[int]struct{}, len(tracks))
 	for _, t := range tracks {
@@ -160,6 +164,11 @@ func (r *Release) SetTracks(tracks []Track) error {
 		}
 		seen[t.TrackNumber()] = struct{}{}
 	}
+	for number, edition := range r.referencedTrackNumbers() {
+		if _, ok := seen[number]; !ok {
+			return fmt.Errorf("トラック番号 %d は既にエディション '%s' から参照されているため削除できません", number, edition)
+		}
+	}
 	r.tracks = tracks
 	r.updatedAt = time.Now()
 	return nil
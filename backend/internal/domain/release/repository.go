@@ -13,4 +13,6 @@ type Repository interface {
 	Count(ctx context.Context, criteria SearchCriteria) (int64, error)
 	FindByExternalID(ctx context.Context, kind ReleaseExternalIDKind, value string) (*Release, error)
 	FindByArtistID(ctx context.Context, artistID string) ([]*Release, error)
+	FindByEditionJANCode(ctx context.Context, janCode string) (*Release, error)
+	FindByEditionUPC(ctx context.Context, upc string) (*Release, error)
 }
@@ -0,0 +1,237 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
+)
+
+// MatchKind は一致の種類。ランキングは exact > prefix > alias > fuzzy の順に並ぶ
+type MatchKind string
+
+const (
+	MatchExact  MatchKind = "exact"  // 正式名（読み・ローマ字表記を含む）の完全一致
+	MatchPrefix MatchKind = "prefix" // 正式名の前方一致
+	MatchAlias  MatchKind = "alias"  // 別名・旧名の一致
+	MatchFuzzy  MatchKind = "fuzzy"  // 部分一致・誤字を許容した類似一致
+)
+
+// 一致の種類ごとのスコア帯。帯同士は重ならないため種類の優先順位が常に保たれる
+const (
+	exactScore  = 1000.0
+	prefixScore = 800.0
+	aliasScore  = 600.0
+	fuzzyScore  = 400.0
+)
+
+const (
+	// MinFuzzySimilarity は類似一致として採用する最低類似度
+	MinFuzzySimilarity = 0.6
+	// minFuzzyQueryLength は類似一致を行う検索キーの最小文字数（短すぎる語は誤検出が多いため）
+	minFuzzyQueryLength = 3
+)
+
+// Range は一致箇所（ルーン単位のオフセットと長さ）
+type Range struct {
+	Start  int
+	Length int
+}
+
+// Highlight は一致したフィールドと一致箇所
+type Highlight struct {
+	Field  string
+	Text   string
+	Ranges []Range
+}
+
+// Hit はランキング済みの検索結果1件
+type Hit struct {
+	Candidate Candidate
+	Kind      MatchKind
+	Score     float64
+	Highlight Highlight
+}
+
+// matchLevel はフィールド単位の文字列一致の強さ
+type matchLevel int
+
+const (
+	levelNone matchLevel = iota
+	levelPartial
+	levelPrefix
+	levelExact
+)
+
+// Rank は候補を検索語との一致度でスコアリングし、スコアの高い順に返す。
+// 一致しない候補（類似度がしきい値未満を含む）は除外する
+func Rank(query string, candidates []Candidate) []Hit {
+	keys := searchkey.QueryKeys(query)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(candidates))
+	hits := make([]Hit, 0, len(candidates))
+	for _, c := range candidates {
+		dedupKey := string(c.Type) + ":" + c.ID
+		if _, ok := seen[dedupKey]; ok {
+			continue
+		}
+		seen[dedupKey] = struct{}{}
+		if hit, ok := scoreCandidate(keys, c); ok {
+			hits = append(hits, hit)
+		}
+	}
+
+	order := make(map[EntityType]int)
+	for i, t := range AllEntityTypes() {
+		order[t] = i
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Candidate.Type != hits[j].Candidate.Type {
+			return order[hits[i].Candidate.Type] < order[hits[j].Candidate.Type]
+		}
+		return hits[i].Candidate.Name < hits[j].Candidate.Name
+	})
+	return hits
+}
+
+// CountByType は種別ごとのヒット件数（ファセット）を返す
+func CountByType(hits []Hit) map[EntityType]int {
+	facets := make(map[EntityType]int)
+	for _, h := range hits {
+		facets[h.Candidate.Type]++
+	}
+	return facets
+}
+
+// scoreCandidate は候補の全フィールドを照合し、最もスコアの高い一致を返す
+func scoreCandidate(keys []string, c Candidate) (Hit, bool) {
+	best := Hit{Candidate: c}
+	found := false
+	for _, field := range c.Fields {
+		kind, score, ranges, ok := scoreField(keys, field)
+		if !ok || (found && score <= best.Score) {
+			continue
+		}
+		best.Kind = kind
+		best.Score = score
+		best.Highlight = Highlight{Field: field.Name, Text: field.Value, Ranges: ranges}
+		found = true
+	}
+	return best, found
+}
+
+// scoreField は1フィールドの一致の種類・スコア・一致箇所を返す。
+// 検索キー（正規化・ローマ字）をフィールドの正規化表記とローマ字表記の双方と照合する
+func scoreField(keys []string, field Field) (MatchKind, float64, []Range, bool) {
+	normalized := searchkey.Normalize(field.Value)
+	if normalized == "" {
+		return "", 0, nil, false
+	}
+	targets := []string{normalized, searchkey.Romanize(normalized)}
+
+	level := levelNone
+	var ratio float64
+	var ranges []Range
+	for _, key := range keys {
+		for i, target := range targets {
+			l := compare(key, target)
+			if l <= level {
+				continue
+			}
+			level = l
+			ratio = float64(utf8.RuneCountInString(key)) / float64(utf8.RuneCountInString(target))
+			ranges = highlightRanges(field.Value, key, l, i == 0)
+		}
+	}
+
+	if level != levelNone {
+		switch {
+		case field.Alias && level == levelExact:
+			return MatchAlias, aliasScore + 190, ranges, true
+		case field.Alias && level == levelPrefix:
+			return MatchAlias, aliasScore + 100 + 50*ratio, ranges, true
+		case field.Alias:
+			return MatchAlias, aliasScore + 50*ratio, ranges, true
+		case level == levelExact:
+			return MatchExact, exactScore, ranges, true
+		case level == levelPrefix:
+			return MatchPrefix, prefixScore + 100*ratio, ranges, true
+		default:
+			// 正式名の途中に含まれる一致は類似一致の最上位として扱う
+			return MatchFuzzy, fuzzyScore * (0.5 + 0.5*ratio), ranges, true
+		}
+	}
+
+	sim := 0.0
+	for _, key := range keys {
+		if utf8.RuneCountInString(key) < minFuzzyQueryLength {
+			continue
+		}
+		for _, target := range targets {
+			if s := similarity(key, target); s > sim {
+				sim = s
+			}
+		}
+	}
+	if sim < MinFuzzySimilarity {
+		return "", 0, nil, false
+	}
+	// 別名の類似一致は正式名の類似一致よりわずかに低く扱う
+	if field.Alias {
+		sim *= 0.95
+	}
+	return MatchFuzzy, fuzzyScore * sim * 0.5, nil, true
+}
+
+func compare(query, target string) matchLevel {
+	switch {
+	case query == target:
+		return levelExact
+	case strings.HasPrefix(target, query):
+		return levelPrefix
+	case strings.Contains(target, query):
+		return levelPartial
+	}
+	return levelNone
+}
+
+// highlightRanges は一致箇所を元の表記上の範囲に変換する。
+// ローマ字表記で一致した場合は元の表記との文字の対応が取れないため、完全一致のみ全体を一致箇所とする
+func highlightRanges(text, key string, level matchLevel, onNormalized bool) []Range {
+	if level == levelExact {
+		return []Range{{Start: 0, Length: utf8.RuneCountInString(text)}}
+	}
+	if !onNormalized {
+		return nil
+	}
+	return locate(text, key)
+}
+
+// locate は正規化キーの一致位置を元の表記のルーン位置へ対応付ける
+func locate(text, key string) []Range {
+	var b strings.Builder
+	owners := make([]int, 0, len(text))
+	index := 0
+	for _, r := range text {
+		piece := searchkey.Normalize(string(r))
+		for i := 0; i < len(piece); i++ {
+			owners = append(owners, index)
+		}
+		b.WriteString(piece)
+		index++
+	}
+	pos := strings.Index(b.String(), key)
+	if pos < 0 || key == "" {
+		return nil
+	}
+	start := owners[pos]
+	end := owners[pos+len(key)-1]
+	return []Range{{Start: start, Length: end - start + 1}}
+}
@@ -0,0 +1,112 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func candidate(t EntityType, id, name string, fields ...Field) Candidate {
+	return Candidate{Type: t, ID: id, Name: name, Fields: append([]Field{{Name: "name", Value: name}}, fields...)}
+}
+
+func TestRank_OrdersExactPrefixAliasFuzzy(t *testing.T) {
+	candidates := []Candidate{
+		candidate(TypeGroup, "fuzzy", "はるさくら"),
+		candidate(TypeIdol, "alias", "山田花子", Field{Name: "aliases", Value: "さくら", Alias: true}),
+		candidate(TypeGroup, "prefix", "さくら学院"),
+		candidate(TypeIdol, "exact", "サクラ"),
+	}
+
+	hits := Rank("さくら", candidates)
+
+	require.Len(t, hits, 4)
+	assert.Equal(t, "exact", hits[0].Candidate.ID)
+	assert.Equal(t, MatchExact, hits[0].Kind)
+	assert.Equal(t, "prefix", hits[1].Candidate.ID)
+	assert.Equal(t, MatchPrefix, hits[1].Kind)
+	assert.Equal(t, "alias", hits[2].Candidate.ID)
+	assert.Equal(t, MatchAlias, hits[2].Kind)
+	assert.Equal(t, "fuzzy", hits[3].Candidate.ID)
+}
+
+func TestRank_MatchesReadingAndRomaji(t *testing.T) {
+	candidates := []Candidate{
+		candidate(TypeIdol, "1", "桜井 日奈子", Field{Name: "name_kana", Value: "さくらい ひなこ"}),
+	}
+
+	hits := Rank("Sakurai", candidates)
+
+	require.Len(t, hits, 1)
+	assert.Equal(t, MatchPrefix, hits[0].Kind)
+	assert.Equal(t, "name_kana", hits[0].Highlight.Field)
+}
+
+func TestRank_ToleratesTypos(t *testing.T) {
+	candidates := []Candidate{
+		candidate(TypeGroup, "1", "nogizaka46"),
+		candidate(TypeGroup, "2", "keyakizaka46"),
+		candidate(TypeVenue, "3", "日本武道館"),
+	}
+
+	hits := Rank("nogizka", candidates)
+
+	require.NotEmpty(t, hits)
+	assert.Equal(t, "1", hits[0].Candidate.ID)
+	assert.Equal(t, MatchFuzzy, hits[0].Kind)
+	for _, h := range hits {
+		assert.NotEqual(t, "3", h.Candidate.ID)
+	}
+}
+
+func TestRank_HighlightsOriginalText(t *testing.T) {
+	candidates := []Candidate{candidate(TypeGroup, "1", "ＡＫＢ４８チーム")}
+
+	hits := Rank("akb48", candidates)
+
+	require.Len(t, hits, 1)
+	assert.Equal(t, []Range{{Start: 0, Length: 5}}, hits[0].Highlight.Ranges)
+}
+
+func TestRank_DeduplicatesCandidates(t *testing.T) {
+	c := candidate(TypeIdol, "1", "さくら")
+
+	hits := Rank("さくら", []Candidate{c, c})
+
+	assert.Len(t, hits, 1)
+}
+
+func TestCountByType(t *testing.T) {
+	hits := Rank("さくら", []Candidate{
+		candidate(TypeIdol, "1", "さくら"),
+		candidate(TypeIdol, "2", "さくらこ"),
+		candidate(TypeRelease, "3", "さくらの歌"),
+	})
+
+	facets := CountByType(hits)
+
+	assert.Equal(t, 2, facets[TypeIdol])
+	assert.Equal(t, 1, facets[TypeRelease])
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, similarity("abc", "abc"), 0.001)
+	assert.Greater(t, similarity("nogizka", "nogizaka46"), MinFuzzySimilarity)
+	assert.Less(t, similarity("xyz", "nogizaka"), MinFuzzySimilarity)
+}
+
+func TestRankSuggestions_SkipsFuzzyAndKeepsHints(t *testing.T) {
+	suggestions := []Suggestion{
+		{Candidate: candidate(TypeGroup, "fuzzy", "はるさくら")},
+		{Candidate: candidate(TypeIdol, "prefix", "さくらこ"), Hint: Hint{BirthYear: 2001}},
+		{Candidate: candidate(TypeIdol, "exact", "さくら")},
+	}
+
+	result := RankSuggestions("さくら", suggestions, 5)
+
+	require.Len(t, result, 2)
+	assert.Equal(t, "exact", result[0].ID)
+	assert.Equal(t, "prefix", result[1].ID)
+	assert.Equal(t, 2001, result[1].Hint.BirthYear)
+}
@@ -0,0 +1,66 @@
+// Package search はアイドル・グループ・事務所・リリース・イベント・会場を横断する名前検索のドメインモデル
+package search
+
+import (
+	"context"
+	"fmt"
+)
+
+// EntityType は検索対象の集約種別
+type EntityType string
+
+const (
+	TypeIdol    EntityType = "idol"
+	TypeGroup   EntityType = "group"
+	TypeAgency  EntityType = "agency"
+	TypeRelease EntityType = "release"
+	TypeEvent   EntityType = "event"
+	TypeVenue   EntityType = "venue"
+)
+
+// AllEntityTypes は検索対象の全種別を表示順で返す
+func AllEntityTypes() []EntityType {
+	return []EntityType{TypeIdol, TypeGroup, TypeAgency, TypeRelease, TypeEvent, TypeVenue}
+}
+
+// ParseEntityType は文字列から検索対象種別を生成する
+func ParseEntityType(value string) (EntityType, error) {
+	for _, t := range AllEntityTypes() {
+		if string(t) == value {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("無効な検索対象タイプです: %s", value)
+}
+
+// Field は照合対象となる名前フィールド
+type Field struct {
+	Name  string // フィールド名（name, name_kana, aliases 等）
+	Value string
+	Alias bool // 別名・旧名など正式名以外の表記
+}
+
+// Candidate はリポジトリが返す検索候補
+type Candidate struct {
+	Type   EntityType
+	ID     string
+	Name   string // 表示名
+	Fields []Field
+}
+
+// Criteria は候補取得の条件
+type Criteria struct {
+	Query string
+	Types []EntityType
+	Limit int // 種別ごとの候補取得上限
+}
+
+// Repository は横断検索の候補取得リポジトリ
+type Repository interface {
+	// FindCandidates は検索語に一致しうる候補を種別ごとに取得する。
+	// 完全一致・前方一致・部分一致に加え、表記ゆれ・誤字を拾うための n-gram 一致の候補も含む
+	FindCandidates(ctx context.Context, criteria Criteria) ([]Candidate, error)
+	// CountMatches は完全一致・前方一致・部分一致・別名で一致する件数を種別ごとに数える。
+	// 候補取得の上限件数に左右されないが、類似一致のみの候補は含まない
+	CountMatches(ctx context.Context, criteria Criteria) (map[EntityType]int, error)
+}
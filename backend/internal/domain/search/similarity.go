@@ -0,0 +1,105 @@
+package search
+
+// similarity は正規化済みキー同士の類似度を 0〜1 で返す。
+// 編集距離（全体および入力途中を想定した先頭部分）と bigram の Dice 係数のうち最も高い値を採用する。
+func similarity(query, target string) float64 {
+	q := []rune(query)
+	t := []rune(target)
+	if len(q) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	best := editSimilarity(q, t)
+	// 入力途中の検索語は先頭部分と比較する（"nogizk" → "nogizaka46"）
+	for n := len(q) - 1; n <= len(q)+1; n++ {
+		if n > 0 && n < len(t) {
+			if s := editSimilarity(q, t[:n]); s > best {
+				best = s
+			}
+		}
+	}
+	if s := diceCoefficient(q, t); s > best {
+		best = s
+	}
+	return best
+}
+
+// editSimilarity はレーベンシュタイン距離を長い方の長さで正規化した類似度を返す
+func editSimilarity(a, b []rune) float64 {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// levenshtein は2つのルーン列の編集距離を返す
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// diceCoefficient は bigram 集合の Dice 係数を返す
+func diceCoefficient(a, b []rune) float64 {
+	ga := bigrams(a)
+	gb := bigrams(b)
+	if len(ga) == 0 || len(gb) == 0 {
+		return 0
+	}
+	counts := make(map[string]int, len(ga))
+	for _, g := range ga {
+		counts[g]++
+	}
+	common := 0
+	for _, g := range gb {
+		if counts[g] > 0 {
+			counts[g]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(ga)+len(gb))
+}
+
+// bigrams は連続する2文字の組を返す
+func bigrams(r []rune) []string {
+	if len(r) < 2 {
+		return nil
+	}
+	grams := make([]string, 0, len(r)-1)
+	for i := 0; i+1 < len(r); i++ {
+		grams = append(grams, string(r[i:i+2]))
+	}
+	return grams
+}
+
+// Bigrams は候補取得用に検索キーの bigram を重複なく返す
+func Bigrams(key string) []string {
+	seen := make(map[string]struct{})
+	var result []string
+	for _, g := range bigrams([]rune(key)) {
+		if _, ok := seen[g]; ok {
+			continue
+		}
+		seen[g] = struct{}{}
+		result = append(result, g)
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"context"
+	"time"
+)
+
+// Hint は同名・似た名前の候補を見分けるための補足情報
+type Hint struct {
+	AgencyName    string     // 所属事務所名（アイドル・グループ）
+	BirthYear     int        // 生年（アイドル）
+	FormationYear int        // 結成年（グループ）
+	ReleaseYear   int        // 発売年（リリース）
+	EventDate     *time.Time // 開催日時（イベント）
+	Prefecture    string     // 都道府県（会場）
+}
+
+// Suggestion は入力補完の候補
+type Suggestion struct {
+	Candidate
+	Kana *string
+	Hint Hint
+}
+
+// SuggestCriteria は入力補完候補の取得条件
+type SuggestCriteria struct {
+	Query string
+	Types []EntityType
+	Limit int // 種別ごとの候補取得上限
+}
+
+// SuggestRepository は入力補完候補の取得リポジトリ
+type SuggestRepository interface {
+	// FindByPrefix は名前・読み・別名の正規化キーが検索語で始まる候補を取得する
+	FindByPrefix(ctx context.Context, criteria SuggestCriteria) ([]Suggestion, error)
+}
+
+// RankSuggestions は入力補完候補を一致度順に並べ、上位 limit 件を返す。
+// 前方一致の候補取得を前提とするため、類似一致のみの候補は除外する
+func RankSuggestions(query string, suggestions []Suggestion, limit int) []Suggestion {
+	byKey := make(map[string]Suggestion, len(suggestions))
+	candidates := make([]Candidate, 0, len(suggestions))
+	for _, s := range suggestions {
+		byKey[string(s.Type)+":"+s.ID] = s
+		candidates = append(candidates, s.Candidate)
+	}
+
+	result := make([]Suggestion, 0, limit)
+	for _, hit := range Rank(query, candidates) {
+		if len(result) >= limit {
+			break
+		}
+		if hit.Kind == MatchFuzzy {
+			continue
+		}
+		result = append(result, byKey[string(hit.Candidate.Type)+":"+hit.Candidate.ID])
+	}
+	return result
+}
@@ -0,0 +1,21 @@
+package tour
+
+import "context"
+
+// SearchCriteria はツアー検索の条件
+type SearchCriteria struct {
+	Title       *string
+	PerformerID *string
+	Offset      int
+	Limit       int
+}
+
+// Repository はツアーの永続化操作を定義するインターフェース
+type Repository interface {
+	Save(ctx context.Context, t *Tour) error
+	FindByID(ctx context.Context, id TourID) (*Tour, error)
+	Search(ctx context.Context, criteria SearchCriteria) ([]*Tour, error)
+	Count(ctx context.Context, criteria SearchCriteria) (int64, error)
+	Update(ctx context.Context, t *Tour) error
+	Delete(ctx context.Context, id TourID) error
+}
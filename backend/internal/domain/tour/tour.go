@@ -0,0 +1,148 @@
+package tour
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/event"
+)
+
+// Tour は複数の公演日程（イベント）をまとめるツアー（イベントシリーズ）集約。
+// 出演者とタグは配下のイベントに引き継がれる
+type Tour struct {
+	id          TourID
+	title       string
+	eventType   event.EventType
+	performers  []event.Performer
+	tags        []string
+	officialURL *string
+	description *string
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewTour は新しいツアーを作成する
+func NewTour(title string, eventType event.EventType) (*Tour, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, errors.New("ツアー名は必須です")
+	}
+	now := time.Now()
+	return &Tour{
+		title:      title,
+		eventType:  eventType,
+		performers: []event.Performer{},
+		tags:       []string{},
+		createdAt:  now,
+		updatedAt:  now,
+	}, nil
+}
+
+// Reconstruct は永続化層からツアーを再構築する
+func Reconstruct(
+	id TourID,
+	title string,
+	eventType event.EventType,
+	performers []event.Performer,
+	tags []string,
+	officialURL *string,
+	description *string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Tour {
+	if performers == nil {
+		performers = []event.Performer{}
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return &Tour{
+		id:          id,
+		title:       title,
+		eventType:   eventType,
+		performers:  performers,
+		tags:        tags,
+		officialURL: officialURL,
+		description: description,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+func (t *Tour) ID() TourID                    { return t.id }
+func (t *Tour) Title() string                 { return t.title }
+func (t *Tour) EventType() event.EventType    { return t.eventType }
+func (t *Tour) Performers() []event.Performer { return t.performers }
+func (t *Tour) Tags() []string                { return t.tags }
+func (t *Tour) OfficialURL() *string          { return t.officialURL }
+func (t *Tour) Description() *string          { return t.description }
+func (t *Tour) CreatedAt() time.Time          { return t.createdAt }
+func (t *Tour) UpdatedAt() time.Time          { return t.updatedAt }
+
+// SetID はIDを設定する（永続化時に使用）
+func (t *Tour) SetID(id TourID) {
+	t.id = id
+}
+
+// UpdateDetails はツアーの基本情報を更新する
+func (t *Tour) UpdateDetails(title *string, eventType *event.EventType, officialURL, description *string) error {
+	if title != nil {
+		v := strings.TrimSpace(*title)
+		if v == "" {
+			return errors.New("ツアー名は必須です")
+		}
+		t.title = v
+	}
+	if eventType != nil {
+		t.eventType = *eventType
+	}
+	if officialURL != nil {
+		t.officialURL = officialURL
+	}
+	if description != nil {
+		t.description = description
+	}
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// ReplacePerformers は出演者を入れ替え、配下のイベントに反映すべき差分を返す
+func (t *Tour) ReplacePerformers(performers []event.Performer) (removed []string, err error) {
+	seen := make(map[string]struct{}, len(performers))
+	for _, p := range performers {
+		if _, ok := seen[p.PerformerID]; ok {
+			return nil, errors.New("出演者が重複しています")
+		}
+		seen[p.PerformerID] = struct{}{}
+	}
+	for _, p := range t.performers {
+		if _, ok := seen[p.PerformerID]; !ok {
+			removed = append(removed, p.PerformerID)
+		}
+	}
+	t.performers = append([]event.Performer{}, performers...)
+	t.updatedAt = time.Now()
+	return removed, nil
+}
+
+// ReplaceTags はタグを入れ替え、外れたタグを返す
+func (t *Tour) ReplaceTags(tags []string) (removed []string) {
+	next := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if _, ok := seen[tag]; ok || tag == "" {
+			continue
+		}
+		seen[tag] = struct{}{}
+		next = append(next, tag)
+	}
+	for _, tag := range t.tags {
+		if _, ok := seen[tag]; !ok {
+			removed = append(removed, tag)
+		}
+	}
+	t.tags = next
+	t.updatedAt = time.Now()
+	return removed
+}
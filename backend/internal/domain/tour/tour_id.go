@@ -0,0 +1,23 @@
+package tour
+
+import "errors"
+
+// TourID はツアーの識別子を表す値オブジェクト
+type TourID struct {
+	value string
+}
+
+func NewTourID(value string) (TourID, error) {
+	if value == "" {
+		return TourID{}, errors.New("ツアーIDは空にできません")
+	}
+	return TourID{value: value}, nil
+}
+
+func (id TourID) Value() string {
+	return id.value
+}
+
+func (id TourID) Equals(other TourID) bool {
+	return id.value == other.value
+}
@@ -0,0 +1,62 @@
+package usage
+
+import "fmt"
+
+// OverageUsage はAPIキーの月次超過リクエスト数（従量課金の対象）
+type OverageUsage struct {
+	keyPrefix string
+	yearMonth string
+	count     int                   // 月間上限を超えて受け付けたリクエスト数
+	reported  int                   // Stripe への報告を確保済みのリクエスト数（送信中の範囲を含む）
+	pending   *PendingOverageReport // 確保済みで Stripe への送信が完了していない範囲
+}
+
+// PendingOverageReport は Stripe への送信が完了していない報告範囲
+// 送信前に永続化し、再送時も同じ識別子を使うことで Stripe 側の二重計上を防ぐ
+type PendingOverageReport struct {
+	From       int
+	To         int
+	Identifier string
+}
+
+// NewPendingOverageReport は from から to までの報告範囲を作成する
+// 識別子は範囲から決まるため、同じ範囲は常に同じ識別子で報告される
+func NewPendingOverageReport(keyPrefix, yearMonth string, from, to int) PendingOverageReport {
+	return PendingOverageReport{
+		From:       from,
+		To:         to,
+		Identifier: fmt.Sprintf("overage_%s_%s_%d_%d", keyPrefix, yearMonth, from, to),
+	}
+}
+
+// Quantity は報告範囲のリクエスト数を返す
+func (p PendingOverageReport) Quantity() int {
+	return p.To - p.From
+}
+
+// ReconstructOverage はDBから取得したデータで OverageUsage を再構築する
+func ReconstructOverage(keyPrefix, yearMonth string, count, reported int, pending *PendingOverageReport) *OverageUsage {
+	return &OverageUsage{
+		keyPrefix: keyPrefix,
+		yearMonth: yearMonth,
+		count:     count,
+		reported:  reported,
+		pending:   pending,
+	}
+}
+
+// Unreported は報告を確保していない超過リクエスト数を返す
+func (o *OverageUsage) Unreported() int {
+	if o.count <= o.reported {
+		return 0
+	}
+	return o.count - o.reported
+}
+
+// Getters
+
+func (o *OverageUsage) KeyPrefix() string              { return o.keyPrefix }
+func (o *OverageUsage) YearMonth() string              { return o.yearMonth }
+func (o *OverageUsage) Count() int                     { return o.count }
+func (o *OverageUsage) Reported() int                  { return o.reported }
+func (o *OverageUsage) Pending() *PendingOverageReport { return o.pending }
@@ -1,6 +1,9 @@
 package usage
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository は月次使用量リポジトリのインターフェース
 type Repository interface {
@@ -11,4 +14,41 @@ type Repository interface {
 	// Get は使用量を取得する（インクリメントなし）
 	// 存在しない場合は count=0 の MonthlyUsage を返す
 	Get(ctx context.Context, keyPrefix, yearMonth string, limit int) (*MonthlyUsage, error)
+
+	// ClaimThreshold はしきい値の通知済みフラグを原子的に立てる
+	// 今回の呼び出しで初めて立てた場合のみ true を返す（複数レプリカ間の重複通知防止）
+	ClaimThreshold(ctx context.Context, keyPrefix, yearMonth string, threshold int) (bool, error)
+
+	// IncrementOverage は月間上限を超えて受け付けたリクエスト数を1増やす
+	IncrementOverage(ctx context.Context, keyPrefix, yearMonth string) error
+
+	// GetOverage は超過リクエスト数と報告済み数を取得する
+	// 存在しない場合は0件の OverageUsage を返す
+	GetOverage(ctx context.Context, keyPrefix, yearMonth string) (*OverageUsage, error)
+
+	// ListUnreportedOverages は指定月に未報告の超過リクエスト、または送信未完了の報告範囲がある使用量を返す
+	ListUnreportedOverages(ctx context.Context, yearMonth string) ([]*OverageUsage, error)
+
+	// ClaimOverageReport は報告済み数を report.From から report.To へ進め、report を送信未完了として記録する
+	// 他で既に更新されていた場合や送信未完了の範囲が残っている場合は false を返す（複数レプリカ間の二重報告防止）
+	ClaimOverageReport(ctx context.Context, keyPrefix, yearMonth string, report PendingOverageReport) (bool, error)
+
+	// CompleteOverageReport は識別子が一致する送信未完了の報告範囲を送信完了として消す
+	CompleteOverageReport(ctx context.Context, keyPrefix, yearMonth, identifier string) error
+}
+
+// ThresholdEmailRepository は送信に失敗したしきい値到達メールの送信箱のインターフェース
+type ThresholdEmailRepository interface {
+	// Save は再送待ちのメールを保存する（同じ ID は上書き）
+	Save(ctx context.Context, email *ThresholdEmail) error
+
+	// ClaimDue は再送時刻を過ぎたメールを1件確保して返す。対象がなければ nil を返す
+	// 確保したメールは lease の間、他のレプリカから確保されないよう再送時刻を進める
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*ThresholdEmail, error)
+
+	// Update は再送結果（試行回数・次回再送時刻）を保存する
+	Update(ctx context.Context, email *ThresholdEmail) error
+
+	// Delete は送信できたメールを送信箱から消す
+	Delete(ctx context.Context, id string) error
 }
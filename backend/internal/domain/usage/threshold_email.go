@@ -0,0 +1,105 @@
+package usage
+
+import (
+	"fmt"
+	"time"
+)
+
+// thresholdEmailBackoff はしきい値到達メールの再送間隔（試行回数ごと）
+var thresholdEmailBackoff = []time.Duration{
+	1 * time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	6 * time.Hour,
+}
+
+// ThresholdAlert はしきい値到達メールの内容
+// Webhook 配信・アップグレードリンク生成はしきい値の確保時に1回だけ行うため、生成済みのリンクも含めて保存する
+type ThresholdAlert struct {
+	To         string
+	KeyName    string
+	MaskedKey  string
+	PlanType   string
+	Threshold  int // 到達したしきい値（%）
+	Count      int
+	Limit      int
+	YearMonth  string
+	UpgradeURL string // アップグレード用 URL（生成できない場合は空）
+	Overage    bool   // 上限超過分を従量課金で継続するキーか
+}
+
+// ThresholdEmail は送信に失敗し再送待ちになっているしきい値到達メール
+type ThresholdEmail struct {
+	id          string // "{key_prefix}_{year_month}_{threshold}"（同じしきい値は1件のみ）
+	keyPrefix   string
+	alert       ThresholdAlert
+	attempts    int
+	maxAttempts int
+	lastError   string
+	nextRetryAt *time.Time // nil は再送を打ち切ったことを表す
+	createdAt   time.Time
+}
+
+// NewThresholdEmail は初回送信に失敗したしきい値到達メールを作成し、次回の再送時刻を設定する
+func NewThresholdEmail(keyPrefix string, alert ThresholdAlert, errMsg string, now time.Time) *ThresholdEmail {
+	e := &ThresholdEmail{
+		id:          fmt.Sprintf("%s_%s_%d", keyPrefix, alert.YearMonth, alert.Threshold),
+		keyPrefix:   keyPrefix,
+		alert:       alert,
+		maxAttempts: len(thresholdEmailBackoff) + 1,
+		createdAt:   now,
+	}
+	e.MarkFailed(errMsg, now)
+	return e
+}
+
+// ReconstructThresholdEmail はDBから取得したデータで ThresholdEmail を再構築する
+func ReconstructThresholdEmail(
+	id, keyPrefix string,
+	alert ThresholdAlert,
+	attempts, maxAttempts int,
+	lastError string,
+	nextRetryAt *time.Time,
+	createdAt time.Time,
+) *ThresholdEmail {
+	return &ThresholdEmail{
+		id:          id,
+		keyPrefix:   keyPrefix,
+		alert:       alert,
+		attempts:    attempts,
+		maxAttempts: maxAttempts,
+		lastError:   lastError,
+		nextRetryAt: nextRetryAt,
+		createdAt:   createdAt,
+	}
+}
+
+// MarkFailed は送信失敗を記録し、次回の再送時刻を指数バックオフで設定する
+// 上限回数に達した場合は再送を打ち切る
+func (e *ThresholdEmail) MarkFailed(errMsg string, now time.Time) {
+	e.attempts++
+	e.lastError = errMsg
+	if e.attempts >= e.maxAttempts {
+		e.nextRetryAt = nil
+		return
+	}
+	next := now.Add(thresholdEmailBackoff[e.attempts-1])
+	e.nextRetryAt = &next
+}
+
+// CanRetry は再送対象かを返す
+func (e *ThresholdEmail) CanRetry() bool {
+	return e.nextRetryAt != nil
+}
+
+// Getters
+
+func (e *ThresholdEmail) ID() string              { return e.id }
+func (e *ThresholdEmail) KeyPrefix() string       { return e.keyPrefix }
+func (e *ThresholdEmail) Alert() ThresholdAlert   { return e.alert }
+func (e *ThresholdEmail) Attempts() int           { return e.attempts }
+func (e *ThresholdEmail) MaxAttempts() int        { return e.maxAttempts }
+func (e *ThresholdEmail) LastError() string       { return e.lastError }
+func (e *ThresholdEmail) NextRetryAt() *time.Time { return e.nextRetryAt }
+func (e *ThresholdEmail) CreatedAt() time.Time    { return e.createdAt }
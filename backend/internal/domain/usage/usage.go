@@ -3,13 +3,17 @@ package usage
 
 import "time"
 
+// AlertThresholds は月次使用量の通知しきい値（プラン上限に対する%）
+var AlertThresholds = []int{80, 95, 100}
+
 // MonthlyUsage はAPIキーの月次リクエスト使用量
 type MonthlyUsage struct {
-	keyPrefix string // APIキーのルックアップ用プレフィックス
-	yearMonth string // "YYYY-MM" 形式（例: "2026-04"）
-	count     int    // 当月のリクエスト数
-	limit     int    // プランの月間上限（0は無制限）
-	updatedAt time.Time
+	keyPrefix          string // APIキーのルックアップ用プレフィックス
+	yearMonth          string // "YYYY-MM" 形式（例: "2026-04"）
+	count              int    // 当月のリクエスト数
+	limit              int    // プランの月間上限（0は無制限）
+	notifiedThresholds []int  // 当月に通知済みのしきい値（%）
+	updatedAt          time.Time
 }
 
 // New は MonthlyUsage を新規作成する
@@ -24,13 +28,14 @@ func New(keyPrefix, yearMonth string, limit int) *MonthlyUsage {
 }
 
 // Reconstruct はDBから取得したデータで MonthlyUsage を再構築する
-func Reconstruct(keyPrefix, yearMonth string, count, limit int, updatedAt time.Time) *MonthlyUsage {
+func Reconstruct(keyPrefix, yearMonth string, count, limit int, notifiedThresholds []int, updatedAt time.Time) *MonthlyUsage {
 	return &MonthlyUsage{
-		keyPrefix: keyPrefix,
-		yearMonth: yearMonth,
-		count:     count,
-		limit:     limit,
-		updatedAt: updatedAt,
+		keyPrefix:          keyPrefix,
+		yearMonth:          yearMonth,
+		count:              count,
+		limit:              limit,
+		notifiedThresholds: notifiedThresholds,
+		updatedAt:          updatedAt,
 	}
 }
 
@@ -39,6 +44,12 @@ func YearMonthOf(t time.Time) string {
 	return t.UTC().Format("2006-01")
 }
 
+// MonthStartOf は t が属する月（UTC）の月初時刻を返す
+func MonthStartOf(t time.Time) time.Time {
+	u := t.UTC()
+	return time.Date(u.Year(), u.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
 // ExceedsLimit は使用量が上限に達しているかを返す
 // limit == 0 は無制限とみなし、常に false を返す
 func (u *MonthlyUsage) ExceedsLimit() bool {
@@ -48,10 +59,52 @@ func (u *MonthlyUsage) ExceedsLimit() bool {
 	return u.count >= u.limit
 }
 
+// Remaining は当月の残りリクエスト数を返す
+// limit == 0（無制限）の場合は -1 を返す
+func (u *MonthlyUsage) Remaining() int {
+	if u.limit == 0 {
+		return -1
+	}
+	if u.count >= u.limit {
+		return 0
+	}
+	return u.limit - u.count
+}
+
+// ReachedThreshold は使用量が上限の percent% 以上に達しているかを返す
+// limit == 0 は無制限とみなし、常に false を返す
+func (u *MonthlyUsage) ReachedThreshold(percent int) bool {
+	if u.limit == 0 {
+		return false
+	}
+	return u.count*100 >= u.limit*percent
+}
+
+// PendingThresholds は到達済みかつ未通知のしきい値を昇順で返す
+func (u *MonthlyUsage) PendingThresholds() []int {
+	var pending []int
+	for _, t := range AlertThresholds {
+		if u.ReachedThreshold(t) && !u.isNotified(t) {
+			pending = append(pending, t)
+		}
+	}
+	return pending
+}
+
+func (u *MonthlyUsage) isNotified(threshold int) bool {
+	for _, n := range u.notifiedThresholds {
+		if n == threshold {
+			return true
+		}
+	}
+	return false
+}
+
 // Getters
 
-func (u *MonthlyUsage) KeyPrefix() string    { return u.keyPrefix }
-func (u *MonthlyUsage) YearMonth() string    { return u.yearMonth }
-func (u *MonthlyUsage) Count() int           { return u.count }
-func (u *MonthlyUsage) Limit() int           { return u.limit }
-func (u *MonthlyUsage) UpdatedAt() time.Time { return u.updatedAt }
+func (u *MonthlyUsage) KeyPrefix() string         { return u.keyPrefix }
+func (u *MonthlyUsage) YearMonth() string         { return u.yearMonth }
+func (u *MonthlyUsage) Count() int                { return u.count }
+func (u *MonthlyUsage) Limit() int                { return u.limit }
+func (u *MonthlyUsage) NotifiedThresholds() []int { return u.notifiedThresholds }
+func (u *MonthlyUsage) UpdatedAt() time.Time      { return u.updatedAt }
@@ -0,0 +1,124 @@
+package venue
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxConfigurations は1会場に登録できる収容構成の上限
+const maxConfigurations = 20
+
+// Layout は収容構成の客席レイアウト
+type Layout string
+
+const (
+	LayoutStanding    Layout = "standing"     // オールスタンディング
+	LayoutSeated      Layout = "seated"       // 全席指定
+	LayoutCenterStage Layout = "center_stage" // センターステージ
+	LayoutOther       Layout = "other"
+)
+
+// IsValid はレイアウトが定義済みの値かを返す
+func (l Layout) IsValid() bool {
+	switch l {
+	case LayoutStanding, LayoutSeated, LayoutCenterStage, LayoutOther:
+		return true
+	}
+	return false
+}
+
+// CapacityConfiguration は会場の収容構成（値オブジェクト）。
+// アリーナのスタンディング・着席・センターステージなど、レイアウトごとの収容人数を名前付きで持つ
+type CapacityConfiguration struct {
+	id       string
+	name     string
+	layout   Layout
+	capacity int
+}
+
+// NewCapacityConfiguration は収容構成を生成する
+func NewCapacityConfiguration(id, name string, layout Layout, capacity int) (CapacityConfiguration, error) {
+	if id == "" {
+		return CapacityConfiguration{}, errors.New("収容構成IDは必須です")
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return CapacityConfiguration{}, errors.New("収容構成名は必須です")
+	}
+	if len([]rune(name)) > 100 {
+		return CapacityConfiguration{}, errors.New("収容構成名は100文字以内で入力してください")
+	}
+	if !layout.IsValid() {
+		return CapacityConfiguration{}, fmt.Errorf("無効なレイアウトです: %s", layout)
+	}
+	if capacity < 1 {
+		return CapacityConfiguration{}, errors.New("収容人数は1以上である必要があります")
+	}
+	return CapacityConfiguration{id: id, name: name, layout: layout, capacity: capacity}, nil
+}
+
+// ReconstructCapacityConfiguration は永続化層から収容構成を再構築する（バリデーションなし）
+func ReconstructCapacityConfiguration(id, name string, layout Layout, capacity int) CapacityConfiguration {
+	return CapacityConfiguration{id: id, name: name, layout: layout, capacity: capacity}
+}
+
+func (c CapacityConfiguration) ID() string     { return c.id }
+func (c CapacityConfiguration) Name() string   { return c.name }
+func (c CapacityConfiguration) Layout() Layout { return c.layout }
+func (c CapacityConfiguration) Capacity() int  { return c.capacity }
+
+// Configurations は登録済みの収容構成を返す
+func (v *Venue) Configurations() []CapacityConfiguration {
+	if v.configurations == nil {
+		return []CapacityConfiguration{}
+	}
+	return v.configurations
+}
+
+// Configuration はIDで収容構成を取得する
+func (v *Venue) Configuration(id string) (CapacityConfiguration, bool) {
+	for _, c := range v.configurations {
+		if c.id == id {
+			return c, true
+		}
+	}
+	return CapacityConfiguration{}, false
+}
+
+// SetConfigurations は収容構成を置き換える。IDと名前は会場内で一意にする
+func (v *Venue) SetConfigurations(configurations []CapacityConfiguration) error {
+	if len(configurations) > maxConfigurations {
+		return fmt.Errorf("収容構成は%d件までです", maxConfigurations)
+	}
+	ids := make(map[string]struct{}, len(configurations))
+	names := make(map[string]struct{}, len(configurations))
+	for _, c := range configurations {
+		if _, ok := ids[c.id]; ok {
+			return fmt.Errorf("収容構成IDが重複しています: %s", c.id)
+		}
+		if _, ok := names[c.name]; ok {
+			return fmt.Errorf("収容構成名が重複しています: %s", c.name)
+		}
+		ids[c.id] = struct{}{}
+		names[c.name] = struct{}{}
+	}
+	v.configurations = configurations
+	v.updatedAt = time.Now()
+	return nil
+}
+
+// CapacityFor はイベントが使った収容構成の収容人数を返す。
+// 収容構成の指定がない場合は会場の収容人数を使い、指定された構成が見つからない場合は収容人数不明として nil を返す
+func (v *Venue) CapacityFor(configurationID *string) *int {
+	if configurationID == nil {
+		return v.capacity
+	}
+	c, ok := v.Configuration(*configurationID)
+	if !ok {
+		return nil
+	}
+	capacity := c.capacity
+	return &capacity
+}
@@ -1,15 +1,25 @@
 package venue
 
-import "context"
+import (
+	"context"
+
+	"github.com/kuro48/idol-api/internal/shared/geo"
+)
 
 // SearchCriteria は会場検索の条件を表す値オブジェクト
 type SearchCriteria struct {
 	Name       *string
 	Prefecture *string
+	Near       *geo.Point // 指定時は RadiusKm 以内の会場を近い順に返す（Sort は無視）
+	RadiusKm   float64
 	Offset     int
 	Limit      int
 	Sort       string
 	Order      string
+
+	// Omit は一覧で取得を省略する任意属性（configurations）。
+	// fields 指定でレスポンスに含めない属性の読み込みを避けるために使う
+	Omit []string
 }
 
 // Repository は会場の永続化操作を定義するインターフェース
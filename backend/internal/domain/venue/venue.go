@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/kuro48/idol-api/internal/shared/geo"
 	"github.com/kuro48/idol-api/internal/shared/source"
 )
 
@@ -19,9 +20,12 @@ type Venue struct {
 	address     *string
 	capacity    *int
 	officialURL *string
-	sources     []source.Source
-	createdAt   time.Time
-	updatedAt   time.Time
+	location    *geo.Point // 緯度・経度（未登録の会場は近傍検索の対象外）
+	// configurations はレイアウトごとの収容構成（capacity は代表的な収容人数として残す）
+	configurations []CapacityConfiguration
+	sources        []source.Source
+	createdAt      time.Time
+	updatedAt      time.Time
 }
 
 // NewVenue は新しい Venue を生成する
@@ -50,21 +54,25 @@ func Reconstruct(
 	address *string,
 	capacity *int,
 	officialURL *string,
+	location *geo.Point,
+	configurations []CapacityConfiguration,
 	sources []source.Source,
 	createdAt, updatedAt time.Time,
 ) *Venue {
 	return &Venue{
-		id:          id,
-		name:        name,
-		nameEn:      nameEn,
-		prefecture:  prefecture,
-		city:        city,
-		address:     address,
-		capacity:    capacity,
-		officialURL: officialURL,
-		sources:     sources,
-		createdAt:   createdAt,
-		updatedAt:   updatedAt,
+		id:             id,
+		name:           name,
+		nameEn:         nameEn,
+		prefecture:     prefecture,
+		city:           city,
+		address:        address,
+		capacity:       capacity,
+		officialURL:    officialURL,
+		location:       location,
+		configurations: configurations,
+		sources:        sources,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
 	}
 }
 
@@ -78,6 +86,7 @@ func (v *Venue) City() *string        { return v.city }
 func (v *Venue) Address() *string     { return v.address }
 func (v *Venue) Capacity() *int       { return v.capacity }
 func (v *Venue) OfficialURL() *string { return v.officialURL }
+func (v *Venue) Location() *geo.Point { return v.location }
 func (v *Venue) CreatedAt() time.Time { return v.createdAt }
 func (v *Venue) UpdatedAt() time.Time { return v.updatedAt }
 
@@ -136,6 +145,11 @@ func (v *Venue) UpdateOfficialURL(url *string) {
 	v.updatedAt = time.Now()
 }
 
+func (v *Venue) UpdateLocation(location *geo.Point) {
+	v.location = location
+	v.updatedAt = time.Now()
+}
+
 func (v *Venue) SetSources(sources []source.Source) {
 	v.sources = sources
 	v.updatedAt = time.Now()
@@ -20,10 +20,15 @@ const (
 	EventEventCreated    EventType = "event.created"
 	EventEventUpdated    EventType = "event.updated"
 	EventEventDeleted    EventType = "event.deleted"
+	EventTourCreated     EventType = "tour.created"
+	EventTourUpdated     EventType = "tour.updated"
+	EventTourDeleted     EventType = "tour.deleted"
 	EventRemovalApproved EventType = "removal.approved"
 	EventReleaseCreated  EventType = "release.created"
 	EventReleaseUpdated  EventType = "release.updated"
 	EventReleaseDeleted  EventType = "release.deleted"
+
+	EventUsageThresholdReached EventType = "usage.threshold_reached"
 )
 
 // IsValidEventType はEventTypeが定義済みの有効な値かを判定する
@@ -33,8 +38,10 @@ func IsValidEventType(e EventType) bool {
 		EventGroupCreated, EventGroupUpdated, EventGroupDeleted,
 		EventAgencyCreated, EventAgencyUpdated, EventAgencyDeleted,
 		EventEventCreated, EventEventUpdated, EventEventDeleted,
+		EventTourCreated, EventTourUpdated, EventTourDeleted,
 		EventRemovalApproved,
-		EventReleaseCreated, EventReleaseUpdated, EventReleaseDeleted:
+		EventReleaseCreated, EventReleaseUpdated, EventReleaseDeleted,
+		EventUsageThresholdReached:
 		return true
 	default:
 		return false
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	appBilling "github.com/kuro48/idol-api/internal/application/billing"
+	domainbilling "github.com/kuro48/idol-api/internal/domain/billing"
+	domainusage "github.com/kuro48/idol-api/internal/domain/usage"
 	usecaseRemoval "github.com/kuro48/idol-api/internal/usecase/removal"
 	"github.com/kuro48/idol-api/internal/usecase/submission"
 )
@@ -78,6 +80,36 @@ func (n *SMTPNotifier) NotifyAPIKeyIssued(ctx context.Context, notification appB
 	return nil
 }
 
+// NotifySubscriptionChanged はサブスクリプションの状態遷移をメール通知する。
+func (n *SMTPNotifier) NotifySubscriptionChanged(ctx context.Context, notification appBilling.SubscriptionChangedNotification) error {
+	subject, body := buildSubscriptionChangedMessage(notification)
+	if err := n.send(notification.To, subject, body); err != nil {
+		return fmt.Errorf("メール送信エラー: %w", err)
+	}
+
+	slog.Info("サブスクリプション状態変更通知送信完了",
+		"to", notification.To,
+		"transition", notification.Transition,
+		"to_plan", notification.ToPlan,
+	)
+	return nil
+}
+
+// NotifyUsageThreshold は月次使用量のしきい値到達をメール通知する。
+func (n *SMTPNotifier) NotifyUsageThreshold(ctx context.Context, notification domainusage.ThresholdAlert) error {
+	subject, body := buildUsageThresholdMessage(notification)
+	if err := n.send(notification.To, subject, body); err != nil {
+		return fmt.Errorf("メール送信エラー: %w", err)
+	}
+
+	slog.Info("使用量しきい値通知送信完了",
+		"to", notification.To,
+		"threshold", notification.Threshold,
+		"year_month", notification.YearMonth,
+	)
+	return nil
+}
+
 // NotifyReceived は削除申請の受付完了をメール通知する。
 func (n *SMTPNotifier) NotifyReceived(ctx context.Context, notification usecaseRemoval.ReceivedNotification) error {
 	subject, body := buildRemovalReceivedMessage(notification)
@@ -316,6 +348,79 @@ Idol API
 	return subject, body
 }
 
+func buildSubscriptionChangedMessage(n appBilling.SubscriptionChangedNotification) (subject, body string) {
+	var summary, detail string
+	switch n.Transition {
+	case domainbilling.TransitionPlanChanged:
+		summary = "ご契約プランを変更しました"
+		detail = fmt.Sprintf("ご契約プランが %s から %s に変更されました。", n.FromPlan, n.ToPlan)
+	case domainbilling.TransitionCanceled:
+		summary = "サブスクリプションを解約しました"
+		detail = fmt.Sprintf("サブスクリプションの解約に伴い、APIキーを %s プランから %s プランへ変更しました。\nAPIキーは引き続き %s プランの上限内でご利用いただけます。", n.FromPlan, n.ToPlan, n.ToPlan)
+	case domainbilling.TransitionPaymentFailed:
+		summary = "お支払いに失敗しました"
+		detail = "ご登録のお支払い方法での決済に失敗しました。お支払い方法をご確認ください。"
+		if n.GraceDeadline != nil {
+			detail += fmt.Sprintf("\n%s までにお支払いが確認できない場合、APIキーは free プランへ変更されます。", n.GraceDeadline.UTC().Format("2006-01-02 15:04:05 UTC"))
+		}
+	case domainbilling.TransitionGraceExpired:
+		summary = "お支払いが確認できないためプランを変更しました"
+		detail = fmt.Sprintf("猶予期間内にお支払いが確認できなかったため、APIキーを %s プランから %s プランへ変更しました。\nお支払いが完了すると %s プランへ自動的に戻ります。", n.FromPlan, n.ToPlan, n.FromPlan)
+	case domainbilling.TransitionRestored:
+		summary = "お支払いを確認しました"
+		detail = fmt.Sprintf("お支払いが確認できたため、APIキーを %s プランへ戻しました。", n.ToPlan)
+	default:
+		summary = "ご契約状態が変更されました"
+		detail = fmt.Sprintf("ご契約プラン: %s", n.ToPlan)
+	}
+
+	subject = fmt.Sprintf("【Idol API】%s", summary)
+	body = fmt.Sprintf(`%s 様
+
+%s
+
+変更日時: %s
+
+プランの確認・変更は Customer Portal から行えます。
+
+---
+Idol API
+`, n.Name, detail, n.OccurredAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	return subject, body
+}
+
+func buildUsageThresholdMessage(n domainusage.ThresholdAlert) (subject, body string) {
+	subject = fmt.Sprintf("【Idol API】今月のAPI使用量が上限の%d%%に達しました", n.Threshold)
+
+	status := "上限に近づいています。上限に達するとリクエストは PLAN_LIMIT_EXCEEDED (429) で拒否されます。"
+	if n.Threshold >= 100 {
+		status = "上限に達したため、今月の残り期間のリクエストは PLAN_LIMIT_EXCEEDED (429) で拒否されます。"
+	}
+	if n.Overage {
+		status = "従量課金が有効なため、上限到達後もリクエストは継続され、超過分は従量料金として請求されます。"
+	}
+
+	upgrade := "プランの変更は管理画面またはサポートまでお問い合わせください。"
+	if n.UpgradeURL != "" {
+		upgrade = fmt.Sprintf("以下のリンクから上位プランへアップグレードできます（リンクの有効期限は24時間です）。\n%s", n.UpgradeURL)
+	}
+
+	body = fmt.Sprintf(`APIキー「%s」（%s）の今月の使用量が上限の %d%% に達しました。
+
+対象月: %s
+プラン: %s
+使用量: %d / %d リクエスト
+
+%s
+
+%s
+
+---
+Idol API
+`, n.KeyName, n.MaskedKey, n.Threshold, n.YearMonth, n.PlanType, n.Count, n.Limit, status, upgrade)
+	return subject, body
+}
+
 func buildRemovalReceivedMessage(n usecaseRemoval.ReceivedNotification) (subject, body string) {
 	subject = fmt.Sprintf("【Idol API】削除申請を受け付けました（%s）", targetTypeLabel(n.TargetType))
 	body = fmt.Sprintf(`削除申請を受け付けました。
@@ -0,0 +1,387 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/affiliation"
+	"github.com/kuro48/idol-api/internal/shared/audit"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// affiliationSeedSource は agency_id から生成した所属履歴に記録するソース
+const affiliationSeedSource = "migration"
+
+type AffiliationRepository struct {
+	collection *mongo.Collection
+	db         *mongo.Database
+}
+
+func NewAffiliationRepository(db *mongo.Database) *AffiliationRepository {
+	return &AffiliationRepository{
+		collection: db.Collection("agency_affiliations"),
+		db:         db,
+	}
+}
+
+type affiliationDocument struct {
+	ID           bson.ObjectID    `bson:"_id,omitempty"`
+	TalentType   string           `bson:"talent_type"`
+	TalentID     string           `bson:"talent_id"`
+	AgencyID     string           `bson:"agency_id"`
+	ContractType string           `bson:"contract_type"`
+	JoinedAt     *time.Time       `bson:"joined_at,omitempty"`
+	LeftAt       *time.Time       `bson:"left_at,omitempty"`
+	Sources      []sourceDocument `bson:"sources,omitempty"`
+	CreatedAt    time.Time        `bson:"created_at"`
+	UpdatedAt    time.Time        `bson:"updated_at"`
+	CreatedBy    string           `bson:"created_by,omitempty"`
+	UpdatedBy    string           `bson:"updated_by,omitempty"`
+	Source       string           `bson:"source,omitempty"`
+	IsDeleted    bool             `bson:"is_deleted,omitempty"`
+	DeletedAt    *time.Time       `bson:"deleted_at,omitempty"`
+	DeletedBy    string           `bson:"deleted_by,omitempty"`
+}
+
+func (r *AffiliationRepository) Save(ctx context.Context, a *affiliation.Affiliation) error {
+	doc := toAffiliationDocument(a)
+	doc.ID = bson.NewObjectID()
+	doc.CreatedAt = time.Now()
+	doc.UpdatedAt = time.Now()
+	doc.CreatedBy = audit.ActorFrom(ctx)
+	doc.UpdatedBy = audit.ActorFrom(ctx)
+	doc.Source = audit.SourceFrom(ctx)
+
+	id, err := affiliation.NewAffiliationID(doc.ID.Hex())
+	if err != nil {
+		return fmt.Errorf("ID生成エラー: %w", err)
+	}
+	a.SetID(id)
+
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("所属履歴の保存エラー: %w", err)
+	}
+	return nil
+}
+
+func (r *AffiliationRepository) FindByID(ctx context.Context, id affiliation.AffiliationID) (*affiliation.Affiliation, error) {
+	objectID, err := bson.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return nil, fmt.Errorf("無効なID形式: %w", err)
+	}
+
+	var doc affiliationDocument
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID, "is_deleted": bson.M{"$ne": true}}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("所属履歴が見つかりません")
+		}
+		return nil, fmt.Errorf("所属履歴取得エラー: %w", err)
+	}
+	return fromAffiliationDocument(&doc)
+}
+
+// FindByTalent はタレントの所属履歴を所属日の古い順（所属日不明を先頭）に取得する
+func (r *AffiliationRepository) FindByTalent(ctx context.Context, talentType affiliation.TalentType, talentID string) ([]*affiliation.Affiliation, error) {
+	cursor, err := r.collection.Find(ctx,
+		bson.M{"talent_type": talentType.String(), "talent_id": talentID, "is_deleted": bson.M{"$ne": true}},
+		options.Find().SetSort(bson.D{{Key: "joined_at", Value: 1}, {Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("タレントの所属履歴取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+	return scanAffiliationCursor(ctx, cursor)
+}
+
+// FindByAgencyID は事務所の所属履歴を取得する。at を指定した場合は [joined_at, left_at) に at を含むものに絞る
+func (r *AffiliationRepository) FindByAgencyID(ctx context.Context, agencyID string, at *time.Time) ([]*affiliation.Affiliation, error) {
+	filter := bson.M{"agency_id": agencyID, "is_deleted": bson.M{"$ne": true}}
+	if at != nil {
+		filter["$and"] = bson.A{
+			bson.M{"$or": bson.A{bson.M{"joined_at": nil}, bson.M{"joined_at": bson.M{"$lte": *at}}}},
+			bson.M{"$or": bson.A{bson.M{"left_at": nil}, bson.M{"left_at": bson.M{"$gt": *at}}}},
+		}
+	}
+	cursor, err := r.collection.Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "joined_at", Value: 1}, {Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("事務所の所属履歴取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+	return scanAffiliationCursor(ctx, cursor)
+}
+
+func (r *AffiliationRepository) Update(ctx context.Context, a *affiliation.Affiliation) error {
+	objectID, err := bson.ObjectIDFromHex(a.ID().Value())
+	if err != nil {
+		return fmt.Errorf("無効なID形式: %w", err)
+	}
+
+	doc := toAffiliationDocument(a)
+	set := bson.M{
+		"contract_type": doc.ContractType,
+		"sources":       doc.Sources,
+		"updated_at":    time.Now(),
+		"updated_by":    audit.ActorFrom(ctx),
+	}
+	unset := bson.M{}
+	if doc.JoinedAt != nil {
+		set["joined_at"] = doc.JoinedAt
+	} else {
+		unset["joined_at"] = ""
+	}
+	if doc.LeftAt != nil {
+		set["left_at"] = doc.LeftAt
+	} else {
+		unset["left_at"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "is_deleted": bson.M{"$ne": true}}, update)
+	if err != nil {
+		return fmt.Errorf("所属履歴の更新エラー: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("所属履歴が見つかりません")
+	}
+	return nil
+}
+
+func (r *AffiliationRepository) Delete(ctx context.Context, id affiliation.AffiliationID) error {
+	objectID, err := bson.ObjectIDFromHex(id.Value())
+	if err != nil {
+		return fmt.Errorf("無効なID形式: %w", err)
+	}
+
+	now := time.Now()
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "is_deleted": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{
+			"is_deleted": true,
+			"deleted_at": now,
+			"deleted_by": audit.ActorFrom(ctx),
+			"updated_at": now,
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("所属履歴の削除エラー: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("所属履歴が見つかりません")
+	}
+	return nil
+}
+
+func (r *AffiliationRepository) EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "talent_type", Value: 1}, {Key: "talent_id", Value: 1}, {Key: "joined_at", Value: 1}}},
+		{Keys: bson.D{{Key: "agency_id", Value: 1}, {Key: "joined_at", Value: 1}}},
+		{Keys: bson.D{{Key: "agency_id", Value: 1}, {Key: "left_at", Value: 1}}},
+		// agency_id から生成する所属履歴はタレント・事務所ごとに1件に限る（複数インスタンスの同時起動対策）
+		{
+			Keys: bson.D{{Key: "talent_type", Value: 1}, {Key: "talent_id", Value: 1}, {Key: "agency_id", Value: 1}},
+			Options: options.Index().
+				SetName("uniq_affiliation_seed").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"source": affiliationSeedSource}),
+		},
+	}
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("インデックス作成エラー: %w", err)
+	}
+	return nil
+}
+
+// SeedFromAgencyIDs は idols / groups の agency_id から所属履歴を生成する。
+// 現在の agency_id への所属履歴を持たないタレントだけを対象にし、削除済みの所属履歴も既存として扱うため、
+// 起動のたびに実行しても重複や削除した履歴の復活は起きない。
+// 所属履歴を生成したタレントに他の事務所への退所日未設定の所属履歴があれば、事務所を移ったものとして退所日を記録する。
+// 生成した件数を返す
+func (r *AffiliationRepository) SeedFromAgencyIDs(ctx context.Context) (int, error) {
+	sources := []struct {
+		collection string
+		talentType affiliation.TalentType
+	}{
+		{"idols", affiliation.TalentIdol},
+		{"groups", affiliation.TalentGroup},
+	}
+
+	seeded := 0
+	for _, src := range sources {
+		cursor, err := r.db.Collection(src.collection).Find(ctx,
+			bson.M{"agency_id": bson.M{"$nin": bson.A{nil, ""}}, "is_deleted": bson.M{"$ne": true}},
+			options.Find().SetProjection(bson.M{"agency_id": 1}),
+		)
+		if err != nil {
+			return seeded, fmt.Errorf("%s の取得エラー: %w", src.collection, err)
+		}
+
+		now := time.Now()
+		var models []mongo.WriteModel
+		var talents []talentAgency
+		for cursor.Next(ctx) {
+			var doc struct {
+				ID       bson.ObjectID `bson:"_id"`
+				AgencyID string        `bson:"agency_id"`
+			}
+			if err := cursor.Decode(&doc); err != nil {
+				cursor.Close(ctx)
+				return seeded, fmt.Errorf("ドキュメントのデコードエラー: %w", err)
+			}
+			// 削除済みを含め同じ事務所への所属履歴があればフィルタに一致して何もしない（$setOnInsert）
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{
+					"talent_type": src.talentType.String(),
+					"talent_id":   doc.ID.Hex(),
+					"agency_id":   doc.AgencyID,
+				}).
+				SetUpdate(bson.M{"$setOnInsert": bson.M{
+					"contract_type": affiliation.ContractExclusive.String(),
+					"created_at":    now,
+					"updated_at":    now,
+					"created_by":    "system",
+					"updated_by":    "system",
+					"source":        affiliationSeedSource,
+				}}).
+				SetUpsert(true))
+			talents = append(talents, talentAgency{talentID: doc.ID.Hex(), agencyID: doc.AgencyID})
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return seeded, fmt.Errorf("カーソルエラー: %w", err)
+		}
+		if len(models) == 0 {
+			continue
+		}
+
+		// 他のインスタンスが先に生成した分は一意インデックスの重複エラーになるため無視する
+		result, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return seeded, fmt.Errorf("所属履歴の一括生成エラー: %w", err)
+		}
+		if result == nil {
+			continue
+		}
+		seeded += int(result.UpsertedCount)
+
+		if err := r.closePreviousAffiliations(ctx, src.talentType, talents, result.UpsertedIDs, now); err != nil {
+			return seeded, err
+		}
+	}
+	return seeded, nil
+}
+
+// talentAgency は所属履歴を生成したタレントと事務所の組
+type talentAgency struct {
+	talentID string
+	agencyID string
+}
+
+// closePreviousAffiliations は所属履歴を新たに生成したタレントについて、
+// 他の事務所への退所日未設定の所属履歴に退所日を記録する。upserted は生成した書き込みの添字
+func (r *AffiliationRepository) closePreviousAffiliations(ctx context.Context, talentType affiliation.TalentType, talents []talentAgency, upserted map[int64]interface{}, leftAt time.Time) error {
+	var models []mongo.WriteModel
+	for idx := range upserted {
+		if idx < 0 || int(idx) >= len(talents) {
+			continue
+		}
+		t := talents[idx]
+		models = append(models, mongo.NewUpdateManyModel().
+			SetFilter(bson.M{
+				"talent_type": talentType.String(),
+				"talent_id":   t.talentID,
+				"agency_id":   bson.M{"$ne": t.agencyID},
+				"left_at":     nil,
+				"is_deleted":  bson.M{"$ne": true},
+			}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"left_at":    leftAt,
+				"updated_at": leftAt,
+				"updated_by": "system",
+			}}))
+	}
+	if len(models) == 0 {
+		return nil
+	}
+	if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("移籍前の所属履歴の退所日記録エラー: %w", err)
+	}
+	return nil
+}
+
+func toAffiliationDocument(a *affiliation.Affiliation) *affiliationDocument {
+	var objectID bson.ObjectID
+	if a.ID().Value() != "" {
+		if oid, err := bson.ObjectIDFromHex(a.ID().Value()); err == nil {
+			objectID = oid
+		}
+	}
+	return &affiliationDocument{
+		ID:           objectID,
+		TalentType:   a.TalentType().String(),
+		TalentID:     a.TalentID(),
+		AgencyID:     a.AgencyID(),
+		ContractType: a.ContractType().String(),
+		JoinedAt:     a.JoinedAt(),
+		LeftAt:       a.LeftAt(),
+		Sources:      toSourceDocuments(a.Sources()),
+		CreatedAt:    a.CreatedAt(),
+		UpdatedAt:    a.UpdatedAt(),
+	}
+}
+
+func fromAffiliationDocument(doc *affiliationDocument) (*affiliation.Affiliation, error) {
+	id, err := affiliation.NewAffiliationID(doc.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	talentType, err := affiliation.NewTalentType(doc.TalentType)
+	if err != nil {
+		return nil, err
+	}
+	contractType, err := affiliation.NewContractType(doc.ContractType)
+	if err != nil {
+		contractType = affiliation.ContractOther
+	}
+	return affiliation.Reconstruct(
+		id,
+		talentType,
+		doc.TalentID,
+		doc.AgencyID,
+		contractType,
+		doc.JoinedAt,
+		doc.LeftAt,
+		fromSourceDocuments(doc.Sources),
+		doc.CreatedAt,
+		doc.UpdatedAt,
+	), nil
+}
+
+func scanAffiliationCursor(ctx context.Context, cursor *mongo.Cursor) ([]*affiliation.Affiliation, error) {
+	result := []*affiliation.Affiliation{}
+	for cursor.Next(ctx) {
+		var doc affiliationDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("デコードエラー: %w", err)
+		}
+		a, err := fromAffiliationDocument(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("ドメインモデル変換エラー: %w", err)
+		}
+		result = append(result, a)
+	}
+	if cursor.Err() != nil {
+		return nil, fmt.Errorf("カーソルエラー: %w", cursor.Err())
+	}
+	return result, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kuro48/idol-api/internal/domain/agency"
 	"github.com/kuro48/idol-api/internal/shared/audit"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -27,23 +28,26 @@ func NewAgencyRepository(db *mongo.Database) *AgencyRepository {
 
 // agencyDocument はMongoDBに保存する事務所ドキュメント
 type agencyDocument struct {
-	ID              string     `bson:"_id"`
-	Name            string     `bson:"name"`
-	NameEn          *string    `bson:"name_en,omitempty"`
-	FoundedDate     *time.Time `bson:"founded_date,omitempty"`
-	Country         string     `bson:"country"`
-	OfficialWebsite *string    `bson:"official_website,omitempty"`
-	Description     *string    `bson:"description,omitempty"`
-	LogoURL         *string    `bson:"logo_url,omitempty"`
-	Version         int        `bson:"version"`
-	CreatedAt       time.Time  `bson:"created_at"`
-	UpdatedAt       time.Time  `bson:"updated_at"`
-	CreatedBy       string     `bson:"created_by,omitempty"`
-	UpdatedBy       string     `bson:"updated_by,omitempty"`
-	Source          string     `bson:"source,omitempty"`
-	IsDeleted       bool       `bson:"is_deleted,omitempty"`
-	DeletedAt       *time.Time `bson:"deleted_at,omitempty"`
-	DeletedBy       string     `bson:"deleted_by,omitempty"`
+	ID                string     `bson:"_id"`
+	Name              string     `bson:"name"`
+	NameEn            *string    `bson:"name_en,omitempty"`
+	NameKana          *string    `bson:"name_kana,omitempty"`
+	FoundedDate       *time.Time `bson:"founded_date,omitempty"`
+	Country           string     `bson:"country"`
+	OfficialWebsite   *string    `bson:"official_website,omitempty"`
+	Description       *string    `bson:"description,omitempty"`
+	LogoURL           *string    `bson:"logo_url,omitempty"`
+	SearchKeys        []string   `bson:"search_keys"`
+	SearchKeysVersion int        `bson:"search_keys_version"`
+	Version           int        `bson:"version"`
+	CreatedAt         time.Time  `bson:"created_at"`
+	UpdatedAt         time.Time  `bson:"updated_at"`
+	CreatedBy         string     `bson:"created_by,omitempty"`
+	UpdatedBy         string     `bson:"updated_by,omitempty"`
+	Source            string     `bson:"source,omitempty"`
+	IsDeleted         bool       `bson:"is_deleted,omitempty"`
+	DeletedAt         *time.Time `bson:"deleted_at,omitempty"`
+	DeletedBy         string     `bson:"deleted_by,omitempty"`
 }
 
 // Save は事務所を保存する
@@ -102,7 +106,7 @@ func (r *AgencyRepository) FindWithPagination(ctx context.Context, opts agency.S
 	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
 
 	if opts.Name != nil {
-		filter["name"] = bson.M{"$regex": safePartialMatchRegex(*opts.Name), "$options": "i"}
+		filter["$or"] = nameSearchConditions(*opts.Name, "name", "name_en")
 	}
 	if opts.Country != nil {
 		filter["country"] = *opts.Country
@@ -129,6 +133,9 @@ func (r *AgencyRepository) FindWithPagination(ctx context.Context, opts agency.S
 		SetSort(bson.D{{Key: sortField, Value: sortOrder}}).
 		SetSkip(skip).
 		SetLimit(limit)
+	if sortField == nameKanaField {
+		findOptions.SetCollation(japaneseCollation())
+	}
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -238,16 +245,19 @@ func (r *AgencyRepository) ExistsByName(ctx context.Context, name agency.AgencyN
 // toAgencyDocument はドメインモデルをMongoDBドキュメントに変換する
 func toAgencyDocument(a *agency.Agency) *agencyDocument {
 	return &agencyDocument{
-		ID:              a.ID().Value(),
-		Name:            a.Name().Value(),
-		NameEn:          a.NameEn(),
-		FoundedDate:     a.FoundedDate(),
-		Country:         a.Country().Value(),
-		OfficialWebsite: a.OfficialWebsite(),
-		Description:     a.Description(),
-		LogoURL:         a.LogoURL(),
-		CreatedAt:       a.CreatedAt(),
-		UpdatedAt:       a.UpdatedAt(),
+		ID:                a.ID().Value(),
+		Name:              a.Name().Value(),
+		NameEn:            a.NameEn(),
+		NameKana:          a.NameKana(),
+		FoundedDate:       a.FoundedDate(),
+		Country:           a.Country().Value(),
+		OfficialWebsite:   a.OfficialWebsite(),
+		Description:       a.Description(),
+		LogoURL:           a.LogoURL(),
+		SearchKeys:        searchkey.Keys(stringValues(a.Name().Value(), a.NameEn(), a.NameKana())...),
+		SearchKeysVersion: searchKeysVersion,
+		CreatedAt:         a.CreatedAt(),
+		UpdatedAt:         a.UpdatedAt(),
 	}
 }
 
@@ -270,6 +280,9 @@ func fromAgencyDocument(doc *agencyDocument) (*agency.Agency, error) {
 
 	a := agency.NewAgency(id, name, country)
 	a.UpdateDetails(nil, doc.NameEn, doc.FoundedDate, doc.OfficialWebsite, doc.Description, doc.LogoURL)
+	if err := a.ChangeNameKana(doc.NameKana); err != nil {
+		return nil, err
+	}
 
 	return a, nil
 }
@@ -283,6 +296,10 @@ func (r *AgencyRepository) EnsureIndexes(ctx context.Context) error {
 				{Key: "name", Value: 1},
 			},
 		},
+		// 正規化検索キーインデックス（かな/ローマ字の表記ゆれ検索用）
+		searchKeysIndex("idx_agency_search_keys"),
+		// 読み仮名インデックス（五十音順ソート用）
+		nameKanaSortIndex("idx_agency_name_kana"),
 		// 国インデックス（フィルタリング用）
 		{
 			Keys: bson.D{
@@ -324,3 +341,8 @@ func (r *AgencyRepository) EnsureIndexes(ctx context.Context) error {
 
 	return nil
 }
+
+// BackfillSearchKeys は検索キーが未生成または古い版の既存事務所に正規化キーを生成する
+func (r *AgencyRepository) BackfillSearchKeys(ctx context.Context) (int, error) {
+	return backfillSearchKeys(ctx, r.collection, "name", "name_en", "name_kana")
+}
@@ -27,6 +27,7 @@ func NewAnalyticsRepository(db *mongo.Database) *AnalyticsRepository {
 type usageDocument struct {
 	ID         bson.ObjectID `bson:"_id,omitempty"`
 	MaskedKey  string        `bson:"masked_key"`
+	KeyPrefix  string        `bson:"key_prefix,omitempty"`
 	Endpoint   string        `bson:"endpoint"`
 	Method     string        `bson:"method"`
 	StatusCode int           `bson:"status_code"`
@@ -39,6 +40,7 @@ func (r *AnalyticsRepository) Save(ctx context.Context, record *analytics.APIUsa
 	doc := usageDocument{
 		ID:         bson.NewObjectID(),
 		MaskedKey:  record.MaskedKey,
+		KeyPrefix:  record.KeyPrefix,
 		Endpoint:   record.Endpoint,
 		Method:     record.Method,
 		StatusCode: record.StatusCode,
@@ -79,6 +81,7 @@ func (r *AnalyticsRepository) FindByMaskedKey(ctx context.Context, maskedKey str
 		records = append(records, &analytics.APIUsageRecord{
 			ID:         doc.ID.Hex(),
 			MaskedKey:  doc.MaskedKey,
+			KeyPrefix:  doc.KeyPrefix,
 			Endpoint:   doc.Endpoint,
 			Method:     doc.Method,
 			StatusCode: doc.StatusCode,
@@ -157,6 +160,142 @@ func (r *AnalyticsRepository) AggregateByKey(ctx context.Context, from, to time.
 	return summaries, nil
 }
 
+// AggregateByKeyPrefix はAPIキープレフィックス単位で利用明細を集計する
+// 日別件数・上位エンドポイント・レイテンシ分布を $facet で一度に取得する
+func (r *AnalyticsRepository) AggregateByKeyPrefix(ctx context.Context, keyPrefix string, from, to time.Time, topEndpoints int) (*analytics.KeyUsageDetail, error) {
+	if topEndpoints <= 0 {
+		topEndpoints = 10
+	}
+
+	errorCond := bson.M{"$cond": bson.A{
+		bson.M{"$gte": bson.A{"$status_code", 400}},
+		1,
+		0,
+	}}
+
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{
+			"key_prefix": keyPrefix,
+			"recorded_at": bson.M{
+				"$gte": from,
+				"$lte": to,
+			},
+		}},
+		bson.M{"$facet": bson.M{
+			"totals": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":            nil,
+					"total_requests": bson.M{"$sum": 1},
+					"error_count":    bson.M{"$sum": errorCond},
+				}},
+			},
+			"daily": bson.A{
+				bson.M{"$group": bson.M{
+					"_id": bson.M{"$dateToString": bson.M{
+						"format": "%Y-%m-%d",
+						"date":   "$recorded_at",
+					}},
+					"requests": bson.M{"$sum": 1},
+					"errors":   bson.M{"$sum": errorCond},
+				}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+			"endpoints": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":            bson.M{"method": "$method", "endpoint": "$endpoint"},
+					"requests":       bson.M{"$sum": 1},
+					"errors":         bson.M{"$sum": errorCond},
+					"avg_latency_ms": bson.M{"$avg": "$latency_ms"},
+				}},
+				bson.M{"$sort": bson.D{{Key: "requests", Value: -1}, {Key: "_id.endpoint", Value: 1}}},
+				bson.M{"$limit": topEndpoints},
+			},
+			"latency": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":   "$latency_ms",
+					"count": bson.M{"$sum": 1},
+				}},
+			},
+		}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("APIキー利用明細の集計エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	type facetResult struct {
+		Totals []struct {
+			TotalRequests int64 `bson:"total_requests"`
+			ErrorCount    int64 `bson:"error_count"`
+		} `bson:"totals"`
+		Daily []struct {
+			Date     string `bson:"_id"`
+			Requests int64  `bson:"requests"`
+			Errors   int64  `bson:"errors"`
+		} `bson:"daily"`
+		Endpoints []struct {
+			ID struct {
+				Method   string `bson:"method"`
+				Endpoint string `bson:"endpoint"`
+			} `bson:"_id"`
+			Requests     int64   `bson:"requests"`
+			Errors       int64   `bson:"errors"`
+			AvgLatencyMs float64 `bson:"avg_latency_ms"`
+		} `bson:"endpoints"`
+		Latency []struct {
+			LatencyMs int64 `bson:"_id"`
+			Count     int64 `bson:"count"`
+		} `bson:"latency"`
+	}
+
+	var results []facetResult
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("集計データ変換エラー: %w", err)
+	}
+
+	detail := &analytics.KeyUsageDetail{
+		KeyPrefix:        keyPrefix,
+		Daily:            []analytics.DailyUsage{},
+		TopEndpoints:     []analytics.EndpointUsage{},
+		LatencyHistogram: []analytics.LatencyBucket{},
+	}
+	if len(results) == 0 {
+		return detail, nil
+	}
+
+	res := results[0]
+	if len(res.Totals) > 0 {
+		detail.TotalRequests = res.Totals[0].TotalRequests
+		detail.ErrorCount = res.Totals[0].ErrorCount
+	}
+	for _, d := range res.Daily {
+		detail.Daily = append(detail.Daily, analytics.DailyUsage{
+			Date:     d.Date,
+			Requests: d.Requests,
+			Errors:   d.Errors,
+		})
+	}
+	for _, e := range res.Endpoints {
+		detail.TopEndpoints = append(detail.TopEndpoints, analytics.EndpointUsage{
+			Method:       e.ID.Method,
+			Endpoint:     e.ID.Endpoint,
+			Requests:     e.Requests,
+			Errors:       e.Errors,
+			AvgLatencyMs: e.AvgLatencyMs,
+		})
+	}
+	for _, l := range res.Latency {
+		detail.LatencyHistogram = append(detail.LatencyHistogram, analytics.LatencyBucket{
+			LatencyMs: l.LatencyMs,
+			Count:     l.Count,
+		})
+	}
+
+	return detail, nil
+}
+
 // EnsureIndexes はインデックスを作成する
 func (r *AnalyticsRepository) EnsureIndexes(ctx context.Context) error {
 	// TTLインデックス: 90日後に自動削除
@@ -175,6 +314,15 @@ func (r *AnalyticsRepository) EnsureIndexes(ctx context.Context) error {
 			},
 			Options: options.Index().SetName("idx_masked_key_recorded_at"),
 		},
+		{
+			Keys: bson.D{
+				{Key: "key_prefix", Value: 1},
+				{Key: "recorded_at", Value: -1},
+			},
+			Options: options.Index().
+				SetName("idx_key_prefix_recorded_at").
+				SetPartialFilterExpression(bson.M{"key_prefix": bson.M{"$exists": true}}),
+		},
 	}
 
 	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
@@ -36,6 +36,9 @@ type apikeyDocument struct {
 	PlanType  string        `bson:"plan_type"`
 	IsActive  bool          `bson:"is_active"`
 	CreatedAt time.Time     `bson:"created_at"`
+	// 従量課金での超過利用設定
+	OverageEnabled bool `bson:"overage_enabled,omitempty"`
+	OverageHardCap int  `bson:"overage_hard_cap,omitempty"`
 }
 
 // EnsureIndexes はコレクションのインデックスを作成する
@@ -75,6 +78,9 @@ func (r *APIKeyRepository) Save(ctx context.Context, key *domainapikey.APIKey) e
 		PlanType:  string(key.PlanType()),
 		IsActive:  key.IsActive(),
 		CreatedAt: key.CreatedAt(),
+
+		OverageEnabled: key.Overage().Enabled,
+		OverageHardCap: key.Overage().HardCap,
 	}
 	_, err = r.collection.InsertOne(ctx, doc)
 	if err != nil {
@@ -155,8 +161,10 @@ func (r *APIKeyRepository) Update(ctx context.Context, key *domainapikey.APIKey)
 	}
 
 	update := bson.M{"$set": bson.M{
-		"is_active": key.IsActive(),
-		"plan_type": string(key.PlanType()),
+		"is_active":        key.IsActive(),
+		"plan_type":        string(key.PlanType()),
+		"overage_enabled":  key.Overage().Enabled,
+		"overage_hard_cap": key.Overage().HardCap,
 	}}
 	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
 	if err != nil {
@@ -175,6 +183,7 @@ func toAPIKeyDomain(doc *apikeyDocument) (*domainapikey.APIKey, error) {
 		doc.Name,
 		plan.Type(doc.PlanType),
 		doc.IsActive,
+		domainapikey.Overage{Enabled: doc.OverageEnabled, HardCap: doc.OverageHardCap},
 		doc.CreatedAt,
 	)
 	if err != nil {
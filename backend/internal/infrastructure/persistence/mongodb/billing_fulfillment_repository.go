@@ -26,15 +26,33 @@ func NewBillingFulfillmentRepository(db *mongo.Database) *BillingFulfillmentRepo
 }
 
 type billingFulfillmentDocument struct {
-	ID         bson.ObjectID `bson:"_id,omitempty"`
-	SessionID  string        `bson:"session_id"`
-	CustomerID string        `bson:"customer_id"`
-	Email      string        `bson:"email"`
-	Name       string        `bson:"name"`
-	PlanType   string        `bson:"plan_type"`
-	APIKeyID   string        `bson:"api_key_id"`
-	NotifiedAt *time.Time    `bson:"notified_at,omitempty"`
-	CreatedAt  time.Time     `bson:"created_at"`
+	ID             bson.ObjectID `bson:"_id,omitempty"`
+	SessionID      string        `bson:"session_id"`
+	CustomerID     string        `bson:"customer_id"`
+	SubscriptionID string        `bson:"subscription_id,omitempty"`
+	Email          string        `bson:"email"`
+	Name           string        `bson:"name"`
+	PlanType       string        `bson:"plan_type"`
+	APIKeyID       string        `bson:"api_key_id"`
+	NotifiedAt     *time.Time    `bson:"notified_at,omitempty"`
+	CreatedAt      time.Time     `bson:"created_at"`
+	Revision       int           `bson:"revision"`
+	// サブスクリプション状態
+	SubscriptionStatus  string                           `bson:"subscription_status,omitempty"`
+	PaymentFailureCount int                              `bson:"payment_failure_count,omitempty"`
+	PaymentFailedAt     *time.Time                       `bson:"payment_failed_at,omitempty"`
+	DowngradeReason     string                           `bson:"downgrade_reason,omitempty"`
+	History             []subscriptionTransitionDocument `bson:"history,omitempty"`
+	LastEventID         string                           `bson:"last_event_id,omitempty"`
+	LastEventAt         *time.Time                       `bson:"last_event_at,omitempty"`
+}
+
+type subscriptionTransitionDocument struct {
+	Type       string    `bson:"type"`
+	FromPlan   string    `bson:"from_plan"`
+	ToPlan     string    `bson:"to_plan"`
+	Status     string    `bson:"status,omitempty"`
+	OccurredAt time.Time `bson:"occurred_at"`
 }
 
 // EnsureIndexes はコレクションインデックスを作成する。
@@ -52,6 +70,18 @@ func (r *BillingFulfillmentRepository) EnsureIndexes(ctx context.Context) error
 			Keys:    bson.D{{Key: "customer_id", Value: 1}, {Key: "created_at", Value: -1}},
 			Options: options.Index().SetName("idx_billing_customer_created_at"),
 		},
+		{
+			Keys:    bson.D{{Key: "subscription_id", Value: 1}},
+			Options: options.Index().SetName("idx_billing_subscription_id").SetSparse(true),
+		},
+		{
+			Keys:    bson.D{{Key: "api_key_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("idx_billing_api_key_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "payment_failed_at", Value: 1}},
+			Options: options.Index().SetName("idx_billing_payment_failed_at").SetSparse(true),
+		},
 	}
 	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
 	return err
@@ -113,35 +143,161 @@ func (r *BillingFulfillmentRepository) FindLatestByCustomerID(ctx context.Contex
 	return toBillingFulfillmentDomain(&doc)
 }
 
-// Update は fulfillment を更新する。
+// FindBySubscriptionID は Stripe Subscription ID に紐づく fulfillment を取得する。
+func (r *BillingFulfillmentRepository) FindBySubscriptionID(ctx context.Context, subscriptionID string) (*domainbilling.CheckoutFulfillment, error) {
+	var doc billingFulfillmentDocument
+	err := r.collection.FindOne(
+		ctx,
+		bson.M{"subscription_id": subscriptionID},
+		options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("billing fulfillment の取得に失敗しました: %w", err)
+	}
+	return toBillingFulfillmentDomain(&doc)
+}
+
+// FindLatestUnlinkedByCustomerID は customer ID に紐づき、Subscription ID を持たない最新 fulfillment を取得する。
+func (r *BillingFulfillmentRepository) FindLatestUnlinkedByCustomerID(ctx context.Context, customerID string) (*domainbilling.CheckoutFulfillment, error) {
+	var doc billingFulfillmentDocument
+	err := r.collection.FindOne(
+		ctx,
+		bson.M{"customer_id": customerID, "subscription_id": bson.M{"$in": bson.A{"", nil}}},
+		options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("billing fulfillment の取得に失敗しました: %w", err)
+	}
+	return toBillingFulfillmentDomain(&doc)
+}
+
+// FindLatestByAPIKeyID は API キーに紐づく最新 fulfillment を取得する。
+func (r *BillingFulfillmentRepository) FindLatestByAPIKeyID(ctx context.Context, apiKeyID string) (*domainbilling.CheckoutFulfillment, error) {
+	var doc billingFulfillmentDocument
+	err := r.collection.FindOne(
+		ctx,
+		bson.M{"api_key_id": apiKeyID},
+		options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}}),
+	).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("billing fulfillment の取得に失敗しました: %w", err)
+	}
+	return toBillingFulfillmentDomain(&doc)
+}
+
+// FindGraceExpired は failedBefore 以前から支払い失敗が続き、まだ降格していない fulfillment を取得する。
+func (r *BillingFulfillmentRepository) FindGraceExpired(ctx context.Context, failedBefore time.Time, limit int) ([]*domainbilling.CheckoutFulfillment, error) {
+	filter := bson.M{
+		"payment_failed_at": bson.M{"$lte": failedBefore},
+		"downgrade_reason":  bson.M{"$in": bson.A{"", nil}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "payment_failed_at", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("billing fulfillment の取得に失敗しました: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []billingFulfillmentDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("billing fulfillment のデコードに失敗しました: %w", err)
+	}
+	fulfillments := make([]*domainbilling.CheckoutFulfillment, 0, len(docs))
+	for i := range docs {
+		fulfillment, err := toBillingFulfillmentDomain(&docs[i])
+		if err != nil {
+			return nil, err
+		}
+		fulfillments = append(fulfillments, fulfillment)
+	}
+	return fulfillments, nil
+}
+
+// Update は読み込んだ版から変わっていない fulfillment を更新し、版を進める。
+// 版を持たない既存ドキュメントは版 0 として扱う。
 func (r *BillingFulfillmentRepository) Update(ctx context.Context, fulfillment *domainbilling.CheckoutFulfillment) error {
-	update := bson.M{
-		"$set": bson.M{
-			"customer_id": fulfillment.CustomerID(),
-			"email":       fulfillment.Email(),
-			"name":        fulfillment.Name(),
-			"plan_type":   string(fulfillment.PlanType()),
-			"api_key_id":  fulfillment.APIKeyID(),
-			"notified_at": fulfillment.NotifiedAt(),
-		},
+	set := bson.M{
+		"customer_id": fulfillment.CustomerID(),
+		"email":       fulfillment.Email(),
+		"name":        fulfillment.Name(),
+		"plan_type":   string(fulfillment.PlanType()),
+		"api_key_id":  fulfillment.APIKeyID(),
+		"notified_at": fulfillment.NotifiedAt(),
+
+		"subscription_status":   fulfillment.SubscriptionStatus(),
+		"payment_failure_count": fulfillment.PaymentFailureCount(),
+		"payment_failed_at":     fulfillment.PaymentFailedAt(),
+		"downgrade_reason":      string(fulfillment.DowngradeReason()),
+		"history":               toSubscriptionTransitionDocuments(fulfillment.History()),
+		"last_event_id":         fulfillment.LastEventID(),
+		"last_event_at":         fulfillment.LastEventAt(),
 	}
-	if _, err := r.collection.UpdateOne(ctx, bson.M{"session_id": fulfillment.SessionID()}, update); err != nil {
+	// 紐づけ前の fulfillment は subscription_id を持たないまま残す（疎インデックスの対象外にする）
+	if fulfillment.SubscriptionID() != "" {
+		set["subscription_id"] = fulfillment.SubscriptionID()
+	}
+	update := bson.M{"$set": set, "$inc": bson.M{"revision": 1}}
+
+	filter := bson.M{"session_id": fulfillment.SessionID(), "revision": fulfillment.Revision()}
+	if fulfillment.Revision() == 0 {
+		filter["revision"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
 		return fmt.Errorf("billing fulfillment の更新に失敗しました: %w", err)
 	}
+	if result.MatchedCount == 0 {
+		return domainbilling.ErrFulfillmentConflict
+	}
 	return nil
 }
 
 func toBillingFulfillmentDocument(fulfillment *domainbilling.CheckoutFulfillment) billingFulfillmentDocument {
 	return billingFulfillmentDocument{
-		SessionID:  fulfillment.SessionID(),
-		CustomerID: fulfillment.CustomerID(),
-		Email:      fulfillment.Email(),
-		Name:       fulfillment.Name(),
-		PlanType:   string(fulfillment.PlanType()),
-		APIKeyID:   fulfillment.APIKeyID(),
-		NotifiedAt: fulfillment.NotifiedAt(),
-		CreatedAt:  fulfillment.CreatedAt(),
+		SessionID:      fulfillment.SessionID(),
+		CustomerID:     fulfillment.CustomerID(),
+		SubscriptionID: fulfillment.SubscriptionID(),
+		Email:          fulfillment.Email(),
+		Name:           fulfillment.Name(),
+		PlanType:       string(fulfillment.PlanType()),
+		APIKeyID:       fulfillment.APIKeyID(),
+		NotifiedAt:     fulfillment.NotifiedAt(),
+		CreatedAt:      fulfillment.CreatedAt(),
+		Revision:       fulfillment.Revision(),
+
+		SubscriptionStatus:  fulfillment.SubscriptionStatus(),
+		PaymentFailureCount: fulfillment.PaymentFailureCount(),
+		PaymentFailedAt:     fulfillment.PaymentFailedAt(),
+		DowngradeReason:     string(fulfillment.DowngradeReason()),
+		History:             toSubscriptionTransitionDocuments(fulfillment.History()),
+		LastEventID:         fulfillment.LastEventID(),
+		LastEventAt:         fulfillment.LastEventAt(),
+	}
+}
+
+func toSubscriptionTransitionDocuments(history []domainbilling.SubscriptionTransition) []subscriptionTransitionDocument {
+	docs := make([]subscriptionTransitionDocument, 0, len(history))
+	for _, h := range history {
+		docs = append(docs, subscriptionTransitionDocument{
+			Type:       string(h.Type),
+			FromPlan:   string(h.FromPlan),
+			ToPlan:     string(h.ToPlan),
+			Status:     h.Status,
+			OccurredAt: h.OccurredAt,
+		})
 	}
+	return docs
 }
 
 func toBillingFulfillmentDomain(doc *billingFulfillmentDocument) (*domainbilling.CheckoutFulfillment, error) {
@@ -152,11 +308,36 @@ func toBillingFulfillmentDomain(doc *billingFulfillmentDocument) (*domainbilling
 	return domainbilling.ReconstructCheckoutFulfillment(
 		doc.SessionID,
 		doc.CustomerID,
+		doc.SubscriptionID,
 		doc.Email,
 		doc.Name,
 		planType,
 		doc.APIKeyID,
 		doc.NotifiedAt,
 		doc.CreatedAt,
+		doc.Revision,
+		toSubscriptionStateDomain(doc),
 	), nil
 }
+
+func toSubscriptionStateDomain(doc *billingFulfillmentDocument) domainbilling.SubscriptionState {
+	history := make([]domainbilling.SubscriptionTransition, 0, len(doc.History))
+	for _, h := range doc.History {
+		history = append(history, domainbilling.SubscriptionTransition{
+			Type:       domainbilling.TransitionType(h.Type),
+			FromPlan:   plan.Type(h.FromPlan),
+			ToPlan:     plan.Type(h.ToPlan),
+			Status:     h.Status,
+			OccurredAt: h.OccurredAt,
+		})
+	}
+	return domainbilling.SubscriptionState{
+		Status:              doc.SubscriptionStatus,
+		PaymentFailureCount: doc.PaymentFailureCount,
+		PaymentFailedAt:     doc.PaymentFailedAt,
+		DowngradeReason:     domainbilling.DowngradeReason(doc.DowngradeReason),
+		History:             history,
+		LastEventID:         doc.LastEventID,
+		LastEventAt:         doc.LastEventAt,
+	}
+}
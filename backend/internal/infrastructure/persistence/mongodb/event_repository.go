@@ -8,6 +8,7 @@ import (
 
 	"github.com/kuro48/idol-api/internal/domain/event"
 	"github.com/kuro48/idol-api/internal/shared/audit"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -25,11 +26,40 @@ func NewEventRepository(db *mongo.Database) *EventRepository {
 	}
 }
 
+type setlistEntryDocument struct {
+	ReleaseID    *string  `bson:"release_id,omitempty"`
+	TrackNumber  *int     `bson:"track_number,omitempty"`
+	Title        string   `bson:"title"`
+	IsEncore     bool     `bson:"is_encore,omitempty"`
+	PerformerIDs []string `bson:"performer_ids,omitempty"`
+	Note         *string  `bson:"note,omitempty"`
+}
+
 type performerDocument struct {
 	PerformerID   string `bson:"performer_id"`
 	BillingStatus string `bson:"billing_status"`
 }
 
+// attendanceDocument はイベントの動員実績
+type attendanceDocument struct {
+	SoldOut bool `bson:"sold_out"`
+	Count   *int `bson:"count,omitempty"`
+}
+
+// streamingDocument はイベントの配信情報
+type streamingDocument struct {
+	Platform     string              `bson:"platform"`
+	URL          *string             `bson:"url,omitempty"`
+	TicketURL    *string             `bson:"ticket_url,omitempty"`
+	ArchiveUntil *time.Time          `bson:"archive_until,omitempty"`
+	PriceTiers   []priceTierDocument `bson:"price_tiers,omitempty"`
+}
+
+type priceTierDocument struct {
+	Name  string `bson:"name"`
+	Price int    `bson:"price"`
+}
+
 // eventDocument はMongoDBに保存するイベントドキュメント
 type eventDocument struct {
 	ID             string               `bson:"_id,omitempty"`
@@ -45,6 +75,15 @@ type eventDocument struct {
 	OfficialURL    *string              `bson:"official_url,omitempty"`
 	Description    *string              `bson:"description,omitempty"`
 	Tags           []string             `bson:"tags"`
+	SearchKeys     []string             `bson:"search_keys"`
+	SearchKeysVersion int `bson:"search_keys_version"`
+	SeriesID       *string              `bson:"series_id,omitempty"`
+	InheritedPerformerIDs []string      `bson:"inherited_performer_ids,omitempty"` // ツアーから引き継いだ出演者
+	InheritedTags  []string             `bson:"inherited_tags,omitempty"`          // ツアーから引き継いだタグ
+	Setlist        []setlistEntryDocument `bson:"setlist,omitempty"`
+	CapacityConfigurationID *string             `bson:"capacity_configuration_id,omitempty"`
+	Attendance     *attendanceDocument  `bson:"attendance,omitempty"`
+	Streaming      *streamingDocument   `bson:"streaming,omitempty"`
 	Version       int        `bson:"version"`
 	CreatedAt     time.Time  `bson:"created_at"`
 	UpdatedAt     time.Time  `bson:"updated_at"`
@@ -98,6 +137,9 @@ func (r *EventRepository) Search(ctx context.Context, criteria event.SearchCrite
 	// ページネーション
 	opts.SetSkip(int64(criteria.Offset))
 	opts.SetLimit(int64(criteria.Limit))
+	if projection := omitProjection(criteria.Omit, "setlist"); projection != nil {
+		opts.SetProjection(projection)
+	}
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -131,6 +173,7 @@ func (r *EventRepository) Count(ctx context.Context, criteria event.SearchCriter
 // Update は既存のイベントを更新する
 func (r *EventRepository) Update(ctx context.Context, e *event.Event) error {
 	doc := toEventDocument(e)
+	doc.Version = e.Version() + 1
 	doc.UpdatedBy = audit.ActorFrom(ctx)
 	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": e.ID().Value()}, doc)
 	if err != nil {
@@ -282,6 +325,16 @@ func toEventDocument(e *event.Event) *eventDocument {
 		OfficialURL:   e.OfficialURL(),
 		Description:   e.Description(),
 		Tags:          e.Tags(),
+		SearchKeys:    searchkey.Keys(e.Title().Value()),
+		SearchKeysVersion: searchKeysVersion,
+		SeriesID:      e.SeriesID(),
+		InheritedPerformerIDs: e.InheritedPerformerIDs(),
+		InheritedTags: e.InheritedTags(),
+		Setlist:       toSetlistDocuments(e.Setlist()),
+		CapacityConfigurationID: e.CapacityConfigurationID(),
+		Attendance:    toAttendanceDocument(e.Attendance()),
+		Streaming:     toStreamingDocument(e.Streaming()),
+		Version:       e.Version(),
 		CreatedAt:     e.CreatedAt(),
 		UpdatedAt:     e.UpdatedAt(),
 	}
@@ -342,11 +395,89 @@ func fromEventDocument(doc *eventDocument) (*event.Event, error) {
 		doc.OfficialURL,
 		doc.Description,
 		doc.Tags,
+		doc.SeriesID,
+		doc.InheritedPerformerIDs,
+		doc.InheritedTags,
+		fromSetlistDocuments(doc.Setlist),
+		doc.CapacityConfigurationID,
+		fromAttendanceDocument(doc.Attendance),
+		fromStreamingDocument(doc.Streaming),
+		doc.Version,
 		doc.CreatedAt,
 		doc.UpdatedAt,
 	), nil
 }
 
+func toAttendanceDocument(a *event.Attendance) *attendanceDocument {
+	if a == nil {
+		return nil
+	}
+	return &attendanceDocument{SoldOut: a.SoldOut(), Count: a.Count()}
+}
+
+func fromAttendanceDocument(doc *attendanceDocument) *event.Attendance {
+	if doc == nil {
+		return nil
+	}
+	a := event.ReconstructAttendance(doc.SoldOut, doc.Count)
+	return &a
+}
+
+func toStreamingDocument(s *event.Streaming) *streamingDocument {
+	if s == nil {
+		return nil
+	}
+	tiers := make([]priceTierDocument, 0, len(s.PriceTiers()))
+	for _, t := range s.PriceTiers() {
+		tiers = append(tiers, priceTierDocument{Name: t.Name, Price: t.Price})
+	}
+	return &streamingDocument{
+		Platform:     string(s.Platform()),
+		URL:          s.URL(),
+		TicketURL:    s.TicketURL(),
+		ArchiveUntil: s.ArchiveUntil(),
+		PriceTiers:   tiers,
+	}
+}
+
+func fromStreamingDocument(doc *streamingDocument) *event.Streaming {
+	if doc == nil {
+		return nil
+	}
+	tiers := make([]event.PriceTier, 0, len(doc.PriceTiers))
+	for _, t := range doc.PriceTiers {
+		tiers = append(tiers, event.PriceTier{Name: t.Name, Price: t.Price})
+	}
+	s := event.ReconstructStreaming(event.StreamingPlatform(doc.Platform), doc.URL, doc.TicketURL, doc.ArchiveUntil, tiers)
+	return &s
+}
+
+func toSetlistDocuments(entries []event.SetlistEntry) []setlistEntryDocument {
+	if len(entries) == 0 {
+		return nil
+	}
+	docs := make([]setlistEntryDocument, 0, len(entries))
+	for _, s := range entries {
+		docs = append(docs, setlistEntryDocument{
+			ReleaseID:    s.ReleaseID(),
+			TrackNumber:  s.TrackNumber(),
+			Title:        s.Title(),
+			IsEncore:     s.IsEncore(),
+			PerformerIDs: s.PerformerIDs(),
+			Note:         s.Note(),
+		})
+	}
+	return docs
+}
+
+func fromSetlistDocuments(docs []setlistEntryDocument) []event.SetlistEntry {
+	entries := make([]event.SetlistEntry, 0, len(docs))
+	for _, d := range docs {
+		entries = append(entries, event.ReconstructSetlistEntry(d.ReleaseID, d.TrackNumber, d.Title, d.IsEncore, d.PerformerIDs, d.Note))
+	}
+	return entries
+}
+
 // buildEventFilter は検索条件からMongoDBフィルタを構築する
 func buildEventFilter(criteria event.SearchCriteria) bson.M {
 	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
@@ -374,6 +505,13 @@ func buildEventFilter(criteria event.SearchCriteria) bson.M {
 	if criteria.VenueID != nil {
 		filter["venue_id"] = *criteria.VenueID
 	}
+	if criteria.VenueIDs != nil {
+		venueFilter := bson.M{"$in": criteria.VenueIDs}
+		if criteria.VenueID != nil {
+			venueFilter["$eq"] = *criteria.VenueID
+		}
+		filter["venue_id"] = venueFilter
+	}
 
 	// パフォーマーID（新形式 + 旧形式の両方を検索）
 	if criteria.PerformerID != nil {
@@ -388,9 +526,225 @@ func buildEventFilter(criteria event.SearchCriteria) bson.M {
 		filter["tags"] = bson.M{"$all": criteria.Tags}
 	}
 
+	// ツアー
+	if criteria.SeriesID != nil {
+		filter["series_id"] = *criteria.SeriesID
+	}
+
+	// セットリストで演奏された収録曲
+	if criteria.PerformedTrack != nil {
+		filter["setlist"] = bson.M{"$elemMatch": bson.M{
+			"release_id":   criteria.PerformedTrack.ReleaseID,
+			"track_number": criteria.PerformedTrack.TrackNumber,
+		}}
+	}
+
+	// 配信の有無（パフォーマーIDの $or と併用するため $and で追加する）
+	if criteria.Online != nil {
+		online := bson.M{"$or": []bson.M{
+			{"streaming": bson.M{"$exists": true}},
+			{"event_type": event.EventTypeOnline},
+		}}
+		if !*criteria.Online {
+			online = bson.M{"streaming": bson.M{"$exists": false}, "event_type": bson.M{"$ne": event.EventTypeOnline}}
+		}
+		filter["$and"] = []bson.M{online}
+	}
+
+	// アーカイブ視聴期間中
+	if criteria.ArchiveAvailableAt != nil {
+		filter["streaming.archive_until"] = bson.M{"$gt": *criteria.ArchiveAvailableAt}
+	}
+
 	return filter
 }
 
+// FindSetlistTrackNumbers はセットリストから参照されているリリースのトラック番号を昇順で返す
+func (r *EventRepository) FindSetlistTrackNumbers(ctx context.Context, releaseID string) ([]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"setlist.release_id": releaseID, "is_deleted": bson.M{"$ne": true}}}},
+		{{Key: "$unwind", Value: "$setlist"}},
+		{{Key: "$match", Value: bson.M{"setlist.release_id": releaseID}}},
+		{{Key: "$group", Value: bson.M{"_id": "$setlist.track_number"}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("セットリストの参照取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		TrackNumber int `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("データ変換エラー: %w", err)
+	}
+	numbers := make([]int, 0, len(docs))
+	for _, d := range docs {
+		numbers = append(numbers, d.TrackNumber)
+	}
+	return numbers, nil
+}
+
+// FindCapacityConfigurationIDs は会場のイベントが参照している収容構成のIDを昇順で返す
+func (r *EventRepository) FindCapacityConfigurationIDs(ctx context.Context, venueID string) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"venue_id":                  venueID,
+			"capacity_configuration_id": bson.M{"$nin": bson.A{nil, ""}},
+			"is_deleted":                bson.M{"$ne": true},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$capacity_configuration_id"}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("収容構成の参照取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ConfigurationID string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("データ変換エラー: %w", err)
+	}
+	ids := make([]string, 0, len(docs))
+	for _, d := range docs {
+		ids = append(ids, d.ConfigurationID)
+	}
+	return ids, nil
+}
+
+// FindBySeries はツアーに属するイベントを開始日時順にすべて取得する
+func (r *EventRepository) FindBySeries(ctx context.Context, seriesID string) ([]*event.Event, error) {
+	filter := bson.M{"series_id": seriesID, "is_deleted": bson.M{"$ne": true}}
+	opts := options.Find().SetSort(bson.D{{Key: "start_date_time", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ツアーのイベント取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []eventDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("データ変換エラー: %w", err)
+	}
+
+	events := make([]*event.Event, 0, len(docs))
+	for _, doc := range docs {
+		e, err := fromEventDocument(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("ドメインモデル変換エラー: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// FindOverlapping は開催時間帯が [from, to) と重なるイベントを開始日時順にすべて取得する
+func (r *EventRepository) FindOverlapping(ctx context.Context, from, to time.Time) ([]*event.Event, error) {
+	filter := bson.M{
+		"start_date_time": bson.M{"$lt": to},
+		"$or": bson.A{
+			bson.M{"end_date_time": bson.M{"$gt": from}},
+			bson.M{
+				"end_date_time":   nil,
+				"start_date_time": bson.M{"$gt": from.Add(-event.DefaultEventDuration)},
+			},
+		},
+		"is_deleted": bson.M{"$ne": true},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "start_date_time", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("期間内のイベント取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []eventDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("データ変換エラー: %w", err)
+	}
+
+	events := make([]*event.Event, 0, len(docs))
+	for _, doc := range docs {
+		e, err := fromEventDocument(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("ドメインモデル変換エラー: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// FindDueForCompletion は終了時刻を過ぎている予定のイベントを開始日時の古い順に最大 limit 件取得する。
+// 終了日時が未登録のイベントの終了時刻はドメインの CompletesAt と同じ規則（開始日の翌日0時）で判定する
+func (r *EventRepository) FindDueForCompletion(ctx context.Context, now time.Time, limit int) ([]*event.Event, error) {
+	filter := bson.M{
+		"status": statusFilter(event.EventStatusScheduled),
+		"$or": bson.A{
+			bson.M{"end_date_time": bson.M{"$lte": now}},
+			bson.M{"end_date_time": nil, "start_date_time": bson.M{"$lt": event.UndatedCompletionCutoff(now)}},
+		},
+		"is_deleted": bson.M{"$ne": true},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "start_date_time", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("終了対象のイベント取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []eventDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("データ変換エラー: %w", err)
+	}
+
+	events := make([]*event.Event, 0, len(docs))
+	for _, doc := range docs {
+		e, err := fromEventDocument(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("ドメインモデル変換エラー: %w", err)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// TransitionStatus はステータスが from のままの場合のみステータスを更新する
+func (r *EventRepository) TransitionStatus(ctx context.Context, e *event.Event, from event.EventStatus) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": e.ID().Value(), "status": statusFilter(from), "is_deleted": bson.M{"$ne": true}},
+		bson.M{
+			"$set": bson.M{
+				"status":     string(e.Status()),
+				"updated_at": e.UpdatedAt(),
+				"updated_by": audit.ActorFrom(ctx),
+			},
+			"$inc": bson.M{"version": 1},
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("イベントのステータス更新エラー: %w", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// statusFilter はステータスの一致条件を返す。ステータス未設定の旧データは予定として扱う
+func statusFilter(status event.EventStatus) interface{} {
+	if status == event.EventStatusScheduled {
+		return bson.M{"$in": bson.A{string(status), nil}}
+	}
+	return string(status)
+}
+
 // EnsureIndexes は検索パフォーマンス向上のためのインデックスを作成
 func (r *EventRepository) EnsureIndexes(ctx context.Context) error {
 	indexes := []mongo.IndexModel{
@@ -423,12 +777,35 @@ func (r *EventRepository) EnsureIndexes(ctx context.Context) error {
 				{Key: "performer_ids", Value: 1},
 			},
 		},
+		// セットリストの収録曲インデックス（楽曲ごとの演奏イベント検索用）
+		{
+			Keys: bson.D{
+				{Key: "setlist.release_id", Value: 1},
+				{Key: "setlist.track_number", Value: 1},
+			},
+		},
+		// ツアーIDインデックス（公演日程の一覧用）
+		{
+			Keys: bson.D{
+				{Key: "series_id", Value: 1},
+				{Key: "start_date_time", Value: 1},
+			},
+		},
 		// タグインデックス
 		{
 			Keys: bson.D{
 				{Key: "tags", Value: 1},
 			},
 		},
+		// アーカイブ視聴期限インデックス（配信イベントのみ）
+		{
+			Keys: bson.D{
+				{Key: "streaming.archive_until", Value: 1},
+			},
+			Options: options.Index().SetSparse(true),
+		},
+		// 正規化検索キーインデックス（横断検索の表記ゆれ照合用）
+		searchKeysIndex("idx_event_search_keys"),
 		// 作成日時インデックス（デフォルトソート用）
 		{
 			Keys: bson.D{
@@ -471,6 +848,13 @@ func (r *EventRepository) EnsureIndexes(ctx context.Context) error {
 				{Key: "created_at", Value: -1},
 			},
 		},
+		// ステータス + 開始日時（終了時刻を過ぎたイベントの自動終了用）
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "start_date_time", Value: 1},
+			},
+		},
 	}
 
 	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
@@ -480,3 +864,8 @@ func (r *EventRepository) EnsureIndexes(ctx context.Context) error {
 
 	return nil
 }
+
+// BackfillSearchKeys は検索キーが未生成または古い版の既存イベントに正規化キーを生成する
+func (r *EventRepository) BackfillSearchKeys(ctx context.Context) (int, error) {
+	return backfillSearchKeys(ctx, r.collection, "title")
+}
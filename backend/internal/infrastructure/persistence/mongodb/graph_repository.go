@@ -0,0 +1,402 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/graph"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// graphQueryLimit は関係グラフの1クエリで取得するドキュメント数の上限
+const graphQueryLimit = 2000
+
+// graphLabelSources はノード種別ごとのコレクションと表示名フィールド
+var graphLabelSources = map[graph.NodeType]struct {
+	collection string
+	labelField string
+	objectID   bool // _id が ObjectID のコレクション
+}{
+	graph.NodeIdol:    {collection: "idols", labelField: "name", objectID: true},
+	graph.NodeGroup:   {collection: "groups", labelField: "name", objectID: true},
+	graph.NodeAgency:  {collection: "agencies", labelField: "name"},
+	graph.NodeRelease: {collection: "releases", labelField: "title", objectID: true},
+	graph.NodeEvent:   {collection: "events", labelField: "title"},
+}
+
+// GraphRepository はメンバーシップ・所属事務所・リリース・イベント出演から関係グラフを組み立てるリポジトリ
+type GraphRepository struct {
+	db *mongo.Database
+}
+
+// NewGraphRepository は GraphRepository を作成する
+func NewGraphRepository(db *mongo.Database) *GraphRepository {
+	return &GraphRepository{db: db}
+}
+
+// graphRefs はノード参照を種別ごとのIDに分けたもの
+type graphRefs struct {
+	idols    []string
+	groups   []string
+	agencies []string
+	releases []string
+	events   []string
+}
+
+func splitGraphRefs(refs []graph.NodeRef) graphRefs {
+	var s graphRefs
+	for _, ref := range refs {
+		switch ref.Type {
+		case graph.NodeIdol:
+			s.idols = append(s.idols, ref.ID)
+		case graph.NodeGroup:
+			s.groups = append(s.groups, ref.ID)
+		case graph.NodeAgency:
+			s.agencies = append(s.agencies, ref.ID)
+		case graph.NodeRelease:
+			s.releases = append(s.releases, ref.ID)
+		case graph.NodeEvent:
+			s.events = append(s.events, ref.ID)
+		}
+	}
+	return s
+}
+
+// FindEdges は指定ノードのいずれかに接続するエッジを返す
+func (r *GraphRepository) FindEdges(ctx context.Context, refs []graph.NodeRef) ([]graph.Edge, error) {
+	s := splitGraphRefs(refs)
+
+	var edges []graph.Edge
+	for _, find := range []func(context.Context, graphRefs) ([]graph.Edge, error){
+		r.membershipEdges,
+		r.affiliationEdges,
+		r.releaseEdges,
+		r.eventEdges,
+	} {
+		found, err := find(ctx, s)
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, found...)
+	}
+
+	// リリース・イベント経由で取得した、指定ノードに接続しないエッジを除く
+	requested := make(map[graph.NodeRef]bool, len(refs))
+	for _, ref := range refs {
+		requested[ref] = true
+	}
+	result := edges[:0]
+	for _, e := range edges {
+		if requested[e.From] || requested[e.To] {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (r *GraphRepository) membershipEdges(ctx context.Context, s graphRefs) ([]graph.Edge, error) {
+	var or bson.A
+	if len(s.idols) > 0 {
+		or = append(or, bson.M{"idol_id": bson.M{"$in": s.idols}})
+	}
+	if len(s.groups) > 0 {
+		or = append(or, bson.M{"group_id": bson.M{"$in": s.groups}})
+	}
+	if len(or) == 0 {
+		return nil, nil
+	}
+
+	var docs []struct {
+		IdolID   string     `bson:"idol_id"`
+		GroupID  string     `bson:"group_id"`
+		Role     string     `bson:"role"`
+		JoinedAt *time.Time `bson:"joined_at"`
+		LeftAt   *time.Time `bson:"left_at"`
+	}
+	if err := r.findAll(ctx, "memberships", bson.M{"is_deleted": bson.M{"$ne": true}, "$or": or}, &docs); err != nil {
+		return nil, fmt.Errorf("メンバーシップの取得エラー: %w", err)
+	}
+
+	edges := make([]graph.Edge, 0, len(docs))
+	for _, d := range docs {
+		edges = append(edges, graph.Edge{
+			From:  graph.NodeRef{Type: graph.NodeIdol, ID: d.IdolID},
+			To:    graph.NodeRef{Type: graph.NodeGroup, ID: d.GroupID},
+			Kind:  graph.EdgeMemberOf,
+			Role:  d.Role,
+			Since: d.JoinedAt,
+			Until: d.LeftAt,
+		})
+	}
+	return edges, nil
+}
+
+// affiliationEdges はアイドル・グループの agency_id から所属事務所のエッジを返す
+func (r *GraphRepository) affiliationEdges(ctx context.Context, s graphRefs) ([]graph.Edge, error) {
+	var edges []graph.Edge
+	for _, target := range []struct {
+		nodeType   graph.NodeType
+		collection string
+		ids        []string
+	}{
+		{graph.NodeIdol, "idols", s.idols},
+		{graph.NodeGroup, "groups", s.groups},
+	} {
+		var or bson.A
+		if objectIDs := toObjectIDs(target.ids); len(objectIDs) > 0 {
+			or = append(or, bson.M{"_id": bson.M{"$in": objectIDs}})
+		}
+		if len(s.agencies) > 0 {
+			or = append(or, bson.M{"agency_id": bson.M{"$in": s.agencies}})
+		}
+		if len(or) == 0 {
+			continue
+		}
+
+		var docs []struct {
+			ID       bson.ObjectID `bson:"_id"`
+			AgencyID *string       `bson:"agency_id"`
+		}
+		filter := bson.M{"is_deleted": bson.M{"$ne": true}, "agency_id": bson.M{"$nin": bson.A{nil, ""}}, "$or": or}
+		if err := r.findAll(ctx, target.collection, filter, &docs); err != nil {
+			return nil, fmt.Errorf("所属事務所の取得エラー: %w", err)
+		}
+		for _, d := range docs {
+			edges = append(edges, graph.Edge{
+				From: graph.NodeRef{Type: target.nodeType, ID: d.ID.Hex()},
+				To:   graph.NodeRef{Type: graph.NodeAgency, ID: *d.AgencyID},
+				Kind: graph.EdgeAffiliatedWith,
+			})
+		}
+	}
+	return edges, nil
+}
+
+// releaseEdges はリリースのアーティスト参照と楽曲参加者からエッジを返す
+func (r *GraphRepository) releaseEdges(ctx context.Context, s graphRefs) ([]graph.Edge, error) {
+	var or bson.A
+	if artists := append(append([]string{}, s.idols...), s.groups...); len(artists) > 0 {
+		or = append(or, bson.M{"artists.id": bson.M{"$in": artists}})
+	}
+	if len(s.idols) > 0 {
+		or = append(or, bson.M{"tracks.participants.idol_id": bson.M{"$in": s.idols}})
+	}
+	if objectIDs := toObjectIDs(s.releases); len(objectIDs) > 0 {
+		or = append(or, bson.M{"_id": bson.M{"$in": objectIDs}})
+	}
+	if len(or) == 0 {
+		return nil, nil
+	}
+
+	var docs []struct {
+		ID          bson.ObjectID       `bson:"_id"`
+		ReleaseDate time.Time           `bson:"release_date"`
+		Artists     []artistRefDocument `bson:"artists"`
+		Tracks      []struct {
+			Participants []trackParticipantDocument `bson:"participants"`
+		} `bson:"tracks"`
+	}
+	if err := r.findAll(ctx, "releases", bson.M{"is_deleted": bson.M{"$ne": true}, "$or": or}, &docs); err != nil {
+		return nil, fmt.Errorf("リリースの取得エラー: %w", err)
+	}
+
+	var edges []graph.Edge
+	for _, d := range docs {
+		release := graph.NodeRef{Type: graph.NodeRelease, ID: d.ID.Hex()}
+		releaseDate := d.ReleaseDate
+		for _, a := range d.Artists {
+			edges = append(edges, graph.Edge{
+				From: graph.NodeRef{Type: graph.NodeType(a.Kind), ID: a.ID},
+				To:   release,
+				Kind: graph.EdgeCreditedOn,
+				Role: a.Role,
+				At:   &releaseDate,
+			})
+		}
+		// 複数の楽曲に参加していても1本のエッジにまとめる
+		participated := make(map[string]bool)
+		for _, t := range d.Tracks {
+			for _, p := range t.Participants {
+				if p.Status != "participating" || participated[p.IdolID] {
+					continue
+				}
+				participated[p.IdolID] = true
+				edges = append(edges, graph.Edge{
+					From: graph.NodeRef{Type: graph.NodeIdol, ID: p.IdolID},
+					To:   release,
+					Kind: graph.EdgeParticipatedIn,
+					At:   &releaseDate,
+				})
+			}
+		}
+	}
+	return edges, nil
+}
+
+// eventEdges はイベントの出演者からエッジを返す。
+// 出演者IDはアイドル・グループのどちらも取りうるため、指定ノード以外は種別を解決する
+func (r *GraphRepository) eventEdges(ctx context.Context, s graphRefs) ([]graph.Edge, error) {
+	known := make(map[string]graph.NodeType)
+	for _, id := range s.idols {
+		known[id] = graph.NodeIdol
+	}
+	for _, id := range s.groups {
+		known[id] = graph.NodeGroup
+	}
+
+	var or bson.A
+	if len(known) > 0 {
+		ids := make([]string, 0, len(known))
+		for id := range known {
+			ids = append(ids, id)
+		}
+		or = append(or, bson.M{"performers.performer_id": bson.M{"$in": ids}})
+	}
+	if len(s.events) > 0 {
+		or = append(or, bson.M{"_id": bson.M{"$in": s.events}})
+	}
+	if len(or) == 0 {
+		return nil, nil
+	}
+
+	var docs []struct {
+		ID            string              `bson:"_id"`
+		StartDateTime time.Time           `bson:"start_date_time"`
+		Performers    []performerDocument `bson:"performers"`
+	}
+	if err := r.findAll(ctx, "events", bson.M{"is_deleted": bson.M{"$ne": true}, "$or": or}, &docs); err != nil {
+		return nil, fmt.Errorf("イベントの取得エラー: %w", err)
+	}
+
+	var unknown []string
+	for _, d := range docs {
+		for _, p := range d.Performers {
+			if _, ok := known[p.PerformerID]; !ok {
+				unknown = append(unknown, p.PerformerID)
+			}
+		}
+	}
+	if err := r.resolvePerformerTypes(ctx, unknown, known); err != nil {
+		return nil, err
+	}
+
+	var edges []graph.Edge
+	for _, d := range docs {
+		start := d.StartDateTime
+		for _, p := range d.Performers {
+			nodeType, ok := known[p.PerformerID]
+			if !ok {
+				continue
+			}
+			edges = append(edges, graph.Edge{
+				From: graph.NodeRef{Type: nodeType, ID: p.PerformerID},
+				To:   graph.NodeRef{Type: graph.NodeEvent, ID: d.ID},
+				Kind: graph.EdgePerformedAt,
+				Role: p.BillingStatus,
+				At:   &start,
+			})
+		}
+	}
+	return edges, nil
+}
+
+// resolvePerformerTypes は出演者IDがアイドル・グループのどちらかを調べて known に追加する。
+// どちらにも存在しないIDは追加しない
+func (r *GraphRepository) resolvePerformerTypes(ctx context.Context, ids []string, known map[string]graph.NodeType) error {
+	objectIDs := toObjectIDs(ids)
+	if len(objectIDs) == 0 {
+		return nil
+	}
+	for _, target := range []struct {
+		nodeType   graph.NodeType
+		collection string
+	}{
+		{graph.NodeIdol, "idols"},
+		{graph.NodeGroup, "groups"},
+	} {
+		var docs []struct {
+			ID bson.ObjectID `bson:"_id"`
+		}
+		if err := r.findAll(ctx, target.collection, bson.M{"_id": bson.M{"$in": objectIDs}}, &docs); err != nil {
+			return fmt.Errorf("出演者の種別解決エラー: %w", err)
+		}
+		for _, d := range docs {
+			known[d.ID.Hex()] = target.nodeType
+		}
+	}
+	return nil
+}
+
+// FindLabels は存在するノードの表示名を返す
+func (r *GraphRepository) FindLabels(ctx context.Context, refs []graph.NodeRef) (map[graph.NodeRef]string, error) {
+	byType := make(map[graph.NodeType][]string)
+	for _, ref := range refs {
+		byType[ref.Type] = append(byType[ref.Type], ref.ID)
+	}
+
+	labels := make(map[graph.NodeRef]string, len(refs))
+	for nodeType, ids := range byType {
+		source, ok := graphLabelSources[nodeType]
+		if !ok {
+			continue
+		}
+		var in any = ids
+		if source.objectID {
+			objectIDs := toObjectIDs(ids)
+			if len(objectIDs) == 0 {
+				continue
+			}
+			in = objectIDs
+		}
+
+		cursor, err := r.db.Collection(source.collection).Find(ctx,
+			bson.M{"_id": bson.M{"$in": in}, "is_deleted": bson.M{"$ne": true}},
+			options.Find().SetProjection(bson.M{"_id": 1, source.labelField: 1}))
+		if err != nil {
+			return nil, fmt.Errorf("%s の表示名取得エラー: %w", nodeType, err)
+		}
+		var docs []bson.M
+		if err := cursor.All(ctx, &docs); err != nil {
+			return nil, fmt.Errorf("データ変換エラー: %w", err)
+		}
+		for _, doc := range docs {
+			label, _ := doc[source.labelField].(string)
+			labels[graph.NodeRef{Type: nodeType, ID: documentID(doc["_id"])}] = label
+		}
+	}
+	return labels, nil
+}
+
+// findAll は filter に一致するドキュメントを _id 順に results（スライスへのポインタ）へ読み込む。
+// graphQueryLimit 件を超える場合は一部だけで隣接関係を組み立てないよう graph.ErrTooManyEdges を返す
+func (r *GraphRepository) findAll(ctx context.Context, collection string, filter bson.M, results any) error {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(graphQueryLimit + 1)
+	cursor, err := r.db.Collection(collection).Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	if err := cursor.All(ctx, results); err != nil {
+		return fmt.Errorf("データ変換エラー: %w", err)
+	}
+	if reflect.ValueOf(results).Elem().Len() > graphQueryLimit {
+		return graph.ErrTooManyEdges
+	}
+	return nil
+}
+
+// toObjectIDs は16進文字列のIDを ObjectID に変換する。形式が不正なIDは除外する
+func toObjectIDs(ids []string) bson.A {
+	var objectIDs bson.A
+	for _, id := range ids {
+		if objectID, err := bson.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+	return objectIDs
+}
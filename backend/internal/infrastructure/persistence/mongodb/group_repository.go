@@ -8,6 +8,7 @@ import (
 
 	"github.com/kuro48/idol-api/internal/domain/group"
 	"github.com/kuro48/idol-api/internal/shared/audit"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -51,7 +52,7 @@ func (r *GroupRepository) FindWithPagination(ctx context.Context, opts group.Sea
 	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
 
 	if opts.Name != nil {
-		filter["name"] = bson.M{"$regex": safePartialMatchRegex(*opts.Name), "$options": "i"}
+		filter["$or"] = nameSearchConditions(*opts.Name, "name", formerNamesField)
 	}
 
 	total, err := r.collection.CountDocuments(ctx, filter)
@@ -75,6 +76,12 @@ func (r *GroupRepository) FindWithPagination(ctx context.Context, opts group.Sea
 		SetSort(bson.D{{Key: sortField, Value: sortOrder}}).
 		SetSkip(skip).
 		SetLimit(limit)
+	if sortField == nameKanaField {
+		findOptions.SetCollation(japaneseCollation())
+	}
+	if projection := omitProjection(opts.Omit, "name_history"); projection != nil {
+		findOptions.SetProjection(projection)
+	}
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -102,6 +109,37 @@ func (r *GroupRepository) FindWithPagination(ctx context.Context, opts group.Sea
 	return &group.SearchResult{Groups: groups, Total: total}, nil
 }
 
+// FindByParentID は指定したグループを親に持つグループを結成日順に検索する
+func (r *GroupRepository) FindByParentID(ctx context.Context, parentID group.GroupID, relationType *group.RelationType) ([]*group.Group, error) {
+	filter := bson.M{"parent_group_id": parentID.Value(), "is_deleted": bson.M{"$ne": true}}
+	if relationType != nil {
+		filter["relation_type"] = string(*relationType)
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "formation_date", Value: 1}, {Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("子グループ一覧の取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	groups := []*group.Group{}
+	for cursor.Next(ctx) {
+		var doc groupDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("グループデコードエラー: %w", err)
+		}
+		g, err := toGroupDomain(&doc)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("カーソルエラー: %w", err)
+	}
+	return groups, nil
+}
+
 // Update implements group.Repository.
 func (r *GroupRepository) Update(ctx context.Context, g *group.Group) error {
 	doc, err := toGroupDocument(g)
@@ -139,26 +177,33 @@ func NewGroupRepository(db *mongo.Database) *GroupRepository {
 }
 
 type groupDocument struct {
-	ID            bson.ObjectID     `bson:"_id,omitempty"`
-	Name          string            `bson:"name"`
-	NameKana      *string           `bson:"name_kana,omitempty"`
-	NameLatin     *string           `bson:"name_latin,omitempty"`
-	Status        string            `bson:"status,omitempty"`
-	FormationDate *time.Time        `bson:"formation_date,omitempty"`
-	DisbandDate   *time.Time        `bson:"disband_date,omitempty"`
-	AgencyID      *string           `bson:"agency_id,omitempty"`
-	LogoURL       *string           `bson:"logo_url,omitempty"`
-	ExternalIDs   map[string]string `bson:"external_ids,omitempty"`
-	Sources       []sourceDocument  `bson:"sources,omitempty"`
-	Version       int               `bson:"version"`
-	CreatedAt     time.Time         `bson:"created_at"`
-	UpdatedAt     time.Time         `bson:"updated_at"`
-	CreatedBy     string            `bson:"created_by,omitempty"`
-	UpdatedBy     string            `bson:"updated_by,omitempty"`
-	Source        string            `bson:"source,omitempty"`
-	IsDeleted     bool              `bson:"is_deleted,omitempty"`
-	DeletedAt     *time.Time        `bson:"deleted_at,omitempty"`
-	DeletedBy     string            `bson:"deleted_by,omitempty"`
+	ID                bson.ObjectID         `bson:"_id,omitempty"`
+	Name              string                `bson:"name"`
+	NameKana          *string               `bson:"name_kana,omitempty"`
+	NameLatin         *string               `bson:"name_latin,omitempty"`
+	NameHistory       []nameHistoryDocument `bson:"name_history,omitempty"`
+	FormerNames       []string              `bson:"former_names,omitempty"`
+	Status            string                `bson:"status,omitempty"`
+	FormationDate     *time.Time            `bson:"formation_date,omitempty"`
+	FormationMonthDay *string               `bson:"formation_month_day,omitempty"` // MM-DD（結成記念日検索用）
+	DisbandDate       *time.Time            `bson:"disband_date,omitempty"`
+	AgencyID          *string               `bson:"agency_id,omitempty"`
+	ParentGroupID     *string               `bson:"parent_group_id,omitempty"`
+	RelationType      string                `bson:"relation_type,omitempty"`
+	LogoURL           *string               `bson:"logo_url,omitempty"`
+	ExternalIDs       map[string]string     `bson:"external_ids,omitempty"`
+	SearchKeys        []string              `bson:"search_keys"`
+	SearchKeysVersion int                   `bson:"search_keys_version"`
+	Sources           []sourceDocument      `bson:"sources,omitempty"`
+	Version           int                   `bson:"version"`
+	CreatedAt         time.Time             `bson:"created_at"`
+	UpdatedAt         time.Time             `bson:"updated_at"`
+	CreatedBy         string                `bson:"created_by,omitempty"`
+	UpdatedBy         string                `bson:"updated_by,omitempty"`
+	Source            string                `bson:"source,omitempty"`
+	IsDeleted         bool                  `bson:"is_deleted,omitempty"`
+	DeletedAt         *time.Time            `bson:"deleted_at,omitempty"`
+	DeletedBy         string                `bson:"deleted_by,omitempty"`
 }
 
 func toGroupDocument(g *group.Group) (*groupDocument, error) {
@@ -188,20 +233,35 @@ func toGroupDocument(g *group.Group) (*groupDocument, error) {
 		}
 	}
 
+	var parentGroupID *string
+	var relationType string
+	if p := g.Parent(); p != nil {
+		id := p.GroupID().Value()
+		parentGroupID = &id
+		relationType = string(p.RelationType())
+	}
+
 	return &groupDocument{
-		ID:            objectID,
-		Name:          g.Name().Value(),
-		NameKana:      g.Name().Kana(),
-		NameLatin:     g.Name().Latin(),
-		Status:        string(g.Status()),
-		FormationDate: formationDate,
-		DisbandDate:   disbandDate,
-		AgencyID:      g.AgencyID(),
-		LogoURL:       g.LogoURL(),
-		ExternalIDs:   externalIDsDoc,
-		Sources:       toSourceDocuments(g.Sources()),
-		CreatedAt:     g.CreatedAt(),
-		UpdatedAt:     g.UpdatedAt(),
+		ID:                objectID,
+		Name:              g.Name().Value(),
+		NameKana:          g.Name().Kana(),
+		NameLatin:         g.Name().Latin(),
+		NameHistory:       toNameHistoryDocuments(g.NameHistory()),
+		FormerNames:       g.NameHistory().Names(),
+		Status:            string(g.Status()),
+		FormationDate:     formationDate,
+		FormationMonthDay: monthDayValue(formationDate),
+		DisbandDate:       disbandDate,
+		AgencyID:          g.AgencyID(),
+		ParentGroupID:     parentGroupID,
+		RelationType:      relationType,
+		LogoURL:           g.LogoURL(),
+		ExternalIDs:       externalIDsDoc,
+		SearchKeys:        searchkey.Keys(stringValues(g.Name().Value(), g.Name().Kana(), g.Name().Latin(), g.NameHistory().Names())...),
+		SearchKeysVersion: searchKeysVersion,
+		Sources:           toSourceDocuments(g.Sources()),
+		CreatedAt:         g.CreatedAt(),
+		UpdatedAt:         g.UpdatedAt(),
 	}, nil
 }
 
@@ -255,12 +315,28 @@ func toGroupDomain(doc *groupDocument) (*group.Group, error) {
 		externalIDs = group.ReconstructExternalIDs(typedIDs)
 	}
 
+	var parent *group.ParentRelation
+	if doc.ParentGroupID != nil {
+		parentID, err := group.NewGroupID(*doc.ParentGroupID)
+		if err != nil {
+			return nil, err
+		}
+		relationType, err := group.NewRelationType(doc.RelationType)
+		if err != nil {
+			return nil, fmt.Errorf("無効な関係種別 %q: %w", doc.RelationType, err)
+		}
+		p, err := group.NewParentRelation(parentID, relationType)
+		if err != nil {
+			return nil, err
+		}
+		parent = &p
+	}
+
 	sources := fromSourceDocuments(doc.Sources)
 
-	return group.Reconstruct(id, name, status, formationDate, disbandDate, doc.AgencyID, doc.LogoURL, externalIDs, sources, doc.CreatedAt, doc.UpdatedAt), nil
+	return group.Reconstruct(id, name, fromNameHistoryDocuments(doc.NameHistory), status, formationDate, disbandDate, doc.AgencyID, parent, doc.LogoURL, externalIDs, sources, doc.CreatedAt, doc.UpdatedAt), nil
 }
 
-
 func (r *GroupRepository) Save(ctx context.Context, g *group.Group) error {
 	doc, err := toGroupDocument(g)
 	if err != nil {
@@ -379,6 +455,61 @@ func (r *GroupRepository) ExistsByName(ctx context.Context, name group.GroupName
 	return count > 0, nil
 }
 
+// FindByFormationMonthDay は条件に合うグループを月日・名前順に offset 件目から limit 件まで検索する
+func (r *GroupRepository) FindByFormationMonthDay(ctx context.Context, criteria group.AnniversaryCriteria, offset, limit int) ([]*group.Group, error) {
+	filter := anniversaryFilter(criteria)
+
+	// ページをまたいで取りこぼさないよう _id で順序を確定させる
+	opts := options.Find().
+		SetSort(bson.D{{Key: formationMonthDayField, Value: 1}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("結成記念日検索エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	groups := []*group.Group{}
+	for cursor.Next(ctx) {
+		var doc groupDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("グループデコードエラー: %w", err)
+		}
+		g, err := toGroupDomain(&doc)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("カーソルエラー: %w", err)
+	}
+	return groups, nil
+}
+
+// CountByFormationMonthDay は条件に合うグループの件数を返す
+func (r *GroupRepository) CountByFormationMonthDay(ctx context.Context, criteria group.AnniversaryCriteria) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, anniversaryFilter(criteria))
+	if err != nil {
+		return 0, fmt.Errorf("結成記念日件数取得エラー: %w", err)
+	}
+	return count, nil
+}
+
+// anniversaryFilter は結成記念日の検索条件を MongoDB のフィルタに変換する
+func anniversaryFilter(criteria group.AnniversaryCriteria) bson.M {
+	filter := monthDayFilter(formationMonthDayField, criteria.Range)
+	formed := bson.M{"$lt": criteria.FormedBefore}
+	if criteria.FormedFrom != nil {
+		formed["$gte"] = *criteria.FormedFrom
+	}
+	filter["formation_date"] = formed
+	filter["is_deleted"] = bson.M{"$ne": true}
+	return filter
+}
+
 // EnsureIndexes はMongoDBのインデックスを作成する
 func (r *GroupRepository) EnsureIndexes(ctx context.Context) error {
 	indexes := []mongo.IndexModel{
@@ -388,12 +519,36 @@ func (r *GroupRepository) EnsureIndexes(ctx context.Context) error {
 				{Key: "name", Value: 1},
 			},
 		},
+		// 正規化検索キーインデックス（かな/ローマ字の表記ゆれ検索用）
+		searchKeysIndex("idx_group_search_keys"),
+		// 読み仮名インデックス（五十音順ソート用）
+		nameKanaSortIndex("idx_group_name_kana"),
 		// 結成日インデックス（時系列検索用）
 		{
 			Keys: bson.D{
 				{Key: "formation_date", Value: 1},
 			},
 		},
+		// 結成日の月日インデックス（年を問わない結成記念日検索用）
+		{
+			Keys: bson.D{
+				{Key: formationMonthDayField, Value: 1},
+			},
+			Options: options.Index().SetSparse(true),
+		},
+		// 事務所IDインデックス（関係グラフの所属事務所検索用）
+		{
+			Keys: bson.D{
+				{Key: "agency_id", Value: 1},
+			},
+		},
+		// 親グループインデックス（サブユニット・姉妹グループ一覧用）
+		{
+			Keys: bson.D{
+				{Key: "parent_group_id", Value: 1},
+				{Key: "relation_type", Value: 1},
+			},
+		},
 		// 作成日時インデックス（デフォルトソート用）
 		{
 			Keys: bson.D{
@@ -416,3 +571,13 @@ func (r *GroupRepository) EnsureIndexes(ctx context.Context) error {
 
 	return nil
 }
+
+// BackfillSearchKeys は検索キーが未生成または古い版の既存グループに正規化キーを生成する
+func (r *GroupRepository) BackfillSearchKeys(ctx context.Context) (int, error) {
+	return backfillSearchKeys(ctx, r.collection, "name", "name_kana", "name_latin", formerNamesField)
+}
+
+// BackfillMonthDays は結成日の月日を持たない既存グループに月日を生成する
+func (r *GroupRepository) BackfillMonthDays(ctx context.Context) (int, error) {
+	return backfillMonthDay(ctx, r.collection, "formation_date", formationMonthDayField)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/kuro48/idol-api/internal/domain/idol"
 	"github.com/kuro48/idol-api/internal/shared/audit"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
 	src "github.com/kuro48/idol-api/internal/shared/source"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
@@ -39,6 +40,7 @@ type idolDocument struct {
 	NameKana    *string              `bson:"name_kana,omitempty"`
 	NameLatin   *string              `bson:"name_latin,omitempty"`
 	Birthdate   *time.Time           `bson:"birthdate,omitempty"`
+	BirthMonthDay *string            `bson:"birth_month_day,omitempty"` // MM-DD（誕生日検索用）
 	Status      string               `bson:"status,omitempty"`
 	AgencyID        *string              `bson:"agency_id,omitempty"`
 	ProfileImageURL *string              `bson:"profile_image_url,omitempty"`
@@ -46,6 +48,10 @@ type idolDocument struct {
 	ExternalIDs map[string]string    `bson:"external_ids,omitempty"`
 	TagIDs      []string             `bson:"tag_ids,omitempty"`
 	Aliases     []string             `bson:"aliases,omitempty"`
+	NameHistory []nameHistoryDocument `bson:"name_history,omitempty"`
+	FormerNames []string             `bson:"former_names,omitempty"`
+	SearchKeys  []string             `bson:"search_keys"`
+	SearchKeysVersion int `bson:"search_keys_version"`
 	Sources     []sourceDocument     `bson:"sources,omitempty"`
 	Version     int                  `bson:"version"`
 	CreatedAt   time.Time            `bson:"created_at"`
@@ -112,6 +118,7 @@ func toIdolDocument(i *idol.Idol) (*idolDocument, error) {
 		NameKana:    i.Name().Kana(),
 		NameLatin:   i.Name().Latin(),
 		Birthdate:   birthdate,
+		BirthMonthDay: monthDayValue(birthdate),
 		Status:          string(i.Status()),
 		AgencyID:        i.AgencyID(),
 		ProfileImageURL: i.ProfileImageURL(),
@@ -119,12 +126,21 @@ func toIdolDocument(i *idol.Idol) (*idolDocument, error) {
 		ExternalIDs: externalIDsDoc,
 		TagIDs:      i.TagIDs(),
 		Aliases:     i.Aliases(),
+		NameHistory: toNameHistoryDocuments(i.NameHistory()),
+		FormerNames: i.NameHistory().Names(),
+		SearchKeys:  idolSearchKeys(i),
+		SearchKeysVersion: searchKeysVersion,
 		Sources:     sourceDocs,
 		CreatedAt:   i.CreatedAt(),
 		UpdatedAt:   i.UpdatedAt(),
 	}, nil
 }
 
+// idolSearchKeys は名前・読み・ラテン表記・別名・旧芸名から検索キーを生成する
+func idolSearchKeys(i *idol.Idol) []string {
+	return searchkey.Keys(stringValues(i.Name().Value(), i.Name().Kana(), i.Name().Latin(), i.Aliases(), i.NameHistory().Names())...)
+}
+
 // toSourceDocuments は出典スライスをドキュメントに変換する
 func toSourceDocuments(sources []src.Source) []sourceDocument {
 	if len(sources) == 0 {
@@ -225,7 +241,7 @@ func toDomain(doc *idolDocument) (*idol.Idol, error) {
 
 	sources := fromSourceDocuments(doc.Sources)
 
-	return idol.Reconstruct(id, name, birthdate, status, doc.AgencyID, doc.ProfileImageURL, socialLinks, externalIDs, tagIDs, aliases, sources, doc.CreatedAt, doc.UpdatedAt), nil
+	return idol.Reconstruct(id, name, fromNameHistoryDocuments(doc.NameHistory), birthdate, status, doc.AgencyID, doc.ProfileImageURL, socialLinks, externalIDs, tagIDs, aliases, sources, doc.CreatedAt, doc.UpdatedAt), nil
 }
 
 // toSocialLinksDomain はドキュメントからSocialLinksドメインモデルを作成する
@@ -347,11 +363,17 @@ func (r *IdolRepository) Update(ctx context.Context, i *idol.Idol) error {
 	setFields := bson.M{
 		"name":       doc.Name,
 		"birthdate":  doc.Birthdate,
+		birthMonthDayField: doc.BirthMonthDay,
 		"agency_id":  doc.AgencyID,
 		"updated_at": doc.UpdatedAt,
 		"updated_by": audit.ActorFrom(ctx),
 		"aliases":    doc.Aliases,
 	}
+	setFields["name_history"] = doc.NameHistory
+	setFields[formerNamesField] = doc.FormerNames
+	setFields[nameKanaField] = doc.NameKana
+	setFields[searchKeysField] = doc.SearchKeys
+	setFields[searchKeysVersionField] = doc.SearchKeysVersion
 	if doc.ExternalIDs != nil {
 		setFields["external_ids"] = doc.ExternalIDs
 	}
@@ -477,10 +499,16 @@ func (r *IdolRepository) Search(ctx context.Context, criteria idol.SearchCriteri
 		sortOrder = -1
 	}
 	opts.SetSort(bson.D{{Key: criteria.Sort, Value: sortOrder}})
+	if criteria.Sort == nameKanaField {
+		opts.SetCollation(japaneseCollation())
+	}
 
 	// ページネーション
 	opts.SetSkip(int64(criteria.Offset))
 	opts.SetLimit(int64(criteria.Limit))
+	if projection := omitProjection(criteria.Omit, "social_links", "external_ids", "aliases"); projection != nil {
+		opts.SetProjection(projection)
+	}
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -508,14 +536,9 @@ func (r *IdolRepository) Search(ctx context.Context, criteria idol.SearchCriteri
 func buildMongoFilter(criteria idol.SearchCriteria) bson.M {
 	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
 
-	// 名前検索（部分一致）: name フィールドまたは aliases フィールドにマッチ
-	// ReDoS対策として正規表現メタ文字をエスケープする
+	// 名前検索（部分一致）: 正規化キー、name フィールドまたは aliases フィールドにマッチ
 	if criteria.Name != nil {
-		nameRegex := bson.M{"$regex": safePartialMatchRegex(*criteria.Name), "$options": "i"}
-		filter["$or"] = bson.A{
-			bson.M{"name": nameRegex},
-			bson.M{"aliases": nameRegex},
-		}
+		filter["$or"] = nameSearchConditions(*criteria.Name, "name", "aliases", formerNamesField)
 	}
 
 	// 事務所ID
@@ -578,6 +601,55 @@ func (r *IdolRepository) FindByExternalID(ctx context.Context, kind idol.Externa
 	return toDomain(&doc)
 }
 
+// FindByBirthMonthDay は条件に合うアイドルを月日・名前順に offset 件目から limit 件まで検索する
+func (r *IdolRepository) FindByBirthMonthDay(ctx context.Context, criteria idol.BirthdayCriteria, offset, limit int) ([]*idol.Idol, error) {
+	filter := birthdayFilter(criteria)
+
+	// ページをまたいで取りこぼさないよう _id で順序を確定させる
+	opts := options.Find().
+		SetSort(bson.D{{Key: birthMonthDayField, Value: 1}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("誕生日検索エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []idolDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("誕生日検索エラー: %w", err)
+	}
+
+	idols := make([]*idol.Idol, 0, len(docs))
+	for _, doc := range docs {
+		i, err := toDomain(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("ドメインモデル変換エラー: %w", err)
+		}
+		idols = append(idols, i)
+	}
+	return idols, nil
+}
+
+// CountByBirthMonthDay は条件に合うアイドルの件数を返す
+func (r *IdolRepository) CountByBirthMonthDay(ctx context.Context, criteria idol.BirthdayCriteria) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, birthdayFilter(criteria))
+	if err != nil {
+		return 0, fmt.Errorf("誕生日件数取得エラー: %w", err)
+	}
+	return count, nil
+}
+
+// birthdayFilter は誕生日の検索条件を MongoDB のフィルタに変換する
+func birthdayFilter(criteria idol.BirthdayCriteria) bson.M {
+	filter := monthDayFilter(birthMonthDayField, criteria.Range)
+	filter["birthdate"] = bson.M{"$lt": criteria.BornBefore}
+	filter["is_deleted"] = bson.M{"$ne": true}
+	return filter
+}
+
 // EnsureIndexes は検索パフォーマンス向上のためのインデックスを作成
 func (r *IdolRepository) EnsureIndexes(ctx context.Context) error {
 	indexes := []mongo.IndexModel{
@@ -599,6 +671,13 @@ func (r *IdolRepository) EnsureIndexes(ctx context.Context) error {
 				{Key: "birthdate", Value: 1},
 			},
 		},
+		// 誕生日の月日インデックス（年を問わない誕生日検索用）
+		{
+			Keys: bson.D{
+				{Key: birthMonthDayField, Value: 1},
+			},
+			Options: options.Index().SetSparse(true),
+		},
 		// 作成日時インデックス（デフォルトソート用）
 		{
 			Keys: bson.D{
@@ -617,6 +696,10 @@ func (r *IdolRepository) EnsureIndexes(ctx context.Context) error {
 				{Key: "aliases", Value: 1},
 			},
 		},
+		// 正規化検索キーインデックス（かな/ローマ字の表記ゆれ検索用）
+		searchKeysIndex("idx_idol_search_keys"),
+		// 読み仮名インデックス（五十音順ソート用）
+		nameKanaSortIndex("idx_idol_name_kana"),
 		// 複合インデックス1: 事務所ID + 作成日時（事務所別一覧取得の最適化）
 		{
 			Keys: bson.D{
@@ -654,3 +737,13 @@ func (r *IdolRepository) EnsureIndexes(ctx context.Context) error {
 
 	return nil
 }
+
+// BackfillSearchKeys は検索キーが未生成または古い版の既存アイドルに正規化キーを生成する
+func (r *IdolRepository) BackfillSearchKeys(ctx context.Context) (int, error) {
+	return backfillSearchKeys(ctx, r.collection, "name", "name_kana", "name_latin", "aliases", formerNamesField)
+}
+
+// BackfillMonthDays は誕生日の月日を持たない既存アイドルに月日を生成する
+func (r *IdolRepository) BackfillMonthDays(ctx context.Context) (int, error) {
+	return backfillMonthDay(ctx, r.collection, "birthdate", birthMonthDayField)
+}
@@ -24,22 +24,23 @@ func NewMembershipRepository(db *mongo.Database) *MembershipRepository {
 }
 
 type membershipDocument struct {
-	ID        bson.ObjectID    `bson:"_id,omitempty"`
-	IdolID    string           `bson:"idol_id"`
-	GroupID   string           `bson:"group_id"`
-	Role      string           `bson:"role"`
-	JoinedAt  *time.Time       `bson:"joined_at,omitempty"`
-	LeftAt    *time.Time       `bson:"left_at,omitempty"`
-	Sources   []sourceDocument `bson:"sources,omitempty"`
-	Version   int              `bson:"version"`
-	CreatedAt time.Time        `bson:"created_at"`
-	UpdatedAt time.Time        `bson:"updated_at"`
-	CreatedBy string           `bson:"created_by,omitempty"`
-	UpdatedBy string           `bson:"updated_by,omitempty"`
-	Source    string           `bson:"source,omitempty"`
-	IsDeleted bool             `bson:"is_deleted,omitempty"`
-	DeletedAt *time.Time       `bson:"deleted_at,omitempty"`
-	DeletedBy string           `bson:"deleted_by,omitempty"`
+	ID         bson.ObjectID    `bson:"_id,omitempty"`
+	IdolID     string           `bson:"idol_id"`
+	GroupID    string           `bson:"group_id"`
+	Role       string           `bson:"role"`
+	Generation *string          `bson:"generation,omitempty"`
+	JoinedAt   *time.Time       `bson:"joined_at,omitempty"`
+	LeftAt     *time.Time       `bson:"left_at,omitempty"`
+	Sources    []sourceDocument `bson:"sources,omitempty"`
+	Version    int              `bson:"version"`
+	CreatedAt  time.Time        `bson:"created_at"`
+	UpdatedAt  time.Time        `bson:"updated_at"`
+	CreatedBy  string           `bson:"created_by,omitempty"`
+	UpdatedBy  string           `bson:"updated_by,omitempty"`
+	Source     string           `bson:"source,omitempty"`
+	IsDeleted  bool             `bson:"is_deleted,omitempty"`
+	DeletedAt  *time.Time       `bson:"deleted_at,omitempty"`
+	DeletedBy  string           `bson:"deleted_by,omitempty"`
 }
 
 func (r *MembershipRepository) Save(ctx context.Context, m *membership.Membership) error {
@@ -92,9 +93,13 @@ func (r *MembershipRepository) FindByIdolID(ctx context.Context, idolID string)
 	return scanMembershipCursor(ctx, cursor)
 }
 
-func (r *MembershipRepository) FindByGroupID(ctx context.Context, groupID string) ([]*membership.Membership, error) {
+// FindByGroupIDs は複数グループのメンバーシップをまとめて加入日の新しい順に取得する
+func (r *MembershipRepository) FindByGroupIDs(ctx context.Context, groupIDs []string) ([]*membership.Membership, error) {
+	if len(groupIDs) == 0 {
+		return []*membership.Membership{}, nil
+	}
 	cursor, err := r.collection.Find(ctx,
-		bson.M{"group_id": groupID, "is_deleted": bson.M{"$ne": true}},
+		bson.M{"group_id": bson.M{"$in": groupIDs}, "is_deleted": bson.M{"$ne": true}},
 		options.Find().SetSort(bson.D{{Key: "joined_at", Value: -1}}),
 	)
 	if err != nil {
@@ -214,6 +219,7 @@ func (r *MembershipRepository) EnsureIndexes(ctx context.Context) error {
 		{Keys: bson.D{{Key: "idol_id", Value: 1}, {Key: "group_id", Value: 1}}},
 		{Keys: bson.D{{Key: "idol_id", Value: 1}, {Key: "joined_at", Value: -1}}},
 		{Keys: bson.D{{Key: "group_id", Value: 1}, {Key: "joined_at", Value: -1}}},
+		{Keys: bson.D{{Key: "group_id", Value: 1}, {Key: "generation", Value: 1}}},
 	}
 	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
 	if err != nil {
@@ -230,15 +236,16 @@ func toMembershipDocument(m *membership.Membership) *membershipDocument {
 		}
 	}
 	return &membershipDocument{
-		ID:        objectID,
-		IdolID:    m.IdolID(),
-		GroupID:   m.GroupID(),
-		Role:      m.Role().String(),
-		JoinedAt:  m.JoinedAt(),
-		LeftAt:    m.LeftAt(),
-		Sources:   toSourceDocuments(m.Sources()),
-		CreatedAt: m.CreatedAt(),
-		UpdatedAt: m.UpdatedAt(),
+		ID:         objectID,
+		IdolID:     m.IdolID(),
+		GroupID:    m.GroupID(),
+		Role:       m.Role().String(),
+		Generation: m.Generation(),
+		JoinedAt:   m.JoinedAt(),
+		LeftAt:     m.LeftAt(),
+		Sources:    toSourceDocuments(m.Sources()),
+		CreatedAt:  m.CreatedAt(),
+		UpdatedAt:  m.UpdatedAt(),
 	}
 }
 
@@ -260,6 +267,7 @@ func fromMembershipDocument(doc *membershipDocument) (*membership.Membership, er
 		doc.IdolID,
 		doc.GroupID,
 		role,
+		doc.Generation,
 		doc.JoinedAt,
 		doc.LeftAt,
 		sources,
@@ -287,6 +295,9 @@ func buildMembershipFilter(criteria membership.SearchCriteria) bson.M {
 	if criteria.Role != nil {
 		filter["role"] = criteria.Role.String()
 	}
+	if criteria.Generation != nil {
+		filter["generation"] = *criteria.Generation
+	}
 
 	return filter
 }
@@ -0,0 +1,51 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/monthday"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// 誕生日・結成日の月日（MM-DD）を保存するフィールド名。年を問わない範囲検索にインデックスを使えるよう日付とは別に持つ
+const (
+	birthMonthDayField     = "birth_month_day"
+	formationMonthDayField = "formation_month_day"
+)
+
+// monthDayValue は日付の月日を "MM-DD" 形式で返す（日付がなければ nil）
+func monthDayValue(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := monthday.Of(*t).String()
+	return &s
+}
+
+// monthDayFilter は月日の範囲の検索条件を返す。年をまたぐ範囲は年末側と年始側の $or にする
+func monthDayFilter(field string, r monthday.Range) bson.M {
+	from, to := r.From.String(), r.To.String()
+	if r.Wraps() {
+		return bson.M{"$or": bson.A{
+			bson.M{field: bson.M{"$gte": from}},
+			bson.M{field: bson.M{"$lte": to}},
+		}}
+	}
+	return bson.M{field: bson.M{"$gte": from, "$lte": to}}
+}
+
+// backfillMonthDay は月日フィールドを持たない既存ドキュメントに日付フィールドから月日を生成して保存する。
+// 日付は UTC の0時で保存しているため UTC のまま月日を取り出す。更新したドキュメント数を返す。
+func backfillMonthDay(ctx context.Context, collection *mongo.Collection, dateField, monthDayField string) (int, error) {
+	result, err := collection.UpdateMany(ctx,
+		bson.M{dateField: bson.M{"$type": "date"}, monthDayField: bson.M{"$exists": false}},
+		bson.A{bson.M{"$set": bson.M{monthDayField: bson.M{"$dateToString": bson.M{"format": "%m-%d", "date": "$" + dateField}}}}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("月日フィールドの一括生成エラー: %w", err)
+	}
+	return int(result.ModifiedCount), nil
+}
@@ -0,0 +1,41 @@
+package mongodb
+
+import (
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
+)
+
+// formerNamesField は旧名の一覧を平坦化したフィールド（旧名での検索・横断検索の照合用）
+const formerNamesField = "former_names"
+
+// nameHistoryDocument は旧名履歴のドキュメント構造（グループ・アイドル共通）
+type nameHistoryDocument struct {
+	Name  string     `bson:"name"`
+	From  *time.Time `bson:"from,omitempty"`
+	Until time.Time  `bson:"until"`
+}
+
+// toNameHistoryDocuments は旧名履歴をドキュメントに変換する
+func toNameHistoryDocuments(h namehistory.History) []nameHistoryDocument {
+	if len(h) == 0 {
+		return nil
+	}
+	docs := make([]nameHistoryDocument, 0, len(h))
+	for _, e := range h {
+		docs = append(docs, nameHistoryDocument{Name: e.Name(), From: e.From(), Until: e.Until()})
+	}
+	return docs
+}
+
+// fromNameHistoryDocuments はドキュメントから旧名履歴を再構築する
+func fromNameHistoryDocuments(docs []nameHistoryDocument) namehistory.History {
+	if len(docs) == 0 {
+		return nil
+	}
+	h := make(namehistory.History, 0, len(docs))
+	for _, d := range docs {
+		h = append(h, namehistory.ReconstructEntry(d.Name, d.From, d.Until))
+	}
+	return h
+}
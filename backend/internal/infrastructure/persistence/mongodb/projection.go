@@ -0,0 +1,21 @@
+package mongodb
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+// omitProjection は一覧取得で省略する属性を除外するプロジェクションを返す（省略がなければ nil）。
+// 必須属性を除外するとドメインモデルを復元できないため、allowed に含まれない属性は無視する
+func omitProjection(omit []string, allowed ...string) bson.M {
+	var projection bson.M
+	for _, field := range omit {
+		for _, a := range allowed {
+			if field != a {
+				continue
+			}
+			if projection == nil {
+				projection = bson.M{}
+			}
+			projection[field] = 0
+		}
+	}
+	return projection
+}
@@ -0,0 +1,16 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestOmitProjectionExcludesOnlyAllowedFields(t *testing.T) {
+	projection := omitProjection([]string{"tracks", "title", "aliases"}, "tracks", "aliases")
+
+	assert.Equal(t, bson.M{"tracks": 0, "aliases": 0}, projection)
+	assert.Nil(t, omitProjection(nil, "tracks"))
+	assert.Nil(t, omitProjection([]string{"title"}, "tracks"))
+}
@@ -0,0 +1,25 @@
+package mongodb
+
+import (
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// nameKanaField は読み仮名を保存するフィールド名（アイドル・グループ・事務所共通）
+const nameKanaField = "name_kana"
+
+// japaneseCollation は読み仮名を五十音順に並べるための照合順序。
+// ICU の ja ロケールはひらがな・カタカナ・半角カナを同じ音として扱い、清音→濁音→半濁音の順に並べる。
+func japaneseCollation() *options.Collation {
+	return &options.Collation{Locale: "ja"}
+}
+
+// nameKanaSortIndex は読み仮名ソート用インデックス。
+// クエリと照合順序が一致しないとインデックスが使われないため ja 照合で作成する。
+func nameKanaSortIndex(name string) mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys:    bson.D{{Key: nameKanaField, Value: 1}},
+		Options: options.Index().SetName(name).SetCollation(japaneseCollation()),
+	}
+}
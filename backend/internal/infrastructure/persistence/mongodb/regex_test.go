@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 func TestSafePartialMatchRegexEscapesUserInput(t *testing.T) {
@@ -14,3 +15,18 @@ func TestSafePartialMatchRegexEscapesUserInput(t *testing.T) {
 	assert.Regexp(t, pattern, "Tokyo.*(idol)?")
 	assert.NotRegexp(t, pattern, "Tokyo super idol")
 }
+
+func TestNameSearchConditionsUsesNormalizedKeysAndFallbackFields(t *testing.T) {
+	conditions := nameSearchConditions("ｻｸﾗ.*", "name", "aliases")
+
+	assert.Len(t, conditions, 3)
+	keyCondition := conditions[0].(bson.M)[searchKeysField].(bson.M)["$in"].(bson.A)
+	assert.Equal(t, bson.A{bson.Regex{Pattern: "さくら"}, bson.Regex{Pattern: "sakura"}}, keyCondition)
+	assert.Equal(t, bson.M{"name": bson.M{"$regex": regexp.QuoteMeta("ｻｸﾗ.*"), "$options": "i"}}, conditions[1])
+}
+
+func TestNameSearchConditionsSkipsKeysForSymbolOnlyQuery(t *testing.T) {
+	conditions := nameSearchConditions("☆", "name")
+
+	assert.Equal(t, bson.A{bson.M{"name": bson.M{"$regex": "☆", "$options": "i"}}}, conditions)
+}
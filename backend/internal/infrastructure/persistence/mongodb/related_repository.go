@@ -0,0 +1,398 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/related"
+	"github.com/kuro48/idol-api/internal/shared/geo"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// RelatedRepository は include 展開用の関連データを複数IDでまとめて取得するリポジトリ
+type RelatedRepository struct {
+	db *mongo.Database
+}
+
+// NewRelatedRepository は RelatedRepository を作成する
+func NewRelatedRepository(db *mongo.Database) *RelatedRepository {
+	return &RelatedRepository{db: db}
+}
+
+// FindAgencies は事務所をIDでまとめて取得する
+func (r *RelatedRepository) FindAgencies(ctx context.Context, ids []string) (map[string]related.AgencySummary, error) {
+	result := make(map[string]related.AgencySummary)
+	if len(ids) == 0 {
+		return result, nil
+	}
+	var docs []struct {
+		ID              string  `bson:"_id"`
+		Name            string  `bson:"name"`
+		NameEn          *string `bson:"name_en"`
+		Country         string  `bson:"country"`
+		OfficialWebsite *string `bson:"official_website"`
+		LogoURL         *string `bson:"logo_url"`
+	}
+	if err := r.findByIDs(ctx, "agencies", ids, &docs); err != nil {
+		return nil, fmt.Errorf("事務所の取得エラー: %w", err)
+	}
+	for _, d := range docs {
+		result[d.ID] = related.AgencySummary{
+			ID:              d.ID,
+			Name:            d.Name,
+			NameEn:          d.NameEn,
+			Country:         d.Country,
+			OfficialWebsite: d.OfficialWebsite,
+			LogoURL:         d.LogoURL,
+		}
+	}
+	return result, nil
+}
+
+// FindIdols はアイドルをIDでまとめて取得する
+func (r *RelatedRepository) FindIdols(ctx context.Context, ids []string) (map[string]related.IdolSummary, error) {
+	result := make(map[string]related.IdolSummary)
+	objectIDs := toObjectIDs(ids)
+	if len(objectIDs) == 0 {
+		return result, nil
+	}
+	var docs []struct {
+		ID          bson.ObjectID         `bson:"_id"`
+		Name        string                `bson:"name"`
+		NameKana    *string               `bson:"name_kana"`
+		NameHistory []nameHistoryDocument `bson:"name_history"`
+	}
+	if err := r.findByIDs(ctx, "idols", objectIDs, &docs); err != nil {
+		return nil, fmt.Errorf("アイドルの取得エラー: %w", err)
+	}
+	for _, d := range docs {
+		result[d.ID.Hex()] = related.IdolSummary{
+			ID:          d.ID.Hex(),
+			Name:        d.Name,
+			NameKana:    d.NameKana,
+			NameHistory: fromNameHistoryDocuments(d.NameHistory),
+		}
+	}
+	return result, nil
+}
+
+// FindGroups はグループをIDでまとめて取得する
+func (r *RelatedRepository) FindGroups(ctx context.Context, ids []string) (map[string]related.GroupSummary, error) {
+	result := make(map[string]related.GroupSummary)
+	objectIDs := toObjectIDs(ids)
+	if len(objectIDs) == 0 {
+		return result, nil
+	}
+	var docs []struct {
+		ID          bson.ObjectID         `bson:"_id"`
+		Name        string                `bson:"name"`
+		NameKana    *string               `bson:"name_kana"`
+		AgencyID    *string               `bson:"agency_id"`
+		NameHistory []nameHistoryDocument `bson:"name_history"`
+	}
+	if err := r.findByIDs(ctx, "groups", objectIDs, &docs); err != nil {
+		return nil, fmt.Errorf("グループの取得エラー: %w", err)
+	}
+	for _, d := range docs {
+		result[d.ID.Hex()] = related.GroupSummary{
+			ID:          d.ID.Hex(),
+			Name:        d.Name,
+			NameKana:    d.NameKana,
+			AgencyID:    d.AgencyID,
+			NameHistory: fromNameHistoryDocuments(d.NameHistory),
+		}
+	}
+	return result, nil
+}
+
+// FindTags はタグをIDでまとめて取得する
+func (r *RelatedRepository) FindTags(ctx context.Context, ids []string) (map[string]related.TagSummary, error) {
+	result := make(map[string]related.TagSummary)
+	objectIDs := toObjectIDs(ids)
+	if len(objectIDs) == 0 {
+		return result, nil
+	}
+	var docs []struct {
+		ID       bson.ObjectID `bson:"_id"`
+		Name     string        `bson:"name"`
+		Category string        `bson:"category"`
+	}
+	if err := r.findByIDs(ctx, "tags", objectIDs, &docs); err != nil {
+		return nil, fmt.Errorf("タグの取得エラー: %w", err)
+	}
+	for _, d := range docs {
+		result[d.ID.Hex()] = related.TagSummary{ID: d.ID.Hex(), Name: d.Name, Category: d.Category}
+	}
+	return result, nil
+}
+
+// FindVenues は会場をIDでまとめて取得する
+func (r *RelatedRepository) FindVenues(ctx context.Context, ids []string) (map[string]related.VenueSummary, error) {
+	result := make(map[string]related.VenueSummary)
+	objectIDs := toObjectIDs(ids)
+	if len(objectIDs) == 0 {
+		return result, nil
+	}
+	var docs []struct {
+		ID         bson.ObjectID `bson:"_id"`
+		Name       string        `bson:"name"`
+		Prefecture *string       `bson:"prefecture"`
+		City       *string       `bson:"city"`
+		Address    *string       `bson:"address"`
+	}
+	if err := r.findByIDs(ctx, "venues", objectIDs, &docs); err != nil {
+		return nil, fmt.Errorf("会場の取得エラー: %w", err)
+	}
+	for _, d := range docs {
+		result[d.ID.Hex()] = related.VenueSummary{ID: d.ID.Hex(), Name: d.Name, Prefecture: d.Prefecture, City: d.City, Address: d.Address}
+	}
+	return result, nil
+}
+
+// FindVenuesNear は中心から半径以内の会場を $geoNear で近い順に取得し、会場IDごとの距離（km）を返す
+func (r *RelatedRepository) FindVenuesNear(ctx context.Context, center geo.Point, radiusKm float64, limit int) (map[string]float64, error) {
+	pipeline := bson.A{
+		bson.M{"$geoNear": bson.M{
+			"near":               toGeoJSONPoint(&center),
+			"key":                "location",
+			"distanceField":      "distance_km",
+			"distanceMultiplier": 0.001,
+			"maxDistance":        radiusKm * 1000,
+			"spherical":          true,
+			"query":              bson.M{"is_deleted": bson.M{"$ne": true}},
+		}},
+		bson.M{"$limit": limit},
+		bson.M{"$project": bson.M{"_id": 1, "distance_km": 1}},
+	}
+	var docs []struct {
+		ID         bson.ObjectID `bson:"_id"`
+		DistanceKm float64       `bson:"distance_km"`
+	}
+	if err := r.aggregate(ctx, "venues", pipeline, &docs); err != nil {
+		return nil, fmt.Errorf("周辺会場の取得エラー: %w", err)
+	}
+	result := make(map[string]float64, len(docs))
+	for _, d := range docs {
+		result[d.ID.Hex()] = d.DistanceKm
+	}
+	return result, nil
+}
+
+// FindSubUnits は親グループごとに直下のサブユニットのIDを結成日順に返す
+func (r *RelatedRepository) FindSubUnits(ctx context.Context, parentIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	if len(parentIDs) == 0 {
+		return result, nil
+	}
+	filter := bson.M{
+		"parent_group_id": bson.M{"$in": parentIDs},
+		"relation_type":   "sub_unit",
+		"is_deleted":      bson.M{"$ne": true},
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "parent_group_id": 1}).
+		SetSort(bson.D{{Key: "formation_date", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.db.Collection("groups").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("サブユニットの取得エラー: %w", err)
+	}
+	var docs []struct {
+		ID            bson.ObjectID `bson:"_id"`
+		ParentGroupID string        `bson:"parent_group_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("サブユニットのデコードエラー: %w", err)
+	}
+	for _, d := range docs {
+		result[d.ParentGroupID] = append(result[d.ParentGroupID], d.ID.Hex())
+	}
+	return result, nil
+}
+
+// relatedMembershipDocument はメンバーシップの展開用フィールド
+type relatedMembershipDocument struct {
+	ID         bson.ObjectID `bson:"_id"`
+	IdolID     string        `bson:"idol_id"`
+	GroupID    string        `bson:"group_id"`
+	Role       string        `bson:"role"`
+	Generation *string       `bson:"generation"`
+	JoinedAt   *time.Time    `bson:"joined_at"`
+	LeftAt     *time.Time    `bson:"left_at"`
+}
+
+func (d relatedMembershipDocument) toSummary() related.MembershipSummary {
+	return related.MembershipSummary{
+		ID:         d.ID.Hex(),
+		IdolID:     d.IdolID,
+		GroupID:    d.GroupID,
+		Role:       d.Role,
+		Generation: d.Generation,
+		JoinedAt:   d.JoinedAt,
+		LeftAt:     d.LeftAt,
+	}
+}
+
+// FindMembershipsByIdols はアイドルごとのメンバーシップを加入日の新しい順に最大 limit 件返す
+func (r *RelatedRepository) FindMembershipsByIdols(ctx context.Context, idolIDs []string, limit int) (map[string][]related.MembershipSummary, error) {
+	result := make(map[string][]related.MembershipSummary)
+	if len(idolIDs) == 0 {
+		return result, nil
+	}
+	var groups []struct {
+		Key   string                      `bson:"_id"`
+		Items []relatedMembershipDocument `bson:"items"`
+	}
+	pipeline := topNPerKey(bson.M{"idol_id": bson.M{"$in": idolIDs}}, nil, bson.D{{Key: "joined_at", Value: -1}}, "$idol_id", limit)
+	if err := r.aggregate(ctx, "memberships", pipeline, &groups); err != nil {
+		return nil, fmt.Errorf("メンバーシップの取得エラー: %w", err)
+	}
+	for _, g := range groups {
+		for _, d := range g.Items {
+			result[g.Key] = append(result[g.Key], d.toSummary())
+		}
+	}
+	return result, nil
+}
+
+// FindMembershipsByGroups はグループごとのメンバーシップを加入日の古い順に最大 limit 件返す
+func (r *RelatedRepository) FindMembershipsByGroups(ctx context.Context, groupIDs []string, limit int) (map[string][]related.MembershipSummary, error) {
+	result := make(map[string][]related.MembershipSummary)
+	if len(groupIDs) == 0 {
+		return result, nil
+	}
+	var groups []struct {
+		Key   string                      `bson:"_id"`
+		Items []relatedMembershipDocument `bson:"items"`
+	}
+	pipeline := topNPerKey(bson.M{"group_id": bson.M{"$in": groupIDs}}, nil, bson.D{{Key: "joined_at", Value: 1}}, "$group_id", limit)
+	if err := r.aggregate(ctx, "memberships", pipeline, &groups); err != nil {
+		return nil, fmt.Errorf("メンバーシップの取得エラー: %w", err)
+	}
+	for _, g := range groups {
+		for _, d := range g.Items {
+			result[g.Key] = append(result[g.Key], d.toSummary())
+		}
+	}
+	return result, nil
+}
+
+// FindReleasesByArtists はアーティストごとのリリースを発売日の新しい順に最大 limit 件返す
+func (r *RelatedRepository) FindReleasesByArtists(ctx context.Context, artistIDs []string, limit int) (map[string][]related.ReleaseSummary, error) {
+	result := make(map[string][]related.ReleaseSummary)
+	if len(artistIDs) == 0 {
+		return result, nil
+	}
+	var groups []struct {
+		Key   string `bson:"_id"`
+		Items []struct {
+			ID          bson.ObjectID `bson:"_id"`
+			Title       string        `bson:"title"`
+			ReleaseType string        `bson:"release_type"`
+			ReleaseDate time.Time     `bson:"release_date"`
+		} `bson:"items"`
+	}
+	match := bson.M{"artists.id": bson.M{"$in": artistIDs}}
+	pipeline := topNPerKey(match, bson.A{
+		bson.M{"$unwind": "$artists"},
+		bson.M{"$match": match},
+	}, bson.D{{Key: "release_date", Value: -1}}, "$artists.id", limit)
+	if err := r.aggregate(ctx, "releases", pipeline, &groups); err != nil {
+		return nil, fmt.Errorf("リリースの取得エラー: %w", err)
+	}
+	for _, g := range groups {
+		for _, d := range g.Items {
+			result[g.Key] = append(result[g.Key], related.ReleaseSummary{
+				ID:          d.ID.Hex(),
+				Title:       d.Title,
+				ReleaseType: d.ReleaseType,
+				ReleaseDate: d.ReleaseDate,
+			})
+		}
+	}
+	return result, nil
+}
+
+// FindEventsByPerformers は出演者ごとのイベントを開催日時の新しい順に最大 limit 件返す
+func (r *RelatedRepository) FindEventsByPerformers(ctx context.Context, performerIDs []string, limit int) (map[string][]related.EventSummary, error) {
+	result := make(map[string][]related.EventSummary)
+	if len(performerIDs) == 0 {
+		return result, nil
+	}
+	var groups []struct {
+		Key   string `bson:"_id"`
+		Items []struct {
+			ID            string    `bson:"_id"`
+			Title         string    `bson:"title"`
+			EventType     string    `bson:"event_type"`
+			Status        string    `bson:"status"`
+			StartDateTime time.Time `bson:"start_date_time"`
+			VenueID       *string   `bson:"venue_id"`
+		} `bson:"items"`
+	}
+	match := bson.M{"performers.performer_id": bson.M{"$in": performerIDs}}
+	pipeline := topNPerKey(match, bson.A{
+		bson.M{"$unwind": "$performers"},
+		bson.M{"$match": match},
+	}, bson.D{{Key: "start_date_time", Value: -1}}, "$performers.performer_id", limit)
+	if err := r.aggregate(ctx, "events", pipeline, &groups); err != nil {
+		return nil, fmt.Errorf("イベントの取得エラー: %w", err)
+	}
+	for _, g := range groups {
+		for _, d := range g.Items {
+			result[g.Key] = append(result[g.Key], related.EventSummary{
+				ID:            d.ID,
+				Title:         d.Title,
+				EventType:     d.EventType,
+				Status:        d.Status,
+				StartDateTime: d.StartDateTime,
+				VenueID:       d.VenueID,
+			})
+		}
+	}
+	return result, nil
+}
+
+// topNPerKey はキーごとに並び順の先頭 limit 件をまとめる集計パイプラインを返す。
+// 配列フィールドをキーにする場合は unwind で展開してから絞り込む
+func topNPerKey(match bson.M, unwind bson.A, sort bson.D, key string, limit int) bson.A {
+	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
+	for k, v := range match {
+		filter[k] = v
+	}
+	pipeline := bson.A{bson.M{"$match": filter}}
+	pipeline = append(pipeline, unwind...)
+	return append(pipeline,
+		bson.M{"$sort": sort},
+		bson.M{"$group": bson.M{
+			"_id":   key,
+			"items": bson.M{"$firstN": bson.M{"input": "$$ROOT", "n": limit}},
+		}},
+	)
+}
+
+func (r *RelatedRepository) findByIDs(ctx context.Context, collection string, ids any, results any) error {
+	cursor, err := r.db.Collection(collection).Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "is_deleted": bson.M{"$ne": true}},
+		options.Find().SetProjection(bson.M{"search_keys": 0, "sources": 0}))
+	if err != nil {
+		return err
+	}
+	if err := cursor.All(ctx, results); err != nil {
+		return fmt.Errorf("データ変換エラー: %w", err)
+	}
+	return nil
+}
+
+func (r *RelatedRepository) aggregate(ctx context.Context, collection string, pipeline bson.A, results any) error {
+	cursor, err := r.db.Collection(collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	if err := cursor.All(ctx, results); err != nil {
+		return fmt.Errorf("データ変換エラー: %w", err)
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/kuro48/idol-api/internal/domain/release"
 	"github.com/kuro48/idol-api/internal/shared/audit"
+	sharedid "github.com/kuro48/idol-api/internal/shared/id"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -27,25 +29,29 @@ func NewReleaseRepository(db *mongo.Database) *ReleaseRepository {
 // ---- ドキュメント構造 ----
 
 type releaseDocument struct {
-	ID             bson.ObjectID           `bson:"_id,omitempty"`
-	Title          string                  `bson:"title"`
-	ReleaseType    string                  `bson:"release_type"`
-	ReleaseDate    time.Time               `bson:"release_date"`
-	Artists        []artistRefDocument     `bson:"artists"`
-	Tracks         []trackDocument         `bson:"tracks,omitempty"`
-	StreamingLinks *streamingLinksDocument `bson:"streaming_links,omitempty"`
-	ExternalIDs    map[string]string       `bson:"external_ids,omitempty"`
-	CoverImageURL  *string                 `bson:"cover_image_url,omitempty"`
-	Aliases        []string                `bson:"aliases,omitempty"`
-	TagIDs         []string                `bson:"tag_ids,omitempty"`
-	Version        int                     `bson:"version"`
-	CreatedAt      time.Time               `bson:"created_at"`
-	UpdatedAt      time.Time               `bson:"updated_at"`
-	CreatedBy      string                  `bson:"created_by,omitempty"`
-	UpdatedBy      string                  `bson:"updated_by,omitempty"`
-	IsDeleted      bool                    `bson:"is_deleted,omitempty"`
-	DeletedAt      *time.Time              `bson:"deleted_at,omitempty"`
-	DeletedBy      string                  `bson:"deleted_by,omitempty"`
+	ID                bson.ObjectID           `bson:"_id,omitempty"`
+	Title             string                  `bson:"title"`
+	ReleaseType       string                  `bson:"release_type"`
+	ReleaseDate       time.Time               `bson:"release_date"`
+	Artists           []artistRefDocument     `bson:"artists"`
+	Tracks            []trackDocument         `bson:"tracks,omitempty"`
+	Editions          []editionDocument       `bson:"editions,omitempty"`
+	EditionCodes      []string                `bson:"edition_codes,omitempty"`
+	StreamingLinks    *streamingLinksDocument `bson:"streaming_links,omitempty"`
+	ExternalIDs       map[string]string       `bson:"external_ids,omitempty"`
+	CoverImageURL     *string                 `bson:"cover_image_url,omitempty"`
+	Aliases           []string                `bson:"aliases,omitempty"`
+	SearchKeys        []string                `bson:"search_keys"`
+	SearchKeysVersion int                     `bson:"search_keys_version"`
+	TagIDs            []string                `bson:"tag_ids,omitempty"`
+	Version           int                     `bson:"version"`
+	CreatedAt         time.Time               `bson:"created_at"`
+	UpdatedAt         time.Time               `bson:"updated_at"`
+	CreatedBy         string                  `bson:"created_by,omitempty"`
+	UpdatedBy         string                  `bson:"updated_by,omitempty"`
+	IsDeleted         bool                    `bson:"is_deleted,omitempty"`
+	DeletedAt         *time.Time              `bson:"deleted_at,omitempty"`
+	DeletedBy         string                  `bson:"deleted_by,omitempty"`
 }
 
 type artistRefDocument struct {
@@ -67,6 +73,30 @@ type trackDocument struct {
 	Participants  []trackParticipantDocument `bson:"participants,omitempty"`
 }
 
+type editionDocument struct {
+	ID          string                 `bson:"id"`
+	Name        string                 `bson:"name"`
+	EditionType string                 `bson:"edition_type"`
+	JANCode     *string                `bson:"jan_code,omitempty"`
+	UPC         *string                `bson:"upc,omitempty"`
+	Price       *int                   `bson:"price,omitempty"`
+	Tracks      []editionTrackDocument `bson:"tracks,omitempty"`
+	BonusMedia  []bonusMediaDocument   `bson:"bonus_media,omitempty"`
+}
+
+type editionTrackDocument struct {
+	DiscNumber        int            `bson:"disc_number"`
+	TrackNumber       int            `bson:"track_number"`
+	SharedTrackNumber *int           `bson:"shared_track_number,omitempty"`
+	Track             *trackDocument `bson:"track,omitempty"`
+}
+
+type bonusMediaDocument struct {
+	Type        string  `bson:"type"`
+	Title       string  `bson:"title"`
+	Description *string `bson:"description,omitempty"`
+}
+
 type trackParticipantDocument struct {
 	IdolID   string  `bson:"idol_id"`
 	Status   string  `bson:"status"`
@@ -98,18 +128,12 @@ func toReleaseDocument(r *release.Release) (*releaseDocument, error) {
 
 	tracks := make([]trackDocument, len(r.Tracks()))
 	for i, t := range r.Tracks() {
-		tracks[i] = trackDocument{
-			TrackNumber:   t.TrackNumber(),
-			Title:         t.Title(),
-			TitleKana:     t.TitleKana(),
-			DurationSec:   t.DurationSec(),
-			ISRC:          t.ISRC(),
-			CoverImageURL: t.CoverImageURL(),
-			Composers:     t.Composers(),
-			Lyricists:     t.Lyricists(),
-			Arrangers:     t.Arrangers(),
-			Participants:  toTrackParticipantDocuments(t.Participants()),
-		}
+		tracks[i] = toTrackDocument(t)
+	}
+
+	editions := make([]editionDocument, len(r.Editions()))
+	for i, e := range r.Editions() {
+		editions[i] = toEditionDocument(e)
 	}
 
 	var sl *streamingLinksDocument
@@ -127,22 +151,75 @@ func toReleaseDocument(r *release.Release) (*releaseDocument, error) {
 	}
 
 	return &releaseDocument{
-		ID:             objectID,
-		Title:          r.Title().Value(),
-		ReleaseType:    r.ReleaseType().Value(),
-		ReleaseDate:    r.ReleaseDate().Value(),
-		Artists:        artists,
-		Tracks:         tracks,
-		StreamingLinks: sl,
-		ExternalIDs:    extIDs,
-		CoverImageURL:  r.CoverImageURL(),
-		Aliases:        r.Aliases(),
-		TagIDs:         r.TagIDs(),
-		CreatedAt:      r.CreatedAt(),
-		UpdatedAt:      r.UpdatedAt(),
+		ID:                objectID,
+		Title:             r.Title().Value(),
+		ReleaseType:       r.ReleaseType().Value(),
+		ReleaseDate:       r.ReleaseDate().Value(),
+		Artists:           artists,
+		Tracks:            tracks,
+		Editions:          editions,
+		EditionCodes:      editionCodes(editions),
+		StreamingLinks:    sl,
+		ExternalIDs:       extIDs,
+		CoverImageURL:     r.CoverImageURL(),
+		Aliases:           r.Aliases(),
+		SearchKeys:        searchkey.Keys(stringValues(r.Title().Value(), r.Aliases())...),
+		SearchKeysVersion: searchKeysVersion,
+		TagIDs:            r.TagIDs(),
+		CreatedAt:         r.CreatedAt(),
+		UpdatedAt:         r.UpdatedAt(),
 	}, nil
 }
 
+func toTrackDocument(t release.Track) trackDocument {
+	return trackDocument{
+		TrackNumber:   t.TrackNumber(),
+		Title:         t.Title(),
+		TitleKana:     t.TitleKana(),
+		DurationSec:   t.DurationSec(),
+		ISRC:          t.ISRC(),
+		CoverImageURL: t.CoverImageURL(),
+		Composers:     t.Composers(),
+		Lyricists:     t.Lyricists(),
+		Arrangers:     t.Arrangers(),
+		Participants:  toTrackParticipantDocuments(t.Participants()),
+	}
+}
+
+func toEditionDocument(e release.Edition) editionDocument {
+	tracks := make([]editionTrackDocument, 0, len(e.Tracks()))
+	for _, t := range e.Tracks() {
+		td := editionTrackDocument{
+			DiscNumber:        t.DiscNumber(),
+			TrackNumber:       t.TrackNumber(),
+			SharedTrackNumber: t.SharedTrackNumber(),
+		}
+		if track := t.Track(); track != nil {
+			doc := toTrackDocument(*track)
+			td.Track = &doc
+		}
+		tracks = append(tracks, td)
+	}
+	bonusMedia := make([]bonusMediaDocument, 0, len(e.BonusMedia()))
+	for _, b := range e.BonusMedia() {
+		bonusMedia = append(bonusMedia, bonusMediaDocument{
+			Type:        string(b.MediaType()),
+			Title:       b.Title(),
+			Description: b.Description(),
+		})
+	}
+	return editionDocument{
+		ID:          e.ID(),
+		Name:        e.Name(),
+		EditionType: string(e.EditionType()),
+		JANCode:     e.JANCode(),
+		UPC:         e.UPC(),
+		Price:       e.Price(),
+		Tracks:      tracks,
+		BonusMedia:  bonusMedia,
+	}
+}
+
 func toTrackParticipantDocuments(participants []release.TrackParticipant) []trackParticipantDocument {
 	if len(participants) == 0 {
 		return nil
@@ -198,15 +275,20 @@ func toReleaseDomain(doc *releaseDocument) (*release.Release, error) {
 
 	tracks := make([]release.Track, 0, len(doc.Tracks))
 	for _, t := range doc.Tracks {
-		participants, err := toTrackParticipantsDomain(t.Participants)
+		track, err := toTrackDomain(t)
 		if err != nil {
-			return nil, fmt.Errorf("楽曲参加情報変換エラー: %w", err)
+			return nil, err
 		}
-		track, err := release.NewTrack(t.TrackNumber, t.Title, t.TitleKana, t.DurationSec, t.ISRC, t.CoverImageURL, t.Composers, t.Lyricists, t.Arrangers, participants)
+		tracks = append(tracks, track)
+	}
+
+	editions := make([]release.Edition, 0, len(doc.Editions))
+	for _, e := range doc.Editions {
+		edition, err := toEditionDomain(e)
 		if err != nil {
-			return nil, fmt.Errorf("楽曲変換エラー: %w", err)
+			return nil, fmt.Errorf("エディション変換エラー: %w", err)
 		}
-		tracks = append(tracks, track)
+		editions = append(editions, edition)
 	}
 
 	var streamingLinks *release.StreamingLinks
@@ -232,12 +314,44 @@ func toReleaseDomain(doc *releaseDocument) (*release.Release, error) {
 
 	return release.Reconstruct(
 		id, title, releaseType, releaseDate,
-		artists, tracks, streamingLinks, extIDs,
+		artists, tracks, editions, streamingLinks, extIDs,
 		doc.CoverImageURL, doc.Aliases, doc.TagIDs,
 		doc.CreatedAt, doc.UpdatedAt,
 	), nil
 }
 
+func toTrackDomain(doc trackDocument) (release.Track, error) {
+	participants, err := toTrackParticipantsDomain(doc.Participants)
+	if err != nil {
+		return release.Track{}, fmt.Errorf("楽曲参加情報変換エラー: %w", err)
+	}
+	track, err := release.NewTrack(doc.TrackNumber, doc.Title, doc.TitleKana, doc.DurationSec, doc.ISRC, doc.CoverImageURL, doc.Composers, doc.Lyricists, doc.Arrangers, participants)
+	if err != nil {
+		return release.Track{}, fmt.Errorf("楽曲変換エラー: %w", err)
+	}
+	return track, nil
+}
+
+func toEditionDomain(doc editionDocument) (release.Edition, error) {
+	tracks := make([]release.EditionTrack, 0, len(doc.Tracks))
+	for _, t := range doc.Tracks {
+		var track *release.Track
+		if t.Track != nil {
+			converted, err := toTrackDomain(*t.Track)
+			if err != nil {
+				return release.Edition{}, err
+			}
+			track = &converted
+		}
+		tracks = append(tracks, release.ReconstructEditionTrack(t.DiscNumber, t.TrackNumber, t.SharedTrackNumber, track))
+	}
+	bonusMedia := make([]release.BonusMedia, 0, len(doc.BonusMedia))
+	for _, b := range doc.BonusMedia {
+		bonusMedia = append(bonusMedia, release.ReconstructBonusMedia(release.BonusMediaType(b.Type), b.Title, b.Description))
+	}
+	return release.ReconstructEdition(doc.ID, doc.Name, release.EditionType(doc.EditionType), doc.JANCode, doc.UPC, doc.Price, tracks, bonusMedia), nil
+}
+
 func toTrackParticipantsDomain(docs []trackParticipantDocument) ([]release.TrackParticipant, error) {
 	if docs == nil {
 		return nil, nil
@@ -382,16 +496,19 @@ func (r *ReleaseRepository) Update(ctx context.Context, rel *release.Release) er
 	}
 
 	setFields := bson.M{
-		"title":           doc.Title,
-		"release_type":    doc.ReleaseType,
-		"release_date":    doc.ReleaseDate,
-		"artists":         artists,
-		"tracks":          tracks,
-		"cover_image_url": doc.CoverImageURL,
-		"aliases":         doc.Aliases,
-		"tag_ids":         doc.TagIDs,
-		"updated_at":      time.Now(),
-		"updated_by":      audit.ActorFrom(ctx),
+		"title":                doc.Title,
+		"release_type":         doc.ReleaseType,
+		"release_date":         doc.ReleaseDate,
+		"artists":              artists,
+		"tracks":               tracks,
+		"editions":             doc.Editions,
+		"cover_image_url":      doc.CoverImageURL,
+		"aliases":              doc.Aliases,
+		"search_keys":          doc.SearchKeys,
+		searchKeysVersionField: doc.SearchKeysVersion,
+		"tag_ids":              doc.TagIDs,
+		"updated_at":           time.Now(),
+		"updated_by":           audit.ActorFrom(ctx),
 	}
 	if doc.StreamingLinks != nil {
 		setFields["streaming_links"] = doc.StreamingLinks
@@ -399,8 +516,14 @@ func (r *ReleaseRepository) Update(ctx context.Context, rel *release.Release) er
 	if doc.ExternalIDs != nil {
 		setFields["external_ids"] = doc.ExternalIDs
 	}
+	update := bson.M{"$set": setFields}
+	if len(doc.EditionCodes) > 0 {
+		setFields["edition_codes"] = doc.EditionCodes
+	} else {
+		update["$unset"] = bson.M{"edition_codes": ""}
+	}
 
-	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": setFields})
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
 	if err != nil {
 		return fmt.Errorf("リリース更新エラー: %w", err)
 	}
@@ -476,6 +599,9 @@ func (r *ReleaseRepository) Search(ctx context.Context, criteria release.SearchC
 	if criteria.Limit > 0 {
 		opts.SetLimit(int64(criteria.Limit))
 	}
+	if projection := omitProjection(criteria.Omit, "tracks", "editions", "streaming_links", "external_ids", "aliases"); projection != nil {
+		opts.SetProjection(projection)
+	}
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -507,11 +633,7 @@ func buildReleaseFilter(criteria release.SearchCriteria) bson.M {
 	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
 
 	if criteria.Title != nil {
-		titleRegex := bson.M{"$regex": safePartialMatchRegex(*criteria.Title), "$options": "i"}
-		filter["$or"] = bson.A{
-			bson.M{"title": titleRegex},
-			bson.M{"aliases": titleRegex},
-		}
+		filter["$or"] = nameSearchConditions(*criteria.Title, "title", "aliases")
 	}
 	if criteria.ReleaseType != nil {
 		filter["release_type"] = criteria.ReleaseType.Value()
@@ -552,6 +674,32 @@ func (r *ReleaseRepository) FindByExternalID(ctx context.Context, kind release.R
 	return toReleaseDomain(&doc)
 }
 
+// FindByEditionJANCode はエディションのJANコードでリリースを検索する
+func (r *ReleaseRepository) FindByEditionJANCode(ctx context.Context, janCode string) (*release.Release, error) {
+	var doc releaseDocument
+	err := r.collection.FindOne(ctx, bson.M{"editions.jan_code": janCode, "is_deleted": bson.M{"$ne": true}}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("JANコード検索エラー: %w", err)
+	}
+	return toReleaseDomain(&doc)
+}
+
+// FindByEditionUPC はエディションのUPCでリリースを検索する
+func (r *ReleaseRepository) FindByEditionUPC(ctx context.Context, upc string) (*release.Release, error) {
+	var doc releaseDocument
+	err := r.collection.FindOne(ctx, bson.M{"editions.upc": upc, "is_deleted": bson.M{"$ne": true}}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("UPC検索エラー: %w", err)
+	}
+	return toReleaseDomain(&doc)
+}
+
 func (r *ReleaseRepository) FindByArtistID(ctx context.Context, artistID string) ([]*release.Release, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{"artists.id": artistID, "is_deleted": bson.M{"$ne": true}})
 	if err != nil {
@@ -585,10 +733,20 @@ func (r *ReleaseRepository) EnsureIndexes(ctx context.Context) error {
 		{Keys: bson.D{{Key: "title", Value: 1}}},
 		{Keys: bson.D{{Key: "created_at", Value: -1}}},
 		{Keys: bson.D{{Key: "tag_ids", Value: 1}}},
+		{Keys: bson.D{{Key: "editions.jan_code", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "editions.upc", Value: 1}}, Options: options.Index().SetSparse(true)},
+		// エディションのJANコード・UPCを集約した edition_codes で全リリースを通した一意性を保証する。
+		// コードを持たないリリースは部分インデックスの対象外にする（削除済みリリースのコードも予約したままにする）
+		{
+			Keys: bson.D{{Key: "edition_codes", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("uniq_release_edition_codes").
+				SetPartialFilterExpression(bson.M{"edition_codes": bson.M{"$type": "string"}}),
+		},
 		{Keys: bson.D{{Key: "release_type", Value: 1}, {Key: "release_date", Value: -1}}},
+		searchKeysIndex("idx_release_search_keys"),
 	}
 
-	for _, kind := range []string{"spotify_album_id", "apple_music_album_id", "upc", "jan_code"} {
+	for _, kind := range []string{"spotify_album_id", "apple_music_album_id"} {
 		field := "external_ids." + kind
 		indexes = append(indexes, mongo.IndexModel{
 			Keys:    bson.D{{Key: field, Value: 1}},
@@ -601,3 +759,153 @@ func (r *ReleaseRepository) EnsureIndexes(ctx context.Context) error {
 	}
 	return nil
 }
+
+// BackfillSearchKeys は検索キーが未生成または古い版の既存リリースに正規化キーを生成する
+func (r *ReleaseRepository) BackfillSearchKeys(ctx context.Context) (int, error) {
+	return backfillSearchKeys(ctx, r.collection, "title", "aliases")
+}
+
+// MigrateJANCodesToEditions はリリース単位の外部ID jan_code をエディションへ移す。
+// エディション未登録のリリースには JAN コードを持つ通常盤を1件作成する
+func (r *ReleaseRepository) MigrateJANCodesToEditions(ctx context.Context) (int, error) {
+	return r.migrateExternalIDToEditions(ctx, "jan_code", "JANコード", release.IsValidJANCode,
+		func(e *editionDocument) **string { return &e.JANCode })
+}
+
+// MigrateUPCsToEditions はリリース単位の外部ID upc をエディションへ移す。
+// エディション未登録のリリースには UPC を持つ通常盤を1件作成する
+func (r *ReleaseRepository) MigrateUPCsToEditions(ctx context.Context) (int, error) {
+	return r.migrateExternalIDToEditions(ctx, "upc", "UPC", release.IsValidUPC,
+		func(e *editionDocument) **string { return &e.UPC })
+}
+
+// migrateExternalIDToEditions は外部ID kind の値をエディションのコード（codeOf が指すフィールド）へ移す
+func (r *ReleaseRepository) migrateExternalIDToEditions(
+	ctx context.Context,
+	kind, label string,
+	valid func(string) bool,
+	codeOf func(*editionDocument) **string,
+) (int, error) {
+	field := "external_ids." + kind
+	cursor, err := r.collection.Find(
+		ctx,
+		bson.M{field: bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{field: 1, "editions": 1}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%s移行対象の取得エラー: %w", label, err)
+	}
+	defer cursor.Close(ctx)
+
+	var models []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID          bson.ObjectID     `bson:"_id"`
+			ExternalIDs map[string]string `bson:"external_ids"`
+			Editions    []editionDocument `bson:"editions"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return 0, fmt.Errorf("ドキュメントのデコードエラー: %w", err)
+		}
+		code := doc.ExternalIDs[kind]
+		update := bson.M{"$unset": bson.M{field: ""}}
+		switch {
+		case len(doc.Editions) == 0 && valid(code):
+			edition := editionDocument{
+				ID:          sharedid.Generate(),
+				Name:        "通常盤",
+				EditionType: string(release.EditionTypeRegular),
+			}
+			*codeOf(&edition) = &code
+			editions := []editionDocument{edition}
+			update["$set"] = bson.M{"editions": editions, "edition_codes": editionCodes(editions)}
+		case hasEditionCode(doc.Editions, code, codeOf):
+			// 移行済みのエディションがあれば外部ID側を消すだけにする
+		default:
+			// 移行先が決まらない値（形式不正・既存エディションと不一致）は手動確認のため残す
+			continue
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": doc.ID}).
+			SetUpdate(update))
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, fmt.Errorf("カーソルエラー: %w", err)
+	}
+	if len(models) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("%sの一括移行エラー: %w", label, err)
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// BackfillEditionCodes は edition_codes 未生成の既存リリースにエディションのJANコード・UPCを集約する。
+// 別のリリースとコードが重複しているリリースは一意インデックスで弾かれるため、手動で解消する必要がある
+func (r *ReleaseRepository) BackfillEditionCodes(ctx context.Context) (int, error) {
+	cursor, err := r.collection.Find(
+		ctx,
+		bson.M{"edition_codes": bson.M{"$exists": false}, "editions": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"editions": 1}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("エディションコード未生成ドキュメントの取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var models []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID       bson.ObjectID     `bson:"_id"`
+			Editions []editionDocument `bson:"editions"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return 0, fmt.Errorf("ドキュメントのデコードエラー: %w", err)
+		}
+		codes := editionCodes(doc.Editions)
+		if len(codes) == 0 {
+			continue
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": doc.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"edition_codes": codes}}))
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, fmt.Errorf("カーソルエラー: %w", err)
+	}
+	if len(models) == 0 {
+		return 0, nil
+	}
+
+	result, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("エディションコードの一括更新エラー: %w", err)
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// editionCodes はエディションのJANコード・UPCを一意制約用の配列にまとめる
+func editionCodes(editions []editionDocument) []string {
+	var codes []string
+	for _, e := range editions {
+		if e.JANCode != nil {
+			codes = append(codes, *e.JANCode)
+		}
+		if e.UPC != nil {
+			codes = append(codes, *e.UPC)
+		}
+	}
+	return codes
+}
+
+func hasEditionCode(editions []editionDocument, code string, codeOf func(*editionDocument) **string) bool {
+	for i := range editions {
+		if c := *codeOf(&editions[i]); c != nil && *c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// SchedulerLeaseRepository は MongoDB を使ったスケジューラのタスク実行権（リース）の実装。
+type SchedulerLeaseRepository struct {
+	collection *mongo.Collection
+}
+
+// NewSchedulerLeaseRepository はリポジトリを作成する。
+func NewSchedulerLeaseRepository(db *mongo.Database) *SchedulerLeaseRepository {
+	return &SchedulerLeaseRepository{
+		collection: db.Collection("scheduler_leases"),
+	}
+}
+
+// TryAcquire はリースを取得する。
+// タスク名を _id にした upsert で、期限内のリースを他の保持者が持っている場合は重複キーとなり false を返す。
+func (r *SchedulerLeaseRepository) TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lte": now}},
+			bson.M{"holder": holder},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"holder":      holder,
+		"acquired_at": now,
+		"expires_at":  now.Add(ttl),
+	}}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("リースの取得エラー: %w", err)
+	}
+	return true, nil
+}
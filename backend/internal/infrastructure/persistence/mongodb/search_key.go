@@ -0,0 +1,126 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// searchKeysField は名前検索用の正規化キーを保存するフィールド名（全コレクション共通）
+const searchKeysField = "search_keys"
+
+// searchKeysVersionField は search_keys を生成したときの版を保存するフィールド名
+const searchKeysVersionField = "search_keys_version"
+
+// searchKeysVersion は検索キーの現在の版。キーの材料にするフィールドや正規化規則を変えたら上げる。
+// 起動時のバックフィルは版が古いドキュメントのキーを作り直す。
+// 版を持たないドキュメントは読み（name_kana）・旧名（former_names）を材料に加える前のキーとして扱う。
+const searchKeysVersion = 1
+
+// nameSearchConditions は名前検索の $or 条件を返す。
+// 正規化キー（かな/ローマ字/全半角の表記ゆれを吸収）の部分一致に加え、
+// キー未生成の旧ドキュメントのために元フィールドの大文字小文字無視の部分一致も残す。
+// 部分一致（先頭に固定しない正規表現）のため search_keys の索引は使われない。
+// ReDoS対策として正規表現メタ文字をエスケープする。
+func nameSearchConditions(query string, fields ...string) bson.A {
+	conditions := bson.A{}
+	if keys := searchkey.QueryKeys(query); len(keys) > 0 {
+		patterns := make(bson.A, 0, len(keys))
+		for _, key := range keys {
+			patterns = append(patterns, bson.Regex{Pattern: safePartialMatchRegex(key)})
+		}
+		conditions = append(conditions, bson.M{searchKeysField: bson.M{"$in": patterns}})
+	}
+
+	rawRegex := bson.M{"$regex": safePartialMatchRegex(query), "$options": "i"}
+	for _, field := range fields {
+		conditions = append(conditions, bson.M{field: rawRegex})
+	}
+	return conditions
+}
+
+// searchKeysIndex は正規化キーの前方一致・完全一致用インデックス（横断検索・入力補完で使う）
+func searchKeysIndex(name string) mongo.IndexModel {
+	return mongo.IndexModel{
+		Keys:    bson.D{{Key: searchKeysField, Value: 1}},
+		Options: options.Index().SetName(name),
+	}
+}
+
+// stringValues はドキュメントのフィールド値（文字列・文字列配列・nil）を文字列スライスへ展開する
+func stringValues(values ...any) []string {
+	var result []string
+	for _, v := range values {
+		switch tv := v.(type) {
+		case string:
+			result = append(result, tv)
+		case *string:
+			if tv != nil {
+				result = append(result, *tv)
+			}
+		case []string:
+			result = append(result, tv...)
+		case bson.A:
+			for _, item := range tv {
+				if s, ok := item.(string); ok {
+					result = append(result, s)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// backfillSearchKeys は search_keys を持たない、または searchKeysVersion より古い版で生成した
+// 既存ドキュメントの正規化キーを作り直して保存する。
+// fields はキーの材料にするフィールド名。更新したドキュメント数を返す。
+func backfillSearchKeys(ctx context.Context, collection *mongo.Collection, fields ...string) (int, error) {
+	projection := bson.M{}
+	for _, field := range fields {
+		projection[field] = 1
+	}
+
+	cursor, err := collection.Find(
+		ctx,
+		bson.M{searchKeysVersionField: bson.M{"$not": bson.M{"$gte": searchKeysVersion}}},
+		options.Find().SetProjection(projection),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("検索キー再生成対象ドキュメントの取得エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var models []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return 0, fmt.Errorf("ドキュメントのデコードエラー: %w", err)
+		}
+		values := make([]any, 0, len(fields))
+		for _, field := range fields {
+			values = append(values, doc[field])
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": doc["_id"]}).
+			SetUpdate(bson.M{"$set": bson.M{
+				searchKeysField:        searchkey.Keys(stringValues(values...)...),
+				searchKeysVersionField: searchKeysVersion,
+			}}))
+	}
+	if err := cursor.Err(); err != nil {
+		return 0, fmt.Errorf("カーソルエラー: %w", err)
+	}
+	if len(models) == 0 {
+		return 0, nil
+	}
+
+	result, err := collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, fmt.Errorf("検索キーの一括更新エラー: %w", err)
+	}
+	return int(result.ModifiedCount), nil
+}
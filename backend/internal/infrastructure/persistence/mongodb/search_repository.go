@@ -0,0 +1,434 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kuro48/idol-api/internal/domain/search"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// searchSourceField は横断検索で照合するフィールド
+type searchSourceField struct {
+	name  string
+	alias bool
+}
+
+// searchSource は検索対象種別ごとのコレクションと照合フィールド
+type searchSource struct {
+	collection string
+	nameField  string // 表示名のフィールド
+	kanaField  string // 読み仮名のフィールド（ない場合は空）
+	fields     []searchSourceField
+	hintFields []string // 入力補完の補足情報に使うフィールド
+}
+
+var searchSources = map[search.EntityType]searchSource{
+	search.TypeIdol: {
+		collection: "idols",
+		nameField:  "name",
+		kanaField:  "name_kana",
+		fields:     []searchSourceField{{name: "name"}, {name: "name_kana"}, {name: "name_latin"}, {name: "aliases", alias: true}, {name: formerNamesField, alias: true}},
+		hintFields: []string{"agency_id", "birthdate"},
+	},
+	search.TypeGroup: {
+		collection: "groups",
+		nameField:  "name",
+		kanaField:  "name_kana",
+		fields:     []searchSourceField{{name: "name"}, {name: "name_kana"}, {name: "name_latin"}, {name: formerNamesField, alias: true}},
+		hintFields: []string{"agency_id", "formation_date"},
+	},
+	search.TypeAgency: {
+		collection: "agencies",
+		nameField:  "name",
+		kanaField:  "name_kana",
+		fields:     []searchSourceField{{name: "name"}, {name: "name_kana"}, {name: "name_en"}},
+	},
+	search.TypeRelease: {
+		collection: "releases",
+		nameField:  "title",
+		fields:     []searchSourceField{{name: "title"}, {name: "aliases", alias: true}},
+		hintFields: []string{"release_date"},
+	},
+	search.TypeEvent: {
+		collection: "events",
+		nameField:  "title",
+		fields:     []searchSourceField{{name: "title"}},
+		hintFields: []string{"start_date_time"},
+	},
+	search.TypeVenue: {
+		collection: "venues",
+		nameField:  "name",
+		fields:     []searchSourceField{{name: "name"}, {name: "name_en"}},
+		hintFields: []string{"prefecture"},
+	},
+}
+
+// minFuzzyCandidateKeyLength は n-gram による類似候補の取得を行う検索キーの最小文字数
+const minFuzzyCandidateKeyLength = 3
+
+// SearchRepository は横断検索の候補を各コレクションから取得するリポジトリ
+type SearchRepository struct {
+	db *mongo.Database
+}
+
+// NewSearchRepository は SearchRepository を作成する
+func NewSearchRepository(db *mongo.Database) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// FindCandidates は種別ごとに並行して候補を取得する。
+// 上限件数で一致度の高い候補が切り捨てられないよう、完全一致・前方一致・部分一致・類似候補の順に
+// それぞれ別のクエリで上限件数まで取得する
+func (r *SearchRepository) FindCandidates(ctx context.Context, criteria search.Criteria) ([]search.Candidate, error) {
+	results, err := forEachSearchSource(criteria.Types, func(t search.EntityType, source searchSource) ([]search.Candidate, error) {
+		return r.findSourceCandidates(ctx, t, source, criteria)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []search.Candidate
+	for _, result := range results {
+		candidates = append(candidates, result...)
+	}
+	return candidates, nil
+}
+
+// CountMatches は種別ごとに並行して、完全一致・前方一致・部分一致・別名の一致件数を数える。
+// 候補取得の上限件数に左右されないファセットを返すためのもので、類似一致は含まない
+func (r *SearchRepository) CountMatches(ctx context.Context, criteria search.Criteria) (map[search.EntityType]int, error) {
+	types := criteria.Types
+	if len(types) == 0 {
+		types = search.AllEntityTypes()
+	}
+	counts, err := forEachSearchSource(types, func(_ search.EntityType, source searchSource) (int, error) {
+		count, err := r.db.Collection(source.collection).CountDocuments(ctx, nameMatchFilter(source, criteria.Query))
+		return int(count), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	facets := make(map[search.EntityType]int, len(types))
+	for i, t := range types {
+		if _, ok := searchSources[t]; ok {
+			facets[t] = counts[i]
+		}
+	}
+	return facets, nil
+}
+
+// forEachSearchSource は種別ごとの検索対象について fn を並行に実行し、種別の順に結果を返す。
+// 未対応の種別の結果はゼロ値となる
+func forEachSearchSource[T any](types []search.EntityType, fn func(search.EntityType, searchSource) (T, error)) ([]T, error) {
+	if len(types) == 0 {
+		types = search.AllEntityTypes()
+	}
+
+	results := make([]T, len(types))
+	errs := make([]error, len(types))
+	var wg sync.WaitGroup
+	for i, t := range types {
+		source, ok := searchSources[t]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, t search.EntityType, source searchSource) {
+			defer wg.Done()
+			results[i], errs[i] = fn(t, source)
+		}(i, t, source)
+	}
+	wg.Wait()
+
+	for i := range types {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("%s の検索エラー: %w", types[i], errs[i])
+		}
+	}
+	return results, nil
+}
+
+// nameMatchFilter は名前・読み・別名のいずれかが検索語に部分一致する未削除ドキュメントの条件を返す
+func nameMatchFilter(source searchSource, query string) bson.M {
+	rawFields := make([]string, 0, len(source.fields))
+	for _, f := range source.fields {
+		rawFields = append(rawFields, f.name)
+	}
+	return bson.M{"is_deleted": bson.M{"$ne": true}, "$or": nameSearchConditions(query, rawFields...)}
+}
+
+func (r *SearchRepository) findSourceCandidates(ctx context.Context, t search.EntityType, source searchSource, criteria search.Criteria) ([]search.Candidate, error) {
+	collection := r.db.Collection(source.collection)
+
+	projection := bson.M{"_id": 1, source.nameField: 1}
+	for _, f := range source.fields {
+		projection[f.name] = 1
+	}
+	findOptions := options.Find().SetProjection(projection).SetLimit(int64(criteria.Limit))
+
+	var filters []bson.M
+	if exact := exactSearchKeyCondition(criteria.Query); exact != nil {
+		filters = append(filters, bson.M{"is_deleted": bson.M{"$ne": true}, searchKeysField: exact})
+	}
+	if prefix := prefixSearchKeyCondition(criteria.Query); prefix != nil {
+		filters = append(filters, bson.M{"is_deleted": bson.M{"$ne": true}, searchKeysField: prefix})
+	}
+	filters = append(filters, nameMatchFilter(source, criteria.Query))
+	if fuzzy := fuzzySearchCondition(criteria.Query); fuzzy != nil {
+		filters = append(filters, bson.M{"is_deleted": bson.M{"$ne": true}, searchKeysField: fuzzy})
+	}
+
+	docs, err := findDistinctDocuments(ctx, collection, filters, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	candidates := make([]search.Candidate, 0, len(docs))
+	for _, doc := range docs {
+		candidates = append(candidates, toSearchCandidate(t, source, doc))
+	}
+	return candidates, nil
+}
+
+// findDistinctDocuments は filters を順に実行し、先に取得したドキュメントを優先して重複を除いた結果を返す
+func findDistinctDocuments(ctx context.Context, collection *mongo.Collection, filters []bson.M, findOptions *options.FindOptionsBuilder) ([]bson.M, error) {
+	seen := make(map[string]struct{})
+	var result []bson.M
+	for _, filter := range filters {
+		cursor, err := collection.Find(ctx, filter, findOptions)
+		if err != nil {
+			return nil, err
+		}
+		var docs []bson.M
+		if err := cursor.All(ctx, &docs); err != nil {
+			return nil, fmt.Errorf("データ変換エラー: %w", err)
+		}
+		for _, doc := range docs {
+			id := documentID(doc["_id"])
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			result = append(result, doc)
+		}
+	}
+	return result, nil
+}
+
+// exactSearchKeyCondition は正規化キーが検索キーと完全に一致する search_keys の条件を返す
+func exactSearchKeyCondition(query string) bson.M {
+	keys := searchkey.QueryKeys(query)
+	if len(keys) == 0 {
+		return nil
+	}
+	values := make(bson.A, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, key)
+	}
+	return bson.M{"$in": values}
+}
+
+// prefixSearchKeyCondition は正規化キーが検索キーで始まる search_keys の条件を返す
+func prefixSearchKeyCondition(query string) bson.M {
+	keys := searchkey.QueryKeys(query)
+	if len(keys) == 0 {
+		return nil
+	}
+	patterns := make(bson.A, 0, len(keys))
+	for _, key := range keys {
+		patterns = append(patterns, bson.Regex{Pattern: "^" + safePartialMatchRegex(key)})
+	}
+	return bson.M{"$in": patterns}
+}
+
+// fuzzySearchCondition は検索キーの bigram のいずれかを含む search_keys の条件を返す。
+// 誤字を含む検索語でも候補に残すためのもので、最終的な採否はドメインのスコアリングで判定する
+func fuzzySearchCondition(query string) bson.M {
+	var patterns bson.A
+	for _, key := range searchkey.QueryKeys(query) {
+		if utf8.RuneCountInString(key) < minFuzzyCandidateKeyLength {
+			continue
+		}
+		for _, gram := range search.Bigrams(key) {
+			patterns = append(patterns, bson.Regex{Pattern: safePartialMatchRegex(gram)})
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	return bson.M{"$in": patterns}
+}
+
+func toSearchCandidate(t search.EntityType, source searchSource, doc bson.M) search.Candidate {
+	c := search.Candidate{Type: t, ID: documentID(doc["_id"])}
+	if name, ok := doc[source.nameField].(string); ok {
+		c.Name = name
+	}
+	for _, f := range source.fields {
+		for _, value := range stringValues(doc[f.name]) {
+			c.Fields = append(c.Fields, search.Field{Name: f.name, Value: value, Alias: f.alias})
+		}
+	}
+	return c
+}
+
+// documentID は ObjectID・文字列のいずれの _id も16進文字列として返す
+func documentID(id any) string {
+	switch v := id.(type) {
+	case bson.ObjectID:
+		return v.Hex()
+	case string:
+		return v
+	}
+	return fmt.Sprint(id)
+}
+
+// FindByPrefix は種別ごとに並行して、検索キーが検索語で始まる入力補完候補を取得する。
+// search_keys の前方一致は索引を利用できるため、入力のたびに呼ばれても軽量に動作する。
+// 完全一致の候補が上限件数で切り捨てられないよう、完全一致を先に別のクエリで取得する
+func (r *SearchRepository) FindByPrefix(ctx context.Context, criteria search.SuggestCriteria) ([]search.Suggestion, error) {
+	exact := exactSearchKeyCondition(criteria.Query)
+	prefix := prefixSearchKeyCondition(criteria.Query)
+	if prefix == nil {
+		return nil, nil
+	}
+	filters := []bson.M{
+		{"is_deleted": bson.M{"$ne": true}, searchKeysField: exact},
+		{"is_deleted": bson.M{"$ne": true}, searchKeysField: prefix},
+	}
+
+	results, err := forEachSearchSource(criteria.Types, func(t search.EntityType, source searchSource) ([]prefixSuggestion, error) {
+		return r.findSourceSuggestions(ctx, t, source, filters, criteria.Limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var found []prefixSuggestion
+	for _, result := range results {
+		found = append(found, result...)
+	}
+	if err := r.fillAgencyNames(ctx, found); err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]search.Suggestion, 0, len(found))
+	for _, f := range found {
+		suggestions = append(suggestions, f.suggestion)
+	}
+	return suggestions, nil
+}
+
+// prefixSuggestion は事務所名を引く前の入力補完候補
+type prefixSuggestion struct {
+	suggestion search.Suggestion
+	agencyID   string
+}
+
+func (r *SearchRepository) findSourceSuggestions(ctx context.Context, t search.EntityType, source searchSource, filters []bson.M, limit int) ([]prefixSuggestion, error) {
+	projection := bson.M{"_id": 1, source.nameField: 1}
+	for _, f := range source.fields {
+		projection[f.name] = 1
+	}
+	for _, f := range source.hintFields {
+		projection[f] = 1
+	}
+	findOptions := options.Find().SetProjection(projection).SetLimit(int64(limit))
+
+	docs, err := findDistinctDocuments(ctx, r.db.Collection(source.collection), filters, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]prefixSuggestion, 0, len(docs))
+	for _, doc := range docs {
+		s := search.Suggestion{Candidate: toSearchCandidate(t, source, doc)}
+		if source.kanaField != "" {
+			if kana, ok := doc[source.kanaField].(string); ok && kana != "" {
+				s.Kana = &kana
+			}
+		}
+		s.Hint.BirthYear = yearOf(doc["birthdate"])
+		s.Hint.FormationYear = yearOf(doc["formation_date"])
+		s.Hint.ReleaseYear = yearOf(doc["release_date"])
+		if start, ok := timeOf(doc["start_date_time"]); ok {
+			s.Hint.EventDate = &start
+		}
+		if prefecture, ok := doc["prefecture"].(string); ok {
+			s.Hint.Prefecture = prefecture
+		}
+		agencyID, _ := doc["agency_id"].(string)
+		suggestions = append(suggestions, prefixSuggestion{suggestion: s, agencyID: agencyID})
+	}
+	return suggestions, nil
+}
+
+// fillAgencyNames は候補の所属事務所名を一括で取得して補足情報に設定する。
+// 削除済み・存在しない事務所は設定しない
+func (r *SearchRepository) fillAgencyNames(ctx context.Context, suggestions []prefixSuggestion) error {
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, s := range suggestions {
+		id := s.agencyID
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	cursor, err := r.db.Collection("agencies").Find(ctx,
+		bson.M{"_id": bson.M{"$in": ids}, "is_deleted": bson.M{"$ne": true}},
+		options.Find().SetProjection(bson.M{"_id": 1, "name": 1}))
+	if err != nil {
+		return fmt.Errorf("事務所名の取得エラー: %w", err)
+	}
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		return fmt.Errorf("データ変換エラー: %w", err)
+	}
+	names := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		if name, ok := doc["name"].(string); ok {
+			names[documentID(doc["_id"])] = name
+		}
+	}
+	for i := range suggestions {
+		if id := suggestions[i].agencyID; id != "" {
+			suggestions[i].suggestion.Hint.AgencyName = names[id]
+		}
+	}
+	return nil
+}
+
+// timeOf はドキュメントの日時値を time.Time に変換する
+func timeOf(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case bson.DateTime:
+		return v.Time().UTC(), true
+	case time.Time:
+		return v.UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// yearOf はドキュメントの日時値の年を返す（値がない場合は 0）
+func yearOf(value any) int {
+	if t, ok := timeOf(value); ok {
+		return t.Year()
+	}
+	return 0
+}
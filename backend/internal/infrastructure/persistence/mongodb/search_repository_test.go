@@ -0,0 +1,52 @@
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/search"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestFuzzySearchConditionUsesBigramsOfLongKeys(t *testing.T) {
+	condition := fuzzySearchCondition("のぎざか")
+
+	patterns := condition["$in"].(bson.A)
+	assert.Contains(t, patterns, bson.Regex{Pattern: "のぎ"})
+	assert.Contains(t, patterns, bson.Regex{Pattern: "ざか"})
+	assert.Contains(t, patterns, bson.Regex{Pattern: "no"})
+}
+
+func TestFuzzySearchConditionSkipsShortQuery(t *testing.T) {
+	assert.Nil(t, fuzzySearchCondition("あ"))
+}
+
+func TestToSearchCandidateExpandsAliases(t *testing.T) {
+	id := bson.NewObjectID()
+	doc := bson.M{
+		"_id":       id,
+		"name":      "桜井ひなこ",
+		"name_kana": "さくらいひなこ",
+		"aliases":   bson.A{"ひなこ", "Hinako"},
+	}
+
+	c := toSearchCandidate(search.TypeIdol, searchSources[search.TypeIdol], doc)
+
+	assert.Equal(t, id.Hex(), c.ID)
+	assert.Equal(t, "桜井ひなこ", c.Name)
+	assert.Equal(t, []search.Field{
+		{Name: "name", Value: "桜井ひなこ"},
+		{Name: "name_kana", Value: "さくらいひなこ"},
+		{Name: "aliases", Value: "ひなこ", Alias: true},
+		{Name: "aliases", Value: "Hinako", Alias: true},
+	}, c.Fields)
+}
+
+func TestYearOfAcceptsStoredDateTypes(t *testing.T) {
+	birthdate := time.Date(2001, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 2001, yearOf(bson.NewDateTimeFromTime(birthdate)))
+	assert.Equal(t, 2001, yearOf(birthdate))
+	assert.Equal(t, 0, yearOf(nil))
+}
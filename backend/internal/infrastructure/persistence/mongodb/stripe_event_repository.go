@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domainbilling "github.com/kuro48/idol-api/internal/domain/billing"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// StripeEventRepository は MongoDB を使った Stripe Webhook イベント台帳のリポジトリ実装。
+type StripeEventRepository struct {
+	collection *mongo.Collection
+}
+
+// NewStripeEventRepository はリポジトリを作成する。
+func NewStripeEventRepository(db *mongo.Database) *StripeEventRepository {
+	return &StripeEventRepository{
+		collection: db.Collection("billing_stripe_events"),
+	}
+}
+
+type stripeEventDocument struct {
+	EventID       string     `bson:"_id"`
+	EventType     string     `bson:"event_type"`
+	CustomerID    string     `bson:"customer_id,omitempty"`
+	ObjectID      string     `bson:"object_id,omitempty"`
+	Created       time.Time  `bson:"created"`
+	Payload       []byte     `bson:"payload"`
+	Status        string     `bson:"status"`
+	ErrorMsg      string     `bson:"error_msg,omitempty"`
+	Attempts      int        `bson:"attempts"`
+	ReceivedAt    time.Time  `bson:"received_at"`
+	LastAttemptAt *time.Time `bson:"last_attempt_at,omitempty"`
+	ProcessedAt   *time.Time `bson:"processed_at,omitempty"`
+}
+
+// EnsureIndexes はコレクションインデックスを作成する。
+func (r *StripeEventRepository) EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "received_at", Value: -1}},
+			Options: options.Index().SetName("idx_stripe_event_status_received_at"),
+		},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// Claim はイベントを処理中として確保する。
+// event_id を _id にした upsert で、既存かつ引き継ぎ対象外のイベントは重複キーとなり false を返す。
+func (r *StripeEventRepository) Claim(ctx context.Context, event *domainbilling.StripeEvent, staleBefore time.Time) (bool, error) {
+	now := time.Now()
+	if event.LastAttemptAt() != nil {
+		now = *event.LastAttemptAt()
+	}
+	filter := bson.M{
+		"_id": event.EventID(),
+		"$or": bson.A{
+			bson.M{"status": string(domainbilling.StripeEventStatusFailed)},
+			bson.M{"status": string(domainbilling.StripeEventStatusProcessing), "last_attempt_at": bson.M{"$lt": staleBefore}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":          string(domainbilling.StripeEventStatusProcessing),
+			"error_msg":       "",
+			"last_attempt_at": now,
+		},
+		"$inc": bson.M{"attempts": 1},
+		"$setOnInsert": bson.M{
+			"event_type":  event.EventType(),
+			"customer_id": event.CustomerID(),
+			"object_id":   event.ObjectID(),
+			"created":     event.Created(),
+			"payload":     event.Payload(),
+			"received_at": event.ReceivedAt(),
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("Stripeイベントの確保に失敗しました: %w", err)
+	}
+	return result.UpsertedCount == 1 || result.ModifiedCount == 1, nil
+}
+
+// FindByEventID はイベントIDから台帳エントリを取得する。
+func (r *StripeEventRepository) FindByEventID(ctx context.Context, eventID string) (*domainbilling.StripeEvent, error) {
+	var doc stripeEventDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": eventID}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Stripeイベントの取得に失敗しました: %w", err)
+	}
+	return toStripeEventDomain(&doc), nil
+}
+
+// Update は処理結果を更新する。
+func (r *StripeEventRepository) Update(ctx context.Context, event *domainbilling.StripeEvent) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":       string(event.Status()),
+			"error_msg":    event.ErrorMsg(),
+			"processed_at": event.ProcessedAt(),
+		},
+	}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": event.EventID()}, update); err != nil {
+		return fmt.Errorf("Stripeイベントの更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// List は処理状態で絞り込んだイベントを受信日時の新しい順に返す。
+func (r *StripeEventRepository) List(ctx context.Context, status domainbilling.StripeEventStatus, limit, offset int) ([]*domainbilling.StripeEvent, int64, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = string(status)
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Stripeイベント件数の取得に失敗しました: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "received_at", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"payload": 0})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Stripeイベント一覧の取得に失敗しました: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []stripeEventDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, 0, fmt.Errorf("Stripeイベント一覧のデコードに失敗しました: %w", err)
+	}
+
+	events := make([]*domainbilling.StripeEvent, 0, len(docs))
+	for i := range docs {
+		events = append(events, toStripeEventDomain(&docs[i]))
+	}
+	return events, total, nil
+}
+
+func toStripeEventDomain(doc *stripeEventDocument) *domainbilling.StripeEvent {
+	return domainbilling.ReconstructStripeEvent(
+		doc.EventID,
+		doc.EventType,
+		doc.CustomerID,
+		doc.ObjectID,
+		doc.Created,
+		doc.Payload,
+		domainbilling.StripeEventStatus(doc.Status),
+		doc.ErrorMsg,
+		doc.Attempts,
+		doc.ReceivedAt,
+		doc.LastAttemptAt,
+		doc.ProcessedAt,
+	)
+}
@@ -0,0 +1,152 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domainusage "github.com/kuro48/idol-api/internal/domain/usage"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ThresholdEmailRepository はMongoDBを使用したしきい値到達メール送信箱の実装
+type ThresholdEmailRepository struct {
+	collection *mongo.Collection
+}
+
+// NewThresholdEmailRepository はしきい値到達メール送信箱のリポジトリを作成する
+func NewThresholdEmailRepository(db *mongo.Database) *ThresholdEmailRepository {
+	return &ThresholdEmailRepository{
+		collection: db.Collection("usage_threshold_emails"),
+	}
+}
+
+type thresholdEmailDocument struct {
+	ID          string     `bson:"_id"`
+	KeyPrefix   string     `bson:"key_prefix"`
+	To          string     `bson:"to"`
+	KeyName     string     `bson:"key_name"`
+	MaskedKey   string     `bson:"masked_key"`
+	PlanType    string     `bson:"plan_type"`
+	Threshold   int        `bson:"threshold"`
+	Count       int        `bson:"count"`
+	Limit       int        `bson:"limit"`
+	YearMonth   string     `bson:"year_month"`
+	UpgradeURL  string     `bson:"upgrade_url,omitempty"`
+	Overage     bool       `bson:"overage,omitempty"`
+	Attempts    int        `bson:"attempts"`
+	MaxAttempts int        `bson:"max_attempts"`
+	LastError   string     `bson:"last_error,omitempty"`
+	NextRetryAt *time.Time `bson:"next_retry_at"` // null は再送打ち切り
+	CreatedAt   time.Time  `bson:"created_at"`
+}
+
+// EnsureIndexes はコレクションのインデックスを作成する
+func (r *ThresholdEmailRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "next_retry_at", Value: 1}},
+		Options: options.Index().SetName("idx_threshold_emails_next_retry"),
+	})
+	return err
+}
+
+// Save は再送待ちのメールを保存する（同じ ID は上書き）
+func (r *ThresholdEmailRepository) Save(ctx context.Context, email *domainusage.ThresholdEmail) error {
+	doc := toThresholdEmailDocument(email)
+	opts := options.Replace().SetUpsert(true)
+	if _, err := r.collection.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, opts); err != nil {
+		return fmt.Errorf("しきい値到達メールの保存に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue は再送時刻を過ぎたメールを1件確保し、再送時刻を lease 後へ進めて返す
+// 確保は FindOneAndUpdate で行うため、複数レプリカが同じメールを同時に再送することはない
+func (r *ThresholdEmailRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*domainusage.ThresholdEmail, error) {
+	filter := bson.M{"next_retry_at": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"next_retry_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_retry_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var doc thresholdEmailDocument
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("しきい値到達メールの確保に失敗しました: %w", err)
+	}
+	return doc.toDomain(), nil
+}
+
+// Update は再送結果を保存する
+func (r *ThresholdEmailRepository) Update(ctx context.Context, email *domainusage.ThresholdEmail) error {
+	update := bson.M{"$set": bson.M{
+		"attempts":      email.Attempts(),
+		"last_error":    email.LastError(),
+		"next_retry_at": email.NextRetryAt(),
+	}}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": email.ID()}, update); err != nil {
+		return fmt.Errorf("しきい値到達メールの更新に失敗しました: %w", err)
+	}
+	return nil
+}
+
+// Delete は送信できたメールを消す
+func (r *ThresholdEmailRepository) Delete(ctx context.Context, id string) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("しきい値到達メールの削除に失敗しました: %w", err)
+	}
+	return nil
+}
+
+func toThresholdEmailDocument(email *domainusage.ThresholdEmail) thresholdEmailDocument {
+	alert := email.Alert()
+	return thresholdEmailDocument{
+		ID:          email.ID(),
+		KeyPrefix:   email.KeyPrefix(),
+		To:          alert.To,
+		KeyName:     alert.KeyName,
+		MaskedKey:   alert.MaskedKey,
+		PlanType:    alert.PlanType,
+		Threshold:   alert.Threshold,
+		Count:       alert.Count,
+		Limit:       alert.Limit,
+		YearMonth:   alert.YearMonth,
+		UpgradeURL:  alert.UpgradeURL,
+		Overage:     alert.Overage,
+		Attempts:    email.Attempts(),
+		MaxAttempts: email.MaxAttempts(),
+		LastError:   email.LastError(),
+		NextRetryAt: email.NextRetryAt(),
+		CreatedAt:   email.CreatedAt(),
+	}
+}
+
+func (d *thresholdEmailDocument) toDomain() *domainusage.ThresholdEmail {
+	return domainusage.ReconstructThresholdEmail(
+		d.ID,
+		d.KeyPrefix,
+		domainusage.ThresholdAlert{
+			To:         d.To,
+			KeyName:    d.KeyName,
+			MaskedKey:  d.MaskedKey,
+			PlanType:   d.PlanType,
+			Threshold:  d.Threshold,
+			Count:      d.Count,
+			Limit:      d.Limit,
+			YearMonth:  d.YearMonth,
+			UpgradeURL: d.UpgradeURL,
+			Overage:    d.Overage,
+		},
+		d.Attempts,
+		d.MaxAttempts,
+		d.LastError,
+		d.NextRetryAt,
+		d.CreatedAt,
+	)
+}
@@ -0,0 +1,206 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/domain/tour"
+	"github.com/kuro48/idol-api/internal/shared/audit"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// TourRepository はMongoDBを使用したツアーリポジトリの実装
+type TourRepository struct {
+	collection *mongo.Collection
+}
+
+// NewTourRepository はTourRepositoryを作成する
+func NewTourRepository(db *mongo.Database) *TourRepository {
+	return &TourRepository{collection: db.Collection("tours")}
+}
+
+// tourDocument はMongoDBに保存するツアードキュメント
+type tourDocument struct {
+	ID                string              `bson:"_id"`
+	Title             string              `bson:"title"`
+	EventType         string              `bson:"event_type"`
+	Performers        []performerDocument `bson:"performers"`
+	Tags              []string            `bson:"tags"`
+	OfficialURL       *string             `bson:"official_url,omitempty"`
+	Description       *string             `bson:"description,omitempty"`
+	SearchKeys        []string            `bson:"search_keys"`
+	SearchKeysVersion int                 `bson:"search_keys_version"`
+	CreatedAt         time.Time           `bson:"created_at"`
+	UpdatedAt         time.Time           `bson:"updated_at"`
+	CreatedBy         string              `bson:"created_by,omitempty"`
+	UpdatedBy         string              `bson:"updated_by,omitempty"`
+	Source            string              `bson:"source,omitempty"`
+	IsDeleted         bool                `bson:"is_deleted,omitempty"`
+	DeletedAt         *time.Time          `bson:"deleted_at,omitempty"`
+	DeletedBy         string              `bson:"deleted_by,omitempty"`
+}
+
+// Save は新しいツアーを保存する
+func (r *TourRepository) Save(ctx context.Context, t *tour.Tour) error {
+	doc := toTourDocument(t)
+	doc.CreatedBy = audit.ActorFrom(ctx)
+	doc.UpdatedBy = audit.ActorFrom(ctx)
+	doc.Source = audit.SourceFrom(ctx)
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("ツアーの保存エラー: %w", err)
+	}
+	return nil
+}
+
+// FindByID はIDでツアーを取得する
+func (r *TourRepository) FindByID(ctx context.Context, id tour.TourID) (*tour.Tour, error) {
+	var doc tourDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id.Value(), "is_deleted": bson.M{"$ne": true}}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("ツアーが見つかりません")
+		}
+		return nil, fmt.Errorf("ツアーの検索エラー: %w", err)
+	}
+	return fromTourDocument(&doc)
+}
+
+// Search は条件に合うツアーを作成日時の新しい順に取得する
+func (r *TourRepository) Search(ctx context.Context, criteria tour.SearchCriteria) ([]*tour.Tour, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(criteria.Offset)).
+		SetLimit(int64(criteria.Limit))
+
+	cursor, err := r.collection.Find(ctx, buildTourFilter(criteria), opts)
+	if err != nil {
+		return nil, fmt.Errorf("ツアー検索エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []tourDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("データ変換エラー: %w", err)
+	}
+	tours := make([]*tour.Tour, 0, len(docs))
+	for _, doc := range docs {
+		t, err := fromTourDocument(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("ドメインモデル変換エラー: %w", err)
+		}
+		tours = append(tours, t)
+	}
+	return tours, nil
+}
+
+// Count は条件に合うツアーの件数を返す
+func (r *TourRepository) Count(ctx context.Context, criteria tour.SearchCriteria) (int64, error) {
+	return r.collection.CountDocuments(ctx, buildTourFilter(criteria))
+}
+
+// Update は既存のツアーを更新する
+func (r *TourRepository) Update(ctx context.Context, t *tour.Tour) error {
+	doc := toTourDocument(t)
+	doc.UpdatedBy = audit.ActorFrom(ctx)
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": t.ID().Value(), "is_deleted": bson.M{"$ne": true}}, doc)
+	if err != nil {
+		return fmt.Errorf("ツアーの更新エラー: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("ツアーが見つかりません")
+	}
+	return nil
+}
+
+// Delete はツアーをソフトデリートする
+func (r *TourRepository) Delete(ctx context.Context, id tour.TourID) error {
+	now := time.Now()
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id.Value(), "is_deleted": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{
+			"is_deleted": true,
+			"deleted_at": now,
+			"deleted_by": audit.ActorFrom(ctx),
+			"updated_at": now,
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("ツアーの削除エラー: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("ツアーが見つかりません")
+	}
+	return nil
+}
+
+// EnsureIndexes はツアー検索用のインデックスを作成する
+func (r *TourRepository) EnsureIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "performers.performer_id", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		searchKeysIndex("idx_tour_search_keys"),
+	}
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return fmt.Errorf("インデックス作成エラー: %w", err)
+	}
+	return nil
+}
+
+func buildTourFilter(criteria tour.SearchCriteria) bson.M {
+	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
+	if criteria.Title != nil {
+		filter["$or"] = nameSearchConditions(*criteria.Title, "title")
+	}
+	if criteria.PerformerID != nil {
+		filter["performers.performer_id"] = *criteria.PerformerID
+	}
+	return filter
+}
+
+func toTourDocument(t *tour.Tour) *tourDocument {
+	performers := make([]performerDocument, 0, len(t.Performers()))
+	for _, p := range t.Performers() {
+		performers = append(performers, performerDocument{
+			PerformerID:   p.PerformerID,
+			BillingStatus: string(p.BillingStatus),
+		})
+	}
+	return &tourDocument{
+		ID:                t.ID().Value(),
+		Title:             t.Title(),
+		EventType:         t.EventType().Value(),
+		Performers:        performers,
+		Tags:              t.Tags(),
+		OfficialURL:       t.OfficialURL(),
+		Description:       t.Description(),
+		SearchKeys:        searchkey.Keys(t.Title()),
+		SearchKeysVersion: searchKeysVersion,
+		CreatedAt:         t.CreatedAt(),
+		UpdatedAt:         t.UpdatedAt(),
+	}
+}
+
+func fromTourDocument(doc *tourDocument) (*tour.Tour, error) {
+	id, err := tour.NewTourID(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+	eventType, err := event.NewEventType(doc.EventType)
+	if err != nil {
+		return nil, err
+	}
+	performers := make([]event.Performer, 0, len(doc.Performers))
+	for _, p := range doc.Performers {
+		performers = append(performers, event.Performer{
+			PerformerID:   p.PerformerID,
+			BillingStatus: event.NewBillingStatus(p.BillingStatus),
+		})
+	}
+	return tour.Reconstruct(id, doc.Title, eventType, performers, doc.Tags, doc.OfficialURL, doc.Description, doc.CreatedAt, doc.UpdatedAt), nil
+}
@@ -25,12 +25,29 @@ func NewUsageRepository(db *mongo.Database) *UsageRepository {
 
 // apiKeyUsageDocument はMongoDBに保存するドキュメント構造
 type apiKeyUsageDocument struct {
-	ID        string    `bson:"_id"`        // "{key_prefix}_{year_month}" の複合キー
-	KeyPrefix string    `bson:"key_prefix"`
-	YearMonth string    `bson:"year_month"`
-	Count     int       `bson:"count"`
-	Limit     int       `bson:"limit"`
-	UpdatedAt time.Time `bson:"updated_at"`
+	ID                 string                  `bson:"_id"` // "{key_prefix}_{year_month}" の複合キー
+	KeyPrefix          string                  `bson:"key_prefix"`
+	YearMonth          string                  `bson:"year_month"`
+	Count              int                     `bson:"count"`
+	Limit              int                     `bson:"limit"`
+	NotifiedThresholds []int                   `bson:"notified_thresholds,omitempty"` // 当月に通知済みの使用率しきい値（%）
+	OverageCount       int                     `bson:"overage_count,omitempty"`       // 上限超過で受け付けたリクエスト数
+	OverageReported    int                     `bson:"overage_reported,omitempty"`    // Stripe への報告を確保済みの超過リクエスト数
+	OveragePending     *overagePendingDocument `bson:"overage_pending,omitempty"`     // Stripe への送信が完了していない報告範囲
+	UpdatedAt          time.Time               `bson:"updated_at"`
+}
+
+type overagePendingDocument struct {
+	From       int    `bson:"from"`
+	To         int    `bson:"to"`
+	Identifier string `bson:"identifier"`
+}
+
+func (d *overagePendingDocument) toDomain() *domainusage.PendingOverageReport {
+	if d == nil {
+		return nil
+	}
+	return &domainusage.PendingOverageReport{From: d.From, To: d.To, Identifier: d.Identifier}
 }
 
 // EnsureIndexes はコレクションのインデックスを作成する
@@ -40,6 +57,10 @@ func (r *UsageRepository) EnsureIndexes(ctx context.Context) error {
 			Keys:    bson.D{{Key: "key_prefix", Value: 1}, {Key: "year_month", Value: 1}},
 			Options: options.Index().SetName("idx_usage_prefix_month"),
 		},
+		{
+			Keys:    bson.D{{Key: "year_month", Value: 1}, {Key: "overage_count", Value: 1}},
+			Options: options.Index().SetName("idx_usage_month_overage"),
+		},
 	}
 	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
 	return err
@@ -70,7 +91,7 @@ func (r *UsageRepository) IncrementAndGet(ctx context.Context, keyPrefix, yearMo
 		return nil, fmt.Errorf("使用量のインクリメントに失敗しました: %w", err)
 	}
 
-	return domainusage.Reconstruct(doc.KeyPrefix, doc.YearMonth, doc.Count, doc.Limit, doc.UpdatedAt), nil
+	return domainusage.Reconstruct(doc.KeyPrefix, doc.YearMonth, doc.Count, doc.Limit, doc.NotifiedThresholds, doc.UpdatedAt), nil
 }
 
 // Get は使用量を取得する（インクリメントなし）
@@ -87,5 +108,123 @@ func (r *UsageRepository) Get(ctx context.Context, keyPrefix, yearMonth string,
 		return nil, fmt.Errorf("使用量の取得に失敗しました: %w", err)
 	}
 
-	return domainusage.Reconstruct(doc.KeyPrefix, doc.YearMonth, doc.Count, doc.Limit, doc.UpdatedAt), nil
+	return domainusage.Reconstruct(doc.KeyPrefix, doc.YearMonth, doc.Count, doc.Limit, doc.NotifiedThresholds, doc.UpdatedAt), nil
+}
+
+// ClaimThreshold はしきい値の通知済みフラグを原子的に立てる
+// 未通知の場合のみ $addToSet が適用されるため、同時に呼ばれても true を返すのは1回だけ
+func (r *UsageRepository) ClaimThreshold(ctx context.Context, keyPrefix, yearMonth string, threshold int) (bool, error) {
+	docID := keyPrefix + "_" + yearMonth
+
+	filter := bson.M{
+		"_id":                 docID,
+		"notified_thresholds": bson.M{"$ne": threshold},
+	}
+	update := bson.M{"$addToSet": bson.M{"notified_thresholds": threshold}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("しきい値通知フラグの更新に失敗しました: %w", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// IncrementOverage は月間上限を超えて受け付けたリクエスト数を1増やす
+func (r *UsageRepository) IncrementOverage(ctx context.Context, keyPrefix, yearMonth string) error {
+	docID := keyPrefix + "_" + yearMonth
+
+	update := bson.M{"$inc": bson.M{"overage_count": 1}}
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": docID}, update); err != nil {
+		return fmt.Errorf("超過使用量のインクリメントに失敗しました: %w", err)
+	}
+	return nil
+}
+
+// GetOverage は超過リクエスト数と報告済み数を取得する
+func (r *UsageRepository) GetOverage(ctx context.Context, keyPrefix, yearMonth string) (*domainusage.OverageUsage, error) {
+	docID := keyPrefix + "_" + yearMonth
+
+	var doc apiKeyUsageDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": docID}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domainusage.ReconstructOverage(keyPrefix, yearMonth, 0, 0, nil), nil
+		}
+		return nil, fmt.Errorf("超過使用量の取得に失敗しました: %w", err)
+	}
+
+	return domainusage.ReconstructOverage(keyPrefix, yearMonth, doc.OverageCount, doc.OverageReported, doc.OveragePending.toDomain()), nil
+}
+
+// ListUnreportedOverages は指定月に未報告の超過リクエスト、または送信未完了の報告範囲がある使用量を返す
+func (r *UsageRepository) ListUnreportedOverages(ctx context.Context, yearMonth string) ([]*domainusage.OverageUsage, error) {
+	filter := bson.M{
+		"year_month":    yearMonth,
+		"overage_count": bson.M{"$gt": 0},
+		"$or": bson.A{
+			bson.M{"overage_pending": bson.M{"$exists": true}},
+			bson.M{"$expr": bson.M{"$gt": bson.A{
+				"$overage_count",
+				bson.M{"$ifNull": bson.A{"$overage_reported", 0}},
+			}}},
+		},
+	}
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("超過使用量の検索に失敗しました: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []apiKeyUsageDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("超過使用量のデコードに失敗しました: %w", err)
+	}
+
+	overages := make([]*domainusage.OverageUsage, 0, len(docs))
+	for _, doc := range docs {
+		overages = append(overages, domainusage.ReconstructOverage(doc.KeyPrefix, doc.YearMonth, doc.OverageCount, doc.OverageReported, doc.OveragePending.toDomain()))
+	}
+	return overages, nil
+}
+
+// ClaimOverageReport は報告済み数を report.From から report.To へ進め、report を送信未完了として記録する
+// 報告済み数が From のままで送信未完了の範囲がない場合のみ更新されるため、同時に呼ばれても true を返すのは1回だけ
+func (r *UsageRepository) ClaimOverageReport(ctx context.Context, keyPrefix, yearMonth string, report domainusage.PendingOverageReport) (bool, error) {
+	docID := keyPrefix + "_" + yearMonth
+
+	filter := bson.M{
+		"_id":              docID,
+		"overage_reported": report.From,
+		"overage_pending":  bson.M{"$exists": false},
+	}
+	if report.From == 0 {
+		// 未報告のドキュメントにはフィールドが存在しない
+		filter["overage_reported"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	update := bson.M{"$set": bson.M{
+		"overage_reported": report.To,
+		"overage_pending": overagePendingDocument{
+			From:       report.From,
+			To:         report.To,
+			Identifier: report.Identifier,
+		},
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("超過使用量の報告済み数の更新に失敗しました: %w", err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// CompleteOverageReport は識別子が一致する送信未完了の報告範囲を消す
+func (r *UsageRepository) CompleteOverageReport(ctx context.Context, keyPrefix, yearMonth, identifier string) error {
+	docID := keyPrefix + "_" + yearMonth
+
+	filter := bson.M{"_id": docID, "overage_pending.identifier": identifier}
+	update := bson.M{"$unset": bson.M{"overage_pending": ""}}
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("超過使用量の報告完了の記録に失敗しました: %w", err)
+	}
+	return nil
 }
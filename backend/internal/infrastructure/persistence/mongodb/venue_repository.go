@@ -8,6 +8,8 @@ import (
 
 	"github.com/kuro48/idol-api/internal/domain/venue"
 	"github.com/kuro48/idol-api/internal/shared/audit"
+	"github.com/kuro48/idol-api/internal/shared/geo"
+	"github.com/kuro48/idol-api/internal/shared/searchkey"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -22,24 +24,76 @@ func NewVenueRepository(db *mongo.Database) *VenueRepository {
 }
 
 type venueDocument struct {
-	ID          bson.ObjectID    `bson:"_id,omitempty"`
-	Name        string           `bson:"name"`
-	NameEn      *string          `bson:"name_en,omitempty"`
-	Prefecture  *string          `bson:"prefecture,omitempty"`
-	City        *string          `bson:"city,omitempty"`
-	Address     *string          `bson:"address,omitempty"`
-	Capacity    *int             `bson:"capacity,omitempty"`
-	OfficialURL *string          `bson:"official_url,omitempty"`
-	Sources     []sourceDocument `bson:"sources,omitempty"`
-	Version     int              `bson:"version"`
-	CreatedAt   time.Time        `bson:"created_at"`
-	UpdatedAt   time.Time        `bson:"updated_at"`
-	CreatedBy   string           `bson:"created_by,omitempty"`
-	UpdatedBy   string           `bson:"updated_by,omitempty"`
-	Source      string           `bson:"source,omitempty"`
-	IsDeleted   bool             `bson:"is_deleted,omitempty"`
-	DeletedAt   *time.Time       `bson:"deleted_at,omitempty"`
-	DeletedBy   string           `bson:"deleted_by,omitempty"`
+	ID                bson.ObjectID                   `bson:"_id,omitempty"`
+	Name              string                          `bson:"name"`
+	NameEn            *string                         `bson:"name_en,omitempty"`
+	Prefecture        *string                         `bson:"prefecture,omitempty"`
+	City              *string                         `bson:"city,omitempty"`
+	Address           *string                         `bson:"address,omitempty"`
+	Capacity          *int                            `bson:"capacity,omitempty"`
+	OfficialURL       *string                         `bson:"official_url,omitempty"`
+	Location          *geoJSONPoint                   `bson:"location,omitempty"`
+	Configurations    []capacityConfigurationDocument `bson:"configurations,omitempty"`
+	SearchKeys        []string                        `bson:"search_keys"`
+	SearchKeysVersion int                             `bson:"search_keys_version"`
+	Sources           []sourceDocument                `bson:"sources,omitempty"`
+	Version           int                             `bson:"version"`
+	CreatedAt         time.Time                       `bson:"created_at"`
+	UpdatedAt         time.Time                       `bson:"updated_at"`
+	CreatedBy         string                          `bson:"created_by,omitempty"`
+	UpdatedBy         string                          `bson:"updated_by,omitempty"`
+	Source            string                          `bson:"source,omitempty"`
+	IsDeleted         bool                            `bson:"is_deleted,omitempty"`
+	DeletedAt         *time.Time                      `bson:"deleted_at,omitempty"`
+	DeletedBy         string                          `bson:"deleted_by,omitempty"`
+}
+
+// geoJSONPoint は 2dsphere インデックスで扱う GeoJSON の Point（coordinates は [経度, 緯度]）
+type geoJSONPoint struct {
+	Type        string    `bson:"type"`
+	Coordinates []float64 `bson:"coordinates"`
+}
+
+// capacityConfigurationDocument は会場の収容構成
+type capacityConfigurationDocument struct {
+	ID       string `bson:"id"`
+	Name     string `bson:"name"`
+	Layout   string `bson:"layout"`
+	Capacity int    `bson:"capacity"`
+}
+
+func toCapacityConfigurationDocuments(configs []venue.CapacityConfiguration) []capacityConfigurationDocument {
+	docs := make([]capacityConfigurationDocument, 0, len(configs))
+	for _, c := range configs {
+		docs = append(docs, capacityConfigurationDocument{ID: c.ID(), Name: c.Name(), Layout: string(c.Layout()), Capacity: c.Capacity()})
+	}
+	return docs
+}
+
+func fromCapacityConfigurationDocuments(docs []capacityConfigurationDocument) []venue.CapacityConfiguration {
+	configs := make([]venue.CapacityConfiguration, 0, len(docs))
+	for _, d := range docs {
+		configs = append(configs, venue.ReconstructCapacityConfiguration(d.ID, d.Name, venue.Layout(d.Layout), d.Capacity))
+	}
+	return configs
+}
+
+func toGeoJSONPoint(p *geo.Point) *geoJSONPoint {
+	if p == nil {
+		return nil
+	}
+	return &geoJSONPoint{Type: "Point", Coordinates: []float64{p.Longitude(), p.Latitude()}}
+}
+
+func fromGeoJSONPoint(doc *geoJSONPoint) *geo.Point {
+	if doc == nil || len(doc.Coordinates) != 2 {
+		return nil
+	}
+	p, err := geo.NewPoint(doc.Coordinates[1], doc.Coordinates[0])
+	if err != nil {
+		return nil
+	}
+	return &p
 }
 
 func (r *VenueRepository) Save(ctx context.Context, v *venue.Venue) error {
@@ -82,6 +136,9 @@ func (r *VenueRepository) FindByID(ctx context.Context, id venue.VenueID) (*venu
 
 func (r *VenueRepository) Search(ctx context.Context, criteria venue.SearchCriteria) ([]*venue.Venue, error) {
 	filter := buildVenueFilter(criteria)
+	if criteria.Near != nil {
+		return r.searchNear(ctx, filter, criteria)
+	}
 
 	sortOrder := 1
 	if criteria.Order == "desc" {
@@ -96,6 +153,31 @@ func (r *VenueRepository) Search(ctx context.Context, criteria venue.SearchCrite
 		SetSort(bson.D{{Key: sortField, Value: sortOrder}}).
 		SetSkip(int64(criteria.Offset)).
 		SetLimit(int64(criteria.Limit))
+	if projection := omitProjection(criteria.Omit, "configurations"); projection != nil {
+		opts.SetProjection(projection)
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("会場検索エラー: %w", err)
+	}
+	defer cursor.Close(ctx)
+	return scanVenueCursor(ctx, cursor)
+}
+
+// searchNear は中心から半径以内の会場を近い順に取得する。
+// $nearSphere は距離順に並べるため、他のソート条件は指定しない
+func (r *VenueRepository) searchNear(ctx context.Context, filter bson.M, criteria venue.SearchCriteria) ([]*venue.Venue, error) {
+	filter["location"] = bson.M{"$nearSphere": bson.M{
+		"$geometry":    toGeoJSONPoint(criteria.Near),
+		"$maxDistance": criteria.RadiusKm * 1000,
+	}}
+	opts := options.Find().
+		SetSkip(int64(criteria.Offset)).
+		SetLimit(int64(criteria.Limit))
+	if projection := omitProjection(criteria.Omit, "configurations"); projection != nil {
+		opts.SetProjection(projection)
+	}
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -159,6 +241,8 @@ func (r *VenueRepository) EnsureIndexes(ctx context.Context) error {
 		{Keys: bson.D{{Key: "name", Value: 1}}},
 		{Keys: bson.D{{Key: "prefecture", Value: 1}}},
 		{Keys: bson.D{{Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+		searchKeysIndex("idx_venue_search_keys"),
 	}
 	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
 	if err != nil {
@@ -175,17 +259,21 @@ func toVenueDocument(v *venue.Venue) *venueDocument {
 		}
 	}
 	return &venueDocument{
-		ID:          objectID,
-		Name:        v.Name(),
-		NameEn:      v.NameEn(),
-		Prefecture:  v.Prefecture(),
-		City:        v.City(),
-		Address:     v.Address(),
-		Capacity:    v.Capacity(),
-		OfficialURL: v.OfficialURL(),
-		Sources:     toSourceDocuments(v.Sources()),
-		CreatedAt:   v.CreatedAt(),
-		UpdatedAt:   v.UpdatedAt(),
+		ID:                objectID,
+		Name:              v.Name(),
+		NameEn:            v.NameEn(),
+		Prefecture:        v.Prefecture(),
+		City:              v.City(),
+		Address:           v.Address(),
+		Capacity:          v.Capacity(),
+		OfficialURL:       v.OfficialURL(),
+		Location:          toGeoJSONPoint(v.Location()),
+		Configurations:    toCapacityConfigurationDocuments(v.Configurations()),
+		SearchKeys:        searchkey.Keys(stringValues(v.Name(), v.NameEn())...),
+		SearchKeysVersion: searchKeysVersion,
+		Sources:           toSourceDocuments(v.Sources()),
+		CreatedAt:         v.CreatedAt(),
+		UpdatedAt:         v.UpdatedAt(),
 	}
 }
 
@@ -204,6 +292,8 @@ func fromVenueDocument(doc *venueDocument) (*venue.Venue, error) {
 		doc.Address,
 		doc.Capacity,
 		doc.OfficialURL,
+		fromGeoJSONPoint(doc.Location),
+		fromCapacityConfigurationDocuments(doc.Configurations),
 		fromSourceDocuments(doc.Sources),
 		doc.CreatedAt,
 		doc.UpdatedAt,
@@ -213,11 +303,20 @@ func fromVenueDocument(doc *venueDocument) (*venue.Venue, error) {
 func buildVenueFilter(criteria venue.SearchCriteria) bson.M {
 	filter := bson.M{"is_deleted": bson.M{"$ne": true}}
 	if criteria.Name != nil {
-		filter["name"] = bson.M{"$regex": safePartialMatchRegex(*criteria.Name), "$options": "i"}
+		filter["$or"] = nameSearchConditions(*criteria.Name, "name", "name_en")
 	}
 	if criteria.Prefecture != nil {
 		filter["prefecture"] = *criteria.Prefecture
 	}
+	if criteria.Near != nil {
+		// 件数取得では $nearSphere を使えないため、同じ範囲を $geoWithin で指定する（Search では近い順の条件に置き換える）
+		filter["location"] = bson.M{"$geoWithin": bson.M{
+			"$centerSphere": bson.A{
+				bson.A{criteria.Near.Longitude(), criteria.Near.Latitude()},
+				criteria.RadiusKm / geo.EarthRadiusKm,
+			},
+		}}
+	}
 	return filter
 }
 
@@ -242,3 +341,8 @@ func scanVenueCursor(ctx context.Context, cursor *mongo.Cursor) ([]*venue.Venue,
 	}
 	return result, nil
 }
+
+// BackfillSearchKeys は検索キーが未生成または古い版の既存会場に正規化キーを生成する
+func (r *VenueRepository) BackfillSearchKeys(ctx context.Context) (int, error) {
+	return backfillSearchKeys(ctx, r.collection, "name", "name_en")
+}
@@ -48,13 +48,20 @@ func NewClient(secretKey, webhookSecret string) *Client {
 func (c *Client) CreateCheckoutSession(ctx context.Context, input appBilling.CreateCheckoutSessionInput) (*appBilling.CheckoutSession, error) {
 	values := url.Values{}
 	values.Set("mode", "subscription")
-	values.Set("customer_email", input.Email)
+	if input.CustomerID != "" {
+		values.Set("customer", input.CustomerID)
+	} else {
+		values.Set("customer_email", input.Email)
+	}
 	values.Set("success_url", input.SuccessURL)
 	values.Set("cancel_url", input.CancelURL)
 	values.Set("line_items[0][price]", input.PriceID)
 	values.Set("line_items[0][quantity]", "1")
 	values.Set("metadata[name]", input.Name)
 	values.Set("metadata[plan_type]", input.PlanType)
+	if input.APIKeyID != "" {
+		values.Set("metadata[api_key_id]", input.APIKeyID)
+	}
 
 	body, err := c.doFormRequest(ctx, http.MethodPost, "/v1/checkout/sessions", values)
 	if err != nil {
@@ -75,20 +82,86 @@ func (c *Client) CreateCheckoutSession(ctx context.Context, input appBilling.Cre
 	return &appBilling.CheckoutSession{ID: resp.ID, URL: resp.URL}, nil
 }
 
+// ReportMeteredUsage は従量課金の使用量を Billing Meter Event として報告する。
+// identifier が同じイベントは Stripe 側で重複排除される。
+func (c *Client) ReportMeteredUsage(ctx context.Context, input appBilling.MeteredUsageInput) error {
+	values := url.Values{}
+	values.Set("event_name", input.EventName)
+	values.Set("payload[stripe_customer_id]", input.CustomerID)
+	values.Set("payload[value]", strconv.Itoa(input.Quantity))
+	values.Set("identifier", input.Identifier)
+	values.Set("timestamp", strconv.FormatInt(input.Timestamp.Unix(), 10))
+
+	if _, err := c.doFormRequest(ctx, http.MethodPost, "/v1/billing/meter_events", values); err != nil {
+		return err
+	}
+	return nil
+}
+
+// EnsureSubscriptionItem は指定したサブスクリプションに price の item がなければ追加する。
+// サブスクリプションが解約済みの場合はエラーを返す。
+func (c *Client) EnsureSubscriptionItem(ctx context.Context, input appBilling.EnsureSubscriptionItemInput) error {
+	body, err := c.doFormRequest(ctx, http.MethodGet, "/v1/subscriptions/"+url.PathEscape(input.SubscriptionID), url.Values{})
+	if err != nil {
+		return err
+	}
+
+	var subscription struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+		Items  struct {
+			Data []struct {
+				Price struct {
+					ID string `json:"id"`
+				} `json:"price"`
+			} `json:"data"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &subscription); err != nil {
+		return fmt.Errorf("Stripe Subscription レスポンスの解析に失敗しました: %w", err)
+	}
+
+	switch subscription.Status {
+	case "canceled", "incomplete_expired":
+		return fmt.Errorf("無効な操作です: 従量課金を追加できる Stripe サブスクリプションがありません")
+	}
+	for _, item := range subscription.Items.Data {
+		if item.Price.ID == input.PriceID {
+			return nil
+		}
+	}
+
+	values := url.Values{}
+	values.Set("subscription", input.SubscriptionID)
+	values.Set("price", input.PriceID)
+	if _, err := c.doFormRequest(ctx, http.MethodPost, "/v1/subscription_items", values); err != nil {
+		return err
+	}
+	return nil
+}
+
 // VerifyWebhookEvent は Stripe Webhook を検証して最小イベントへ変換する。
 func (c *Client) VerifyWebhookEvent(payload []byte, signature string) (*appBilling.WebhookEvent, error) {
 	if err := c.verifySignature(payload, signature); err != nil {
 		return nil, err
 	}
+	return c.ParseWebhookEvent(payload)
+}
 
+// ParseWebhookEvent は署名検証済みの Webhook ペイロードを解析する。
+// 台帳に保存したイベントの再実行で使う。
+func (c *Client) ParseWebhookEvent(payload []byte) (*appBilling.WebhookEvent, error) {
 	var event struct {
-		Type string `json:"type"`
-		Data struct {
+		ID      string `json:"id"`
+		Type    string `json:"type"`
+		Created int64  `json:"created"`
+		Data    struct {
 			Object struct {
 				ID            string            `json:"id"`
 				Customer      string            `json:"customer"`
 				CustomerEmail string            `json:"customer_email"`
 				Status        string            `json:"status"`
+				Subscription  string            `json:"subscription"`
 				Paid          bool              `json:"paid"`
 				Metadata      map[string]string `json:"metadata"`
 				Items         *struct {
@@ -102,6 +175,12 @@ func (c *Client) VerifyWebhookEvent(payload []byte, signature string) (*appBilli
 					Email string `json:"email"`
 					Name  string `json:"name"`
 				} `json:"customer_details"`
+				// 新しい API バージョンの Invoice は Subscription ID を parent に持つ
+				Parent *struct {
+					SubscriptionDetails *struct {
+						Subscription string `json:"subscription"`
+					} `json:"subscription_details"`
+				} `json:"parent"`
 			} `json:"object"`
 		} `json:"data"`
 	}
@@ -109,7 +188,10 @@ func (c *Client) VerifyWebhookEvent(payload []byte, signature string) (*appBilli
 		return nil, fmt.Errorf("Stripe Webhook のJSON解析に失敗しました: %w", err)
 	}
 
-	result := &appBilling.WebhookEvent{Type: event.Type}
+	result := &appBilling.WebhookEvent{ID: event.ID, Type: event.Type}
+	if event.Created > 0 {
+		result.Created = time.Unix(event.Created, 0).UTC()
+	}
 	switch event.Type {
 	case appBilling.WebhookEventTypeCheckoutSessionCompleted:
 		metadata := event.Data.Object.Metadata
@@ -132,29 +214,39 @@ func (c *Client) VerifyWebhookEvent(payload []byte, signature string) (*appBilli
 		}
 
 		result.CheckoutSession = &appBilling.CheckoutSessionCompleted{
-			SessionID:  event.Data.Object.ID,
-			CustomerID: event.Data.Object.Customer,
-			Email:      email,
-			Name:       name,
-			PlanType:   planType,
+			SessionID:      event.Data.Object.ID,
+			CustomerID:     event.Data.Object.Customer,
+			SubscriptionID: event.Data.Object.Subscription,
+			Email:          email,
+			Name:           name,
+			PlanType:       planType,
+			APIKeyID:       metadata["api_key_id"],
 		}
 	case appBilling.WebhookEventTypeSubscriptionUpdated, appBilling.WebhookEventTypeSubscriptionDeleted:
-		priceID, err := subscriptionPriceID(event.Data.Object.Items)
+		priceIDs, err := subscriptionPriceIDs(event.Data.Object.Items)
 		if err != nil {
 			return nil, err
 		}
 		result.Subscription = &appBilling.SubscriptionUpdated{
+			ID:         event.Data.Object.ID,
 			CustomerID: event.Data.Object.Customer,
-			PriceID:    priceID,
+			PriceID:    priceIDs[0],
+			PriceIDs:   priceIDs,
 			Status:     event.Data.Object.Status,
 		}
 	case appBilling.WebhookEventTypeInvoicePaymentFailed, appBilling.WebhookEventTypeInvoicePaid:
 		if event.Data.Object.Customer == "" {
 			return nil, fmt.Errorf("Stripe Invoice の customer が不足しています")
 		}
+		subscriptionID := event.Data.Object.Subscription
+		if parent := event.Data.Object.Parent; subscriptionID == "" && parent != nil && parent.SubscriptionDetails != nil {
+			subscriptionID = parent.SubscriptionDetails.Subscription
+		}
 		result.Invoice = &appBilling.InvoiceUpdated{
-			CustomerID: event.Data.Object.Customer,
-			Paid:       event.Data.Object.Paid,
+			ID:             event.Data.Object.ID,
+			CustomerID:     event.Data.Object.Customer,
+			SubscriptionID: subscriptionID,
+			Paid:           event.Data.Object.Paid,
 		}
 	}
 	return result, nil
@@ -270,19 +362,24 @@ func (c *Client) doFormRequest(ctx context.Context, method, path string, values
 	return body, nil
 }
 
-func subscriptionPriceID(items *struct {
+// subscriptionPriceIDs は Subscription の全 item の price ID を返す。
+// 従量課金の metered price が基本プランと同じ Subscription に含まれるため、先頭だけでは判定できない。
+func subscriptionPriceIDs(items *struct {
 	Data []struct {
 		Price struct {
 			ID string `json:"id"`
 		} `json:"price"`
 	} `json:"data"`
-}) (string, error) {
+}) ([]string, error) {
 	if items == nil || len(items.Data) == 0 {
-		return "", fmt.Errorf("Stripe Subscription に price 情報がありません")
+		return nil, fmt.Errorf("Stripe Subscription に price 情報がありません")
 	}
-	priceID := items.Data[0].Price.ID
-	if priceID == "" {
-		return "", fmt.Errorf("Stripe Subscription の price ID が不足しています")
+	priceIDs := make([]string, 0, len(items.Data))
+	for _, item := range items.Data {
+		if item.Price.ID == "" {
+			return nil, fmt.Errorf("Stripe Subscription の price ID が不足しています")
+		}
+		priceIDs = append(priceIDs, item.Price.ID)
 	}
-	return priceID, nil
+	return priceIDs, nil
 }
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
+	"github.com/kuro48/idol-api/internal/usecase/affiliation"
+)
+
+// affiliationFields は fields パラメータで指定できる所属履歴のフィールド
+var affiliationFields = fieldset.SchemaOf(affiliation.AffiliationDTO{})
+
+type AffiliationHandler struct {
+	usecase affiliation.AffiliationUseCase
+}
+
+func NewAffiliationHandler(uc affiliation.AffiliationUseCase) *AffiliationHandler {
+	return &AffiliationHandler{usecase: uc}
+}
+
+type CreateAffiliationRequest struct {
+	TalentType   string  `json:"talent_type" binding:"required,oneof=idol group"`
+	TalentID     string  `json:"talent_id" binding:"required"`
+	AgencyID     string  `json:"agency_id" binding:"required"`
+	ContractType *string `json:"contract_type" binding:"omitempty,oneof=exclusive partnership trainee other"` // 省略時は exclusive
+	JoinedAt     *string `json:"joined_at" binding:"omitempty,datetime=2006-01-02"`
+	LeftAt       *string `json:"left_at" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type UpdateAffiliationRequest struct {
+	ContractType *string `json:"contract_type" binding:"omitempty,oneof=exclusive partnership trainee other"`
+	JoinedAt     *string `json:"joined_at"` // 空文字で解除
+	LeftAt       *string `json:"left_at"`   // 空文字で解除（所属中に戻す）
+}
+
+// CreateAffiliation は所属履歴を作成する
+// @Summary      所属履歴作成
+// @Description  アイドル・グループの事務所への所属（所属期間・契約形態付き）を登録する。同じ事務所への所属期間が重なる場合は 409
+// @Tags         affiliations
+// @Accept       json
+// @Produce      json
+// @Param        affiliation body CreateAffiliationRequest true "所属履歴作成リクエスト"
+// @Success      201 {object} affiliation.AffiliationDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      409 {object} middleware.ErrorResponse
+// @Router       /affiliations [post]
+func (h *AffiliationHandler) CreateAffiliation(c *gin.Context) {
+	var req CreateAffiliationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	cmd := affiliation.CreateAffiliationCommand{
+		TalentType:   req.TalentType,
+		TalentID:     req.TalentID,
+		AgencyID:     req.AgencyID,
+		ContractType: req.ContractType,
+		JoinedAt:     req.JoinedAt,
+		LeftAt:       req.LeftAt,
+	}
+
+	dto, err := h.usecase.CreateAffiliation(middleware.AuditContextFor(c), cmd)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Resource: "所属履歴",
+			Message:  "所属履歴の作成に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// GetAffiliation は所属履歴を取得する
+// @Summary      所属履歴詳細取得
+// @Tags         affiliations
+// @Produce      json
+// @Param        id path string true "所属履歴ID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {object} affiliation.AffiliationDTO
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /affiliations/{id} [get]
+func (h *AffiliationHandler) GetAffiliation(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	sel, ok := getFields(c, affiliationFields)
+	if !ok {
+		return
+	}
+
+	dto, err := h.usecase.GetAffiliation(c.Request.Context(), affiliation.GetAffiliationQuery{ID: id})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "所属履歴"})
+		return
+	}
+
+	writeFields(c, http.StatusOK, sel, dto)
+}
+
+// ListIdolAgencies はアイドルの所属事務所の履歴を取得する
+// @Summary      アイドルの所属事務所履歴
+// @Description  移籍を含む所属事務所の履歴を所属日の古い順に返す
+// @Tags         idols
+// @Produce      json
+// @Param        id path string true "アイドルID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {array} affiliation.AffiliationDTO
+// @Router       /idols/{id}/agencies [get]
+func (h *AffiliationHandler) ListIdolAgencies(c *gin.Context) {
+	h.listTalentAgencies(c, "idol")
+}
+
+// ListGroupAgencies はグループの所属事務所の履歴を取得する
+// @Summary      グループの所属事務所履歴
+// @Description  移籍を含む所属事務所の履歴を所属日の古い順に返す
+// @Tags         groups
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {array} affiliation.AffiliationDTO
+// @Router       /groups/{id}/agencies [get]
+func (h *AffiliationHandler) ListGroupAgencies(c *gin.Context) {
+	h.listTalentAgencies(c, "group")
+}
+
+func (h *AffiliationHandler) listTalentAgencies(c *gin.Context, talentType string) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	sel, ok := getFields(c, affiliationFields)
+	if !ok {
+		return
+	}
+
+	dtos, err := h.usecase.ListTalentAgencies(c.Request.Context(), affiliation.ListTalentAgenciesQuery{TalentType: talentType, TalentID: id})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "所属履歴"})
+		return
+	}
+
+	writeFieldsList(c, http.StatusOK, sel, dtos)
+}
+
+// ListAgencyTalents は事務所の所属タレントを取得する
+// @Summary      事務所の所属タレント一覧
+// @Description  at を指定するとその日に所属していたアイドル・グループを当時の名前で返す
+// @Tags         agencies
+// @Produce      json
+// @Param        id          path  string true  "事務所ID"
+// @Param        at          query string false "基準日 (YYYY-MM-DD。省略時は全期間)"
+// @Param        talent_type query string false "タレント種別" Enums(idol, group)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {array} affiliation.AffiliationDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Router       /agencies/{id}/talents [get]
+func (h *AffiliationHandler) ListAgencyTalents(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	sel, ok := getFields(c, affiliationFields)
+	if !ok {
+		return
+	}
+
+	var query affiliation.ListAgencyTalentsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.AgencyID = id
+
+	dtos, err := h.usecase.ListAgencyTalents(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "所属タレント"})
+		return
+	}
+
+	writeFieldsList(c, http.StatusOK, sel, dtos)
+}
+
+// UpdateAffiliation は所属履歴を更新する
+// @Summary      所属履歴更新
+// @Description  退所日の登録（left_at）や契約形態の変更に使う
+// @Tags         affiliations
+// @Accept       json
+// @Produce      json
+// @Param        id          path string true "所属履歴ID"
+// @Param        affiliation body UpdateAffiliationRequest true "所属履歴更新リクエスト"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      409 {object} middleware.ErrorResponse
+// @Router       /affiliations/{id} [put]
+func (h *AffiliationHandler) UpdateAffiliation(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateAffiliationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	cmd := affiliation.UpdateAffiliationCommand{
+		ID:           id,
+		ContractType: req.ContractType,
+		JoinedAt:     req.JoinedAt,
+		LeftAt:       req.LeftAt,
+	}
+
+	if err := h.usecase.UpdateAffiliation(middleware.AuditContextFor(c), cmd); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Resource: "所属履歴",
+			Message:  "所属履歴の更新に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "所属履歴が更新されました"})
+}
+
+// DeleteAffiliation は所属履歴を削除する
+// @Summary      所属履歴削除
+// @Tags         affiliations
+// @Param        id path string true "所属履歴ID"
+// @Success      204
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /affiliations/{id} [delete]
+func (h *AffiliationHandler) DeleteAffiliation(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	cmd := affiliation.DeleteAffiliationCommand{ID: id}
+	if err := h.usecase.DeleteAffiliation(middleware.AuditContextFor(c), cmd); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Resource: "所属履歴",
+			Message:  "所属履歴の削除に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -5,9 +5,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 	"github.com/kuro48/idol-api/internal/usecase/agency"
 )
 
+// agencyFields は fields パラメータで指定できる事務所のフィールド
+var agencyFields = fieldset.SchemaOf(agency.AgencyDTO{})
+
 // AgencyHandler は事務所ハンドラー
 type AgencyHandler struct {
 	usecase agency.AgencyUseCase
@@ -57,11 +61,17 @@ func (h *AgencyHandler) CreateAgency(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "事務所ID"
 // @Param        include query []string false "関連データ読み込み"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} agency.AgencyDTO
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      404 {object} middleware.ErrorResponse
 // @Router       /agencies/{id} [get]
 func (h *AgencyHandler) GetAgency(c *gin.Context) {
+	sel, ok := getFields(c, agencyFields)
+	if !ok {
+		return
+	}
+
 	var query agency.GetAgencyQuery
 	if err := c.ShouldBindUri(&query); err != nil {
 		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("IDは必須です"))
@@ -79,7 +89,7 @@ func (h *AgencyHandler) GetAgency(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto)
+	writeFields(c, http.StatusOK, sel, dto)
 }
 
 // ListAgencies は事務所一覧を取得する
@@ -89,15 +99,21 @@ func (h *AgencyHandler) GetAgency(c *gin.Context) {
 // @Produce      json
 // @Param        name query string false "名前（部分一致）"
 // @Param        country query string false "国コード"
-// @Param        sort query string false "ソート項目" Enums(name, founded_date, created_at) default(created_at)
+// @Param        sort query string false "ソート項目" Enums(name, name_kana, founded_date, created_at) default(created_at)
 // @Param        order query string false "ソート順" Enums(asc, desc) default(desc)
 // @Param        page query int false "ページ番号" default(1)
 // @Param        limit query int false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} agency.AgencySearchResult
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /agencies [get]
 func (h *AgencyHandler) ListAgencies(c *gin.Context) {
+	sel, ok := getFields(c, agencyFields)
+	if !ok {
+		return
+	}
+
 	var query agency.ListAgenciesQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
@@ -112,7 +128,7 @@ func (h *AgencyHandler) ListAgencies(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeFieldsList(c, http.StatusOK, sel, result)
 }
 
 // UpdateAgency は事務所を更新する
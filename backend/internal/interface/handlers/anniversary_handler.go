@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/usecase/anniversary"
+)
+
+// AnniversaryHandler は誕生日・結成記念日のハンドラー
+type AnniversaryHandler struct {
+	usecase anniversary.AnniversaryUseCase
+}
+
+// NewAnniversaryHandler は誕生日・結成記念日ハンドラーを作成する
+func NewAnniversaryHandler(usecase anniversary.AnniversaryUseCase) *AnniversaryHandler {
+	return &AnniversaryHandler{usecase: usecase}
+}
+
+// Birthdays は期間内に誕生日を迎えるアイドルを返す
+// @Summary      誕生日一覧
+// @Description  from〜to（MM-DD、両端を含む）に誕生日を迎えるアイドルを日付順に返す。to が from より前の月日なら年をまたぐ。閏日生まれは閏年以外の年で2月28日とする
+// @Tags         idols
+// @Produce      json
+// @Param        from query string false "開始の月日 (MM-DD、省略時は今日)"
+// @Param        to   query string false "終了の月日 (MM-DD、省略時は from から1週間)"
+// @Param        page query int false "ページ番号" default(1)
+// @Param        limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success      200 {object} anniversary.BirthdayList
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /idols/birthdays [get]
+func (h *AnniversaryHandler) Birthdays(c *gin.Context) {
+	var query anniversary.BirthdaysQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
+		return
+	}
+
+	result, err := h.usecase.Birthdays(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "誕生日一覧の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GroupAnniversaries は対象月に結成記念日を迎えるグループを返す
+// @Summary      結成記念日一覧
+// @Description  対象月に結成記念日を迎えるグループを日付順に返す。years を指定するとその周年のグループのみ返す
+// @Tags         groups
+// @Produce      json
+// @Param        month query string false "対象月 (YYYY-MM または MM、省略時は今月)"
+// @Param        years query int    false "周年（例: 10）"
+// @Param        page query int false "ページ番号" default(1)
+// @Param        limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success      200 {object} anniversary.GroupAnniversaryList
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /groups/anniversaries [get]
+func (h *AnniversaryHandler) GroupAnniversaries(c *gin.Context) {
+	var query anniversary.GroupAnniversariesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
+		return
+	}
+
+	result, err := h.usecase.GroupAnniversaries(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "結成記念日一覧の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AnniversariesICS はアイドルの誕生日とグループの結成記念日を iCalendar 形式で配信する
+// @Summary      誕生日・結成記念日のカレンダー配信
+// @Description  アイドルの誕生日とグループの結成記念日を終日予定として iCalendar (RFC 5545) 形式で配信する。誕生日・結成記念日のどちらかが期間内に1000件を超える場合は 400 を返す
+// @Tags         events
+// @Produce      text/calendar
+// @Param        from query string false "開始日 (YYYY-MM-DD、省略時は今日)"
+// @Param        days query int    false "日数（既定90、最大366）"
+// @Success      200 {string} string "iCalendar"
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /anniversaries.ics [get]
+func (h *AnniversaryHandler) AnniversariesICS(c *gin.Context) {
+	var query anniversary.CalendarFeedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
+		return
+	}
+
+	cal, err := h.usecase.CalendarFeed(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "カレンダーの取得に失敗しました"})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="anniversaries.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", cal.Encode())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	appAPIKey "github.com/kuro48/idol-api/internal/application/apikey"
 	domainapikey "github.com/kuro48/idol-api/internal/domain/apikey"
+	domainAuth "github.com/kuro48/idol-api/internal/domain/auth"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
 )
 
@@ -26,13 +27,21 @@ type createAPIKeyRequest struct {
 }
 
 type apiKeyResponse struct {
-	ID        string `json:"id"`
-	MaskedKey string `json:"masked_key"`
-	Email     string `json:"email"`
-	Name      string `json:"name"`
-	PlanType  string `json:"plan_type"`
-	IsActive  bool   `json:"is_active"`
-	CreatedAt string `json:"created_at"`
+	ID             string `json:"id"`
+	MaskedKey      string `json:"masked_key"`
+	Email          string `json:"email"`
+	Name           string `json:"name"`
+	PlanType       string `json:"plan_type"`
+	IsActive       bool   `json:"is_active"`
+	OverageEnabled bool   `json:"overage_enabled"`
+	OverageHardCap int    `json:"overage_hard_cap,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// setOverageRequest は従量課金での超過利用設定リクエスト
+type setOverageRequest struct {
+	Enabled *bool `json:"enabled"  binding:"required"`
+	HardCap int   `json:"hard_cap" binding:"min=0"`
 }
 
 // createAPIKeyResponse はAPIキー作成レスポンス（生キーを一度だけ含む）
@@ -120,14 +129,56 @@ func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// SetMyAPIKeyOverage は自分のAPIキーの従量課金での超過利用を設定する
+// @Summary     APIキーの超過利用設定
+// @Description Business プランのキーで月間上限を超えたリクエストを従量課金で受け付けるかを設定する。hard_cap は超過分を含む月間リクエスト総数の上限（0はシステム既定値）
+// @Tags        me
+// @Accept      json
+// @Produce     json
+// @Param       id      path string            true "APIキーID"
+// @Param       request body setOverageRequest true "超過利用設定"
+// @Success     200 {object} apiKeyResponse
+// @Failure     400 {object} middleware.ErrorResponse
+// @Failure     401 {object} middleware.ErrorResponse
+// @Failure     404 {object} middleware.ErrorResponse
+// @Router      /me/apikeys/{id}/overage [put]
+func (h *APIKeyHandler) SetMyAPIKeyOverage(c *gin.Context) {
+	principal, ok := domainAuth.PrincipalFromContext(c.Request.Context())
+	if !ok || principal.Email == "" {
+		c.JSON(http.StatusUnauthorized, middleware.NewUnauthorizedError())
+		return
+	}
+
+	var req setOverageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "リクエストが不正です"})
+		return
+	}
+
+	key, err := h.service.SetOverage(c.Request.Context(), appAPIKey.SetOverageInput{
+		Email:   principal.Email,
+		KeyID:   c.Param("id"),
+		Enabled: *req.Enabled,
+		HardCap: req.HardCap,
+	})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "APIキー", Message: "超過利用の設定に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toAPIKeyResponse(key))
+}
+
 func toAPIKeyResponse(k *domainapikey.APIKey) apiKeyResponse {
 	return apiKeyResponse{
-		ID:        k.ID(),
-		MaskedKey: k.MaskedKey(),
-		Email:     k.Email(),
-		Name:      k.Name(),
-		PlanType:  string(k.PlanType()),
-		IsActive:  k.IsActive(),
-		CreatedAt: k.CreatedAt().UTC().Format("2006-01-02T15:04:05Z"),
+		ID:             k.ID(),
+		MaskedKey:      k.MaskedKey(),
+		Email:          k.Email(),
+		Name:           k.Name(),
+		PlanType:       string(k.PlanType()),
+		IsActive:       k.IsActive(),
+		OverageEnabled: k.Overage().Enabled,
+		OverageHardCap: k.Overage().HardCap,
+		CreatedAt:      k.CreatedAt().UTC().Format("2006-01-02T15:04:05Z"),
 	}
 }
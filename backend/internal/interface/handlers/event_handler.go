@@ -1,13 +1,19 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 	"github.com/kuro48/idol-api/internal/usecase/event"
 )
 
+// eventFields は fields パラメータで指定できるイベントのフィールド
+var eventFields = fieldset.SchemaOf(event.EventDTO{})
+
 // EventHandler はイベントハンドラー
 type EventHandler struct {
 	usecase event.EventUseCase
@@ -19,6 +25,11 @@ type AddPerformerRequest struct {
 	BillingStatus string `json:"billing_status,omitempty"`
 }
 
+// UpdateSetlistRequest はセットリスト更新リクエスト。entries は演奏順に並べる（空配列でセットリストを消去）
+type UpdateSetlistRequest struct {
+	Entries []event.SetlistEntryInput `json:"entries" binding:"dive"`
+}
+
 // NewEventHandler はイベントハンドラーを作成する
 func NewEventHandler(usecase event.EventUseCase) *EventHandler {
 	return &EventHandler{
@@ -28,21 +39,29 @@ func NewEventHandler(usecase event.EventUseCase) *EventHandler {
 
 // CreateEvent はイベントを作成する
 // @Summary      イベント作成
-// @Description  新しいイベント/ライブを作成する
+// @Description  新しいイベント/ライブを作成する。出演者・会場の日程が重複するイベントは conflicts に警告として返し、strict=true の場合は作成しない
 // @Tags         events
 // @Accept       json
 // @Produce      json
 // @Param        event body event.CreateEventCommand true "イベント作成リクエスト"
+// @Param        strict query bool false "日程が重複する場合は作成しない"
 // @Success      201 {object} event.EventDTO
 // @Failure      400 {object} middleware.ErrorResponse
+// @Failure      409 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /events [post]
 func (h *EventHandler) CreateEvent(c *gin.Context) {
+	strict, ok := getStrict(c)
+	if !ok {
+		return
+	}
+
 	var cmd event.CreateEventCommand
 	if err := c.ShouldBindJSON(&cmd); err != nil {
 		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
 		return
 	}
+	cmd.Strict = strict
 
 	dto, err := h.usecase.CreateEvent(middleware.AuditContextFor(c), cmd)
 	if err != nil {
@@ -63,6 +82,8 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "イベントID"
+// @Param        include query string false "関連データ読み込み (カンマ区切り: venue,performers)"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} event.EventDTO
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      404 {object} middleware.ErrorResponse
@@ -72,8 +93,18 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, eventFields)
+	if !ok {
+		return
+	}
 
-	query := event.GetEventQuery{ID: id}
+	var query event.GetEventQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.ID = id
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
 
 	dto, err := h.usecase.GetEvent(c.Request.Context(), query)
 	if err != nil {
@@ -81,7 +112,7 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto)
+	writeFields(c, http.StatusOK, sel, dto)
 }
 
 // ListEvents はイベント一覧を取得する（検索機能付き）
@@ -96,15 +127,30 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 // @Param        venue_id query string false "会場ID"
 // @Param        performer_id query string false "パフォーマーID"
 // @Param        tags query []string false "タグ（複数可）"
+// @Param        release_id query string false "演奏された収録曲のリリースID（track_number と併用）"
+// @Param        track_number query int false "演奏された収録曲のトラック番号（release_id と併用）"
+// @Param        near query string false "中心座標（緯度,経度）。半径内の会場で開催されるイベントに絞り込み distance_km を返す"
+// @Param        radius_km query number false "near からの検索半径（km、既定10、上限100。半径内の会場が1000件を超える場合は400）"
+// @Param        from query string false "start_date_from の別名 (YYYY-MM-DD)"
+// @Param        to query string false "start_date_to の別名 (YYYY-MM-DD)"
+// @Param        online query bool false "true: 配信のあるイベント（オンライン・ハイブリッド）、false: 会場のみのイベント"
+// @Param        archive_available query bool false "true: 現在アーカイブを視聴できるイベント"
+// @Param        include query string false "関連データ読み込み (カンマ区切り: venue,performers)"
 // @Param        sort query string false "ソート項目" Enums(start_date_time, created_at) default(start_date_time)
 // @Param        order query string false "ソート順" Enums(asc, desc) default(asc)
 // @Param        page query int false "ページ番号" default(1)
 // @Param        limit query int false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} event.SearchResult
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /events [get]
 func (h *EventHandler) ListEvents(c *gin.Context) {
+	sel, ok := getFields(c, eventFields)
+	if !ok {
+		return
+	}
+
 	var query event.ListEventsQuery
 
 	// クエリパラメータをバインド
@@ -115,6 +161,8 @@ func (h *EventHandler) ListEvents(c *gin.Context) {
 
 	// デフォルト値を適用
 	query.ApplyDefaults()
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
+	query.Fields = sel.Requested()
 
 	// バリデーション
 	if err := query.Validate(); err != nil {
@@ -131,20 +179,22 @@ func (h *EventHandler) ListEvents(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeFieldsList(c, http.StatusOK, sel, result)
 }
 
 // UpdateEvent はイベントを更新する
 // @Summary      イベント更新
-// @Description  既存のイベントを更新する
+// @Description  既存のイベントを更新する。出演者・会場の日程が重複するイベントは conflicts に警告として返し、strict=true の場合は更新しない
 // @Tags         events
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "イベントID"
 // @Param        event body event.UpdateEventCommand true "イベント更新リクエスト"
-// @Success      200 {object} map[string]string
+// @Param        strict query bool false "日程が重複する場合は更新しない"
+// @Success      200 {object} map[string]interface{}
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      404 {object} middleware.ErrorResponse
+// @Failure      409 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /events/{id} [put]
 func (h *EventHandler) UpdateEvent(c *gin.Context) {
@@ -152,6 +202,10 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	if !ok {
 		return
 	}
+	strict, ok := getStrict(c)
+	if !ok {
+		return
+	}
 
 	var cmd event.UpdateEventCommand
 	if err := c.ShouldBindJSON(&cmd); err != nil {
@@ -160,8 +214,9 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	}
 
 	cmd.ID = id
+	cmd.Strict = strict
 
-	err := h.usecase.UpdateEvent(middleware.AuditContextFor(c), cmd)
+	conflicts, err := h.usecase.UpdateEvent(middleware.AuditContextFor(c), cmd)
 	if err != nil {
 		middleware.WriteError(c, err, middleware.ErrorContext{
 			Resource: "イベント",
@@ -170,7 +225,54 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "イベントが更新されました"})
+	res := gin.H{"message": "イベントが更新されました"}
+	if len(conflicts) > 0 {
+		res["conflicts"] = conflicts
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// ListConflicts は期間内のイベント同士の日程重複を取得する
+// @Summary      日程重複レポート
+// @Description  出演者が別会場のイベントと時間帯が重なっているもの、同じ会場で時間帯が重なっているものを一覧する（終了日時が未登録のイベントは3時間とみなす）
+// @Tags         admin
+// @Produce      json
+// @Param        from query string false "対象期間の開始日 (YYYY-MM-DD、省略時は今日)"
+// @Param        to query string false "対象期間の終了日 (YYYY-MM-DD、省略時は開始日から90日間)"
+// @Success      200 {object} event.ConflictReport
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /admin/events/conflicts [get]
+func (h *EventHandler) ListConflicts(c *gin.Context) {
+	var query event.ConflictReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
+		return
+	}
+
+	report, err := h.usecase.ConflictReport(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Message: "日程重複の取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// getStrict は strict クエリパラメータを取得する。不正な値の場合は 400 を返して false を返す
+func getStrict(c *gin.Context) (bool, bool) {
+	raw := c.Query("strict")
+	if raw == "" {
+		return false, true
+	}
+	strict, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: strict は true または false で指定してください"))
+		return false, false
+	}
+	return strict, true
 }
 
 // DeleteEvent はイベントを削除する
@@ -284,15 +386,253 @@ func (h *EventHandler) RemovePerformer(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "パフォーマーが削除されました"})
 }
 
+// UpdateSetlist はイベントのセットリストを置き換える
+// @Summary      セットリスト更新
+// @Description  ライブイベントのセットリストを演奏順の一覧で置き換える。収録曲は release_id と track_number で参照し、カバー曲などは title のみで登録する
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "イベントID"
+// @Param        request body UpdateSetlistRequest true "セットリスト更新リクエスト"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /events/{id}/setlist [put]
+func (h *EventHandler) UpdateSetlist(c *gin.Context) {
+	eventID, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateSetlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	cmd := event.UpdateSetlistCommand{
+		EventID: eventID,
+		Entries: req.Entries,
+	}
+
+	if err := h.usecase.UpdateSetlist(middleware.AuditContextFor(c), cmd); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Resource: "イベント",
+			Message:  "セットリストの更新に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "セットリストが更新されました"})
+}
+
+// RecordAttendance はイベントの完売・動員数を記録する
+// @Summary      動員実績の記録
+// @Description  完売したか、公表された動員数を記録する。中止・延期したイベントには記録できない
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "イベントID"
+// @Param        request body event.RecordAttendanceCommand true "動員実績"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /events/{id}/attendance [put]
+func (h *EventHandler) RecordAttendance(c *gin.Context) {
+	eventID, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var cmd event.RecordAttendanceCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+	cmd.EventID = eventID
+
+	if err := h.usecase.RecordAttendance(middleware.AuditContextFor(c), cmd); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Resource: "イベント",
+			Message:  "動員実績の記録に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "動員実績が記録されました"})
+}
+
+// UpdateStreaming はイベントの配信情報を置き換える
+// @Summary      配信情報の更新
+// @Description  配信プラットフォーム・配信URL・アーカイブの視聴期限・価格区分を置き換える。会場の指定があるイベントはハイブリッド開催になる
+// @Tags         events
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "イベントID"
+// @Param        request body event.StreamingInput true "配信情報"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /events/{id}/streaming [put]
+func (h *EventHandler) UpdateStreaming(c *gin.Context) {
+	eventID, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var req event.StreamingInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	cmd := event.UpdateStreamingCommand{EventID: eventID, Streaming: &req}
+	if err := h.usecase.UpdateStreaming(middleware.AuditContextFor(c), cmd); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Resource: "イベント",
+			Message:  "配信情報の更新に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "配信情報が更新されました"})
+}
+
+// DeleteStreaming はイベントの配信情報を削除する
+// @Summary      配信情報の削除
+// @Description  配信を取りやめたイベントの配信情報を削除する
+// @Tags         events
+// @Produce      json
+// @Param        id path string true "イベントID"
+// @Success      200 {object} map[string]string
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /events/{id}/streaming [delete]
+func (h *EventHandler) DeleteStreaming(c *gin.Context) {
+	eventID, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	cmd := event.UpdateStreamingCommand{EventID: eventID}
+	if err := h.usecase.UpdateStreaming(middleware.AuditContextFor(c), cmd); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Resource: "イベント",
+			Message:  "配信情報の削除に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "配信情報が削除されました"})
+}
+
+// CapacityReport はツアー・出演者ごとの収容人数と動員を月ごとに集計する
+// @Summary      収容人数レポート
+// @Description  ツアーまたは出演者のイベントについて、公演で使った収容構成（未指定なら会場の収容人数）の合計と完売・動員数を月ごとに集計する。中止・延期したイベントは含めない
+// @Tags         admin
+// @Produce      json
+// @Param        tour_id query string false "ツアーID（performer_id とどちらか一方）"
+// @Param        performer_id query string false "出演者ID（tour_id とどちらか一方）"
+// @Param        from query string false "対象期間の開始月 (YYYY-MM、省略時は to の11か月前)"
+// @Param        to query string false "対象期間の終了月 (YYYY-MM、省略時は今月)"
+// @Success      200 {object} event.CapacityReport
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /admin/analytics/capacity [get]
+func (h *EventHandler) CapacityReport(c *gin.Context) {
+	var query event.CapacityReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
+		return
+	}
+
+	report, err := h.usecase.CapacityReport(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Message: "収容人数レポートの取得に失敗しました",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ListTrackPerformances は収録曲が演奏されたイベントを取得する
+// @Summary      収録曲の演奏イベント一覧
+// @Description  セットリストに収録曲が含まれるイベントを取得する。meta.total がライブでの演奏回数になる
+// @Tags         releases
+// @Produce      json
+// @Param        id path string true "リリースID"
+// @Param        track_number path int true "トラック番号"
+// @Param        sort query string false "ソート項目" Enums(start_date_time, created_at) default(start_date_time)
+// @Param        order query string false "ソート順" Enums(asc, desc) default(asc)
+// @Param        page query int false "ページ番号" default(1)
+// @Param        limit query int false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {object} event.SearchResult
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /releases/{id}/tracks/{track_number}/events [get]
+func (h *EventHandler) ListTrackPerformances(c *gin.Context) {
+	releaseID, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	trackNumber, err := strconv.Atoi(c.Param("track_number"))
+	if err != nil || trackNumber < 1 {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なトラック番号です"))
+		return
+	}
+
+	sel, ok := getFields(c, eventFields)
+	if !ok {
+		return
+	}
+
+	var query event.ListEventsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
+		return
+	}
+	query.ReleaseID = &releaseID
+	query.TrackNumber = &trackNumber
+	query.ApplyDefaults()
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
+
+	if err := query.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError(err.Error()))
+		return
+	}
+
+	result, err := h.usecase.SearchEvents(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Message: "検索に失敗しました",
+		})
+		return
+	}
+
+	writeFieldsList(c, http.StatusOK, sel, result)
+}
+
 // GetUpcomingEvents は今後開催されるイベントを取得する
 // @Summary      今後のイベント取得
 // @Description  今後開催されるイベントを取得する
 // @Tags         events
 // @Produce      json
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {array} event.EventDTO
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /events/upcoming [get]
 func (h *EventHandler) GetUpcomingEvents(c *gin.Context) {
+	sel, ok := getFields(c, eventFields)
+	if !ok {
+		return
+	}
+
 	limit := 20 // デフォルト値
 
 	dtos, err := h.usecase.FindUpcoming(c.Request.Context(), limit)
@@ -303,5 +643,111 @@ func (h *EventHandler) GetUpcomingEvents(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dtos)
+	writeFieldsList(c, http.StatusOK, sel, dtos)
+}
+
+// EventsICS は条件に合うイベントを iCalendar 形式で配信する
+// @Summary      イベントカレンダー配信
+// @Description  条件に合うイベントを iCalendar (RFC 5545) 形式で配信する。開始日の指定がない場合は90日前以降、最大500件
+// @Tags         events
+// @Produce      text/calendar
+// @Param        event_type query string false "イベントタイプ" Enums(live, handshake, release, fan_meeting, online)
+// @Param        start_date_from query string false "開始日FROM (YYYY-MM-DD)"
+// @Param        start_date_to query string false "開始日TO (YYYY-MM-DD)"
+// @Param        venue_id query string false "会場ID"
+// @Param        performer_id query string false "パフォーマーID"
+// @Param        tags query []string false "タグ（複数可）"
+// @Success      200 {string} string "iCalendar"
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /events.ics [get]
+func (h *EventHandler) EventsICS(c *gin.Context) {
+	var query event.CalendarFeedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
+		return
+	}
+	h.writeCalendar(c, query, "events")
+}
+
+// IdolEventsICS はアイドルの出演イベントを iCalendar 形式で配信する
+// @Summary      アイドルのイベントカレンダー配信
+// @Description  アイドルの出演イベントを iCalendar (RFC 5545) 形式で配信する
+// @Tags         events
+// @Produce      text/calendar
+// @Param        id path string true "アイドルID"
+// @Param        start_date_from query string false "開始日FROM (YYYY-MM-DD)"
+// @Param        start_date_to query string false "開始日TO (YYYY-MM-DD)"
+// @Success      200 {string} string "iCalendar"
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /idols/{id}/events.ics [get]
+func (h *EventHandler) IdolEventsICS(c *gin.Context) {
+	h.writeScopedCalendar(c, "idol", func(q *event.CalendarFeedQuery, id string) { q.PerformerID = &id })
+}
+
+// GroupEventsICS はグループの出演イベントを iCalendar 形式で配信する
+// @Summary      グループのイベントカレンダー配信
+// @Description  グループの出演イベントを iCalendar (RFC 5545) 形式で配信する
+// @Tags         events
+// @Produce      text/calendar
+// @Param        id path string true "グループID"
+// @Param        start_date_from query string false "開始日FROM (YYYY-MM-DD)"
+// @Param        start_date_to query string false "開始日TO (YYYY-MM-DD)"
+// @Success      200 {string} string "iCalendar"
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /groups/{id}/events.ics [get]
+func (h *EventHandler) GroupEventsICS(c *gin.Context) {
+	h.writeScopedCalendar(c, "group", func(q *event.CalendarFeedQuery, id string) { q.PerformerID = &id })
+}
+
+// VenueEventsICS は会場で開催されるイベントを iCalendar 形式で配信する
+// @Summary      会場のイベントカレンダー配信
+// @Description  会場で開催されるイベントを iCalendar (RFC 5545) 形式で配信する
+// @Tags         events
+// @Produce      text/calendar
+// @Param        id path string true "会場ID"
+// @Param        start_date_from query string false "開始日FROM (YYYY-MM-DD)"
+// @Param        start_date_to query string false "開始日TO (YYYY-MM-DD)"
+// @Success      200 {string} string "iCalendar"
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /venues/{id}/events.ics [get]
+func (h *EventHandler) VenueEventsICS(c *gin.Context) {
+	h.writeScopedCalendar(c, "venue", func(q *event.CalendarFeedQuery, id string) { q.VenueID = &id })
+}
+
+// writeScopedCalendar はパスの ID で絞り込んだカレンダーを配信する。
+// パスで指定した対象を優先し、クエリの出演者・会場の指定は無視する
+func (h *EventHandler) writeScopedCalendar(c *gin.Context, scope string, apply func(*event.CalendarFeedQuery, string)) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	var query event.CalendarFeedQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
+		return
+	}
+	query.PerformerID, query.VenueID = nil, nil
+	apply(&query, id)
+	h.writeCalendar(c, query, scope+"_"+id)
+}
+
+func (h *EventHandler) writeCalendar(c *gin.Context, query event.CalendarFeedQuery, filename string) {
+	cal, err := h.usecase.CalendarFeed(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{
+			Message: "カレンダーの取得に失敗しました",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, filename))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", cal.Encode())
 }
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/usecase/graph"
+)
+
+// GraphHandler は関係グラフのハンドラー
+type GraphHandler struct {
+	usecase graph.GraphUseCase
+}
+
+// NewGraphHandler は関係グラフハンドラーを作成する
+func NewGraphHandler(usecase graph.GraphUseCase) *GraphHandler {
+	return &GraphHandler{usecase: usecase}
+}
+
+// IdolGraph はアイドルを起点とした関係グラフを返す
+// @Summary      アイドルの関係グラフ取得
+// @Description  メンバーシップ・所属事務所・リリース（アーティスト/楽曲参加）・イベント出演を辿り、指定ホップ以内のノードとエッジを返す
+// @Tags         graph
+// @Produce      json
+// @Param        id    path  string true  "アイドルID"
+// @Param        depth query int    false "探索ホップ数（1〜3）" default(2)
+// @Success      200 {object} graph.GraphDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /idols/{id}/graph [get]
+func (h *GraphHandler) IdolGraph(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	var query graph.IdolGraphQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+
+	result, err := h.usecase.IdolGraph(c.Request.Context(), id, query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "アイドル", Message: "関係グラフの取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CoMembers は同じグループに在籍したことのあるアイドルを返す
+// @Summary      共演メンバー取得
+// @Description  同じグループに在籍したことのあるアイドルを、同時在籍の有無とともに返す
+// @Tags         graph
+// @Produce      json
+// @Param        id path string true "アイドルID"
+// @Success      200 {object} graph.CoMembersDTO
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /idols/{id}/co-members [get]
+func (h *GraphHandler) CoMembers(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.usecase.CoMembers(c.Request.Context(), id)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "アイドル", Message: "共演メンバーの取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CoStars は同じイベントに出演した共演者を返す
+// @Summary      共演者取得
+// @Description  本人または在籍中の所属グループとして出演したイベントで共演したアイドル・グループを、共演回数の多い順に返す
+// @Tags         graph
+// @Produce      json
+// @Param        id    path  string true  "アイドルID"
+// @Param        limit query int    false "返却件数（最大100）" default(20)
+// @Success      200 {object} graph.CoStarsDTO
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /idols/{id}/co-stars [get]
+func (h *GraphHandler) CoStars(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	var query graph.CoStarsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+
+	result, err := h.usecase.CoStars(c.Request.Context(), id, query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "アイドル", Message: "共演者の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ShortestPath は2アイドル間の最短経路を返す
+// @Summary      アイドル間の最短経路取得
+// @Description  関係グラフ上で2アイドルを結ぶ最短経路を返す。max_depth 以内に見つからない場合は found=false を返す
+// @Tags         graph
+// @Produce      json
+// @Param        id        path  string true  "起点のアイドルID"
+// @Param        to        query string true  "終点のアイドルID"
+// @Param        max_depth query int    false "最大ホップ数（1〜6）" default(4)
+// @Success      200 {object} graph.PathDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /idols/{id}/path [get]
+func (h *GraphHandler) ShortestPath(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	var query graph.PathQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+
+	result, err := h.usecase.ShortestPath(c.Request.Context(), id, query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "アイドル", Message: "経路の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -5,9 +5,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 	"github.com/kuro48/idol-api/internal/usecase/group"
 )
 
+// groupFields は fields パラメータで指定できるグループのフィールド
+var groupFields = fieldset.SchemaOf(group.GroupDTO{})
+
 type GroupHandler struct {
 	usecase group.GroupUseCase
 }
@@ -20,14 +24,22 @@ func NewGroupHandler(usecase group.GroupUseCase) *GroupHandler {
 
 type CreateGroupRequest struct {
 	Name          string  `json:"name" binding:"required,min=1,max=100"`
+	NameKana      *string `json:"name_kana" binding:"omitempty,max=200"`
 	FormationDate *string `json:"formation_date" binding:"omitempty,datetime=2006-01-02"`
 	DisbandDate   *string `json:"disband_date" binding:"omitempty,datetime=2006-01-02"`
+	ParentGroupID *string `json:"parent_group_id"`
+	RelationType  *string `json:"relation_type" binding:"omitempty,oneof=sub_unit sister successor"`
 }
 
 type UpdateGroupRequest struct {
-	Name          *string `json:"name" binding:"omitempty,min=1,max=100"`
-	FormationDate *string `json:"formation_date" binding:"omitempty,datetime=2006-01-02"`
-	DisbandDate   *string `json:"disband_date" binding:"omitempty,datetime=2006-01-02"`
+	Name          *string              `json:"name" binding:"omitempty,min=1,max=100"`
+	NameKana      *string              `json:"name_kana" binding:"omitempty,max=200"`
+	FormationDate *string              `json:"formation_date" binding:"omitempty,datetime=2006-01-02"`
+	DisbandDate   *string              `json:"disband_date" binding:"omitempty,datetime=2006-01-02"`
+	ParentGroupID *string              `json:"parent_group_id"` // 空文字で親グループの指定を解除
+	RelationType  *string              `json:"relation_type" binding:"omitempty,oneof=sub_unit sister successor"`
+	RenamedAt     *string              `json:"renamed_at" binding:"omitempty,datetime=2006-01-02"` // name と併せて指定すると旧グループ名を履歴に残す
+	NameHistory   []NameHistoryRequest `json:"name_history" binding:"omitempty,dive"`              // 改名履歴の置き換え（空配列で削除）
 }
 
 // CreateGroup はグループを作成する
@@ -50,8 +62,11 @@ func (h *GroupHandler) CreateGroup(c *gin.Context) {
 
 	cmd := group.CreateGroupCommand{
 		Name:          req.Name,
+		NameKana:      req.NameKana,
 		FormationDate: req.FormationDate,
 		DisbandDate:   req.DisbandDate,
+		ParentGroupID: req.ParentGroupID,
+		RelationType:  req.RelationType,
 	}
 
 	dto, err := h.usecase.CreateGroup(middleware.AuditContextFor(c), cmd)
@@ -72,6 +87,8 @@ func (h *GroupHandler) CreateGroup(c *gin.Context) {
 // @Tags         groups
 // @Produce      json
 // @Param        id path string true "グループID"
+// @Param        include query string false "関連データ読み込み (カンマ区切り: members,agency,releases。同時指定数・展開件数はプランで制限)"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} group.GroupDTO
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      404 {object} middleware.ErrorResponse
@@ -81,8 +98,18 @@ func (h *GroupHandler) GetGroup(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, groupFields)
+	if !ok {
+		return
+	}
 
-	query := group.GetGroupQuery{ID: id}
+	var query group.GetGroupQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.ID = id
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
 
 	dto, err := h.usecase.GetGroup(c.Request.Context(), query)
 	if err != nil {
@@ -90,7 +117,7 @@ func (h *GroupHandler) GetGroup(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto)
+	writeFields(c, http.StatusOK, sel, dto)
 }
 
 // ListGroup はグループ一覧を取得する
@@ -99,20 +126,29 @@ func (h *GroupHandler) GetGroup(c *gin.Context) {
 // @Tags         groups
 // @Produce      json
 // @Param        name query string false "名前（部分一致）"
-// @Param        sort query string false "ソート項目" Enums(name, formation_date, created_at) default(created_at)
+// @Param        include query string false "関連データ読み込み (カンマ区切り: members,agency,releases。同時指定数・展開件数はプランで制限)"
+// @Param        sort query string false "ソート項目" Enums(name, name_kana, formation_date, created_at) default(created_at)
 // @Param        order query string false "ソート順" Enums(asc, desc) default(desc)
 // @Param        page query int false "ページ番号" default(1)
 // @Param        limit query int false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} group.GroupSearchResult
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /groups [get]
 func (h *GroupHandler) ListGroup(c *gin.Context) {
+	sel, ok := getFields(c, groupFields)
+	if !ok {
+		return
+	}
+
 	var query group.ListGroupQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
 		return
 	}
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
+	query.Fields = sel.Requested()
 
 	result, err := h.usecase.ListGroup(c.Request.Context(), query)
 	if err != nil {
@@ -122,7 +158,102 @@ func (h *GroupHandler) ListGroup(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeFieldsList(c, http.StatusOK, sel, result)
+}
+
+// ListUnits はグループを親に持つ子グループの一覧を取得する
+// @Summary      子グループ一覧取得
+// @Description  サブユニット・姉妹グループ・後継グループなど、指定したグループを親に持つグループを結成日順に取得する
+// @Tags         groups
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Param        relation query string false "関係種別" Enums(sub_unit, sister, successor)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {array} group.GroupDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /groups/{id}/units [get]
+func (h *GroupHandler) ListUnits(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	sel, ok := getFields(c, groupFields)
+	if !ok {
+		return
+	}
+
+	var query group.ListUnitsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.ID = id
+
+	dtos, err := h.usecase.ListUnits(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "グループ"})
+		return
+	}
+
+	writeFieldsList(c, http.StatusOK, sel, dtos)
+}
+
+// GetParent はグループの親グループを取得する
+// @Summary      親グループ取得
+// @Description  サブユニット等の親グループを関係種別とともに取得する。親グループがない場合は 404
+// @Tags         groups
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Success      200 {object} group.ParentGroupDTO
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /groups/{id}/parent [get]
+func (h *GroupHandler) GetParent(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	dto, err := h.usecase.GetParent(c.Request.Context(), id)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "親グループ"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
+// GetNameAt は指定日時点のグループ名を取得する
+// @Summary      指定日時点のグループ名取得
+// @Description  改名履歴から指定日に使われていたグループ名を取得する。リリースやイベント当時の表記の表示に使う
+// @Tags         groups
+// @Produce      json
+// @Param        id path string true "グループID"
+// @Param        at query string false "基準日 (YYYY-MM-DD。省略時は現在)"
+// @Success      200 {object} group.NameAtDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /groups/{id}/name [get]
+func (h *GroupHandler) GetNameAt(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var query group.NameAtQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.ID = id
+
+	dto, err := h.usecase.GetNameAt(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "グループ"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
 }
 
 // UpdateGroup はグループを更新する
@@ -153,8 +284,13 @@ func (h *GroupHandler) UpdateGroup(c *gin.Context) {
 	cmd := group.UpdateGroupCommand{
 		ID:            id,
 		Name:          req.Name,
+		NameKana:      req.NameKana,
 		FormationDate: req.FormationDate,
 		DisbandDate:   req.DisbandDate,
+		ParentGroupID: req.ParentGroupID,
+		RelationType:  req.RelationType,
+		RenamedAt:     req.RenamedAt,
+		NameHistory:   toGroupNameHistoryEntries(req.NameHistory),
 	}
 
 	err := h.usecase.UpdateGroup(middleware.AuditContextFor(c), cmd)
@@ -198,3 +334,14 @@ func (h *GroupHandler) DeleteGroup(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+func toGroupNameHistoryEntries(reqs []NameHistoryRequest) []group.NameHistoryEntry {
+	if reqs == nil {
+		return nil
+	}
+	entries := make([]group.NameHistoryEntry, 0, len(reqs))
+	for _, r := range reqs {
+		entries = append(entries, group.NameHistoryEntry{Name: r.Name, From: r.From, Until: r.Until})
+	}
+	return entries
+}
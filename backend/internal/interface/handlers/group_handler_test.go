@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kuro48/idol-api/internal/domain/plan"
 	"github.com/kuro48/idol-api/internal/interface/handlers"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
 	"github.com/kuro48/idol-api/internal/usecase/group"
@@ -55,6 +56,30 @@ func (m *MockGroupUseCase) DeleteGroup(ctx context.Context, cmd group.DeleteGrou
 	return args.Error(0)
 }
 
+func (m *MockGroupUseCase) ListUnits(ctx context.Context, query group.ListUnitsQuery) ([]*group.GroupDTO, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*group.GroupDTO), args.Error(1)
+}
+
+func (m *MockGroupUseCase) GetParent(ctx context.Context, id string) (*group.ParentGroupDTO, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*group.ParentGroupDTO), args.Error(1)
+}
+
+func (m *MockGroupUseCase) GetNameAt(ctx context.Context, query group.NameAtQuery) (*group.NameAtDTO, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*group.NameAtDTO), args.Error(1)
+}
+
 func setupGroupRouter(usecase group.GroupUseCase) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -63,6 +88,9 @@ func setupGroupRouter(usecase group.GroupUseCase) *gin.Engine {
 	router.POST("/groups", h.CreateGroup)
 	router.GET("/groups/:id", h.GetGroup)
 	router.GET("/groups", h.ListGroup)
+	router.GET("/groups/:id/units", h.ListUnits)
+	router.GET("/groups/:id/parent", h.GetParent)
+	router.GET("/groups/:id/name", h.GetNameAt)
 	router.PUT("/groups/:id", h.UpdateGroup)
 	router.DELETE("/groups/:id", h.DeleteGroup)
 	return router
@@ -130,7 +158,7 @@ func TestCreateGroup_UsecaseError(t *testing.T) {
 func TestGetGroup_Found(t *testing.T) {
 	mockUC := new(MockGroupUseCase)
 	dto := &group.GroupDTO{ID: "abc123", Name: "テストグループ"}
-	mockUC.On("GetGroup", mock.Anything, group.GetGroupQuery{ID: "abc123"}).Return(dto, nil)
+	mockUC.On("GetGroup", mock.Anything, group.GetGroupQuery{ID: "abc123", IncludeLimits: plan.GetLimits(plan.TypeFree).Include}).Return(dto, nil)
 
 	router := setupGroupRouter(mockUC)
 	req := httptest.NewRequest(http.MethodGet, "/groups/abc123", nil)
@@ -143,7 +171,7 @@ func TestGetGroup_Found(t *testing.T) {
 
 func TestGetGroup_NotFound(t *testing.T) {
 	mockUC := new(MockGroupUseCase)
-	mockUC.On("GetGroup", mock.Anything, group.GetGroupQuery{ID: "nonexistent"}).Return(nil, errors.New("グループが見つかりません"))
+	mockUC.On("GetGroup", mock.Anything, group.GetGroupQuery{ID: "nonexistent", IncludeLimits: plan.GetLimits(plan.TypeFree).Include}).Return(nil, errors.New("グループが見つかりません"))
 
 	router := setupGroupRouter(mockUC)
 	req := httptest.NewRequest(http.MethodGet, "/groups/nonexistent", nil)
@@ -254,3 +282,78 @@ func TestDeleteGroup_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 	mockUC.AssertExpectations(t)
 }
+
+func TestListUnits_PassesRelationFilter(t *testing.T) {
+	mockUC := new(MockGroupUseCase)
+	relation := "sub_unit"
+	units := []*group.GroupDTO{{ID: "unit1", Name: "ユニット", Parent: &group.ParentRefDTO{GroupID: "abc123", RelationType: "sub_unit"}}}
+	mockUC.On("ListUnits", mock.Anything, group.ListUnitsQuery{ID: "abc123", Relation: &relation}).Return(units, nil)
+
+	router := setupGroupRouter(mockUC)
+	req := httptest.NewRequest(http.MethodGet, "/groups/abc123/units?relation=sub_unit", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"parent":{"group_id":"abc123","relation_type":"sub_unit"}`)
+	mockUC.AssertExpectations(t)
+}
+
+func TestGetParent_NoParent(t *testing.T) {
+	mockUC := new(MockGroupUseCase)
+	mockUC.On("GetParent", mock.Anything, "abc123").Return(nil, errors.New("親グループが見つかりません"))
+
+	router := setupGroupRouter(mockUC)
+	req := httptest.NewRequest(http.MethodGet, "/groups/abc123/parent", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUC.AssertExpectations(t)
+}
+
+func TestUpdateGroup_Rename(t *testing.T) {
+	mockUC := new(MockGroupUseCase)
+	mockUC.On("UpdateGroup", mock.Anything, mock.MatchedBy(func(cmd group.UpdateGroupCommand) bool {
+		return cmd.ID == "abc123" && *cmd.Name == "新グループ名" && *cmd.RenamedAt == "2024-04-01" && cmd.NameHistory == nil
+	})).Return(nil)
+
+	router := setupGroupRouter(mockUC)
+	body := `{"name":"新グループ名","renamed_at":"2024-04-01"}`
+	req := httptest.NewRequest(http.MethodPut, "/groups/abc123", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUC.AssertExpectations(t)
+}
+
+func TestUpdateGroup_NameHistoryMissingUntil(t *testing.T) {
+	mockUC := new(MockGroupUseCase)
+	router := setupGroupRouter(mockUC)
+
+	body := `{"name_history":[{"name":"旧グループ名"}]}`
+	req := httptest.NewRequest(http.MethodPut, "/groups/abc123", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUC.AssertNotCalled(t, "UpdateGroup")
+}
+
+func TestGetGroupNameAt_InvalidDate(t *testing.T) {
+	mockUC := new(MockGroupUseCase)
+	at := "20190601"
+	mockUC.On("GetNameAt", mock.Anything, group.NameAtQuery{ID: "abc123", At: &at}).
+		Return(nil, errors.New("基準日の形式が不正です: YYYY-MM-DD で指定してください"))
+
+	router := setupGroupRouter(mockUC)
+	req := httptest.NewRequest(http.MethodGet, "/groups/abc123/name?at=20190601", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUC.AssertExpectations(t)
+}
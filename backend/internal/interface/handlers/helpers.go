@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 )
 
 const accessTokenHeader = "X-Access-Token"
@@ -30,3 +32,36 @@ func getAccessToken(c *gin.Context) (string, bool) {
 	}
 	return token, true
 }
+
+// getFields は fields / fields[<フィールド名>] パラメータをスキーマに照らして検証する。
+// include で展開を求められた関連データは fields の指定にかかわらず返す。不正な指定は 400 を返して false を返す。
+// 大きな任意属性の読み込み省略（Omit）はアイドル・グループ・イベント・会場・リリースの一覧でのみ行い、
+// ID 指定の取得やその他の一覧ではレスポンスの射影だけを行う。
+func getFields(c *gin.Context, schema *fieldset.Schema) (*fieldset.Selection, bool) {
+	sel, err := fieldset.Parse(c.Query("fields"), c.QueryMap("fields"), strings.Split(c.Query("include"), ","), schema)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError(err.Error()))
+		return nil, false
+	}
+	return sel, true
+}
+
+// writeFields は fields 指定に従って射影したレスポンスを返す
+func writeFields(c *gin.Context, status int, sel *fieldset.Selection, body any) {
+	projected, err := sel.Apply(body)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{})
+		return
+	}
+	c.JSON(status, projected)
+}
+
+// writeFieldsList は一覧レスポンスの data 配下の各要素を fields 指定に従って射影して返す
+func writeFieldsList(c *gin.Context, status int, sel *fieldset.Selection, body any) {
+	projected, err := sel.ApplyList(body, "data")
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{})
+		return
+	}
+	c.JSON(status, projected)
+}
@@ -5,9 +5,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 	"github.com/kuro48/idol-api/internal/usecase/idol"
 )
 
+// idolFields は fields パラメータで指定できるアイドルのフィールド
+var idolFields = fieldset.SchemaOf(idol.IdolDTO{})
+
 // IdolHandler はアイドルハンドラー
 type IdolHandler struct {
 	usecase idol.IdolUseCase
@@ -23,6 +27,7 @@ func NewIdolHandler(usecase idol.IdolUseCase) *IdolHandler {
 // CreateIdolRequest はアイドル作成リクエスト
 type CreateIdolRequest struct {
 	Name      string   `json:"name" binding:"required,min=1,max=100"`
+	NameKana  *string  `json:"name_kana" binding:"omitempty,max=200"`
 	Birthdate string   `json:"birthdate" binding:"omitempty,datetime=2006-01-02"`
 	AgencyID  *string  `json:"agency_id" binding:"omitempty"`
 	Aliases   []string `json:"aliases" binding:"omitempty"`
@@ -31,12 +36,22 @@ type CreateIdolRequest struct {
 // UpdateIdolRequest はアイドル更新リクエスト
 type UpdateIdolRequest struct {
 	Name        *string                        `json:"name" binding:"omitempty,min=1,max=100"`
+	NameKana    *string                        `json:"name_kana" binding:"omitempty,max=200"`
 	Birthdate   *string                        `json:"birthdate" binding:"omitempty,datetime=2006-01-02"`
-	AgencyID    *string                        `json:"agency_id" binding:"omitempty"`
+	AgencyID    *string                        `json:"agency_id" binding:"omitempty"` // 現在の所属事務所と同じ値のみ指定可（変更は所属履歴で行う）
 	Aliases     []string                       `json:"aliases" binding:"omitempty"`
 	SocialLinks *idol.UpdateSocialLinksCommand `json:"social_links" binding:"omitempty"`
 	ExternalIDs map[string]string              `json:"external_ids" binding:"omitempty"`
 	Restore     *bool                          `json:"restore" binding:"omitempty"`
+	RenamedAt   *string                        `json:"renamed_at" binding:"omitempty,datetime=2006-01-02"` // name と併せて指定すると旧芸名を履歴に残す
+	NameHistory []NameHistoryRequest           `json:"name_history" binding:"omitempty,dive"`              // 改名履歴の置き換え（空配列で削除）
+}
+
+// NameHistoryRequest は使用期間付きの旧名1件
+type NameHistoryRequest struct {
+	Name  string  `json:"name" binding:"required,min=1,max=100"`
+	From  *string `json:"from" binding:"omitempty,datetime=2006-01-02"`
+	Until string  `json:"until" binding:"required,datetime=2006-01-02"` // 改名日
 }
 
 // CreateIdol はアイドルを作成する
@@ -63,6 +78,7 @@ func (h *IdolHandler) CreateIdol(c *gin.Context) {
 	}
 	cmd := idol.CreateIdolCommand{
 		Name:      req.Name,
+		NameKana:  req.NameKana,
 		Birthdate: birthdate,
 		AgencyID:  req.AgencyID,
 		Aliases:   req.Aliases,
@@ -84,7 +100,8 @@ func (h *IdolHandler) CreateIdol(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "アイドルID"
-// @Param        include query string false "関連データ読み込み (カンマ区切り: agency,groups)"
+// @Param        include query string false "関連データ読み込み (カンマ区切り: agency,groups,memberships,releases,events,tags。同時指定数・展開件数はプランで制限)"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} idol.IdolDTO
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      404 {object} middleware.ErrorResponse
@@ -94,12 +111,55 @@ func (h *IdolHandler) GetIdol(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, idolFields)
+	if !ok {
+		return
+	}
 
-	query := idol.GetIdolQuery{ID: id}
+	var query idol.GetIdolQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.ID = id
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
 
 	dto, err := h.usecase.GetIdol(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusNotFound, middleware.NewNotFoundError("アイドル"))
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "アイドル"})
+		return
+	}
+
+	writeFields(c, http.StatusOK, sel, dto)
+}
+
+// GetNameAt は指定日時点の芸名を取得する
+// @Summary      指定日時点の芸名取得
+// @Description  改名履歴から指定日に使われていた芸名を取得する。リリースやイベント当時の表記の表示に使う
+// @Tags         idols
+// @Produce      json
+// @Param        id path string true "アイドルID"
+// @Param        at query string false "基準日 (YYYY-MM-DD。省略時は現在)"
+// @Success      200 {object} idol.NameAtDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /idols/{id}/name [get]
+func (h *IdolHandler) GetNameAt(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var query idol.NameAtQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.ID = id
+
+	dto, err := h.usecase.GetNameAt(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "アイドル"})
 		return
 	}
 
@@ -118,16 +178,22 @@ func (h *IdolHandler) GetIdol(c *gin.Context) {
 // @Param        age_max query int false "最大年齢"
 // @Param        birthdate_from query string false "生年月日FROM (YYYY-MM-DD)"
 // @Param        birthdate_to query string false "生年月日TO (YYYY-MM-DD)"
-// @Param        include query string false "関連データ読み込み (カンマ区切り: agency,groups)"
-// @Param        sort query string false "ソート項目" Enums(name, birthdate, created_at) default(created_at)
+// @Param        include query string false "関連データ読み込み (カンマ区切り: agency,groups,memberships,releases,events,tags。同時指定数・展開件数はプランで制限)"
+// @Param        sort query string false "ソート項目" Enums(name, name_kana, birthdate, created_at) default(created_at)
 // @Param        order query string false "ソート順" Enums(asc, desc) default(desc)
 // @Param        page query int false "ページ番号" default(1)
 // @Param        limit query int false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} idol.SearchResult
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /idols [get]
 func (h *IdolHandler) ListIdols(c *gin.Context) {
+	sel, ok := getFields(c, idolFields)
+	if !ok {
+		return
+	}
+
 	var query idol.ListIdolsQuery
 
 	// クエリパラメータをバインド
@@ -138,6 +204,8 @@ func (h *IdolHandler) ListIdols(c *gin.Context) {
 
 	// デフォルト値を適用
 	query.ApplyDefaults()
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
+	query.Fields = sel.Requested()
 
 	// バリデーション
 	if err := query.Validate(); err != nil {
@@ -152,7 +220,7 @@ func (h *IdolHandler) ListIdols(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeFieldsList(c, http.StatusOK, sel, result)
 }
 
 // PatchIdol はアイドルを部分更新する
@@ -186,13 +254,17 @@ func (h *IdolHandler) PatchIdol(c *gin.Context) {
 		}
 	}
 
-	if req.Name != nil || req.Birthdate != nil || req.AgencyID != nil || req.Aliases != nil {
+	if req.Name != nil || req.NameKana != nil || req.Birthdate != nil || req.AgencyID != nil || req.Aliases != nil ||
+		req.RenamedAt != nil || req.NameHistory != nil {
 		cmd := idol.UpdateIdolCommand{
-			ID:        id,
-			Name:      req.Name,
-			Birthdate: req.Birthdate,
-			AgencyID:  req.AgencyID,
-			Aliases:   req.Aliases,
+			ID:          id,
+			Name:        req.Name,
+			NameKana:    req.NameKana,
+			Birthdate:   req.Birthdate,
+			AgencyID:    req.AgencyID,
+			Aliases:     req.Aliases,
+			RenamedAt:   req.RenamedAt,
+			NameHistory: toIdolNameHistoryEntries(req.NameHistory),
 		}
 		if err := h.usecase.UpdateIdol(middleware.AuditContextFor(c), cmd); err != nil {
 			middleware.WriteError(c, err, middleware.ErrorContext{Resource: "アイドル", Message: "アイドルの更新に失敗しました"})
@@ -273,3 +345,14 @@ func (h *IdolHandler) GetExternalIDs(c *gin.Context) {
 type UpdateExternalIDsRequest struct {
 	ExternalIDs map[string]string `json:"external_ids" binding:"required"`
 }
+
+func toIdolNameHistoryEntries(reqs []NameHistoryRequest) []idol.NameHistoryEntry {
+	if reqs == nil {
+		return nil
+	}
+	entries := make([]idol.NameHistoryEntry, 0, len(reqs))
+	for _, r := range reqs {
+		entries = append(entries, idol.NameHistoryEntry{Name: r.Name, From: r.From, Until: r.Until})
+	}
+	return entries
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kuro48/idol-api/internal/domain/plan"
 	"github.com/kuro48/idol-api/internal/interface/handlers"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
 	"github.com/kuro48/idol-api/internal/usecase/idol"
@@ -86,6 +87,14 @@ func (m *MockIdolUseCase) UpdateExternalIDs(ctx context.Context, cmd idol.Update
 	return args.Error(0)
 }
 
+func (m *MockIdolUseCase) GetNameAt(ctx context.Context, query idol.NameAtQuery) (*idol.NameAtDTO, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*idol.NameAtDTO), args.Error(1)
+}
+
 func setupIdolRouter(usecase idol.IdolUseCase) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -94,6 +103,7 @@ func setupIdolRouter(usecase idol.IdolUseCase) *gin.Engine {
 	router.POST("/idols", h.CreateIdol)
 	router.GET("/idols/:id", h.GetIdol)
 	router.GET("/idols", h.ListIdols)
+	router.GET("/idols/:id/name", h.GetNameAt)
 	router.PATCH("/idols/:id", h.PatchIdol)
 	router.DELETE("/idols/:id", h.DeleteIdol)
 	return router
@@ -161,7 +171,7 @@ func TestCreateIdol_UsecaseError(t *testing.T) {
 func TestGetIdol_Found(t *testing.T) {
 	mockUC := new(MockIdolUseCase)
 	dto := &idol.IdolDTO{ID: "idol-001", Name: "テストアイドル"}
-	mockUC.On("GetIdol", mock.Anything, idol.GetIdolQuery{ID: "idol-001"}).Return(dto, nil)
+	mockUC.On("GetIdol", mock.Anything, idol.GetIdolQuery{ID: "idol-001", IncludeLimits: plan.GetLimits(plan.TypeFree).Include}).Return(dto, nil)
 
 	router := setupIdolRouter(mockUC)
 	req := httptest.NewRequest(http.MethodGet, "/idols/idol-001", nil)
@@ -174,7 +184,7 @@ func TestGetIdol_Found(t *testing.T) {
 
 func TestGetIdol_NotFound(t *testing.T) {
 	mockUC := new(MockIdolUseCase)
-	mockUC.On("GetIdol", mock.Anything, idol.GetIdolQuery{ID: "nonexistent"}).Return(nil, errors.New("アイドルが見つかりません"))
+	mockUC.On("GetIdol", mock.Anything, idol.GetIdolQuery{ID: "nonexistent", IncludeLimits: plan.GetLimits(plan.TypeFree).Include}).Return(nil, errors.New("アイドルが見つかりません"))
 
 	router := setupIdolRouter(mockUC)
 	req := httptest.NewRequest(http.MethodGet, "/idols/nonexistent", nil)
@@ -185,6 +195,21 @@ func TestGetIdol_NotFound(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
+func TestGetIdol_PassesIncludeAndRejectsInvalidInclude(t *testing.T) {
+	mockUC := new(MockIdolUseCase)
+	include := "groups,tags"
+	mockUC.On("GetIdol", mock.Anything, idol.GetIdolQuery{ID: "idol-001", Include: &include, IncludeLimits: plan.GetLimits(plan.TypeFree).Include}).
+		Return(nil, errors.New("無効な include 指定です: 現在のプランで同時に指定できるのは2件までです"))
+
+	router := setupIdolRouter(mockUC)
+	req := httptest.NewRequest(http.MethodGet, "/idols/idol-001?include=groups,tags", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUC.AssertExpectations(t)
+}
+
 func TestListIdols_Success(t *testing.T) {
 	mockUC := new(MockIdolUseCase)
 	result := &idol.SearchResult{
@@ -204,6 +229,44 @@ func TestListIdols_Success(t *testing.T) {
 	mockUC.AssertExpectations(t)
 }
 
+func TestListIdols_ProjectsFields(t *testing.T) {
+	mockUC := new(MockIdolUseCase)
+	agency := &idol.AgencyRefDTO{ID: "agency-001", Name: "事務所A", Country: "JP"}
+	result := &idol.SearchResult{
+		Data: []*idol.IdolDTO{{ID: "idol-001", Name: "アイドルA", Birthdate: "2001-04-01", Agency: agency}},
+		Meta: &idol.PaginationMeta{Total: 1, Page: 1, PerPage: 20, TotalPages: 1},
+	}
+	mockUC.On("SearchIdols", mock.Anything, mock.MatchedBy(func(q idol.ListIdolsQuery) bool {
+		return assert.ObjectsAreEqual([]string{"agency", "id", "name"}, q.Fields)
+	})).Return(result, nil)
+
+	router := setupIdolRouter(mockUC)
+	req := httptest.NewRequest(http.MethodGet, "/idols?fields=name&include=agency&fields[agency]=name", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"data": [{"id": "idol-001", "name": "アイドルA", "agency": {"id": "agency-001", "name": "事務所A"}}],
+		"meta": {"total": 1, "page": 1, "per_page": 20, "total_pages": 1, "has_next": false, "has_prev": false}
+	}`, w.Body.String())
+	mockUC.AssertExpectations(t)
+}
+
+func TestListIdols_InvalidFields(t *testing.T) {
+	mockUC := new(MockIdolUseCase)
+	router := setupIdolRouter(mockUC)
+
+	for _, q := range []string{"fields=name,height", "fields[social]=twitter"} {
+		req := httptest.NewRequest(http.MethodGet, "/idols?"+q, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+	mockUC.AssertNotCalled(t, "SearchIdols")
+}
+
 func TestListIdols_InvalidSortParam(t *testing.T) {
 	mockUC := new(MockIdolUseCase)
 	router := setupIdolRouter(mockUC)
@@ -319,3 +382,51 @@ func TestRestoreIdol_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 	mockUC.AssertExpectations(t)
 }
+
+func TestPatchIdol_RenameWithNameHistory(t *testing.T) {
+	mockUC := new(MockIdolUseCase)
+	mockUC.On("UpdateIdol", mock.Anything, mock.MatchedBy(func(cmd idol.UpdateIdolCommand) bool {
+		return cmd.ID == "idol-001" && *cmd.Name == "新芸名" && *cmd.RenamedAt == "2024-04-01" &&
+			len(cmd.NameHistory) == 1 && cmd.NameHistory[0].Name == "旧芸名" && cmd.NameHistory[0].Until == "2020-01-01"
+	})).Return(nil)
+
+	router := setupIdolRouter(mockUC)
+	body := `{"name":"新芸名","renamed_at":"2024-04-01","name_history":[{"name":"旧芸名","until":"2020-01-01"}]}`
+	req := httptest.NewRequest(http.MethodPatch, "/idols/idol-001", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockUC.AssertExpectations(t)
+}
+
+func TestPatchIdol_InvalidRenamedAt(t *testing.T) {
+	mockUC := new(MockIdolUseCase)
+	router := setupIdolRouter(mockUC)
+
+	body := `{"name":"新芸名","renamed_at":"2024/04/01"}`
+	req := httptest.NewRequest(http.MethodPatch, "/idols/idol-001", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockUC.AssertNotCalled(t, "UpdateIdol")
+}
+
+func TestGetIdolNameAt_Success(t *testing.T) {
+	mockUC := new(MockIdolUseCase)
+	at := "2019-06-01"
+	dto := &idol.NameAtDTO{ID: "idol-001", At: at, Name: "旧芸名", IsCurrent: false}
+	mockUC.On("GetNameAt", mock.Anything, idol.NameAtQuery{ID: "idol-001", At: &at}).Return(dto, nil)
+
+	router := setupIdolRouter(mockUC)
+	req := httptest.NewRequest(http.MethodGet, "/idols/idol-001/name?at=2019-06-01", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"旧芸名"`)
+	mockUC.AssertExpectations(t)
+}
@@ -67,7 +67,8 @@ func NewJobHandler(svc JobService) *JobHandler {
 // BulkImportItem はバルクインポートの1件分のデータ
 type BulkImportItem struct {
 	Name      string   `json:"name" binding:"required"`
-	Birthdate string   `json:"birthdate,omitempty"` // YYYY-MM-DD
+	NameKana  string   `json:"name_kana,omitempty" binding:"omitempty,max=200"` // 読み仮名（ひらがな/カタカナ）
+	Birthdate string   `json:"birthdate,omitempty"`                             // YYYY-MM-DD
 	AgencyID  string   `json:"agency_id,omitempty"`
 	Aliases   []string `json:"aliases,omitempty"`
 	TagIDs    []string `json:"tag_ids,omitempty"`
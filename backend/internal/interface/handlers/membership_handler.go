@@ -5,9 +5,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 	"github.com/kuro48/idol-api/internal/usecase/membership"
 )
 
+// membershipFields は fields パラメータで指定できるメンバーシップのフィールド
+var membershipFields = fieldset.SchemaOf(membership.MembershipDTO{})
+
 type MembershipHandler struct {
 	usecase membership.MembershipUseCase
 }
@@ -17,16 +21,18 @@ func NewMembershipHandler(uc membership.MembershipUseCase) *MembershipHandler {
 }
 
 type CreateMembershipRequest struct {
-	IdolID   string  `json:"idol_id" binding:"required"`
-	GroupID  string  `json:"group_id" binding:"required"`
-	Role     string  `json:"role" binding:"required"`
-	JoinedAt *string `json:"joined_at" binding:"omitempty,datetime=2006-01-02"`
+	IdolID     string  `json:"idol_id" binding:"required"`
+	GroupID    string  `json:"group_id" binding:"required"`
+	Role       string  `json:"role" binding:"required"`
+	Generation *string `json:"generation" binding:"omitempty,max=50"` // 期生ラベル（"1期生" など）
+	JoinedAt   *string `json:"joined_at" binding:"omitempty,datetime=2006-01-02"`
 }
 
 type UpdateMembershipRequest struct {
-	Role     *string `json:"role"`
-	JoinedAt *string `json:"joined_at" binding:"omitempty,datetime=2006-01-02"`
-	LeftAt   *string `json:"left_at" binding:"omitempty,datetime=2006-01-02"`
+	Role       *string `json:"role"`
+	Generation *string `json:"generation" binding:"omitempty,max=50"` // 空文字で解除
+	JoinedAt   *string `json:"joined_at" binding:"omitempty,datetime=2006-01-02"`
+	LeftAt     *string `json:"left_at" binding:"omitempty,datetime=2006-01-02"`
 }
 
 // CreateMembership はメンバーシップを作成する
@@ -46,10 +52,11 @@ func (h *MembershipHandler) CreateMembership(c *gin.Context) {
 	}
 
 	cmd := membership.CreateMembershipCommand{
-		IdolID:   req.IdolID,
-		GroupID:  req.GroupID,
-		Role:     req.Role,
-		JoinedAt: req.JoinedAt,
+		IdolID:     req.IdolID,
+		GroupID:    req.GroupID,
+		Role:       req.Role,
+		Generation: req.Generation,
+		JoinedAt:   req.JoinedAt,
 	}
 
 	dto, err := h.usecase.CreateMembership(middleware.AuditContextFor(c), cmd)
@@ -69,6 +76,7 @@ func (h *MembershipHandler) CreateMembership(c *gin.Context) {
 // @Tags         memberships
 // @Produce      json
 // @Param        id path string true "メンバーシップID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} membership.MembershipDTO
 // @Failure      404 {object} middleware.ErrorResponse
 // @Router       /memberships/{id} [get]
@@ -77,6 +85,10 @@ func (h *MembershipHandler) GetMembership(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, membershipFields)
+	if !ok {
+		return
+	}
 
 	dto, err := h.usecase.GetMembership(c.Request.Context(), membership.GetMembershipQuery{ID: id})
 	if err != nil {
@@ -84,7 +96,7 @@ func (h *MembershipHandler) GetMembership(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto)
+	writeFields(c, http.StatusOK, sel, dto)
 }
 
 // ListMemberships はメンバーシップ一覧を取得する
@@ -95,14 +107,21 @@ func (h *MembershipHandler) GetMembership(c *gin.Context) {
 // @Param        group_id  query string false "グループID"
 // @Param        is_active query bool   false "アクティブのみ"
 // @Param        role      query string false "ロール"
+// @Param        generation query string false "期生ラベル（完全一致）"
 // @Param        sort      query string false "ソート項目" Enums(joined_at, left_at, created_at) default(created_at)
 // @Param        order     query string false "ソート順" Enums(asc, desc) default(desc)
 // @Param        page      query int    false "ページ番号" default(1)
 // @Param        limit     query int    false "件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} membership.MembershipSearchResult
 // @Failure      400 {object} middleware.ErrorResponse
 // @Router       /memberships [get]
 func (h *MembershipHandler) ListMemberships(c *gin.Context) {
+	sel, ok := getFields(c, membershipFields)
+	if !ok {
+		return
+	}
+
 	var query membership.ListMembershipQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
@@ -115,7 +134,7 @@ func (h *MembershipHandler) ListMemberships(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeFieldsList(c, http.StatusOK, sel, result)
 }
 
 // ListIdolMemberships はアイドルのメンバーシップ一覧を取得する
@@ -123,6 +142,7 @@ func (h *MembershipHandler) ListMemberships(c *gin.Context) {
 // @Tags         idols
 // @Produce      json
 // @Param        id path string true "アイドルID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {array} membership.MembershipDTO
 // @Failure      404 {object} middleware.ErrorResponse
 // @Router       /idols/{id}/memberships [get]
@@ -131,6 +151,10 @@ func (h *MembershipHandler) ListIdolMemberships(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, membershipFields)
+	if !ok {
+		return
+	}
 
 	dtos, err := h.usecase.ListByIdolID(c.Request.Context(), id)
 	if err != nil {
@@ -138,14 +162,16 @@ func (h *MembershipHandler) ListIdolMemberships(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dtos)
+	writeFieldsList(c, http.StatusOK, sel, dtos)
 }
 
 // ListGroupMemberships はグループのメンバーシップ一覧を取得する
 // @Summary      グループのメンバーシップ一覧
+// @Description  直下のサブユニットのメンバーシップも親グループのメンバーとして含める（group_id は所属ユニット）
 // @Tags         groups
 // @Produce      json
 // @Param        id path string true "グループID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {array} membership.MembershipDTO
 // @Failure      404 {object} middleware.ErrorResponse
 // @Router       /groups/{id}/memberships [get]
@@ -154,6 +180,10 @@ func (h *MembershipHandler) ListGroupMemberships(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, membershipFields)
+	if !ok {
+		return
+	}
 
 	dtos, err := h.usecase.ListByGroupID(c.Request.Context(), id)
 	if err != nil {
@@ -161,7 +191,7 @@ func (h *MembershipHandler) ListGroupMemberships(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dtos)
+	writeFieldsList(c, http.StatusOK, sel, dtos)
 }
 
 // UpdateMembership はメンバーシップを更新する
@@ -188,10 +218,11 @@ func (h *MembershipHandler) UpdateMembership(c *gin.Context) {
 	}
 
 	cmd := membership.UpdateMembershipCommand{
-		ID:       id,
-		Role:     req.Role,
-		JoinedAt: req.JoinedAt,
-		LeftAt:   req.LeftAt,
+		ID:         id,
+		Role:       req.Role,
+		Generation: req.Generation,
+		JoinedAt:   req.JoinedAt,
+		LeftAt:     req.LeftAt,
 	}
 
 	if err := h.usecase.UpdateMembership(middleware.AuditContextFor(c), cmd); err != nil {
@@ -5,9 +5,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 	"github.com/kuro48/idol-api/internal/usecase/release"
 )
 
+// releaseFields は fields パラメータで指定できるリリースのフィールド
+var releaseFields = fieldset.SchemaOf(release.ReleaseDTO{})
+
 // ReleaseHandler はリリースハンドラー
 type ReleaseHandler struct {
 	usecase release.ReleaseUseCase
@@ -99,6 +103,39 @@ type UpdateReleaseExternalIDsRequest struct {
 	ExternalIDs map[string]string `json:"external_ids" binding:"required"`
 }
 
+// EditionTrackRequest はエディション収録曲リクエスト。
+// shared_track_number（リリース共通の収録曲の番号）と track（エディション固有の楽曲）のどちらか一方を指定する
+type EditionTrackRequest struct {
+	DiscNumber        int           `json:"disc_number" binding:"omitempty,min=1"`
+	TrackNumber       int           `json:"track_number" binding:"omitempty,min=1"`
+	SharedTrackNumber *int          `json:"shared_track_number" binding:"omitempty,min=1"`
+	Track             *TrackRequest `json:"track" binding:"omitempty"`
+}
+
+// BonusMediaRequest は特典リクエスト
+type BonusMediaRequest struct {
+	Type        string  `json:"type" binding:"required,oneof=dvd blu_ray cd photobook photo_card serial_code goods other"`
+	Title       string  `json:"title" binding:"required,min=1,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+}
+
+// EditionRequest はエディションリクエスト
+type EditionRequest struct {
+	ID          *string               `json:"id"`
+	Name        string                `json:"name" binding:"required,min=1,max=100"`
+	EditionType string                `json:"edition_type" binding:"required,oneof=first_press_limited limited regular theater venue other"`
+	JANCode     *string               `json:"jan_code" binding:"omitempty,numeric"`
+	UPC         *string               `json:"upc" binding:"omitempty,numeric,len=12"`
+	Price       *int                  `json:"price" binding:"omitempty,min=0"`
+	Tracks      []EditionTrackRequest `json:"tracks" binding:"omitempty,dive"`
+	BonusMedia  []BonusMediaRequest   `json:"bonus_media" binding:"omitempty,max=20,dive"`
+}
+
+// UpdateEditionsRequest はエディション一括更新リクエスト
+type UpdateEditionsRequest struct {
+	Editions []EditionRequest `json:"editions" binding:"max=20,dive"`
+}
+
 // CreateRelease はリリースを作成する
 // @Summary      リリース作成
 // @Description  新しいリリース（シングル・アルバム等）を作成する
@@ -147,7 +184,10 @@ func (h *ReleaseHandler) CreateRelease(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "リリースID"
+// @Param        include query string false "関連データ読み込み (カンマ区切り: artists,participants)"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} release.ReleaseDTO
+// @Failure      400 {object} middleware.ErrorResponse
 // @Failure      404 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /releases/{id} [get]
@@ -156,14 +196,26 @@ func (h *ReleaseHandler) GetRelease(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, releaseFields)
+	if !ok {
+		return
+	}
+
+	var query release.GetReleaseQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.ID = id
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
 
-	dto, err := h.usecase.GetRelease(c.Request.Context(), id)
+	dto, err := h.usecase.GetRelease(c.Request.Context(), query)
 	if err != nil {
-		c.JSON(http.StatusNotFound, middleware.NewNotFoundError("リリース"))
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "リリース"})
 		return
 	}
 
-	c.JSON(http.StatusOK, dto)
+	writeFields(c, http.StatusOK, sel, dto)
 }
 
 // ListReleases はリリース一覧を取得する
@@ -178,15 +230,22 @@ func (h *ReleaseHandler) GetRelease(c *gin.Context) {
 // @Param        artist_kind query string false "アーティスト種別" Enums(idol, group)
 // @Param        release_date_from query string false "リリース日FROM (YYYY-MM-DD)"
 // @Param        release_date_to query string false "リリース日TO (YYYY-MM-DD)"
+// @Param        include query string false "関連データ読み込み (カンマ区切り: artists,participants)"
 // @Param        sort query string false "ソート項目" Enums(release_date, title, created_at) default(release_date)
 // @Param        order query string false "ソート順" Enums(asc, desc) default(desc)
 // @Param        page query int false "ページ番号" default(1)
 // @Param        limit query int false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} release.SearchResult
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /releases [get]
 func (h *ReleaseHandler) ListReleases(c *gin.Context) {
+	sel, ok := getFields(c, releaseFields)
+	if !ok {
+		return
+	}
+
 	var query release.ListReleasesQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです: "+err.Error()))
@@ -194,6 +253,8 @@ func (h *ReleaseHandler) ListReleases(c *gin.Context) {
 	}
 
 	query.ApplyDefaults()
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
+	query.Fields = sel.Requested()
 
 	if err := query.Validate(); err != nil {
 		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError(err.Error()))
@@ -206,7 +267,7 @@ func (h *ReleaseHandler) ListReleases(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeFieldsList(c, http.StatusOK, sel, result)
 }
 
 // UpdateRelease はリリースを更新する
@@ -386,6 +447,66 @@ func (h *ReleaseHandler) UpdateExternalIDs(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "外部IDが更新されました"})
 }
 
+// UpdateEditions はリリースのエディションを置き換える
+// @Summary      エディション更新
+// @Description  初回限定盤・通常盤・劇場盤などのエディションを一覧で置き換える。既存のエディションは id を指定すると同じIDのまま更新できる。収録曲はリリース共通の収録曲の参照（shared_track_number）かエディション固有の楽曲（track）で指定する
+// @Tags         releases
+// @Accept       json
+// @Produce      json
+// @Param        id       path string true "リリースID"
+// @Param        editions body UpdateEditionsRequest true "エディション（最大20件）"
+// @Success      200 {object} release.ReleaseDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      409 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /releases/{id}/editions [put]
+func (h *ReleaseHandler) UpdateEditions(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateEditionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	dto, err := h.usecase.UpdateEditions(middleware.AuditContextFor(c), release.UpdateEditionsCommand{
+		ID:       id,
+		Editions: toEditionCommands(req.Editions),
+	})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "リリース", Message: "エディションの更新に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
+// GetEditionByJANCode はJANコードからエディションを取得する
+// @Summary      JANコードによるエディション検索
+// @Description  JANコード（8桁または13桁）に一致するエディションと、その親リリースを取得する
+// @Tags         releases
+// @Accept       json
+// @Produce      json
+// @Param        jan_code path string true "JANコード"
+// @Success      200 {object} release.EditionLookupDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /releases/jan/{jan_code} [get]
+func (h *ReleaseHandler) GetEditionByJANCode(c *gin.Context) {
+	dto, err := h.usecase.FindEditionByJANCode(c.Request.Context(), c.Param("jan_code"))
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "エディション"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
 func toArtistRefCommands(reqs []ArtistRefRequest) []release.ArtistRefCommand {
 	if reqs == nil {
 		return nil
@@ -419,6 +540,39 @@ func toTrackCommands(reqs []TrackRequest) []release.TrackCommand {
 	return cmds
 }
 
+func toEditionCommands(reqs []EditionRequest) []release.EditionCommand {
+	cmds := make([]release.EditionCommand, 0, len(reqs))
+	for _, r := range reqs {
+		tracks := make([]release.EditionTrackCommand, 0, len(r.Tracks))
+		for _, t := range r.Tracks {
+			track := release.EditionTrackCommand{
+				DiscNumber:        t.DiscNumber,
+				TrackNumber:       t.TrackNumber,
+				SharedTrackNumber: t.SharedTrackNumber,
+			}
+			if t.Track != nil {
+				track.Track = &toTrackCommands([]TrackRequest{*t.Track})[0]
+			}
+			tracks = append(tracks, track)
+		}
+		bonusMedia := make([]release.BonusMediaCommand, 0, len(r.BonusMedia))
+		for _, b := range r.BonusMedia {
+			bonusMedia = append(bonusMedia, release.BonusMediaCommand{Type: b.Type, Title: b.Title, Description: b.Description})
+		}
+		cmds = append(cmds, release.EditionCommand{
+			ID:          r.ID,
+			Name:        r.Name,
+			EditionType: r.EditionType,
+			JANCode:     r.JANCode,
+			UPC:         r.UPC,
+			Price:       r.Price,
+			Tracks:      tracks,
+			BonusMedia:  bonusMedia,
+		})
+	}
+	return cmds
+}
+
 func toTrackParticipantCommands(reqs []TrackParticipantRequest) []release.TrackParticipantCommand {
 	if reqs == nil {
 		return nil
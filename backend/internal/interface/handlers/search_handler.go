@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/usecase/search"
+)
+
+// SearchHandler は横断検索のハンドラー
+type SearchHandler struct {
+	usecase search.SearchUseCase
+}
+
+// NewSearchHandler は横断検索ハンドラーを作成する
+func NewSearchHandler(usecase search.SearchUseCase) *SearchHandler {
+	return &SearchHandler{usecase: usecase}
+}
+
+// Search はアイドル・グループ・事務所・リリース・イベント・会場を横断して検索する
+// @Summary      横断検索
+// @Description  全集約を横断して名前を検索し、完全一致 > 前方一致 > 別名一致 > 類似一致 の順にランキングして返す。かな/カナ/ローマ字の表記ゆれと誤字を許容する
+// @Tags         search
+// @Produce      json
+// @Param        q     query string true  "検索語"
+// @Param        types query string false "対象種別（カンマ区切り: idol,group,agency,release,event,venue）"
+// @Param        limit query int    false "返却件数" default(20)
+// @Success      200 {object} search.SearchResultDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var query search.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+
+	result, err := h.usecase.Search(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "検索に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Suggest はエンティティ選択欄の入力補完候補を返す
+// @Summary      入力補完
+// @Description  名前・読み・別名の前方一致で候補を返す。同名を見分けるための補足情報（所属事務所・生年など）を含む。結果は短時間キャッシュされる
+// @Tags         search
+// @Produce      json
+// @Param        q     query string true  "入力途中の検索語"
+// @Param        types query string false "対象種別（カンマ区切り: idol,group,agency,release,event,venue）"
+// @Param        limit query int    false "返却件数" default(10)
+// @Success      200 {object} search.SuggestResultDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /suggest [get]
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	var query search.SuggestQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+
+	result, err := h.usecase.Suggest(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "入力補完候補の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
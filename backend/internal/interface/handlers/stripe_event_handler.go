@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	domainbilling "github.com/kuro48/idol-api/internal/domain/billing"
+	"github.com/kuro48/idol-api/internal/interface/middleware"
+)
+
+// StripeEventService は Stripe Webhook イベント台帳の管理に必要な契約。
+type StripeEventService interface {
+	ListStripeEvents(ctx context.Context, status string, limit, offset int) ([]*domainbilling.StripeEvent, int64, error)
+	ReplayStripeEvent(ctx context.Context, eventID string) (*domainbilling.StripeEvent, error)
+}
+
+// StripeEventHandler は Stripe Webhook イベント台帳の管理ハンドラー。
+type StripeEventHandler struct {
+	service StripeEventService
+}
+
+// NewStripeEventHandler は StripeEventHandler を作成する。
+func NewStripeEventHandler(service StripeEventService) *StripeEventHandler {
+	return &StripeEventHandler{service: service}
+}
+
+// StripeEventResponse は台帳エントリのレスポンス。
+type StripeEventResponse struct {
+	EventID       string  `json:"event_id"`
+	EventType     string  `json:"event_type"`
+	CustomerID    string  `json:"customer_id,omitempty"`
+	ObjectID      string  `json:"object_id,omitempty"`
+	Created       string  `json:"created"`
+	Status        string  `json:"status"`
+	ErrorMsg      string  `json:"error_msg,omitempty"`
+	Attempts      int     `json:"attempts"`
+	ReceivedAt    string  `json:"received_at"`
+	LastAttemptAt *string `json:"last_attempt_at,omitempty"`
+	ProcessedAt   *string `json:"processed_at,omitempty"`
+}
+
+// StripeEventListResponse は台帳一覧のレスポンス。
+type StripeEventListResponse struct {
+	Data   []StripeEventResponse `json:"data"`
+	Total  int64                 `json:"total"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+func toStripeEventResponse(e *domainbilling.StripeEvent) StripeEventResponse {
+	resp := StripeEventResponse{
+		EventID:    e.EventID(),
+		EventType:  e.EventType(),
+		CustomerID: e.CustomerID(),
+		ObjectID:   e.ObjectID(),
+		Created:    e.Created().Format(time.RFC3339),
+		Status:     string(e.Status()),
+		ErrorMsg:   e.ErrorMsg(),
+		Attempts:   e.Attempts(),
+		ReceivedAt: e.ReceivedAt().Format(time.RFC3339),
+	}
+	if e.LastAttemptAt() != nil {
+		s := e.LastAttemptAt().Format(time.RFC3339)
+		resp.LastAttemptAt = &s
+	}
+	if e.ProcessedAt() != nil {
+		s := e.ProcessedAt().Format(time.RFC3339)
+		resp.ProcessedAt = &s
+	}
+	return resp
+}
+
+// ListStripeEvents は Stripe Webhook イベント台帳を一覧取得する
+// @Summary      Stripeイベント台帳一覧
+// @Description  受信した Stripe Webhook イベントの処理結果を新しい順に返す（管理者専用）
+// @Tags         admin
+// @Produce      json
+// @Param        status query string false "処理状態（processing/succeeded/failed/skipped）"
+// @Param        limit  query int    false "取得件数（1〜100、デフォルト20）"
+// @Param        offset query int    false "オフセット"
+// @Success      200 {object} StripeEventListResponse
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /admin/billing/stripe-events [get]
+func (h *StripeEventHandler) ListStripeEvents(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("limit は 1〜100 で指定してください"))
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("offset は 0 以上で指定してください"))
+		return
+	}
+
+	events, total, err := h.service.ListStripeEvents(c.Request.Context(), c.Query("status"), limit, offset)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "Stripeイベントの取得に失敗しました"})
+		return
+	}
+
+	data := make([]StripeEventResponse, 0, len(events))
+	for _, e := range events {
+		data = append(data, toStripeEventResponse(e))
+	}
+	c.JSON(http.StatusOK, StripeEventListResponse{Data: data, Total: total, Limit: limit, Offset: offset})
+}
+
+// ReplayStripeEvent は失敗した Stripe Webhook イベントを再実行する
+// @Summary      Stripeイベント再実行
+// @Description  失敗した Stripe Webhook イベントを保存済みペイロードから再実行する（管理者専用）
+// @Tags         admin
+// @Produce      json
+// @Param        id path string true "Stripe イベントID"
+// @Success      200 {object} StripeEventResponse
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      409 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /admin/billing/stripe-events/{id}/replay [post]
+func (h *StripeEventHandler) ReplayStripeEvent(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.service.ReplayStripeEvent(c.Request.Context(), id)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "Stripeイベント", Message: "Stripeイベントの再実行に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toStripeEventResponse(event))
+}
@@ -6,9 +6,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 	"github.com/kuro48/idol-api/internal/usecase/tag"
 )
 
+// tagFields は fields パラメータで指定できるタグのフィールド
+var tagFields = fieldset.SchemaOf(tag.TagDTO{})
+
 // TagHandler はタグのハンドラー
 type TagHandler struct {
 	usecase tag.TagUseCase
@@ -58,6 +62,7 @@ func (h *TagHandler) CreateTag(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "タグID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} tag.TagDTO
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      404 {object} middleware.ErrorResponse
@@ -67,6 +72,10 @@ func (h *TagHandler) GetTag(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, tagFields)
+	if !ok {
+		return
+	}
 
 	dto, err := h.usecase.GetTag(c.Request.Context(), id)
 	if err != nil {
@@ -74,7 +83,7 @@ func (h *TagHandler) GetTag(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto)
+	writeFields(c, http.StatusOK, sel, dto)
 }
 
 // ListTags はタグ一覧を取得する（検索機能付き）
@@ -87,11 +96,17 @@ func (h *TagHandler) GetTag(c *gin.Context) {
 // @Param        category query string false "カテゴリ" Enums(genre, region, style, other)
 // @Param        page query int false "ページ番号" default(1)
 // @Param        limit query int false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} tag.SearchResult
 // @Failure      400 {object} middleware.ErrorResponse
 // @Failure      500 {object} middleware.ErrorResponse
 // @Router       /tags [get]
 func (h *TagHandler) ListTags(c *gin.Context) {
+	sel, ok := getFields(c, tagFields)
+	if !ok {
+		return
+	}
+
 	// クエリパラメータの取得
 	name := c.Query("name")
 	category := c.Query("category")
@@ -121,7 +136,7 @@ func (h *TagHandler) ListTags(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeFieldsList(c, http.StatusOK, sel, result)
 }
 
 // UpdateTag はタグを更新する
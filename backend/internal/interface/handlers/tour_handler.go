@@ -0,0 +1,317 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
+	"github.com/kuro48/idol-api/internal/usecase/event"
+	"github.com/kuro48/idol-api/internal/usecase/tour"
+)
+
+// tourFields は fields パラメータで指定できるツアーのフィールド
+var tourFields = fieldset.SchemaOf(tour.TourDTO{})
+
+// TourHandler はツアー（複数日程のイベントシリーズ）のハンドラー。
+// 公演日程の一覧・追加はイベントのユースケースに委ねる
+type TourHandler struct {
+	usecase      tour.TourUseCase
+	eventUsecase event.EventUseCase
+}
+
+func NewTourHandler(uc tour.TourUseCase, eventUC event.EventUseCase) *TourHandler {
+	return &TourHandler{usecase: uc, eventUsecase: eventUC}
+}
+
+type CreateTourRequest struct {
+	Title       string                `json:"title" binding:"required"`
+	EventType   string                `json:"event_type" binding:"required"`
+	Performers  []tour.PerformerInput `json:"performers" binding:"omitempty,dive"`
+	Tags        []string              `json:"tags"`
+	OfficialURL *string               `json:"official_url"`
+	Description *string               `json:"description"`
+}
+
+type UpdateTourRequest struct {
+	Title       *string               `json:"title"`
+	EventType   *string               `json:"event_type"`
+	Performers  []tour.PerformerInput `json:"performers" binding:"omitempty,dive"` // 指定時は丸ごと置き換え、配下の公演にも反映する
+	Tags        []string              `json:"tags"`                                // 指定時は丸ごと置き換え、配下の公演にも反映する
+	OfficialURL *string               `json:"official_url"`
+	Description *string               `json:"description"`
+}
+
+// CreateTourEventRequest はツアーの公演日程の追加リクエスト。イベントタイプを省略するとツアーのものを使う
+type CreateTourEventRequest struct {
+	Title         string                 `json:"title" binding:"required"`
+	EventType     string                 `json:"event_type"`
+	StartDateTime string                 `json:"start_date_time" binding:"required"`
+	EndDateTime   *string                `json:"end_date_time"`
+	VenueID       *string                `json:"venue_id"`
+	Performers    []event.PerformerInput `json:"performers" binding:"omitempty,dive"`
+	TicketURL     *string                `json:"ticket_url"`
+	OfficialURL   *string                `json:"official_url"`
+	Description   *string                `json:"description"`
+	Tags          []string               `json:"tags"`
+}
+
+// CreateTour はツアーを作成する
+// @Summary      ツアー作成
+// @Description  複数の公演日程をまとめるツアー（イベントシリーズ）を作成する
+// @Tags         tours
+// @Accept       json
+// @Produce      json
+// @Param        tour body CreateTourRequest true "ツアー作成リクエスト"
+// @Success      201 {object} tour.TourDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Router       /tours [post]
+func (h *TourHandler) CreateTour(c *gin.Context) {
+	var req CreateTourRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	dto, err := h.usecase.CreateTour(middleware.AuditContextFor(c), tour.CreateTourCommand{
+		Title:       req.Title,
+		EventType:   req.EventType,
+		Performers:  req.Performers,
+		Tags:        req.Tags,
+		OfficialURL: req.OfficialURL,
+		Description: req.Description,
+	})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "ツアー", Message: "ツアーの作成に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// GetTour はツアーを取得する
+// @Summary      ツアー詳細取得
+// @Tags         tours
+// @Produce      json
+// @Param        id path string true "ツアーID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {object} tour.TourDTO
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /tours/{id} [get]
+func (h *TourHandler) GetTour(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	sel, ok := getFields(c, tourFields)
+	if !ok {
+		return
+	}
+
+	dto, err := h.usecase.GetTour(c.Request.Context(), tour.GetTourQuery{ID: id})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "ツアー"})
+		return
+	}
+
+	writeFields(c, http.StatusOK, sel, dto)
+}
+
+// ListTours はツアー一覧を取得する
+// @Summary      ツアー一覧取得
+// @Tags         tours
+// @Produce      json
+// @Param        title        query string false "ツアー名（部分一致）"
+// @Param        performer_id query string false "出演者ID"
+// @Param        page         query int    false "ページ番号" default(1)
+// @Param        limit        query int    false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {object} tour.TourSearchResult
+// @Failure      400 {object} middleware.ErrorResponse
+// @Router       /tours [get]
+func (h *TourHandler) ListTours(c *gin.Context) {
+	sel, ok := getFields(c, tourFields)
+	if !ok {
+		return
+	}
+
+	var query tour.ListTourQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+
+	result, err := h.usecase.ListTours(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "ツアー一覧の取得に失敗しました"})
+		return
+	}
+
+	writeFieldsList(c, http.StatusOK, sel, result)
+}
+
+// UpdateTour はツアーを更新する
+// @Summary      ツアー更新
+// @Description  ツアーを更新する。出演者・タグを指定した場合は配下の全公演に反映し、Webhook は tour.updated を1件だけ通知する
+// @Tags         tours
+// @Accept       json
+// @Produce      json
+// @Param        id   path string true "ツアーID"
+// @Param        tour body UpdateTourRequest true "ツアー更新リクエスト"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /tours/{id} [put]
+func (h *TourHandler) UpdateTour(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateTourRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	err := h.usecase.UpdateTour(middleware.AuditContextFor(c), tour.UpdateTourCommand{
+		ID:          id,
+		Title:       req.Title,
+		EventType:   req.EventType,
+		Performers:  req.Performers,
+		Tags:        req.Tags,
+		OfficialURL: req.OfficialURL,
+		Description: req.Description,
+	})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "ツアー", Message: "ツアーの更新に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ツアーが更新されました"})
+}
+
+// DeleteTour はツアーを削除する
+// @Summary      ツアー削除
+// @Description  ツアーを削除する。配下の公演は削除せずツアーから外す
+// @Tags         tours
+// @Param        id path string true "ツアーID"
+// @Success      204
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /tours/{id} [delete]
+func (h *TourHandler) DeleteTour(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteTour(middleware.AuditContextFor(c), tour.DeleteTourCommand{ID: id}); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "ツアー", Message: "ツアーの削除に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// ListTourEvents はツアーの公演日程を取得する
+// @Summary      ツアーの公演一覧
+// @Tags         tours
+// @Produce      json
+// @Param        id      path  string true  "ツアーID"
+// @Param        include query string false "関連データ読み込み (カンマ区切り: venue,performers)"
+// @Param        page    query int    false "ページ番号" default(1)
+// @Param        limit   query int    false "1ページあたりの件数" default(20)
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
+// @Success      200 {object} event.SearchResult
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Router       /tours/{id}/events [get]
+func (h *TourHandler) ListTourEvents(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+	sel, ok := getFields(c, eventFields)
+	if !ok {
+		return
+	}
+
+	var query event.ListEventsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
+		return
+	}
+	query.ApplyDefaults()
+	query.SeriesID = &id
+	query.IncludeLimits = middleware.IncludeLimitsOf(c)
+	if err := query.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError(err.Error()))
+		return
+	}
+
+	if _, err := h.usecase.GetTour(c.Request.Context(), tour.GetTourQuery{ID: id}); err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "ツアー"})
+		return
+	}
+
+	result, err := h.eventUsecase.SearchEvents(c.Request.Context(), query)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Message: "ツアーの公演一覧の取得に失敗しました"})
+		return
+	}
+
+	writeFieldsList(c, http.StatusOK, sel, result)
+}
+
+// CreateTourEvent はツアーに公演日程を追加する
+// @Summary      ツアーの公演追加
+// @Description  ツアーの公演としてイベントを作成する。ツアーの出演者・タグを引き継ぐ
+// @Tags         tours
+// @Accept       json
+// @Produce      json
+// @Param        id    path string true "ツアーID"
+// @Param        event body CreateTourEventRequest true "公演作成リクエスト"
+// @Param        strict query bool false "日程が重複する場合は作成しない"
+// @Success      201 {object} event.EventDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      409 {object} middleware.ErrorResponse
+// @Router       /tours/{id}/events [post]
+func (h *TourHandler) CreateTourEvent(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	strict, ok := getStrict(c)
+	if !ok {
+		return
+	}
+
+	var req CreateTourEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	dto, err := h.eventUsecase.CreateEvent(middleware.AuditContextFor(c), event.CreateEventCommand{
+		Title:         req.Title,
+		EventType:     req.EventType,
+		StartDateTime: req.StartDateTime,
+		EndDateTime:   req.EndDateTime,
+		VenueID:       req.VenueID,
+		Performers:    req.Performers,
+		TicketURL:     req.TicketURL,
+		OfficialURL:   req.OfficialURL,
+		Description:   req.Description,
+		Tags:          req.Tags,
+		SeriesID:      &id,
+		Strict:        strict,
+	})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "ツアー", Message: "公演の追加に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	appUsage "github.com/kuro48/idol-api/internal/application/usage"
+	domainAuth "github.com/kuro48/idol-api/internal/domain/auth"
+	"github.com/kuro48/idol-api/internal/interface/middleware"
+)
+
+// usageReportService は UsageHandler が依存するサービス契約
+type usageReportService interface {
+	GetOwnerUsage(ctx context.Context, input appUsage.GetOwnerUsageInput) ([]*appUsage.KeyUsageReport, error)
+}
+
+// UsageHandler はAPIキー所有者向けの利用状況ハンドラー
+type UsageHandler struct {
+	svc usageReportService
+}
+
+// NewUsageHandler はUsageHandlerを作成する
+func NewUsageHandler(svc usageReportService) *UsageHandler {
+	return &UsageHandler{svc: svc}
+}
+
+// DailyUsageResponse は日別利用件数のレスポンス
+type DailyUsageResponse struct {
+	Date     string `json:"date"`
+	Requests int64  `json:"requests"`
+	Errors   int64  `json:"errors"`
+}
+
+// EndpointUsageResponse はエンドポイント別利用件数のレスポンス
+type EndpointUsageResponse struct {
+	Method       string  `json:"method"`
+	Endpoint     string  `json:"endpoint"`
+	Requests     int64   `json:"requests"`
+	Errors       int64   `json:"errors"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+// KeyUsageReportResponse はAPIキー単位の当月利用レポートのレスポンス
+type KeyUsageReportResponse struct {
+	KeyID         string                  `json:"key_id"`
+	MaskedKey     string                  `json:"masked_key"`
+	Name          string                  `json:"name"`
+	PlanType      string                  `json:"plan_type"`
+	IsActive      bool                    `json:"is_active"`
+	YearMonth     string                  `json:"year_month"`
+	MonthlyCount  int                     `json:"monthly_count"`
+	MonthlyLimit  int                     `json:"monthly_limit"`
+	Remaining     int                     `json:"remaining"`
+	OverageActive bool                    `json:"overage_active"`
+	OverageCount  int                     `json:"overage_count"`
+	TotalRequests int64                   `json:"total_requests"`
+	ErrorCount    int64                   `json:"error_count"`
+	ErrorRate     float64                 `json:"error_rate"`
+	LatencyP50Ms  int64                   `json:"latency_p50_ms"`
+	LatencyP95Ms  int64                   `json:"latency_p95_ms"`
+	Daily         []DailyUsageResponse    `json:"daily"`
+	TopEndpoints  []EndpointUsageResponse `json:"top_endpoints"`
+}
+
+// GetMyUsage は認証済みユーザーが所有するAPIキーの当月利用状況を返す
+// @Summary      自分のAPIキー利用状況取得
+// @Description  当月のリクエスト数と上限、日別件数、上位エンドポイント、エラー率、p50/p95レイテンシをAPIキー単位で返す
+// @Tags         me
+// @Produce      json
+// @Produce      text/csv
+// @Param        key_id  query string false "対象APIキーID（省略時は全キー）"
+// @Param        format  query string false "出力形式 (json|csv)" default(json)
+// @Param        section query string false "CSV出力の内容 (summary|daily|endpoints)" default(daily)
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      401 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      500 {object} middleware.ErrorResponse
+// @Router       /me/usage [get]
+func (h *UsageHandler) GetMyUsage(c *gin.Context) {
+	principal, ok := domainAuth.PrincipalFromContext(c.Request.Context())
+	if !ok || principal.Email == "" {
+		c.JSON(http.StatusUnauthorized, middleware.NewUnauthorizedError())
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("format は json または csv を指定してください"))
+		return
+	}
+	section := c.DefaultQuery("section", "daily")
+	if section != "summary" && section != "daily" && section != "endpoints" {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("section は summary, daily, endpoints のいずれかを指定してください"))
+		return
+	}
+
+	reports, err := h.svc.GetOwnerUsage(c.Request.Context(), appUsage.GetOwnerUsageInput{
+		Email: principal.Email,
+		KeyID: c.Query("key_id"),
+	})
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "APIキー", Message: "利用状況の取得に失敗しました"})
+		return
+	}
+
+	responses := make([]KeyUsageReportResponse, 0, len(reports))
+	for _, r := range reports {
+		responses = append(responses, toKeyUsageReportResponse(r))
+	}
+
+	if format == "csv" {
+		h.writeCSV(c, responses, section)
+		return
+	}
+
+	resp := gin.H{
+		"data":  responses,
+		"count": len(responses),
+	}
+	if len(reports) > 0 {
+		resp["period_from"] = reports[0].PeriodFrom.Format(time.RFC3339)
+		resp["period_to"] = reports[0].PeriodTo.Format(time.RFC3339)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func toKeyUsageReportResponse(r *appUsage.KeyUsageReport) KeyUsageReportResponse {
+	resp := KeyUsageReportResponse{
+		KeyID:         r.Key.ID(),
+		MaskedKey:     r.Key.MaskedKey(),
+		Name:          r.Key.Name(),
+		PlanType:      string(r.Key.PlanType()),
+		IsActive:      r.Key.IsActive(),
+		YearMonth:     r.Monthly.YearMonth(),
+		MonthlyCount:  r.Monthly.Count(),
+		MonthlyLimit:  r.Monthly.Limit(),
+		Remaining:     r.Monthly.Remaining(),
+		OverageActive: r.Key.OverageActive(),
+		OverageCount:  r.Overage.Count(),
+		TotalRequests: r.Detail.TotalRequests,
+		ErrorCount:    r.Detail.ErrorCount,
+		ErrorRate:     r.Detail.ErrorRate(),
+		LatencyP50Ms:  r.Detail.LatencyPercentile(50),
+		LatencyP95Ms:  r.Detail.LatencyPercentile(95),
+		Daily:         make([]DailyUsageResponse, 0, len(r.Detail.Daily)),
+		TopEndpoints:  make([]EndpointUsageResponse, 0, len(r.Detail.TopEndpoints)),
+	}
+	for _, d := range r.Detail.Daily {
+		resp.Daily = append(resp.Daily, DailyUsageResponse{
+			Date:     d.Date,
+			Requests: d.Requests,
+			Errors:   d.Errors,
+		})
+	}
+	for _, e := range r.Detail.TopEndpoints {
+		resp.TopEndpoints = append(resp.TopEndpoints, EndpointUsageResponse{
+			Method:       e.Method,
+			Endpoint:     e.Endpoint,
+			Requests:     e.Requests,
+			Errors:       e.Errors,
+			AvgLatencyMs: e.AvgLatencyMs,
+		})
+	}
+	return resp
+}
+
+func (h *UsageHandler) writeCSV(c *gin.Context, reports []KeyUsageReportResponse, section string) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	switch section {
+	case "summary":
+		_ = w.Write([]string{"key_id", "masked_key", "plan_type", "year_month", "monthly_count", "monthly_limit", "remaining", "total_requests", "error_count", "error_rate", "latency_p50_ms", "latency_p95_ms"})
+		for _, r := range reports {
+			_ = w.Write([]string{
+				r.KeyID,
+				r.MaskedKey,
+				r.PlanType,
+				r.YearMonth,
+				strconv.Itoa(r.MonthlyCount),
+				strconv.Itoa(r.MonthlyLimit),
+				strconv.Itoa(r.Remaining),
+				strconv.FormatInt(r.TotalRequests, 10),
+				strconv.FormatInt(r.ErrorCount, 10),
+				strconv.FormatFloat(r.ErrorRate, 'f', 4, 64),
+				strconv.FormatInt(r.LatencyP50Ms, 10),
+				strconv.FormatInt(r.LatencyP95Ms, 10),
+			})
+		}
+	case "endpoints":
+		_ = w.Write([]string{"key_id", "masked_key", "method", "endpoint", "requests", "errors", "avg_latency_ms"})
+		for _, r := range reports {
+			for _, e := range r.TopEndpoints {
+				_ = w.Write([]string{
+					r.KeyID,
+					r.MaskedKey,
+					e.Method,
+					e.Endpoint,
+					strconv.FormatInt(e.Requests, 10),
+					strconv.FormatInt(e.Errors, 10),
+					strconv.FormatFloat(e.AvgLatencyMs, 'f', 1, 64),
+				})
+			}
+		}
+	default:
+		_ = w.Write([]string{"key_id", "masked_key", "date", "requests", "errors"})
+		for _, r := range reports {
+			for _, d := range r.Daily {
+				_ = w.Write([]string{
+					r.KeyID,
+					r.MaskedKey,
+					d.Date,
+					strconv.FormatInt(d.Requests, 10),
+					strconv.FormatInt(d.Errors, 10),
+				})
+			}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		c.JSON(http.StatusInternalServerError, middleware.NewInternalError("CSVの生成に失敗しました"))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="usage_%s_%s.csv"`, section, time.Now().UTC().Format("2006-01")))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
@@ -5,9 +5,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/kuro48/idol-api/internal/interface/middleware"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 	"github.com/kuro48/idol-api/internal/usecase/venue"
 )
 
+// venueFields は fields パラメータで指定できる会場のフィールド
+var venueFields = fieldset.SchemaOf(venue.VenueDTO{})
+
 type VenueHandler struct {
 	usecase venue.VenueUseCase
 }
@@ -46,6 +50,7 @@ func (h *VenueHandler) CreateVenue(c *gin.Context) {
 // @Tags         venues
 // @Produce      json
 // @Param        id path string true "会場ID"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} venue.VenueDTO
 // @Failure      404 {object} middleware.ErrorResponse
 // @Router       /venues/{id} [get]
@@ -54,6 +59,10 @@ func (h *VenueHandler) GetVenue(c *gin.Context) {
 	if !ok {
 		return
 	}
+	sel, ok := getFields(c, venueFields)
+	if !ok {
+		return
+	}
 
 	dto, err := h.usecase.GetVenue(c.Request.Context(), venue.GetVenueQuery{ID: id})
 	if err != nil {
@@ -61,7 +70,7 @@ func (h *VenueHandler) GetVenue(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto)
+	writeFields(c, http.StatusOK, sel, dto)
 }
 
 // ListVenues は会場一覧を取得する
@@ -70,15 +79,24 @@ func (h *VenueHandler) GetVenue(c *gin.Context) {
 // @Produce      json
 // @Param        name       query string false "会場名（部分一致）"
 // @Param        prefecture query string false "都道府県"
+// @Param        near       query string false "中心座標（緯度,経度）。指定時は近い順に並べ distance_km を返す"
+// @Param        radius_km  query number false "near からの検索半径（km、既定10、上限100）"
+// @Param        fields query string false "レスポンスに含めるフィールド (カンマ区切り。fields[<フィールド>]=... で入れ子も絞り込み可)"
 // @Success      200 {object} venue.VenueSearchResult
 // @Failure      400 {object} middleware.ErrorResponse
 // @Router       /venues [get]
 func (h *VenueHandler) ListVenues(c *gin.Context) {
+	sel, ok := getFields(c, venueFields)
+	if !ok {
+		return
+	}
+
 	var query venue.ListVenueQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
 		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("無効なクエリパラメータです"))
 		return
 	}
+	query.Fields = sel.Requested()
 
 	result, err := h.usecase.ListVenues(c.Request.Context(), query)
 	if err != nil {
@@ -86,7 +104,7 @@ func (h *VenueHandler) ListVenues(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	writeFieldsList(c, http.StatusOK, sel, result)
 }
 
 // UpdateVenue は会場を更新する
@@ -120,6 +138,41 @@ func (h *VenueHandler) UpdateVenue(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "会場が更新されました"})
 }
 
+// UpdateVenueConfigurations は会場の収容構成を置き換える
+// @Summary      会場の収容構成の更新
+// @Description  スタンディング・着席・センターステージなどレイアウトごとの収容人数を一覧で置き換える。既存の構成は id を指定するとイベントからの参照を保ったまま更新できる
+// @Tags         venues
+// @Accept       json
+// @Produce      json
+// @Param        id      path string true "会場ID"
+// @Param        request body venue.UpdateVenueConfigurationsCommand true "収容構成（最大20件）"
+// @Success      200 {object} venue.VenueDTO
+// @Failure      400 {object} middleware.ErrorResponse
+// @Failure      404 {object} middleware.ErrorResponse
+// @Failure      409 {object} middleware.ErrorResponse
+// @Router       /venues/{id}/configurations [put]
+func (h *VenueHandler) UpdateVenueConfigurations(c *gin.Context) {
+	id, ok := getPathID(c)
+	if !ok {
+		return
+	}
+
+	var cmd venue.UpdateVenueConfigurationsCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+	cmd.VenueID = id
+
+	dto, err := h.usecase.UpdateVenueConfigurations(middleware.AuditContextFor(c), cmd)
+	if err != nil {
+		middleware.WriteError(c, err, middleware.ErrorContext{Resource: "会場", Message: "収容構成の更新に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
 // DeleteVenue は会場を削除する
 // @Summary      会場削除
 // @Tags         venues
@@ -140,3 +193,45 @@ func (h *VenueHandler) DeleteVenue(c *gin.Context) {
 
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// VenueLocationItem は座標一括登録の1件分
+type VenueLocationItem struct {
+	VenueID   string   `json:"venue_id" binding:"required"`
+	Latitude  *float64 `json:"latitude" binding:"required"`
+	Longitude *float64 `json:"longitude" binding:"required"`
+}
+
+// UpdateVenueLocationsRequest は会場の座標一括登録リクエスト
+type UpdateVenueLocationsRequest struct {
+	Items []VenueLocationItem `json:"items" binding:"required,min=1,max=1000,dive"`
+}
+
+// UpdateVenueLocations は会場の座標を一括登録する
+// @Summary      会場座標の一括登録
+// @Description  手入力や外部データから用意した緯度・経度をまとめて登録する。1件ごとに検証し、失敗した行は errors に返す
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request body UpdateVenueLocationsRequest true "座標一括登録リクエスト（最大1000件）"
+// @Success      200 {object} venue.VenueLocationsResult
+// @Failure      400 {object} middleware.ErrorResponse
+// @Router       /admin/venues/locations [put]
+func (h *VenueHandler) UpdateVenueLocations(c *gin.Context) {
+	var req UpdateVenueLocationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.NewBadRequestError("リクエストが不正です: "+err.Error()))
+		return
+	}
+
+	items := make([]venue.VenueLocationInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, venue.VenueLocationInput{
+			VenueID:   item.VenueID,
+			Latitude:  *item.Latitude,
+			Longitude: *item.Longitude,
+		})
+	}
+
+	result := h.usecase.UpdateVenueLocations(middleware.AuditContextFor(c), venue.UpdateVenueLocationsCommand{Items: items})
+	c.JSON(http.StatusOK, result)
+}
@@ -22,15 +22,25 @@ const (
 	CtxPlanPrefix = "plan_prefix"
 	// CtxPlanEmail はGinコンテキストに格納するプラン所有者メールアドレスのキー
 	CtxPlanEmail = "plan_email"
+	// CtxPlanOverage はGinコンテキストに格納する「上限超過分を従量課金で受け付けた」フラグのキー
+	CtxPlanOverage = "plan_overage"
 
 	// planAuthTimeout はDB問い合わせのタイムアウト
 	planAuthTimeout = 3 * time.Second
 )
 
+// UsageThresholdAlerter は月次使用量のしきい値到達を通知する契約（非ブロッキングで実装すること）
+type UsageThresholdAlerter interface {
+	CheckThresholds(ctx context.Context, key *domainapikey.APIKey, monthly *domainusage.MonthlyUsage)
+}
+
 // PlanAuthMiddleware はプランベースのAPIキー認証と月次使用量制限を行うミドルウェア
 type PlanAuthMiddleware struct {
 	apikeyRepo domainapikey.Repository
 	usageRepo  domainusage.Repository
+	alerter    UsageThresholdAlerter
+	// overageHardCap は従量課金キーの月間リクエスト総数のシステム上限（0は無制限）
+	overageHardCap int
 }
 
 // NewPlanAuth は PlanAuthMiddleware を作成する
@@ -41,6 +51,21 @@ func NewPlanAuth(apikeyRepo domainapikey.Repository, usageRepo domainusage.Repos
 	}
 }
 
+// NewPlanAuthWithAlerter はしきい値到達通知付きの PlanAuthMiddleware を作成する
+func NewPlanAuthWithAlerter(apikeyRepo domainapikey.Repository, usageRepo domainusage.Repository, alerter UsageThresholdAlerter) *PlanAuthMiddleware {
+	return &PlanAuthMiddleware{
+		apikeyRepo: apikeyRepo,
+		usageRepo:  usageRepo,
+		alerter:    alerter,
+	}
+}
+
+// WithOverageHardCap は従量課金キーに適用する月間リクエスト総数のシステム上限を設定する
+func (m *PlanAuthMiddleware) WithOverageHardCap(hardCap int) *PlanAuthMiddleware {
+	m.overageHardCap = hardCap
+	return m
+}
+
 // Auth はAPIキー認証 + プラン制限を行うミドルウェア関数を返す
 // Authorization: Bearer <api_key> ヘッダーからキーを取得する（キーなしは 401）
 func (m *PlanAuthMiddleware) Auth() gin.HandlerFunc {
@@ -82,23 +107,82 @@ func (m *PlanAuthMiddleware) Auth() gin.HandlerFunc {
 			return
 		}
 
+		// しきい値（80/95/100%）を跨いだ場合は通知する（上限到達で拒否するリクエストも含む）
+		if m.alerter != nil {
+			m.alerter.CheckThresholds(c.Request.Context(), apiKey, usage)
+		}
+
+		overage := false
 		if usage.ExceedsLimit() {
-			c.JSON(http.StatusTooManyRequests, ErrorResponse{
-				Code:    "PLAN_LIMIT_EXCEEDED",
-				Message: "月間リクエスト上限に達しました。プランのアップグレードをご検討ください。",
-			})
-			c.Abort()
-			return
+			if !apiKey.OverageActive() {
+				c.JSON(http.StatusTooManyRequests, ErrorResponse{
+					Code:    "PLAN_LIMIT_EXCEEDED",
+					Message: "月間リクエスト上限に達しました。プランのアップグレードをご検討ください。",
+				})
+				c.Abort()
+				return
+			}
+
+			// 従量課金キーは上限超過後も受け付けるが、総数の上限は超えられない
+			if hardCap := apiKey.OverageHardCap(m.overageHardCap); hardCap > 0 && usage.Count() > hardCap {
+				c.JSON(http.StatusTooManyRequests, ErrorResponse{
+					Code:    "OVERAGE_HARD_CAP_EXCEEDED",
+					Message: "従量課金の月間リクエスト上限に達しました。",
+				})
+				c.Abort()
+				return
+			}
+
+			if err := m.usageRepo.IncrementOverage(ctx, apiKey.Prefix(), yearMonth); err != nil {
+				slog.Error("超過使用量カウントエラー", "error", err)
+				c.JSON(http.StatusInternalServerError, NewInternalError("使用量の記録に失敗しました"))
+				c.Abort()
+				return
+			}
+			overage = true
+			c.Header("X-Usage-Overage", "true")
 		}
 
 		c.Set(CtxKeyPlanType, string(apiKey.PlanType()))
 		c.Set(CtxKeyWriteEnabled, limits.WriteEnabled)
 		c.Set(CtxPlanPrefix, apiKey.Prefix())
 		c.Set(CtxPlanEmail, apiKey.Email())
+		c.Set(CtxPlanOverage, overage)
 		c.Next()
 	}
 }
 
+// Identify は公開エンドポイント向けにAPIキーからプラン種別だけを判定するミドルウェア関数を返す。
+// キーがない・一致しない場合も拒否せず匿名（Free 相当）として扱い、使用量もカウントしない
+func (m *PlanAuthMiddleware) Identify() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := extractBearerToken(c)
+		if !strings.HasPrefix(rawKey, domainapikey.KeyPrefix) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), planAuthTimeout)
+		defer cancel()
+
+		candidates, err := m.apikeyRepo.FindByPrefix(ctx, domainapikey.PrefixOf(rawKey))
+		if err != nil {
+			slog.Warn("APIキー検索エラー（匿名として処理を継続）", "error", err)
+			c.Next()
+			return
+		}
+		if apiKey := findMatchingKey(candidates, rawKey); apiKey != nil {
+			c.Set(CtxKeyPlanType, string(apiKey.PlanType()))
+		}
+		c.Next()
+	}
+}
+
+// IncludeLimitsOf はコンテキストのプラン種別に応じた include 展開の上限を返す（未判定は Free 扱い）
+func IncludeLimitsOf(c *gin.Context) plan.IncludeLimits {
+	return plan.GetLimits(plan.Type(c.GetString(CtxKeyPlanType))).Include
+}
+
 // RequireWrite は write スコープが必要なエンドポイント用ミドルウェアを返す
 // PlanAuth.Auth() の後に使用する
 func RequireWrite() gin.HandlerFunc {
@@ -34,8 +34,9 @@ func (r *stubAPIKeyRepo) FindByEmail(_ context.Context, _ string) ([]*domainapik
 func (r *stubAPIKeyRepo) Update(_ context.Context, _ *domainapikey.APIKey) error { return nil }
 
 type stubUsageRepo struct {
-	usage *domainusage.MonthlyUsage
-	err   error
+	usage             *domainusage.MonthlyUsage
+	err               error
+	overageIncrements int
 }
 
 func (r *stubUsageRepo) IncrementAndGet(_ context.Context, _, _ string, _ int) (*domainusage.MonthlyUsage, error) {
@@ -44,6 +45,33 @@ func (r *stubUsageRepo) IncrementAndGet(_ context.Context, _, _ string, _ int) (
 func (r *stubUsageRepo) Get(_ context.Context, _, _ string, _ int) (*domainusage.MonthlyUsage, error) {
 	return r.usage, r.err
 }
+func (r *stubUsageRepo) ClaimThreshold(_ context.Context, _, _ string, _ int) (bool, error) {
+	return true, r.err
+}
+func (r *stubUsageRepo) IncrementOverage(_ context.Context, _, _ string) error {
+	r.overageIncrements++
+	return r.err
+}
+func (r *stubUsageRepo) GetOverage(_ context.Context, keyPrefix, yearMonth string) (*domainusage.OverageUsage, error) {
+	return domainusage.ReconstructOverage(keyPrefix, yearMonth, r.overageIncrements, 0, nil), r.err
+}
+func (r *stubUsageRepo) ListUnreportedOverages(_ context.Context, _ string) ([]*domainusage.OverageUsage, error) {
+	return nil, r.err
+}
+func (r *stubUsageRepo) ClaimOverageReport(_ context.Context, _, _ string, _ domainusage.PendingOverageReport) (bool, error) {
+	return true, r.err
+}
+func (r *stubUsageRepo) CompleteOverageReport(_ context.Context, _, _, _ string) error {
+	return r.err
+}
+
+type stubAlerter struct {
+	calls []*domainusage.MonthlyUsage
+}
+
+func (a *stubAlerter) CheckThresholds(_ context.Context, _ *domainapikey.APIKey, monthly *domainusage.MonthlyUsage) {
+	a.calls = append(a.calls, monthly)
+}
 
 // --- ヘルパー ---
 
@@ -59,11 +87,11 @@ func newTestAPIKey(t *testing.T) *domainapikey.APIKey {
 }
 
 func withinLimitUsage() *domainusage.MonthlyUsage {
-	return domainusage.Reconstruct("ik_live_aabbccdd", "2026-04", 1, 1000, time.Now())
+	return domainusage.Reconstruct("ik_live_aabbccdd", "2026-04", 1, 1000, nil, time.Now())
 }
 
 func atLimitUsage() *domainusage.MonthlyUsage {
-	return domainusage.Reconstruct("ik_live_aabbccdd", "2026-04", 1000, 1000, time.Now())
+	return domainusage.Reconstruct("ik_live_aabbccdd", "2026-04", 1000, 1000, nil, time.Now())
 }
 
 func newRouter(mw ...gin.HandlerFunc) *gin.Engine {
@@ -104,6 +132,121 @@ func TestAuth_ValidToken_PassesThrough(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestAuth_AtLimit_AlertsBeforeRejecting(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	alerter := &stubAlerter{}
+	m := middleware.NewPlanAuthWithAlerter(
+		&stubAPIKeyRepo{keys: []*domainapikey.APIKey{newTestAPIKey(t)}},
+		&stubUsageRepo{usage: atLimitUsage()},
+		alerter,
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+testRawKey)
+	w := httptest.NewRecorder()
+	newRouter(m.Auth()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Len(t, alerter.calls, 1)
+	assert.Equal(t, []int{80, 95, 100}, alerter.calls[0].PendingThresholds())
+}
+
+func newBusinessOverageKey(t *testing.T, hardCap int) *domainapikey.APIKey {
+	t.Helper()
+	k, err := domainapikey.New("aabbccddeeff001122334455", testRawKey, "biz@example.com", "biz", "business")
+	if err != nil {
+		t.Fatalf("APIKey作成失敗: %v", err)
+	}
+	if err := k.EnableOverage(hardCap); err != nil {
+		t.Fatalf("超過利用の有効化失敗: %v", err)
+	}
+	return k
+}
+
+func TestAuth_OverageEnabled_AllowsBeyondLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	usageRepo := &stubUsageRepo{usage: domainusage.Reconstruct("ik_live_aabbccdd", "2026-04", 500_001, 500_000, nil, time.Now())}
+	m := middleware.NewPlanAuth(
+		&stubAPIKeyRepo{keys: []*domainapikey.APIKey{newBusinessOverageKey(t, 0)}},
+		usageRepo,
+	).WithOverageHardCap(1_000_000)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+testRawKey)
+	w := httptest.NewRecorder()
+	newRouter(m.Auth()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("X-Usage-Overage"))
+	assert.Equal(t, 1, usageRepo.overageIncrements)
+}
+
+func TestAuth_OverageHardCapExceeded_Returns429(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	usageRepo := &stubUsageRepo{usage: domainusage.Reconstruct("ik_live_aabbccdd", "2026-04", 600_001, 500_000, nil, time.Now())}
+	m := middleware.NewPlanAuth(
+		&stubAPIKeyRepo{keys: []*domainapikey.APIKey{newBusinessOverageKey(t, 600_000)}},
+		usageRepo,
+	).WithOverageHardCap(1_000_000)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+testRawKey)
+	w := httptest.NewRecorder()
+	newRouter(m.Auth()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), "OVERAGE_HARD_CAP_EXCEEDED")
+	assert.Equal(t, 0, usageRepo.overageIncrements)
+}
+
+// --- Identify ---
+
+func newIdentifyRouter(m *middleware.PlanAuthMiddleware, limits *int) *gin.Engine {
+	router := gin.New()
+	router.Use(m.Identify())
+	router.GET("/test", func(c *gin.Context) {
+		*limits = middleware.IncludeLimitsOf(c).MaxTargets
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestIdentify_ValidToken_SetsPlanLimits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	key, err := domainapikey.New("aabbccddeeff001122334455", testRawKey, "test@example.com", "test", "developer")
+	if err != nil {
+		t.Fatalf("APIKey作成失敗: %v", err)
+	}
+	m := middleware.NewPlanAuth(&stubAPIKeyRepo{keys: []*domainapikey.APIKey{key}}, &stubUsageRepo{})
+
+	var maxTargets int
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+testRawKey)
+	w := httptest.NewRecorder()
+	newIdentifyRouter(m, &maxTargets).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 5, maxTargets)
+}
+
+func TestIdentify_NoOrUnknownToken_FallsBackToFree(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := middleware.NewPlanAuth(&stubAPIKeyRepo{}, &stubUsageRepo{})
+
+	for _, header := range []string{"", "Bearer " + testRawKey, "Bearer eyJhbGciOi"} {
+		var maxTargets int
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		newIdentifyRouter(m, &maxTargets).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, maxTargets)
+	}
+}
+
 // --- RequireWrite ---
 
 func TestRequireWrite_WithWriteEnabled_PassesThrough(t *testing.T) {
@@ -36,6 +36,7 @@ func UsageTrackerMiddleware(svc usageRecorder) gin.HandlerFunc {
 
 		record := &domainAnalytics.APIUsageRecord{
 			MaskedKey:  maskedKey,
+			KeyPrefix:  c.GetString(CtxPlanPrefix),
 			Endpoint:   path,
 			Method:     c.Request.Method,
 			StatusCode: c.Writer.Status(),
@@ -0,0 +1,285 @@
+// Package fieldset は fields パラメータによる疎なフィールド指定（sparse fieldsets）を扱う。
+// 指定できるフィールドは DTO の json タグから導出し、レスポンスは JSON 表現の段階で射影する。
+package fieldset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// idField は指定の有無にかかわらず常に返すフィールド
+const idField = "id"
+
+// Schema はレスポンスDTOの JSON フィールド構成
+type Schema struct {
+	fields []string
+	nested map[string]*Schema // 構造体を値に持つフィールド（関連データ・収録曲など）の構成
+}
+
+// SchemaOf は DTO の json タグからスキーマを生成する。
+// 構造体（およびそのポインタ・スライス）を値に持つフィールドは fields[<フィールド名>] で絞り込める入れ子のスキーマになる
+func SchemaOf(dto any) *Schema {
+	return schemaOfType(reflect.TypeOf(dto))
+}
+
+// Fields は名前を列挙してスキーマを生成する（型から構成を導けない map 等の値に使う）
+func Fields(names ...string) *Schema {
+	return &Schema{fields: names, nested: map[string]*Schema{}}
+}
+
+// WithNested は入れ子のスキーマを追加・上書きしたスキーマを返す
+func (s *Schema) WithNested(name string, nested *Schema) *Schema {
+	copied := &Schema{fields: s.fields, nested: make(map[string]*Schema, len(s.nested)+1)}
+	for k, v := range s.nested {
+		copied.nested[k] = v
+	}
+	copied.nested[name] = nested
+	if !copied.has(name) {
+		copied.fields = append(append([]string{}, s.fields...), name)
+	}
+	return copied
+}
+
+func (s *Schema) has(field string) bool {
+	for _, f := range s.fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func schemaOfType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	s := &Schema{nested: map[string]*Schema{}}
+	if t.Kind() != reflect.Struct {
+		return s
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if !f.IsExported() || name == "" || name == "-" {
+			continue
+		}
+		s.fields = append(s.fields, name)
+		if elem := structElem(f.Type); elem != nil {
+			s.nested[name] = schemaOfType(elem)
+		}
+	}
+	return s
+}
+
+// structElem はポインタ・スライスを剥がした先が構造体ならその型を返す。
+// time.Time など独自の JSON 表現を持つ型は単一の値として扱う
+func structElem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return nil
+	}
+	return t
+}
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// Selection は検証済みのフィールド指定
+type Selection struct {
+	top    map[string]bool            // nil は全フィールド
+	nested map[string]map[string]bool // 入れ子のフィールド指定（指定のないものは全フィールド）
+}
+
+// Parse は fields（トップレベル）と fields[<フィールド名>]（入れ子）の指定をスキーマに照らして検証する。
+// keep は fields の指定にかかわらず返すフィールド（include で明示的に展開を求められた関連データ等）
+func Parse(top string, nested map[string]string, keep []string, schema *Schema) (*Selection, error) {
+	sel := &Selection{}
+
+	if names, err := parseNames(top, schema); err != nil {
+		return nil, err
+	} else if len(names) > 0 {
+		sel.top = map[string]bool{idField: true}
+		for _, n := range names {
+			sel.top[n] = true
+		}
+		for _, k := range keep {
+			if k = strings.TrimSpace(k); schema.has(k) {
+				sel.top[k] = true
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(nested))
+	for k := range nested {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		child, ok := schema.nested[key]
+		if !ok {
+			return nil, fmt.Errorf("無効な fields 指定です: fields[%s] は絞り込みできるフィールドではありません", key)
+		}
+		names, err := parseNames(nested[key], child)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			continue
+		}
+		if sel.nested == nil {
+			sel.nested = make(map[string]map[string]bool)
+		}
+		set := make(map[string]bool, len(names))
+		for _, n := range names {
+			set[n] = true
+		}
+		sel.nested[key] = set
+		// 入れ子を絞り込んだフィールド自体はトップレベルの指定に含まれているものとみなす
+		if sel.top != nil {
+			sel.top[key] = true
+		}
+	}
+	return sel, nil
+}
+
+func parseNames(raw string, schema *Schema) ([]string, error) {
+	var names []string
+	for _, n := range strings.Split(raw, ",") {
+		n = strings.TrimSpace(n)
+		if n == "" {
+			continue
+		}
+		if !schema.has(n) {
+			return nil, fmt.Errorf("無効な fields 指定です: %s（指定可能: %s）", n, strings.Join(schema.fields, ","))
+		}
+		names = append(names, n)
+	}
+	return names, nil
+}
+
+// IsAll は絞り込みの指定がないかを返す
+func (s *Selection) IsAll() bool {
+	return s == nil || (s.top == nil && s.nested == nil)
+}
+
+// Has はトップレベルのフィールドがレスポンスに含まれるかを返す
+func (s *Selection) Has(field string) bool {
+	return s == nil || s.top == nil || s.top[field]
+}
+
+// Requested はトップレベルで指定されたフィールドを返す（指定がなければ nil）
+func (s *Selection) Requested() []string {
+	if s == nil || s.top == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.top))
+	for n := range s.top {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Omitted は指定されたフィールド fields に含まれない任意属性を返す（指定がなければ何も省略しない）。
+// 任意属性の取得を省くリポジトリの検索条件に使う
+func Omitted(fields []string, optional ...string) []string {
+	if fields == nil {
+		return nil
+	}
+	var result []string
+	for _, o := range optional {
+		if !slices.Contains(fields, o) {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// Apply は値を JSON 表現に変換し、指定されたフィールドだけを残した値を返す
+func (s *Selection) Apply(v any) (any, error) {
+	if s.IsAll() {
+		return v, nil
+	}
+	generic, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	return s.project(generic), nil
+}
+
+// ApplyList は一覧レスポンスの key 配下の各要素に射影を適用する（ページネーション情報等はそのまま残す）
+func (s *Selection) ApplyList(v any, key string) (any, error) {
+	if s.IsAll() {
+		return v, nil
+	}
+	generic, err := toGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	if envelope, ok := generic.(map[string]any); ok {
+		envelope[key] = s.project(envelope[key])
+		return envelope, nil
+	}
+	return s.project(generic), nil
+}
+
+// project はオブジェクトまたはオブジェクトの配列に射影を適用する
+func (s *Selection) project(v any) any {
+	switch value := v.(type) {
+	case []any:
+		for i, item := range value {
+			value[i] = s.project(item)
+		}
+		return value
+	case map[string]any:
+		for k, child := range value {
+			if s.top != nil && !s.top[k] {
+				delete(value, k)
+				continue
+			}
+			if fields, ok := s.nested[k]; ok {
+				value[k] = filterKeys(child, fields)
+			}
+		}
+		return value
+	}
+	return v
+}
+
+// filterKeys は入れ子のオブジェクト（またはその配列）から指定外のキーを取り除く
+func filterKeys(v any, fields map[string]bool) any {
+	switch value := v.(type) {
+	case []any:
+		for i, item := range value {
+			value[i] = filterKeys(item, fields)
+		}
+	case map[string]any:
+		for k := range value {
+			if !fields[k] && k != idField {
+				delete(value, k)
+			}
+		}
+	}
+	return v
+}
+
+func toGeneric(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("レスポンスの変換エラー: %w", err)
+	}
+	// 数値は元の表記のまま返すため json.Number で受ける
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("レスポンスの変換エラー: %w", err)
+	}
+	return generic, nil
+}
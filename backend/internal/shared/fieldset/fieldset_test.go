@@ -0,0 +1,127 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type trackDTO struct {
+	Number int    `json:"track_number"`
+	Title  string `json:"title"`
+}
+
+type agencyDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type releaseDTO struct {
+	ID     string     `json:"id"`
+	At     *time.Time `json:"at,omitempty"`
+	Title  string     `json:"title"`
+	Date   string     `json:"release_date"`
+	Tracks []trackDTO `json:"tracks,omitempty"`
+	Agency *agencyDTO `json:"agency,omitempty"`
+	hidden string
+}
+
+type listResult struct {
+	Data []releaseDTO   `json:"data"`
+	Meta map[string]int `json:"meta"`
+}
+
+func sample() releaseDTO {
+	return releaseDTO{
+		ID:     "r1",
+		Title:  "1st Single",
+		Date:   "2024-04-01",
+		Tracks: []trackDTO{{Number: 1, Title: "A"}, {Number: 2, Title: "B"}},
+		Agency: &agencyDTO{ID: "a1", Name: "事務所"},
+	}
+}
+
+func toJSON(t *testing.T, v any) string {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(raw)
+}
+
+func TestSchemaOf_DerivesFieldsAndNested(t *testing.T) {
+	s := SchemaOf(&releaseDTO{})
+
+	assert.Equal(t, []string{"id", "at", "title", "release_date", "tracks", "agency"}, s.fields)
+	assert.Contains(t, s.nested, "tracks")
+	assert.Contains(t, s.nested, "agency")
+	assert.NotContains(t, s.nested, "at")
+}
+
+func TestParse_RejectsUnknownFields(t *testing.T) {
+	schema := SchemaOf(releaseDTO{})
+
+	_, err := Parse("id,price", nil, nil, schema)
+	assert.ErrorContains(t, err, "無効な fields 指定です: price")
+
+	_, err = Parse("", map[string]string{"title": "x"}, nil, schema)
+	assert.ErrorContains(t, err, "fields[title]")
+
+	_, err = Parse("", map[string]string{"tracks": "lyrics"}, nil, schema)
+	assert.ErrorContains(t, err, "無効な fields 指定です: lyrics")
+}
+
+func TestApply_ProjectsTopLevelAndNested(t *testing.T) {
+	sel, err := Parse("title", map[string]string{"tracks": "title"}, nil, SchemaOf(releaseDTO{}))
+	require.NoError(t, err)
+
+	got, err := sel.Apply(sample())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"id":"r1","title":"1st Single","tracks":[{"title":"A"},{"title":"B"}]}`, toJSON(t, got))
+}
+
+func TestApply_KeepsExplicitIncludes(t *testing.T) {
+	sel, err := Parse("title", map[string]string{"agency": "name"}, nil, SchemaOf(releaseDTO{}))
+	require.NoError(t, err)
+	got, err := sel.Apply(sample())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"r1","title":"1st Single","agency":{"id":"a1","name":"事務所"}}`, toJSON(t, got))
+
+	sel, err = Parse("title", nil, []string{"agency"}, SchemaOf(releaseDTO{}))
+	require.NoError(t, err)
+	got, err = sel.Apply(sample())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"r1","title":"1st Single","agency":{"id":"a1","name":"事務所"}}`, toJSON(t, got))
+}
+
+func TestApplyList_LeavesEnvelopeIntact(t *testing.T) {
+	sel, err := Parse("release_date", nil, nil, SchemaOf(releaseDTO{}))
+	require.NoError(t, err)
+
+	got, err := sel.ApplyList(listResult{Data: []releaseDTO{sample()}, Meta: map[string]int{"total": 1}}, "data")
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"data":[{"id":"r1","release_date":"2024-04-01"}],"meta":{"total":1}}`, toJSON(t, got))
+}
+
+func TestSelection_NoFieldsReturnsValueAsIs(t *testing.T) {
+	sel, err := Parse(" ", nil, []string{"agency"}, SchemaOf(releaseDTO{}))
+	require.NoError(t, err)
+
+	assert.True(t, sel.IsAll())
+	assert.True(t, sel.Has("tracks"))
+	assert.Nil(t, sel.Requested())
+	v := sample()
+	got, err := sel.Apply(v)
+	require.NoError(t, err)
+	assert.Equal(t, v, got)
+}
+
+func TestOmitted_ReturnsOptionalFieldsNotRequested(t *testing.T) {
+	assert.Nil(t, Omitted(nil, "tracks", "editions"))
+	assert.Equal(t, []string{"editions"}, Omitted([]string{"id", "tracks"}, "tracks", "editions"))
+	assert.Empty(t, Omitted([]string{"tracks", "editions"}, "tracks", "editions"))
+}
@@ -0,0 +1,87 @@
+// Package geo は緯度・経度の座標と2点間の距離計算を提供する。
+// 外部のジオコーディングサービスには依存せず、座標は手入力またはインポートで登録する前提。
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// EarthRadiusKm は距離計算に使う地球の半径（MongoDB の球面計算と同じ値）
+const EarthRadiusKm = 6378.1
+
+// Point は緯度・経度の座標（値オブジェクト）
+type Point struct {
+	latitude  float64
+	longitude float64
+}
+
+// NewPoint は座標を生成する
+func NewPoint(latitude, longitude float64) (Point, error) {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return Point{}, errors.New("緯度は-90〜90の範囲で指定してください（無効な座標）")
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return Point{}, errors.New("経度は-180〜180の範囲で指定してください（無効な座標）")
+	}
+	return Point{latitude: latitude, longitude: longitude}, nil
+}
+
+// Parse は "緯度,経度" 形式の文字列から座標を生成する
+func Parse(s string) (Point, error) {
+	lat, lng, ok := strings.Cut(s, ",")
+	if !ok {
+		return Point{}, errors.New("座標の形式が不正です: 緯度,経度 で指定してください")
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return Point{}, errors.New("座標の形式が不正です: 緯度,経度 で指定してください")
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if err != nil {
+		return Point{}, errors.New("座標の形式が不正です: 緯度,経度 で指定してください")
+	}
+	return NewPoint(latitude, longitude)
+}
+
+func (p Point) Latitude() float64  { return p.latitude }
+func (p Point) Longitude() float64 { return p.longitude }
+
+// DistanceKm は2点間の大圏距離（km）を返す
+func (p Point) DistanceKm(other Point) float64 {
+	lat1, lat2 := p.latitude*math.Pi/180, other.latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (other.longitude - p.longitude) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// RoundKm は距離を小数第2位（10m単位）に丸める
+func RoundKm(km float64) float64 {
+	return math.Round(km*100) / 100
+}
+
+// DefaultSearchRadiusKm は近傍検索で半径を省略した場合の既定値
+const DefaultSearchRadiusKm = 10.0
+
+// MaxSearchRadiusKm は近傍検索で指定できる半径の上限
+const MaxSearchRadiusKm = 100.0
+
+// ParseSearchArea は near（"緯度,経度"）と radius_km クエリから近傍検索の中心と半径を返す
+func ParseSearchArea(near string, radiusKm *float64) (Point, float64, error) {
+	center, err := Parse(near)
+	if err != nil {
+		return Point{}, 0, err
+	}
+	radius := DefaultSearchRadiusKm
+	if radiusKm != nil {
+		radius = *radiusKm
+	}
+	if radius <= 0 || radius > MaxSearchRadiusKm {
+		return Point{}, 0, fmt.Errorf("無効な検索半径です: 0より大きく%gkm以下で指定してください", MaxSearchRadiusKm)
+	}
+	return center, radius, nil
+}
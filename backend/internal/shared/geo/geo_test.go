@@ -0,0 +1,48 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	p, err := Parse("35.6932, 139.7500")
+	require.NoError(t, err)
+	assert.Equal(t, 35.6932, p.Latitude())
+	assert.Equal(t, 139.75, p.Longitude())
+
+	_, err = Parse("35.6932")
+	assert.ErrorContains(t, err, "形式が不正")
+	_, err = Parse("abc,139")
+	assert.ErrorContains(t, err, "形式が不正")
+	_, err = Parse("95,139")
+	assert.ErrorContains(t, err, "無効な座標")
+	_, err = Parse("35,181")
+	assert.ErrorContains(t, err, "無効な座標")
+}
+
+func TestPoint_DistanceKm(t *testing.T) {
+	budokan, _ := NewPoint(35.6933, 139.7498)
+	osakaJo, _ := NewPoint(34.6873, 135.5262)
+
+	// 日本武道館〜大阪城ホールは約400km
+	assert.InDelta(t, 400, budokan.DistanceKm(osakaJo), 10)
+	assert.Zero(t, budokan.DistanceKm(budokan))
+	assert.Equal(t, 1.23, RoundKm(1.23456))
+}
+
+func TestParseSearchArea(t *testing.T) {
+	center, radius, err := ParseSearchArea("35.69,139.75", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 35.69, center.Latitude())
+	assert.Equal(t, DefaultSearchRadiusKm, radius)
+
+	r := 150.0
+	_, _, err = ParseSearchArea("35.69,139.75", &r)
+	assert.ErrorContains(t, err, "無効な検索半径")
+	r = 0
+	_, _, err = ParseSearchArea("35.69,139.75", &r)
+	assert.ErrorContains(t, err, "無効な検索半径")
+}
@@ -0,0 +1,159 @@
+// Package ical は iCalendar (RFC 5545) 形式のカレンダーフィードを生成する
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// TimeZone はフィード内の日時に使うタイムゾーン。VTIMEZONE として定義を埋め込む
+const TimeZone = "Asia/Tokyo"
+
+// jst は Asia/Tokyo（夏時間なしの UTC+9）。tzdata のない環境でも同じ結果になるよう固定オフセットで扱う
+var jst = time.FixedZone("JST", 9*60*60)
+
+const (
+	dateLayout        = "20060102"
+	dateTimeLayout    = "20060102T150405"
+	utcDateTimeLayout = "20060102T150405Z"
+	maxLineOctets     = 75
+)
+
+// Status は VEVENT の STATUS
+type Status string
+
+const (
+	StatusConfirmed Status = "CONFIRMED"
+	StatusTentative Status = "TENTATIVE"
+	StatusCancelled Status = "CANCELLED"
+)
+
+// Event は VEVENT 1件
+type Event struct {
+	UID          string // フィードをまたいで安定した識別子
+	Sequence     int    // 改訂番号。更新のたびに増やすとカレンダーアプリが変更を反映する
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Status       Status
+	Start        time.Time
+	End          *time.Time
+	AllDay       bool // 終日の予定。Start の日付（Asia/Tokyo）のみを使い、End は無視して1日とする
+	Created      time.Time
+	LastModified time.Time
+}
+
+// Calendar は VCALENDAR 1件
+type Calendar struct {
+	ProdID string
+	Name   string // X-WR-CALNAME
+	Events []Event
+}
+
+// Encode は CRLF 区切り・75オクテットで折り返した iCalendar テキストを返す
+func (c Calendar) Encode() []byte {
+	w := &writer{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + c.ProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+	w.line("X-WR-TIMEZONE:" + TimeZone)
+	writeTimeZone(w)
+	for _, e := range c.Events {
+		writeEvent(w, e)
+	}
+	w.line("END:VCALENDAR")
+	return w.buf.Bytes()
+}
+
+func writeTimeZone(w *writer) {
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + TimeZone)
+	w.line("BEGIN:STANDARD")
+	w.line("DTSTART:19700101T000000")
+	w.line("TZOFFSETFROM:+0900")
+	w.line("TZOFFSETTO:+0900")
+	w.line("TZNAME:JST")
+	w.line("END:STANDARD")
+	w.line("END:VTIMEZONE")
+}
+
+func writeEvent(w *writer, e Event) {
+	w.line("BEGIN:VEVENT")
+	w.line("UID:" + e.UID)
+	w.line("DTSTAMP:" + utc(e.LastModified))
+	w.line("CREATED:" + utc(e.Created))
+	w.line("LAST-MODIFIED:" + utc(e.LastModified))
+	w.line(fmt.Sprintf("SEQUENCE:%d", e.Sequence))
+	switch {
+	case e.AllDay:
+		start := e.Start.In(jst)
+		w.line("DTSTART;VALUE=DATE:" + start.Format(dateLayout))
+		w.line("DTEND;VALUE=DATE:" + start.AddDate(0, 0, 1).Format(dateLayout))
+	default:
+		w.line("DTSTART;TZID=" + TimeZone + ":" + e.Start.In(jst).Format(dateTimeLayout))
+		if e.End != nil {
+			w.line("DTEND;TZID=" + TimeZone + ":" + e.End.In(jst).Format(dateTimeLayout))
+		}
+	}
+	w.line("SUMMARY:" + escapeText(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION:" + escapeText(e.Description))
+	}
+	if e.Location != "" {
+		w.line("LOCATION:" + escapeText(e.Location))
+	}
+	if e.URL != "" {
+		w.line("URL:" + e.URL)
+	}
+	if e.Status != "" {
+		w.line("STATUS:" + string(e.Status))
+	}
+	w.line("END:VEVENT")
+}
+
+func utc(t time.Time) string {
+	return t.UTC().Format(utcDateTimeLayout)
+}
+
+// escapeText は TEXT 型の値のバックスラッシュ・カンマ・セミコロン・改行をエスケープする
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line はコンテンツ行を書き込む。75オクテットを超える行は UTF-8 の文字境界で折り返す
+func (w *writer) line(s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n ")
+		s = s[cut:]
+		// 継続行は先頭の空白1オクテット分だけ短くする
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\r\n")
+}
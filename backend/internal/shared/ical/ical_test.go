@@ -0,0 +1,79 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar_Encode(t *testing.T) {
+	start := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC) // 18:00 JST
+	end := start.Add(2 * time.Hour)
+	cal := Calendar{
+		ProdID: "-//idol-api//events//JA",
+		Name:   "テスト, カレンダー",
+		Events: []Event{{
+			UID:          "evt-1@idol-api",
+			Sequence:     3,
+			Summary:      "夏ライブ; 東京公演",
+			Description:  "出演: A\nチケット発売中",
+			Location:     "日本武道館, 東京都千代田区北の丸公園2-3",
+			URL:          "https://example.com/tickets",
+			Status:       StatusCancelled,
+			Start:        start,
+			End:          &end,
+			Created:      start.AddDate(0, -1, 0),
+			LastModified: start.AddDate(0, 0, -1),
+		}},
+	}
+
+	out := string(cal.Encode())
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "X-WR-CALNAME:テスト\\, カレンダー\r\n")
+	assert.Contains(t, out, "BEGIN:VTIMEZONE\r\nTZID:Asia/Tokyo\r\n")
+	assert.Contains(t, out, "UID:evt-1@idol-api\r\n")
+	assert.Contains(t, out, "SEQUENCE:3\r\n")
+	assert.Contains(t, out, "DTSTART;TZID=Asia/Tokyo:20250801T180000\r\n")
+	assert.Contains(t, out, "DTEND;TZID=Asia/Tokyo:20250801T200000\r\n")
+	assert.Contains(t, out, "DTSTAMP:20250731T090000Z\r\n")
+	assert.Contains(t, out, "SUMMARY:夏ライブ\\; 東京公演\r\n")
+	assert.Contains(t, out, "DESCRIPTION:出演: A\\nチケット発売中\r\n")
+	assert.Contains(t, out, "URL:https://example.com/tickets\r\n")
+	assert.Contains(t, out, "STATUS:CANCELLED\r\n")
+}
+
+func TestCalendar_EncodeAllDay(t *testing.T) {
+	start := time.Date(2025, 12, 31, 15, 0, 0, 0, time.UTC) // 2026-01-01 00:00 JST
+	cal := Calendar{
+		ProdID: "-//idol-api//anniversaries//JA",
+		Events: []Event{{UID: "birthday-1-2026@idol-api", Summary: "誕生日", Start: start, AllDay: true}},
+	}
+
+	out := string(cal.Encode())
+
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20260101\r\n")
+	assert.Contains(t, out, "DTEND;VALUE=DATE:20260102\r\n")
+	assert.NotContains(t, out, "DTSTART;TZID=")
+}
+
+func TestWriter_FoldsLongLinesOnRuneBoundary(t *testing.T) {
+	w := &writer{}
+	w.line("SUMMARY:" + strings.Repeat("あ", 40))
+
+	lines := strings.Split(strings.TrimSuffix(w.buf.String(), "\r\n"), "\r\n")
+	assert.Greater(t, len(lines), 1)
+	var unfolded strings.Builder
+	for i, l := range lines {
+		assert.LessOrEqual(t, len(l), maxLineOctets)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(l, " "))
+			l = l[1:]
+		}
+		unfolded.WriteString(l)
+	}
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("あ", 40), unfolded.String())
+}
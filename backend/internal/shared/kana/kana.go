@@ -0,0 +1,41 @@
+// Package kana は読み仮名（ふりがな）の検証を提供する。
+package kana
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxReadingLength は読み仮名の最大文字数
+const MaxReadingLength = 200
+
+// ValidateReading は読み仮名がひらがな・カタカナ（長音符・中黒・空白を含む）のみで構成されているかを検証する
+func ValidateReading(reading string) error {
+	if strings.TrimSpace(reading) == "" {
+		return errors.New("読み仮名は空にできません")
+	}
+	if utf8.RuneCountInString(reading) > MaxReadingLength {
+		return errors.New("読み仮名は200文字以内である必要があります")
+	}
+	for _, r := range reading {
+		if !isReadingRune(r) {
+			return errors.New("読み仮名はひらがなまたはカタカナで入力してください")
+		}
+	}
+	return nil
+}
+
+func isReadingRune(r rune) bool {
+	switch {
+	case r >= 'ぁ' && r <= 'ゖ', r >= 'ゝ' && r <= 'ゞ': // ひらがな・踊り字
+		return true
+	case r >= 'ァ' && r <= 'ヺ', r >= 'ヽ' && r <= 'ヾ': // カタカナ・踊り字
+		return true
+	case r >= 'ｦ' && r <= 'ﾟ': // 半角カタカナ
+		return true
+	case r == 'ー', r == '・', r == '゛', r == '゜', r == ' ', r == '　':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package kana
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReading(t *testing.T) {
+	valid := []string{"さくら", "サクラ", "ｻｸﾗ", "さくらざか フォーティーシックス", "モーニング・ムスメ", "ヴィジュアル"}
+	for _, v := range valid {
+		assert.NoError(t, ValidateReading(v), v)
+	}
+
+	invalid := []string{"", "  ", "桜", "sakura", "さくら46", strings.Repeat("あ", MaxReadingLength+1)}
+	for _, v := range invalid {
+		assert.Error(t, ValidateReading(v), v)
+	}
+}
@@ -0,0 +1,147 @@
+// Package monthday は誕生日・結成記念日のように年を問わない月日と、その毎年の発生日を扱う。
+// 2月29日は閏年以外の年では2月28日に発生するものとする。
+package monthday
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MonthDay は年を問わない月日（値オブジェクト）
+type MonthDay struct {
+	month time.Month
+	day   int
+}
+
+var (
+	first    = MonthDay{month: time.January, day: 1}
+	last     = MonthDay{month: time.December, day: 31}
+	feb28    = MonthDay{month: time.February, day: 28}
+	leapDay  = MonthDay{month: time.February, day: 29}
+	errParse = errors.New("月日の形式が不正です: MM-DD で指定してください")
+)
+
+// New は月日を生成する。2月29日も指定できる
+func New(month time.Month, day int) (MonthDay, error) {
+	// 閏年（2000年）の暦で日付の妥当性を検証する
+	if month < time.January || month > time.December || day < 1 || time.Date(2000, month, day, 0, 0, 0, 0, time.UTC).Day() != day {
+		return MonthDay{}, fmt.Errorf("無効な月日です: %02d-%02d", int(month), day)
+	}
+	return MonthDay{month: month, day: day}, nil
+}
+
+// Parse は "MM-DD" 形式の文字列から月日を生成する
+func Parse(s string) (MonthDay, error) {
+	if len(s) != 5 || s[2] != '-' {
+		return MonthDay{}, errParse
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if s[i] < '0' || s[i] > '9' {
+			return MonthDay{}, errParse
+		}
+	}
+	month := int(s[0]-'0')*10 + int(s[1]-'0')
+	day := int(s[3]-'0')*10 + int(s[4]-'0')
+	return New(time.Month(month), day)
+}
+
+// Of は日時の月日を返す（タイムゾーンの変換はしない）
+func Of(t time.Time) MonthDay {
+	return MonthDay{month: t.Month(), day: t.Day()}
+}
+
+func (m MonthDay) Month() time.Month { return m.month }
+func (m MonthDay) Day() int          { return m.day }
+
+// String は "MM-DD" 形式の文字列を返す。文字列の大小が暦の順序と一致する
+func (m MonthDay) String() string {
+	return fmt.Sprintf("%02d-%02d", int(m.month), m.day)
+}
+
+// In は指定した年の発生日（loc の0時）を返す。閏年以外の2月29日は2月28日とする
+func (m MonthDay) In(year int, loc *time.Location) time.Time {
+	day := m.day
+	if m == leapDay && !isLeap(year) {
+		day = 28
+	}
+	return time.Date(year, m.month, day, 0, 0, 0, 0, loc)
+}
+
+func (m MonthDay) before(other MonthDay) bool {
+	return m.month < other.month || (m.month == other.month && m.day < other.day)
+}
+
+// Range は月日の範囲（両端を含む）。From が To より後の場合は年をまたぐ（例: 12-25〜01-05）
+type Range struct {
+	From MonthDay
+	To   MonthDay
+}
+
+// Wraps は年をまたぐ範囲かを返す
+func (r Range) Wraps() bool {
+	return r.To.before(r.From)
+}
+
+// Contains は月日が範囲に含まれるかを返す
+func (r Range) Contains(m MonthDay) bool {
+	if r.Wraps() {
+		return !m.before(r.From) || !r.To.before(m)
+	}
+	return !m.before(r.From) && !r.To.before(m)
+}
+
+// Between は日付 from〜to（両端を含む）に発生する月日の範囲を返す。
+// 1年以上にわたる場合は全ての月日とし、閏年以外の2月28日で終わる場合は2月29日も含める
+func Between(from, to time.Time) Range {
+	if !to.Before(from.AddDate(1, 0, -1)) {
+		return Range{From: first, To: last}
+	}
+	r := Range{From: Of(from), To: Of(to)}
+	if r.To == feb28 && !isLeap(to.Year()) {
+		r.To = leapDay
+	}
+	return r
+}
+
+// Span は1暦年に収まる期間（両端を含む日付）
+type Span struct {
+	From time.Time
+	To   time.Time
+}
+
+// SplitByYear は from〜to（両端を含む日付）を暦年ごとの期間に分ける。
+// 各期間の月日の範囲は年をまたがないため、月日順に並べれば日付順になる
+func SplitByYear(from, to time.Time) []Span {
+	var spans []Span
+	for start := from; !start.After(to); {
+		end := time.Date(start.Year(), time.December, 31, 0, 0, 0, 0, start.Location())
+		if end.After(to) {
+			end = to
+		}
+		spans = append(spans, Span{From: start, To: end})
+		start = time.Date(start.Year()+1, time.January, 1, 0, 0, 0, 0, start.Location())
+	}
+	return spans
+}
+
+// Occurrences は origin の月日が from〜to（両端を含む）に発生する日付を昇順で返す。
+// from と to は同じタイムゾーンの0時とし、origin の年以前の発生は含めない
+func Occurrences(origin, from, to time.Time) []time.Time {
+	m := Of(origin)
+	var dates []time.Time
+	for year := from.Year(); year <= to.Year(); year++ {
+		if year <= origin.Year() {
+			continue
+		}
+		d := m.In(year, from.Location())
+		if !d.Before(from) && !d.After(to) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -0,0 +1,86 @@
+package monthday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	m, err := Parse("02-29")
+	require.NoError(t, err)
+	assert.Equal(t, time.February, m.Month())
+	assert.Equal(t, 29, m.Day())
+	assert.Equal(t, "02-29", m.String())
+
+	for _, s := range []string{"2-29", "02/29", "13-01", "02-30", "04-31", "00-10", "ab-cd", "+1-01"} {
+		_, err := Parse(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRange_Contains(t *testing.T) {
+	md := func(s string) MonthDay {
+		m, err := Parse(s)
+		require.NoError(t, err)
+		return m
+	}
+
+	week := Range{From: md("10-18"), To: md("10-24")}
+	assert.False(t, week.Wraps())
+	assert.True(t, week.Contains(md("10-18")))
+	assert.True(t, week.Contains(md("10-24")))
+	assert.False(t, week.Contains(md("10-25")))
+
+	newYear := Range{From: md("12-25"), To: md("01-05")}
+	assert.True(t, newYear.Wraps())
+	assert.True(t, newYear.Contains(md("12-31")))
+	assert.True(t, newYear.Contains(md("01-01")))
+	assert.False(t, newYear.Contains(md("06-01")))
+}
+
+func TestBetween(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, jst) }
+
+	r := Between(date(2026, 12, 28), date(2027, 1, 3))
+	assert.Equal(t, "12-28", r.From.String())
+	assert.Equal(t, "01-03", r.To.String())
+
+	// 閏年以外の2月28日で終わる範囲は2月29日生まれも含める
+	r = Between(date(2027, 2, 22), date(2027, 2, 28))
+	assert.Equal(t, "02-29", r.To.String())
+
+	r = Between(date(2026, 10, 18), date(2027, 10, 17))
+	assert.Equal(t, "01-01", r.From.String())
+	assert.Equal(t, "12-31", r.To.String())
+}
+
+func TestOccurrences(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	origin := time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	got := Occurrences(origin, time.Date(2026, 2, 1, 0, 0, 0, 0, jst), time.Date(2028, 3, 1, 0, 0, 0, 0, jst))
+	require.Len(t, got, 3)
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, jst), got[0])
+	assert.Equal(t, time.Date(2027, 2, 28, 0, 0, 0, 0, jst), got[1])
+	assert.Equal(t, time.Date(2028, 2, 29, 0, 0, 0, 0, jst), got[2])
+
+	// 起点の年以前は含めない
+	assert.Empty(t, Occurrences(origin, time.Date(2004, 1, 1, 0, 0, 0, 0, jst), time.Date(2004, 12, 31, 0, 0, 0, 0, jst)))
+}
+
+func TestSplitByYear(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, jst) }
+
+	spans := SplitByYear(date(2026, 12, 28), date(2027, 1, 3))
+	require.Len(t, spans, 2)
+	assert.Equal(t, Span{From: date(2026, 12, 28), To: date(2026, 12, 31)}, spans[0])
+	assert.Equal(t, Span{From: date(2027, 1, 1), To: date(2027, 1, 3)}, spans[1])
+
+	spans = SplitByYear(date(2026, 10, 1), date(2026, 10, 31))
+	assert.Equal(t, []Span{{From: date(2026, 10, 1), To: date(2026, 10, 31)}}, spans)
+}
@@ -0,0 +1,124 @@
+// Package namehistory は改名に伴う旧名の履歴（使用期間付き）を扱う値オブジェクト
+package namehistory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry は使用期間付きの旧名1件
+type Entry struct {
+	name  string
+	from  *time.Time // 使用開始日（不明な場合は nil）
+	until time.Time  // 改名日。この日から次の名前を使用する
+}
+
+// NewEntry は旧名を生成する
+func NewEntry(name string, from *time.Time, until time.Time) (Entry, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Entry{}, errors.New("旧名は必須です")
+	}
+	if until.IsZero() {
+		return Entry{}, errors.New("改名日は必須です")
+	}
+	if from != nil && !from.Before(until) {
+		return Entry{}, fmt.Errorf("旧名 %q の使用開始日は改名日より前である必要があります（不正な期間）", name)
+	}
+	return Entry{name: name, from: from, until: until}, nil
+}
+
+// ReconstructEntry は永続化層から旧名を再構築する（バリデーションなし）
+func ReconstructEntry(name string, from *time.Time, until time.Time) Entry {
+	return Entry{name: name, from: from, until: until}
+}
+
+func (e Entry) Name() string     { return e.name }
+func (e Entry) From() *time.Time { return e.from }
+func (e Entry) Until() time.Time { return e.until }
+
+// History は改名日の古い順に並んだ旧名の履歴
+type History []Entry
+
+// New は旧名の一覧を改名日順に並べ、期間が重ならないことを検証して履歴を生成する
+func New(entries []Entry) (History, error) {
+	h := append(History{}, entries...)
+	sort.SliceStable(h, func(i, j int) bool { return h[i].until.Before(h[j].until) })
+	for i := 1; i < len(h); i++ {
+		if !h[i-1].until.Before(h[i].until) {
+			return nil, fmt.Errorf("改名日 %s が重複しています（不正な履歴）", h[i].until.Format("2006-01-02"))
+		}
+		if h[i].from != nil && h[i].from.Before(h[i-1].until) {
+			return nil, fmt.Errorf("旧名 %q の使用期間が前の名前と重なっています（不正な履歴）", h[i].name)
+		}
+	}
+	return h, nil
+}
+
+// Record は改名前の名前を履歴に追加した新しい履歴を返す。
+// 使用開始日は直前の改名日（履歴がない場合は since）とする
+func (h History) Record(previous string, since *time.Time, renamedAt time.Time) (History, error) {
+	from := since
+	if len(h) > 0 {
+		last := h[len(h)-1].until
+		if !last.Before(renamedAt) {
+			return nil, fmt.Errorf("改名日は直前の改名日 %s より後である必要があります（不正な改名日）", last.Format("2006-01-02"))
+		}
+		from = &last
+	}
+	if from != nil && !from.Before(renamedAt) {
+		from = nil
+	}
+	entry, err := NewEntry(previous, from, renamedAt)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(History{}, h...), entry), nil
+}
+
+// NameAt は指定日時点で使われていた名前を返す。いずれの旧名の期間にも当たらない場合は現在の名前を返す
+func (h History) NameAt(at time.Time, current string) string {
+	for _, e := range h {
+		if at.Before(e.until) {
+			if e.from != nil && at.Before(*e.from) {
+				continue
+			}
+			return e.name
+		}
+	}
+	return current
+}
+
+// Names は旧名の一覧（重複なし）を返す
+func (h History) Names() []string {
+	seen := make(map[string]struct{}, len(h))
+	names := make([]string, 0, len(h))
+	for _, e := range h {
+		if _, ok := seen[e.name]; ok {
+			continue
+		}
+		seen[e.name] = struct{}{}
+		names = append(names, e.name)
+	}
+	return names
+}
+
+// ParseEntry は "2006-01-02" 形式の日付文字列から旧名を生成する
+func ParseEntry(name string, from *string, until string) (Entry, error) {
+	u, err := time.Parse("2006-01-02", until)
+	if err != nil {
+		return Entry{}, fmt.Errorf("改名日の形式が不正です: %w", err)
+	}
+	var f *time.Time
+	if from != nil && *from != "" {
+		t, err := time.Parse("2006-01-02", *from)
+		if err != nil {
+			return Entry{}, fmt.Errorf("使用開始日の形式が不正です: %w", err)
+		}
+		f = &t
+	}
+	return NewEntry(name, f, u)
+}
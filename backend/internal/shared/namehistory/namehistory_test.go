@@ -0,0 +1,64 @@
+package namehistory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestHistory_RecordAndNameAt(t *testing.T) {
+	formed := date(2015, 8, 21)
+	h, err := History{}.Record("欅坂46", &formed, date(2020, 10, 14))
+	require.NoError(t, err)
+	h, err = h.Record("櫻坂46 (仮)", nil, date(2021, 1, 1))
+	require.NoError(t, err)
+
+	require.Len(t, h, 2)
+	assert.Equal(t, date(2020, 10, 14), *h[1].From())
+
+	assert.Equal(t, "欅坂46", h.NameAt(date(2018, 1, 1), "櫻坂46"))
+	assert.Equal(t, "櫻坂46 (仮)", h.NameAt(date(2020, 10, 14), "櫻坂46"))
+	assert.Equal(t, "櫻坂46", h.NameAt(date(2021, 1, 1), "櫻坂46"))
+	assert.Equal(t, []string{"欅坂46", "櫻坂46 (仮)"}, h.Names())
+}
+
+func TestHistory_RecordRejectsPastRenameDate(t *testing.T) {
+	h, err := History{}.Record("旧名", nil, date(2020, 1, 1))
+	require.NoError(t, err)
+
+	_, err = h.Record("次の旧名", nil, date(2019, 1, 1))
+	assert.ErrorContains(t, err, "不正な改名日")
+}
+
+func TestNew_SortsAndRejectsOverlap(t *testing.T) {
+	a, err := NewEntry("B", nil, date(2020, 1, 1))
+	require.NoError(t, err)
+	from := date(2019, 1, 1)
+	b, err := NewEntry("C", &from, date(2021, 1, 1))
+	require.NoError(t, err)
+
+	_, err = New([]Entry{b, a})
+	assert.ErrorContains(t, err, "重なっています")
+
+	from = date(2020, 1, 1)
+	b, err = NewEntry("C", &from, date(2021, 1, 1))
+	require.NoError(t, err)
+	h, err := New([]Entry{b, a})
+	require.NoError(t, err)
+	assert.Equal(t, "B", h[0].Name())
+}
+
+func TestNewEntry_Validates(t *testing.T) {
+	_, err := NewEntry(" ", nil, date(2020, 1, 1))
+	assert.Error(t, err)
+
+	from := date(2021, 1, 1)
+	_, err = NewEntry("旧名", &from, date(2020, 1, 1))
+	assert.ErrorContains(t, err, "不正な期間")
+}
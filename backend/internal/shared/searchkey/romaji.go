@@ -0,0 +1,197 @@
+package searchkey
+
+import "strings"
+
+// kanaRomaji はひらがな1文字のヘボン式ローマ字表記。
+var kanaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'ゔ': "vu",
+	'ゕ': "ka", 'ゖ': "ke", 'ゎ': "wa",
+}
+
+// smallYa は拗音を作る小書きのや行。
+var smallYa = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+// smallVowel は外来音（ファ、ティ、ウィ 等）を作る小書きの母音。
+var smallVowel = map[rune]string{'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o"}
+
+// kanaToRomaji はひらがなをヘボン式ローマ字へ変換する。ひらがな以外の文字はそのまま残す。
+func kanaToRomaji(s string) string {
+	pieces := make([]string, 0, len(s))
+	sokuon := false // 直前が促音「っ」
+	appendPiece := func(p string) {
+		if sokuon {
+			p = geminate(p)
+			sokuon = false
+		}
+		pieces = append(pieces, p)
+	}
+	last := func() string {
+		if len(pieces) == 0 {
+			return ""
+		}
+		return pieces[len(pieces)-1]
+	}
+
+	for _, r := range s {
+		if r == 'っ' {
+			sokuon = true
+			continue
+		}
+		if vowel, ok := smallYa[r]; ok {
+			// きゃ → kya、しゃ → sha、ちゃ → cha、じゃ → ja
+			if prev := last(); strings.HasSuffix(prev, "i") && len(prev) > 1 {
+				stem := strings.TrimSuffix(prev, "i")
+				if strings.HasSuffix(stem, "sh") || strings.HasSuffix(stem, "ch") || strings.HasSuffix(stem, "j") {
+					pieces[len(pieces)-1] = stem + vowel
+				} else {
+					pieces[len(pieces)-1] = stem + "y" + vowel
+				}
+				continue
+			}
+			appendPiece("y" + vowel)
+			continue
+		}
+		if vowel, ok := smallVowel[r]; ok {
+			// ふぁ → fa、しぇ → she、てぃ → ti、うぃ → wi
+			if prev := last(); prev != "" && isRomajiSyllable(prev) {
+				stem := prev[:len(prev)-1]
+				if stem == "" {
+					stem = "w"
+				}
+				pieces[len(pieces)-1] = stem + vowel
+				continue
+			}
+			appendPiece(vowel)
+			continue
+		}
+		if romaji, ok := kanaRomaji[r]; ok {
+			appendPiece(romaji)
+			continue
+		}
+		sokuon = false
+		pieces = append(pieces, string(r))
+	}
+	return strings.Join(pieces, "")
+}
+
+// geminate は促音に続く音節の子音を重ねる（っか → kka、っち → tchi）。
+func geminate(p string) string {
+	if p == "" || isVowel(p[0]) || p == "n" {
+		return p
+	}
+	if strings.HasPrefix(p, "ch") {
+		return "t" + p
+	}
+	return p[:1] + p
+}
+
+// isRomajiSyllable はかな1文字分のローマ字（母音で終わる）かを返す。
+func isRomajiSyllable(p string) bool {
+	return p != "" && isVowel(p[len(p)-1]) && isASCIIAlpha(p)
+}
+
+// hepburnFolds は訓令式・日本式などの綴りをヘボン式へ寄せる置換規則（長い順に評価する）。
+var hepburnFolds = []struct{ from, to string }{
+	{"sy", "sh"},
+	{"ty", "ch"},
+	{"zy", "j"},
+	{"jy", "j"},
+	{"dy", "j"},
+	{"si", "shi"},
+	{"ti", "chi"},
+	{"tu", "tsu"},
+	{"hu", "fu"},
+	{"zi", "ji"},
+	{"di", "ji"},
+	{"du", "zu"},
+	{"wo", "o"},
+	{"mb", "nb"},
+	{"mp", "np"},
+}
+
+// foldRomaji はローマ字の表記ゆれを畳み込む。
+//
+//   - 訓令式・日本式をヘボン式へ（si → shi、tu → tsu、hu → fu 等）
+//   - 撥音の m 表記を n へ（shimbun → shinbun）
+//   - 長音の表記を短母音へ（ou/oo/oh → o、uu → u 等）と nn → n
+func foldRomaji(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 8)
+	for i := 0; i < len(s); {
+		matched := false
+		for _, f := range hepburnFolds {
+			if !strings.HasPrefix(s[i:], f.from) {
+				continue
+			}
+			// sh/ch の h を fu と誤認しない
+			if f.from == "hu" && i > 0 && (s[i-1] == 's' || s[i-1] == 'c') {
+				continue
+			}
+			b.WriteString(f.to)
+			i += len(f.from)
+			matched = true
+			break
+		}
+		if !matched {
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+	return foldLongVowels(b.String())
+}
+
+// foldLongVowels は長音・撥音の重ね書きを1文字に畳み込む。
+func foldLongVowels(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	var prev byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == 'u' && prev == 'o':
+			// ou → o
+			continue
+		case c == 'h' && prev == 'o' && (i+1 == len(s) || !isVowel(s[i+1]) && s[i+1] != 'y'):
+			// oh + 子音 → o（Ohno → Ono）
+			continue
+		case c == prev && (isVowel(c) || c == 'n'):
+			// aa/ii/uu/ee/oo/nn → 1文字
+			continue
+		}
+		b.WriteByte(c)
+		prev = c
+	}
+	return b.String()
+}
+
+func isVowel(c byte) bool {
+	switch c {
+	case 'a', 'i', 'u', 'e', 'o':
+		return true
+	}
+	return false
+}
+
+func isASCIIAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
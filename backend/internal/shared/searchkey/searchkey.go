@@ -0,0 +1,91 @@
+// Package searchkey は日本語の名前検索に使う正規化キーを生成する。
+//
+// ファンが入力するあらゆる表記ゆれ（ひらがな/カタカナ、全角/半角、長音、ヘボン式/訓令式ローマ字）を
+// 同じキーに畳み込むことで、「さくら」「サクラ」「ｻｸﾗ」「Sakura」を相互に検索できるようにする。
+// 漢字の読みは推定しないため、漢字名をかな・ローマ字で引くには読み（name_kana 等）や別名をキーの材料に含める。
+package searchkey
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize は表記ゆれを吸収した検索キーを返す。
+//
+//   - NFKC 正規化（全角英数・半角カナを統一）と小文字化
+//   - カタカナをひらがなへ畳み込み
+//   - 長音記号・空白・記号を除去
+//   - ラテン文字のダイアクリティカルマーク（ā, ō 等）を除去
+func Normalize(s string) string {
+	s = norm.NFKC.String(s)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case isLongVowelMark(r):
+			continue
+		case r >= 'ァ' && r <= 'ヶ':
+			// カタカナ → ひらがな（ヴヵヶ もそれぞれ ゔゕゖ に対応する）
+			b.WriteRune(r - 0x60)
+		case unicode.In(r, unicode.Latin):
+			writeLatin(&b, r)
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Romanize は Normalize 済みのキーに含まれるかなをヘボン式ローマ字へ変換し、
+// 訓令式・長音表記のゆれを畳み込んだキーを返す。かな以外の文字（漢字等）はそのまま残す。
+func Romanize(normalized string) string {
+	return foldRomaji(kanaToRomaji(normalized))
+}
+
+// Keys は名前・読み・別名などから保存用の検索キー集合を生成する。
+// 各値について正規化キーとローマ字キーを生成し、空と重複を除いて返す。
+func Keys(values ...string) []string {
+	seen := make(map[string]struct{}, len(values)*2)
+	keys := make([]string, 0, len(values)*2)
+	for _, v := range values {
+		normalized := Normalize(v)
+		if normalized == "" {
+			continue
+		}
+		for _, key := range []string{normalized, Romanize(normalized)} {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// QueryKeys は検索語から照合用のキーを生成する。
+// 記号のみの検索語など、キーが生成できない場合は空を返す。
+func QueryKeys(query string) []string {
+	return Keys(query)
+}
+
+func isLongVowelMark(r rune) bool {
+	switch r {
+	case 'ー', '〜', '～':
+		return true
+	}
+	return false
+}
+
+// writeLatin はラテン文字を小文字化し、ダイアクリティカルマークを除去して書き込む。
+func writeLatin(b *strings.Builder, r rune) {
+	for _, d := range norm.NFD.String(string(unicode.ToLower(r))) {
+		if unicode.Is(unicode.Mn, d) {
+			continue
+		}
+		b.WriteRune(d)
+	}
+}
@@ -0,0 +1,97 @@
+package searchkey
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"カタカナをひらがなへ", "サクラ", "さくら"},
+		{"半角カナ", "ｻｸﾗ", "さくら"},
+		{"全角英数", "ＡＫＢ４８", "akb48"},
+		{"長音を除去", "ユーカ", "ゆか"},
+		{"記号と空白を除去", "=LOVE 〜ｲｺｰﾙﾗﾌﾞ〜", "loveいこるらぶ"},
+		{"ダイアクリティカルマーク", "Yūka Ōno", "yukaono"},
+		{"漢字はそのまま", "桜井 さくら", "桜井さくら"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Normalize(tt.input))
+		})
+	}
+}
+
+func TestRomanize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"さくら", "sakura"},
+		{"しゃしん", "shashin"},
+		{"ちゅうごく", "chugoku"},
+		{"きっちん", "kitchin"},
+		{"がっこう", "gakko"},
+		{"ふぁん", "fan"},
+		{"うぃんたー", "winta"},
+		{"しんぶん", "shinbun"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, Romanize(Normalize(tt.input)))
+		})
+	}
+}
+
+func TestKeys_MatchesSpellingVariants(t *testing.T) {
+	stored := Keys("さくら", "Sakura")
+
+	variants := []string{"さくら", "サクラ", "ｻｸﾗ", "sakura", "SAKURA", "Ｓａｋｕｒａ"}
+	for _, v := range variants {
+		t.Run(v, func(t *testing.T) {
+			assert.True(t, matchesAny(stored, QueryKeys(v)), "stored=%v query=%v", stored, QueryKeys(v))
+		})
+	}
+}
+
+func TestKeys_FoldsRomajiSystemsAndLongVowels(t *testing.T) {
+	pairs := [][2]string{
+		{"しおり", "siori"},     // 訓令式
+		{"ちひろ", "tihiro"},    // 訓令式
+		{"ゆうか", "Yuka"},      // 長音省略
+		{"ゆうか", "Yūka"},      // マクロン
+		{"おおの", "Ohno"},      // 長音 oh
+		{"ユーカ", "yuuka"},     // 長音記号
+		{"じゅり", "zyuri"},     // 訓令式拗音
+		{"ふみか", "Humika"},    // 訓令式 hu
+		{"しんぶん", "shimbun"},  // 撥音の m
+		{"まっちゃ", "matcha"},   // 促音 + ch
+		{"こうき", "kōki"},      // マクロン
+		{"けんいち", "Ken'ichi"}, // アポストロフィ
+	}
+	for _, p := range pairs {
+		t.Run(p[0]+"_"+p[1], func(t *testing.T) {
+			assert.True(t, matchesAny(Keys(p[0]), QueryKeys(p[1])), "stored=%v query=%v", Keys(p[0]), QueryKeys(p[1]))
+		})
+	}
+}
+
+func TestKeys_PartialAndDedup(t *testing.T) {
+	keys := Keys("桜井さくら", "桜井さくら", "")
+	assert.Equal(t, []string{"桜井さくら", "桜井sakura"}, keys)
+	assert.True(t, matchesAny(keys, QueryKeys("Sakura")))
+	assert.Empty(t, QueryKeys("☆★"))
+}
+
+func matchesAny(stored, query []string) bool {
+	return slices.ContainsFunc(query, func(q string) bool {
+		return slices.ContainsFunc(stored, func(s string) bool { return strings.Contains(s, q) })
+	})
+}
@@ -0,0 +1,21 @@
+package affiliation
+
+type CreateAffiliationCommand struct {
+	TalentType   string
+	TalentID     string
+	AgencyID     string
+	ContractType *string
+	JoinedAt     *string
+	LeftAt       *string
+}
+
+type UpdateAffiliationCommand struct {
+	ID           string
+	ContractType *string
+	JoinedAt     *string // 空文字で解除
+	LeftAt       *string // 空文字で解除（所属中に戻す）
+}
+
+type DeleteAffiliationCommand struct {
+	ID string
+}
@@ -0,0 +1,12 @@
+package affiliation
+
+import "context"
+
+type AffiliationUseCase interface {
+	CreateAffiliation(ctx context.Context, cmd CreateAffiliationCommand) (*AffiliationDTO, error)
+	GetAffiliation(ctx context.Context, query GetAffiliationQuery) (*AffiliationDTO, error)
+	ListTalentAgencies(ctx context.Context, query ListTalentAgenciesQuery) ([]*AffiliationDTO, error)
+	ListAgencyTalents(ctx context.Context, query ListAgencyTalentsQuery) ([]*AffiliationDTO, error)
+	UpdateAffiliation(ctx context.Context, cmd UpdateAffiliationCommand) error
+	DeleteAffiliation(ctx context.Context, cmd DeleteAffiliationCommand) error
+}
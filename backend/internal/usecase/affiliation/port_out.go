@@ -0,0 +1,42 @@
+package affiliation
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/affiliation"
+	"github.com/kuro48/idol-api/internal/domain/related"
+)
+
+// AffiliationAppPort は Usecase が application サービスに要求する契約
+type AffiliationAppPort interface {
+	CreateAffiliation(ctx context.Context, input AffiliationCreateInput) (*domain.Affiliation, error)
+	GetAffiliation(ctx context.Context, id string) (*domain.Affiliation, error)
+	ListByTalent(ctx context.Context, talentType, talentID string) ([]*domain.Affiliation, error)
+	ListByAgency(ctx context.Context, agencyID string, at *time.Time) ([]*domain.Affiliation, error)
+	UpdateAffiliation(ctx context.Context, input AffiliationUpdateInput) error
+	DeleteAffiliation(ctx context.Context, id string) error
+}
+
+// RelatedAppPort は事務所名・タレント名の引き当てに使う読み取り専用の契約
+type RelatedAppPort interface {
+	FindAgencies(ctx context.Context, ids []string) (map[string]related.AgencySummary, error)
+	FindIdols(ctx context.Context, ids []string) (map[string]related.IdolSummary, error)
+	FindGroups(ctx context.Context, ids []string) (map[string]related.GroupSummary, error)
+}
+
+type AffiliationCreateInput struct {
+	TalentType   string
+	TalentID     string
+	AgencyID     string
+	ContractType *string
+	JoinedAt     *string
+	LeftAt       *string
+}
+
+type AffiliationUpdateInput struct {
+	ID           string
+	ContractType *string
+	JoinedAt     *string
+	LeftAt       *string
+}
@@ -0,0 +1,68 @@
+package affiliation
+
+import (
+	"errors"
+	"time"
+)
+
+type GetAffiliationQuery struct {
+	ID string
+}
+
+// ListTalentAgenciesQuery はアイドル・グループの所属事務所履歴の取得クエリ
+type ListTalentAgenciesQuery struct {
+	TalentType string // "idol" or "group"
+	TalentID   string
+}
+
+// ListAgencyTalentsQuery は事務所の所属タレント取得クエリ
+type ListAgencyTalentsQuery struct {
+	AgencyID   string
+	At         *string `form:"at"`          // "2006-01-02"。指定日に所属していたタレントに絞る（省略時は全期間）
+	TalentType *string `form:"talent_type"` // idol, group
+}
+
+// Date は基準日を返す。未指定の場合は nil
+func (q ListAgencyTalentsQuery) Date() (*time.Time, error) {
+	if q.At == nil || *q.At == "" {
+		return nil, nil
+	}
+	at, err := time.Parse("2006-01-02", *q.At)
+	if err != nil {
+		return nil, errors.New("基準日の形式が不正です: YYYY-MM-DD で指定してください")
+	}
+	return &at, nil
+}
+
+// AffiliationDTO は所属履歴のデータ転送オブジェクト
+type AffiliationDTO struct {
+	ID           string  `json:"id"`
+	TalentType   string  `json:"talent_type"` // idol, group
+	TalentID     string  `json:"talent_id"`
+	AgencyID     string  `json:"agency_id"`
+	ContractType string  `json:"contract_type"` // exclusive, partnership, trainee, other
+	JoinedAt     *string `json:"joined_at,omitempty"`
+	LeftAt       *string `json:"left_at,omitempty"`
+	IsActive     bool    `json:"is_active"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+
+	Agency *AgencyRefDTO `json:"agency,omitempty"` // タレント起点の一覧で展開
+	Talent *TalentRefDTO `json:"talent,omitempty"` // 事務所起点の一覧で展開
+}
+
+// AgencyRefDTO は所属先の事務所
+type AgencyRefDTO struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	NameEn  *string `json:"name_en,omitempty"`
+	Country string  `json:"country"`
+}
+
+// TalentRefDTO は所属タレント（名前は基準日時点の表記）
+type TalentRefDTO struct {
+	Type     string  `json:"type"` // idol, group
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	NameKana *string `json:"name_kana,omitempty"`
+}
@@ -0,0 +1,174 @@
+package affiliation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/affiliation"
+)
+
+type Usecase struct {
+	appService AffiliationAppPort
+	relatedApp RelatedAppPort
+}
+
+func NewUsecase(appService AffiliationAppPort, relatedApp RelatedAppPort) *Usecase {
+	return &Usecase{appService: appService, relatedApp: relatedApp}
+}
+
+func (u *Usecase) CreateAffiliation(ctx context.Context, cmd CreateAffiliationCommand) (*AffiliationDTO, error) {
+	a, err := u.appService.CreateAffiliation(ctx, AffiliationCreateInput{
+		TalentType:   cmd.TalentType,
+		TalentID:     cmd.TalentID,
+		AgencyID:     cmd.AgencyID,
+		ContractType: cmd.ContractType,
+		JoinedAt:     cmd.JoinedAt,
+		LeftAt:       cmd.LeftAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dto := toDTO(a)
+	return &dto, nil
+}
+
+func (u *Usecase) GetAffiliation(ctx context.Context, query GetAffiliationQuery) (*AffiliationDTO, error) {
+	a, err := u.appService.GetAffiliation(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	dto := toDTO(a)
+	return &dto, nil
+}
+
+// ListTalentAgencies はアイドル・グループの所属事務所の履歴を所属日の古い順に取得する
+func (u *Usecase) ListTalentAgencies(ctx context.Context, query ListTalentAgenciesQuery) ([]*AffiliationDTO, error) {
+	as, err := u.appService.ListByTalent(ctx, query.TalentType, query.TalentID)
+	if err != nil {
+		return nil, err
+	}
+
+	agencyIDs := make([]string, 0, len(as))
+	for _, a := range as {
+		agencyIDs = append(agencyIDs, a.AgencyID())
+	}
+	agencies, err := u.relatedApp.FindAgencies(ctx, agencyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("事務所の取得エラー: %w", err)
+	}
+
+	dtos := make([]*AffiliationDTO, 0, len(as))
+	for _, a := range as {
+		dto := toDTO(a)
+		if agency, ok := agencies[a.AgencyID()]; ok {
+			dto.Agency = &AgencyRefDTO{ID: agency.ID, Name: agency.Name, NameEn: agency.NameEn, Country: agency.Country}
+		}
+		dtos = append(dtos, &dto)
+	}
+	return dtos, nil
+}
+
+// ListAgencyTalents は事務所の所属タレントを取得する。
+// 基準日を指定した場合はその日に所属していたタレントに絞り、名前もその日時点の表記で返す
+func (u *Usecase) ListAgencyTalents(ctx context.Context, query ListAgencyTalentsQuery) ([]*AffiliationDTO, error) {
+	at, err := query.Date()
+	if err != nil {
+		return nil, err
+	}
+	var talentType *domain.TalentType
+	if query.TalentType != nil {
+		t, err := domain.NewTalentType(*query.TalentType)
+		if err != nil {
+			return nil, err
+		}
+		talentType = &t
+	}
+
+	as, err := u.appService.ListByAgency(ctx, query.AgencyID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	var idolIDs, groupIDs []string
+	for _, a := range as {
+		if a.TalentType() == domain.TalentGroup {
+			groupIDs = append(groupIDs, a.TalentID())
+		} else {
+			idolIDs = append(idolIDs, a.TalentID())
+		}
+	}
+	idols, err := u.relatedApp.FindIdols(ctx, idolIDs)
+	if err != nil {
+		return nil, fmt.Errorf("アイドルの取得エラー: %w", err)
+	}
+	groups, err := u.relatedApp.FindGroups(ctx, groupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("グループの取得エラー: %w", err)
+	}
+
+	nameAt := time.Now()
+	if at != nil {
+		nameAt = *at
+	}
+	dtos := make([]*AffiliationDTO, 0, len(as))
+	for _, a := range as {
+		if talentType != nil && a.TalentType() != *talentType {
+			continue
+		}
+		dto := toDTO(a)
+		// 削除済みのタレントは一覧に含めない
+		switch a.TalentType() {
+		case domain.TalentIdol:
+			idol, ok := idols[a.TalentID()]
+			if !ok {
+				continue
+			}
+			dto.Talent = &TalentRefDTO{Type: a.TalentType().String(), ID: idol.ID, Name: idol.NameAt(nameAt), NameKana: idol.NameKana}
+		case domain.TalentGroup:
+			group, ok := groups[a.TalentID()]
+			if !ok {
+				continue
+			}
+			dto.Talent = &TalentRefDTO{Type: a.TalentType().String(), ID: group.ID, Name: group.NameAt(nameAt), NameKana: group.NameKana}
+		}
+		dtos = append(dtos, &dto)
+	}
+	return dtos, nil
+}
+
+func (u *Usecase) UpdateAffiliation(ctx context.Context, cmd UpdateAffiliationCommand) error {
+	return u.appService.UpdateAffiliation(ctx, AffiliationUpdateInput{
+		ID:           cmd.ID,
+		ContractType: cmd.ContractType,
+		JoinedAt:     cmd.JoinedAt,
+		LeftAt:       cmd.LeftAt,
+	})
+}
+
+func (u *Usecase) DeleteAffiliation(ctx context.Context, cmd DeleteAffiliationCommand) error {
+	return u.appService.DeleteAffiliation(ctx, cmd.ID)
+}
+
+func toDTO(a *domain.Affiliation) AffiliationDTO {
+	return AffiliationDTO{
+		ID:           a.ID().Value(),
+		TalentType:   a.TalentType().String(),
+		TalentID:     a.TalentID(),
+		AgencyID:     a.AgencyID(),
+		ContractType: a.ContractType().String(),
+		JoinedAt:     formatDate(a.JoinedAt()),
+		LeftAt:       formatDate(a.LeftAt()),
+		IsActive:     a.IsActive(),
+		CreatedAt:    a.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:    a.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
+}
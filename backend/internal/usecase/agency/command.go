@@ -4,6 +4,7 @@ package agency
 type CreateAgencyCommand struct {
 	Name            string  `json:"name" binding:"required"`
 	NameEn          *string `json:"name_en"`
+	NameKana        *string `json:"name_kana" binding:"omitempty,max=200"`
 	FoundedDate     *string `json:"founded_date"` // YYYY-MM-DD
 	Country         string  `json:"country" binding:"required"`
 	OfficialWebsite *string `json:"official_website"`
@@ -16,6 +17,7 @@ type UpdateAgencyCommand struct {
 	ID              string  `json:"-"`
 	Name            *string `json:"name"`
 	NameEn          *string `json:"name_en"`
+	NameKana        *string `json:"name_kana" binding:"omitempty,max=200"`
 	FoundedDate     *string `json:"founded_date"` // YYYY-MM-DD
 	OfficialWebsite *string `json:"official_website"`
 	Description     *string `json:"description"`
@@ -20,6 +20,7 @@ type AgencyAppPort interface {
 type AgencyCreateInput struct {
 	Name            string
 	NameEn          *string
+	NameKana        *string
 	FoundedDate     *string
 	Country         string
 	OfficialWebsite *string
@@ -32,6 +33,7 @@ type AgencyUpdateInput struct {
 	ID              string
 	Name            *string
 	NameEn          *string
+	NameKana        *string
 	FoundedDate     *string
 	OfficialWebsite *string
 	Description     *string
@@ -15,7 +15,7 @@ type ListAgenciesQuery struct {
 	Country *string `form:"country"` // 国コード完全一致
 
 	// ソート
-	Sort  *string `form:"sort"`  // name, founded_date, created_at
+	Sort  *string `form:"sort"`  // name, name_kana, founded_date, created_at
 	Order *string `form:"order"` // asc, desc
 
 	// ページネーション
@@ -50,7 +50,7 @@ func (q *ListAgenciesQuery) Normalize() {
 // Validate は検索条件の許可リスト検証を行う。
 func (q *ListAgenciesQuery) Validate() error {
 	if q.Sort != nil {
-		allowedSorts := []string{"name", "founded_date", "created_at"}
+		allowedSorts := []string{"name", "name_kana", "founded_date", "created_at"}
 		if !contains(allowedSorts, *q.Sort) {
 			return errors.New("無効なソート項目です")
 		}
@@ -78,6 +78,7 @@ type AgencyDTO struct {
 	ID              string  `json:"id"`
 	Name            string  `json:"name"`
 	NameEn          *string `json:"name_en,omitempty"`
+	NameKana        *string `json:"name_kana,omitempty"`    // 読み仮名
 	FoundedDate     *string `json:"founded_date,omitempty"` // YYYY-MM-DD
 	Country         string  `json:"country"`
 	OfficialWebsite *string `json:"official_website,omitempty"`
@@ -25,3 +25,12 @@ func TestListAgenciesQueryValidateAllowsKnownSortAndOrder(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestListAgenciesQueryValidateAllowsNameKanaSort(t *testing.T) {
+	sort := "name_kana"
+	query := ListAgenciesQuery{Sort: &sort}
+
+	err := query.Validate()
+
+	assert.NoError(t, err)
+}
@@ -22,6 +22,7 @@ func (u *Usecase) CreateAgency(ctx context.Context, cmd CreateAgencyCommand) (*A
 	entity, err := u.appService.CreateAgency(ctx, AgencyCreateInput{
 		Name:            cmd.Name,
 		NameEn:          cmd.NameEn,
+		NameKana:        cmd.NameKana,
 		FoundedDate:     cmd.FoundedDate,
 		Country:         cmd.Country,
 		OfficialWebsite: cmd.OfficialWebsite,
@@ -94,6 +95,7 @@ func (u *Usecase) UpdateAgency(ctx context.Context, cmd UpdateAgencyCommand) err
 		ID:              cmd.ID,
 		Name:            cmd.Name,
 		NameEn:          cmd.NameEn,
+		NameKana:        cmd.NameKana,
 		FoundedDate:     cmd.FoundedDate,
 		OfficialWebsite: cmd.OfficialWebsite,
 		Description:     cmd.Description,
@@ -117,6 +119,7 @@ func toDTO(a *domain.Agency) AgencyDTO {
 		ID:              a.ID().Value(),
 		Name:            a.Name().Value(),
 		NameEn:          a.NameEn(),
+		NameKana:        a.NameKana(),
 		FoundedDate:     foundedDateStr,
 		Country:         a.Country().Value(),
 		OfficialWebsite: a.OfficialWebsite(),
@@ -0,0 +1,14 @@
+package anniversary
+
+import (
+	"context"
+
+	"github.com/kuro48/idol-api/internal/shared/ical"
+)
+
+// AnniversaryUseCase は誕生日・結成記念日のユースケース Input Port
+type AnniversaryUseCase interface {
+	Birthdays(ctx context.Context, query BirthdaysQuery) (*BirthdayList, error)
+	GroupAnniversaries(ctx context.Context, query GroupAnniversariesQuery) (*GroupAnniversaryList, error)
+	CalendarFeed(ctx context.Context, query CalendarFeedQuery) (*ical.Calendar, error)
+}
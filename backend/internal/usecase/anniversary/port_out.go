@@ -0,0 +1,28 @@
+package anniversary
+
+import (
+	"context"
+	"time"
+
+	groupDomain "github.com/kuro48/idol-api/internal/domain/group"
+	idolDomain "github.com/kuro48/idol-api/internal/domain/idol"
+)
+
+// IdolAppPort は anniversary.Usecase が idol application サービスに要求する契約
+type IdolAppPort interface {
+	Birthdays(ctx context.Context, input PeriodInput) ([]idolDomain.Birthday, int, error)
+}
+
+// GroupAppPort は anniversary.Usecase が group application サービスに要求する契約
+type GroupAppPort interface {
+	Anniversaries(ctx context.Context, input PeriodInput) ([]groupDomain.Anniversary, int, error)
+}
+
+// PeriodInput は誕生日・結成記念日を探す期間（両端を含む日付、Asia/Tokyo の0時）と取得範囲
+type PeriodInput struct {
+	From   time.Time
+	To     time.Time
+	Years  *int // 結成記念日の周年の絞り込み（nil は絞り込みなし）
+	Offset int
+	Limit  int
+}
@@ -0,0 +1,173 @@
+package anniversary
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/shared/monthday"
+)
+
+// jst は誕生日・記念日の日付を決めるタイムゾーン（Asia/Tokyo）
+var jst = time.FixedZone("JST", 9*60*60)
+
+const (
+	// defaultBirthdayDays は to を省略したときの誕生日一覧の日数（from を含む1週間）
+	defaultBirthdayDays = 7
+	// defaultCalendarDays はカレンダー配信の既定の日数
+	defaultCalendarDays = 90
+	// maxCalendarDays はカレンダー配信の日数の上限
+	maxCalendarDays = 366
+	// maxCalendarEntries はカレンダー配信に含める誕生日・結成記念日それぞれの件数の上限
+	maxCalendarEntries = 1000
+	// defaultLimit・maxLimit は一覧の1ページの件数の既定値と上限
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// today は now の日付（Asia/Tokyo の0時）を返す
+func today(now time.Time) time.Time {
+	t := now.In(jst)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, jst)
+}
+
+// BirthdaysQuery は誕生日一覧のクエリ
+type BirthdaysQuery struct {
+	From  *string `form:"from"` // MM-DD（省略時は今日）
+	To    *string `form:"to"`   // MM-DD（省略時は from から1週間。from より前の月日は翌年とする）
+	Page  *int    `form:"page"`
+	Limit *int    `form:"limit"`
+}
+
+// period は対象期間を両端を含む日付に変換する。from は今年の日付とする
+func (q BirthdaysQuery) period(now time.Time) (time.Time, time.Time, error) {
+	from := today(now)
+	if q.From != nil {
+		md, err := monthday.Parse(*q.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("from: %w", err)
+		}
+		from = md.In(from.Year(), jst)
+	}
+	to := from.AddDate(0, 0, defaultBirthdayDays-1)
+	if q.To != nil {
+		md, err := monthday.Parse(*q.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("to: %w", err)
+		}
+		to = md.In(from.Year(), jst)
+		if to.Before(from) {
+			to = md.In(from.Year()+1, jst)
+		}
+	}
+	return from, to, nil
+}
+
+// GroupAnniversariesQuery は結成記念日一覧のクエリ
+type GroupAnniversariesQuery struct {
+	Month *string `form:"month"` // YYYY-MM または MM（今年）。省略時は今月
+	Years *int    `form:"years"` // 指定した周年のみ（例: 10）
+	Page  *int    `form:"page"`
+	Limit *int    `form:"limit"`
+}
+
+// pagination は page・limit を既定値と上限で正規化する
+func pagination(page, limit *int) (int, int) {
+	p, l := 1, defaultLimit
+	if page != nil && *page > 1 {
+		p = *page
+	}
+	if limit != nil && *limit >= 1 {
+		l = min(*limit, maxLimit)
+	}
+	return p, l
+}
+
+// period は対象月の初日と末日を返す
+func (q GroupAnniversariesQuery) period(now time.Time) (time.Time, time.Time, error) {
+	t := today(now)
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, jst)
+	if q.Month != nil {
+		parsed, err := time.Parse("2006-01", *q.Month)
+		if err != nil {
+			parsed, err = time.Parse("01", *q.Month)
+			if err != nil {
+				return time.Time{}, time.Time{}, errors.New("month の形式が不正です: YYYY-MM または MM で指定してください")
+			}
+			parsed = parsed.AddDate(t.Year(), 0, 0)
+		}
+		from = time.Date(parsed.Year(), parsed.Month(), 1, 0, 0, 0, 0, jst)
+	}
+	if q.Years != nil && *q.Years < 1 {
+		return time.Time{}, time.Time{}, errors.New("years は1以上で指定してください（無効な周年）")
+	}
+	return from, from.AddDate(0, 1, -1), nil
+}
+
+// CalendarFeedQuery は誕生日・結成記念日のカレンダー配信（iCalendar）クエリ
+type CalendarFeedQuery struct {
+	From *string `form:"from"` // YYYY-MM-DD（省略時は今日）
+	Days *int    `form:"days"` // 日数（既定90、最大366）
+}
+
+// period は対象期間を両端を含む日付に変換する
+func (q CalendarFeedQuery) period(now time.Time) (time.Time, time.Time, error) {
+	from := today(now)
+	if q.From != nil {
+		t, err := time.ParseInLocation("2006-01-02", *q.From, jst)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from の形式が不正です: YYYY-MM-DD で指定してください")
+		}
+		from = t
+	}
+	days := defaultCalendarDays
+	if q.Days != nil {
+		if *q.Days < 1 || *q.Days > maxCalendarDays {
+			return time.Time{}, time.Time{}, fmt.Errorf("days は1〜%dで指定してください（無効な日数）", maxCalendarDays)
+		}
+		days = *q.Days
+	}
+	return from, from.AddDate(0, 0, days-1), nil
+}
+
+// BirthdayDTO は期間内の誕生日
+type BirthdayDTO struct {
+	IdolID    string `json:"idol_id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Birthdate string `json:"birthdate"` // 生年月日（YYYY-MM-DD）
+	Date      string `json:"date"`      // 期間内の誕生日（YYYY-MM-DD。閏日生まれは閏年以外で2月28日）
+	Age       int    `json:"age"`       // この誕生日で迎える年齢
+}
+
+// GroupAnniversaryDTO は期間内の結成記念日
+type GroupAnniversaryDTO struct {
+	GroupID       string `json:"group_id"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	FormationDate string `json:"formation_date"` // 結成日（YYYY-MM-DD）
+	Date          string `json:"date"`           // 期間内の結成記念日（YYYY-MM-DD）
+	Years         int    `json:"years"`          // 周年
+}
+
+// PeriodMeta は一覧の対象期間とページネーション情報
+type PeriodMeta struct {
+	From       string `json:"from"` // YYYY-MM-DD
+	To         string `json:"to"`   // YYYY-MM-DD（この日を含む）
+	Total      int    `json:"total"`
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	TotalPages int    `json:"total_pages"`
+}
+
+// BirthdayList は誕生日一覧
+type BirthdayList struct {
+	Data []BirthdayDTO `json:"data"`
+	Meta PeriodMeta    `json:"meta"`
+}
+
+// GroupAnniversaryList は結成記念日一覧
+type GroupAnniversaryList struct {
+	Data []GroupAnniversaryDTO `json:"data"`
+	Meta PeriodMeta            `json:"meta"`
+}
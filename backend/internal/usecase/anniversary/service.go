@@ -0,0 +1,167 @@
+package anniversary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	groupDomain "github.com/kuro48/idol-api/internal/domain/group"
+	idolDomain "github.com/kuro48/idol-api/internal/domain/idol"
+	"github.com/kuro48/idol-api/internal/shared/ical"
+)
+
+// calendarProdID は配信するカレンダーの PRODID
+const calendarProdID = "-//idol-api//anniversaries//JA"
+
+const dateLayout = "2006-01-02"
+
+// Usecase は誕生日・結成記念日のユースケース
+type Usecase struct {
+	idolApp  IdolAppPort
+	groupApp GroupAppPort
+}
+
+// NewUsecase はユースケースを作成する
+func NewUsecase(idolApp IdolAppPort, groupApp GroupAppPort) *Usecase {
+	return &Usecase{idolApp: idolApp, groupApp: groupApp}
+}
+
+// Birthdays は期間内に誕生日を迎えるアイドルを日付順に返す
+func (u *Usecase) Birthdays(ctx context.Context, query BirthdaysQuery) (*BirthdayList, error) {
+	from, to, err := query.period(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	page, limit := pagination(query.Page, query.Limit)
+	birthdays, total, err := u.idolApp.Birthdays(ctx, PeriodInput{From: from, To: to, Offset: (page - 1) * limit, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]BirthdayDTO, 0, len(birthdays))
+	for _, b := range birthdays {
+		data = append(data, BirthdayDTO{
+			IdolID:    b.Idol.ID().Value(),
+			Name:      b.Idol.Name().Value(),
+			Status:    string(b.Idol.Status()),
+			Birthdate: b.Idol.Birthdate().String(),
+			Date:      b.Date.Format(dateLayout),
+			Age:       b.Age,
+		})
+	}
+	return &BirthdayList{Data: data, Meta: periodMeta(from, to, total, page, limit)}, nil
+}
+
+// GroupAnniversaries は対象月に結成記念日を迎えるグループを日付順に返す
+func (u *Usecase) GroupAnniversaries(ctx context.Context, query GroupAnniversariesQuery) (*GroupAnniversaryList, error) {
+	from, to, err := query.period(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	page, limit := pagination(query.Page, query.Limit)
+	anniversaries, total, err := u.groupApp.Anniversaries(ctx, PeriodInput{
+		From:   from,
+		To:     to,
+		Years:  query.Years,
+		Offset: (page - 1) * limit,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]GroupAnniversaryDTO, 0, len(anniversaries))
+	for _, a := range anniversaries {
+		data = append(data, GroupAnniversaryDTO{
+			GroupID:       a.Group.ID().Value(),
+			Name:          a.Group.Name().Value(),
+			Status:        string(a.Group.Status()),
+			FormationDate: a.Group.FormationDate().String(),
+			Date:          a.Date.Format(dateLayout),
+			Years:         a.Years,
+		})
+	}
+	return &GroupAnniversaryList{Data: data, Meta: periodMeta(from, to, total, page, limit)}, nil
+}
+
+// CalendarFeed はアイドルの誕生日とグループの結成記念日をまとめた終日予定のカレンダーを返す。
+// 誕生日・結成記念日のどちらかが上限件数を超える期間は、一部だけを配信せずエラーにする
+func (u *Usecase) CalendarFeed(ctx context.Context, query CalendarFeedQuery) (*ical.Calendar, error) {
+	from, to, err := query.period(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	input := PeriodInput{From: from, To: to, Limit: maxCalendarEntries}
+	birthdays, total, err := u.idolApp.Birthdays(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if total > maxCalendarEntries {
+		return nil, calendarTooLargeError(total)
+	}
+	anniversaries, total, err := u.groupApp.Anniversaries(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if total > maxCalendarEntries {
+		return nil, calendarTooLargeError(total)
+	}
+
+	events := make([]ical.Event, 0, len(birthdays)+len(anniversaries))
+	for _, b := range birthdays {
+		events = append(events, birthdayCalendarEvent(b))
+	}
+	for _, a := range anniversaries {
+		events = append(events, anniversaryCalendarEvent(a))
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+
+	return &ical.Calendar{ProdID: calendarProdID, Name: "誕生日・結成記念日", Events: events}, nil
+}
+
+// birthdayCalendarEvent は誕生日を終日予定に変換する。UID は年ごとに分ける
+func birthdayCalendarEvent(b idolDomain.Birthday) ical.Event {
+	return ical.Event{
+		UID:          fmt.Sprintf("birthday-%s-%d@idol-api", b.Idol.ID().Value(), b.Date.Year()),
+		Summary:      fmt.Sprintf("%s 誕生日（%d歳）", b.Idol.Name().Value(), b.Age),
+		Description:  "生年月日: " + b.Idol.Birthdate().String(),
+		Start:        b.Date,
+		AllDay:       true,
+		Created:      b.Idol.CreatedAt(),
+		LastModified: b.Idol.UpdatedAt(),
+	}
+}
+
+// anniversaryCalendarEvent は結成記念日を終日予定に変換する。UID は年ごとに分ける
+func anniversaryCalendarEvent(a groupDomain.Anniversary) ical.Event {
+	return ical.Event{
+		UID:          fmt.Sprintf("anniversary-%s-%d@idol-api", a.Group.ID().Value(), a.Date.Year()),
+		Summary:      fmt.Sprintf("%s 結成%d周年", a.Group.Name().Value(), a.Years),
+		Description:  "結成日: " + a.Group.FormationDate().String(),
+		Start:        a.Date,
+		AllDay:       true,
+		Created:      a.Group.CreatedAt(),
+		LastModified: a.Group.UpdatedAt(),
+	}
+}
+
+// calendarTooLargeError は期間内の件数がカレンダー配信の上限を超えたときのエラーを返す
+func calendarTooLargeError(total int) error {
+	return fmt.Errorf("期間内の予定が%d件あり、カレンダー配信の上限（%d件）を超えるため無効な期間です: days を小さくしてください", total, maxCalendarEntries)
+}
+
+func periodMeta(from, to time.Time, total, page, perPage int) PeriodMeta {
+	totalPages := total / perPage
+	if total%perPage != 0 {
+		totalPages++
+	}
+	return PeriodMeta{
+		From:       from.Format(dateLayout),
+		To:         to.Format(dateLayout),
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+		TotalPages: totalPages,
+	}
+}
@@ -0,0 +1,146 @@
+package anniversary
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	groupDomain "github.com/kuro48/idol-api/internal/domain/group"
+	idolDomain "github.com/kuro48/idol-api/internal/domain/idol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, jst)
+}
+
+func TestBirthdaysQuery_Period(t *testing.T) {
+	now := time.Date(2026, 10, 17, 16, 0, 0, 0, time.UTC) // JST では10月18日
+
+	from, to, err := BirthdaysQuery{}.period(now)
+	require.NoError(t, err)
+	assert.Equal(t, date(2026, 10, 18), from)
+	assert.Equal(t, date(2026, 10, 24), to)
+
+	// to が from より前の月日なら翌年
+	from, to, err = BirthdaysQuery{From: strPtr("12-28"), To: strPtr("01-03")}.period(now)
+	require.NoError(t, err)
+	assert.Equal(t, date(2026, 12, 28), from)
+	assert.Equal(t, date(2027, 1, 3), to)
+
+	_, _, err = BirthdaysQuery{From: strPtr("2026-10-18")}.period(now)
+	assert.ErrorContains(t, err, "形式が不正")
+}
+
+func TestGroupAnniversariesQuery_Period(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, jst)
+
+	from, to, err := GroupAnniversariesQuery{}.period(now)
+	require.NoError(t, err)
+	assert.Equal(t, date(2026, 10, 1), from)
+	assert.Equal(t, date(2026, 10, 31), to)
+
+	from, to, err = GroupAnniversariesQuery{Month: strPtr("02")}.period(now)
+	require.NoError(t, err)
+	assert.Equal(t, date(2026, 2, 1), from)
+	assert.Equal(t, date(2026, 2, 28), to)
+
+	from, _, err = GroupAnniversariesQuery{Month: strPtr("2027-01")}.period(now)
+	require.NoError(t, err)
+	assert.Equal(t, date(2027, 1, 1), from)
+
+	zero := 0
+	_, _, err = GroupAnniversariesQuery{Years: &zero}.period(now)
+	assert.ErrorContains(t, err, "無効な周年")
+	_, _, err = GroupAnniversariesQuery{Month: strPtr("13")}.period(now)
+	assert.ErrorContains(t, err, "形式が不正")
+}
+
+type idolAppStub struct {
+	birthdays []idolDomain.Birthday
+	total     int
+}
+
+func (s *idolAppStub) Birthdays(context.Context, PeriodInput) ([]idolDomain.Birthday, int, error) {
+	return s.birthdays, max(s.total, len(s.birthdays)), nil
+}
+
+type groupAppStub struct {
+	anniversaries []groupDomain.Anniversary
+	total         int
+	input         PeriodInput
+}
+
+func (s *groupAppStub) Anniversaries(_ context.Context, input PeriodInput) ([]groupDomain.Anniversary, int, error) {
+	s.input = input
+	return s.anniversaries, max(s.total, len(s.anniversaries)), nil
+}
+
+func TestUsecase_CalendarFeed(t *testing.T) {
+	name, err := idolDomain.NewIdolName("星野みく")
+	require.NoError(t, err)
+	birthdate, err := idolDomain.NewBirthdate(2004, 11, 3)
+	require.NoError(t, err)
+	idol, err := idolDomain.NewIdol(name, &birthdate)
+	require.NoError(t, err)
+	idolID, err := idolDomain.NewIdolID("idol-1")
+	require.NoError(t, err)
+	idol.SetID(idolID)
+
+	groupName, err := groupDomain.NewGroupName("テストグループ")
+	require.NoError(t, err)
+	formation, err := groupDomain.NewFormationDate(2016, 10, 20)
+	require.NoError(t, err)
+	group, err := groupDomain.NewGroup(groupName, &formation)
+	require.NoError(t, err)
+	groupID, err := groupDomain.NewGroupID("group-1")
+	require.NoError(t, err)
+	group.SetID(groupID)
+
+	idols := &idolAppStub{birthdays: []idolDomain.Birthday{{Idol: idol, Date: date(2026, 11, 3), Age: 22}}}
+	groups := &groupAppStub{anniversaries: []groupDomain.Anniversary{{Group: group, Date: date(2026, 10, 20), Years: 10}}}
+	u := NewUsecase(idols, groups)
+
+	cal, err := u.CalendarFeed(context.Background(), CalendarFeedQuery{From: strPtr("2026-10-18")})
+	require.NoError(t, err)
+	require.Len(t, cal.Events, 2)
+	assert.Equal(t, "テストグループ 結成10周年", cal.Events[0].Summary)
+	assert.Equal(t, "星野みく 誕生日（22歳）", cal.Events[1].Summary)
+	assert.Equal(t, "birthday-idol-1-2026@idol-api", cal.Events[1].UID)
+	assert.True(t, strings.Contains(string(cal.Encode()), "DTSTART;VALUE=DATE:20261103"))
+
+	tooLong := 400
+	_, err = u.CalendarFeed(context.Background(), CalendarFeedQuery{Days: &tooLong})
+	assert.ErrorContains(t, err, "無効な日数")
+
+	// 上限を超える件数は一部だけ配信せずエラーにする
+	idols.total = maxCalendarEntries + 1
+	_, err = u.CalendarFeed(context.Background(), CalendarFeedQuery{From: strPtr("2026-10-18")})
+	assert.ErrorContains(t, err, "無効な期間")
+}
+
+func TestUsecase_GroupAnniversariesPagination(t *testing.T) {
+	groups := &groupAppStub{total: 45}
+	u := NewUsecase(&idolAppStub{}, groups)
+
+	years, page, limit := 5, 3, 20
+	list, err := u.GroupAnniversaries(context.Background(), GroupAnniversariesQuery{Years: &years, Page: &page, Limit: &limit})
+	require.NoError(t, err)
+	assert.Equal(t, &years, groups.input.Years)
+	assert.Equal(t, 40, groups.input.Offset)
+	assert.Equal(t, 20, groups.input.Limit)
+	assert.Equal(t, 45, list.Meta.Total)
+	assert.Equal(t, 3, list.Meta.Page)
+	assert.Equal(t, 20, list.Meta.PerPage)
+	assert.Equal(t, 3, list.Meta.TotalPages)
+
+	tooMany := 500
+	_, err = u.GroupAnniversaries(context.Background(), GroupAnniversariesQuery{Limit: &tooMany})
+	require.NoError(t, err)
+	assert.Equal(t, maxLimit, groups.input.Limit)
+	assert.Equal(t, 0, groups.input.Offset)
+}
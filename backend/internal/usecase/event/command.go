@@ -18,6 +18,12 @@ type CreateEventCommand struct {
 	OfficialURL   *string          `json:"official_url,omitempty"`
 	Description   *string          `json:"description,omitempty"`
 	Tags          []string         `json:"tags,omitempty"`
+	SeriesID      *string          `json:"series_id,omitempty"` // ツアーID（出演者・タグを引き継ぐ）
+	Strict        bool             `json:"-"`                   // true の場合は日程が重複すると作成しない
+	// CapacityConfigurationID は公演で使った会場の収容構成のID（venue_id の指定が必須）
+	CapacityConfigurationID *string `json:"capacity_configuration_id,omitempty"`
+	// Streaming は配信情報。venue_id と併せて指定するとハイブリッド開催になる
+	Streaming *StreamingInput `json:"streaming,omitempty"`
 }
 
 // UpdateEventCommand はイベント更新コマンド
@@ -30,6 +36,10 @@ type UpdateEventCommand struct {
 	TicketURL     *string `json:"ticket_url,omitempty"`
 	OfficialURL   *string `json:"official_url,omitempty"`
 	Description   *string `json:"description,omitempty"`
+	SeriesID      *string `json:"series_id,omitempty"` // ツアーID（空文字でツアーから外す。元のツアーから引き継いだ出演者・タグは外れる）
+	Strict        bool    `json:"-"`                   // true の場合は日程が重複すると更新しない
+	// CapacityConfigurationID は公演で使った会場の収容構成のID（空文字で解除。会場の変更時に省略すると解除される）
+	CapacityConfigurationID *string `json:"capacity_configuration_id,omitempty"`
 }
 
 // DeleteEventCommand はイベント削除コマンド
@@ -49,3 +59,47 @@ type RemovePerformerCommand struct {
 	EventID     string
 	PerformerID string
 }
+
+// SetlistEntryInput はセットリストの1曲（コマンド用）
+type SetlistEntryInput struct {
+	ReleaseID    *string  `json:"release_id,omitempty"`   // 収録曲を参照する場合のリリースID
+	TrackNumber  *int     `json:"track_number,omitempty"` // 収録曲を参照する場合のトラック番号
+	Title        string   `json:"title,omitempty"`        // 収録曲を参照しない曲（カバー曲など）の曲名
+	IsEncore     bool     `json:"is_encore,omitempty"`
+	PerformerIDs []string `json:"performer_ids,omitempty"` // この曲を披露した出演者（省略時は全員）
+	Note         *string  `json:"note,omitempty"`
+}
+
+// UpdateSetlistCommand はセットリスト更新コマンド（演奏順の一覧で置き換える）
+type UpdateSetlistCommand struct {
+	EventID string
+	Entries []SetlistEntryInput
+}
+
+// RecordAttendanceCommand は動員実績の記録コマンド
+type RecordAttendanceCommand struct {
+	EventID    string `json:"-"`
+	SoldOut    bool   `json:"sold_out"`
+	Attendance *int   `json:"attendance,omitempty" binding:"omitempty,min=0"` // 動員数（未公表なら省略）
+}
+
+// PriceTierInput は配信チケットの価格区分（コマンド用）
+type PriceTierInput struct {
+	Name  string `json:"name" binding:"required,max=50"` // 例: 通常視聴券、アーカイブ付き
+	Price int    `json:"price" binding:"min=0"`          // 円
+}
+
+// StreamingInput は配信情報（コマンド用）
+type StreamingInput struct {
+	Platform     string           `json:"platform" binding:"required"` // youtube, niconico, showroom, twitcasting, zaiko, stagecrowd, streaming_plus, abema, other
+	URL          *string          `json:"url,omitempty" binding:"omitempty,url"`
+	TicketURL    *string          `json:"ticket_url,omitempty" binding:"omitempty,url"` // 視聴チケットの購入URL
+	ArchiveUntil *string          `json:"archive_until,omitempty"`                      // アーカイブの視聴期限（RFC3339形式）
+	PriceTiers   []PriceTierInput `json:"price_tiers,omitempty" binding:"max=10,dive"`
+}
+
+// UpdateStreamingCommand は配信情報の更新コマンド（Streaming が nil なら削除する）
+type UpdateStreamingCommand struct {
+	EventID   string
+	Streaming *StreamingInput
+}
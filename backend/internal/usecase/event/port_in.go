@@ -1,15 +1,25 @@
 package event
 
-import "context"
+import (
+	"context"
+
+	"github.com/kuro48/idol-api/internal/shared/ical"
+)
 
 // EventUseCase はイベントのユースケース Input Port
 type EventUseCase interface {
 	CreateEvent(ctx context.Context, cmd CreateEventCommand) (*EventDTO, error)
 	GetEvent(ctx context.Context, query GetEventQuery) (*EventDTO, error)
 	SearchEvents(ctx context.Context, query ListEventsQuery) (*SearchResult, error)
-	UpdateEvent(ctx context.Context, cmd UpdateEventCommand) error
+	UpdateEvent(ctx context.Context, cmd UpdateEventCommand) ([]ConflictDTO, error)
 	DeleteEvent(ctx context.Context, cmd DeleteEventCommand) error
 	AddPerformer(ctx context.Context, cmd AddPerformerCommand) error
 	RemovePerformer(ctx context.Context, cmd RemovePerformerCommand) error
+	UpdateSetlist(ctx context.Context, cmd UpdateSetlistCommand) error
+	RecordAttendance(ctx context.Context, cmd RecordAttendanceCommand) error
+	UpdateStreaming(ctx context.Context, cmd UpdateStreamingCommand) error
 	FindUpcoming(ctx context.Context, limit int) ([]*EventDTO, error)
+	CalendarFeed(ctx context.Context, query CalendarFeedQuery) (*ical.Calendar, error)
+	ConflictReport(ctx context.Context, query ConflictReportQuery) (*ConflictReport, error)
+	CapacityReport(ctx context.Context, query CapacityReportQuery) (*CapacityReport, error)
 }
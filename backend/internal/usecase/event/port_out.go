@@ -2,8 +2,11 @@ package event
 
 import (
 	"context"
+	"time"
 
 	domain "github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/domain/related"
+	"github.com/kuro48/idol-api/internal/shared/geo"
 )
 
 // EventAppPort は event.Usecase が event application サービスに要求する契約
@@ -15,7 +18,21 @@ type EventAppPort interface {
 	DeleteEvent(ctx context.Context, id string) error
 	AddPerformer(ctx context.Context, input EventAddPerformerInput) error
 	RemovePerformer(ctx context.Context, input EventRemovePerformerInput) error
+	UpdateSetlist(ctx context.Context, input EventUpdateSetlistInput) error
 	FindUpcoming(ctx context.Context, limit int) ([]*domain.Event, error)
+	FindConflicts(ctx context.Context, id string) ([]domain.Conflict, error)
+	ConflictReport(ctx context.Context, from, to time.Time) ([]domain.Conflict, error)
+	RecordAttendance(ctx context.Context, input EventRecordAttendanceInput) error
+	UpdateStreaming(ctx context.Context, input EventUpdateStreamingInput) error
+	CapacityReport(ctx context.Context, input EventCapacityReportInput) ([]domain.CapacityStats, error)
+}
+
+// RelatedAppPort は event.Usecase が include 展開のために関連データ読み込みサービスに要求する契約
+type RelatedAppPort interface {
+	FindIdols(ctx context.Context, ids []string) (map[string]related.IdolSummary, error)
+	FindGroups(ctx context.Context, ids []string) (map[string]related.GroupSummary, error)
+	FindVenues(ctx context.Context, ids []string) (map[string]related.VenueSummary, error)
+	FindVenuesNear(ctx context.Context, center geo.Point, radiusKm float64, limit int) (map[string]float64, error)
 }
 
 // EventPerformerInput はパフォーマー入力データ
@@ -26,28 +43,35 @@ type EventPerformerInput struct {
 
 // EventCreateInput はイベント作成の入力
 type EventCreateInput struct {
-	Title         string
-	EventType     string
-	StartDateTime string
-	EndDateTime   *string
-	VenueID       *string
-	Performers    []EventPerformerInput
-	TicketURL     *string
-	OfficialURL   *string
-	Description   *string
-	Tags          []string
+	Title                   string
+	EventType               string
+	StartDateTime           string
+	EndDateTime             *string
+	VenueID                 *string
+	Performers              []EventPerformerInput
+	TicketURL               *string
+	OfficialURL             *string
+	Description             *string
+	Tags                    []string
+	SeriesID                *string
+	Strict                  bool
+	CapacityConfigurationID *string
+	Streaming               *EventStreamingInput
 }
 
 // EventUpdateInput はイベント更新の入力
 type EventUpdateInput struct {
-	ID            string
-	Title         *string
-	StartDateTime *string
-	EndDateTime   *string
-	VenueID       *string
-	TicketURL     *string
-	OfficialURL   *string
-	Description   *string
+	ID                      string
+	Title                   *string
+	StartDateTime           *string
+	EndDateTime             *string
+	VenueID                 *string
+	TicketURL               *string
+	OfficialURL             *string
+	Description             *string
+	SeriesID                *string
+	Strict                  bool
+	CapacityConfigurationID *string
 }
 
 // EventAddPerformerInput はパフォーマー追加の入力
@@ -62,3 +86,55 @@ type EventRemovePerformerInput struct {
 	EventID     string
 	PerformerID string
 }
+
+// EventSetlistEntryInput はセットリストの1曲の入力
+type EventSetlistEntryInput struct {
+	ReleaseID    *string
+	TrackNumber  *int
+	Title        string
+	IsEncore     bool
+	PerformerIDs []string
+	Note         *string
+}
+
+// EventUpdateSetlistInput はセットリスト更新の入力
+type EventUpdateSetlistInput struct {
+	EventID string
+	Entries []EventSetlistEntryInput
+}
+
+// EventRecordAttendanceInput は動員実績の記録の入力
+type EventRecordAttendanceInput struct {
+	EventID    string
+	SoldOut    bool
+	Attendance *int
+}
+
+// EventCapacityReportInput は収容人数レポートの入力
+type EventCapacityReportInput struct {
+	SeriesID    *string
+	PerformerID *string
+	From        time.Time
+	To          time.Time
+}
+
+// EventPriceTierInput は配信チケットの価格区分の入力
+type EventPriceTierInput struct {
+	Name  string
+	Price int
+}
+
+// EventStreamingInput は配信情報の入力
+type EventStreamingInput struct {
+	Platform     string
+	URL          *string
+	TicketURL    *string
+	ArchiveUntil *string
+	PriceTiers   []EventPriceTierInput
+}
+
+// EventUpdateStreamingInput は配信情報の更新の入力
+type EventUpdateStreamingInput struct {
+	EventID   string
+	Streaming *EventStreamingInput
+}
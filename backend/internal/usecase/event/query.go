@@ -1,45 +1,127 @@
 package event
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	"github.com/kuro48/idol-api/internal/shared/geo"
+)
+
+// IncludeTargets は include パラメータで指定できる関連データ
+var IncludeTargets = []string{"venue", "performers"}
 
 // GetEventQuery はイベント取得クエリ
 type GetEventQuery struct {
-	ID string
+	ID            string
+	Include       *string            `form:"include"` // カンマ区切り: "venue,performers"
+	IncludeLimits plan.IncludeLimits `form:"-"`       // 呼び出し元のプランに応じた展開上限
 }
 
 // PerformerDTO はパフォーマーのデータ転送オブジェクト
 type PerformerDTO struct {
 	PerformerID   string `json:"performer_id"`
 	BillingStatus string `json:"billing_status"`
+	Type          string `json:"type,omitempty"` // include=performers時に展開（idol / group）
+	Name          string `json:"name,omitempty"` // include=performers時に展開
+}
+
+// VenueRefDTO は include=venue で展開する会場
+type VenueRefDTO struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Prefecture *string `json:"prefecture,omitempty"`
+	City       *string `json:"city,omitempty"`
+	Address    *string `json:"address,omitempty"`
+}
+
+// SetlistEntryDTO はセットリストの1曲のデータ転送オブジェクト
+type SetlistEntryDTO struct {
+	Position     int      `json:"position"` // 演奏順（1始まり）
+	ReleaseID    *string  `json:"release_id,omitempty"`
+	TrackNumber  *int     `json:"track_number,omitempty"`
+	Title        string   `json:"title"`
+	IsEncore     bool     `json:"is_encore"`
+	PerformerIDs []string `json:"performer_ids,omitempty"`
+	Note         *string  `json:"note,omitempty"`
 }
 
 // EventDTO はイベントのデータ転送オブジェクト
 type EventDTO struct {
-	ID            string         `json:"id"`
-	Title         string         `json:"title"`
-	EventType     string         `json:"event_type"`
-	Status        string         `json:"status"`
-	StartDateTime string         `json:"start_date_time"`
-	EndDateTime   *string        `json:"end_date_time,omitempty"`
-	VenueID       *string        `json:"venue_id,omitempty"`
-	Performers    []PerformerDTO `json:"performers"`
-	TicketURL     *string        `json:"ticket_url,omitempty"`
-	OfficialURL   *string        `json:"official_url,omitempty"`
-	Description   *string        `json:"description,omitempty"`
-	Tags          []string       `json:"tags"`
-	CreatedAt     string         `json:"created_at"`
-	UpdatedAt     string         `json:"updated_at"`
+	ID                      string            `json:"id"`
+	Title                   string            `json:"title"`
+	EventType               string            `json:"event_type"`
+	Status                  string            `json:"status"`
+	StartDateTime           string            `json:"start_date_time"`
+	EndDateTime             *string           `json:"end_date_time,omitempty"`
+	VenueID                 *string           `json:"venue_id,omitempty"`
+	Venue                   *VenueRefDTO      `json:"venue,omitempty"` // include=venue時に展開
+	Performers              []PerformerDTO    `json:"performers"`
+	TicketURL               *string           `json:"ticket_url,omitempty"`
+	OfficialURL             *string           `json:"official_url,omitempty"`
+	Description             *string           `json:"description,omitempty"`
+	Tags                    []string          `json:"tags"`
+	SeriesID                *string           `json:"series_id,omitempty"` // 所属するツアーのID
+	Setlist                 []SetlistEntryDTO `json:"setlist,omitempty"`
+	Conflicts               []ConflictDTO     `json:"conflicts,omitempty"`                 // 作成時のみ: 日程が重複するイベント（警告）
+	DistanceKm              *float64          `json:"distance_km,omitempty"`               // near 指定時のみ: 中心から会場までの距離
+	CapacityConfigurationID *string           `json:"capacity_configuration_id,omitempty"` // 公演で使った会場の収容構成
+	Attendance              *AttendanceDTO    `json:"attendance,omitempty"`
+	AttendanceMode          string            `json:"attendance_mode"` // offline, online, hybrid
+	Streaming               *StreamingDTO     `json:"streaming,omitempty"`
+	CreatedAt               string            `json:"created_at"`
+	UpdatedAt               string            `json:"updated_at"`
+}
+
+// AttendanceDTO はイベントの動員実績
+type AttendanceDTO struct {
+	SoldOut bool `json:"sold_out"`
+	Count   *int `json:"count,omitempty"`
+}
+
+// StreamingDTO はイベントの配信情報
+type StreamingDTO struct {
+	Platform         string         `json:"platform"`
+	URL              *string        `json:"url,omitempty"`
+	TicketURL        *string        `json:"ticket_url,omitempty"`
+	ArchiveUntil     *string        `json:"archive_until,omitempty"`
+	ArchiveAvailable bool           `json:"archive_available"` // 現在アーカイブを視聴できるか
+	PriceTiers       []PriceTierDTO `json:"price_tiers"`
+}
+
+// PriceTierDTO は配信チケットの価格区分
+type PriceTierDTO struct {
+	Name  string `json:"name"`
+	Price int    `json:"price"`
 }
 
 // ListEventsQuery はイベント一覧取得クエリ
 type ListEventsQuery struct {
 	// 検索条件
-	EventType     *string  `form:"event_type"`
-	StartDateFrom *string  `form:"start_date_from"` // YYYY-MM-DD
-	StartDateTo   *string  `form:"start_date_to"`   // YYYY-MM-DD
-	VenueID       *string  `form:"venue_id"`
-	PerformerID   *string  `form:"performer_id"`
-	Tags          []string `form:"tags"`
+	EventType        *string  `form:"event_type"`
+	StartDateFrom    *string  `form:"start_date_from"` // YYYY-MM-DD
+	StartDateTo      *string  `form:"start_date_to"`   // YYYY-MM-DD
+	VenueID          *string  `form:"venue_id"`
+	PerformerID      *string  `form:"performer_id"`
+	Tags             []string `form:"tags"`
+	SeriesID         *string  `form:"series_id"`
+	ReleaseID        *string  `form:"release_id"` // track_number と組み合わせて演奏された曲で絞り込む
+	TrackNumber      *int     `form:"track_number"`
+	Near             *string  `form:"near"`              // "緯度,経度"。半径内の会場で開催されるイベントに絞り込む
+	RadiusKm         *float64 `form:"radius_km"`         // near からの半径（km、既定10、上限100）
+	From             *string  `form:"from"`              // start_date_from の別名（YYYY-MM-DD）
+	To               *string  `form:"to"`                // start_date_to の別名（YYYY-MM-DD）
+	Online           *bool    `form:"online"`            // true: 配信のあるイベント、false: 会場のみのイベント
+	ArchiveAvailable *bool    `form:"archive_available"` // true: 現在アーカイブを視聴できるイベント
+
+	// 関連データの読み込み
+	Include       *string            `form:"include"` // カンマ区切り: "venue,performers"
+	IncludeLimits plan.IncludeLimits `form:"-"`       // 呼び出し元のプランに応じた展開上限
+
+	// レスポンスに含めるフィールド（fields 指定。nil は全フィールド）
+	Fields []string `form:"-"`
 
 	// ソート
 	Sort  *string `form:"sort"`  // start_date_time, created_at
@@ -51,6 +133,12 @@ type ListEventsQuery struct {
 }
 
 func (q *ListEventsQuery) ApplyDefaults() {
+	if q.StartDateFrom == nil {
+		q.StartDateFrom = q.From
+	}
+	if q.StartDateTo == nil {
+		q.StartDateTo = q.To
+	}
 	if q.Page == nil || *q.Page < 1 {
 		defaultPage := 1
 		q.Page = &defaultPage
@@ -87,9 +175,36 @@ func (q *ListEventsQuery) Validate() error {
 			return errors.New("無効なソート順です")
 		}
 	}
+	if (q.ReleaseID == nil) != (q.TrackNumber == nil) {
+		return errors.New("release_id と track_number は両方の指定が必須です")
+	}
+	if q.TrackNumber != nil && *q.TrackNumber < 1 {
+		return errors.New("無効なトラック番号です")
+	}
+	if q.Near == nil && q.RadiusKm != nil {
+		return errors.New("radius_km は near と組み合わせて指定してください（無効な検索条件）")
+	}
+	if q.ArchiveAvailable != nil && !*q.ArchiveAvailable {
+		return errors.New("archive_available は true のみ指定できます（無効な検索条件）")
+	}
+	if _, _, err := q.searchArea(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// searchArea は周辺検索の中心と半径を返す。near が未指定なら nil を返す
+func (q *ListEventsQuery) searchArea() (*geo.Point, float64, error) {
+	if q.Near == nil {
+		return nil, 0, nil
+	}
+	center, radius, err := geo.ParseSearchArea(*q.Near, q.RadiusKm)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &center, radius, nil
+}
+
 // contains はスライスに要素が含まれているかチェック
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -124,3 +239,201 @@ type PaginationLinks struct {
 	Next  *string `json:"next"`
 	Last  string  `json:"last"`
 }
+
+// ConflictEventDTO は日程重複の当事者となるイベント
+type ConflictEventDTO struct {
+	ID            string  `json:"id"`
+	Title         string  `json:"title"`
+	StartDateTime string  `json:"start_date_time"`
+	EndDateTime   *string `json:"end_date_time,omitempty"`
+	VenueID       *string `json:"venue_id,omitempty"`
+}
+
+// ConflictDTO は2つのイベントの日程重複
+type ConflictDTO struct {
+	Kind             string           `json:"kind"`       // performer（出演者が別会場と重複） / venue（会場の重複）
+	SubjectID        string           `json:"subject_id"` // 重複している出演者ID または会場ID
+	Event            ConflictEventDTO `json:"event"`
+	ConflictingEvent ConflictEventDTO `json:"conflicting_event"`
+}
+
+// conflictReportDefaultDays は期間の終了日を省略した日程重複レポートの対象日数
+const conflictReportDefaultDays = 90
+
+// conflictReportMaxDays は日程重複レポートで指定できる期間の上限日数
+const conflictReportMaxDays = 366
+
+// ConflictReportQuery は日程重複レポートのクエリ
+type ConflictReportQuery struct {
+	From *string `form:"from"` // YYYY-MM-DD（省略時は今日）
+	To   *string `form:"to"`   // YYYY-MM-DD（この日を含む。省略時は from から90日間）
+}
+
+// period は対象期間を [from, to) の時刻範囲に変換する
+func (q ConflictReportQuery) period(now time.Time) (time.Time, time.Time, error) {
+	from, err := time.Parse("2006-01-02", now.Format("2006-01-02"))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if q.From != nil {
+		if from, err = time.Parse("2006-01-02", *q.From); err != nil {
+			return time.Time{}, time.Time{}, errors.New("from の形式が不正です: YYYY-MM-DD で指定してください")
+		}
+	}
+	to := from.AddDate(0, 0, conflictReportDefaultDays)
+	if q.To != nil {
+		t, err := time.Parse("2006-01-02", *q.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to の形式が不正です: YYYY-MM-DD で指定してください")
+		}
+		to = t.AddDate(0, 0, 1)
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, errors.New("無効な期間です: to は from 以降の日付を指定してください")
+	}
+	if to.Sub(from) > conflictReportMaxDays*24*time.Hour {
+		return time.Time{}, time.Time{}, fmt.Errorf("無効な期間です: %d日以内で指定してください", conflictReportMaxDays)
+	}
+	return from, to, nil
+}
+
+// ConflictReport は日程重複レポート
+type ConflictReport struct {
+	Data []ConflictDTO      `json:"data"`
+	Meta ConflictReportMeta `json:"meta"`
+}
+
+// ConflictReportMeta は日程重複レポートの対象期間と件数
+type ConflictReportMeta struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Total int    `json:"total"`
+}
+
+// capacityReportDefaultMonths は収容人数レポートの期間を省略したときの月数（今月まで）
+const capacityReportDefaultMonths = 12
+
+// capacityReportMaxMonths は収容人数レポートで指定できる期間の上限月数
+const capacityReportMaxMonths = 60
+
+// CapacityReportQuery は収容人数レポートのクエリ。tour_id か performer_id のどちらか一方を指定する
+type CapacityReportQuery struct {
+	TourID      *string `form:"tour_id"`
+	PerformerID *string `form:"performer_id"`
+	From        *string `form:"from"` // YYYY-MM（省略時は to の11か月前）
+	To          *string `form:"to"`   // YYYY-MM（この月を含む。省略時は今月）
+}
+
+// Validate は集計対象の指定を検証する
+func (q CapacityReportQuery) Validate() error {
+	if (q.TourID == nil) == (q.PerformerID == nil) {
+		return errors.New("tour_id と performer_id のどちらか一方の指定が必須です")
+	}
+	return nil
+}
+
+// period は対象期間を暦月単位の [from, to) の時刻範囲に変換する
+func (q CapacityReportQuery) period(now time.Time) (time.Time, time.Time, error) {
+	to := domain.MonthOf(now).AddDate(0, 1, 0)
+	if q.To != nil {
+		t, err := time.Parse("2006-01", *q.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to の形式が不正です: YYYY-MM で指定してください")
+		}
+		to = domain.MonthOf(t).AddDate(0, 1, 0)
+	}
+	from := to.AddDate(0, -capacityReportDefaultMonths, 0)
+	if q.From != nil {
+		t, err := time.Parse("2006-01", *q.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from の形式が不正です: YYYY-MM で指定してください")
+		}
+		from = domain.MonthOf(t)
+	}
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, errors.New("無効な期間です: to は from 以降の月を指定してください")
+	}
+	if from.AddDate(0, capacityReportMaxMonths, 0).Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("無効な期間です: %dか月以内で指定してください", capacityReportMaxMonths)
+	}
+	return from, to, nil
+}
+
+// CapacityStatsDTO は月ごとの収容人数・動員の集計
+type CapacityStatsDTO struct {
+	Month                    string `json:"month,omitempty"` // YYYY-MM（合計では省略）
+	Events                   int    `json:"events"`
+	CapacityTotal            int    `json:"capacity_total"`
+	UnknownCapacityEvents    int    `json:"unknown_capacity_events"` // 会場・収容人数が未登録で集計できなかったイベント数
+	AttendanceTotal          int    `json:"attendance_total"`
+	AttendanceRecordedEvents int    `json:"attendance_recorded_events"`
+	SoldOutEvents            int    `json:"sold_out_events"`
+}
+
+// CapacityReport は収容人数レポート
+type CapacityReport struct {
+	Data   []CapacityStatsDTO `json:"data"`
+	Totals CapacityStatsDTO   `json:"totals"`
+	Meta   CapacityReportMeta `json:"meta"`
+}
+
+// CapacityReportMeta は収容人数レポートの集計対象と期間
+type CapacityReportMeta struct {
+	TourID      *string `json:"tour_id,omitempty"`
+	PerformerID *string `json:"performer_id,omitempty"`
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+}
+
+// MaxCalendarEvents はカレンダー配信1回あたりのイベント件数上限
+const MaxCalendarEvents = 500
+
+// calendarLookback は開始日の指定がないカレンダー配信で遡る期間
+const calendarLookback = 90 * 24 * time.Hour
+
+// CalendarFeedQuery はイベントのカレンダー配信（iCalendar）クエリ
+type CalendarFeedQuery struct {
+	EventType     *string  `form:"event_type"`
+	StartDateFrom *string  `form:"start_date_from"` // YYYY-MM-DD（省略時は90日前から）
+	StartDateTo   *string  `form:"start_date_to"`   // YYYY-MM-DD
+	VenueID       *string  `form:"venue_id"`
+	PerformerID   *string  `form:"performer_id"`
+	Tags          []string `form:"tags"`
+	SeriesID      *string  `form:"series_id"`
+}
+
+// Validate は日付の形式を検証する
+func (q CalendarFeedQuery) Validate() error {
+	for _, d := range []*string{q.StartDateFrom, q.StartDateTo} {
+		if d == nil {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", *d); err != nil {
+			return errors.New("開始日の形式が不正です: YYYY-MM-DD で指定してください")
+		}
+	}
+	return nil
+}
+
+// toListQuery は開始日時の昇順で上限件数までを取得する一覧クエリに変換する
+func (q CalendarFeedQuery) toListQuery(now time.Time) ListEventsQuery {
+	from := q.StartDateFrom
+	if from == nil {
+		d := now.Add(-calendarLookback).Format("2006-01-02")
+		from = &d
+	}
+	sort, order, page, limit := "start_date_time", "asc", 1, MaxCalendarEvents
+	return ListEventsQuery{
+		EventType:     q.EventType,
+		StartDateFrom: from,
+		StartDateTo:   q.StartDateTo,
+		VenueID:       q.VenueID,
+		PerformerID:   q.PerformerID,
+		Tags:          q.Tags,
+		SeriesID:      q.SeriesID,
+		Sort:          &sort,
+		Order:         &order,
+		Page:          &page,
+		Limit:         &limit,
+	}
+}
@@ -2,22 +2,38 @@ package event
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"math"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	domain "github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/domain/related"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
+	"github.com/kuro48/idol-api/internal/shared/geo"
+	"github.com/kuro48/idol-api/internal/shared/ical"
 )
 
+// calendarProdID は配信するカレンダーの PRODID
+const calendarProdID = "-//idol-api//events//JA"
+
+// maxNearbyVenues は周辺検索でイベントを絞り込む会場数の上限。
+// 半径内の会場がこれを超える場合は一部の会場のイベントが欠けるため、検索を受け付けない
+const maxNearbyVenues = 1000
+
 // Usecase はイベントのユースケース
 type Usecase struct {
 	appService EventAppPort
+	relatedApp RelatedAppPort
 }
 
 // NewUsecase はユースケースを作成する
-func NewUsecase(appService EventAppPort) *Usecase {
-	return &Usecase{appService: appService}
+func NewUsecase(appService EventAppPort, relatedApp RelatedAppPort) *Usecase {
+	return &Usecase{appService: appService, relatedApp: relatedApp}
 }
 
 // CreateEvent はイベントを作成する
@@ -30,41 +46,95 @@ func (u *Usecase) CreateEvent(ctx context.Context, cmd CreateEventCommand) (*Eve
 		})
 	}
 
+	var streaming *EventStreamingInput
+	if cmd.Streaming != nil {
+		s := toStreamingInput(*cmd.Streaming)
+		streaming = &s
+	}
+
 	entity, err := u.appService.CreateEvent(ctx, EventCreateInput{
-		Title:         cmd.Title,
-		EventType:     cmd.EventType,
-		StartDateTime: cmd.StartDateTime,
-		EndDateTime:   cmd.EndDateTime,
-		VenueID:       cmd.VenueID,
-		Performers:    performers,
-		TicketURL:     cmd.TicketURL,
-		OfficialURL:   cmd.OfficialURL,
-		Description:   cmd.Description,
-		Tags:          cmd.Tags,
+		Title:                   cmd.Title,
+		EventType:               cmd.EventType,
+		StartDateTime:           cmd.StartDateTime,
+		EndDateTime:             cmd.EndDateTime,
+		VenueID:                 cmd.VenueID,
+		Performers:              performers,
+		TicketURL:               cmd.TicketURL,
+		OfficialURL:             cmd.OfficialURL,
+		Description:             cmd.Description,
+		Tags:                    cmd.Tags,
+		SeriesID:                cmd.SeriesID,
+		Strict:                  cmd.Strict,
+		CapacityConfigurationID: cmd.CapacityConfigurationID,
+		Streaming:               streaming,
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	dto := toDTO(entity)
+	if !cmd.Strict {
+		dto.Conflicts = u.findConflicts(ctx, dto.ID)
+	}
 	return &dto, nil
 }
 
 // GetEvent はイベントを取得する
 func (u *Usecase) GetEvent(ctx context.Context, query GetEventQuery) (*EventDTO, error) {
+	targets, err := related.ParseIncludes(query.Include, IncludeTargets, query.IncludeLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	entity, err := u.appService.GetEvent(ctx, query.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	dto := toDTO(entity)
+	if err := u.loadIncludes(ctx, []*EventDTO{&dto}, targets); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
+	}
 	return &dto, nil
 }
 
 // SearchEvents は条件を指定してイベントを検索する
 func (u *Usecase) SearchEvents(ctx context.Context, query ListEventsQuery) (*SearchResult, error) {
+	targets, err := related.ParseIncludes(query.Include, IncludeTargets, query.IncludeLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	criteria := u.queryToCriteria(query)
 
+	// 周辺検索は半径内の会場に絞り込み、会場までの距離を返す
+	var distances map[string]float64
+	center, radius, err := query.searchArea()
+	if err != nil {
+		return nil, err
+	}
+	if center != nil {
+		// 上限を超えたかを判定するため1件多く取得する
+		distances, err = u.relatedApp.FindVenuesNear(ctx, *center, radius, maxNearbyVenues+1)
+		if err != nil {
+			return nil, fmt.Errorf("周辺会場の検索エラー: %w", err)
+		}
+		if len(distances) > maxNearbyVenues {
+			return nil, fmt.Errorf("半径内の会場が %d 件を超えるため周辺検索の範囲が不正です。radius_km を小さくしてください", maxNearbyVenues)
+		}
+		if len(distances) == 0 {
+			return &SearchResult{
+				Data:  []*EventDTO{},
+				Meta:  u.calculatePaginationMeta(0, *query.Page, *query.Limit),
+				Links: u.generatePaginationLinks(query, 1),
+			}, nil
+		}
+		criteria.VenueIDs = make([]string, 0, len(distances))
+		for id := range distances {
+			criteria.VenueIDs = append(criteria.VenueIDs, id)
+		}
+	}
+
 	events, total, err := u.appService.SearchEvents(ctx, criteria)
 	if err != nil {
 		return nil, err
@@ -73,8 +143,17 @@ func (u *Usecase) SearchEvents(ctx context.Context, query ListEventsQuery) (*Sea
 	dtos := make([]*EventDTO, 0, len(events))
 	for _, e := range events {
 		dto := toDTO(e)
+		if dto.VenueID != nil {
+			if d, ok := distances[*dto.VenueID]; ok {
+				d = geo.RoundKm(d)
+				dto.DistanceKm = &d
+			}
+		}
 		dtos = append(dtos, &dto)
 	}
+	if err := u.loadIncludes(ctx, dtos, targets); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
+	}
 
 	meta := u.calculatePaginationMeta(total, *query.Page, *query.Limit)
 	links := u.generatePaginationLinks(query, meta.TotalPages)
@@ -86,18 +165,110 @@ func (u *Usecase) SearchEvents(ctx context.Context, query ListEventsQuery) (*Sea
 	}, nil
 }
 
-// UpdateEvent はイベントを更新する
-func (u *Usecase) UpdateEvent(ctx context.Context, cmd UpdateEventCommand) error {
-	return u.appService.UpdateEvent(ctx, EventUpdateInput{
-		ID:            cmd.ID,
-		Title:         cmd.Title,
-		StartDateTime: cmd.StartDateTime,
-		EndDateTime:   cmd.EndDateTime,
-		VenueID:       cmd.VenueID,
-		TicketURL:     cmd.TicketURL,
-		OfficialURL:   cmd.OfficialURL,
-		Description:   cmd.Description,
+// UpdateEvent はイベントを更新し、日程が重複するイベントを警告として返す
+func (u *Usecase) UpdateEvent(ctx context.Context, cmd UpdateEventCommand) ([]ConflictDTO, error) {
+	err := u.appService.UpdateEvent(ctx, EventUpdateInput{
+		ID:                      cmd.ID,
+		Title:                   cmd.Title,
+		StartDateTime:           cmd.StartDateTime,
+		EndDateTime:             cmd.EndDateTime,
+		VenueID:                 cmd.VenueID,
+		TicketURL:               cmd.TicketURL,
+		OfficialURL:             cmd.OfficialURL,
+		Description:             cmd.Description,
+		SeriesID:                cmd.SeriesID,
+		Strict:                  cmd.Strict,
+		CapacityConfigurationID: cmd.CapacityConfigurationID,
 	})
+	if err != nil || cmd.Strict {
+		return nil, err
+	}
+	return u.findConflicts(ctx, cmd.ID), nil
+}
+
+// findConflicts は書き込み後のイベントと日程が重複するイベントを取得する。
+// 重複は警告として返すだけなので、確認に失敗しても書き込み済みの結果は返せるようにログに残して空とする
+func (u *Usecase) findConflicts(ctx context.Context, id string) []ConflictDTO {
+	conflicts, err := u.appService.FindConflicts(ctx, id)
+	if err != nil {
+		slog.Error("日程重複の確認に失敗しました", "event_id", id, "error", err)
+		return nil
+	}
+	return toConflictDTOs(conflicts)
+}
+
+// ConflictReport は期間内に開催されるイベント同士の日程重複を取得する
+func (u *Usecase) ConflictReport(ctx context.Context, query ConflictReportQuery) (*ConflictReport, error) {
+	from, to, err := query.period(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := u.appService.ConflictReport(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	data := toConflictDTOs(conflicts)
+	if data == nil {
+		data = []ConflictDTO{}
+	}
+	return &ConflictReport{
+		Data: data,
+		Meta: ConflictReportMeta{
+			From:  from.Format("2006-01-02"),
+			To:    to.AddDate(0, 0, -1).Format("2006-01-02"),
+			Total: len(data),
+		},
+	}, nil
+}
+
+// CapacityReport はツアーまたは出演者のイベントの収容人数・動員を月ごとに集計する
+func (u *Usecase) CapacityReport(ctx context.Context, query CapacityReportQuery) (*CapacityReport, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	from, to, err := query.period(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := u.appService.CapacityReport(ctx, EventCapacityReportInput{
+		SeriesID:    query.TourID,
+		PerformerID: query.PerformerID,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CapacityReport{
+		Data: make([]CapacityStatsDTO, 0, len(stats)),
+		Meta: CapacityReportMeta{
+			TourID:      query.TourID,
+			PerformerID: query.PerformerID,
+			From:        domain.MonthOf(from).Format("2006-01"),
+			To:          domain.MonthOf(to).AddDate(0, -1, 0).Format("2006-01"),
+		},
+	}
+	for _, s := range stats {
+		dto := CapacityStatsDTO{
+			Month:                    s.Period.Format("2006-01"),
+			Events:                   s.Events,
+			CapacityTotal:            s.CapacityTotal,
+			UnknownCapacityEvents:    s.UnknownCapacity,
+			AttendanceTotal:          s.AttendanceTotal,
+			AttendanceRecordedEvents: s.AttendanceRecorded,
+			SoldOutEvents:            s.SoldOut,
+		}
+		report.Data = append(report.Data, dto)
+
+		report.Totals.Events += dto.Events
+		report.Totals.CapacityTotal += dto.CapacityTotal
+		report.Totals.UnknownCapacityEvents += dto.UnknownCapacityEvents
+		report.Totals.AttendanceTotal += dto.AttendanceTotal
+		report.Totals.AttendanceRecordedEvents += dto.AttendanceRecordedEvents
+		report.Totals.SoldOutEvents += dto.SoldOutEvents
+	}
+	return report, nil
 }
 
 // DeleteEvent はイベントを削除する
@@ -122,6 +293,58 @@ func (u *Usecase) RemovePerformer(ctx context.Context, cmd RemovePerformerComman
 	})
 }
 
+// UpdateStreaming はイベントの配信情報を置き換える（Streaming が nil なら削除する）
+func (u *Usecase) UpdateStreaming(ctx context.Context, cmd UpdateStreamingCommand) error {
+	input := EventUpdateStreamingInput{EventID: cmd.EventID}
+	if cmd.Streaming != nil {
+		s := toStreamingInput(*cmd.Streaming)
+		input.Streaming = &s
+	}
+	return u.appService.UpdateStreaming(ctx, input)
+}
+
+func toStreamingInput(s StreamingInput) EventStreamingInput {
+	tiers := make([]EventPriceTierInput, 0, len(s.PriceTiers))
+	for _, t := range s.PriceTiers {
+		tiers = append(tiers, EventPriceTierInput{Name: t.Name, Price: t.Price})
+	}
+	return EventStreamingInput{
+		Platform:     s.Platform,
+		URL:          s.URL,
+		TicketURL:    s.TicketURL,
+		ArchiveUntil: s.ArchiveUntil,
+		PriceTiers:   tiers,
+	}
+}
+
+// RecordAttendance はイベントの完売・動員数を記録する
+func (u *Usecase) RecordAttendance(ctx context.Context, cmd RecordAttendanceCommand) error {
+	return u.appService.RecordAttendance(ctx, EventRecordAttendanceInput{
+		EventID:    cmd.EventID,
+		SoldOut:    cmd.SoldOut,
+		Attendance: cmd.Attendance,
+	})
+}
+
+// UpdateSetlist はイベントのセットリストを置き換える
+func (u *Usecase) UpdateSetlist(ctx context.Context, cmd UpdateSetlistCommand) error {
+	entries := make([]EventSetlistEntryInput, 0, len(cmd.Entries))
+	for _, e := range cmd.Entries {
+		entries = append(entries, EventSetlistEntryInput{
+			ReleaseID:    e.ReleaseID,
+			TrackNumber:  e.TrackNumber,
+			Title:        e.Title,
+			IsEncore:     e.IsEncore,
+			PerformerIDs: e.PerformerIDs,
+			Note:         e.Note,
+		})
+	}
+	return u.appService.UpdateSetlist(ctx, EventUpdateSetlistInput{
+		EventID: cmd.EventID,
+		Entries: entries,
+	})
+}
+
 // FindUpcoming は今後開催されるイベントを取得する
 func (u *Usecase) FindUpcoming(ctx context.Context, limit int) ([]*EventDTO, error) {
 	events, err := u.appService.FindUpcoming(ctx, limit)
@@ -138,16 +361,171 @@ func (u *Usecase) FindUpcoming(ctx context.Context, limit int) ([]*EventDTO, err
 	return dtos, nil
 }
 
+// CalendarFeed は条件に合うイベントを iCalendar のカレンダーとして取得する。
+// 出演者・会場で絞り込む場合はその名前をカレンダー名に使う
+func (u *Usecase) CalendarFeed(ctx context.Context, query CalendarFeedQuery) (*ical.Calendar, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	name, err := u.calendarName(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	events, _, err := u.appService.SearchEvents(ctx, u.queryToCriteria(query.toListQuery(time.Now())))
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*EventDTO, 0, len(events))
+	for _, e := range events {
+		dto := toDTO(e)
+		dtos = append(dtos, &dto)
+	}
+	if err := u.loadIncludes(ctx, dtos, IncludeTargets); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
+	}
+
+	cal := &ical.Calendar{ProdID: calendarProdID, Name: name, Events: make([]ical.Event, 0, len(events))}
+	for i, e := range events {
+		cal.Events = append(cal.Events, toCalendarEvent(e, dtos[i]))
+	}
+	return cal, nil
+}
+
+// calendarName は絞り込み対象の出演者・会場からカレンダー名を決める
+func (u *Usecase) calendarName(ctx context.Context, query CalendarFeedQuery) (string, error) {
+	switch {
+	case query.PerformerID != nil:
+		id := *query.PerformerID
+		idols, err := u.relatedApp.FindIdols(ctx, []string{id})
+		if err != nil {
+			return "", err
+		}
+		if idol, ok := idols[id]; ok {
+			return idol.Name + " のイベント", nil
+		}
+		groups, err := u.relatedApp.FindGroups(ctx, []string{id})
+		if err != nil {
+			return "", err
+		}
+		if group, ok := groups[id]; ok {
+			return group.Name + " のイベント", nil
+		}
+		return "", errors.New("出演者が見つかりません")
+	case query.VenueID != nil:
+		id := *query.VenueID
+		venues, err := u.relatedApp.FindVenues(ctx, []string{id})
+		if err != nil {
+			return "", err
+		}
+		if venue, ok := venues[id]; ok {
+			return venue.Name + " のイベント", nil
+		}
+		return "", errors.New("会場が見つかりません")
+	}
+	return "イベント", nil
+}
+
+// toCalendarEvent はイベントを VEVENT に変換する。
+// UID はイベントIDから決まるため、同じイベントは何度配信しても同じ予定として扱われる
+func toCalendarEvent(e *domain.Event, dto *EventDTO) ical.Event {
+	ce := ical.Event{
+		UID:          e.ID().Value() + "@idol-api",
+		Sequence:     e.Version(),
+		Summary:      e.Title().Value(),
+		Status:       calendarStatus(e.Status()),
+		Start:        e.StartDateTime(),
+		End:          e.EndDateTime(),
+		Created:      e.CreatedAt(),
+		LastModified: e.UpdatedAt(),
+	}
+	if dto.Venue != nil {
+		ce.Location = venueLocation(dto.Venue)
+	}
+
+	var lines []string
+	if e.Description() != nil && *e.Description() != "" {
+		lines = append(lines, *e.Description())
+	}
+	var names []string
+	for _, p := range dto.Performers {
+		if p.Name != "" {
+			names = append(names, p.Name)
+		}
+	}
+	if len(names) > 0 {
+		lines = append(lines, "出演: "+strings.Join(names, "、"))
+	}
+	switch {
+	case e.TicketURL() != nil:
+		ce.URL = *e.TicketURL()
+		if e.OfficialURL() != nil {
+			lines = append(lines, "公式サイト: "+*e.OfficialURL())
+		}
+	case e.OfficialURL() != nil:
+		ce.URL = *e.OfficialURL()
+	}
+	if s := e.Streaming(); s != nil && s.URL() != nil {
+		lines = append(lines, "配信: "+*s.URL())
+	}
+	ce.Description = strings.Join(lines, "\n\n")
+	return ce
+}
+
+// calendarStatus はイベントステータスを VEVENT の STATUS に対応付ける
+func calendarStatus(s domain.EventStatus) ical.Status {
+	switch s {
+	case domain.EventStatusCancelled:
+		return ical.StatusCancelled
+	case domain.EventStatusPostponed:
+		return ical.StatusTentative
+	}
+	return ical.StatusConfirmed
+}
+
+// venueLocation は会場名と住所（住所がなければ都道府県・市区町村）を LOCATION 用に連結する
+func venueLocation(v *VenueRefDTO) string {
+	parts := []string{v.Name}
+	if v.Address != nil && *v.Address != "" {
+		parts = append(parts, *v.Address)
+		return strings.Join(parts, ", ")
+	}
+	var area string
+	if v.Prefecture != nil {
+		area += *v.Prefecture
+	}
+	if v.City != nil {
+		area += *v.City
+	}
+	if area != "" {
+		parts = append(parts, area)
+	}
+	return strings.Join(parts, ", ")
+}
+
 // queryToCriteria はListEventsQueryをSearchCriteriaに変換
 func (u *Usecase) queryToCriteria(query ListEventsQuery) domain.SearchCriteria {
 	criteria := domain.SearchCriteria{
 		VenueID:     query.VenueID,
 		PerformerID: query.PerformerID,
 		Tags:        query.Tags,
+		SeriesID:    query.SeriesID,
 		Sort:        *query.Sort,
 		Order:       *query.Order,
 		Offset:      (*query.Page - 1) * *query.Limit,
 		Limit:       *query.Limit,
+		Omit:        fieldset.Omitted(query.Fields, "setlist"),
+	}
+
+	criteria.Online = query.Online
+	if query.ArchiveAvailable != nil && *query.ArchiveAvailable {
+		now := time.Now()
+		criteria.ArchiveAvailableAt = &now
+	}
+
+	if query.ReleaseID != nil && query.TrackNumber != nil {
+		criteria.PerformedTrack = &domain.TrackRef{ReleaseID: *query.ReleaseID, TrackNumber: *query.TrackNumber}
 	}
 
 	// イベントタイプの変換
@@ -174,6 +552,66 @@ func (u *Usecase) queryToCriteria(query ListEventsQuery) domain.SearchCriteria {
 	return criteria
 }
 
+// loadIncludes は会場・出演者を一覧全体でまとめて解決してDTOに展開する。
+// 出演者はイベント自体が持つ一覧の名前解決のため、プランの展開件数上限は適用しない
+func (u *Usecase) loadIncludes(ctx context.Context, dtos []*EventDTO, targets []string) error {
+	if related.Has(targets, "venue") {
+		var venueIDs []string
+		for _, dto := range dtos {
+			if dto.VenueID != nil {
+				venueIDs = append(venueIDs, *dto.VenueID)
+			}
+		}
+		venues, err := u.relatedApp.FindVenues(ctx, venueIDs)
+		if err != nil {
+			return err
+		}
+		for _, dto := range dtos {
+			if dto.VenueID == nil {
+				continue
+			}
+			if v, ok := venues[*dto.VenueID]; ok {
+				dto.Venue = &VenueRefDTO{ID: v.ID, Name: v.Name, Prefecture: v.Prefecture, City: v.City, Address: v.Address}
+			}
+		}
+	}
+
+	if related.Has(targets, "performers") {
+		var performerIDs []string
+		for _, dto := range dtos {
+			for _, p := range dto.Performers {
+				performerIDs = append(performerIDs, p.PerformerID)
+			}
+		}
+		// 出演者IDはアイドル・グループのどちらも取りうるため両方を引き当てる
+		idols, err := u.relatedApp.FindIdols(ctx, performerIDs)
+		if err != nil {
+			return err
+		}
+		groups, err := u.relatedApp.FindGroups(ctx, performerIDs)
+		if err != nil {
+			return err
+		}
+		// 改名前の公演は当時の名前で表示する（開始日時を解釈できなければ現在の名前）
+		for _, dto := range dtos {
+			var startAt *time.Time
+			if t, err := time.Parse(time.RFC3339, dto.StartDateTime); err == nil {
+				startAt = &t
+			}
+			for i, p := range dto.Performers {
+				if idol, ok := idols[p.PerformerID]; ok {
+					dto.Performers[i].Type = "idol"
+					dto.Performers[i].Name = idol.NameAsOf(startAt)
+				} else if group, ok := groups[p.PerformerID]; ok {
+					dto.Performers[i].Type = "group"
+					dto.Performers[i].Name = group.NameAsOf(startAt)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // calculatePaginationMeta はページネーション情報を計算
 func (u *Usecase) calculatePaginationMeta(total int64, page, perPage int) *PaginationMeta {
 	totalPages := int(math.Ceil(float64(total) / float64(perPage)))
@@ -218,6 +656,30 @@ func (u *Usecase) generatePaginationLinks(query ListEventsQuery, totalPages int)
 		for _, tag := range query.Tags {
 			params.Add("tags", tag)
 		}
+		if query.SeriesID != nil {
+			params.Set("series_id", *query.SeriesID)
+		}
+		if query.ReleaseID != nil {
+			params.Set("release_id", *query.ReleaseID)
+		}
+		if query.TrackNumber != nil {
+			params.Set("track_number", strconv.Itoa(*query.TrackNumber))
+		}
+		if query.Online != nil {
+			params.Set("online", strconv.FormatBool(*query.Online))
+		}
+		if query.ArchiveAvailable != nil {
+			params.Set("archive_available", strconv.FormatBool(*query.ArchiveAvailable))
+		}
+		if query.Near != nil {
+			params.Set("near", *query.Near)
+		}
+		if query.RadiusKm != nil {
+			params.Set("radius_km", strconv.FormatFloat(*query.RadiusKm, 'f', -1, 64))
+		}
+		if query.Include != nil {
+			params.Set("include", *query.Include)
+		}
 		if query.Sort != nil {
 			params.Set("sort", *query.Sort)
 		}
@@ -246,6 +708,56 @@ func (u *Usecase) generatePaginationLinks(query ListEventsQuery, totalPages int)
 	return links
 }
 
+// toConflictDTOs は日程重複をDTOに変換する
+func toConflictDTOs(conflicts []domain.Conflict) []ConflictDTO {
+	var dtos []ConflictDTO
+	for _, c := range conflicts {
+		dtos = append(dtos, ConflictDTO{
+			Kind:             string(c.Kind),
+			SubjectID:        c.SubjectID,
+			Event:            toConflictEventDTO(c.Event),
+			ConflictingEvent: toConflictEventDTO(c.Other),
+		})
+	}
+	return dtos
+}
+
+func toConflictEventDTO(e *domain.Event) ConflictEventDTO {
+	dto := ConflictEventDTO{
+		ID:            e.ID().Value(),
+		Title:         e.Title().Value(),
+		StartDateTime: e.StartDateTime().Format(time.RFC3339),
+		VenueID:       e.VenueID(),
+	}
+	if e.EndDateTime() != nil {
+		end := e.EndDateTime().Format(time.RFC3339)
+		dto.EndDateTime = &end
+	}
+	return dto
+}
+
+// toStreamingDTO は配信情報をDTOに変換する。アーカイブの視聴可否は now 時点で判定する
+func toStreamingDTO(s *domain.Streaming, now time.Time) *StreamingDTO {
+	if s == nil {
+		return nil
+	}
+	dto := &StreamingDTO{
+		Platform:         string(s.Platform()),
+		URL:              s.URL(),
+		TicketURL:        s.TicketURL(),
+		ArchiveAvailable: s.ArchiveAvailableAt(now),
+		PriceTiers:       make([]PriceTierDTO, 0, len(s.PriceTiers())),
+	}
+	if s.ArchiveUntil() != nil {
+		str := s.ArchiveUntil().Format(time.RFC3339)
+		dto.ArchiveUntil = &str
+	}
+	for _, t := range s.PriceTiers() {
+		dto.PriceTiers = append(dto.PriceTiers, PriceTierDTO{Name: t.Name, Price: t.Price})
+	}
+	return dto
+}
+
 // toDTO はドメインモデルをDTOに変換する
 func toDTO(e *domain.Event) EventDTO {
 	var endDateTime *string
@@ -262,20 +774,44 @@ func toDTO(e *domain.Event) EventDTO {
 		})
 	}
 
+	var setlist []SetlistEntryDTO
+	for i, s := range e.Setlist() {
+		setlist = append(setlist, SetlistEntryDTO{
+			Position:     i + 1,
+			ReleaseID:    s.ReleaseID(),
+			TrackNumber:  s.TrackNumber(),
+			Title:        s.Title(),
+			IsEncore:     s.IsEncore(),
+			PerformerIDs: s.PerformerIDs(),
+			Note:         s.Note(),
+		})
+	}
+
+	var attendance *AttendanceDTO
+	if a := e.Attendance(); a != nil {
+		attendance = &AttendanceDTO{SoldOut: a.SoldOut(), Count: a.Count()}
+	}
+
 	return EventDTO{
-		ID:            e.ID().Value(),
-		Title:         e.Title().Value(),
-		EventType:     e.EventType().Value(),
-		Status:        string(e.Status()),
-		StartDateTime: e.StartDateTime().Format(time.RFC3339),
-		EndDateTime:   endDateTime,
-		VenueID:       e.VenueID(),
-		Performers:    performers,
-		TicketURL:     e.TicketURL(),
-		OfficialURL:   e.OfficialURL(),
-		Description:   e.Description(),
-		Tags:          e.Tags(),
-		CreatedAt:     e.CreatedAt().Format(time.RFC3339),
-		UpdatedAt:     e.UpdatedAt().Format(time.RFC3339),
+		ID:                      e.ID().Value(),
+		Title:                   e.Title().Value(),
+		EventType:               e.EventType().Value(),
+		Status:                  string(e.Status()),
+		StartDateTime:           e.StartDateTime().Format(time.RFC3339),
+		EndDateTime:             endDateTime,
+		VenueID:                 e.VenueID(),
+		Performers:              performers,
+		TicketURL:               e.TicketURL(),
+		OfficialURL:             e.OfficialURL(),
+		Description:             e.Description(),
+		Tags:                    e.Tags(),
+		SeriesID:                e.SeriesID(),
+		Setlist:                 setlist,
+		CapacityConfigurationID: e.CapacityConfigurationID(),
+		Attendance:              attendance,
+		AttendanceMode:          string(e.AttendanceMode()),
+		Streaming:               toStreamingDTO(e.Streaming(), time.Now()),
+		CreatedAt:               e.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:               e.UpdatedAt().Format(time.RFC3339),
 	}
 }
@@ -0,0 +1,137 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/event"
+	"github.com/kuro48/idol-api/internal/shared/ical"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestToCalendarEvent(t *testing.T) {
+	id, _ := domain.NewEventID("evt-1")
+	title, _ := domain.NewEventTitle("夏ライブ")
+	eventType, _ := domain.NewEventType("live")
+	start := time.Date(2025, 8, 1, 18, 0, 0, 0, time.UTC)
+	e := domain.Reconstruct(id, title, eventType, domain.EventStatusCancelled, start, nil, strPtr("venue-1"), nil,
+		strPtr("https://example.com/tickets"), strPtr("https://example.com"), strPtr("雨天決行"), nil, nil, nil, nil, nil, nil, nil, nil, 4, start, start)
+	dto := toDTO(e)
+	dto.Venue = &VenueRefDTO{ID: "venue-1", Name: "日本武道館", Address: strPtr("東京都千代田区北の丸公園2-3")}
+	dto.Performers = []PerformerDTO{{PerformerID: "idol-1", Name: "A"}, {PerformerID: "idol-2", Name: "B"}}
+
+	got := toCalendarEvent(e, &dto)
+
+	assert.Equal(t, "evt-1@idol-api", got.UID)
+	assert.Equal(t, 4, got.Sequence)
+	assert.Equal(t, ical.StatusCancelled, got.Status)
+	assert.Equal(t, "日本武道館, 東京都千代田区北の丸公園2-3", got.Location)
+	assert.Equal(t, "https://example.com/tickets", got.URL)
+	assert.Equal(t, "雨天決行\n\n出演: A、B\n\n公式サイト: https://example.com", got.Description)
+}
+
+func TestVenueLocation_FallsBackToArea(t *testing.T) {
+	assert.Equal(t, "Zepp Haneda, 東京都大田区", venueLocation(&VenueRefDTO{Name: "Zepp Haneda", Prefecture: strPtr("東京都"), City: strPtr("大田区")}))
+	assert.Equal(t, "未定", venueLocation(&VenueRefDTO{Name: "未定"}))
+}
+
+func TestCalendarFeedQuery_DefaultsToRecentWindow(t *testing.T) {
+	now := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	q := CalendarFeedQuery{}.toListQuery(now)
+
+	assert.Equal(t, "2025-01-01", *q.StartDateFrom)
+	assert.Equal(t, MaxCalendarEvents, *q.Limit)
+	assert.Error(t, CalendarFeedQuery{StartDateTo: strPtr("2025/04/01")}.Validate())
+}
+
+func TestConflictReportQuery_Period(t *testing.T) {
+	now := time.Date(2026, 8, 1, 15, 0, 0, 0, time.UTC)
+
+	from, to, err := ConflictReportQuery{}.period(now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, from.AddDate(0, 0, conflictReportDefaultDays), to)
+
+	from, to, err = ConflictReportQuery{From: strPtr("2026-09-01"), To: strPtr("2026-09-30")}.period(now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), to)
+
+	_, _, err = ConflictReportQuery{From: strPtr("2026-09-30"), To: strPtr("2026-09-01")}.period(now)
+	assert.ErrorContains(t, err, "無効な期間")
+	_, _, err = ConflictReportQuery{From: strPtr("2026-01-01"), To: strPtr("2027-12-31")}.period(now)
+	assert.ErrorContains(t, err, "無効な期間")
+	_, _, err = ConflictReportQuery{From: strPtr("09/01")}.period(now)
+	assert.ErrorContains(t, err, "形式が不正")
+}
+
+func TestListEventsQuery_NearSearch(t *testing.T) {
+	q := ListEventsQuery{Near: strPtr("35.6812,139.7671"), From: strPtr("2026-08-01"), To: strPtr("2026-08-31")}
+	q.ApplyDefaults()
+	assert.NoError(t, q.Validate())
+	assert.Equal(t, "2026-08-01", *q.StartDateFrom)
+	assert.Equal(t, "2026-08-31", *q.StartDateTo)
+
+	center, radius, err := q.searchArea()
+	assert.NoError(t, err)
+	assert.InDelta(t, 35.6812, center.Latitude(), 1e-9)
+	assert.Equal(t, 10.0, radius)
+
+	radiusOnly := 5.0
+	q = ListEventsQuery{RadiusKm: &radiusOnly}
+	q.ApplyDefaults()
+	assert.ErrorContains(t, q.Validate(), "near と組み合わせて")
+
+	tooFar := 500.0
+	q = ListEventsQuery{Near: strPtr("35.6812,139.7671"), RadiusKm: &tooFar}
+	q.ApplyDefaults()
+	assert.ErrorContains(t, q.Validate(), "無効な検索半径")
+
+	q = ListEventsQuery{Near: strPtr("91,139.7671")}
+	q.ApplyDefaults()
+	assert.ErrorContains(t, q.Validate(), "無効な座標")
+}
+
+func TestListEventsQuery_StreamingFilters(t *testing.T) {
+	online, archive := true, true
+	q := ListEventsQuery{Online: &online, ArchiveAvailable: &archive}
+	q.ApplyDefaults()
+	assert.NoError(t, q.Validate())
+
+	criteria := (&Usecase{}).queryToCriteria(q)
+	assert.True(t, *criteria.Online)
+	assert.NotNil(t, criteria.ArchiveAvailableAt)
+
+	noArchive := false
+	q = ListEventsQuery{ArchiveAvailable: &noArchive}
+	q.ApplyDefaults()
+	assert.ErrorContains(t, q.Validate(), "true のみ指定できます")
+}
+
+func TestCapacityReportQuery_Period(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 8, 31, 16, 0, 0, 0, time.UTC) // JST では9月1日
+
+	from, to, err := CapacityReportQuery{}.period(now)
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2025, 10, 1, 0, 0, 0, 0, jst).Equal(from))
+	assert.True(t, time.Date(2026, 10, 1, 0, 0, 0, 0, jst).Equal(to))
+
+	from, to, err = CapacityReportQuery{From: strPtr("2026-04"), To: strPtr("2026-06")}.period(now)
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2026, 4, 1, 0, 0, 0, 0, jst).Equal(from))
+	assert.True(t, time.Date(2026, 7, 1, 0, 0, 0, 0, jst).Equal(to))
+
+	_, _, err = CapacityReportQuery{From: strPtr("2026-07"), To: strPtr("2026-06")}.period(now)
+	assert.ErrorContains(t, err, "無効な期間")
+	_, _, err = CapacityReportQuery{From: strPtr("2020-01"), To: strPtr("2026-06")}.period(now)
+	assert.ErrorContains(t, err, "無効な期間")
+	_, _, err = CapacityReportQuery{To: strPtr("2026/06")}.period(now)
+	assert.ErrorContains(t, err, "形式が不正")
+
+	assert.ErrorContains(t, CapacityReportQuery{}.Validate(), "どちらか一方")
+	assert.NoError(t, CapacityReportQuery{TourID: strPtr("tour-1")}.Validate())
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/graph"
+)
+
+// GraphNodeDTO はグラフのノード
+type GraphNodeDTO struct {
+	Key   string `json:"key"` // 種別をまたいで一意なキー（例: "idol:abc"）
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// GraphEdgeDTO はグラフのエッジ。source はアイドル・グループ側、target は所属先・作品・イベント側のノードキー
+type GraphEdgeDTO struct {
+	Source string  `json:"source"`
+	Target string  `json:"target"`
+	Kind   string  `json:"kind"` // member_of, affiliated_with, credited_on, participated_in, performed_at
+	Role   string  `json:"role,omitempty"`
+	Since  *string `json:"since,omitempty"` // 在籍開始日（YYYY-MM-DD）
+	Until  *string `json:"until,omitempty"` // 在籍終了日（YYYY-MM-DD）
+	At     *string `json:"at,omitempty"`    // 発売日・開催日時（RFC3339）
+}
+
+// GraphDTO はアイドル起点の関係グラフ
+type GraphDTO struct {
+	Root      string         `json:"root"`
+	Depth     int            `json:"depth"`
+	Nodes     []GraphNodeDTO `json:"nodes"`
+	Edges     []GraphEdgeDTO `json:"edges"`
+	Truncated bool           `json:"truncated"` // ノード数の上限で打ち切った場合 true
+}
+
+// SharedGroupDTO は共通して在籍したグループ
+type SharedGroupDTO struct {
+	Group      GraphNodeDTO `json:"group"`
+	Overlapped bool         `json:"overlapped"` // 同時に在籍していた
+}
+
+// CoMemberDTO は同じグループに在籍したアイドル
+type CoMemberDTO struct {
+	Idol   GraphNodeDTO     `json:"idol"`
+	Groups []SharedGroupDTO `json:"groups"`
+}
+
+// CoMembersDTO は共演メンバー一覧
+type CoMembersDTO struct {
+	Data []CoMemberDTO `json:"data"`
+}
+
+// CoStarDTO は同じイベントに出演した共演者
+type CoStarDTO struct {
+	Performer  GraphNodeDTO   `json:"performer"`
+	EventCount int            `json:"event_count"`
+	Events     []GraphNodeDTO `json:"events"` // 新しい順、最大5件
+}
+
+// CoStarsDTO は共演者一覧
+type CoStarsDTO struct {
+	Data []CoStarDTO `json:"data"`
+}
+
+// PathDTO は2アイドル間の最短経路
+type PathDTO struct {
+	Found  bool           `json:"found"`
+	Length int            `json:"length"` // ホップ数（見つからない場合は 0）
+	Nodes  []GraphNodeDTO `json:"nodes"`
+	Edges  []GraphEdgeDTO `json:"edges"`
+}
+
+// ToNodeDTO はドメインのノードをDTOに変換する
+func ToNodeDTO(n domain.Node) GraphNodeDTO {
+	return GraphNodeDTO{Key: n.Ref.Key(), Type: string(n.Ref.Type), ID: n.Ref.ID, Label: n.Label}
+}
+
+// ToEdgeDTO はドメインのエッジをDTOに変換する
+func ToEdgeDTO(e domain.Edge) GraphEdgeDTO {
+	return GraphEdgeDTO{
+		Source: e.From.Key(),
+		Target: e.To.Key(),
+		Kind:   string(e.Kind),
+		Role:   e.Role,
+		Since:  formatDate(e.Since),
+		Until:  formatDate(e.Until),
+		At:     formatDateTime(e.At),
+	}
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
+}
+
+func formatDateTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
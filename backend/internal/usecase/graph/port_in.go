@@ -0,0 +1,11 @@
+package graph
+
+import "context"
+
+// GraphUseCase は関係グラフのユースケース Input Port
+type GraphUseCase interface {
+	IdolGraph(ctx context.Context, idolID string, query IdolGraphQuery) (*GraphDTO, error)
+	CoMembers(ctx context.Context, idolID string) (*CoMembersDTO, error)
+	CoStars(ctx context.Context, idolID string, query CoStarsQuery) (*CoStarsDTO, error)
+	ShortestPath(ctx context.Context, idolID string, query PathQuery) (*PathDTO, error)
+}
@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"context"
+
+	domain "github.com/kuro48/idol-api/internal/domain/graph"
+)
+
+// GraphAppPort は graph.Usecase が graph application サービスに要求する契約
+type GraphAppPort interface {
+	IdolGraph(ctx context.Context, input IdolGraphInput) (*domain.Graph, error)
+	CoMembers(ctx context.Context, idolID string) ([]domain.CoMember, error)
+	CoStars(ctx context.Context, input CoStarsInput) ([]domain.CoStar, error)
+	ShortestPath(ctx context.Context, input PathInput) (*domain.Path, error)
+}
+
+// IdolGraphInput はアイドル起点の関係グラフ取得の入力
+type IdolGraphInput struct {
+	IdolID string
+	Depth  int
+}
+
+// CoStarsInput は共演者取得の入力
+type CoStarsInput struct {
+	IdolID string
+	Limit  int
+}
+
+// PathInput は2アイドル間の最短経路取得の入力
+type PathInput struct {
+	FromIdolID string
+	ToIdolID   string
+	MaxDepth   int
+}
@@ -0,0 +1,17 @@
+package graph
+
+// IdolGraphQuery は関係グラフ取得クエリ
+type IdolGraphQuery struct {
+	Depth int `form:"depth"` // 探索ホップ数（既定2、最大3）
+}
+
+// CoStarsQuery は共演者取得クエリ
+type CoStarsQuery struct {
+	Limit int `form:"limit"` // 返却件数（既定20、最大100）
+}
+
+// PathQuery は最短経路取得クエリ
+type PathQuery struct {
+	To       string `form:"to"`        // 終点のアイドルID
+	MaxDepth int    `form:"max_depth"` // 最大ホップ数（既定4、最大6）
+}
@@ -0,0 +1,94 @@
+package graph
+
+import "context"
+
+// Usecase は関係グラフのユースケース
+type Usecase struct {
+	appService GraphAppPort
+}
+
+// NewUsecase はユースケースを作成する
+func NewUsecase(appService GraphAppPort) *Usecase {
+	return &Usecase{appService: appService}
+}
+
+// IdolGraph はアイドル起点の関係グラフを返す
+func (u *Usecase) IdolGraph(ctx context.Context, idolID string, query IdolGraphQuery) (*GraphDTO, error) {
+	g, err := u.appService.IdolGraph(ctx, IdolGraphInput{IdolID: idolID, Depth: query.Depth})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &GraphDTO{
+		Root:      g.Root.Key(),
+		Depth:     g.Depth,
+		Nodes:     make([]GraphNodeDTO, 0, len(g.Nodes)),
+		Edges:     make([]GraphEdgeDTO, 0, len(g.Edges)),
+		Truncated: g.Truncated,
+	}
+	for _, n := range g.Nodes {
+		dto.Nodes = append(dto.Nodes, ToNodeDTO(n))
+	}
+	for _, e := range g.Edges {
+		dto.Edges = append(dto.Edges, ToEdgeDTO(e))
+	}
+	return dto, nil
+}
+
+// CoMembers は同じグループに在籍したことのあるアイドルを返す
+func (u *Usecase) CoMembers(ctx context.Context, idolID string) (*CoMembersDTO, error) {
+	members, err := u.appService.CoMembers(ctx, idolID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]CoMemberDTO, 0, len(members))
+	for _, m := range members {
+		dto := CoMemberDTO{Idol: ToNodeDTO(m.Idol), Groups: make([]SharedGroupDTO, 0, len(m.Groups))}
+		for _, g := range m.Groups {
+			dto.Groups = append(dto.Groups, SharedGroupDTO{Group: ToNodeDTO(g.Group), Overlapped: g.Overlapped})
+		}
+		data = append(data, dto)
+	}
+	return &CoMembersDTO{Data: data}, nil
+}
+
+// CoStars は同じイベントに出演した共演者を返す
+func (u *Usecase) CoStars(ctx context.Context, idolID string, query CoStarsQuery) (*CoStarsDTO, error) {
+	stars, err := u.appService.CoStars(ctx, CoStarsInput{IdolID: idolID, Limit: query.Limit})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]CoStarDTO, 0, len(stars))
+	for _, s := range stars {
+		dto := CoStarDTO{Performer: ToNodeDTO(s.Performer), EventCount: s.EventCount, Events: make([]GraphNodeDTO, 0, len(s.Events))}
+		for _, e := range s.Events {
+			dto.Events = append(dto.Events, ToNodeDTO(e))
+		}
+		data = append(data, dto)
+	}
+	return &CoStarsDTO{Data: data}, nil
+}
+
+// ShortestPath は2アイドル間の最短経路を返す
+func (u *Usecase) ShortestPath(ctx context.Context, idolID string, query PathQuery) (*PathDTO, error) {
+	path, err := u.appService.ShortestPath(ctx, PathInput{FromIdolID: idolID, ToIdolID: query.To, MaxDepth: query.MaxDepth})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &PathDTO{Nodes: []GraphNodeDTO{}, Edges: []GraphEdgeDTO{}}
+	if path == nil {
+		return dto, nil
+	}
+	dto.Found = true
+	dto.Length = len(path.Edges)
+	for _, n := range path.Nodes {
+		dto.Nodes = append(dto.Nodes, ToNodeDTO(n))
+	}
+	for _, e := range path.Edges {
+		dto.Edges = append(dto.Edges, ToEdgeDTO(e))
+	}
+	return dto, nil
+}
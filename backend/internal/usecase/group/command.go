@@ -2,15 +2,30 @@ package group
 
 type CreateGroupCommand struct {
 	Name          string
+	NameKana      *string
 	FormationDate *string
 	DisbandDate   *string
+	ParentGroupID *string
+	RelationType  *string // sub_unit / sister / successor
 }
 
 type UpdateGroupCommand struct {
 	ID            string
 	Name          *string
+	NameKana      *string // nil は変更なし、空文字は削除
 	FormationDate *string
 	DisbandDate   *string
+	ParentGroupID *string            // nil は変更なし、空文字は親グループの指定を解除
+	RelationType  *string            // nil は変更なし
+	RenamedAt     *string            // Name と併せて指定すると改名として旧グループ名を履歴に残す
+	NameHistory   []NameHistoryEntry // nil は変更なし、空スライスは履歴を削除
+}
+
+// NameHistoryEntry は旧グループ名1件の入力
+type NameHistoryEntry struct {
+	Name  string
+	From  *string // "2006-01-02"
+	Until string  // 改名日 "2006-01-02"
 }
 
 type DeleteGroupCommand struct {
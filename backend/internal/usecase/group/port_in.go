@@ -7,6 +7,9 @@ type GroupUseCase interface {
 	CreateGroup(ctx context.Context, cmd CreateGroupCommand) (*GroupDTO, error)
 	GetGroup(ctx context.Context, query GetGroupQuery) (*GroupDTO, error)
 	ListGroup(ctx context.Context, query ListGroupQuery) (*GroupSearchResult, error)
+	ListUnits(ctx context.Context, query ListUnitsQuery) ([]*GroupDTO, error)
+	GetParent(ctx context.Context, id string) (*ParentGroupDTO, error)
+	GetNameAt(ctx context.Context, query NameAtQuery) (*NameAtDTO, error)
 	UpdateGroup(ctx context.Context, cmd UpdateGroupCommand) error
 	DeleteGroup(ctx context.Context, cmd DeleteGroupCommand) error
 }
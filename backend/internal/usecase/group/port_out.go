@@ -4,6 +4,7 @@ import (
 	"context"
 
 	domain "github.com/kuro48/idol-api/internal/domain/group"
+	"github.com/kuro48/idol-api/internal/domain/related"
 )
 
 // GroupAppPort は group.Usecase が group application サービスに要求する契約
@@ -12,21 +13,40 @@ type GroupAppPort interface {
 	GetGroup(ctx context.Context, id string) (*domain.Group, error)
 	ListGroup(ctx context.Context) ([]*domain.Group, error)
 	ListGroupWithPagination(ctx context.Context, opts domain.SearchOptions) (*domain.SearchResult, error)
+	ListUnits(ctx context.Context, id string, relationType *string) ([]*domain.Group, error)
+	GetParent(ctx context.Context, id string) (*domain.Group, domain.RelationType, error)
 	UpdateGroup(ctx context.Context, input GroupUpdateInput) error
 	DeleteGroup(ctx context.Context, id string) error
 }
 
+// RelatedAppPort は group.Usecase が include 展開のために関連データ読み込みサービスに要求する契約
+type RelatedAppPort interface {
+	FindAgencies(ctx context.Context, ids []string) (map[string]related.AgencySummary, error)
+	FindIdols(ctx context.Context, ids []string) (map[string]related.IdolSummary, error)
+	FindSubUnits(ctx context.Context, parentIDs []string) (map[string][]string, error)
+	FindMembershipsByGroups(ctx context.Context, groupIDs []string, limit int) (map[string][]related.MembershipSummary, error)
+	FindReleasesByArtists(ctx context.Context, artistIDs []string, limit int) (map[string][]related.ReleaseSummary, error)
+}
+
 // GroupCreateInput はグループ作成の入力
 type GroupCreateInput struct {
 	Name          string
+	NameKana      *string
 	FormationDate *string
 	DisbandDate   *string
+	ParentGroupID *string
+	RelationType  *string
 }
 
 // GroupUpdateInput はグループ更新の入力
 type GroupUpdateInput struct {
 	ID            string
 	Name          *string
+	NameKana      *string
 	FormationDate *string
 	DisbandDate   *string
+	ParentGroupID *string
+	RelationType  *string
+	RenamedAt     *string
+	NameHistory   []NameHistoryEntry
 }
@@ -1,10 +1,26 @@
 package group
 
-import "errors"
+import (
+	"errors"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/plan"
+)
+
+// IncludeTargets は include パラメータで指定できる関連データ
+var IncludeTargets = []string{"members", "agency", "releases"}
 
 // GetGroupQuery はグループ取得クエリ
 type GetGroupQuery struct {
-	ID string
+	ID            string
+	Include       *string            `form:"include"` // カンマ区切り: "members,agency"
+	IncludeLimits plan.IncludeLimits `form:"-"`       // 呼び出し元のプランに応じた展開上限
+}
+
+// ListUnitsQuery は子グループ（サブユニット・姉妹グループ・後継グループ）一覧取得クエリ
+type ListUnitsQuery struct {
+	ID       string
+	Relation *string `form:"relation"` // sub_unit, sister, successor（省略時はすべて）
 }
 
 // ListGroupQuery はグループ一覧取得クエリ（標準検索仕様準拠）
@@ -12,8 +28,15 @@ type ListGroupQuery struct {
 	// フィルタ
 	Name *string `form:"name"` // 部分一致検索
 
+	// 関連データの読み込み
+	Include       *string            `form:"include"` // カンマ区切り: "members,agency"
+	IncludeLimits plan.IncludeLimits `form:"-"`       // 呼び出し元のプランに応じた展開上限
+
+	// レスポンスに含めるフィールド（fields 指定。nil は全フィールド）
+	Fields []string `form:"-"`
+
 	// ソート
-	Sort  *string `form:"sort"`  // name, formation_date, created_at
+	Sort  *string `form:"sort"`  // name, name_kana, formation_date, created_at
 	Order *string `form:"order"` // asc, desc
 
 	// ページネーション
@@ -48,7 +71,7 @@ func (q *ListGroupQuery) Normalize() {
 // Validate は検索条件の許可リスト検証を行う。
 func (q *ListGroupQuery) Validate() error {
 	if q.Sort != nil {
-		allowedSorts := []string{"name", "formation_date", "created_at"}
+		allowedSorts := []string{"name", "name_kana", "formation_date", "created_at"}
 		if !contains(allowedSorts, *q.Sort) {
 			return errors.New("無効なソート項目です")
 		}
@@ -73,12 +96,64 @@ func contains(slice []string, item string) bool {
 
 // GroupDTO はグループのデータ転送オブジェクト
 type GroupDTO struct {
-	ID            string `json:"id"`
-	Name          string `json:"name"`
-	FormationDate string `json:"formation_date,omitempty"`
-	DisbandDate   string `json:"disband_date,omitempty"`
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	NameKana      *string          `json:"name_kana,omitempty"` // 読み仮名
+	FormationDate string           `json:"formation_date,omitempty"`
+	DisbandDate   string           `json:"disband_date,omitempty"`
+	AgencyID      *string          `json:"agency_id,omitempty"`
+	Parent        *ParentRefDTO    `json:"parent,omitempty"`       // 親グループへの参照
+	NameHistory   []NameHistoryDTO `json:"name_history,omitempty"` // 改名履歴（使用期間付きの旧グループ名）
+	CreatedAt     string           `json:"created_at"`
+	UpdatedAt     string           `json:"updated_at"`
+
+	// include 指定時に展開する関連データ
+	Members  []MemberDTO     `json:"members,omitempty"`
+	Agency   *AgencyRefDTO   `json:"agency,omitempty"`
+	Releases []ReleaseRefDTO `json:"releases,omitempty"`
+}
+
+// ParentRefDTO は親グループへの参照と関係種別
+type ParentRefDTO struct {
+	GroupID      string `json:"group_id"`
+	RelationType string `json:"relation_type"` // sub_unit, sister, successor
+}
+
+// ParentGroupDTO は親グループ取得のレスポンス
+type ParentGroupDTO struct {
+	RelationType string    `json:"relation_type"`
+	Group        *GroupDTO `json:"group"`
+}
+
+// MemberDTO は include=members で展開するメンバー（在籍期間・役割付き）
+type MemberDTO struct {
+	IdolID     string   `json:"idol_id"`
+	Name       string   `json:"name"`
+	NameKana   *string  `json:"name_kana,omitempty"`
+	Role       string   `json:"role"`
+	Generation *string  `json:"generation,omitempty"` // 期生ラベル
+	UnitID     *string  `json:"unit_id,omitempty"`    // サブユニット経由で計上したメンバーの所属ユニット
+	UnitIDs    []string `json:"unit_ids,omitempty"`   // 所属しているサブユニット（親グループとの兼任を含む）
+	JoinedAt   *string  `json:"joined_at,omitempty"`
+	LeftAt     *string  `json:"left_at,omitempty"`
+}
+
+// AgencyRefDTO は include=agency で展開する事務所
+type AgencyRefDTO struct {
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	NameEn          *string `json:"name_en,omitempty"`
+	Country         string  `json:"country"`
+	OfficialWebsite *string `json:"official_website,omitempty"`
+	LogoURL         *string `json:"logo_url,omitempty"`
+}
+
+// ReleaseRefDTO は include=releases で展開するリリースの概要
+type ReleaseRefDTO struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	ReleaseType string `json:"release_type"`
+	ReleaseDate string `json:"release_date"`
 }
 
 // GroupSearchResult はグループ検索結果
@@ -94,3 +169,36 @@ type PaginationMeta struct {
 	PerPage    int   `json:"per_page"`
 	TotalPages int   `json:"total_pages"`
 }
+
+// NameAtQuery は指定日時点のグループ名の取得クエリ
+type NameAtQuery struct {
+	ID string
+	At *string `form:"at"` // "2006-01-02"（省略時は現在）
+}
+
+// Date は基準日を返す
+func (q NameAtQuery) Date() (time.Time, error) {
+	if q.At == nil || *q.At == "" {
+		return time.Now(), nil
+	}
+	at, err := time.Parse("2006-01-02", *q.At)
+	if err != nil {
+		return time.Time{}, errors.New("基準日の形式が不正です: YYYY-MM-DD で指定してください")
+	}
+	return at, nil
+}
+
+// NameAtDTO は指定日時点のグループ名
+type NameAtDTO struct {
+	ID        string `json:"id"`
+	At        string `json:"at"`
+	Name      string `json:"name"`
+	IsCurrent bool   `json:"is_current"` // 現在のグループ名と同じか
+}
+
+// NameHistoryDTO は旧グループ名1件
+type NameHistoryDTO struct {
+	Name  string  `json:"name"`
+	From  *string `json:"from,omitempty"` // 使用開始日
+	Until string  `json:"until"`          // 改名日
+}
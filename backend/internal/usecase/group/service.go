@@ -3,26 +3,37 @@ package group
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	domain "github.com/kuro48/idol-api/internal/domain/group"
+	"github.com/kuro48/idol-api/internal/domain/membership"
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	"github.com/kuro48/idol-api/internal/domain/related"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
 )
 
 // Usecase はグループのユースケース
 type Usecase struct {
 	appService GroupAppPort
+	relatedApp RelatedAppPort
 }
 
 // NewUsecase はユースケースを作成する
-func NewUsecase(appService GroupAppPort) *Usecase {
-	return &Usecase{appService: appService}
+func NewUsecase(appService GroupAppPort, relatedApp RelatedAppPort) *Usecase {
+	return &Usecase{appService: appService, relatedApp: relatedApp}
 }
 
 // CreateGroup はグループを作成する
 func (u *Usecase) CreateGroup(ctx context.Context, cmd CreateGroupCommand) (*GroupDTO, error) {
 	entity, err := u.appService.CreateGroup(ctx, GroupCreateInput{
 		Name:          cmd.Name,
+		NameKana:      cmd.NameKana,
 		FormationDate: cmd.FormationDate,
 		DisbandDate:   cmd.DisbandDate,
+		ParentGroupID: cmd.ParentGroupID,
+		RelationType:  cmd.RelationType,
 	})
 	if err != nil {
 		return nil, err
@@ -34,12 +45,20 @@ func (u *Usecase) CreateGroup(ctx context.Context, cmd CreateGroupCommand) (*Gro
 
 // GetGroup はグループを取得する
 func (u *Usecase) GetGroup(ctx context.Context, query GetGroupQuery) (*GroupDTO, error) {
+	targets, err := related.ParseIncludes(query.Include, IncludeTargets, query.IncludeLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	entity, err := u.appService.GetGroup(ctx, query.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	dto := toDTO(entity)
+	if err := u.loadIncludes(ctx, []*GroupDTO{&dto}, targets, query.IncludeLimits); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
+	}
 	return &dto, nil
 }
 
@@ -49,6 +68,10 @@ func (u *Usecase) ListGroup(ctx context.Context, query ListGroupQuery) (*GroupSe
 	if err := query.Validate(); err != nil {
 		return nil, err
 	}
+	targets, err := related.ParseIncludes(query.Include, IncludeTargets, query.IncludeLimits)
+	if err != nil {
+		return nil, err
+	}
 
 	result, err := u.appService.ListGroupWithPagination(ctx, domain.SearchOptions{
 		Name:  query.Name,
@@ -56,6 +79,7 @@ func (u *Usecase) ListGroup(ctx context.Context, query ListGroupQuery) (*GroupSe
 		Order: *query.Order,
 		Page:  *query.Page,
 		Limit: *query.Limit,
+		Omit:  fieldset.Omitted(query.Fields, "name_history"),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("グループ一覧の取得エラー: %w", err)
@@ -66,6 +90,9 @@ func (u *Usecase) ListGroup(ctx context.Context, query ListGroupQuery) (*GroupSe
 		dto := toDTO(g)
 		dtos = append(dtos, &dto)
 	}
+	if err := u.loadIncludes(ctx, dtos, targets, query.IncludeLimits); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
+	}
 
 	totalPages := int(result.Total) / *query.Limit
 	if int(result.Total)%*query.Limit != 0 {
@@ -83,21 +110,241 @@ func (u *Usecase) ListGroup(ctx context.Context, query ListGroupQuery) (*GroupSe
 	}, nil
 }
 
+// ListUnits はグループを親に持つ子グループの一覧を取得する
+func (u *Usecase) ListUnits(ctx context.Context, query ListUnitsQuery) ([]*GroupDTO, error) {
+	units, err := u.appService.ListUnits(ctx, query.ID, query.Relation)
+	if err != nil {
+		return nil, err
+	}
+	dtos := make([]*GroupDTO, 0, len(units))
+	for _, g := range units {
+		dto := toDTO(g)
+		dtos = append(dtos, &dto)
+	}
+	return dtos, nil
+}
+
+// GetParent はグループの親グループを関係種別とともに取得する
+func (u *Usecase) GetParent(ctx context.Context, id string) (*ParentGroupDTO, error) {
+	parent, relationType, err := u.appService.GetParent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dto := toDTO(parent)
+	return &ParentGroupDTO{RelationType: string(relationType), Group: &dto}, nil
+}
+
 // UpdateGroup はグループを更新する
 func (u *Usecase) UpdateGroup(ctx context.Context, cmd UpdateGroupCommand) error {
 	return u.appService.UpdateGroup(ctx, GroupUpdateInput{
 		ID:            cmd.ID,
 		Name:          cmd.Name,
+		NameKana:      cmd.NameKana,
 		FormationDate: cmd.FormationDate,
 		DisbandDate:   cmd.DisbandDate,
+		ParentGroupID: cmd.ParentGroupID,
+		RelationType:  cmd.RelationType,
+		RenamedAt:     cmd.RenamedAt,
+		NameHistory:   cmd.NameHistory,
 	})
 }
 
+// GetNameAt は指定日時点のグループ名を取得する
+func (u *Usecase) GetNameAt(ctx context.Context, query NameAtQuery) (*NameAtDTO, error) {
+	at, err := query.Date()
+	if err != nil {
+		return nil, err
+	}
+	entity, err := u.appService.GetGroup(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	name := entity.NameAt(at)
+	return &NameAtDTO{
+		ID:        entity.ID().Value(),
+		At:        at.Format("2006-01-02"),
+		Name:      name,
+		IsCurrent: name == entity.Name().Value(),
+	}, nil
+}
+
 // DeleteGroup はグループを削除する
 func (u *Usecase) DeleteGroup(ctx context.Context, cmd DeleteGroupCommand) error {
 	return u.appService.DeleteGroup(ctx, cmd.ID)
 }
 
+// loadIncludes は関連データを一覧全体でまとめて読み込んでDTOに展開する
+func (u *Usecase) loadIncludes(ctx context.Context, dtos []*GroupDTO, targets []string, limits plan.IncludeLimits) error {
+	if len(targets) == 0 || len(dtos) == 0 {
+		return nil
+	}
+	limits = related.EffectiveLimits(limits)
+
+	ids := make([]string, 0, len(dtos))
+	for _, dto := range dtos {
+		ids = append(ids, dto.ID)
+	}
+
+	if related.Has(targets, "members") {
+		if err := u.loadMembers(ctx, dtos, ids, limits.MaxItems); err != nil {
+			return err
+		}
+	}
+
+	if related.Has(targets, "agency") {
+		var agencyIDs []string
+		for _, dto := range dtos {
+			if dto.AgencyID != nil {
+				agencyIDs = append(agencyIDs, *dto.AgencyID)
+			}
+		}
+		agencies, err := u.relatedApp.FindAgencies(ctx, agencyIDs)
+		if err != nil {
+			return err
+		}
+		for _, dto := range dtos {
+			if dto.AgencyID == nil {
+				continue
+			}
+			if a, ok := agencies[*dto.AgencyID]; ok {
+				dto.Agency = &AgencyRefDTO{
+					ID:              a.ID,
+					Name:            a.Name,
+					NameEn:          a.NameEn,
+					Country:         a.Country,
+					OfficialWebsite: a.OfficialWebsite,
+					LogoURL:         a.LogoURL,
+				}
+			}
+		}
+	}
+
+	if related.Has(targets, "releases") {
+		releases, err := u.relatedApp.FindReleasesByArtists(ctx, ids, limits.MaxItems)
+		if err != nil {
+			return err
+		}
+		for _, dto := range dtos {
+			dto.Releases = make([]ReleaseRefDTO, 0, len(releases[dto.ID]))
+			for _, r := range releases[dto.ID] {
+				dto.Releases = append(dto.Releases, ReleaseRefDTO{
+					ID:          r.ID,
+					Title:       r.Title,
+					ReleaseType: r.ReleaseType,
+					ReleaseDate: r.ReleaseDate.Format("2006-01-02"),
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// loadMembers はメンバーシップとアイドル名をまとめて読み込み、加入順にメンバーを展開する。
+// 直下のサブユニットのメンバーシップも親グループのメンバーとして計上する
+func (u *Usecase) loadMembers(ctx context.Context, dtos []*GroupDTO, groupIDs []string, limit int) error {
+	units, err := u.relatedApp.FindSubUnits(ctx, groupIDs)
+	if err != nil {
+		return err
+	}
+	targetIDs := groupIDs
+	for _, unitIDs := range units {
+		targetIDs = append(targetIDs, unitIDs...)
+	}
+	found, err := u.relatedApp.FindMembershipsByGroups(ctx, targetIDs, limit)
+	if err != nil {
+		return err
+	}
+	memberships := make(map[string][]rolledUpMembership, len(groupIDs))
+	for _, id := range groupIDs {
+		memberships[id] = rollUpMemberships(id, units[id], found, limit)
+	}
+	var idolIDs []string
+	for _, ms := range memberships {
+		for _, m := range ms {
+			idolIDs = append(idolIDs, m.IdolID)
+		}
+	}
+	idols, err := u.relatedApp.FindIdols(ctx, idolIDs)
+	if err != nil {
+		return err
+	}
+	for _, dto := range dtos {
+		dto.Members = make([]MemberDTO, 0, len(memberships[dto.ID]))
+		for _, m := range memberships[dto.ID] {
+			idol, ok := idols[m.IdolID]
+			if !ok {
+				continue
+			}
+			member := MemberDTO{
+				IdolID:     idol.ID,
+				Name:       idol.Name,
+				NameKana:   idol.NameKana,
+				Role:       m.Role,
+				Generation: m.Generation,
+				UnitIDs:    m.UnitIDs,
+				JoinedAt:   formatDate(m.JoinedAt),
+				LeftAt:     formatDate(m.LeftAt),
+			}
+			if m.GroupID != dto.ID {
+				unitID := m.GroupID
+				member.UnitID = &unitID
+			}
+			dto.Members = append(dto.Members, member)
+		}
+	}
+	return nil
+}
+
+// rolledUpMembership はサブユニットを統合したメンバーシップ
+type rolledUpMembership struct {
+	related.MembershipSummary
+	UnitIDs []string // 同じアイドルが所属しているサブユニット
+}
+
+// rollUpMemberships はグループ本体とサブユニットのメンバーシップを加入日の古い順に統合し、最大 limit 件に絞る。
+// 同じアイドルは1件にまとめ、親グループのメンバーシップを優先して所属サブユニットを UnitIDs に記録する。
+// 加入日が不明なメンバーシップは先頭に並べる（ストアの昇順ソートと同じ扱い）
+func rollUpMemberships(groupID string, unitIDs []string, found map[string][]related.MembershipSummary, limit int) []rolledUpMembership {
+	if len(unitIDs) == 0 {
+		merged := make([]rolledUpMembership, 0, len(found[groupID]))
+		for _, m := range found[groupID] {
+			merged = append(merged, rolledUpMembership{MembershipSummary: m})
+		}
+		return merged
+	}
+
+	all := append([]related.MembershipSummary{}, found[groupID]...)
+	for _, unitID := range unitIDs {
+		all = append(all, found[unitID]...)
+	}
+	rolled := membership.RollUpSubUnits(groupID, all, func(m related.MembershipSummary) (string, string) {
+		return m.IdolID, m.GroupID
+	})
+	merged := make([]rolledUpMembership, 0, len(rolled))
+	for _, r := range rolled {
+		merged = append(merged, rolledUpMembership{MembershipSummary: r.Membership, UnitIDs: r.UnitIDs})
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i].JoinedAt, merged[j].JoinedAt
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
+}
+
 func toDTO(g *domain.Group) GroupDTO {
 	var formationDate string
 	if g.FormationDate() != nil {
@@ -109,12 +356,32 @@ func toDTO(g *domain.Group) GroupDTO {
 		disbandDate = g.DisbandDate().String()
 	}
 
+	var parent *ParentRefDTO
+	if p := g.Parent(); p != nil {
+		parent = &ParentRefDTO{GroupID: p.GroupID().Value(), RelationType: string(p.RelationType())}
+	}
+
 	return GroupDTO{
 		ID:            g.ID().Value(),
 		Name:          g.Name().Value(),
+		NameKana:      g.Name().Kana(),
 		FormationDate: formationDate,
 		DisbandDate:   disbandDate,
+		AgencyID:      g.AgencyID(),
+		Parent:        parent,
+		NameHistory:   toNameHistoryDTOs(g.NameHistory()),
 		CreatedAt:     g.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:     g.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
+
+func toNameHistoryDTOs(h namehistory.History) []NameHistoryDTO {
+	if len(h) == 0 {
+		return nil
+	}
+	dtos := make([]NameHistoryDTO, 0, len(h))
+	for _, e := range h {
+		dtos = append(dtos, NameHistoryDTO{Name: e.Name(), From: formatDate(e.From()), Until: e.Until().Format("2006-01-02")})
+	}
+	return dtos
+}
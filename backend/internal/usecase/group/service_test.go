@@ -0,0 +1,51 @@
+package group
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/related"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollUpMemberships_MergesSubUnitsByJoinedAt(t *testing.T) {
+	day := func(d int) *time.Time {
+		v := time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	found := map[string][]related.MembershipSummary{
+		"parent": {{ID: "p1", IdolID: "i1", GroupID: "parent", JoinedAt: day(2)}, {ID: "p2", IdolID: "i2", GroupID: "parent", JoinedAt: day(5)}},
+		"unit":   {{ID: "u0", IdolID: "i3", GroupID: "unit"}, {ID: "u1", IdolID: "i4", GroupID: "unit", JoinedAt: day(3)}},
+	}
+
+	merged := rollUpMemberships("parent", []string{"unit"}, found, 3)
+
+	ids := make([]string, 0, len(merged))
+	for _, m := range merged {
+		ids = append(ids, m.ID)
+	}
+	assert.Equal(t, []string{"u0", "p1", "u1"}, ids)
+}
+
+func TestRollUpMemberships_DedupesIdolsAcrossParentAndUnits(t *testing.T) {
+	found := map[string][]related.MembershipSummary{
+		"parent": {{ID: "p1", IdolID: "i1", GroupID: "parent"}},
+		"unit-a": {{ID: "a1", IdolID: "i1", GroupID: "unit-a"}, {ID: "a2", IdolID: "i2", GroupID: "unit-a"}},
+		"unit-b": {{ID: "b1", IdolID: "i1", GroupID: "unit-b"}, {ID: "b2", IdolID: "i2", GroupID: "unit-b"}},
+	}
+
+	merged := rollUpMemberships("parent", []string{"unit-a", "unit-b"}, found, 2)
+
+	assert.Len(t, merged, 2)
+	assert.Equal(t, "p1", merged[0].ID)
+	assert.Equal(t, []string{"unit-a", "unit-b"}, merged[0].UnitIDs)
+	assert.Equal(t, "a2", merged[1].ID)
+	assert.Equal(t, []string{"unit-a", "unit-b"}, merged[1].UnitIDs)
+}
+
+func TestRollUpMemberships_WithoutUnits(t *testing.T) {
+	found := map[string][]related.MembershipSummary{"parent": {{ID: "p1", GroupID: "parent"}}}
+
+	assert.Len(t, rollUpMemberships("parent", nil, found, 10), 1)
+	assert.Empty(t, rollUpMemberships("other", nil, found, 10))
+}
@@ -3,6 +3,7 @@ package idol
 // CreateIdolCommand はアイドル作成コマンド
 type CreateIdolCommand struct {
 	Name      string
+	NameKana  *string
 	Birthdate *string
 	AgencyID  *string
 	Aliases   []string
@@ -10,11 +11,21 @@ type CreateIdolCommand struct {
 
 // UpdateIdolCommand はアイドル更新コマンド
 type UpdateIdolCommand struct {
-	ID        string
-	Name      *string
-	Birthdate *string
-	AgencyID  *string
-	Aliases   []string
+	ID          string
+	Name        *string
+	NameKana    *string // nil は変更なし、空文字は削除
+	Birthdate   *string
+	AgencyID    *string
+	Aliases     []string
+	RenamedAt   *string            // Name と併せて指定すると改名として旧芸名を履歴に残す
+	NameHistory []NameHistoryEntry // nil は変更なし、空スライスは履歴を削除
+}
+
+// NameHistoryEntry は旧芸名1件の入力
+type NameHistoryEntry struct {
+	Name  string
+	From  *string // "2006-01-02"
+	Until string  // 改名日 "2006-01-02"
 }
 
 // DeleteIdolCommand はアイドル削除コマンド
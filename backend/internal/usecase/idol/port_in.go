@@ -6,6 +6,7 @@ import "context"
 type IdolUseCase interface {
 	CreateIdol(ctx context.Context, cmd CreateIdolCommand) (*IdolDTO, error)
 	GetIdol(ctx context.Context, query GetIdolQuery) (*IdolDTO, error)
+	GetNameAt(ctx context.Context, query NameAtQuery) (*NameAtDTO, error)
 	ListIdols(ctx context.Context, query ListIdolsQuery) ([]*IdolDTO, error)
 	SearchIdols(ctx context.Context, query ListIdolsQuery) (*SearchResult, error)
 	UpdateIdol(ctx context.Context, cmd UpdateIdolCommand) error
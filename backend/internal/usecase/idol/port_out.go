@@ -5,6 +5,7 @@ import (
 
 	agencyDomain "github.com/kuro48/idol-api/internal/domain/agency"
 	domain "github.com/kuro48/idol-api/internal/domain/idol"
+	"github.com/kuro48/idol-api/internal/domain/related"
 )
 
 // IdolAppPort は idol.Usecase が idol application サービスに要求する契約
@@ -25,9 +26,20 @@ type AgencyAppPort interface {
 	GetAgency(ctx context.Context, id string) (*agencyDomain.Agency, error)
 }
 
+// RelatedAppPort は idol.Usecase が include 展開のために関連データ読み込みサービスに要求する契約
+type RelatedAppPort interface {
+	FindAgencies(ctx context.Context, ids []string) (map[string]related.AgencySummary, error)
+	FindGroups(ctx context.Context, ids []string) (map[string]related.GroupSummary, error)
+	FindTags(ctx context.Context, ids []string) (map[string]related.TagSummary, error)
+	FindMembershipsByIdols(ctx context.Context, idolIDs []string, limit int) (map[string][]related.MembershipSummary, error)
+	FindReleasesByArtists(ctx context.Context, artistIDs []string, limit int) (map[string][]related.ReleaseSummary, error)
+	FindEventsByPerformers(ctx context.Context, performerIDs []string, limit int) (map[string][]related.EventSummary, error)
+}
+
 // IdolCreateInput はアイドル作成の入力
 type IdolCreateInput struct {
 	Name      string
+	NameKana  *string
 	Birthdate *string
 	AgencyID  *string
 	Aliases   []string
@@ -35,11 +47,14 @@ type IdolCreateInput struct {
 
 // IdolUpdateInput はアイドル更新の入力
 type IdolUpdateInput struct {
-	ID        string
-	Name      *string
-	Birthdate *string
-	AgencyID  *string
-	Aliases   []string
+	ID          string
+	Name        *string
+	NameKana    *string
+	Birthdate   *string
+	AgencyID    *string
+	Aliases     []string
+	RenamedAt   *string
+	NameHistory []NameHistoryEntry
 }
 
 // IdolUpdateSocialLinksInput はSNS/外部リンク更新の入力
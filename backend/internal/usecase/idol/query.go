@@ -1,26 +1,99 @@
 package idol
 
-import "errors"
+import (
+	"errors"
+	"time"
+
+	"github.com/kuro48/idol-api/internal/domain/plan"
+)
+
+// IncludeTargets は include パラメータで指定できる関連データ
+var IncludeTargets = []string{"agency", "groups", "memberships", "releases", "events", "tags"}
 
 // GetIdolQuery はアイドル取得クエリ
 type GetIdolQuery struct {
-	ID      string
-	Include *string `form:"include"` // カンマ区切り: "agency,groups"
+	ID            string
+	Include       *string            `form:"include"` // カンマ区切り: "agency,groups"
+	IncludeLimits plan.IncludeLimits `form:"-"`       // 呼び出し元のプランに応じた展開上限
 }
 
 // IdolDTO はアイドルのデータ転送オブジェクト
 type IdolDTO struct {
 	ID          string            `json:"id"`
 	Name        string            `json:"name"`
+	NameKana    *string           `json:"name_kana,omitempty"` // 読み仮名
 	Birthdate   string            `json:"birthdate,omitempty"`
 	Age         *int              `json:"age,omitempty"`
 	AgencyID    *string           `json:"agency_id,omitempty"`
-	Agency      interface{}       `json:"agency,omitempty"`       // include=agency時に展開
+	Agency      *AgencyRefDTO     `json:"agency,omitempty"`       // include=agency時に展開
 	SocialLinks interface{}       `json:"social_links,omitempty"` // SNS/外部リンク
 	ExternalIDs map[string]string `json:"external_ids,omitempty"` // 外部サービスIDマッピング
 	Aliases     []string          `json:"aliases,omitempty"`      // 別名一覧（多言語・旧名）
+	NameHistory []NameHistoryDTO  `json:"name_history,omitempty"` // 改名履歴（使用期間付きの旧芸名）
+	TagIDs      []string          `json:"tag_ids,omitempty"`
 	CreatedAt   string            `json:"created_at"`
 	UpdatedAt   string            `json:"updated_at"`
+
+	// include 指定時に展開する関連データ
+	Groups      []IdolGroupDTO      `json:"groups,omitempty"`
+	Memberships []IdolMembershipDTO `json:"memberships,omitempty"`
+	Releases    []ReleaseRefDTO     `json:"releases,omitempty"`
+	Events      []EventRefDTO       `json:"events,omitempty"`
+	Tags        []TagRefDTO         `json:"tags,omitempty"`
+}
+
+// AgencyRefDTO は include=agency で展開する事務所
+type AgencyRefDTO struct {
+	ID              string  `json:"id"`
+	Name            string  `json:"name"`
+	NameEn          *string `json:"name_en"`
+	Country         string  `json:"country"`
+	OfficialWebsite *string `json:"official_website"`
+	LogoURL         *string `json:"logo_url"`
+}
+
+// IdolGroupDTO は include=groups で展開する所属グループ（在籍期間・役割付き）
+type IdolGroupDTO struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	NameKana *string `json:"name_kana,omitempty"`
+	Role     string  `json:"role"`
+	JoinedAt *string `json:"joined_at,omitempty"`
+	LeftAt   *string `json:"left_at,omitempty"`
+}
+
+// IdolMembershipDTO は include=memberships で展開するメンバーシップ
+type IdolMembershipDTO struct {
+	ID       string  `json:"id"`
+	GroupID  string  `json:"group_id"`
+	Role     string  `json:"role"`
+	JoinedAt *string `json:"joined_at,omitempty"`
+	LeftAt   *string `json:"left_at,omitempty"`
+}
+
+// ReleaseRefDTO は include=releases で展開するリリースの概要
+type ReleaseRefDTO struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	ReleaseType string `json:"release_type"`
+	ReleaseDate string `json:"release_date"`
+}
+
+// EventRefDTO は include=events で展開するイベントの概要
+type EventRefDTO struct {
+	ID            string  `json:"id"`
+	Title         string  `json:"title"`
+	EventType     string  `json:"event_type"`
+	Status        string  `json:"status"`
+	StartDateTime string  `json:"start_date_time"`
+	VenueID       *string `json:"venue_id,omitempty"`
+}
+
+// TagRefDTO は include=tags で展開するタグ
+type TagRefDTO struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
 }
 
 // ListIdolsQuery はアイドル一覧取得クエリ
@@ -41,10 +114,14 @@ type ListIdolsQuery struct {
 	Tags []string `form:"tags"`
 
 	// 関連データの読み込み
-	Include *string `form:"include"` // カンマ区切り: "agency,groups"
+	Include       *string            `form:"include"` // カンマ区切り: "agency,groups"
+	IncludeLimits plan.IncludeLimits `form:"-"`       // 呼び出し元のプランに応じた展開上限
+
+	// レスポンスに含めるフィールド（fields 指定。nil は全フィールド）
+	Fields []string `form:"-"`
 
 	// ソート
-	Sort  *string `form:"sort"`  // name, birthdate, created_at
+	Sort  *string `form:"sort"`  // name, name_kana, birthdate, created_at
 	Order *string `form:"order"` // asc, desc
 
 	// ページネーション
@@ -78,7 +155,7 @@ func (q *ListIdolsQuery) ApplyDefaults() {
 // バリデーション
 func (q *ListIdolsQuery) Validate() error {
 	if q.Sort != nil {
-		allowedSorts := []string{"name", "birthdate", "created_at"}
+		allowedSorts := []string{"name", "name_kana", "birthdate", "created_at"}
 		if !contains(allowedSorts, *q.Sort) {
 			return errors.New("無効なソート項目です")
 		}
@@ -126,3 +203,36 @@ type PaginationLinks struct {
 	Next  *string `json:"next"`
 	Last  string  `json:"last"`
 }
+
+// NameAtQuery は指定日時点の芸名の取得クエリ
+type NameAtQuery struct {
+	ID string
+	At *string `form:"at"` // "2006-01-02"（省略時は現在）
+}
+
+// Date は基準日を返す
+func (q NameAtQuery) Date() (time.Time, error) {
+	if q.At == nil || *q.At == "" {
+		return time.Now(), nil
+	}
+	at, err := time.Parse("2006-01-02", *q.At)
+	if err != nil {
+		return time.Time{}, errors.New("基準日の形式が不正です: YYYY-MM-DD で指定してください")
+	}
+	return at, nil
+}
+
+// NameAtDTO は指定日時点の芸名
+type NameAtDTO struct {
+	ID        string `json:"id"`
+	At        string `json:"at"`
+	Name      string `json:"name"`
+	IsCurrent bool   `json:"is_current"` // 現在の芸名と同じか
+}
+
+// NameHistoryDTO は旧芸名1件
+type NameHistoryDTO struct {
+	Name  string  `json:"name"`
+	From  *string `json:"from,omitempty"` // 使用開始日
+	Until string  `json:"until"`          // 改名日
+}
@@ -6,21 +6,25 @@ import (
 	"math"
 	"net/url"
 	"strconv"
-	"strings"
 	"time"
 
 	domain "github.com/kuro48/idol-api/internal/domain/idol"
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	"github.com/kuro48/idol-api/internal/domain/related"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
+	"github.com/kuro48/idol-api/internal/shared/namehistory"
 )
 
 // Usecase はアイドルのユースケース
 type Usecase struct {
 	appService IdolAppPort
 	agencyApp  AgencyAppPort
+	relatedApp RelatedAppPort
 }
 
 // NewUsecase はユースケースを作成する
-func NewUsecase(appService IdolAppPort, agencyApp AgencyAppPort) *Usecase {
-	return &Usecase{appService: appService, agencyApp: agencyApp}
+func NewUsecase(appService IdolAppPort, agencyApp AgencyAppPort, relatedApp RelatedAppPort) *Usecase {
+	return &Usecase{appService: appService, agencyApp: agencyApp, relatedApp: relatedApp}
 }
 
 // CreateIdol はアイドルを作成する
@@ -34,6 +38,7 @@ func (u *Usecase) CreateIdol(ctx context.Context, cmd CreateIdolCommand) (*IdolD
 
 	entity, err := u.appService.CreateIdol(ctx, IdolCreateInput{
 		Name:      cmd.Name,
+		NameKana:  cmd.NameKana,
 		Birthdate: cmd.Birthdate,
 		AgencyID:  cmd.AgencyID,
 		Aliases:   cmd.Aliases,
@@ -48,6 +53,11 @@ func (u *Usecase) CreateIdol(ctx context.Context, cmd CreateIdolCommand) (*IdolD
 
 // GetIdol はアイドルを取得する
 func (u *Usecase) GetIdol(ctx context.Context, query GetIdolQuery) (*IdolDTO, error) {
+	targets, err := related.ParseIncludes(query.Include, IncludeTargets, query.IncludeLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	entity, err := u.appService.GetIdol(ctx, query.ID)
 	if err != nil {
 		return nil, err
@@ -56,11 +66,8 @@ func (u *Usecase) GetIdol(ctx context.Context, query GetIdolQuery) (*IdolDTO, er
 	dto := u.toDTO(entity)
 
 	// includeパラメータの処理
-	if query.Include != nil {
-		includes := strings.Split(*query.Include, ",")
-		if err := u.loadIncludes(ctx, dto, includes); err != nil {
-			return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
-		}
+	if err := u.loadIncludes(ctx, []*IdolDTO{dto}, targets, query.IncludeLimits); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
 	}
 
 	return dto, nil
@@ -91,14 +98,36 @@ func (u *Usecase) UpdateIdol(ctx context.Context, cmd UpdateIdolCommand) error {
 	}
 
 	return u.appService.UpdateIdol(ctx, IdolUpdateInput{
-		ID:        cmd.ID,
-		Name:      cmd.Name,
-		Birthdate: cmd.Birthdate,
-		AgencyID:  cmd.AgencyID,
-		Aliases:   cmd.Aliases,
+		ID:          cmd.ID,
+		Name:        cmd.Name,
+		NameKana:    cmd.NameKana,
+		Birthdate:   cmd.Birthdate,
+		AgencyID:    cmd.AgencyID,
+		Aliases:     cmd.Aliases,
+		RenamedAt:   cmd.RenamedAt,
+		NameHistory: cmd.NameHistory,
 	})
 }
 
+// GetNameAt は指定日時点の芸名を取得する
+func (u *Usecase) GetNameAt(ctx context.Context, query NameAtQuery) (*NameAtDTO, error) {
+	at, err := query.Date()
+	if err != nil {
+		return nil, err
+	}
+	entity, err := u.appService.GetIdol(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	name := entity.NameAt(at)
+	return &NameAtDTO{
+		ID:        entity.ID().Value(),
+		At:        at.Format("2006-01-02"),
+		Name:      name,
+		IsCurrent: name == entity.Name().Value(),
+	}, nil
+}
+
 // DeleteIdol はアイドルを削除する
 func (u *Usecase) DeleteIdol(ctx context.Context, cmd DeleteIdolCommand) error {
 	return u.appService.DeleteIdol(ctx, cmd.ID)
@@ -125,6 +154,11 @@ func (u *Usecase) UpdateSocialLinks(ctx context.Context, cmd UpdateSocialLinksCo
 
 // SearchIdols は条件を指定してアイドルを検索する
 func (u *Usecase) SearchIdols(ctx context.Context, query ListIdolsQuery) (*SearchResult, error) {
+	targets, err := related.ParseIncludes(query.Include, IncludeTargets, query.IncludeLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	criteria := u.queryToCriteria(query)
 
 	idols, total, err := u.appService.SearchIdols(ctx, criteria)
@@ -137,14 +171,9 @@ func (u *Usecase) SearchIdols(ctx context.Context, query ListIdolsQuery) (*Searc
 		dtos = append(dtos, u.toDTO(i))
 	}
 
-	// includeパラメータの処理
-	if query.Include != nil {
-		includes := strings.Split(*query.Include, ",")
-		for _, dto := range dtos {
-			if err := u.loadIncludes(ctx, dto, includes); err != nil {
-				return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
-			}
-		}
+	// includeパラメータの処理（一覧全体でまとめて読み込む）
+	if err := u.loadIncludes(ctx, dtos, targets, query.IncludeLimits); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
 	}
 
 	// ページネーション情報を計算
@@ -171,6 +200,7 @@ func (u *Usecase) queryToCriteria(query ListIdolsQuery) domain.SearchCriteria {
 		Order:    *query.Order,
 		Offset:   (*query.Page - 1) * *query.Limit,
 		Limit:    *query.Limit,
+		Omit:     fieldset.Omitted(query.Fields, "social_links", "external_ids", "aliases"),
 	}
 
 	// 生年月日範囲の変換（YYYY-MM-DDからtime.Timeへ）
@@ -266,32 +296,194 @@ func (u *Usecase) generatePaginationLinks(query ListIdolsQuery, totalPages int)
 	return links
 }
 
-// loadIncludes は関連データを読み込んでDTOに展開する
-func (u *Usecase) loadIncludes(ctx context.Context, dto *IdolDTO, includes []string) error {
-	for _, include := range includes {
-		switch strings.TrimSpace(include) {
-		case "agency":
-			if dto.AgencyID != nil {
-				foundAgency, err := u.agencyApp.GetAgency(ctx, *dto.AgencyID)
-				if err != nil {
-					// 事務所が見つからない場合はnilのまま（エラーにしない）
-					continue
-				}
-				dto.Agency = map[string]interface{}{
-					"id":               foundAgency.ID().Value(),
-					"name":             foundAgency.Name().Value(),
-					"name_en":          foundAgency.NameEn(),
-					"country":          foundAgency.Country().Value(),
-					"official_website": foundAgency.OfficialWebsite(),
-					"logo_url":         foundAgency.LogoURL(),
+// loadIncludes は関連データを読み込んでDTOに展開する。
+// 対象ごとに一覧全体のIDをまとめて問い合わせ、N+1 を避ける。展開件数はプランの上限で打ち切る
+func (u *Usecase) loadIncludes(ctx context.Context, dtos []*IdolDTO, targets []string, limits plan.IncludeLimits) error {
+	if len(targets) == 0 || len(dtos) == 0 {
+		return nil
+	}
+	limits = related.EffectiveLimits(limits)
+
+	ids := make([]string, 0, len(dtos))
+	for _, dto := range dtos {
+		ids = append(ids, dto.ID)
+	}
+
+	if related.Has(targets, "agency") {
+		if err := u.loadAgencies(ctx, dtos); err != nil {
+			return err
+		}
+	}
+
+	if related.Has(targets, "groups") || related.Has(targets, "memberships") {
+		memberships, err := u.relatedApp.FindMembershipsByIdols(ctx, ids, limits.MaxItems)
+		if err != nil {
+			return err
+		}
+		if related.Has(targets, "memberships") {
+			for _, dto := range dtos {
+				dto.Memberships = make([]IdolMembershipDTO, 0, len(memberships[dto.ID]))
+				for _, m := range memberships[dto.ID] {
+					dto.Memberships = append(dto.Memberships, IdolMembershipDTO{
+						ID:       m.ID,
+						GroupID:  m.GroupID,
+						Role:     m.Role,
+						JoinedAt: formatDate(m.JoinedAt),
+						LeftAt:   formatDate(m.LeftAt),
+					})
 				}
 			}
-			// 将来的に他のinclude対象（groups, events等）を追加可能
+		}
+		if related.Has(targets, "groups") {
+			if err := u.loadGroups(ctx, dtos, memberships); err != nil {
+				return err
+			}
+		}
+	}
+
+	if related.Has(targets, "releases") {
+		releases, err := u.relatedApp.FindReleasesByArtists(ctx, ids, limits.MaxItems)
+		if err != nil {
+			return err
+		}
+		for _, dto := range dtos {
+			dto.Releases = make([]ReleaseRefDTO, 0, len(releases[dto.ID]))
+			for _, r := range releases[dto.ID] {
+				dto.Releases = append(dto.Releases, ReleaseRefDTO{
+					ID:          r.ID,
+					Title:       r.Title,
+					ReleaseType: r.ReleaseType,
+					ReleaseDate: r.ReleaseDate.Format("2006-01-02"),
+				})
+			}
+		}
+	}
+
+	if related.Has(targets, "events") {
+		events, err := u.relatedApp.FindEventsByPerformers(ctx, ids, limits.MaxItems)
+		if err != nil {
+			return err
+		}
+		for _, dto := range dtos {
+			dto.Events = make([]EventRefDTO, 0, len(events[dto.ID]))
+			for _, e := range events[dto.ID] {
+				dto.Events = append(dto.Events, EventRefDTO{
+					ID:            e.ID,
+					Title:         e.Title,
+					EventType:     e.EventType,
+					Status:        e.Status,
+					StartDateTime: e.StartDateTime.Format(time.RFC3339),
+					VenueID:       e.VenueID,
+				})
+			}
+		}
+	}
+
+	if related.Has(targets, "tags") {
+		if err := u.loadTags(ctx, dtos, limits.MaxItems); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadAgencies は所属事務所を展開する（削除済み・存在しない事務所は展開しない）
+func (u *Usecase) loadAgencies(ctx context.Context, dtos []*IdolDTO) error {
+	agencyIDs := make([]string, 0, len(dtos))
+	for _, dto := range dtos {
+		if dto.AgencyID != nil {
+			agencyIDs = append(agencyIDs, *dto.AgencyID)
+		}
+	}
+	if len(agencyIDs) == 0 {
+		return nil
+	}
+	agencies, err := u.relatedApp.FindAgencies(ctx, agencyIDs)
+	if err != nil {
+		return err
+	}
+	for _, dto := range dtos {
+		if dto.AgencyID == nil {
+			continue
+		}
+		if a, ok := agencies[*dto.AgencyID]; ok {
+			dto.Agency = &AgencyRefDTO{
+				ID:              a.ID,
+				Name:            a.Name,
+				NameEn:          a.NameEn,
+				Country:         a.Country,
+				OfficialWebsite: a.OfficialWebsite,
+				LogoURL:         a.LogoURL,
+			}
+		}
+	}
+	return nil
+}
+
+// loadGroups はメンバーシップを元に所属グループを展開する
+func (u *Usecase) loadGroups(ctx context.Context, dtos []*IdolDTO, memberships map[string][]related.MembershipSummary) error {
+	var groupIDs []string
+	for _, ms := range memberships {
+		for _, m := range ms {
+			groupIDs = append(groupIDs, m.GroupID)
+		}
+	}
+	groups, err := u.relatedApp.FindGroups(ctx, groupIDs)
+	if err != nil {
+		return err
+	}
+	for _, dto := range dtos {
+		dto.Groups = make([]IdolGroupDTO, 0, len(memberships[dto.ID]))
+		for _, m := range memberships[dto.ID] {
+			g, ok := groups[m.GroupID]
+			if !ok {
+				continue
+			}
+			dto.Groups = append(dto.Groups, IdolGroupDTO{
+				ID:       g.ID,
+				Name:     g.Name,
+				NameKana: g.NameKana,
+				Role:     m.Role,
+				JoinedAt: formatDate(m.JoinedAt),
+				LeftAt:   formatDate(m.LeftAt),
+			})
 		}
 	}
 	return nil
 }
 
+// loadTags は付与されたタグを展開する
+func (u *Usecase) loadTags(ctx context.Context, dtos []*IdolDTO, limit int) error {
+	var tagIDs []string
+	for _, dto := range dtos {
+		tagIDs = append(tagIDs, dto.TagIDs...)
+	}
+	tags, err := u.relatedApp.FindTags(ctx, tagIDs)
+	if err != nil {
+		return err
+	}
+	for _, dto := range dtos {
+		dto.Tags = make([]TagRefDTO, 0, len(dto.TagIDs))
+		for _, id := range dto.TagIDs {
+			if len(dto.Tags) >= limit {
+				break
+			}
+			if t, ok := tags[id]; ok {
+				dto.Tags = append(dto.Tags, TagRefDTO{ID: t.ID, Name: t.Name, Category: t.Category})
+			}
+		}
+	}
+	return nil
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("2006-01-02")
+	return &s
+}
+
 // toDTO はドメインモデルをDTOに変換する
 func (u *Usecase) toDTO(i *domain.Idol) *IdolDTO {
 	var birthdateStr string
@@ -330,12 +522,15 @@ func (u *Usecase) toDTO(i *domain.Idol) *IdolDTO {
 	return &IdolDTO{
 		ID:          i.ID().Value(),
 		Name:        i.Name().Value(),
+		NameKana:    i.Name().Kana(),
 		Birthdate:   birthdateStr,
 		Age:         age,
 		AgencyID:    i.AgencyID(),
 		SocialLinks: socialLinksMap,
 		ExternalIDs: externalIDsMap,
 		Aliases:     i.Aliases(),
+		NameHistory: toNameHistoryDTOs(i.NameHistory()),
+		TagIDs:      i.TagIDs(),
 		CreatedAt:   i.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   i.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -366,3 +561,14 @@ func (u *Usecase) UpdateExternalIDs(ctx context.Context, cmd UpdateExternalIDsCo
 		ExternalIDs: cmd.ExternalIDs,
 	})
 }
+
+func toNameHistoryDTOs(h namehistory.History) []NameHistoryDTO {
+	if len(h) == 0 {
+		return nil
+	}
+	dtos := make([]NameHistoryDTO, 0, len(h))
+	for _, e := range h {
+		dtos = append(dtos, NameHistoryDTO{Name: e.Name(), From: formatDate(e.From()), Until: e.Until().Format("2006-01-02")})
+	}
+	return dtos
+}
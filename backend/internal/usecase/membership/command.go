@@ -1,17 +1,19 @@
 package membership
 
 type CreateMembershipCommand struct {
-	IdolID   string
-	GroupID  string
-	Role     string
-	JoinedAt *string
+	IdolID     string
+	GroupID    string
+	Role       string
+	Generation *string
+	JoinedAt   *string
 }
 
 type UpdateMembershipCommand struct {
-	ID       string
-	Role     *string
-	JoinedAt *string
-	LeftAt   *string
+	ID         string
+	Role       *string
+	Generation *string
+	JoinedAt   *string
+	LeftAt     *string
 }
 
 type DeleteMembershipCommand struct {
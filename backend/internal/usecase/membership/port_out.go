@@ -11,23 +11,30 @@ type MembershipAppPort interface {
 	CreateMembership(ctx context.Context, input MembershipCreateInput) (*domain.Membership, error)
 	GetMembership(ctx context.Context, id string) (*domain.Membership, error)
 	ListByIdolID(ctx context.Context, idolID string) ([]*domain.Membership, error)
-	ListByGroupID(ctx context.Context, groupID string) ([]*domain.Membership, error)
+	ListByGroupIDs(ctx context.Context, groupIDs []string) ([]*domain.Membership, error)
 	SearchMemberships(ctx context.Context, criteria domain.SearchCriteria) ([]*domain.Membership, error)
 	CountMemberships(ctx context.Context, criteria domain.SearchCriteria) (int64, error)
 	UpdateMembership(ctx context.Context, input MembershipUpdateInput) error
 	DeleteMembership(ctx context.Context, id string) error
 }
 
+// GroupUnitPort はサブユニットのメンバーを親グループに計上するためにグループの階層を参照する
+type GroupUnitPort interface {
+	ListSubUnitIDs(ctx context.Context, groupID string) ([]string, error)
+}
+
 type MembershipCreateInput struct {
-	IdolID   string
-	GroupID  string
-	Role     string
-	JoinedAt *string
+	IdolID     string
+	GroupID    string
+	Role       string
+	Generation *string
+	JoinedAt   *string
 }
 
 type MembershipUpdateInput struct {
-	ID       string
-	Role     *string
-	JoinedAt *string
-	LeftAt   *string
+	ID         string
+	Role       *string
+	Generation *string
+	JoinedAt   *string
+	LeftAt     *string
 }
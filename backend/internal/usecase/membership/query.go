@@ -7,14 +7,15 @@ type GetMembershipQuery struct {
 }
 
 type ListMembershipQuery struct {
-	IdolID   *string `form:"idol_id"`
-	GroupID  *string `form:"group_id"`
-	IsActive *bool   `form:"is_active"`
-	Role     *string `form:"role"`
-	Sort     *string `form:"sort"`
-	Order    *string `form:"order"`
-	Page     *int    `form:"page"`
-	Limit    *int    `form:"limit"`
+	IdolID     *string `form:"idol_id"`
+	GroupID    *string `form:"group_id"`
+	IsActive   *bool   `form:"is_active"`
+	Role       *string `form:"role"`
+	Generation *string `form:"generation"` // 期生ラベルの完全一致
+	Sort       *string `form:"sort"`
+	Order      *string `form:"order"`
+	Page       *int    `form:"page"`
+	Limit      *int    `form:"limit"`
 }
 
 func (q *ListMembershipQuery) Normalize() {
@@ -65,15 +66,17 @@ func containsStr(slice []string, item string) bool {
 }
 
 type MembershipDTO struct {
-	ID        string  `json:"id"`
-	IdolID    string  `json:"idol_id"`
-	GroupID   string  `json:"group_id"`
-	Role      string  `json:"role"`
-	JoinedAt  *string `json:"joined_at,omitempty"`
-	LeftAt    *string `json:"left_at,omitempty"`
-	IsActive  bool    `json:"is_active"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	ID         string   `json:"id"`
+	IdolID     string   `json:"idol_id"`
+	GroupID    string   `json:"group_id"`
+	Role       string   `json:"role"`
+	Generation *string  `json:"generation,omitempty"` // 期生ラベル（"1期生" など）
+	JoinedAt   *string  `json:"joined_at,omitempty"`
+	LeftAt     *string  `json:"left_at,omitempty"`
+	IsActive   bool     `json:"is_active"`
+	UnitIDs    []string `json:"unit_ids,omitempty"` // グループ別一覧で統合した同じアイドルのサブユニット
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
 }
 
 type MembershipSearchResult struct {
@@ -9,18 +9,20 @@ import (
 
 type Usecase struct {
 	appService MembershipAppPort
+	groupUnits GroupUnitPort
 }
 
-func NewUsecase(appService MembershipAppPort) *Usecase {
-	return &Usecase{appService: appService}
+func NewUsecase(appService MembershipAppPort, groupUnits GroupUnitPort) *Usecase {
+	return &Usecase{appService: appService, groupUnits: groupUnits}
 }
 
 func (u *Usecase) CreateMembership(ctx context.Context, cmd CreateMembershipCommand) (*MembershipDTO, error) {
 	m, err := u.appService.CreateMembership(ctx, MembershipCreateInput{
-		IdolID:   cmd.IdolID,
-		GroupID:  cmd.GroupID,
-		Role:     cmd.Role,
-		JoinedAt: cmd.JoinedAt,
+		IdolID:     cmd.IdolID,
+		GroupID:    cmd.GroupID,
+		Role:       cmd.Role,
+		Generation: cmd.Generation,
+		JoinedAt:   cmd.JoinedAt,
 	})
 	if err != nil {
 		return nil, err
@@ -54,14 +56,15 @@ func (u *Usecase) ListMemberships(ctx context.Context, query ListMembershipQuery
 	}
 
 	criteria := domain.SearchCriteria{
-		IdolID:   query.IdolID,
-		GroupID:  query.GroupID,
-		IsActive: query.IsActive,
-		Role:     role,
-		Sort:     *query.Sort,
-		Order:    *query.Order,
-		Offset:   (*query.Page - 1) * *query.Limit,
-		Limit:    *query.Limit,
+		IdolID:     query.IdolID,
+		GroupID:    query.GroupID,
+		IsActive:   query.IsActive,
+		Role:       role,
+		Generation: query.Generation,
+		Sort:       *query.Sort,
+		Order:      *query.Order,
+		Offset:     (*query.Page - 1) * *query.Limit,
+		Limit:      *query.Limit,
 	}
 
 	ms, err := u.appService.SearchMemberships(ctx, criteria)
@@ -104,20 +107,37 @@ func (u *Usecase) ListByIdolID(ctx context.Context, idolID string) ([]*Membershi
 	return toDTOs(ms), nil
 }
 
+// ListByGroupID はグループのメンバーシップを取得する。
+// 直下のサブユニットのメンバーシップも親グループのメンバーとして含める（group_id は所属ユニットのまま返す）。
+// 同じアイドルは1件にまとめ、親グループのメンバーシップを優先して所属サブユニットを unit_ids に記録する
 func (u *Usecase) ListByGroupID(ctx context.Context, groupID string) ([]*MembershipDTO, error) {
-	ms, err := u.appService.ListByGroupID(ctx, groupID)
+	unitIDs, err := u.groupUnits.ListSubUnitIDs(ctx, groupID)
 	if err != nil {
 		return nil, err
 	}
-	return toDTOs(ms), nil
+	ms, err := u.appService.ListByGroupIDs(ctx, append([]string{groupID}, unitIDs...))
+	if err != nil {
+		return nil, err
+	}
+	rolled := domain.RollUpSubUnits(groupID, ms, func(m *domain.Membership) (string, string) {
+		return m.IdolID(), m.GroupID()
+	})
+	dtos := make([]*MembershipDTO, 0, len(rolled))
+	for _, r := range rolled {
+		dto := toDTO(r.Membership)
+		dto.UnitIDs = r.UnitIDs
+		dtos = append(dtos, &dto)
+	}
+	return dtos, nil
 }
 
 func (u *Usecase) UpdateMembership(ctx context.Context, cmd UpdateMembershipCommand) error {
 	return u.appService.UpdateMembership(ctx, MembershipUpdateInput{
-		ID:       cmd.ID,
-		Role:     cmd.Role,
-		JoinedAt: cmd.JoinedAt,
-		LeftAt:   cmd.LeftAt,
+		ID:         cmd.ID,
+		Role:       cmd.Role,
+		Generation: cmd.Generation,
+		JoinedAt:   cmd.JoinedAt,
+		LeftAt:     cmd.LeftAt,
 	})
 }
 
@@ -127,13 +147,14 @@ func (u *Usecase) DeleteMembership(ctx context.Context, cmd DeleteMembershipComm
 
 func toDTO(m *domain.Membership) MembershipDTO {
 	dto := MembershipDTO{
-		ID:        m.ID().Value(),
-		IdolID:    m.IdolID(),
-		GroupID:   m.GroupID(),
-		Role:      m.Role().String(),
-		IsActive:  m.IsActive(),
-		CreatedAt: m.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: m.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		ID:         m.ID().Value(),
+		IdolID:     m.IdolID(),
+		GroupID:    m.GroupID(),
+		Role:       m.Role().String(),
+		Generation: m.Generation(),
+		IsActive:   m.IsActive(),
+		CreatedAt:  m.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:  m.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
 	}
 	if m.JoinedAt() != nil {
 		s := m.JoinedAt().Format("2006-01-02")
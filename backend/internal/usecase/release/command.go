@@ -82,3 +82,36 @@ type UpdateExternalIDsCommand struct {
 	ID          string
 	ExternalIDs map[string]string
 }
+
+// EditionTrackCommand はエディション収録曲のコマンド入力（SharedTrackNumber と Track のどちらか一方）
+type EditionTrackCommand struct {
+	DiscNumber        int
+	TrackNumber       int
+	SharedTrackNumber *int
+	Track             *TrackCommand
+}
+
+// BonusMediaCommand は特典のコマンド入力
+type BonusMediaCommand struct {
+	Type        string
+	Title       string
+	Description *string
+}
+
+// EditionCommand はエディションのコマンド入力
+type EditionCommand struct {
+	ID          *string
+	Name        string
+	EditionType string
+	JANCode     *string
+	UPC         *string
+	Price       *int
+	Tracks      []EditionTrackCommand
+	BonusMedia  []BonusMediaCommand
+}
+
+// UpdateEditionsCommand はエディション一括更新コマンド
+type UpdateEditionsCommand struct {
+	ID       string
+	Editions []EditionCommand
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	appRelease "github.com/kuro48/idol-api/internal/application/release"
+	"github.com/kuro48/idol-api/internal/domain/related"
 	domainRelease "github.com/kuro48/idol-api/internal/domain/release"
 )
 
@@ -17,6 +18,8 @@ type ReleaseAppPort interface {
 	SearchReleases(ctx context.Context, criteria domainRelease.SearchCriteria) ([]*domainRelease.Release, int64, error)
 	UpdateStreamingLinks(ctx context.Context, input appRelease.UpdateStreamingLinksInput) error
 	UpdateExternalIDs(ctx context.Context, input appRelease.UpdateExternalIDsInput) error
+	UpdateEditions(ctx context.Context, input appRelease.UpdateEditionsInput) (*domainRelease.Release, error)
+	FindEditionByJANCode(ctx context.Context, janCode string) (*domainRelease.Release, domainRelease.Edition, error)
 }
 
 // IdolExistencePort はアイドルの存在確認に使用する
@@ -28,3 +31,9 @@ type IdolExistencePort interface {
 type GroupExistencePort interface {
 	GetGroup(ctx context.Context, id string) error
 }
+
+// RelatedAppPort は release.Usecase が include 展開のために関連データ読み込みサービスに要求する契約
+type RelatedAppPort interface {
+	FindIdols(ctx context.Context, ids []string) (map[string]related.IdolSummary, error)
+	FindGroups(ctx context.Context, ids []string) (map[string]related.GroupSummary, error)
+}
@@ -1,10 +1,19 @@
 package release
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/kuro48/idol-api/internal/domain/plan"
+)
+
+// IncludeTargets は include パラメータで指定できる関連データ
+var IncludeTargets = []string{"artists", "participants"}
 
 // GetReleaseQuery はリリース取得クエリ
 type GetReleaseQuery struct {
-	ID string
+	ID            string
+	Include       *string            `form:"include"` // カンマ区切り: "artists,participants"
+	IncludeLimits plan.IncludeLimits `form:"-"`       // 呼び出し元のプランに応じた展開上限
 }
 
 // ListReleasesQuery はリリース一覧取得クエリ
@@ -16,6 +25,12 @@ type ListReleasesQuery struct {
 	ReleaseDateFrom *string `form:"release_date_from"` // YYYY-MM-DD
 	ReleaseDateTo   *string `form:"release_date_to"`   // YYYY-MM-DD
 
+	Include       *string            `form:"include"` // カンマ区切り: "artists,participants"
+	IncludeLimits plan.IncludeLimits `form:"-"`       // 呼び出し元のプランに応じた展開上限
+
+	// レスポンスに含めるフィールド（fields 指定。nil は全フィールド）
+	Fields []string `form:"-"`
+
 	Sort  *string `form:"sort"`  // release_date, title, created_at
 	Order *string `form:"order"` // asc, desc
 
@@ -87,6 +102,7 @@ func containsStr(slice []string, item string) bool {
 type ArtistRefDTO struct {
 	Kind string `json:"kind"`
 	ID   string `json:"id"`
+	Name string `json:"name,omitempty"` // include=artists時に展開
 	Role string `json:"role,omitempty"`
 }
 
@@ -107,10 +123,19 @@ type TrackDTO struct {
 // TrackParticipantDTO は楽曲単位のアイドル参加情報のデータ転送オブジェクト
 type TrackParticipantDTO struct {
 	IdolID   string  `json:"idol_id"`
+	IdolName string  `json:"idol_name,omitempty"` // include=participants時に展開
 	Status   string  `json:"status"`
 	Position *string `json:"position,omitempty"`
 }
 
+// ParticipantDTO は include=participants で展開する参加アイドル（参加曲の曲番号付き）
+type ParticipantDTO struct {
+	IdolID       string  `json:"idol_id"`
+	Name         string  `json:"name"`
+	NameKana     *string `json:"name_kana,omitempty"`
+	TrackNumbers []int   `json:"track_numbers"`
+}
+
 // StreamingLinksDTO はストリーミングリンクのデータ転送オブジェクト
 type StreamingLinksDTO struct {
 	Spotify      *string `json:"spotify,omitempty"`
@@ -122,6 +147,40 @@ type StreamingLinksDTO struct {
 	Official     *string `json:"official,omitempty"`
 }
 
+// EditionDTO はエディション（初回限定盤・通常盤など）のデータ転送オブジェクト
+type EditionDTO struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	EditionType string            `json:"edition_type"`
+	JANCode     *string           `json:"jan_code,omitempty"`
+	UPC         *string           `json:"upc,omitempty"`
+	Price       *int              `json:"price,omitempty"`
+	Tracks      []EditionTrackDTO `json:"tracks"`
+	BonusMedia  []BonusMediaDTO   `json:"bonus_media"`
+}
+
+// EditionTrackDTO はエディション収録曲のデータ転送オブジェクト。
+// Track は共通曲の参照を解決した楽曲情報（track_number はリリース共通の収録曲の番号）
+type EditionTrackDTO struct {
+	DiscNumber        int       `json:"disc_number"`
+	TrackNumber       int       `json:"track_number"`
+	SharedTrackNumber *int      `json:"shared_track_number,omitempty"`
+	Track             *TrackDTO `json:"track,omitempty"`
+}
+
+// BonusMediaDTO は特典のデータ転送オブジェクト
+type BonusMediaDTO struct {
+	Type        string  `json:"type"`
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+}
+
+// EditionLookupDTO はJANコード検索の結果（エディションと親リリース）
+type EditionLookupDTO struct {
+	Edition EditionDTO  `json:"edition"`
+	Release *ReleaseDTO `json:"release"`
+}
+
 // ReleaseDTO はリリースのデータ転送オブジェクト
 type ReleaseDTO struct {
 	ID             string             `json:"id"`
@@ -130,11 +189,13 @@ type ReleaseDTO struct {
 	ReleaseDate    string             `json:"release_date"`
 	Artists        []ArtistRefDTO     `json:"artists"`
 	Tracks         []TrackDTO         `json:"tracks,omitempty"`
+	Editions       []EditionDTO       `json:"editions,omitempty"`
 	StreamingLinks *StreamingLinksDTO `json:"streaming_links,omitempty"`
 	CoverImageURL  *string            `json:"cover_image_url,omitempty"`
 	Aliases        []string           `json:"aliases,omitempty"`
 	TagIDs         []string           `json:"tag_ids,omitempty"`
 	ExternalIDs    map[string]string  `json:"external_ids,omitempty"`
+	Participants   []ParticipantDTO   `json:"participants,omitempty"` // include=participants時に展開
 	CreatedAt      string             `json:"created_at"`
 	UpdatedAt      string             `json:"updated_at"`
 }
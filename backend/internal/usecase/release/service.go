@@ -9,7 +9,10 @@ import (
 	"time"
 
 	appRelease "github.com/kuro48/idol-api/internal/application/release"
+	"github.com/kuro48/idol-api/internal/domain/plan"
+	"github.com/kuro48/idol-api/internal/domain/related"
 	domainRelease "github.com/kuro48/idol-api/internal/domain/release"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
 )
 
 // Usecase はリリースのユースケース
@@ -17,11 +20,12 @@ type Usecase struct {
 	appService ReleaseAppPort
 	idolApp    IdolExistencePort
 	groupApp   GroupExistencePort
+	relatedApp RelatedAppPort
 }
 
 // NewUsecase はユースケースを作成する
-func NewUsecase(appService ReleaseAppPort, idolApp IdolExistencePort, groupApp GroupExistencePort) *Usecase {
-	return &Usecase{appService: appService, idolApp: idolApp, groupApp: groupApp}
+func NewUsecase(appService ReleaseAppPort, idolApp IdolExistencePort, groupApp GroupExistencePort, relatedApp RelatedAppPort) *Usecase {
+	return &Usecase{appService: appService, idolApp: idolApp, groupApp: groupApp, relatedApp: relatedApp}
 }
 
 // CreateRelease はリリースを作成する
@@ -55,17 +59,37 @@ func (u *Usecase) CreateRelease(ctx context.Context, cmd CreateReleaseCommand) (
 }
 
 // GetRelease はリリースを取得する
-func (u *Usecase) GetRelease(ctx context.Context, id string) (*ReleaseDTO, error) {
-	r, err := u.appService.GetRelease(ctx, id)
+func (u *Usecase) GetRelease(ctx context.Context, query GetReleaseQuery) (*ReleaseDTO, error) {
+	targets, err := related.ParseIncludes(query.Include, IncludeTargets, query.IncludeLimits)
 	if err != nil {
 		return nil, err
 	}
-	return u.toDTO(r), nil
+
+	r, err := u.appService.GetRelease(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	dto := u.toDTO(r)
+	if err := u.loadIncludes(ctx, []*ReleaseDTO{dto}, targets, query.IncludeLimits); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
+	}
+	return dto, nil
 }
 
 // SearchReleases は条件を指定してリリースを検索する
 func (u *Usecase) SearchReleases(ctx context.Context, query ListReleasesQuery) (*SearchResult, error) {
+	targets, err := related.ParseIncludes(query.Include, IncludeTargets, query.IncludeLimits)
+	if err != nil {
+		return nil, err
+	}
+
 	criteria := u.queryToCriteria(query)
+	fields := query.Fields
+	if fields != nil && related.Has(targets, "participants") {
+		// 参加アイドルは収録曲から集計するため、レスポンスに含めなくても読み込む
+		fields = append(fields, "tracks")
+	}
+	criteria.Omit = fieldset.Omitted(fields, "tracks", "editions", "streaming_links", "external_ids", "aliases")
 
 	releases, total, err := u.appService.SearchReleases(ctx, criteria)
 	if err != nil {
@@ -76,6 +100,9 @@ func (u *Usecase) SearchReleases(ctx context.Context, query ListReleasesQuery) (
 	for _, r := range releases {
 		dtos = append(dtos, u.toDTO(r))
 	}
+	if err := u.loadIncludes(ctx, dtos, targets, query.IncludeLimits); err != nil {
+		return nil, fmt.Errorf("関連データの読み込みエラー: %w", err)
+	}
 
 	meta := u.calcMeta(total, *query.Page, *query.Limit)
 	links := u.buildLinks(query, meta.TotalPages)
@@ -132,6 +159,39 @@ func (u *Usecase) UpdateExternalIDs(ctx context.Context, cmd UpdateExternalIDsCo
 	})
 }
 
+// UpdateEditions はリリースのエディションを置き換える
+func (u *Usecase) UpdateEditions(ctx context.Context, cmd UpdateEditionsCommand) (*ReleaseDTO, error) {
+	var exclusive []TrackCommand
+	for _, e := range cmd.Editions {
+		for _, t := range e.Tracks {
+			if t.Track != nil {
+				exclusive = append(exclusive, *t.Track)
+			}
+		}
+	}
+	if err := u.validateTrackParticipants(ctx, exclusive); err != nil {
+		return nil, err
+	}
+
+	r, err := u.appService.UpdateEditions(ctx, appRelease.UpdateEditionsInput{
+		ID:       cmd.ID,
+		Editions: toAppEditions(cmd.Editions),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.toDTO(r), nil
+}
+
+// FindEditionByJANCode はJANコードからエディションと親リリースを取得する
+func (u *Usecase) FindEditionByJANCode(ctx context.Context, janCode string) (*EditionLookupDTO, error) {
+	r, edition, err := u.appService.FindEditionByJANCode(ctx, janCode)
+	if err != nil {
+		return nil, err
+	}
+	return &EditionLookupDTO{Edition: toEditionDTO(r, edition), Release: u.toDTO(r)}, nil
+}
+
 // validateArtists はアーティスト参照の存在確認を行う
 func (u *Usecase) validateArtists(ctx context.Context, artists []ArtistRefCommand) error {
 	for _, a := range artists {
@@ -232,6 +292,9 @@ func (u *Usecase) buildLinks(query ListReleasesQuery, totalPages int) *Paginatio
 		if query.ReleaseDateTo != nil {
 			p.Set("release_date_to", *query.ReleaseDateTo)
 		}
+		if query.Include != nil {
+			p.Set("include", *query.Include)
+		}
 		if query.Sort != nil {
 			p.Set("sort", *query.Sort)
 		}
@@ -253,6 +316,99 @@ func (u *Usecase) buildLinks(query ListReleasesQuery, totalPages int) *Paginatio
 	return links
 }
 
+// loadIncludes はアーティスト名・参加アイドル名を一覧全体でまとめて解決してDTOに展開する
+func (u *Usecase) loadIncludes(ctx context.Context, dtos []*ReleaseDTO, targets []string, limits plan.IncludeLimits) error {
+	withArtists := related.Has(targets, "artists")
+	withParticipants := related.Has(targets, "participants")
+	if !withArtists && !withParticipants {
+		return nil
+	}
+	limits = related.EffectiveLimits(limits)
+
+	var idolIDs, groupIDs []string
+	for _, dto := range dtos {
+		if withArtists {
+			for _, a := range dto.Artists {
+				if a.Kind == "group" {
+					groupIDs = append(groupIDs, a.ID)
+				} else {
+					idolIDs = append(idolIDs, a.ID)
+				}
+			}
+		}
+		if withParticipants {
+			for _, t := range dto.Tracks {
+				for _, p := range t.Participants {
+					idolIDs = append(idolIDs, p.IdolID)
+				}
+			}
+		}
+	}
+
+	idols, err := u.relatedApp.FindIdols(ctx, idolIDs)
+	if err != nil {
+		return err
+	}
+	groups := map[string]related.GroupSummary{}
+	if len(groupIDs) > 0 {
+		if groups, err = u.relatedApp.FindGroups(ctx, groupIDs); err != nil {
+			return err
+		}
+	}
+
+	// 改名前のリリースは発売日時点の名前で表示する（発売日を解釈できなければ現在の名前）
+	for _, dto := range dtos {
+		var releasedAt *time.Time
+		if t, err := time.Parse("2006-01-02", dto.ReleaseDate); err == nil {
+			releasedAt = &t
+		}
+		if withArtists {
+			for i, a := range dto.Artists {
+				if a.Kind == "group" {
+					dto.Artists[i].Name = groups[a.ID].NameAsOf(releasedAt)
+				} else {
+					dto.Artists[i].Name = idols[a.ID].NameAsOf(releasedAt)
+				}
+			}
+		}
+		if withParticipants {
+			dto.Participants = participantsOf(dto, idols, releasedAt, limits.MaxItems)
+		}
+	}
+	return nil
+}
+
+// participantsOf は収録曲の参加者に発売日時点の名前を付与し、参加アイドルを初登場順に最大 limit 件まとめる
+func participantsOf(dto *ReleaseDTO, idols map[string]related.IdolSummary, releasedAt *time.Time, limit int) []ParticipantDTO {
+	index := make(map[string]int)
+	participants := make([]ParticipantDTO, 0)
+	for ti, t := range dto.Tracks {
+		for pi, p := range t.Participants {
+			idol, ok := idols[p.IdolID]
+			if !ok {
+				continue
+			}
+			name := idol.NameAsOf(releasedAt)
+			dto.Tracks[ti].Participants[pi].IdolName = name
+			if i, seen := index[p.IdolID]; seen {
+				participants[i].TrackNumbers = append(participants[i].TrackNumbers, t.TrackNumber)
+				continue
+			}
+			if len(participants) >= limit {
+				continue
+			}
+			index[p.IdolID] = len(participants)
+			participants = append(participants, ParticipantDTO{
+				IdolID:       idol.ID,
+				Name:         name,
+				NameKana:     idol.NameKana,
+				TrackNumbers: []int{t.TrackNumber},
+			})
+		}
+	}
+	return participants
+}
+
 func (u *Usecase) toDTO(r *domainRelease.Release) *ReleaseDTO {
 	artists := make([]ArtistRefDTO, 0, len(r.Artists()))
 	for _, a := range r.Artists() {
@@ -265,26 +421,12 @@ func (u *Usecase) toDTO(r *domainRelease.Release) *ReleaseDTO {
 
 	tracks := make([]TrackDTO, 0, len(r.Tracks()))
 	for _, t := range r.Tracks() {
-		participants := make([]TrackParticipantDTO, 0, len(t.Participants()))
-		for _, p := range t.Participants() {
-			participants = append(participants, TrackParticipantDTO{
-				IdolID:   p.IdolID(),
-				Status:   p.Status().Value(),
-				Position: p.Position(),
-			})
-		}
-		tracks = append(tracks, TrackDTO{
-			TrackNumber:   t.TrackNumber(),
-			Title:         t.Title(),
-			TitleKana:     t.TitleKana(),
-			DurationSec:   t.DurationSec(),
-			ISRC:          t.ISRC(),
-			CoverImageURL: t.CoverImageURL(),
-			Composers:     t.Composers(),
-			Lyricists:     t.Lyricists(),
-			Arrangers:     t.Arrangers(),
-			Participants:  participants,
-		})
+		tracks = append(tracks, toTrackDTO(t))
+	}
+
+	var editions []EditionDTO
+	for _, e := range r.Editions() {
+		editions = append(editions, toEditionDTO(r, e))
 	}
 
 	var linksDTO *StreamingLinksDTO
@@ -316,6 +458,7 @@ func (u *Usecase) toDTO(r *domainRelease.Release) *ReleaseDTO {
 		ReleaseDate:    r.ReleaseDate().String(),
 		Artists:        artists,
 		Tracks:         tracks,
+		Editions:       editions,
 		StreamingLinks: linksDTO,
 		CoverImageURL:  r.CoverImageURL(),
 		Aliases:        r.Aliases(),
@@ -326,6 +469,64 @@ func (u *Usecase) toDTO(r *domainRelease.Release) *ReleaseDTO {
 	}
 }
 
+func toTrackDTO(t domainRelease.Track) TrackDTO {
+	participants := make([]TrackParticipantDTO, 0, len(t.Participants()))
+	for _, p := range t.Participants() {
+		participants = append(participants, TrackParticipantDTO{
+			IdolID:   p.IdolID(),
+			Status:   p.Status().Value(),
+			Position: p.Position(),
+		})
+	}
+	return TrackDTO{
+		TrackNumber:   t.TrackNumber(),
+		Title:         t.Title(),
+		TitleKana:     t.TitleKana(),
+		DurationSec:   t.DurationSec(),
+		ISRC:          t.ISRC(),
+		CoverImageURL: t.CoverImageURL(),
+		Composers:     t.Composers(),
+		Lyricists:     t.Lyricists(),
+		Arrangers:     t.Arrangers(),
+		Participants:  participants,
+	}
+}
+
+// toEditionDTO はエディションを変換する。共通曲の参照はリリースの収録曲に解決する
+func toEditionDTO(r *domainRelease.Release, e domainRelease.Edition) EditionDTO {
+	tracks := make([]EditionTrackDTO, 0, len(e.Tracks()))
+	for _, t := range e.Tracks() {
+		dto := EditionTrackDTO{
+			DiscNumber:        t.DiscNumber(),
+			TrackNumber:       t.TrackNumber(),
+			SharedTrackNumber: t.SharedTrackNumber(),
+		}
+		if track, ok := r.EditionTrackOf(t); ok {
+			trackDTO := toTrackDTO(track)
+			dto.Track = &trackDTO
+		}
+		tracks = append(tracks, dto)
+	}
+	bonusMedia := make([]BonusMediaDTO, 0, len(e.BonusMedia()))
+	for _, b := range e.BonusMedia() {
+		bonusMedia = append(bonusMedia, BonusMediaDTO{
+			Type:        string(b.MediaType()),
+			Title:       b.Title(),
+			Description: b.Description(),
+		})
+	}
+	return EditionDTO{
+		ID:          e.ID(),
+		Name:        e.Name(),
+		EditionType: string(e.EditionType()),
+		JANCode:     e.JANCode(),
+		UPC:         e.UPC(),
+		Price:       e.Price(),
+		Tracks:      tracks,
+		BonusMedia:  bonusMedia,
+	}
+}
+
 func toAppArtistRefs(cmds []ArtistRefCommand) []appRelease.ArtistRefInput {
 	if cmds == nil {
 		return nil
@@ -359,6 +560,39 @@ func toAppTracks(cmds []TrackCommand) []appRelease.TrackInput {
 	return tracks
 }
 
+func toAppEditions(cmds []EditionCommand) []appRelease.EditionInput {
+	editions := make([]appRelease.EditionInput, 0, len(cmds))
+	for _, c := range cmds {
+		tracks := make([]appRelease.EditionTrackInput, 0, len(c.Tracks))
+		for _, t := range c.Tracks {
+			track := appRelease.EditionTrackInput{
+				DiscNumber:        t.DiscNumber,
+				TrackNumber:       t.TrackNumber,
+				SharedTrackNumber: t.SharedTrackNumber,
+			}
+			if t.Track != nil {
+				track.Track = &toAppTracks([]TrackCommand{*t.Track})[0]
+			}
+			tracks = append(tracks, track)
+		}
+		bonusMedia := make([]appRelease.BonusMediaInput, 0, len(c.BonusMedia))
+		for _, b := range c.BonusMedia {
+			bonusMedia = append(bonusMedia, appRelease.BonusMediaInput{Type: b.Type, Title: b.Title, Description: b.Description})
+		}
+		editions = append(editions, appRelease.EditionInput{
+			ID:          c.ID,
+			Name:        c.Name,
+			EditionType: c.EditionType,
+			JANCode:     c.JANCode,
+			UPC:         c.UPC,
+			Price:       c.Price,
+			Tracks:      tracks,
+			BonusMedia:  bonusMedia,
+		})
+	}
+	return editions
+}
+
 func toAppTrackParticipants(cmds []TrackParticipantCommand) []appRelease.TrackParticipantInput {
 	if cmds == nil {
 		return nil
@@ -0,0 +1,132 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/search"
+)
+
+// SearchResultDTO は横断検索の結果
+type SearchResultDTO struct {
+	Query  string         `json:"query"`
+	Data   []SearchHitDTO `json:"data"`
+	Facets map[string]int `json:"facets"` // 種別ごとの総ヒット件数
+	Meta   SearchMetaDTO  `json:"meta"`
+}
+
+// SearchMetaDTO は横断検索のメタ情報
+type SearchMetaDTO struct {
+	Total int `json:"total"`
+	Limit int `json:"limit"`
+}
+
+// SearchHitDTO は検索結果1件
+type SearchHitDTO struct {
+	Type      string       `json:"type"`
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Match     string       `json:"match"` // exact, prefix, alias, fuzzy
+	Score     float64      `json:"score"`
+	Highlight HighlightDTO `json:"highlight"`
+}
+
+// HighlightDTO は一致したフィールドと一致箇所
+type HighlightDTO struct {
+	Field  string     `json:"field"`
+	Text   string     `json:"text"`
+	Ranges []RangeDTO `json:"ranges"`
+}
+
+// RangeDTO は一致箇所（文字単位のオフセットと長さ）
+type RangeDTO struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// ToHitDTO はドメインのヒットをDTOに変換する
+func ToHitDTO(hit domain.Hit) SearchHitDTO {
+	ranges := make([]RangeDTO, 0, len(hit.Highlight.Ranges))
+	for _, r := range hit.Highlight.Ranges {
+		ranges = append(ranges, RangeDTO{Start: r.Start, Length: r.Length})
+	}
+	return SearchHitDTO{
+		Type:  string(hit.Candidate.Type),
+		ID:    hit.Candidate.ID,
+		Name:  hit.Candidate.Name,
+		Match: string(hit.Kind),
+		Score: math.Round(hit.Score*100) / 100,
+		Highlight: HighlightDTO{
+			Field:  hit.Highlight.Field,
+			Text:   hit.Highlight.Text,
+			Ranges: ranges,
+		},
+	}
+}
+
+// SuggestResultDTO は入力補完の結果
+type SuggestResultDTO struct {
+	Query string          `json:"query"`
+	Data  []SuggestionDTO `json:"data"`
+}
+
+// SuggestionDTO は入力補完候補1件。同名の候補を見分けるための補足情報を含む
+type SuggestionDTO struct {
+	Type          string     `json:"type"`
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	NameKana      *string    `json:"name_kana,omitempty"`
+	AgencyName    string     `json:"agency_name,omitempty"`
+	BirthYear     int        `json:"birth_year,omitempty"`
+	FormationYear int        `json:"formation_year,omitempty"`
+	ReleaseYear   int        `json:"release_year,omitempty"`
+	EventDate     *time.Time `json:"event_date,omitempty"`
+	Prefecture    string     `json:"prefecture,omitempty"`
+	Hint          string     `json:"hint,omitempty"` // 表示用の補足（例: "アミューズ / 2001年生まれ"）
+}
+
+// jst は補足情報の日付表示に使う日本標準時
+var jst = time.FixedZone("JST", 9*60*60)
+
+// ToSuggestionDTO はドメインの入力補完候補をDTOに変換する
+func ToSuggestionDTO(s domain.Suggestion) SuggestionDTO {
+	return SuggestionDTO{
+		Type:          string(s.Type),
+		ID:            s.ID,
+		Name:          s.Name,
+		NameKana:      s.Kana,
+		AgencyName:    s.Hint.AgencyName,
+		BirthYear:     s.Hint.BirthYear,
+		FormationYear: s.Hint.FormationYear,
+		ReleaseYear:   s.Hint.ReleaseYear,
+		EventDate:     s.Hint.EventDate,
+		Prefecture:    s.Hint.Prefecture,
+		Hint:          hintText(s.Hint),
+	}
+}
+
+// hintText は補足情報を " / " 区切りの表示用文字列にする
+func hintText(h domain.Hint) string {
+	var parts []string
+	if h.AgencyName != "" {
+		parts = append(parts, h.AgencyName)
+	}
+	if h.BirthYear > 0 {
+		parts = append(parts, fmt.Sprintf("%d年生まれ", h.BirthYear))
+	}
+	if h.FormationYear > 0 {
+		parts = append(parts, fmt.Sprintf("%d年結成", h.FormationYear))
+	}
+	if h.ReleaseYear > 0 {
+		parts = append(parts, fmt.Sprintf("%d年発売", h.ReleaseYear))
+	}
+	if h.EventDate != nil {
+		parts = append(parts, h.EventDate.In(jst).Format("2006/01/02"))
+	}
+	if h.Prefecture != "" {
+		parts = append(parts, h.Prefecture)
+	}
+	return strings.Join(parts, " / ")
+}
@@ -0,0 +1,9 @@
+package search
+
+import "context"
+
+// SearchUseCase は横断検索のユースケース Input Port
+type SearchUseCase interface {
+	Search(ctx context.Context, query SearchQuery) (SearchResultDTO, error)
+	Suggest(ctx context.Context, query SuggestQuery) (SuggestResultDTO, error)
+}
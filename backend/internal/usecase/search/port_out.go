@@ -0,0 +1,42 @@
+package search
+
+import (
+	"context"
+
+	domain "github.com/kuro48/idol-api/internal/domain/search"
+)
+
+// SearchAppPort は search.Usecase が search application サービスに要求する契約
+type SearchAppPort interface {
+	Search(ctx context.Context, input SearchInput) (*SearchOutput, error)
+	Suggest(ctx context.Context, input SuggestInput) (*SuggestOutput, error)
+}
+
+// SearchInput は横断検索の入力
+type SearchInput struct {
+	Query string
+	Types []string
+	Limit int
+}
+
+// SearchOutput は横断検索の出力
+type SearchOutput struct {
+	Types  []domain.EntityType
+	Hits   []domain.Hit
+	Facets map[domain.EntityType]int
+	Total  int
+	Limit  int
+}
+
+// SuggestInput は入力補完の入力
+type SuggestInput struct {
+	Query string
+	Types []string
+	Limit int
+}
+
+// SuggestOutput は入力補完の出力
+type SuggestOutput struct {
+	Suggestions []domain.Suggestion
+	Limit       int
+}
@@ -0,0 +1,40 @@
+package search
+
+import "strings"
+
+// SearchQuery は横断検索クエリ
+type SearchQuery struct {
+	Q     string `form:"q"`     // 検索語
+	Types string `form:"types"` // 対象種別（カンマ区切り: idol,group,agency,release,event,venue）
+	Limit int    `form:"limit"` // 返却件数（既定20、最大50）
+}
+
+// TypeList はカンマ区切りの対象種別を分割して返す
+func (q SearchQuery) TypeList() []string {
+	return splitTypes(q.Types)
+}
+
+// SuggestQuery は入力補完クエリ
+type SuggestQuery struct {
+	Q     string `form:"q"`     // 入力途中の検索語
+	Types string `form:"types"` // 対象種別（カンマ区切り: idol,group,agency,release,event,venue）
+	Limit int    `form:"limit"` // 返却件数（既定10、最大20）
+}
+
+// TypeList はカンマ区切りの対象種別を分割して返す
+func (q SuggestQuery) TypeList() []string {
+	return splitTypes(q.Types)
+}
+
+func splitTypes(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	var types []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
@@ -0,0 +1,61 @@
+package search
+
+import "context"
+
+// Usecase は横断検索のユースケース
+type Usecase struct {
+	appService SearchAppPort
+}
+
+// NewUsecase はユースケースを作成する
+func NewUsecase(appService SearchAppPort) *Usecase {
+	return &Usecase{appService: appService}
+}
+
+// Search は全集約を横断して検索する
+func (u *Usecase) Search(ctx context.Context, query SearchQuery) (SearchResultDTO, error) {
+	output, err := u.appService.Search(ctx, SearchInput{
+		Query: query.Q,
+		Types: query.TypeList(),
+		Limit: query.Limit,
+	})
+	if err != nil {
+		return SearchResultDTO{}, err
+	}
+
+	data := make([]SearchHitDTO, 0, len(output.Hits))
+	for _, hit := range output.Hits {
+		data = append(data, ToHitDTO(hit))
+	}
+
+	// 対象種別はヒットが0件でもファセットに含める（検索バーのタブ表示用）
+	facets := make(map[string]int, len(output.Types))
+	for _, t := range output.Types {
+		facets[string(t)] = output.Facets[t]
+	}
+
+	return SearchResultDTO{
+		Query:  query.Q,
+		Data:   data,
+		Facets: facets,
+		Meta:   SearchMetaDTO{Total: output.Total, Limit: output.Limit},
+	}, nil
+}
+
+// Suggest は入力途中の検索語から入力補完候補を返す
+func (u *Usecase) Suggest(ctx context.Context, query SuggestQuery) (SuggestResultDTO, error) {
+	output, err := u.appService.Suggest(ctx, SuggestInput{
+		Query: query.Q,
+		Types: query.TypeList(),
+		Limit: query.Limit,
+	})
+	if err != nil {
+		return SuggestResultDTO{}, err
+	}
+
+	data := make([]SuggestionDTO, 0, len(output.Suggestions))
+	for _, s := range output.Suggestions {
+		data = append(data, ToSuggestionDTO(s))
+	}
+	return SuggestResultDTO{Query: query.Q, Data: data}, nil
+}
@@ -53,6 +53,7 @@ type SubmissionTargetPort interface {
 // IdolCreateInput は承認済み idol 投稿の作成入力
 type IdolCreateInput struct {
 	Name      string   `json:"name"`
+	NameKana  *string  `json:"name_kana,omitempty"`
 	Birthdate *string  `json:"birthdate,omitempty"`
 	AgencyID  *string  `json:"agency_id,omitempty"`
 	Aliases   []string `json:"aliases,omitempty"`
@@ -61,6 +62,7 @@ type IdolCreateInput struct {
 // GroupCreateInput は承認済み group 投稿の作成入力
 type GroupCreateInput struct {
 	Name          string  `json:"name"`
+	NameKana      *string `json:"name_kana,omitempty"`
 	FormationDate *string `json:"formation_date,omitempty"`
 	DisbandDate   *string `json:"disband_date,omitempty"`
 }
@@ -69,6 +71,7 @@ type GroupCreateInput struct {
 type AgencyCreateInput struct {
 	Name            string  `json:"name"`
 	NameEn          *string `json:"name_en,omitempty"`
+	NameKana        *string `json:"name_kana,omitempty"`
 	FoundedDate     *string `json:"founded_date,omitempty"`
 	Country         string  `json:"country"`
 	OfficialWebsite *string `json:"official_website,omitempty"`
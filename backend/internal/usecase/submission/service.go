@@ -9,6 +9,7 @@ import (
 	"log/slog"
 
 	domain "github.com/kuro48/idol-api/internal/domain/submission"
+	"github.com/kuro48/idol-api/internal/shared/kana"
 )
 
 // Usecase は投稿審査のユースケース実装
@@ -210,6 +211,9 @@ func validateSubmissionPayload(targetType string, payload []byte) error {
 		if input.Name == "" {
 			return fmt.Errorf("idol 投稿ペイロードには name が必須です")
 		}
+		if err := validateNameKana(input.NameKana); err != nil {
+			return err
+		}
 	case "group":
 		var input GroupCreateInput
 		if err := decodeStrictPayload(payload, &input); err != nil {
@@ -218,6 +222,9 @@ func validateSubmissionPayload(targetType string, payload []byte) error {
 		if input.Name == "" {
 			return fmt.Errorf("group 投稿ペイロードには name が必須です")
 		}
+		if err := validateNameKana(input.NameKana); err != nil {
+			return err
+		}
 	case "agency":
 		var input AgencyCreateInput
 		if err := decodeStrictPayload(payload, &input); err != nil {
@@ -226,6 +233,9 @@ func validateSubmissionPayload(targetType string, payload []byte) error {
 		if input.Name == "" || input.Country == "" {
 			return fmt.Errorf("agency 投稿ペイロードには name と country が必須です")
 		}
+		if err := validateNameKana(input.NameKana); err != nil {
+			return err
+		}
 	case "event":
 		var input EventCreateInput
 		if err := decodeStrictPayload(payload, &input); err != nil {
@@ -240,6 +250,17 @@ func validateSubmissionPayload(targetType string, payload []byte) error {
 	return nil
 }
 
+// validateNameKana は投稿ペイロードの読み仮名を検証する（未指定・空文字は許容）
+func validateNameKana(reading *string) error {
+	if reading == nil || *reading == "" {
+		return nil
+	}
+	if err := kana.ValidateReading(*reading); err != nil {
+		return fmt.Errorf("投稿ペイロードの name_kana が不正です: %w", err)
+	}
+	return nil
+}
+
 func decodeStrictPayload(payload []byte, dest interface{}) error {
 	decoder := json.NewDecoder(bytes.NewReader(payload))
 	decoder.DisallowUnknownFields()
@@ -86,7 +86,7 @@ func (f *fakeApprovedTargetPort) CreateEvent(_ context.Context, input EventCreat
 func TestUpdateStatus_ApprovedCreatesIdol(t *testing.T) {
 	t.Parallel()
 
-	sub := newSubmissionForTest(t, domain.SubmissionTypeIdol, `{"name":"星野みく","birthdate":"2001-05-01","agency_id":"agency-1","aliases":["みく","Miku"]}`)
+	sub := newSubmissionForTest(t, domain.SubmissionTypeIdol, `{"name":"星野みく","name_kana":"ほしのみく","birthdate":"2001-05-01","agency_id":"agency-1","aliases":["みく","Miku"]}`)
 	app := &fakeSubmissionApp{submission: sub}
 	targets := &fakeApprovedTargetPort{}
 	uc := NewUsecase(app, targets, nil)
@@ -100,6 +100,8 @@ func TestUpdateStatus_ApprovedCreatesIdol(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, targets.idolInput)
 	assert.Equal(t, "星野みく", targets.idolInput.Name)
+	require.NotNil(t, targets.idolInput.NameKana)
+	assert.Equal(t, "ほしのみく", *targets.idolInput.NameKana)
 	require.NotNil(t, targets.idolInput.Birthdate)
 	assert.Equal(t, "2001-05-01", *targets.idolInput.Birthdate)
 	require.NotNil(t, targets.idolInput.AgencyID)
@@ -198,6 +200,20 @@ func TestUpdateStatus_ApprovedCreatesEvent(t *testing.T) {
 	assert.Equal(t, []string{"live", "tour"}, targets.eventInput.Tags)
 }
 
+func TestCreateSubmission_RejectsInvalidNameKana(t *testing.T) {
+	t.Parallel()
+
+	uc := NewUsecase(&fakeSubmissionApp{}, &fakeApprovedTargetPort{}, nil)
+
+	_, err := uc.CreateSubmission(context.Background(), CreateSubmissionCommand{
+		TargetType: "group",
+		Payload:    map[string]interface{}{"name": "テストグループ", "name_kana": "Test Group"},
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name_kana")
+}
+
 func TestUpdateStatus_ApprovedDoesNotPersistWhenTargetCreationFails(t *testing.T) {
 	t.Parallel()
 
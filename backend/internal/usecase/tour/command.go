@@ -0,0 +1,33 @@
+package tour
+
+// PerformerInput は出演者の入力（コマンド用）
+type PerformerInput struct {
+	PerformerID   string `json:"performer_id" binding:"required"`
+	BillingStatus string `json:"billing_status,omitempty"`
+}
+
+// CreateTourCommand はツアー作成コマンド
+type CreateTourCommand struct {
+	Title       string
+	EventType   string
+	Performers  []PerformerInput
+	Tags        []string
+	OfficialURL *string
+	Description *string
+}
+
+// UpdateTourCommand はツアー更新コマンド。Performers・Tags は指定した場合のみ丸ごと置き換える
+type UpdateTourCommand struct {
+	ID          string
+	Title       *string
+	EventType   *string
+	Performers  []PerformerInput
+	Tags        []string
+	OfficialURL *string
+	Description *string
+}
+
+// DeleteTourCommand はツアー削除コマンド
+type DeleteTourCommand struct {
+	ID string
+}
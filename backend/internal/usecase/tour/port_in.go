@@ -0,0 +1,12 @@
+package tour
+
+import "context"
+
+// TourUseCase はツアーユースケースのポート（プレゼンテーション層向け）
+type TourUseCase interface {
+	CreateTour(ctx context.Context, cmd CreateTourCommand) (*TourDTO, error)
+	GetTour(ctx context.Context, query GetTourQuery) (*TourDTO, error)
+	ListTours(ctx context.Context, query ListTourQuery) (*TourSearchResult, error)
+	UpdateTour(ctx context.Context, cmd UpdateTourCommand) error
+	DeleteTour(ctx context.Context, cmd DeleteTourCommand) error
+}
@@ -0,0 +1,43 @@
+package tour
+
+import (
+	"context"
+
+	domain "github.com/kuro48/idol-api/internal/domain/tour"
+)
+
+// TourAppPort は tour.Usecase が tour application サービスに要求する契約
+type TourAppPort interface {
+	CreateTour(ctx context.Context, input TourCreateInput) (*domain.Tour, error)
+	GetTour(ctx context.Context, id string) (*domain.Tour, error)
+	SearchTours(ctx context.Context, criteria domain.SearchCriteria) ([]*domain.Tour, int64, error)
+	UpdateTour(ctx context.Context, input TourUpdateInput) error
+	DeleteTour(ctx context.Context, id string) error
+}
+
+// TourPerformerInput は出演者の入力データ
+type TourPerformerInput struct {
+	PerformerID   string
+	BillingStatus string
+}
+
+// TourCreateInput はツアー作成の入力
+type TourCreateInput struct {
+	Title       string
+	EventType   string
+	Performers  []TourPerformerInput
+	Tags        []string
+	OfficialURL *string
+	Description *string
+}
+
+// TourUpdateInput はツアー更新の入力
+type TourUpdateInput struct {
+	ID          string
+	Title       *string
+	EventType   *string
+	Performers  []TourPerformerInput
+	Tags        []string
+	OfficialURL *string
+	Description *string
+}
@@ -0,0 +1,62 @@
+package tour
+
+// GetTourQuery はツアー詳細取得クエリ
+type GetTourQuery struct {
+	ID string
+}
+
+// ListTourQuery はツアー一覧取得クエリ
+type ListTourQuery struct {
+	Title       *string `form:"title"`
+	PerformerID *string `form:"performer_id"`
+	Page        *int    `form:"page"`
+	Limit       *int    `form:"limit"`
+}
+
+func (q *ListTourQuery) Normalize() {
+	if q.Page == nil || *q.Page < 1 {
+		p := 1
+		q.Page = &p
+	}
+	if q.Limit == nil || *q.Limit < 1 {
+		l := 20
+		q.Limit = &l
+	}
+	if *q.Limit > 100 {
+		l := 100
+		q.Limit = &l
+	}
+}
+
+// PerformerDTO はツアー出演者の転送オブジェクト
+type PerformerDTO struct {
+	PerformerID   string `json:"performer_id"`
+	BillingStatus string `json:"billing_status"`
+}
+
+// TourDTO はツアーの転送オブジェクト
+type TourDTO struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	EventType   string         `json:"event_type"`
+	Performers  []PerformerDTO `json:"performers"`
+	Tags        []string       `json:"tags"`
+	OfficialURL *string        `json:"official_url,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	CreatedAt   string         `json:"created_at"`
+	UpdatedAt   string         `json:"updated_at"`
+}
+
+// TourSearchResult はツアー検索結果
+type TourSearchResult struct {
+	Data []*TourDTO      `json:"data"`
+	Meta *PaginationMeta `json:"meta"`
+}
+
+// PaginationMeta はページネーションのメタ情報
+type PaginationMeta struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PerPage    int   `json:"per_page"`
+	TotalPages int   `json:"total_pages"`
+}
@@ -0,0 +1,124 @@
+package tour
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/kuro48/idol-api/internal/domain/tour"
+)
+
+// Usecase はツアーユースケースの実装
+type Usecase struct {
+	appService TourAppPort
+}
+
+func NewUsecase(appService TourAppPort) *Usecase {
+	return &Usecase{appService: appService}
+}
+
+func (u *Usecase) CreateTour(ctx context.Context, cmd CreateTourCommand) (*TourDTO, error) {
+	t, err := u.appService.CreateTour(ctx, TourCreateInput{
+		Title:       cmd.Title,
+		EventType:   cmd.EventType,
+		Performers:  toPerformerInputs(cmd.Performers),
+		Tags:        cmd.Tags,
+		OfficialURL: cmd.OfficialURL,
+		Description: cmd.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dto := toDTO(t)
+	return &dto, nil
+}
+
+func (u *Usecase) GetTour(ctx context.Context, query GetTourQuery) (*TourDTO, error) {
+	t, err := u.appService.GetTour(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	dto := toDTO(t)
+	return &dto, nil
+}
+
+func (u *Usecase) ListTours(ctx context.Context, query ListTourQuery) (*TourSearchResult, error) {
+	query.Normalize()
+
+	tours, total, err := u.appService.SearchTours(ctx, domain.SearchCriteria{
+		Title:       query.Title,
+		PerformerID: query.PerformerID,
+		Offset:      (*query.Page - 1) * *query.Limit,
+		Limit:       *query.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ツアー一覧の取得エラー: %w", err)
+	}
+
+	dtos := make([]*TourDTO, 0, len(tours))
+	for _, t := range tours {
+		dto := toDTO(t)
+		dtos = append(dtos, &dto)
+	}
+
+	totalPages := int(total) / *query.Limit
+	if int(total)%*query.Limit != 0 {
+		totalPages++
+	}
+
+	return &TourSearchResult{
+		Data: dtos,
+		Meta: &PaginationMeta{
+			Total:      total,
+			Page:       *query.Page,
+			PerPage:    *query.Limit,
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+func (u *Usecase) UpdateTour(ctx context.Context, cmd UpdateTourCommand) error {
+	return u.appService.UpdateTour(ctx, TourUpdateInput{
+		ID:          cmd.ID,
+		Title:       cmd.Title,
+		EventType:   cmd.EventType,
+		Performers:  toPerformerInputs(cmd.Performers),
+		Tags:        cmd.Tags,
+		OfficialURL: cmd.OfficialURL,
+		Description: cmd.Description,
+	})
+}
+
+func (u *Usecase) DeleteTour(ctx context.Context, cmd DeleteTourCommand) error {
+	return u.appService.DeleteTour(ctx, cmd.ID)
+}
+
+// toPerformerInputs は出演者の入力を変換する。nil（未指定）は nil のまま渡す
+func toPerformerInputs(performers []PerformerInput) []TourPerformerInput {
+	if performers == nil {
+		return nil
+	}
+	inputs := make([]TourPerformerInput, 0, len(performers))
+	for _, p := range performers {
+		inputs = append(inputs, TourPerformerInput{PerformerID: p.PerformerID, BillingStatus: p.BillingStatus})
+	}
+	return inputs
+}
+
+func toDTO(t *domain.Tour) TourDTO {
+	performers := make([]PerformerDTO, 0, len(t.Performers()))
+	for _, p := range t.Performers() {
+		performers = append(performers, PerformerDTO{PerformerID: p.PerformerID, BillingStatus: string(p.BillingStatus)})
+	}
+	return TourDTO{
+		ID:          t.ID().Value(),
+		Title:       t.Title(),
+		EventType:   t.EventType().Value(),
+		Performers:  performers,
+		Tags:        t.Tags(),
+		OfficialURL: t.OfficialURL(),
+		Description: t.Description(),
+		CreatedAt:   t.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:   t.UpdatedAt().Format(time.RFC3339),
+	}
+}
@@ -1,5 +1,10 @@
 package venue
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 // CreateVenueCommand は会場作成コマンド
 type CreateVenueCommand struct {
 	Name        string
@@ -9,6 +14,8 @@ type CreateVenueCommand struct {
 	Address     *string
 	Capacity    *int
 	OfficialURL *string
+	Latitude    *float64 // 緯度・経度は両方指定する
+	Longitude   *float64
 }
 
 // UpdateVenueCommand は会場更新コマンド
@@ -21,9 +28,58 @@ type UpdateVenueCommand struct {
 	Address     *string
 	Capacity    *int
 	OfficialURL *string
+	Latitude    *float64 // 緯度・経度は両方指定する（null で座標を削除）
+	Longitude   *float64
+
+	ClearLocation bool `json:"-"` // 緯度・経度に明示的な null が指定された
+}
+
+// UnmarshalJSON は緯度・経度に明示的な null が指定されたことを ClearLocation に記録する。
+// 省略（変更なし）と null（削除）を区別するためにキーの有無を確認する
+func (c *UpdateVenueCommand) UnmarshalJSON(data []byte) error {
+	type plain UpdateVenueCommand
+	if err := json.Unmarshal(data, (*plain)(c)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	isNull := func(key string) bool {
+		raw, ok := fields[key]
+		return ok && bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+	}
+	c.ClearLocation = (isNull("latitude") || isNull("longitude")) && c.Latitude == nil && c.Longitude == nil
+	return nil
 }
 
 // DeleteVenueCommand は会場削除コマンド
 type DeleteVenueCommand struct {
 	ID string
 }
+
+// VenueLocationInput は会場の座標を一括登録する1件分の入力
+type VenueLocationInput struct {
+	VenueID   string
+	Latitude  float64
+	Longitude float64
+}
+
+// UpdateVenueLocationsCommand は会場の座標一括登録コマンド
+type UpdateVenueLocationsCommand struct {
+	Items []VenueLocationInput
+}
+
+// CapacityConfigurationInput は収容構成1件分の入力（コマンド用）
+type CapacityConfigurationInput struct {
+	ID       *string `json:"id,omitempty"` // 既存の構成を更新する場合に指定（省略時は追加）
+	Name     string  `json:"name" binding:"required,max=100"`
+	Layout   string  `json:"layout" binding:"required,oneof=standing seated center_stage other"`
+	Capacity int     `json:"capacity" binding:"required,min=1"`
+}
+
+// UpdateVenueConfigurationsCommand は会場の収容構成を置き換えるコマンド
+type UpdateVenueConfigurationsCommand struct {
+	VenueID        string                       `json:"-"`
+	Configurations []CapacityConfigurationInput `json:"configurations" binding:"max=20,dive"`
+}
@@ -9,4 +9,6 @@ type VenueUseCase interface {
 	ListVenues(ctx context.Context, query ListVenueQuery) (*VenueSearchResult, error)
 	UpdateVenue(ctx context.Context, cmd UpdateVenueCommand) error
 	DeleteVenue(ctx context.Context, cmd DeleteVenueCommand) error
+	UpdateVenueConfigurations(ctx context.Context, cmd UpdateVenueConfigurationsCommand) (*VenueDTO, error)
+	UpdateVenueLocations(ctx context.Context, cmd UpdateVenueLocationsCommand) *VenueLocationsResult
 }
@@ -14,6 +14,22 @@ type VenueAppPort interface {
 	CountVenues(ctx context.Context, criteria domain.SearchCriteria) (int64, error)
 	UpdateVenue(ctx context.Context, input VenueUpdateInput) error
 	DeleteVenue(ctx context.Context, id string) error
+	UpdateLocations(ctx context.Context, items []VenueLocationInput) *VenueLocationsResult
+	UpdateConfigurations(ctx context.Context, input VenueConfigurationsInput) (*domain.Venue, error)
+}
+
+// VenueConfigurationInput は収容構成1件分の入力データ（usecase→application）
+type VenueConfigurationInput struct {
+	ID       *string
+	Name     string
+	Layout   string
+	Capacity int
+}
+
+// VenueConfigurationsInput は収容構成の置き換えの入力データ（usecase→application）
+type VenueConfigurationsInput struct {
+	VenueID        string
+	Configurations []VenueConfigurationInput
 }
 
 // VenueCreateInput は会場作成の入力データ（usecase→application）
@@ -25,16 +41,21 @@ type VenueCreateInput struct {
 	Address     *string
 	Capacity    *int
 	OfficialURL *string
+	Latitude    *float64
+	Longitude   *float64
 }
 
 // VenueUpdateInput は会場更新の入力データ（usecase→application）
 type VenueUpdateInput struct {
-	ID          string
-	Name        *string
-	NameEn      *string
-	Prefecture  *string
-	City        *string
-	Address     *string
-	Capacity    *int
-	OfficialURL *string
+	ID            string
+	Name          *string
+	NameEn        *string
+	Prefecture    *string
+	City          *string
+	Address       *string
+	Capacity      *int
+	OfficialURL   *string
+	Latitude      *float64
+	Longitude     *float64
+	ClearLocation bool // 座標を削除する（緯度・経度に null が指定された）
 }
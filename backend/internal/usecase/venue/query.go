@@ -1,6 +1,10 @@
 package venue
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/kuro48/idol-api/internal/shared/geo"
+)
 
 // GetVenueQuery は会場詳細取得クエリ
 type GetVenueQuery struct {
@@ -9,12 +13,17 @@ type GetVenueQuery struct {
 
 // ListVenueQuery は会場一覧取得クエリ
 type ListVenueQuery struct {
-	Name       *string `form:"name"`
-	Prefecture *string `form:"prefecture"`
-	Sort       *string `form:"sort"`
-	Order      *string `form:"order"`
-	Page       *int    `form:"page"`
-	Limit      *int    `form:"limit"`
+	Name       *string  `form:"name"`
+	Prefecture *string  `form:"prefecture"`
+	Near       *string  `form:"near"`      // "緯度,経度"。指定時は近い順に並べる
+	RadiusKm   *float64 `form:"radius_km"` // near からの半径（km、既定10、上限100）
+	Sort       *string  `form:"sort"`
+	Order      *string  `form:"order"`
+	Page       *int     `form:"page"`
+	Limit      *int     `form:"limit"`
+
+	// レスポンスに含めるフィールド（fields 指定。nil は全フィールド）
+	Fields []string `form:"-"`
 }
 
 func (q *ListVenueQuery) Normalize() {
@@ -52,9 +61,24 @@ func (q *ListVenueQuery) Validate() error {
 			return errors.New("無効なソート順です")
 		}
 	}
+	if q.Near == nil && q.RadiusKm != nil {
+		return errors.New("radius_km は near と組み合わせて指定してください（無効な検索条件）")
+	}
 	return nil
 }
 
+// searchArea は近傍検索の中心と半径を返す。near が未指定なら nil を返す
+func (q *ListVenueQuery) searchArea() (*geo.Point, float64, error) {
+	if q.Near == nil {
+		return nil, 0, nil
+	}
+	center, radius, err := geo.ParseSearchArea(*q.Near, q.RadiusKm)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &center, radius, nil
+}
+
 func containsStr(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -66,16 +90,28 @@ func containsStr(slice []string, item string) bool {
 
 // VenueDTO は会場の転送オブジェクト
 type VenueDTO struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	NameEn      *string `json:"name_en,omitempty"`
-	Prefecture  *string `json:"prefecture,omitempty"`
-	City        *string `json:"city,omitempty"`
-	Address     *string `json:"address,omitempty"`
-	Capacity    *int    `json:"capacity,omitempty"`
-	OfficialURL *string `json:"official_url,omitempty"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	ID             string                     `json:"id"`
+	Name           string                     `json:"name"`
+	NameEn         *string                    `json:"name_en,omitempty"`
+	Prefecture     *string                    `json:"prefecture,omitempty"`
+	City           *string                    `json:"city,omitempty"`
+	Address        *string                    `json:"address,omitempty"`
+	Capacity       *int                       `json:"capacity,omitempty"`
+	OfficialURL    *string                    `json:"official_url,omitempty"`
+	Latitude       *float64                   `json:"latitude,omitempty"`
+	Longitude      *float64                   `json:"longitude,omitempty"`
+	DistanceKm     *float64                   `json:"distance_km,omitempty"` // near 指定時のみ: 中心からの距離
+	Configurations []CapacityConfigurationDTO `json:"configurations"`        // レイアウトごとの収容構成
+	CreatedAt      string                     `json:"created_at"`
+	UpdatedAt      string                     `json:"updated_at"`
+}
+
+// CapacityConfigurationDTO は会場の収容構成
+type CapacityConfigurationDTO struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Layout   string `json:"layout"`
+	Capacity int    `json:"capacity"`
 }
 
 // VenueSearchResult は会場検索結果
@@ -84,6 +120,20 @@ type VenueSearchResult struct {
 	Meta *PaginationMeta `json:"meta"`
 }
 
+// VenueLocationError は座標の一括登録で失敗した1件
+type VenueLocationError struct {
+	Index   int    `json:"index"`
+	VenueID string `json:"venue_id"`
+	Error   string `json:"error"`
+}
+
+// VenueLocationsResult は座標の一括登録結果
+type VenueLocationsResult struct {
+	Processed int                  `json:"processed"`
+	Success   int                  `json:"success"`
+	Errors    []VenueLocationError `json:"errors"`
+}
+
 // PaginationMeta はページネーションのメタ情報
 type PaginationMeta struct {
 	Total      int64 `json:"total"`
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	domain "github.com/kuro48/idol-api/internal/domain/venue"
+	"github.com/kuro48/idol-api/internal/shared/fieldset"
+	"github.com/kuro48/idol-api/internal/shared/geo"
 )
 
 // Usecase は会場ユースケースの実装
@@ -25,6 +27,8 @@ func (u *Usecase) CreateVenue(ctx context.Context, cmd CreateVenueCommand) (*Ven
 		Address:     cmd.Address,
 		Capacity:    cmd.Capacity,
 		OfficialURL: cmd.OfficialURL,
+		Latitude:    cmd.Latitude,
+		Longitude:   cmd.Longitude,
 	})
 	if err != nil {
 		return nil, err
@@ -47,14 +51,21 @@ func (u *Usecase) ListVenues(ctx context.Context, query ListVenueQuery) (*VenueS
 	if err := query.Validate(); err != nil {
 		return nil, err
 	}
+	center, radius, err := query.searchArea()
+	if err != nil {
+		return nil, err
+	}
 
 	criteria := domain.SearchCriteria{
 		Name:       query.Name,
 		Prefecture: query.Prefecture,
+		Near:       center,
+		RadiusKm:   radius,
 		Sort:       *query.Sort,
 		Order:      *query.Order,
 		Offset:     (*query.Page - 1) * *query.Limit,
 		Limit:      *query.Limit,
+		Omit:       fieldset.Omitted(query.Fields, "configurations"),
 	}
 
 	vs, err := u.appService.SearchVenues(ctx, criteria)
@@ -70,6 +81,10 @@ func (u *Usecase) ListVenues(ctx context.Context, query ListVenueQuery) (*VenueS
 	dtos := make([]*VenueDTO, 0, len(vs))
 	for _, v := range vs {
 		dto := toDTO(v)
+		if center != nil && v.Location() != nil {
+			d := geo.RoundKm(center.DistanceKm(*v.Location()))
+			dto.DistanceKm = &d
+		}
 		dtos = append(dtos, &dto)
 	}
 
@@ -91,14 +106,17 @@ func (u *Usecase) ListVenues(ctx context.Context, query ListVenueQuery) (*VenueS
 
 func (u *Usecase) UpdateVenue(ctx context.Context, cmd UpdateVenueCommand) error {
 	return u.appService.UpdateVenue(ctx, VenueUpdateInput{
-		ID:          cmd.ID,
-		Name:        cmd.Name,
-		NameEn:      cmd.NameEn,
-		Prefecture:  cmd.Prefecture,
-		City:        cmd.City,
-		Address:     cmd.Address,
-		Capacity:    cmd.Capacity,
-		OfficialURL: cmd.OfficialURL,
+		ID:            cmd.ID,
+		Name:          cmd.Name,
+		NameEn:        cmd.NameEn,
+		Prefecture:    cmd.Prefecture,
+		City:          cmd.City,
+		Address:       cmd.Address,
+		Capacity:      cmd.Capacity,
+		OfficialURL:   cmd.OfficialURL,
+		Latitude:      cmd.Latitude,
+		Longitude:     cmd.Longitude,
+		ClearLocation: cmd.ClearLocation,
 	})
 }
 
@@ -106,8 +124,27 @@ func (u *Usecase) DeleteVenue(ctx context.Context, cmd DeleteVenueCommand) error
 	return u.appService.DeleteVenue(ctx, cmd.ID)
 }
 
+// UpdateVenueConfigurations は会場の収容構成を置き換える
+func (u *Usecase) UpdateVenueConfigurations(ctx context.Context, cmd UpdateVenueConfigurationsCommand) (*VenueDTO, error) {
+	configs := make([]VenueConfigurationInput, 0, len(cmd.Configurations))
+	for _, c := range cmd.Configurations {
+		configs = append(configs, VenueConfigurationInput{ID: c.ID, Name: c.Name, Layout: c.Layout, Capacity: c.Capacity})
+	}
+	v, err := u.appService.UpdateConfigurations(ctx, VenueConfigurationsInput{VenueID: cmd.VenueID, Configurations: configs})
+	if err != nil {
+		return nil, err
+	}
+	dto := toDTO(v)
+	return &dto, nil
+}
+
+// UpdateVenueLocations は複数の会場の座標をまとめて登録する
+func (u *Usecase) UpdateVenueLocations(ctx context.Context, cmd UpdateVenueLocationsCommand) *VenueLocationsResult {
+	return u.appService.UpdateLocations(ctx, cmd.Items)
+}
+
 func toDTO(v *domain.Venue) VenueDTO {
-	return VenueDTO{
+	dto := VenueDTO{
 		ID:          v.ID().Value(),
 		Name:        v.Name(),
 		NameEn:      v.NameEn(),
@@ -119,4 +156,18 @@ func toDTO(v *domain.Venue) VenueDTO {
 		CreatedAt:   v.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:   v.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
 	}
+	dto.Configurations = make([]CapacityConfigurationDTO, 0, len(v.Configurations()))
+	for _, c := range v.Configurations() {
+		dto.Configurations = append(dto.Configurations, CapacityConfigurationDTO{
+			ID:       c.ID(),
+			Name:     c.Name(),
+			Layout:   string(c.Layout()),
+			Capacity: c.Capacity(),
+		})
+	}
+	if loc := v.Location(); loc != nil {
+		lat, lng := loc.Latitude(), loc.Longitude()
+		dto.Latitude, dto.Longitude = &lat, &lng
+	}
+	return dto
 }